@@ -33,6 +33,9 @@ type bindCache struct {
 	cache       *kvcache.SimpleLRUCache
 	memCapacity int64
 	memTracker  *memory.Tracker // track memory usage.
+	// crossDBKeys records the cache keys which hold cross-database bindings, so the matching of
+	// cross-database bindings can be skipped when there is none of them.
+	crossDBKeys map[bindCacheKey]struct{}
 }
 
 type bindCacheKey string
@@ -57,6 +60,7 @@ func newBindCache() *bindCache {
 		cache:       cache,
 		memCapacity: variable.MemQuotaBindingCache.Load(),
 		memTracker:  memory.NewTracker(memory.LabelForBindCache, -1),
+		crossDBKeys: make(map[bindCacheKey]struct{}),
 	}
 	return &c
 }
@@ -111,9 +115,17 @@ func (c *bindCache) set(key bindCacheKey, value []*BindRecord) (ok bool, err err
 			return
 		}
 		c.memTracker.Consume(-calcBindCacheKVMem(evictedKey.(bindCacheKey), evictedValue.([]*BindRecord)))
+		delete(c.crossDBKeys, evictedKey.(bindCacheKey))
 	}
 	c.memTracker.Consume(mem)
 	c.cache.Put(key, value)
+	delete(c.crossDBKeys, key)
+	for _, bindRecord := range value {
+		if bindRecord.IsCrossDB() {
+			c.crossDBKeys[key] = struct{}{}
+			break
+		}
+	}
 	ok = true
 	return
 }
@@ -126,6 +138,7 @@ func (c *bindCache) delete(key bindCacheKey) bool {
 		mem := calcBindCacheKVMem(key, bindRecords)
 		c.cache.Delete(key)
 		c.memTracker.Consume(-mem)
+		delete(c.crossDBKeys, key)
 		return true
 	}
 	return false
@@ -146,6 +159,14 @@ func (c *bindCache) GetBindRecord(hash, normdOrigSQL, _ string) *BindRecord {
 	return nil
 }
 
+// HasCrossDBBindRecords checks whether there are cross-database bindings in the cache.
+// The function is thread-safe.
+func (c *bindCache) HasCrossDBBindRecords() bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.crossDBKeys) > 0
+}
+
 // GetBindRecordBySQLDigest gets the BindRecord from the cache.
 // The return value is not read-only, but it shouldn't be changed in the caller functions.
 // The function is thread-safe.
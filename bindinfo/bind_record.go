@@ -15,6 +15,7 @@
 package bindinfo
 
 import (
+	"strings"
 	"time"
 	"unsafe"
 
@@ -159,6 +160,11 @@ func (br *BindRecord) FindEnabledBinding() *Binding {
 	return nil
 }
 
+// IsCrossDB checks whether the BindRecord is a cross-database binding, whose tables use the wildcard schema.
+func (br *BindRecord) IsCrossDB() bool {
+	return strings.Contains(br.OriginalSQL, "`"+CrossDBSchema+"` .")
+}
+
 // FindBinding find bindings in BindRecord.
 func (br *BindRecord) FindBinding(hint string) *Binding {
 	for i := range br.Bindings {
@@ -182,7 +188,8 @@ func (br *BindRecord) prepareHints(sctx sessionctx.Context) error {
 		if err != nil {
 			return err
 		}
-		if sctx != nil {
+		// The tables of a cross-database binding can't be resolved, so we can't check it by explaining the BindSQL.
+		if sctx != nil && !IsCrossDBBinding(stmt) {
 			paramChecker := &paramMarkerChecker{}
 			stmt.Accept(paramChecker)
 			if !paramChecker.hasParamMarker {
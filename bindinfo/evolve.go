@@ -0,0 +1,243 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/sessionctx"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	timerrt "github.com/pingcap/tidb/timer/runtime"
+	"github.com/pingcap/tidb/timer/tablestore"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	evolveTimerKey       = "/tidb/bindinfo/evolve"
+	evolveTimerHookClass = "tidb.bindinfo.evolve"
+	// evolveTimerInterval is the interval of the timer events to verify the pending verified bindings.
+	evolveTimerInterval       = "1m"
+	evolveManagerLoopInterval = 5 * time.Second
+	// evolveTasksPerEvent is the max number of bindings verified in a timer event, so the latest
+	// parameters of the evolution are used for the remaining ones.
+	evolveTasksPerEvent = 16
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// EvolveManager verifies the pending verified bindings in the background. The verifications are scheduled
+// by a timer on the owner of the bindings, and the timer only exists when there are bindings to verify.
+type EvolveManager struct {
+	handle  *BindHandle
+	sctx    sessionctx.Context
+	store   *timerapi.TimerStore
+	cli     timerapi.TimerClient
+	isOwner func() bool
+	rt      *timerrt.TimerGroupRuntime
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// NewEvolveManager creates a new EvolveManager, the bindings are verified with `sctx`.
+func NewEvolveManager(handle *BindHandle, sctx sessionctx.Context, pool sessionPool, etcd *clientv3.Client, isOwner func() bool) *EvolveManager {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &EvolveManager{
+		handle:  handle,
+		sctx:    sctx,
+		store:   store,
+		cli:     timerapi.NewDefaultTimerClient(store),
+		isOwner: isOwner,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start starts the loop of the manager.
+func (m *EvolveManager) Start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(evolveManagerLoopInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.ctx.Done():
+				m.pause()
+				m.store.Close()
+				return
+			case <-ticker.C:
+				m.onTick()
+			}
+		}
+	}()
+}
+
+// Stop stops the manager and waits for it to exit.
+func (m *EvolveManager) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+func (m *EvolveManager) onTick() {
+	if !m.isOwner() {
+		m.pause()
+		return
+	}
+	m.resume()
+	if err := m.syncTimer(m.ctx); err != nil {
+		logutil.BgLogger().Warn("failed to sync the binding evolution timer", zap.String("category", "sql-bind"), zap.Error(err))
+	}
+}
+
+func (m *EvolveManager) resume() {
+	if m.rt != nil {
+		return
+	}
+	m.rt = timerrt.NewTimerRuntimeBuilder("bindinfo", m.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(evolveTimerKey)}).
+		RegisterHookFactory(evolveTimerHookClass, func(_ string, cli timerapi.TimerClient) timerapi.Hook {
+			return newEvolveHook(m.handle, m.sctx, cli)
+		}).
+		Build()
+	m.rt.Start()
+}
+
+func (m *EvolveManager) pause() {
+	if rt := m.rt; rt != nil {
+		m.rt = nil
+		rt.Stop()
+	}
+}
+
+// syncTimer creates the timer when there are bindings to verify, and deletes the idle timer otherwise.
+func (m *EvolveManager) syncTimer(ctx context.Context) error {
+	originalSQL, _, _ := m.handle.getOnePendingVerifyJob()
+	timer, err := m.cli.GetTimerByKey(ctx, evolveTimerKey)
+	if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+		timer, err = nil, nil
+	}
+	if err != nil {
+		return err
+	}
+	switch {
+	case originalSQL != "" && timer == nil:
+		_, err = m.cli.CreateTimer(ctx, timerapi.TimerSpec{
+			Key:             evolveTimerKey,
+			SchedPolicyType: timerapi.SchedEventInterval,
+			SchedPolicyExpr: evolveTimerInterval,
+			HookClass:       evolveTimerHookClass,
+			Watermark:       time.Now(),
+			Enable:          true,
+		})
+	case originalSQL == "" && timer != nil && timer.EventStatus == timerapi.SchedEventIdle:
+		_, err = m.cli.DeleteTimer(ctx, timer.ID)
+	}
+	return err
+}
+
+type evolveHook struct {
+	handle *BindHandle
+	sctx   sessionctx.Context
+	cli    timerapi.TimerClient
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newEvolveHook(handle *BindHandle, sctx sessionctx.Context, cli timerapi.TimerClient) *evolveHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &evolveHook{
+		handle: handle,
+		sctx:   sctx,
+		cli:    cli,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (*evolveHook) Start() {}
+
+func (h *evolveHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*evolveHook) OnPreSchedEvent(context.Context, timerapi.TimerShedEvent) (timerapi.PreSchedEventResult, error) {
+	return timerapi.PreSchedEventResult{}, nil
+}
+
+// OnSchedEvent verifies the pending verified bindings in the background, the event is closed when
+// the verifications are done.
+func (h *evolveHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		for i := 0; i < evolveTasksPerEvent && h.ctx.Err() == nil; i++ {
+			evolved, err := h.handle.evolvePlanTask(h.sctx, false)
+			if err != nil {
+				logutil.BgLogger().Info("evolve plan failed", zap.String("category", "sql-bind"), zap.Error(err))
+			}
+			if !evolved {
+				break
+			}
+		}
+		err := h.cli.CloseTimerEvent(h.ctx, timer.ID, event.EventID(), timerapi.WithSetWatermark(timer.EventStart))
+		if err != nil {
+			logutil.BgLogger().Warn("failed to close the binding evolution timer event", zap.String("category", "sql-bind"),
+				zap.String("eventID", event.EventID()), zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+// recordEvolution records the result of verifying a binding in mysql.bind_info_evolution.
+// The plan times are NULL if they are unknown.
+func recordEvolution(sctx sessionctx.Context, originalSQL, db string, binding *Binding, reason string,
+	acceptedPlanTime, verifiedPlanTime time.Duration) {
+	planTime := func(d time.Duration) interface{} {
+		if d <= 0 {
+			return nil
+		}
+		return d.Seconds()
+	}
+	var reasonVal interface{}
+	if reason != "" {
+		reasonVal = reason
+	}
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	_, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(ctx, nil,
+		`INSERT INTO mysql.bind_info_evolution (original_sql, default_db, bind_sql, sql_digest, status, reason,
+		accepted_plan_time, verified_plan_time) VALUES (%?, %?, %?, %?, %?, %?, %?, %?)`,
+		originalSQL, db, binding.BindSQL, binding.SQLDigest, binding.Status, reasonVal,
+		planTime(acceptedPlanTime), planTime(verifiedPlanTime))
+	if err != nil {
+		logutil.BgLogger().Warn("failed to record the binding evolution", zap.String("category", "sql-bind"), zap.Error(err))
+	}
+}
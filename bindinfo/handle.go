@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx"
@@ -86,6 +87,9 @@ type BindHandle struct {
 
 	// pendingVerifyBindRecordMap indicates the pending verify bind records that found during query.
 	pendingVerifyBindRecordMap tmpBindRecordMap
+
+	// crossDBHits records the hit counts of the cross-database bindings on this instance.
+	crossDBHits crossDBBindingHits
 }
 
 // Lease influences the duration of loading bind info and handling invalid bind.
@@ -414,6 +418,9 @@ func (h *BindHandle) DropBindRecord(originalSQL, db string, binding *Binding) (d
 			record.Bindings = append(record.Bindings, *binding)
 		}
 		h.removeBindRecord(parser.DigestNormalized(originalSQL).String(), record)
+		if binding == nil {
+			h.crossDBHits.remove(originalSQL)
+		}
 	}()
 
 	// Lock mysql.bind_info to synchronize with CreateBindRecord / AddBindRecord / DropBindRecord on other tidb instances.
@@ -1063,6 +1070,182 @@ func (*paramMarkerChecker) Leave(in ast.Node) (ast.Node, bool) {
 	return in, true
 }
 
+// CrossDBSchema is the wildcard schema used by the cross-database bindings, such as
+// `create global binding for select * from *.t using select * from *.t use index(idx)`.
+const CrossDBSchema = "*"
+
+type crossDBBindingChecker struct {
+	isCrossDB bool
+}
+
+func (c *crossDBBindingChecker) Enter(in ast.Node) (ast.Node, bool) {
+	if tn, ok := in.(*ast.TableName); ok && tn.Schema.L == CrossDBSchema {
+		c.isCrossDB = true
+	}
+	return in, c.isCrossDB
+}
+
+func (*crossDBBindingChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// IsCrossDBBinding checks whether the statement of a binding uses the wildcard schema for its tables.
+func IsCrossDBBinding(stmt ast.Node) bool {
+	checker := &crossDBBindingChecker{}
+	stmt.Accept(checker)
+	return checker.isCrossDB
+}
+
+// schemaEraser replaces the schema names of the tables with the wildcard schema and removes the
+// schema names of the columns. The original schema names are kept to recover the statement later.
+type schemaEraser struct {
+	tables        []*ast.TableName
+	tableSchemas  []model.CIStr
+	columns       []*ast.ColumnName
+	columnSchemas []model.CIStr
+	// schemas are the explicit schemas of the tables in the statement.
+	schemas map[string]struct{}
+	// unqualifiedTables are the names of the tables without explicit schemas, which may refer to CTEs.
+	unqualifiedTables []string
+	cteNames          map[string]struct{}
+}
+
+func (e *schemaEraser) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.CommonTableExpression:
+		e.cteNames[x.Name.L] = struct{}{}
+	case *ast.TableName:
+		if x.Schema.L == "" {
+			e.unqualifiedTables = append(e.unqualifiedTables, x.Name.L)
+		} else {
+			if x.Schema.L != CrossDBSchema {
+				e.schemas[x.Schema.L] = struct{}{}
+			}
+			e.tables = append(e.tables, x)
+			e.tableSchemas = append(e.tableSchemas, x.Schema)
+			x.Schema = model.NewCIStr(CrossDBSchema)
+		}
+	case *ast.ColumnName:
+		if x.Schema.L != "" {
+			e.columns = append(e.columns, x)
+			e.columnSchemas = append(e.columnSchemas, x.Schema)
+			x.Schema = model.CIStr{}
+		}
+	}
+	return in, false
+}
+
+func (*schemaEraser) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// schema returns the schema which all the tables of the statement are in, defaultDB is used for the tables
+// without explicit schemas. It returns an empty string if the tables are in more than one schema.
+func (e *schemaEraser) schema(defaultDB string) string {
+	for _, name := range e.unqualifiedTables {
+		if _, ok := e.cteNames[name]; !ok {
+			e.schemas[strings.ToLower(defaultDB)] = struct{}{}
+			break
+		}
+	}
+	if len(e.schemas) != 1 {
+		return ""
+	}
+	for schema := range e.schemas {
+		return schema
+	}
+	return ""
+}
+
+func (e *schemaEraser) recover() {
+	for i, tn := range e.tables {
+		tn.Schema = e.tableSchemas[i]
+	}
+	for i, cn := range e.columns {
+		cn.Schema = e.columnSchemas[i]
+	}
+}
+
+// NormalizeStmtForCrossDBBinding returns the normalized SQL and the digest of the statement after
+// replacing all the schemas of its tables with the wildcard schema. This second-level digest is
+// used to match the cross-database bindings. The schema which all the tables of the statement are
+// in is also returned, defaultDB is used for the tables without explicit schemas. The schema is
+// empty if the statement spans more than one schema.
+func NormalizeStmtForCrossDBBinding(stmtNode ast.StmtNode, defaultDB string) (normalizedSQL, hash, schema string) {
+	eraser := &schemaEraser{schemas: make(map[string]struct{}), cteNames: make(map[string]struct{})}
+	stmtNode.Accept(eraser)
+	defer eraser.recover()
+	normalizedSQL, digest := parser.NormalizeDigest(utilparser.RestoreWithDefaultDB(stmtNode, CrossDBSchema, ""))
+	return normalizedSQL, digest.String(), eraser.schema(defaultDB)
+}
+
+// matchCrossDBBindRecord finds the cross-database BindRecord in the cache for the statement,
+// and returns the schema the statement runs on. The tables in the hints of the binding are
+// resolved against that schema, so the statements which span more than one schema don't match.
+func matchCrossDBBindRecord(cache *bindCache, stmtNode ast.StmtNode, defaultDB string) (*BindRecord, string) {
+	if !cache.HasCrossDBBindRecords() {
+		return nil, ""
+	}
+	normalizedSQL, hash, schema := NormalizeStmtForCrossDBBinding(stmtNode, defaultDB)
+	if schema == "" {
+		return nil, ""
+	}
+	bindRecord := cache.GetBindRecord(hash, normalizedSQL, "")
+	if bindRecord == nil {
+		return nil, ""
+	}
+	return bindRecord, schema
+}
+
+// crossDBBindingHits counts how many times the cross-database bindings are hit on each schema.
+type crossDBBindingHits struct {
+	sync.Mutex
+	// hits maps the original sql of a binding to the hit counts of the schemas.
+	hits map[string]map[string]int64
+}
+
+func (c *crossDBBindingHits) record(originalSQL, schema string) {
+	c.Lock()
+	defer c.Unlock()
+	if c.hits == nil {
+		c.hits = make(map[string]map[string]int64)
+	}
+	schemaHits, ok := c.hits[originalSQL]
+	if !ok {
+		schemaHits = make(map[string]int64)
+		c.hits[originalSQL] = schemaHits
+	}
+	schemaHits[schema]++
+}
+
+func (c *crossDBBindingHits) get(originalSQL string) map[string]int64 {
+	c.Lock()
+	defer c.Unlock()
+	return maps.Clone(c.hits[originalSQL])
+}
+
+func (c *crossDBBindingHits) remove(originalSQL string) {
+	c.Lock()
+	defer c.Unlock()
+	delete(c.hits, originalSQL)
+}
+
+// MatchCrossDBBindRecord returns the cross-database BindRecord which matches the statement if it
+// exists, and the schema the statement runs on.
+func (h *BindHandle) MatchCrossDBBindRecord(stmtNode ast.StmtNode, defaultDB string) (*BindRecord, string) {
+	return matchCrossDBBindRecord(h.bindInfo.Load().(*bindCache), stmtNode, defaultDB)
+}
+
+// RecordCrossDBBindingHit increases the hit count of the cross-database binding on the schema.
+func (h *BindHandle) RecordCrossDBBindingHit(originalSQL, schema string) {
+	h.crossDBHits.record(originalSQL, schema)
+}
+
+// GetCrossDBBindingHits returns the hit counts of the cross-database binding on each schema.
+func (h *BindHandle) GetCrossDBBindingHits(originalSQL string) map[string]int64 {
+	return h.crossDBHits.get(originalSQL)
+}
+
 // AddEvolvePlanTask adds the evolve plan task into memory cache. It would be flushed to store periodically.
 func (h *BindHandle) AddEvolvePlanTask(originalSQL, db string, binding Binding) {
 	br := &BindRecord{
@@ -1078,42 +1261,49 @@ func (h *BindHandle) SaveEvolveTasksToStore() {
 	h.pendingVerifyBindRecordMap.flushToStore()
 }
 
-func getEvolveParameters(sctx sessionctx.Context) (time.Duration, time.Time, time.Time, error) {
+func getEvolveParameters(sctx sessionctx.Context) (time.Duration, time.Time, time.Time, int64, error) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
 	rows, _, err := sctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(
 		ctx,
 		nil,
-		"SELECT variable_name, variable_value FROM mysql.global_variables WHERE variable_name IN (%?, %?, %?)",
+		"SELECT variable_name, variable_value FROM mysql.global_variables WHERE variable_name IN (%?, %?, %?, %?)",
 		variable.TiDBEvolvePlanTaskMaxTime,
 		variable.TiDBEvolvePlanTaskStartTime,
 		variable.TiDBEvolvePlanTaskEndTime,
+		variable.TiDBEvolvePlanTaskMemQuota,
 	)
 	if err != nil {
-		return 0, time.Time{}, time.Time{}, err
+		return 0, time.Time{}, time.Time{}, 0, err
 	}
 	maxTime, startTimeStr, endTimeStr := int64(variable.DefTiDBEvolvePlanTaskMaxTime), variable.DefTiDBEvolvePlanTaskStartTime, variable.DefAutoAnalyzeEndTime
+	memQuota := int64(variable.DefTiDBEvolvePlanTaskMemQuota)
 	for _, row := range rows {
 		switch row.GetString(0) {
 		case variable.TiDBEvolvePlanTaskMaxTime:
 			maxTime, err = strconv.ParseInt(row.GetString(1), 10, 64)
 			if err != nil {
-				return 0, time.Time{}, time.Time{}, err
+				return 0, time.Time{}, time.Time{}, 0, err
 			}
 		case variable.TiDBEvolvePlanTaskStartTime:
 			startTimeStr = row.GetString(1)
 		case variable.TiDBEvolvePlanTaskEndTime:
 			endTimeStr = row.GetString(1)
+		case variable.TiDBEvolvePlanTaskMemQuota:
+			memQuota, err = strconv.ParseInt(row.GetString(1), 10, 64)
+			if err != nil {
+				return 0, time.Time{}, time.Time{}, 0, err
+			}
 		}
 	}
 	startTime, err := time.ParseInLocation(variable.FullDayTimeFormat, startTimeStr, time.UTC)
 	if err != nil {
-		return 0, time.Time{}, time.Time{}, err
+		return 0, time.Time{}, time.Time{}, 0, err
 	}
 	endTime, err := time.ParseInLocation(variable.FullDayTimeFormat, endTimeStr, time.UTC)
 	if err != nil {
-		return 0, time.Time{}, time.Time{}, err
+		return 0, time.Time{}, time.Time{}, 0, err
 	}
-	return time.Duration(maxTime) * time.Second, startTime, endTime, nil
+	return time.Duration(maxTime) * time.Second, startTime, endTime, memQuota, nil
 }
 
 const (
@@ -1212,50 +1402,75 @@ func runSQL(ctx context.Context, sctx sessionctx.Context, sql string, resultChan
 // HandleEvolvePlanTask tries to evolve one plan task.
 // It only processes one task at a time because we want each task to use the latest parameters.
 func (h *BindHandle) HandleEvolvePlanTask(sctx sessionctx.Context, adminEvolve bool) error {
+	_, err := h.evolvePlanTask(sctx, adminEvolve)
+	return err
+}
+
+// evolvePlanTask verifies one pending verified binding by running it with both the accepted plans and
+// its own plan, the binding is enabled if its plan runs faster enough, otherwise it is rejected and will
+// be verified again after `nextVerifyDuration`. The result is recorded in mysql.bind_info_evolution.
+// It returns whether a binding is verified.
+func (h *BindHandle) evolvePlanTask(sctx sessionctx.Context, adminEvolve bool) (bool, error) {
 	originalSQL, db, binding := h.getOnePendingVerifyJob()
 	if originalSQL == "" {
-		return nil
+		return false, nil
 	}
-	maxTime, startTime, endTime, err := getEvolveParameters(sctx)
+	maxTime, startTime, endTime, memQuota, err := getEvolveParameters(sctx)
 	if err != nil {
-		return err
+		return false, err
 	}
 	if maxTime == 0 || (!timeutil.WithinDayTimePeriod(startTime, endTime, time.Now()) && !adminEvolve) {
-		return nil
-	}
-	sctx.GetSessionVars().UsePlanBaselines = true
-	currentPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
+		return false, nil
+	}
+	sessVars := sctx.GetSessionVars()
+	defer func(usePlanBaselines bool, memQuotaQuery int64) {
+		sessVars.UsePlanBaselines = usePlanBaselines
+		sessVars.MemQuotaQuery = memQuotaQuery
+	}(sessVars.UsePlanBaselines, sessVars.MemQuotaQuery)
+	// The statements are run under the memory quota, so a bad plan could not use up the memory.
+	sessVars.MemQuotaQuery = memQuota
+	sessVars.UsePlanBaselines = true
+	acceptedPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
 	// If we just return the error to the caller, this job will be retried again and again and cause endless logs,
 	// since it is still in the bind record. Now we just drop it and if it is actually retryable,
 	// we will hope for that we can capture this evolve task again.
 	if err != nil {
+		binding.Status = deleted
+		recordEvolution(sctx, originalSQL, db, &binding, "failed to run the accepted plans: "+err.Error(), 0, 0)
 		_, err = h.DropBindRecord(originalSQL, db, &binding)
-		return err
+		return true, err
 	}
 	// If the accepted plan timeouts, it is hard to decide the timeout for verify plan.
-	// Currently we simply mark the verify plan as `using` if it could run successfully within maxTime.
-	if currentPlanTime > 0 {
-		maxTime = time.Duration(float64(currentPlanTime) * verifyTimeoutFactor)
-	}
-	sctx.GetSessionVars().UsePlanBaselines = false
-	verifyPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
-	if err != nil {
-		_, err = h.DropBindRecord(originalSQL, db, &binding)
-		return err
-	}
-	if verifyPlanTime == -1 || (float64(verifyPlanTime)*acceptFactor > float64(currentPlanTime)) {
+	// Currently we simply mark the verify plan as `enabled` if it could run successfully within maxTime.
+	if acceptedPlanTime > 0 {
+		maxTime = time.Duration(float64(acceptedPlanTime) * verifyTimeoutFactor)
+	}
+	sessVars.UsePlanBaselines = false
+	verifiedPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
+	var reason string
+	switch {
+	case err != nil:
+		reason = "failed to run the verified plan: " + err.Error()
+	case verifiedPlanTime == -1:
+		reason = fmt.Sprintf("the verified plan timed out after %v", maxTime)
+	case acceptedPlanTime != -1 && float64(verifiedPlanTime)*acceptFactor > float64(acceptedPlanTime):
+		reason = fmt.Sprintf("the verified plan is not %v times faster than the accepted plans", acceptFactor)
+	}
+	if reason != "" {
 		binding.Status = Rejected
 		digestText, _ := parser.NormalizeDigest(binding.BindSQL) // for log desensitization
 		logutil.BgLogger().Debug("new plan rejected", zap.String("category", "sql-bind"),
-			zap.Duration("currentPlanTime", currentPlanTime),
-			zap.Duration("verifyPlanTime", verifyPlanTime),
+			zap.Duration("acceptedPlanTime", acceptedPlanTime),
+			zap.Duration("verifiedPlanTime", verifiedPlanTime),
 			zap.String("digestText", digestText),
+			zap.String("reason", reason),
 		)
 	} else {
 		binding.Status = Enabled
 	}
+	recordEvolution(sctx, originalSQL, db, &binding, reason, acceptedPlanTime, verifiedPlanTime)
 	// We don't need to pass the `sctx` because the BindSQL has been validated already.
-	return h.AddBindRecord(nil, &BindRecord{OriginalSQL: originalSQL, Db: db, Bindings: []Binding{binding}})
+	return true, h.AddBindRecord(nil, &BindRecord{OriginalSQL: originalSQL, Db: db, Bindings: []Binding{binding}})
 }
 
 // Clear resets the bind handle. It is only used for test.
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/sessionstates"
@@ -34,6 +35,8 @@ import (
 // SessionHandle is used to handle all session sql bind operations.
 type SessionHandle struct {
 	ch *bindCache
+	// crossDBHits records the hit counts of the cross-database session bindings.
+	crossDBHits crossDBBindingHits
 }
 
 // NewSessionBindHandle creates a new SessionBindHandle.
@@ -94,6 +97,9 @@ func (h *SessionHandle) DropBindRecord(originalSQL, db string, binding *Binding)
 		return err
 	}
 	updateMetrics(metrics.ScopeSession, oldRecord, newRecord, false)
+	if binding == nil {
+		h.crossDBHits.remove(originalSQL)
+	}
 	return nil
 }
 
@@ -111,6 +117,22 @@ func (h *SessionHandle) GetBindRecord(hash, normdOrigSQL, db string) *BindRecord
 	return h.ch.GetBindRecord(hash, normdOrigSQL, db)
 }
 
+// MatchCrossDBBindRecord returns the cross-database BindRecord which matches the statement if it
+// exists, and the schema the statement runs on.
+func (h *SessionHandle) MatchCrossDBBindRecord(stmtNode ast.StmtNode, defaultDB string) (*BindRecord, string) {
+	return matchCrossDBBindRecord(h.ch, stmtNode, defaultDB)
+}
+
+// RecordCrossDBBindingHit increases the hit count of the cross-database binding on the schema.
+func (h *SessionHandle) RecordCrossDBBindingHit(originalSQL, schema string) {
+	h.crossDBHits.record(originalSQL, schema)
+}
+
+// GetCrossDBBindingHits returns the hit counts of the cross-database binding on each schema.
+func (h *SessionHandle) GetCrossDBBindingHits(originalSQL string) map[string]int64 {
+	return h.crossDBHits.get(originalSQL)
+}
+
 // GetBindRecordBySQLDigest return all BindMeta corresponding to sqlDigest.
 func (h *SessionHandle) GetBindRecordBySQLDigest(sqlDigest string) (*BindRecord, error) {
 	return h.ch.GetBindRecordBySQLDigest(sqlDigest)
@@ -664,6 +664,48 @@ func TestAddEvolveTasks(t *testing.T) {
 	require.Equal(t, "SELECT /*+ use_index(@`sel_1` `test`.`t` )*/ * FROM `test`.`t` WHERE `a` >= 4 AND `b` >= 1 AND `c` = 0", rows[0][1])
 	status := rows[0][3].(string)
 	require.True(t, status == bindinfo.Enabled || status == bindinfo.Rejected)
+	tk.MustQuery("select bind_sql, status from mysql.bind_info_evolution").Check(testkit.Rows(
+		"SELECT /*+ use_index(@`sel_1` `test`.`t` )*/ * FROM `test`.`t` WHERE `a` >= 4 AND `b` >= 1 AND `c` = 0 " + status))
+}
+
+func TestEvolveTasksUnderMemQuota(t *testing.T) {
+	originalVal := config.CheckTableBeforeDrop
+	config.CheckTableBeforeDrop = true
+	defer func() {
+		config.CheckTableBeforeDrop = originalVal
+	}()
+
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, c int, index idx_a(a), index idx_b(b), index idx_c(c))")
+	tk.MustExec("insert into t values (1,1,1), (2,2,2), (3,3,3), (4,4,4), (5,5,5)")
+	tk.MustExec("analyze table t")
+	tk.MustExec("create global binding for select * from t where a >= 1 and b >= 1 and c = 0 using select * from t use index(idx_a) where a >= 1 and b >= 1 and c = 0")
+	tk.MustExec("set @@tidb_evolve_plan_baselines=1")
+	tk.MustQuery("select * from t where a >= 4 and b >= 1 and c = 0")
+	tk.MustExec("admin flush bindings")
+	rows := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, "pending verify", rows[0][3])
+
+	// The verifications are cancelled since the statements exceed the memory quota.
+	tk.MustExec("set @@global.tidb_mem_oom_action = 'CANCEL'")
+	defer tk.MustExec("set @@global.tidb_mem_oom_action = default")
+	tk.MustExec("set @@global.tidb_evolve_plan_task_mem_quota = 1")
+	defer tk.MustExec("set @@global.tidb_evolve_plan_task_mem_quota = default")
+	tk.MustExec("admin evolve bindings")
+	rows = tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, bindinfo.Enabled, rows[0][3])
+	rows = tk.MustQuery("select status, reason, accepted_plan_time, verified_plan_time from mysql.bind_info_evolution").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "deleted", rows[0][0])
+	require.Contains(t, rows[0][1], "failed to run the accepted plans")
+	require.Equal(t, "<nil>", rows[0][2])
+	require.Equal(t, "<nil>", rows[0][3])
 }
 
 func TestRuntimeHintsInEvolveTasks(t *testing.T) {
@@ -1136,6 +1178,25 @@ func TestForbidEvolvePlanBaseLinesBeforeGA(t *testing.T) {
 	require.EqualError(t, err, "Cannot enable baseline evolution feature, it is not generally available now")
 }
 
+func TestEvolvePlanBaselinesWithExperimentalConfig(t *testing.T) {
+	originalVal := config.CheckTableBeforeDrop
+	config.CheckTableBeforeDrop = false
+	defer func() {
+		config.CheckTableBeforeDrop = originalVal
+	}()
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Experimental.EnablePlanBaselineEvolution = true
+	})
+
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("set @@TiDB_Evolve_pLan_baselines=oN")
+	tk.MustQuery("select @@tidb_evolve_plan_baselines").Check(testkit.Rows("1"))
+	tk.MustExec("admin evolve bindings")
+}
+
 func TestExplainTableStmts(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 
@@ -1299,7 +1360,7 @@ func TestBindSQLDigest(t *testing.T) {
 		sql := "create global binding for " + c.origin + " using " + c.hint
 		tk.MustExec(sql)
 		res := tk.MustQuery(`show global bindings`).Rows()
-		require.Equal(t, len(res[0]), 11)
+		require.Equal(t, len(res[0]), 12)
 
 		parser4binding := parser.New()
 		originNode, err := parser4binding.ParseOneStmt(c.origin, "utf8mb4", "utf8mb4_general_ci")
@@ -1366,7 +1427,7 @@ func TestDropBindBySQLDigest(t *testing.T) {
 		res := tk.MustQuery(`show global bindings`).Rows()
 
 		require.Equal(t, len(res), 1)
-		require.Equal(t, len(res[0]), 11)
+		require.Equal(t, len(res[0]), 12)
 		drop := fmt.Sprintf("drop global binding for sql digest '%s'", res[0][9])
 		tk.MustExec(drop)
 		require.NoError(t, h.GCBindRecord())
@@ -1382,7 +1443,7 @@ func TestDropBindBySQLDigest(t *testing.T) {
 		res := tk.MustQuery(`show bindings`).Rows()
 
 		require.Equal(t, len(res), 1)
-		require.Equal(t, len(res[0]), 11)
+		require.Equal(t, len(res[0]), 12)
 		drop := fmt.Sprintf("drop binding for sql digest '%s'", res[0][9])
 		tk.MustExec(drop)
 		require.NoError(t, h.GCBindRecord())
@@ -1393,3 +1454,72 @@ func TestDropBindBySQLDigest(t *testing.T) {
 	tk.MustGetErrMsg(fmt.Sprintf("drop binding for sql digest '%s'", "1"), "can't find any binding for '1'")
 	tk.MustGetErrMsg(fmt.Sprintf("drop binding for sql digest '%s'", ""), "sql digest is empty")
 }
+
+func TestCrossDBBinding(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+
+	for _, db := range []string{"db1", "db2"} {
+		tk.MustExec("create database " + db)
+		tk.MustExec("create table " + db + ".t(a int, b int, index idx_a(a), index idx_b(b))")
+	}
+	tk.MustExec("use test")
+	tk.MustExec("create global binding for select * from *.t where a > 1 and b > 1 using select * from *.t use index(idx_b) where a > 1 and b > 1")
+	rows := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "select * from `*` . `t` where `a` > ? and `b` > ?", rows[0][0])
+	require.Equal(t, "SELECT * FROM `*`.`t` USE INDEX (`idx_b`) WHERE `a` > 1 AND `b` > 1", rows[0][1])
+	require.Equal(t, "", rows[0][2])
+	require.Equal(t, "", rows[0][11])
+
+	// The binding is used for the tables with the same name in all the databases.
+	tk.MustExec("use db1")
+	tk.MustQuery("select * from t where a > 2 and b > 2")
+	require.Equal(t, "t:idx_b", tk.Session().GetSessionVars().StmtCtx.IndexNames[0])
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	for i := 0; i < 2; i++ {
+		tk.MustQuery("select * from db2.t where a > 3 and b > 3")
+		require.Equal(t, "t:idx_b", tk.Session().GetSessionVars().StmtCtx.IndexNames[0])
+		tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	}
+	rows = tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "db1:1, db2:2", rows[0][11])
+
+	// The binding for the specified database takes precedence over the cross-database binding.
+	tk.MustExec("create global binding for select * from t where a > 1 and b > 1 using select * from t use index(idx_a) where a > 1 and b > 1")
+	tk.MustQuery("select * from t where a > 2 and b > 2")
+	require.Equal(t, "t:idx_a", tk.Session().GetSessionVars().StmtCtx.IndexNames[0])
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from db2.t where a > 3 and b > 3")
+	require.Equal(t, "t:idx_b", tk.Session().GetSessionVars().StmtCtx.IndexNames[0])
+	tk.MustExec("drop global binding for select * from t where a > 1 and b > 1")
+
+	// The tables in the hints refer to the database the statement runs on.
+	tk.MustExec("create binding for select * from *.t t1, *.t t2 where t1.a = t2.a using select /*+ inl_join(t1) */ * from *.t t1, *.t t2 where t1.a = t2.a")
+	require.True(t, tk.HasPlan("select * from db2.t t1, db2.t t2 where t1.a = t2.a", "IndexJoin"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	rows = tk.MustQuery("show session bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "select * from ( `*` . `t` as `t1` ) join `*` . `t` as `t2` where `t1` . `a` = `t2` . `a`", rows[0][0])
+	require.Equal(t, "db2:1", rows[0][11])
+
+	// The statements which span more than one schema don't match the cross-database bindings,
+	// because the tables in the hints couldn't be resolved.
+	require.False(t, tk.HasPlan("select * from db1.t t1, db2.t t2 where t1.a = t2.a", "IndexJoin"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("0"))
+	require.False(t, tk.HasPlan("select * from t t1, db2.t t2 where t1.a = t2.a", "IndexJoin"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("0"))
+	tk.MustExec("drop binding for select * from *.t t1, *.t t2 where t1.a = t2.a")
+	tk.MustQuery("show session bindings").Check(testkit.Rows())
+
+	tk.MustExec("drop global binding for select * from *.t where a > 1 and b > 1")
+	tk.MustQuery("show global bindings").Check(testkit.Rows())
+	tk.MustQuery("select * from db2.t where a > 3 and b > 3")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("0"))
+
+	// The tables of the cross-database bindings are not resolved when creating them.
+	tk.MustExec("create global binding for select * from *.t_not_exist where a > 1 using select * from *.t_not_exist use index(idx_a) where a > 1")
+	tk.MustGetErrMsg("create global binding for select * from *.t where a > 1 using select * from db1.t use index(idx_a) where a > 1",
+		"hinted sql and origin sql don't match when hinted sql erase the hint info, after erase hint info, originSQL:select * from `*` . `t` where `a` > ?, hintedSQL:select * from `db1` . `t` where `a` > ?")
+}
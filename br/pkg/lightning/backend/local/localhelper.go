@@ -707,8 +707,8 @@ func EstimateCompactionThreshold(files []mydump.FileInfo, cp *checkpoints.TableC
 			if !ok {
 				size = chunk.FileMeta.FileSize
 			}
-			if chunk.FileMeta.Type == mydump.SourceTypeParquet {
-				// parquet file is compressed, thus estimates with a factor of 2
+			if chunk.FileMeta.Type.IsRowBased() {
+				// parquet and avro files are compressed, thus estimates with a factor of 2
 				size *= 2
 			}
 			totalRawFileSize += size
@@ -765,6 +765,14 @@ func (s *StringOrStringSlice) UnmarshalTOML(in interface{}) error {
 	return nil
 }
 
+// JSONLConfig is the config for JSON Lines files.
+type JSONLConfig struct {
+	// ColumnPaths maps the column name to the JSON path expression used to extract
+	// its value from each line, such as `$.user.id`. When it's empty, the columns
+	// are the top-level keys of the first line.
+	ColumnPaths map[string]string `toml:"column-paths" json:"column-paths"`
+}
+
 // CSVConfig is the config for CSV files.
 type CSVConfig struct {
 	// Separator, Delimiter and Terminator should all be in utf8mb4 encoding.
@@ -844,6 +852,7 @@ type MydumperRuntime struct {
 	SourceDir        string           `toml:"data-source-dir" json:"data-source-dir"`
 	CharacterSet     string           `toml:"character-set" json:"character-set"`
 	CSV              CSVConfig        `toml:"csv" json:"csv"`
+	JSONL            JSONLConfig      `toml:"jsonl" json:"jsonl"`
 	MaxRegionSize    ByteSize         `toml:"max-region-size" json:"max-region-size"`
 	Filter           []string         `toml:"filter" json:"filter"`
 	FileRouters      []*FileRouteRule `toml:"files" json:"files"`
@@ -109,6 +109,16 @@ func openParser(
 		if err != nil {
 			return nil, err
 		}
+	case mydump.SourceTypeJSONL:
+		parser, err = mydump.NewJSONLParser(ctx, &cfg.Mydumper.JSONL, reader, blockBufSize, ioWorkers, nil)
+		if err != nil {
+			return nil, err
+		}
+	case mydump.SourceTypeAvro:
+		parser, err = mydump.NewAvroParser(ctx, reader, nil)
+		if err != nil {
+			return nil, err
+		}
 	default:
 		return nil, errors.Errorf("file '%s' with unknown source type '%s'", chunk.Key.Path, chunk.FileMeta.Type.String())
 	}
@@ -344,7 +354,7 @@ func (cr *chunkProcessor) encodeLoop(
 			err = cr.parser.ReadRow()
 			columnNames := cr.parser.Columns()
 			newOffset, rowID = cr.parser.Pos()
-			if cr.chunk.FileMeta.Compression != mydump.CompressionNone || cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+			if cr.chunk.FileMeta.Compression != mydump.CompressionNone || cr.chunk.FileMeta.Type.IsRowBased() {
 				newScannedOffset, scannedOffsetErr = cr.parser.ScannedPos()
 				if scannedOffsetErr != nil {
 					logger.Warn("fail to get data engine ScannedPos, progress may not be accurate",
@@ -494,7 +504,7 @@ func (cr *chunkProcessor) encodeLoop(
 		if m, ok := metric.FromContext(ctx); ok {
 			m.RowEncodeSecondsHistogram.Observe(encodeDur.Seconds())
 			m.RowReadSecondsHistogram.Observe(readDur.Seconds())
-			if cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+			if cr.chunk.FileMeta.Type.IsRowBased() {
 				m.RowReadBytesHistogram.Observe(float64(newScannedOffset - scannedOffset))
 			} else {
 				m.RowReadBytesHistogram.Observe(float64(newOffset - offset))
@@ -711,7 +721,7 @@ func (cr *chunkProcessor) deliverLoop(
 			}
 			delta := highOffset - lowOffset
 			if delta >= 0 {
-				if cr.chunk.FileMeta.Type == mydump.SourceTypeParquet {
+				if cr.chunk.FileMeta.Type.IsRowBased() {
 					if currRealOffset > startRealOffset {
 						m.BytesCounter.WithLabelValues(metric.StateRestored).Add(float64(currRealOffset - startRealOffset))
 					}
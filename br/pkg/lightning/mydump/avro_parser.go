@@ -0,0 +1,958 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bufio"
+	"bytes"
+	"compress/bzip2"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"hash/crc32"
+	"io"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/types"
+)
+
+// avro primitive and complex type names.
+// See: https://avro.apache.org/docs/1.11.1/specification/
+const (
+	avroTypeNull    = "null"
+	avroTypeBoolean = "boolean"
+	avroTypeInt     = "int"
+	avroTypeLong    = "long"
+	avroTypeFloat   = "float"
+	avroTypeDouble  = "double"
+	avroTypeBytes   = "bytes"
+	avroTypeString  = "string"
+	avroTypeRecord  = "record"
+	avroTypeError   = "error"
+	avroTypeEnum    = "enum"
+	avroTypeArray   = "array"
+	avroTypeMap     = "map"
+	avroTypeFixed   = "fixed"
+	// avroTypeUnion is not a type name in the schema, union is declared as a JSON array.
+	avroTypeUnion = "union"
+
+	avroSyncSize = 16
+)
+
+var (
+	avroMagic = []byte{'O', 'b', 'j', 1}
+
+	errAvroUnexpectedEOF = errors.NewNoStackError("avro: unexpected end of data")
+)
+
+type avroSchema struct {
+	typ     string
+	logical string
+	scale   int
+	// full name of record, enum and fixed.
+	name     string
+	fields   []*avroField
+	symbols  []string
+	size     int
+	items    *avroSchema
+	branches []*avroSchema
+}
+
+type avroField struct {
+	name   string
+	schema *avroSchema
+}
+
+type avroSchemaParser struct {
+	named map[string]*avroSchema
+}
+
+func parseAvroSchema(data []byte) (*avroSchema, error) {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, errors.Annotate(err, "invalid avro schema")
+	}
+	p := &avroSchemaParser{named: make(map[string]*avroSchema)}
+	return p.parse(v, "")
+}
+
+func avroFullName(name, namespace string) string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return namespace + "." + name
+}
+
+func (p *avroSchemaParser) parse(v any, namespace string) (*avroSchema, error) {
+	switch x := v.(type) {
+	case string:
+		return p.parseName(x, namespace)
+	case []any:
+		s := &avroSchema{typ: avroTypeUnion}
+		for _, b := range x {
+			branch, err := p.parse(b, namespace)
+			if err != nil {
+				return nil, err
+			}
+			s.branches = append(s.branches, branch)
+		}
+		return s, nil
+	case map[string]any:
+		return p.parseObject(x, namespace)
+	default:
+		return nil, errors.Errorf("invalid avro schema: %v", v)
+	}
+}
+
+func (p *avroSchemaParser) parseName(name, namespace string) (*avroSchema, error) {
+	switch name {
+	case avroTypeNull, avroTypeBoolean, avroTypeInt, avroTypeLong, avroTypeFloat,
+		avroTypeDouble, avroTypeBytes, avroTypeString:
+		return &avroSchema{typ: name}, nil
+	}
+	if s, ok := p.named[avroFullName(name, namespace)]; ok {
+		return s, nil
+	}
+	if s, ok := p.named[name]; ok {
+		return s, nil
+	}
+	return nil, errors.Errorf("unknown avro type '%s'", name)
+}
+
+func (p *avroSchemaParser) parseObject(obj map[string]any, namespace string) (*avroSchema, error) {
+	typ, ok := obj["type"].(string)
+	if !ok {
+		return p.parse(obj["type"], namespace)
+	}
+	s := &avroSchema{typ: typ}
+	s.logical, _ = obj["logicalType"].(string)
+	if scale, ok := obj["scale"].(float64); ok {
+		s.scale = int(scale)
+	}
+
+	switch typ {
+	case avroTypeRecord, avroTypeError, avroTypeEnum, avroTypeFixed:
+		name, _ := obj["name"].(string)
+		if name == "" {
+			return nil, errors.Errorf("avro %s type should have a name", typ)
+		}
+		if ns, ok := obj["namespace"].(string); ok {
+			namespace = ns
+		}
+		if idx := strings.LastIndexByte(name, '.'); idx >= 0 {
+			namespace = name[:idx]
+		}
+		s.name = avroFullName(name, namespace)
+		// register before parsing the fields, so recursive types can refer to it.
+		p.named[s.name] = s
+	}
+
+	switch typ {
+	case avroTypeRecord, avroTypeError:
+		s.typ = avroTypeRecord
+		fields, _ := obj["fields"].([]any)
+		for _, f := range fields {
+			fieldObj, ok := f.(map[string]any)
+			if !ok {
+				return nil, errors.Errorf("invalid field of avro record '%s'", s.name)
+			}
+			name, _ := fieldObj["name"].(string)
+			fieldSchema, err := p.parse(fieldObj["type"], namespace)
+			if err != nil {
+				return nil, errors.Annotatef(err, "invalid type of field '%s'", name)
+			}
+			s.fields = append(s.fields, &avroField{name: name, schema: fieldSchema})
+		}
+	case avroTypeEnum:
+		symbols, _ := obj["symbols"].([]any)
+		for _, symbol := range symbols {
+			str, _ := symbol.(string)
+			s.symbols = append(s.symbols, str)
+		}
+	case avroTypeFixed:
+		size, _ := obj["size"].(float64)
+		s.size = int(size)
+	case avroTypeArray, avroTypeMap:
+		key := "items"
+		if typ == avroTypeMap {
+			key = "values"
+		}
+		items, err := p.parse(obj[key], namespace)
+		if err != nil {
+			return nil, err
+		}
+		s.items = items
+	default:
+		named, err := p.parseName(typ, namespace)
+		if err != nil {
+			return nil, err
+		}
+		if named.name != "" {
+			return named, nil
+		}
+	}
+	return s, nil
+}
+
+// avroDecoder decodes the avro binary encoding of values.
+type avroDecoder struct {
+	buf []byte
+	off int
+}
+
+func (d *avroDecoder) readLong() (int64, error) {
+	var (
+		v     uint64
+		shift uint
+	)
+	for {
+		if d.off >= len(d.buf) {
+			return 0, errAvroUnexpectedEOF
+		}
+		b := d.buf[d.off]
+		d.off++
+		v |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+		if shift > 63 {
+			return 0, errors.New("avro: varint overflows a 64-bit integer")
+		}
+	}
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func (d *avroDecoder) readFixed(n int) ([]byte, error) {
+	if n < 0 || n > len(d.buf)-d.off {
+		return nil, errAvroUnexpectedEOF
+	}
+	b := d.buf[d.off : d.off+n]
+	d.off += n
+	return b, nil
+}
+
+func (d *avroDecoder) readBytes() ([]byte, error) {
+	n, err := d.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if n > math.MaxInt32 {
+		return nil, errAvroUnexpectedEOF
+	}
+	return d.readFixed(int(n))
+}
+
+// readBlockCount reads the item count of the next block of array and map.
+func (d *avroDecoder) readBlockCount() (int64, error) {
+	n, err := d.readLong()
+	if err != nil {
+		return 0, err
+	}
+	if n < 0 {
+		// the block size in bytes follows a negative count
+		if _, err = d.readLong(); err != nil {
+			return 0, err
+		}
+		n = -n
+	}
+	return n, nil
+}
+
+// decode decodes a value of the schema. The result is one of nil, bool, int64,
+// float32, float64, string, []byte, []any and map[string]any. Values of logical
+// types are converted to string except the unknown ones.
+func (d *avroDecoder) decode(s *avroSchema) (any, error) {
+	switch s.typ {
+	case avroTypeNull:
+		return nil, nil
+	case avroTypeBoolean:
+		b, err := d.readFixed(1)
+		if err != nil {
+			return nil, err
+		}
+		return b[0] != 0, nil
+	case avroTypeInt, avroTypeLong:
+		v, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		return convertAvroLong(v, s), nil
+	case avroTypeFloat:
+		b, err := d.readFixed(4)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float32frombits(binary.LittleEndian.Uint32(b)), nil
+	case avroTypeDouble:
+		b, err := d.readFixed(8)
+		if err != nil {
+			return nil, err
+		}
+		return math.Float64frombits(binary.LittleEndian.Uint64(b)), nil
+	case avroTypeBytes, avroTypeFixed:
+		var (
+			b   []byte
+			err error
+		)
+		if s.typ == avroTypeFixed {
+			b, err = d.readFixed(s.size)
+		} else {
+			b, err = d.readBytes()
+		}
+		if err != nil {
+			return nil, err
+		}
+		// the buffer is reused by the next block
+		b = slices.Clone(b)
+		if s.logical == "decimal" && len(b) > 0 {
+			return binaryToDecimalStr(b, s.scale), nil
+		}
+		return b, nil
+	case avroTypeString:
+		b, err := d.readBytes()
+		if err != nil {
+			return nil, err
+		}
+		return string(b), nil
+	case avroTypeEnum:
+		idx, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if idx < 0 || idx >= int64(len(s.symbols)) {
+			return nil, errors.Errorf("avro: enum index %d out of range of '%s'", idx, s.name)
+		}
+		return s.symbols[idx], nil
+	case avroTypeArray:
+		items := make([]any, 0)
+		for {
+			n, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return items, nil
+			}
+			for i := int64(0); i < n; i++ {
+				item, err := d.decode(s.items)
+				if err != nil {
+					return nil, err
+				}
+				items = append(items, item)
+			}
+		}
+	case avroTypeMap:
+		m := make(map[string]any)
+		for {
+			n, err := d.readBlockCount()
+			if err != nil {
+				return nil, err
+			}
+			if n == 0 {
+				return m, nil
+			}
+			for i := int64(0); i < n; i++ {
+				key, err := d.readBytes()
+				if err != nil {
+					return nil, err
+				}
+				value, err := d.decode(s.items)
+				if err != nil {
+					return nil, err
+				}
+				m[string(key)] = value
+			}
+		}
+	case avroTypeUnion:
+		idx, err := d.readLong()
+		if err != nil {
+			return nil, err
+		}
+		if idx < 0 || idx >= int64(len(s.branches)) {
+			return nil, errors.Errorf("avro: union index %d out of range", idx)
+		}
+		return d.decode(s.branches[idx])
+	case avroTypeRecord:
+		m := make(map[string]any, len(s.fields))
+		for _, f := range s.fields {
+			v, err := d.decode(f.schema)
+			if err != nil {
+				return nil, err
+			}
+			m[f.name] = v
+		}
+		return m, nil
+	default:
+		return nil, errors.Errorf("avro: unknown type '%s'", s.typ)
+	}
+}
+
+// convertAvroLong converts the date and time logical types to string, and keeps
+// the other values as int64.
+func convertAvroLong(v int64, s *avroSchema) any {
+	switch s.logical {
+	case "date":
+		return time.Unix(v*secPerDay, 0).UTC().Format(time.DateOnly)
+	case "time-millis":
+		return time.UnixMilli(v).UTC().Format("15:04:05.999999")
+	case "time-micros":
+		return time.UnixMicro(v).UTC().Format("15:04:05.999999")
+	case "timestamp-millis":
+		return time.UnixMilli(v).UTC().Format(utcTimeLayout)
+	case "timestamp-micros":
+		return time.UnixMicro(v).UTC().Format(utcTimeLayout)
+	case "timestamp-nanos":
+		return time.Unix(0, v).UTC().Format(utcTimeLayout)
+	case "local-timestamp-millis":
+		return time.UnixMilli(v).UTC().Format(timeLayout)
+	case "local-timestamp-micros":
+		return time.UnixMicro(v).UTC().Format(timeLayout)
+	case "local-timestamp-nanos":
+		return time.Unix(0, v).UTC().Format(timeLayout)
+	default:
+		return v
+	}
+}
+
+// avroToJSONValue converts the decoded value to the value which can be marshaled
+// as JSON, bytes are kept as string instead of base64.
+func avroToJSONValue(v any) any {
+	switch x := v.(type) {
+	case []byte:
+		return string(x)
+	case []any:
+		for i := range x {
+			x[i] = avroToJSONValue(x[i])
+		}
+		return x
+	case map[string]any:
+		for k := range x {
+			x[k] = avroToJSONValue(x[k])
+		}
+		return x
+	default:
+		return v
+	}
+}
+
+func setDatumByAvro(d *types.Datum, v any) error {
+	switch x := v.(type) {
+	case nil:
+		d.SetNull()
+	case bool:
+		if x {
+			d.SetUint64(1)
+		} else {
+			d.SetUint64(0)
+		}
+	case int64:
+		d.SetInt64(x)
+	case float32:
+		d.SetFloat32(x)
+	case float64:
+		d.SetFloat64(x)
+	case string:
+		d.SetString(x, "utf8mb4_bin")
+	case []byte:
+		d.SetBytes(x)
+	default:
+		// records, arrays and maps are kept as JSON text
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(avroToJSONValue(v)); err != nil {
+			return errors.Trace(err)
+		}
+		d.SetString(strings.TrimSuffix(buf.String(), "\n"), "utf8mb4_bin")
+	}
+	return nil
+}
+
+// avroFileReader reads the objects in an avro object container file.
+// See: https://avro.apache.org/docs/1.11.1/specification/#object-container-files
+type avroFileReader struct {
+	src ReadSeekCloser
+	r   *bufio.Reader
+	// offset is the number of bytes consumed from src.
+	offset int64
+
+	schema      *avroSchema
+	codec       string
+	sync        []byte
+	zstdDecoder *zstd.Decoder
+
+	dataBuf []byte
+	block   avroDecoder
+	// remaining is the number of objects not read in the current block.
+	remaining int64
+}
+
+func newAvroFileReader(src ReadSeekCloser) (*avroFileReader, error) {
+	r := &avroFileReader{
+		src: src,
+		r:   bufio.NewReader(src),
+	}
+	magic, err := r.readFull(len(avroMagic))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if !bytes.Equal(magic, avroMagic) {
+		return nil, errors.New("not an avro object container file")
+	}
+
+	meta := make(map[string][]byte)
+	for {
+		n, err := r.readLong()
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		if n == 0 {
+			break
+		}
+		if n < 0 {
+			if _, err = r.readLong(); err != nil {
+				return nil, errors.Trace(err)
+			}
+			n = -n
+		}
+		for i := int64(0); i < n; i++ {
+			key, err := r.readBytes()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			value, err := r.readBytes()
+			if err != nil {
+				return nil, errors.Trace(err)
+			}
+			meta[string(key)] = value
+		}
+	}
+	if r.sync, err = r.readFull(avroSyncSize); err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	if r.schema, err = parseAvroSchema(meta["avro.schema"]); err != nil {
+		return nil, err
+	}
+	r.codec = string(meta["avro.codec"])
+	switch r.codec {
+	case "", "null", "deflate", "snappy", "bzip2":
+	case "zstandard":
+		if r.zstdDecoder, err = zstd.NewReader(nil); err != nil {
+			return nil, errors.Trace(err)
+		}
+	default:
+		return nil, errors.Errorf("unsupported avro codec '%s'", r.codec)
+	}
+	return r, nil
+}
+
+func (r *avroFileReader) readFull(n int) ([]byte, error) {
+	buf := make([]byte, n)
+	read, err := io.ReadFull(r.r, buf)
+	r.offset += int64(read)
+	if err == io.ErrUnexpectedEOF || err == io.EOF {
+		return nil, errAvroUnexpectedEOF
+	}
+	return buf, err
+}
+
+func (r *avroFileReader) readLong() (int64, error) {
+	var (
+		v     uint64
+		shift uint
+	)
+	for {
+		b, err := r.r.ReadByte()
+		if err != nil {
+			if err == io.EOF && shift > 0 {
+				return 0, errAvroUnexpectedEOF
+			}
+			return 0, err
+		}
+		r.offset++
+		v |= uint64(b&0x7f) << shift
+		if b&0x80 == 0 {
+			break
+		}
+		shift += 7
+		if shift > 63 {
+			return 0, errors.New("avro: varint overflows a 64-bit integer")
+		}
+	}
+	return int64(v>>1) ^ -int64(v&1), nil
+}
+
+func (r *avroFileReader) readBytes() ([]byte, error) {
+	n, err := r.readLong()
+	if err != nil {
+		return nil, err
+	}
+	if n < 0 || n > int64(LargestEntryLimit) {
+		return nil, errors.Errorf("avro: invalid length %d", n)
+	}
+	return r.readFull(int(n))
+}
+
+// readBlockHeader reads the object count and the size in bytes of the next
+// block, it returns io.EOF when there's no more block.
+func (r *avroFileReader) readBlockHeader() (count int64, size int64, err error) {
+	if count, err = r.readLong(); err != nil {
+		return 0, 0, err
+	}
+	if size, err = r.readLong(); err != nil {
+		if err == io.EOF {
+			err = errAvroUnexpectedEOF
+		}
+		return 0, 0, err
+	}
+	if count < 0 || size < 0 {
+		return 0, 0, errors.Errorf("avro: invalid block with count %d and size %d", count, size)
+	}
+	return count, size, nil
+}
+
+func (r *avroFileReader) checkSync() error {
+	sync, err := r.readFull(avroSyncSize)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(sync, r.sync) {
+		return errors.New("avro: sync marker mismatch")
+	}
+	return nil
+}
+
+// skipBlockData skips the data of the block without decoding.
+func (r *avroFileReader) skipBlockData(size int64) error {
+	if buffered := int64(r.r.Buffered()); size > buffered {
+		if _, err := r.r.Discard(int(buffered)); err != nil {
+			return errors.Trace(err)
+		}
+		if _, err := r.src.Seek(size-buffered, io.SeekCurrent); err != nil {
+			return errors.Trace(err)
+		}
+		r.r.Reset(r.src)
+	} else if _, err := r.r.Discard(int(size)); err != nil {
+		return errors.Trace(err)
+	}
+	r.offset += size
+	return r.checkSync()
+}
+
+func (r *avroFileReader) loadBlockData(count int64, size int64) error {
+	if size > int64(LargestEntryLimit) {
+		return errors.Errorf("avro: size of block %d exceeds the max value of txn-entry-size-limit", size)
+	}
+	if int64(cap(r.dataBuf)) < size {
+		r.dataBuf = make([]byte, size)
+	}
+	data := r.dataBuf[:size]
+	n, err := io.ReadFull(r.r, data)
+	r.offset += int64(n)
+	if err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return errAvroUnexpectedEOF
+		}
+		return errors.Trace(err)
+	}
+	if err = r.checkSync(); err != nil {
+		return err
+	}
+
+	switch r.codec {
+	case "deflate":
+		data, err = io.ReadAll(flate.NewReader(bytes.NewReader(data)))
+	case "bzip2":
+		data, err = io.ReadAll(bzip2.NewReader(bytes.NewReader(data)))
+	case "snappy":
+		// the data is followed by the 4-byte, big-endian CRC32 checksum of the uncompressed data
+		if len(data) < 4 {
+			return errAvroUnexpectedEOF
+		}
+		checksum := binary.BigEndian.Uint32(data[len(data)-4:])
+		if data, err = snappy.Decode(nil, data[:len(data)-4]); err == nil && crc32.ChecksumIEEE(data) != checksum {
+			err = errors.New("avro: snappy checksum mismatch")
+		}
+	case "zstandard":
+		data, err = r.zstdDecoder.DecodeAll(data, nil)
+	}
+	if err != nil {
+		return errors.Annotatef(err, "failed to decompress avro block with codec '%s'", r.codec)
+	}
+	r.block = avroDecoder{buf: data}
+	r.remaining = count
+	return nil
+}
+
+// nextObject makes sure the next object can be decoded from r.block, it returns
+// io.EOF when there's no more object.
+func (r *avroFileReader) nextObject() error {
+	for r.remaining == 0 {
+		count, size, err := r.readBlockHeader()
+		if err != nil {
+			return err
+		}
+		if err = r.loadBlockData(count, size); err != nil {
+			return err
+		}
+	}
+	r.remaining--
+	return nil
+}
+
+// skipObjects skips n objects, whole blocks are skipped without decoding.
+func (r *avroFileReader) skipObjects(n int64) error {
+	for n > 0 {
+		if r.remaining == 0 {
+			count, size, err := r.readBlockHeader()
+			if err != nil {
+				return err
+			}
+			if count <= n {
+				if err = r.skipBlockData(size); err != nil {
+					return err
+				}
+				n -= count
+				continue
+			}
+			if err = r.loadBlockData(count, size); err != nil {
+				return err
+			}
+		}
+		if _, err := r.block.decode(r.schema); err != nil {
+			return err
+		}
+		r.remaining--
+		n--
+	}
+	return nil
+}
+
+func (r *avroFileReader) close() error {
+	if r.zstdDecoder != nil {
+		r.zstdDecoder.Close()
+	}
+	return r.src.Close()
+}
+
+// ReadAvroFileRowCountByFile reads the avro file row count through fileMeta.
+// Only the block headers are read.
+func ReadAvroFileRowCountByFile(
+	ctx context.Context,
+	store storage.ExternalStorage,
+	fileMeta SourceFileMeta,
+) (int64, error) {
+	src, err := store.Open(ctx, fileMeta.Path)
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	r, err := newAvroFileReader(src)
+	if err != nil {
+		_ = src.Close()
+		return 0, errors.Trace(err)
+	}
+	//nolint: errcheck
+	defer r.close()
+
+	var numRows int64
+	for {
+		count, size, err := r.readBlockHeader()
+		if err != nil {
+			if err == io.EOF {
+				return numRows, nil
+			}
+			return 0, errors.Trace(err)
+		}
+		if err = r.skipBlockData(size); err != nil {
+			return 0, errors.Trace(err)
+		}
+		numRows += count
+	}
+}
+
+// AvroParser parses an avro object container file for import. Each field of
+// the top-level record is a column, nested values are converted to JSON text.
+// It implements the Parser interface.
+type AvroParser struct {
+	reader  *avroFileReader
+	columns []string
+	// fieldIdx is the index of the record field for each column.
+	fieldIdx    []int
+	fieldValues []any
+	// readRows is the number of rows read, it's used as the position like parquet.
+	readRows int64
+	lastRow  Row
+	logger   log.Logger
+}
+
+// NewAvroParser creates an avro parser. When columns is empty, all fields of the
+// top-level record are read in the order of the schema, otherwise the fields
+// with the same name ignoring case are read in the order of columns.
+func NewAvroParser(
+	ctx context.Context,
+	r ReadSeekCloser,
+	columns []string,
+) (*AvroParser, error) {
+	reader, err := newAvroFileReader(r)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if reader.schema.typ != avroTypeRecord {
+		_ = reader.close()
+		return nil, errors.Errorf("the schema of avro file should be a record, got '%s'", reader.schema.typ)
+	}
+
+	fields := reader.schema.fields
+	fieldNames := make(map[string]int, len(fields))
+	allColumns := make([]string, 0, len(fields))
+	for i, f := range fields {
+		name := strings.ToLower(f.name)
+		fieldNames[name] = i
+		allColumns = append(allColumns, name)
+	}
+	parser := &AvroParser{
+		reader:      reader,
+		fieldValues: make([]any, len(fields)),
+		logger:      log.FromContext(ctx),
+	}
+	if len(columns) == 0 {
+		parser.columns = allColumns
+		parser.fieldIdx = make([]int, len(fields))
+		for i := range fields {
+			parser.fieldIdx[i] = i
+		}
+		return parser, nil
+	}
+	for _, col := range columns {
+		name := strings.ToLower(col)
+		idx, ok := fieldNames[name]
+		if !ok {
+			_ = reader.close()
+			return nil, errors.Errorf("column '%s' is not found in the avro schema", col)
+		}
+		parser.columns = append(parser.columns, name)
+		parser.fieldIdx = append(parser.fieldIdx, idx)
+	}
+	return parser, nil
+}
+
+// Pos returns the currently row number of the avro file.
+func (ap *AvroParser) Pos() (pos int64, rowID int64) {
+	return ap.readRows, ap.lastRow.RowID
+}
+
+// SetPos sets the position in an avro file.
+// It implements the Parser interface.
+func (ap *AvroParser) SetPos(pos int64, rowID int64) error {
+	if pos < ap.readRows {
+		return errors.Errorf("avro parser doesn't support seek back, current: %d, required: %d", ap.readRows, pos)
+	}
+	if err := ap.reader.skipObjects(pos - ap.readRows); err != nil {
+		return errors.Trace(err)
+	}
+	ap.readRows = pos
+	ap.lastRow.RowID = rowID
+	return nil
+}
+
+// ScannedPos implements the Parser interface.
+// For avro it's the number of bytes consumed from the file.
+func (ap *AvroParser) ScannedPos() (int64, error) {
+	return ap.reader.offset, nil
+}
+
+// Close closes the avro file of the parser.
+// It implements the Parser interface.
+func (ap *AvroParser) Close() error {
+	return ap.reader.close()
+}
+
+// ReadRow reads a row in the avro file by the parser.
+// It implements the Parser interface.
+func (ap *AvroParser) ReadRow() error {
+	ap.lastRow.RowID++
+	ap.lastRow.Length = 0
+	if err := ap.reader.nextObject(); err != nil {
+		return errors.Trace(err)
+	}
+
+	block := &ap.reader.block
+	start := block.off
+	for i, f := range ap.reader.schema.fields {
+		v, err := block.decode(f.schema)
+		if err != nil {
+			return errors.Annotatef(err, "failed to decode field '%s' of row %d", f.name, ap.readRows+1)
+		}
+		ap.fieldValues[i] = v
+	}
+	ap.readRows++
+	ap.lastRow.Length = block.off - start
+
+	if cap(ap.lastRow.Row) < len(ap.fieldIdx) {
+		ap.lastRow.Row = make([]types.Datum, len(ap.fieldIdx))
+	} else {
+		ap.lastRow.Row = ap.lastRow.Row[:len(ap.fieldIdx)]
+	}
+	for i, idx := range ap.fieldIdx {
+		if err := setDatumByAvro(&ap.lastRow.Row[i], ap.fieldValues[idx]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LastRow gets the last row parsed by the parser.
+// It implements the Parser interface.
+func (ap *AvroParser) LastRow() Row {
+	return ap.lastRow
+}
+
+// RecycleRow implements the Parser interface.
+func (*AvroParser) RecycleRow(_ Row) {
+}
+
+// Columns returns the _lower-case_ column names corresponding to values in
+// the LastRow.
+func (ap *AvroParser) Columns() []string {
+	return ap.columns
+}
+
+// SetColumns set restored column names to parser
+func (*AvroParser) SetColumns(_ []string) {
+	// just do nothing
+}
+
+// SetLogger sets the logger used in the parser.
+// It implements the Parser interface.
+func (ap *AvroParser) SetLogger(l log.Logger) {
+	ap.logger = l
+}
+
+// SetRowID sets the rowID in an avro file.
+// It implements the Parser interface.
+func (ap *AvroParser) SetRowID(rowID int64) {
+	ap.lastRow.RowID = rowID
+}
@@ -0,0 +1,462 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump_test
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/snappy"
+	"github.com/klauspost/compress/zstd"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+const testAvroSchema = `{
+  "type": "record", "name": "Event", "namespace": "com.example",
+  "fields": [
+    {"name": "ID", "type": "long"},
+    {"name": "name", "type": ["null", "string"]},
+    {"name": "score", "type": "double"},
+    {"name": "ok", "type": "boolean"},
+    {"name": "amount", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+    {"name": "day", "type": {"type": "int", "logicalType": "date"}},
+    {"name": "ts", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "kind", "type": {"type": "enum", "name": "Kind", "symbols": ["A", "B"]}},
+    {"name": "tags", "type": {"type": "array", "items": "string"}},
+    {"name": "attrs", "type": {"type": "map", "values": "int"}},
+    {"name": "inner", "type": {"type": "record", "name": "Inner", "fields": [
+      {"name": "x", "type": "float"},
+      {"name": "raw", "type": {"type": "fixed", "name": "F2", "size": 2}}
+    ]}},
+    {"name": "next", "type": ["null", "com.example.Kind"]}
+  ]
+}`
+
+func appendAvroLong(b []byte, v int64) []byte {
+	u := uint64((v << 1) ^ (v >> 63))
+	for u >= 0x80 {
+		b = append(b, byte(u)|0x80)
+		u >>= 7
+	}
+	return append(b, byte(u))
+}
+
+func appendAvroBytes(b []byte, data []byte) []byte {
+	b = appendAvroLong(b, int64(len(data)))
+	return append(b, data...)
+}
+
+func encodeTestAvroRow(i int) []byte {
+	var b []byte
+	b = appendAvroLong(b, int64(i))
+	if i%2 == 0 {
+		b = appendAvroLong(b, 1)
+		b = appendAvroBytes(b, []byte(fmt.Sprintf("n%d", i)))
+	} else {
+		b = appendAvroLong(b, 0)
+	}
+	b = binary.LittleEndian.AppendUint64(b, math.Float64bits(float64(i)+0.5))
+	b = append(b, byte(i%2))
+	if i == 2 {
+		b = appendAvroBytes(b, []byte{0xff})
+	} else {
+		b = appendAvroBytes(b, binary.BigEndian.AppendUint16(nil, uint16(12345+i)))
+	}
+	b = appendAvroLong(b, int64(19000+i))
+	b = appendAvroLong(b, 1672531200000+int64(i)*1000)
+	b = appendAvroLong(b, int64(i%2))
+	if i%2 == 0 {
+		b = appendAvroLong(b, 2)
+	} else {
+		// negative count is followed by the block size
+		b = appendAvroLong(b, -2)
+		b = appendAvroLong(b, 4)
+	}
+	b = appendAvroBytes(b, []byte("a"))
+	b = appendAvroBytes(b, []byte("b"))
+	b = appendAvroLong(b, 0)
+	b = appendAvroLong(b, 1)
+	b = appendAvroBytes(b, []byte("k"))
+	b = appendAvroLong(b, int64(i))
+	b = appendAvroLong(b, 0)
+	b = binary.LittleEndian.AppendUint32(b, math.Float32bits(1.5))
+	b = append(b, 'x', 'y')
+	if i%2 == 0 {
+		b = appendAvroLong(b, 0)
+	} else {
+		b = appendAvroLong(b, 1)
+		b = appendAvroLong(b, 1)
+	}
+	return b
+}
+
+func expectedTestAvroRow(i int) []types.Datum {
+	name := types.NewDatum(nil)
+	next := types.NewDatum(nil)
+	if i%2 == 0 {
+		name = types.NewCollationStringDatum(fmt.Sprintf("n%d", i), "utf8mb4_bin")
+	} else {
+		next = types.NewCollationStringDatum("B", "utf8mb4_bin")
+	}
+	amount := fmt.Sprintf("123.%d", 45+i)
+	if i == 2 {
+		amount = "-0.01"
+	}
+	return []types.Datum{
+		types.NewIntDatum(int64(i)),
+		name,
+		types.NewFloat64Datum(float64(i) + 0.5),
+		types.NewUintDatum(uint64(i % 2)),
+		types.NewCollationStringDatum(amount, "utf8mb4_bin"),
+		types.NewCollationStringDatum(time.Date(2022, 1, 8, 0, 0, 0, 0, time.UTC).AddDate(0, 0, i).Format(time.DateOnly), "utf8mb4_bin"),
+		types.NewCollationStringDatum(fmt.Sprintf("2023-01-01 00:00:0%dZ", i), "utf8mb4_bin"),
+		types.NewCollationStringDatum([]string{"A", "B"}[i%2], "utf8mb4_bin"),
+		types.NewCollationStringDatum(`["a","b"]`, "utf8mb4_bin"),
+		types.NewCollationStringDatum(fmt.Sprintf(`{"k":%d}`, i), "utf8mb4_bin"),
+		types.NewCollationStringDatum(`{"raw":"xy","x":1.5}`, "utf8mb4_bin"),
+		next,
+	}
+}
+
+// requireAvroRowEqual compares the rows ignoring the stale content of the datums,
+// since the datums of the last row are reused and the kind of a union may change.
+func requireAvroRowEqual(t *testing.T, expected []types.Datum, actual []types.Datum, msgAndArgs ...any) {
+	normalized := make([]types.Datum, len(actual))
+	for i, d := range actual {
+		switch d.Kind() {
+		case types.KindNull:
+		case types.KindInt64:
+			normalized[i] = types.NewIntDatum(d.GetInt64())
+		case types.KindUint64:
+			normalized[i] = types.NewUintDatum(d.GetUint64())
+		case types.KindString:
+			normalized[i] = types.NewCollationStringDatum(d.GetString(), d.Collation())
+		default:
+			normalized[i] = d
+		}
+	}
+	require.Equal(t, expected, normalized, msgAndArgs...)
+}
+
+// compressAvroBlock compresses the data of a block with the codec.
+func compressAvroBlock(t *testing.T, codec string, data []byte) []byte {
+	switch codec {
+	case "deflate":
+		var buf bytes.Buffer
+		w, err := flate.NewWriter(&buf, flate.DefaultCompression)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		return buf.Bytes()
+	case "snappy":
+		checksum := crc32.ChecksumIEEE(data)
+		return binary.BigEndian.AppendUint32(snappy.Encode(nil, data), checksum)
+	case "zstandard":
+		w, err := zstd.NewWriter(nil)
+		require.NoError(t, err)
+		data = w.EncodeAll(data, nil)
+		require.NoError(t, w.Close())
+		return data
+	}
+	return data
+}
+
+// writeAvroFile writes an avro object container file, the i-th block contains
+// counts[i] objects and the compressed data blocks[i].
+func writeAvroFile(schema, codec string, counts []int, blocks [][]byte) []byte {
+	sync := []byte("0123456789abcdef")
+	var b []byte
+	b = append(b, 'O', 'b', 'j', 1)
+	b = appendAvroLong(b, 2)
+	b = appendAvroBytes(b, []byte("avro.schema"))
+	b = appendAvroBytes(b, []byte(schema))
+	b = appendAvroBytes(b, []byte("avro.codec"))
+	b = appendAvroBytes(b, []byte(codec))
+	b = appendAvroLong(b, 0)
+	b = append(b, sync...)
+	for i, data := range blocks {
+		b = appendAvroLong(b, int64(counts[i]))
+		b = appendAvroBytes(b, data)
+		b = append(b, sync...)
+	}
+	return b
+}
+
+// writeTestAvroFile writes rows [0, sum(blockRows)) into an avro object container file.
+func writeTestAvroFile(t *testing.T, codec string, blockRows []int) []byte {
+	blocks := make([][]byte, 0, len(blockRows))
+	row := 0
+	for _, cnt := range blockRows {
+		var data []byte
+		for i := 0; i < cnt; i++ {
+			data = append(data, encodeTestAvroRow(row)...)
+			row++
+		}
+		blocks = append(blocks, compressAvroBlock(t, codec, data))
+	}
+	return writeAvroFile(testAvroSchema, codec, blockRows, blocks)
+}
+
+func TestAvroParser(t *testing.T) {
+	ctx := context.Background()
+	for _, codec := range []string{"null", "deflate", "snappy", "zstandard"} {
+		data := writeTestAvroFile(t, codec, []int{2, 2, 1})
+		parser, err := mydump.NewAvroParser(ctx, mydump.NewStringReader(string(data)), nil)
+		require.NoError(t, err, codec)
+		require.Equal(t, []string{"id", "name", "score", "ok", "amount", "day", "ts", "kind", "tags", "attrs", "inner", "next"}, parser.Columns())
+		for i := 0; i < 5; i++ {
+			require.NoError(t, parser.ReadRow(), codec)
+			requireAvroRowEqual(t, expectedTestAvroRow(i), parser.LastRow().Row, codec)
+			assertPosEqual(t, parser, int64(i+1), int64(i+1))
+		}
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+		scanned, err := parser.ScannedPos()
+		require.NoError(t, err)
+		require.Equal(t, int64(len(data)), scanned)
+		require.NoError(t, parser.Close())
+
+		// skip the first block without decoding, and part of the second block
+		parser, err = mydump.NewAvroParser(ctx, mydump.NewStringReader(string(data)), nil)
+		require.NoError(t, err)
+		require.NoError(t, parser.SetPos(3, 3))
+		require.NoError(t, parser.ReadRow())
+		requireAvroRowEqual(t, expectedTestAvroRow(3), parser.LastRow().Row, codec)
+		assertPosEqual(t, parser, 4, 4)
+		require.ErrorContains(t, parser.SetPos(1, 1), "avro parser doesn't support seek back")
+		require.NoError(t, parser.SetPos(5, 5))
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+		require.NoError(t, parser.Close())
+	}
+}
+
+func TestAvroParserColumns(t *testing.T) {
+	ctx := context.Background()
+	data := writeTestAvroFile(t, "null", []int{1})
+	parser, err := mydump.NewAvroParser(ctx, mydump.NewStringReader(string(data)), []string{"Next", "id"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"next", "id"}, parser.Columns())
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewDatum(nil), types.NewIntDatum(0)}, parser.LastRow().Row)
+	require.NoError(t, parser.Close())
+
+	_, err = mydump.NewAvroParser(ctx, mydump.NewStringReader(string(data)), []string{"id", "unknown"})
+	require.ErrorContains(t, err, "column 'unknown' is not found in the avro schema")
+
+	_, err = mydump.NewAvroParser(ctx, mydump.NewStringReader("a,b,c\n"), nil)
+	require.ErrorContains(t, err, "not an avro object container file")
+
+	data = writeTestAvroFile(t, "xz", nil)
+	_, err = mydump.NewAvroParser(ctx, mydump.NewStringReader(string(data)), nil)
+	require.ErrorContains(t, err, "unsupported avro codec 'xz'")
+
+	// truncated file
+	data = writeTestAvroFile(t, "null", []int{2})
+	parser, err = mydump.NewAvroParser(ctx, mydump.NewStringReader(string(data[:len(data)-20])), nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "avro: unexpected end of data")
+	require.NoError(t, parser.Close())
+}
+
+func TestReadAvroFileRowCount(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "db.tbl.avro"), writeTestAvroFile(t, "deflate", []int{2, 2, 1}), 0o644))
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	rows, err := mydump.ReadAvroFileRowCountByFile(context.Background(), store, mydump.SourceFileMeta{Path: "db.tbl.avro"})
+	require.NoError(t, err)
+	require.Equal(t, int64(5), rows)
+}
+
+func TestAvroParserUnion(t *testing.T) {
+	schema := `{
+  "type": "record", "name": "U",
+  "fields": [
+    {"name": "u1", "type": ["null", "long", "string"]},
+    {"name": "u2", "type": ["string", "null"]},
+    {"name": "u3", "type": ["null", {"type": "record", "name": "R", "fields": [{"name": "a", "type": "int"}]}]},
+    {"name": "u4", "type": ["null", {"type": "bytes", "logicalType": "decimal", "precision": 5, "scale": 1}]}
+  ]
+}`
+	var data []byte
+	// the branches other than null, and the null branch which isn't the first one
+	data = appendAvroLong(data, 1)
+	data = appendAvroLong(data, 7)
+	data = appendAvroLong(data, 0)
+	data = appendAvroBytes(data, []byte("s"))
+	data = appendAvroLong(data, 1)
+	data = appendAvroLong(data, 3)
+	data = appendAvroLong(data, 1)
+	data = appendAvroBytes(data, []byte{0x7b})
+	// the last branch and null
+	data = appendAvroLong(data, 2)
+	data = appendAvroBytes(data, []byte("x"))
+	data = appendAvroLong(data, 1)
+	data = appendAvroLong(data, 0)
+	data = appendAvroLong(data, 0)
+	// all null
+	data = appendAvroLong(data, 0)
+	data = appendAvroLong(data, 1)
+	data = appendAvroLong(data, 0)
+	data = appendAvroLong(data, 0)
+	// the branch index is out of range
+	data = appendAvroLong(data, 3)
+
+	ctx := context.Background()
+	file := writeAvroFile(schema, "null", []int{4}, [][]byte{data})
+	parser, err := mydump.NewAvroParser(ctx, mydump.NewStringReader(string(file)), nil)
+	require.NoError(t, err)
+	expected := [][]types.Datum{
+		{
+			types.NewIntDatum(7),
+			types.NewCollationStringDatum("s", "utf8mb4_bin"),
+			types.NewCollationStringDatum(`{"a":3}`, "utf8mb4_bin"),
+			types.NewCollationStringDatum("12.3", "utf8mb4_bin"),
+		},
+		{
+			types.NewCollationStringDatum("x", "utf8mb4_bin"),
+			types.NewDatum(nil),
+			types.NewDatum(nil),
+			types.NewDatum(nil),
+		},
+		{types.NewDatum(nil), types.NewDatum(nil), types.NewDatum(nil), types.NewDatum(nil)},
+	}
+	for i, row := range expected {
+		require.NoError(t, parser.ReadRow())
+		requireAvroRowEqual(t, row, parser.LastRow().Row, i)
+	}
+	require.ErrorContains(t, parser.ReadRow(), "failed to decode field 'u1' of row 4: avro: union index 3 out of range")
+	require.NoError(t, parser.Close())
+}
+
+func TestAvroParserLogicalTypes(t *testing.T) {
+	schema := `{
+  "type": "record", "name": "L",
+  "fields": [
+    {"name": "d1", "type": {"type": "bytes", "logicalType": "decimal", "precision": 10, "scale": 2}},
+    {"name": "d2", "type": {"type": "fixed", "name": "D4", "size": 4, "logicalType": "decimal", "precision": 9, "scale": 3}},
+    {"name": "d3", "type": {"type": "bytes", "logicalType": "decimal", "precision": 5}},
+    {"name": "ts", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+    {"name": "tsu", "type": {"type": "long", "logicalType": "timestamp-micros"}},
+    {"name": "lts", "type": {"type": "long", "logicalType": "local-timestamp-millis"}},
+    {"name": "dt", "type": {"type": "int", "logicalType": "date"}},
+    {"name": "tm", "type": {"type": "int", "logicalType": "time-millis"}}
+  ]
+}`
+	var data []byte
+	data = appendAvroBytes(data, []byte{0x00, 0x01})
+	data = append(data, 0xff, 0xff, 0xff, 0xfe)
+	data = appendAvroBytes(data, []byte{0x04, 0xd2})
+	data = appendAvroLong(data, 1672531200123)
+	data = appendAvroLong(data, 1672531200123456)
+	data = appendAvroLong(data, 1672531200123)
+	data = appendAvroLong(data, 19358)
+	data = appendAvroLong(data, 3723004)
+	// negative decimals and the time before the epoch
+	data = appendAvroBytes(data, []byte{0x80, 0x00})
+	data = append(data, 0x00, 0x00, 0x30, 0x39)
+	data = appendAvroBytes(data, []byte{0xff})
+	data = appendAvroLong(data, -1)
+	data = appendAvroLong(data, 0)
+	data = appendAvroLong(data, 0)
+	data = appendAvroLong(data, -1)
+	data = appendAvroLong(data, 0)
+
+	ctx := context.Background()
+	file := writeAvroFile(schema, "null", []int{2}, [][]byte{data})
+	parser, err := mydump.NewAvroParser(ctx, mydump.NewStringReader(string(file)), nil)
+	require.NoError(t, err)
+	expected := [][]string{
+		{"0.01", "-0.002", "1234", "2023-01-01 00:00:00.123Z", "2023-01-01 00:00:00.123456Z", "2023-01-01 00:00:00.123", "2023-01-01", "01:02:03.004"},
+		{"-327.68", "12.345", "-1", "1969-12-31 23:59:59.999Z", "1970-01-01 00:00:00Z", "1970-01-01 00:00:00", "1969-12-31", "00:00:00"},
+	}
+	for i, row := range expected {
+		require.NoError(t, parser.ReadRow())
+		datums := make([]types.Datum, 0, len(row))
+		for _, v := range row {
+			datums = append(datums, types.NewCollationStringDatum(v, "utf8mb4_bin"))
+		}
+		require.Equal(t, datums, parser.LastRow().Row, i)
+	}
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+	require.NoError(t, parser.Close())
+}
+
+func TestAvroParserCodecs(t *testing.T) {
+	schema := `{"type": "record", "name": "C", "fields": [{"name": "id", "type": "long"}, {"name": "s", "type": "string"}]}`
+	encodeRows := func(start, end int) []byte {
+		var data []byte
+		for i := start; i < end; i++ {
+			data = appendAvroLong(data, int64(i))
+			data = appendAvroBytes(data, bytes.Repeat([]byte{byte('a' + i%26)}, 100))
+		}
+		return data
+	}
+	ctx := context.Background()
+	for _, codec := range []string{"deflate", "snappy", "zstandard"} {
+		// the blocks are large enough to be compressed, and the rows are read across the blocks
+		file := writeAvroFile(schema, codec, []int{300, 700}, [][]byte{
+			compressAvroBlock(t, codec, encodeRows(0, 300)),
+			compressAvroBlock(t, codec, encodeRows(300, 1000)),
+		})
+		require.Less(t, len(file), 1000*100, codec)
+		parser, err := mydump.NewAvroParser(ctx, mydump.NewStringReader(string(file)), nil)
+		require.NoError(t, err, codec)
+		for i := 0; i < 1000; i++ {
+			require.NoError(t, parser.ReadRow(), codec)
+			require.Equal(t, []types.Datum{
+				types.NewIntDatum(int64(i)),
+				types.NewCollationStringDatum(string(bytes.Repeat([]byte{byte('a' + i%26)}, 100)), "utf8mb4_bin"),
+			}, parser.LastRow().Row, codec)
+		}
+		require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF, codec)
+		require.NoError(t, parser.Close())
+	}
+
+	// the checksum of snappy is the CRC32 of the uncompressed data
+	block := compressAvroBlock(t, "snappy", encodeRows(0, 10))
+	block[len(block)-1]++
+	file := writeAvroFile(schema, "snappy", []int{10}, [][]byte{block})
+	parser, err := mydump.NewAvroParser(ctx, mydump.NewStringReader(string(file)), nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "failed to decompress avro block with codec 'snappy': avro: snappy checksum mismatch")
+	require.NoError(t, parser.Close())
+	file = writeAvroFile(schema, "snappy", []int{10}, [][]byte{{0x01, 0x02}})
+	parser, err = mydump.NewAvroParser(ctx, mydump.NewStringReader(string(file)), nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "avro: unexpected end of data")
+	require.NoError(t, parser.Close())
+
+	// the block isn't compressed by zstandard
+	file = writeAvroFile(schema, "zstandard", []int{10}, [][]byte{encodeRows(0, 10)})
+	parser, err = mydump.NewAvroParser(ctx, mydump.NewStringReader(string(file)), nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "failed to decompress avro block with codec 'zstandard'")
+	require.NoError(t, parser.Close())
+}
@@ -0,0 +1,302 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/log"
+	"github.com/pingcap/tidb/br/pkg/lightning/metric"
+	"github.com/pingcap/tidb/br/pkg/lightning/worker"
+	"github.com/pingcap/tidb/types"
+	"go.uber.org/zap"
+)
+
+// jsonlColumn describes how to extract the value of a column from a JSON line.
+type jsonlColumn struct {
+	path types.JSONPathExpression
+	// topLevelKey is not empty when the column is not given an explicit path,
+	// the value is the top-level key with the same name ignoring case.
+	topLevelKey string
+}
+
+// JSONLParser is a parser for JSON Lines files. Each non-empty line is a JSON
+// document, and the value of each column is extracted by a JSON path.
+type JSONLParser struct {
+	blockParser
+
+	// lower-case column name -> JSON path
+	columnPaths map[string]string
+	jsonColumns []jsonlColumn
+}
+
+// NewJSONLParser creates a JSON Lines parser. The columns are decided in order:
+//   - columns, used by IMPORT INTO to map values to the field list.
+//   - keys of cfg.ColumnPaths in lexicographical order.
+//   - top-level keys of the first line.
+//
+// Columns without a path in cfg.ColumnPaths take the top-level key with the
+// same name.
+func NewJSONLParser(
+	ctx context.Context,
+	cfg *config.JSONLConfig,
+	reader ReadSeekCloser,
+	blockBufSize int64,
+	ioWorkers *worker.Pool,
+	columns []string,
+) (*JSONLParser, error) {
+	metrics, _ := metric.FromContext(ctx)
+	parser := &JSONLParser{
+		blockParser: makeBlockParser(reader, blockBufSize, ioWorkers, metrics, log.FromContext(ctx)),
+		columnPaths: make(map[string]string, len(cfg.ColumnPaths)),
+	}
+	for name, path := range cfg.ColumnPaths {
+		if _, err := types.ParseJSONPathExpr(path); err != nil {
+			return nil, errors.Annotatef(err, "invalid JSON path '%s' of column '%s'", path, name)
+		}
+		parser.columnPaths[strings.ToLower(name)] = path
+	}
+	if len(columns) == 0 && len(parser.columnPaths) > 0 {
+		columns = make([]string, 0, len(parser.columnPaths))
+		for name := range parser.columnPaths {
+			columns = append(columns, name)
+		}
+		slices.Sort(columns)
+	}
+	if len(columns) > 0 {
+		if err := parser.setColumns(columns); err != nil {
+			return nil, err
+		}
+	}
+	return parser, nil
+}
+
+func (parser *JSONLParser) setColumns(columns []string) error {
+	lowerColumns := make([]string, 0, len(columns))
+	jsonColumns := make([]jsonlColumn, 0, len(columns))
+	for _, name := range columns {
+		lowerName := strings.ToLower(name)
+		col := jsonlColumn{}
+		pathStr, ok := parser.columnPaths[lowerName]
+		if !ok {
+			pathStr = "$." + strconv.Quote(name)
+			col.topLevelKey = lowerName
+		}
+		path, err := types.ParseJSONPathExpr(pathStr)
+		if err != nil {
+			return errors.Annotatef(err, "invalid JSON path '%s' of column '%s'", pathStr, name)
+		}
+		col.path = path
+		lowerColumns = append(lowerColumns, lowerName)
+		jsonColumns = append(jsonColumns, col)
+	}
+	parser.columns = lowerColumns
+	parser.jsonColumns = jsonColumns
+	return nil
+}
+
+// SetPos changes the reported position and row ID. When the columns are taken
+// from the first line, the first line is read before seeking, so the columns
+// are the same no matter where the parser starts.
+func (parser *JSONLParser) SetPos(pos int64, rowID int64) error {
+	if parser.jsonColumns == nil && pos > 0 {
+		if err := parser.readFirstLine(); err != nil {
+			return err
+		}
+	}
+	return parser.blockParser.SetPos(pos, rowID)
+}
+
+func (parser *JSONLParser) readFirstLine() error {
+	if _, err := parser.reader.Seek(0, io.SeekStart); err != nil {
+		return errors.Trace(err)
+	}
+	parser.buf, parser.pos, parser.isLastChunk = nil, 0, false
+	line, err := parser.readNonEmptyLine()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	doc, err := types.ParseBinaryJSONFromString(string(line))
+	if err != nil {
+		return errors.Annotate(err, "invalid JSON line at the beginning of the file")
+	}
+	if err = parser.inferColumns(doc); err != nil {
+		return err
+	}
+	parser.buf, parser.isLastChunk = nil, false
+	return nil
+}
+
+// SetColumns implements the Parser interface. The columns are decided when
+// creating the parser or from the first line, so it's ignored.
+func (*JSONLParser) SetColumns(_ []string) {
+}
+
+// readLine reads until the next '\n' or the end of file, the returned line
+// doesn't contain the terminator.
+func (parser *JSONLParser) readLine() ([]byte, error) {
+	var buf []byte
+	for {
+		if idx := bytes.IndexByte(parser.buf, '\n'); idx >= 0 {
+			line := parser.buf[:idx]
+			if len(buf) > 0 {
+				line = append(buf, line...)
+			}
+			parser.buf = parser.buf[idx+1:]
+			parser.pos += int64(idx + 1)
+			return line, nil
+		}
+		buf = append(buf, parser.buf...)
+		parser.pos += int64(len(parser.buf))
+		parser.buf = nil
+		if len(buf) > LargestEntryLimit {
+			return nil, errors.New("size of row cannot exceed the max value of txn-entry-size-limit")
+		}
+		if err := parser.readBlock(); err != nil {
+			return nil, errors.Trace(err)
+		}
+		if len(parser.buf) == 0 {
+			if len(buf) == 0 {
+				return nil, io.EOF
+			}
+			return buf, nil
+		}
+	}
+}
+
+// readNonEmptyLine skips the blank lines and returns the next line with the
+// surrounding whitespaces trimmed.
+func (parser *JSONLParser) readNonEmptyLine() ([]byte, error) {
+	for {
+		line, err := parser.readLine()
+		if err != nil {
+			return nil, err
+		}
+		if line = bytes.TrimSpace(line); len(line) > 0 {
+			return line, nil
+		}
+	}
+}
+
+// ReadRow reads the next non-empty line and converts it to a row.
+func (parser *JSONLParser) ReadRow() error {
+	line, err := parser.readNonEmptyLine()
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	row := &parser.lastRow
+	row.Length = len(line)
+	row.RowID++
+
+	doc, err := types.ParseBinaryJSONFromString(string(line))
+	if err != nil {
+		content := line
+		if len(content) > 256 {
+			content = content[:256]
+		}
+		parser.Logger.Error("syntax error", zap.Int64("pos", parser.pos), zap.ByteString("content", content))
+		return errors.Annotatef(err, "invalid JSON line before offset %d", parser.pos)
+	}
+	if parser.jsonColumns == nil {
+		if err = parser.inferColumns(doc); err != nil {
+			return err
+		}
+	}
+
+	row.Row = parser.acquireDatumSlice()
+	if cap(row.Row) >= len(parser.jsonColumns) {
+		row.Row = row.Row[:len(parser.jsonColumns)]
+	} else {
+		row.Row = make([]types.Datum, len(parser.jsonColumns))
+	}
+	for i := range parser.jsonColumns {
+		value, found := parser.extract(doc, &parser.jsonColumns[i])
+		if !found {
+			row.Row[i].SetNull()
+			continue
+		}
+		setDatumByJSON(&row.Row[i], value)
+	}
+	return nil
+}
+
+// inferColumns uses the top-level keys of the line as the columns.
+func (parser *JSONLParser) inferColumns(doc types.BinaryJSON) error {
+	if doc.TypeCode != types.JSONTypeCodeObject {
+		return errors.Errorf("JSON line should be an object to infer the columns, got %s", doc.Type())
+	}
+	keys := doc.GetKeys()
+	columns := make([]string, 0, keys.GetElemCount())
+	for i := 0; i < keys.GetElemCount(); i++ {
+		columns = append(columns, string(keys.ArrayGetElem(i).GetString()))
+	}
+	return parser.setColumns(columns)
+}
+
+func (*JSONLParser) extract(doc types.BinaryJSON, col *jsonlColumn) (types.BinaryJSON, bool) {
+	value, found := doc.Extract([]types.JSONPathExpression{col.path})
+	if found || col.topLevelKey == "" || doc.TypeCode != types.JSONTypeCodeObject {
+		return value, found
+	}
+	// the column name might be in a different case with the key, find the key
+	// ignoring case and use it for the following lines.
+	keys := doc.GetKeys()
+	for i := 0; i < keys.GetElemCount(); i++ {
+		key := string(keys.ArrayGetElem(i).GetString())
+		if strings.ToLower(key) != col.topLevelKey {
+			continue
+		}
+		path, err := types.ParseJSONPathExpr("$." + strconv.Quote(key))
+		if err != nil {
+			return value, false
+		}
+		col.path = path
+		return doc.Extract([]types.JSONPathExpression{path})
+	}
+	return value, false
+}
+
+func setDatumByJSON(d *types.Datum, value types.BinaryJSON) {
+	switch value.TypeCode {
+	case types.JSONTypeCodeLiteral:
+		switch value.Value[0] {
+		case types.JSONLiteralTrue:
+			d.SetUint64(1)
+		case types.JSONLiteralFalse:
+			d.SetUint64(0)
+		default:
+			d.SetNull()
+		}
+	case types.JSONTypeCodeInt64:
+		d.SetInt64(value.GetInt64())
+	case types.JSONTypeCodeUint64:
+		d.SetUint64(value.GetUint64())
+	case types.JSONTypeCodeFloat64:
+		d.SetFloat64(value.GetFloat64())
+	case types.JSONTypeCodeString:
+		d.SetString(string(value.GetString()), "utf8mb4_bin")
+	default:
+		// objects and arrays are kept as JSON text
+		d.SetString(value.String(), "utf8mb4_bin")
+	}
+}
@@ -0,0 +1,154 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mydump_test
+
+import (
+	"context"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONLParserInferColumns(t *testing.T) {
+	input := `{"id": 1, "Name": "a", "score": 1.5, "ok": true, "tags": ["x", "y"]}` + "\r\n" +
+		"\n" +
+		`{"id": 18446744073709551615, "name": null, "extra": 1, "ok": false, "tags": {"k": "v"}}` + "\n" +
+		`  {"id": -2, "NAME": "c"}`
+	parser, err := mydump.NewJSONLParser(context.Background(), &config.JSONLConfig{}, mydump.NewStringReader(input), 4, ioWorkersForCSV, nil)
+	require.NoError(t, err)
+	require.Nil(t, parser.Columns())
+
+	require.NoError(t, parser.ReadRow())
+	// keys of JSON object are sorted by length and then bytes
+	require.Equal(t, []string{"name", "id", "ok", "score", "tags"}, parser.Columns())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("a", "utf8mb4_bin"),
+		types.NewIntDatum(1),
+		types.NewUintDatum(1),
+		types.NewFloat64Datum(1.5),
+		types.NewCollationStringDatum(`["x", "y"]`, "utf8mb4_bin"),
+	}, parser.LastRow().Row)
+	assertPosEqual(t, parser, 70, 1)
+
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewDatum(nil),
+		types.NewUintDatum(18446744073709551615),
+		types.NewUintDatum(0),
+		types.NewDatum(nil),
+		types.NewCollationStringDatum(`{"k": "v"}`, "utf8mb4_bin"),
+	}, parser.LastRow().Row)
+	assertPosEqual(t, parser, 159, 2)
+
+	// the key is matched ignoring case
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("c", "utf8mb4_bin"),
+		types.NewIntDatum(-2),
+		types.NewDatum(nil),
+		types.NewDatum(nil),
+		types.NewDatum(nil),
+	}, parser.LastRow().Row)
+	assertPosEqual(t, parser, int64(len(input)), 3)
+
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+	require.NoError(t, parser.Close())
+}
+
+func TestJSONLParserColumnPaths(t *testing.T) {
+	input := `{"user": {"id": 1, "name": "a"}, "ts": "2023-01-01 00:00:00", "items": [10, 20]}` + "\n" +
+		`{"user": {"id": 2}, "ts": "2023-01-02 00:00:00", "items": []}` + "\n"
+	cfg := &config.JSONLConfig{ColumnPaths: map[string]string{
+		"ID":     "$.user.id",
+		"name":   "$.user.name",
+		"first":  "$.items[0]",
+		"unused": "$.unused",
+	}}
+
+	// columns are the keys of column paths
+	parser, err := mydump.NewJSONLParser(context.Background(), cfg, mydump.NewStringReader(input), 16, ioWorkersForCSV, nil)
+	require.NoError(t, err)
+	require.Equal(t, []string{"first", "id", "name", "unused"}, parser.Columns())
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewIntDatum(10),
+		types.NewIntDatum(1),
+		types.NewCollationStringDatum("a", "utf8mb4_bin"),
+		types.NewDatum(nil),
+	}, parser.LastRow().Row)
+	require.NoError(t, parser.Close())
+
+	// columns are specified, and the column without path uses the top-level key
+	parser, err = mydump.NewJSONLParser(context.Background(), cfg, mydump.NewStringReader(input), 16, ioWorkersForCSV, []string{"ts", "id", "name"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"ts", "id", "name"}, parser.Columns())
+	require.NoError(t, parser.ReadRow())
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewCollationStringDatum("2023-01-02 00:00:00", "utf8mb4_bin"),
+		types.NewIntDatum(2),
+		types.NewDatum(nil),
+	}, parser.LastRow().Row)
+	assertPosEqual(t, parser, int64(len(input)), 2)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+	require.NoError(t, parser.Close())
+
+	// invalid path
+	cfg.ColumnPaths["bad"] = "user.id"
+	_, err = mydump.NewJSONLParser(context.Background(), cfg, mydump.NewStringReader(input), 16, ioWorkersForCSV, nil)
+	require.ErrorContains(t, err, "invalid JSON path 'user.id' of column 'bad'")
+}
+
+func TestJSONLParserSetPos(t *testing.T) {
+	lines := []string{
+		`{"a": 1, "b": "x"}`,
+		`{"b": "y", "a": 2}`,
+		`{"c": 3}`,
+	}
+	input := strings.Join(lines, "\n")
+
+	// columns are inferred from the first line even the parser starts from the middle
+	parser, err := mydump.NewJSONLParser(context.Background(), &config.JSONLConfig{}, mydump.NewStringReader(input), 8, ioWorkersForCSV, nil)
+	require.NoError(t, err)
+	require.NoError(t, parser.SetPos(int64(len(lines[0])+1), 1))
+	require.Equal(t, []string{"a", "b"}, parser.Columns())
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{
+		types.NewIntDatum(2),
+		types.NewCollationStringDatum("y", "utf8mb4_bin"),
+	}, parser.LastRow().Row)
+	assertPosEqual(t, parser, int64(len(lines[0])+len(lines[1])+2), 2)
+	require.NoError(t, parser.ReadRow())
+	require.Equal(t, []types.Datum{types.NewDatum(nil), types.NewDatum(nil)}, parser.LastRow().Row)
+	require.ErrorIs(t, errors.Cause(parser.ReadRow()), io.EOF)
+	require.NoError(t, parser.Close())
+
+	// invalid JSON
+	parser, err = mydump.NewJSONLParser(context.Background(), &config.JSONLConfig{}, mydump.NewStringReader(`{"a": 1}`+"\n{\"a\": }"), 8, ioWorkersForCSV, nil)
+	require.NoError(t, err)
+	require.NoError(t, parser.ReadRow())
+	require.ErrorContains(t, parser.ReadRow(), "invalid JSON line before offset 16")
+
+	// the first line should be an object to infer columns
+	parser, err = mydump.NewJSONLParser(context.Background(), &config.JSONLConfig{}, mydump.NewStringReader("[1, 2]\n"), 8, ioWorkersForCSV, nil)
+	require.NoError(t, err)
+	require.ErrorContains(t, parser.ReadRow(), "JSON line should be an object to infer the columns, got ARRAY")
+}
@@ -100,7 +100,7 @@ type ChunkParser struct {
 // Chunk represents a portion of the data file.
 type Chunk struct {
 	Offset int64
-	// for parquet file, it's the total row count
+	// for parquet and avro file, it's the total row count
 	// see makeParquetFileRegion
 	EndOffset  int64
 	RealOffset int64
@@ -145,7 +145,7 @@ const (
 type Parser interface {
 	// Pos returns means the position that parser have already handled. It's mainly used for checkpoint.
 	// For normal files it's the file offset we handled.
-	// For parquet files it's the row count we handled.
+	// For parquet and avro files it's the row count we handled.
 	// For compressed files it's the uncompressed file offset we handled.
 	// TODO: replace pos with a new structure to specify position offset and rows offset
 	Pos() (pos int64, rowID int64)
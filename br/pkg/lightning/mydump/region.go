@@ -241,6 +241,8 @@ func MakeTableRegions(
 			dataFileSize := info.FileMeta.FileSize
 			if info.FileMeta.Type == SourceTypeParquet {
 				regions, sizes, err = makeParquetFileRegion(egCtx, cfg, info)
+			} else if info.FileMeta.Type == SourceTypeAvro {
+				regions, sizes, err = makeAvroFileRegion(egCtx, cfg, info)
 			} else if info.FileMeta.Type == SourceTypeCSV && cfg.StrictFormat &&
 				info.FileMeta.Compression == CompressionNone &&
 				dataFileSize > cfg.MaxChunkSize+cfg.MaxChunkSize/largeCSVLowerThresholdRation {
@@ -392,6 +394,37 @@ func makeParquetFileRegion(
 	return []*TableRegion{region}, []float64{float64(dataFile.FileMeta.FileSize)}, nil
 }
 
+// avro files are split into blocks which can't be located without reading the
+// whole file, so there is only one region for each file and the offset is the
+// number of rows like parquet.
+func makeAvroFileRegion(
+	ctx context.Context,
+	cfg *DataDivideConfig,
+	dataFile FileInfo,
+) ([]*TableRegion, []float64, error) {
+	numberRows := dataFile.FileMeta.Rows
+	var err error
+	if numberRows <= 0 {
+		numberRows, err = ReadAvroFileRowCountByFile(ctx, cfg.Store, dataFile.FileMeta)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	region := &TableRegion{
+		DB:       cfg.TableMeta.DB,
+		Table:    cfg.TableMeta.Name,
+		FileMeta: dataFile.FileMeta,
+		Chunk: Chunk{
+			Offset:       0,
+			EndOffset:    numberRows,
+			RealOffset:   0,
+			PrevRowIDMax: 0,
+			RowIDMax:     numberRows,
+		},
+	}
+	return []*TableRegion{region}, []float64{float64(dataFile.FileMeta.FileSize)}, nil
+}
+
 // SplitLargeCSV splits a large csv file into multiple regions, the size of
 // each regions is specified by `config.MaxRegionSize`.
 // Note: We split the file coarsely, thus the format of csv file is needed to be
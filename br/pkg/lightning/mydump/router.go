@@ -34,6 +34,10 @@ const (
 	SourceTypeParquet
 	// SourceTypeViewSchema means this source file is a schema file for the view.
 	SourceTypeViewSchema
+	// SourceTypeJSONL means this source file is a JSON Lines data file.
+	SourceTypeJSONL
+	// SourceTypeAvro means this source file is an Avro object container file.
+	SourceTypeAvro
 )
 
 const (
@@ -49,6 +53,10 @@ const (
 	TypeCSV = "csv"
 	// TypeParquet is the source type value for parquet data file.
 	TypeParquet = "parquet"
+	// TypeJSONL is the source type value for JSON Lines data file.
+	TypeJSONL = "jsonl"
+	// TypeAvro is the source type value for avro data file.
+	TypeAvro = "avro"
 	// TypeIgnore is the source type value for a ignored data file.
 	TypeIgnore = "ignore"
 )
@@ -102,6 +110,10 @@ func parseSourceType(t string) (SourceType, error) {
 		return SourceTypeCSV, nil
 	case TypeParquet:
 		return SourceTypeParquet, nil
+	case TypeJSONL:
+		return SourceTypeJSONL, nil
+	case TypeAvro:
+		return SourceTypeAvro, nil
 	case TypeIgnore:
 		return SourceTypeIgnore, nil
 	case ViewSchema:
@@ -123,6 +135,10 @@ func (s SourceType) String() string {
 		return TypeSQL
 	case SourceTypeParquet:
 		return TypeParquet
+	case SourceTypeJSONL:
+		return TypeJSONL
+	case SourceTypeAvro:
+		return TypeAvro
 	case SourceTypeViewSchema:
 		return ViewSchema
 	default:
@@ -130,6 +146,12 @@ func (s SourceType) String() string {
 	}
 }
 
+// IsRowBased returns whether the position of the data file is the row count
+// instead of the file offset, see Parser.Pos.
+func (s SourceType) IsRowBased() bool {
+	return s == SourceTypeParquet || s == SourceTypeAvro
+}
+
 // ParseCompressionOnFileExtension parses the compression type from the file extension.
 func ParseCompressionOnFileExtension(filename string) Compression {
 	fileExt := strings.ToLower(filepath.Ext(filename))
@@ -171,7 +193,7 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// ignore *-schema-trigger.sql, *-schema-post.sql files
 	{Pattern: `(?i).*(-schema-trigger|-schema-post)\.sql(?:\.(\w*?))?$`, Type: "ignore"},
 	// ignore backup files
-	{Pattern: `(?i).*\.(sql|csv|parquet)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
+	{Pattern: `(?i).*\.(sql|csv|parquet|jsonl|avro)(\.(\w+))?\.(bak|BAK)$`, Type: "ignore"},
 	// db schema create file pattern, matches files like '{schema}-schema-create.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)-schema-create\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "", Type: SchemaSchema, Compression: "$2", Unescape: true},
@@ -181,8 +203,8 @@ var defaultFileRouteRules = []*config.FileRouteRule{
 	// view schema create file pattern, matches files like '{schema}.{table}-schema-view.sql[.{compress}]'
 	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)-schema-view\.sql(?:\.(\w*?))?$`,
 		Schema: "$1", Table: "$2", Type: ViewSchema, Compression: "$3", Unescape: true},
-	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv}[.{compress}]'
-	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet)(?:\.(\w+))?$`,
+	// source file pattern, matches files like '{schema}.{table}.0001.{sql|csv|parquet|jsonl|avro}[.{compress}]'
+	{Pattern: `(?i)^(?:[^/]*/)*([^/.]+)\.(.*?)(?:\.([0-9]+))?\.(sql|csv|parquet|jsonl|avro)(?:\.(\w+))?$`,
 		Schema: "$1", Table: "$2", Type: "$4", Key: "$3", Compression: "$5", Unescape: true},
 }
 
@@ -347,6 +369,9 @@ func (p regexRouterParser) Parse(r *config.FileRouteRule, logger log.Logger) (*R
 			if result.Type == SourceTypeParquet && compression != CompressionNone {
 				return errors.Errorf("can't support whole compressed parquet file, should compress parquet files by choosing correct parquet compress writer, path: %s", r.Path)
 			}
+			if result.Type == SourceTypeAvro && compression != CompressionNone {
+				return errors.Errorf("can't support whole compressed avro file, should compress avro files by choosing the block codec of avro writer, path: %s", r.Path)
+			}
 			result.Compression = compression
 			return nil
 		})
@@ -55,6 +55,8 @@ func TestDefaultRouter(t *testing.T) {
 		"/test/123/my_schema.my_table.sql.gz":    {"my_schema", "my_table", "", "gz", "sql"},
 		"my_dir/my_schema.my_table.csv.lzo":      {"my_schema", "my_table", "", "lzo", "csv"},
 		"my_schema.my_table.0001.sql.snappy":     {"my_schema", "my_table", "0001", "snappy", "sql"},
+		"my_schema.my_table.0001.jsonl.gz":       {"my_schema", "my_table", "0001", "gz", "jsonl"},
+		"my_schema.my_table.avro":                {"my_schema", "my_table", "", "", "avro"},
 	}
 	for path, fields := range inputOutputMap {
 		res, err := r.Route(path)
@@ -310,3 +312,10 @@ func TestRouteWithCompressedParquet(t *testing.T) {
 	_, err = router.Route(fileName)
 	require.Error(t, err)
 }
+
+func TestRouteWithCompressedAvro(t *testing.T) {
+	r, err := NewFileRouter(defaultFileRouteRules, log.L())
+	require.NoError(t, err)
+	_, err = r.Route("myschema.my_table.000.avro.gz")
+	require.ErrorContains(t, err, "can't support whole compressed avro file")
+}
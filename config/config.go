@@ -879,6 +879,8 @@ type Experimental struct {
 	AllowsExpressionIndex bool `toml:"allow-expression-index" json:"allow-expression-index"`
 	// Whether enable charset feature.
 	EnableNewCharset bool `toml:"enable-new-charset" json:"-"`
+	// Whether enable the baseline evolution feature, which is not generally available yet.
+	EnablePlanBaselineEvolution bool `toml:"enable-plan-baseline-evolution" json:"enable-plan-baseline-evolution"`
 }
 
 var defTiKVCfg = tikvcfg.DefaultConfig()
@@ -884,7 +884,9 @@ func adjustTableInfoAfterModifyColumnWithData(tblInfo *model.TableInfo, pos *ast
 		replaceOldIndexes(tblInfo, indexesToRemove)
 	}
 	if tblInfo.TTLInfo != nil {
-		updateTTLInfoWhenModifyColumn(tblInfo, oldCol.Name, changingCol.Name)
+		if err = updateTTLInfoWhenModifyColumn(tblInfo, oldCol.Name, changingCol.Name); err != nil {
+			return errors.Trace(err)
+		}
 	}
 	// Move the new column to a correct offset.
 	destOffset, err := LocateOffsetToMove(changingCol.Offset, pos, tblInfo)
@@ -960,15 +962,21 @@ func updateFKInfoWhenModifyColumn(tblInfo *model.TableInfo, oldCol, newCol model
 	}
 }
 
-func updateTTLInfoWhenModifyColumn(tblInfo *model.TableInfo, oldCol, newCol model.CIStr) {
+func updateTTLInfoWhenModifyColumn(tblInfo *model.TableInfo, oldCol, newCol model.CIStr) error {
 	if oldCol.L == newCol.L {
-		return
+		return nil
 	}
 	if tblInfo.TTLInfo != nil {
 		if tblInfo.TTLInfo.ColumnName.L == oldCol.L {
 			tblInfo.TTLInfo.ColumnName = newCol
 		}
+		filter, err := renameColumnInTTLFilter(tblInfo.TTLInfo.FilterExprStr, oldCol, newCol)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		tblInfo.TTLInfo.FilterExprStr = filter
 	}
+	return nil
 }
 
 // filterIndexesToRemove filters out the indexes that can be removed.
@@ -1590,8 +1598,7 @@ func adjustTableInfoAfterModifyColumn(
 	tblInfo.MoveColumnInfo(oldCol.Offset, destOffset)
 	updateNewIdxColsNameOffset(tblInfo.Indices, oldCol.Name, newCol)
 	updateFKInfoWhenModifyColumn(tblInfo, oldCol.Name, newCol.Name)
-	updateTTLInfoWhenModifyColumn(tblInfo, oldCol.Name, newCol.Name)
-	return nil
+	return updateTTLInfoWhenModifyColumn(tblInfo, oldCol.Name, newCol.Name)
 }
 
 func adjustForeignKeyChildTableInfoAfterModifyColumn(d *ddlCtx, t *meta.Meta, job *model.Job, tblInfo *model.TableInfo, newCol, oldCol *model.ColumnInfo) ([]schemaIDAndTableInfo, error) {
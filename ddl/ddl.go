@@ -173,6 +173,10 @@ type DDL interface {
 	RecoverTable(ctx sessionctx.Context, recoverInfo *RecoverInfo) (err error)
 	RecoverSchema(ctx sessionctx.Context, recoverSchemaInfo *RecoverSchemaInfo) error
 	DropView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err error)
+	CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error
+	DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error
+	CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error
+	DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error
 	CreateIndex(ctx sessionctx.Context, stmt *ast.CreateIndexStmt) error
 	DropIndex(ctx sessionctx.Context, stmt *ast.DropIndexStmt) error
 	AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast.AlterTableStmt) error
@@ -30,6 +30,7 @@ import (
 	"time"
 	"unicode/utf8"
 
+	"github.com/docker/go-units"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/config"
@@ -38,9 +39,11 @@ import (
 	ddlutil "github.com/pingcap/tidb/ddl/util"
 	rg "github.com/pingcap/tidb/domain/resourcegroup"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/extension"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/mview"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/charset"
@@ -924,11 +927,11 @@ func buildColumnAndConstraint(
 
 // checkColumnDefaultValue checks the default value of the column.
 // In non-strict SQL mode, if the default value of the column is an empty string, the default value can be ignored.
-// In strict SQL mode, TEXT/BLOB/JSON can't have not null default values.
+// In strict SQL mode, TEXT/BLOB/JSON/GEOMETRY can't have not null default values.
 // In NO_ZERO_DATE SQL mode, TIMESTAMP/DATE/DATETIME type can't have zero date like '0000-00-00' or '0000-00-00 00:00:00'.
 func checkColumnDefaultValue(ctx sessionctx.Context, col *table.Column, value interface{}) (bool, interface{}, error) {
 	hasDefaultValue := true
-	if value != nil && (col.GetType() == mysql.TypeJSON ||
+	if value != nil && (col.GetType() == mysql.TypeJSON || col.GetType() == mysql.TypeGeometry ||
 		col.GetType() == mysql.TypeTinyBlob || col.GetType() == mysql.TypeMediumBlob ||
 		col.GetType() == mysql.TypeLongBlob || col.GetType() == mysql.TypeBlob) {
 		// In non-strict SQL mode.
@@ -1899,6 +1902,12 @@ func BuildTableInfo(
 	}
 	foreignKeyID := tbInfo.MaxForeignKeyID
 	for _, constr := range constraints {
+		if constr.Tp == ast.ConstraintSpatial {
+			if err := checkSpatialIndex(tbInfo.Columns, constr.Keys); err != nil {
+				return nil, err
+			}
+			constr.Option = spatialIndexOption(constr.Option)
+		}
 		// Build hidden columns if necessary.
 		hiddenCols, err := buildHiddenColumnInfoWithCheck(ctx, constr.Keys, model.NewCIStr(constr.Name), tbInfo, tblColumns)
 		if err != nil {
@@ -2232,7 +2241,75 @@ func checkTableInfoValidWithStmt(ctx sessionctx.Context, tbInfo *model.TableInfo
 			return errors.Trace(err)
 		}
 	}
+	if tbInfo.Engine != "" {
+		if err := checkCustomEngineTableInfo(tbInfo); err != nil {
+			return errors.Trace(err)
+		}
+	}
+
+	return nil
+}
+
+// checkCustomEngineTableInfo checks the table created with a custom engine provided by extension.
+// The rows of these tables are not stored in TiKV, so the features depending on TiKV storage are not supported.
+func checkCustomEngineTableInfo(tbInfo *model.TableInfo) error {
+	def, ok := extension.GetTableSource(tbInfo.Engine)
+	if !ok {
+		return dbterror.ErrUnknownEngine.GenWithStackByArgs(tbInfo.Engine)
+	}
 
+	var unsupported string
+	switch {
+	case len(tbInfo.Indices) > 0:
+		unsupported = "index"
+	case tbInfo.Partition != nil:
+		unsupported = "partition"
+	case tbInfo.TTLInfo != nil:
+		unsupported = "TTL"
+	case tbInfo.TempTableType != model.TempTableNone:
+		unsupported = "temporary table"
+	case tbInfo.GetAutoIncrementColInfo() != nil:
+		unsupported = "auto_increment"
+	case tbInfo.AutoRandomBits > 0:
+		unsupported = "auto_random"
+	case len(tbInfo.ForeignKeys) > 0:
+		unsupported = "foreign key"
+	}
+	if unsupported != "" {
+		return checkNotCustomEngineTable(tbInfo, unsupported)
+	}
+
+	if def.ValidateTable != nil {
+		return def.ValidateTable(tbInfo)
+	}
+	return nil
+}
+
+// checkAlterTableEngine checks the engine of a table is not changed from or to a custom engine, because the rows can't
+// be moved between the storages. Changing the engine between the builtin engines is ignored for compatibility.
+func (d *ddl) checkAlterTableEngine(sctx sessionctx.Context, ident ast.Ident, engine string) error {
+	_, t, err := d.getSchemaAndTableByIdent(sctx, ident)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tbInfo := t.Meta()
+	if tbInfo.Engine != "" {
+		if strings.EqualFold(tbInfo.Engine, engine) {
+			return nil
+		}
+		return checkNotCustomEngineTable(tbInfo, "changing engine")
+	}
+	if _, ok := extension.GetTableSource(engine); ok {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf("changing engine to ENGINE=%s", strings.ToLower(engine)))
+	}
+	return nil
+}
+
+// checkNotCustomEngineTable returns an error if the operation is applied to a table with a custom engine.
+func checkNotCustomEngineTable(tbInfo *model.TableInfo, op string) error {
+	if tbInfo.Engine != "" {
+		return dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf("%s on table with ENGINE=%s", op, tbInfo.Engine))
+	}
 	return nil
 }
 
@@ -2294,6 +2371,7 @@ func BuildTableInfoWithLike(ctx sessionctx.Context, ident ast.Ident, referTblInf
 	tblInfo.Name = ident.Name
 	tblInfo.AutoIncID = 0
 	tblInfo.ForeignKeys = nil
+	tblInfo.Triggers = nil
 	// Ignore TiFlash replicas for temporary tables.
 	if s.TemporaryKeyword != ast.TemporaryNone {
 		tblInfo.TiFlashReplica = nil
@@ -3206,7 +3284,7 @@ func SetDirectResourceGroupSettings(groupInfo *model.ResourceGroupInfo, opt *ast
 			resourceGroupSettings.Runaway = nil
 		}
 		for _, opt := range opt.RunawayOptionList {
-			if err := SetDirectResourceGroupRunawayOption(resourceGroupSettings, opt.Tp, opt.StrValue, opt.IntValue); err != nil {
+			if err := SetDirectResourceGroupRunawayOption(resourceGroupSettings, opt); err != nil {
 				return err
 			}
 		}
@@ -3230,25 +3308,43 @@ func SetDirectResourceGroupSettings(groupInfo *model.ResourceGroupInfo, opt *ast
 }
 
 // SetDirectResourceGroupRunawayOption tries to set runaway part of the ResourceGroupSettings.
-func SetDirectResourceGroupRunawayOption(resourceGroupSettings *model.ResourceGroupSettings, typ ast.RunawayOptionType, stringVal string, intVal int32) error {
+func SetDirectResourceGroupRunawayOption(resourceGroupSettings *model.ResourceGroupSettings, opt *ast.ResourceGroupRunawayOption) error {
 	if resourceGroupSettings.Runaway == nil {
 		resourceGroupSettings.Runaway = &model.ResourceGroupRunawaySettings{}
 	}
 	settings := resourceGroupSettings.Runaway
-	switch typ {
+	switch opt.Tp {
 	case ast.RunawayRule:
 		// because execute time won't be too long, we use `time` pkg which does not support to parse unit 'd'.
-		dur, err := time.ParseDuration(stringVal)
+		dur, err := time.ParseDuration(opt.StrValue)
 		if err != nil {
 			return err
 		}
 		settings.ExecElapsedTimeMs = uint64(dur.Milliseconds())
+	case ast.RunawayRuleProcessedKeys:
+		settings.ProcessedKeys = opt.UintValue
+	case ast.RunawayRuleRU:
+		settings.RequestUnit = opt.UintValue
+	case ast.RunawayRuleMemory:
+		if len(opt.StrValue) > 0 {
+			bytes, err := units.RAMInBytes(opt.StrValue)
+			if err != nil {
+				return err
+			}
+			if bytes <= 0 {
+				return errors.Errorf("invalid memory size '%s'", opt.StrValue)
+			}
+			settings.MemoryBytes = uint64(bytes)
+		} else {
+			settings.MemoryBytes = opt.UintValue
+		}
 	case ast.RunawayAction:
-		settings.Action = model.RunawayActionType(intVal)
+		settings.Action = model.RunawayActionType(opt.IntValue)
+		settings.SwitchGroupName = strings.ToLower(opt.StrValue)
 	case ast.RunawayWatch:
-		settings.WatchType = model.RunawayWatchType(intVal)
-		if len(stringVal) > 0 {
-			dur, err := time.ParseDuration(stringVal)
+		settings.WatchType = model.RunawayWatchType(opt.IntValue)
+		if len(opt.StrValue) > 0 {
+			dur, err := time.ParseDuration(opt.StrValue)
 			if err != nil {
 				return err
 			}
@@ -3339,27 +3435,29 @@ func handleTableOptions(options []*ast.TableOption, tbInfo *model.TableInfo) err
 			tbInfo.PlacementPolicyRef = &model.PolicyRefInfo{
 				Name: model.NewCIStr(op.StrValue),
 			}
-		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
+		case ast.TableOptionEngine:
+			if def, ok := extension.GetTableSource(op.StrValue); ok {
+				tbInfo.Engine = strings.ToLower(def.Engine)
+			}
+		case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval,
+			ast.TableOptionTTLFilter, ast.TableOptionTTLArchiveTo, ast.TableOptionTTLJobWindow, ast.TableOptionTTLDeleteRate:
 			if ttlOptionsHandled {
 				continue
 			}
 
-			ttlInfo, ttlEnable, ttlJobInterval, err := getTTLInfoInOptions(options)
+			ttlOpts, err := getTTLInfoInOptions(options)
 			if err != nil {
 				return err
 			}
-			// It's impossible that `ttlInfo` and `ttlEnable` are all nil, because we have met this option.
-			// After exclude the situation `ttlInfo == nil && ttlEnable != nil`, we could say `ttlInfo != nil`
-			if ttlInfo == nil {
-				if ttlEnable != nil {
-					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ENABLE"))
-				}
-				if ttlJobInterval != nil {
-					return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
+			// It's impossible that all the options are nil, because we have met this option.
+			// After exclude the situation that other options are set without TTL, we could say `ttlOpts.info != nil`
+			if ttlOpts.info == nil {
+				if err = ttlOpts.checkForNonTTLTable(); err != nil {
+					return err
 				}
 			}
 
-			tbInfo.TTLInfo = ttlInfo
+			tbInfo.TTLInfo = ttlOpts.info
 			ttlOptionsHandled = true
 		}
 	}
@@ -3647,6 +3745,9 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 				err = d.CreatePrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintFulltext:
 				sctx.GetSessionVars().StmtCtx.AppendWarning(dbterror.ErrTableCantHandleFt)
+			case ast.ConstraintSpatial:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintCheck:
 				if !variable.EnableCheckConstraint.Load() {
 					sctx.GetSessionVars().StmtCtx.AppendWarning(errors.New("the switch of check constraint is off"))
@@ -3714,19 +3815,19 @@ func (d *ddl) AlterTable(ctx context.Context, sctx sessionctx.Context, stmt *ast
 						Name: model.NewCIStr(opt.StrValue),
 					}
 				case ast.TableOptionEngine:
-				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval:
-					var ttlInfo *model.TTLInfo
-					var ttlEnable *bool
-					var ttlJobInterval *string
+					err = d.checkAlterTableEngine(sctx, ident, opt.StrValue)
+				case ast.TableOptionTTL, ast.TableOptionTTLEnable, ast.TableOptionTTLJobInterval,
+					ast.TableOptionTTLFilter, ast.TableOptionTTLArchiveTo, ast.TableOptionTTLJobWindow, ast.TableOptionTTLDeleteRate:
+					var ttlOpts ttlOptions
 
 					if ttlOptionsHandled {
 						continue
 					}
-					ttlInfo, ttlEnable, ttlJobInterval, err = getTTLInfoInOptions(spec.Options)
+					ttlOpts, err = getTTLInfoInOptions(spec.Options)
 					if err != nil {
 						return err
 					}
-					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlInfo, ttlEnable, ttlJobInterval)
+					err = d.AlterTableTTLInfoOrEnable(sctx, ident, ttlOpts)
 
 					ttlOptionsHandled = true
 				default:
@@ -6142,6 +6243,9 @@ func (d *ddl) AlterTableSetTiFlashReplica(ctx sessionctx.Context, ident ast.Iden
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if err = checkNotCustomEngineTable(tb.Meta(), "TiFlash replica"); err != nil {
+		return err
+	}
 
 	tbReplicaInfo := tb.Meta().TiFlashReplica
 	if !shouldModifyTiFlashReplica(tbReplicaInfo, replicaInfo) {
@@ -6171,14 +6275,13 @@ func (d *ddl) AlterTableSetTiFlashReplica(ctx sessionctx.Context, ident ast.Iden
 	return errors.Trace(err)
 }
 
-// AlterTableTTLInfoOrEnable submit ddl job to change table info according to the ttlInfo, or ttlEnable
-// at least one of the `ttlInfo`, `ttlEnable` or `ttlCronJobSchedule` should be not nil.
-// When `ttlInfo` is nil, and `ttlEnable` is not, it will use the original `.TTLInfo` in the table info and modify the
-// `.Enable`. If the `.TTLInfo` in the table info is empty, this function will return an error.
-// When `ttlInfo` is nil, and `ttlCronJobSchedule` is not, it will use the original `.TTLInfo` in the table info and modify the
-// `.JobInterval`. If the `.TTLInfo` in the table info is empty, this function will return an error.
-// When `ttlInfo` is not nil, it simply submits the job with the `ttlInfo` and ignore the `ttlEnable`.
-func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string) error {
+// AlterTableTTLInfoOrEnable submit ddl job to change table info according to the TTL options, at least one of the
+// options should be not nil.
+// When `opts.info` is nil, and other options are not, it will use the original `.TTLInfo` in the table info and modify
+// the corresponding fields, e.g. `.Enable` for `opts.enable`. If the `.TTLInfo` in the table info is empty, this function
+// will return an error.
+// When `opts.info` is not nil, it simply submits the job with the `opts.info` and ignore the other options.
+func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident, opts ttlOptions) error {
 	is := d.infoCache.GetLatest()
 	schema, ok := is.SchemaByName(ident.Schema)
 	if !ok {
@@ -6195,19 +6298,30 @@ func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident,
 	tableName := tblInfo.Name.L
 
 	var job *model.Job
-	if ttlInfo != nil {
-		tblInfo.TTLInfo = ttlInfo
+	if opts.info != nil {
+		tblInfo.TTLInfo = opts.info
 		err = checkTTLInfoValid(ctx, ident.Schema, tblInfo)
 		if err != nil {
 			return err
 		}
 	} else {
 		if tblInfo.TTLInfo == nil {
-			if ttlEnable != nil {
-				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ENABLE"))
+			if err = opts.checkForNonTTLTable(); err != nil {
+				return err
+			}
+		} else if opts.filter != nil || opts.archiveTo != nil {
+			tblInfo.TTLInfo = tblInfo.TTLInfo.Clone()
+			if opts.filter != nil {
+				tblInfo.TTLInfo.FilterExprStr = *opts.filter
+				if err = checkTTLFilterExpr(ctx, tblInfo); err != nil {
+					return err
+				}
 			}
-			if ttlCronJobSchedule != nil {
-				return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
+			if opts.archiveTo != nil && opts.archiveTo.Table.L != "" {
+				tblInfo.TTLInfo.ArchiveTo = opts.archiveTo
+				if err = checkTTLArchiveTable(ctx, ident.Schema, tblInfo); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -6219,7 +6333,8 @@ func (d *ddl) AlterTableTTLInfoOrEnable(ctx sessionctx.Context, ident ast.Ident,
 		TableName:  tableName,
 		Type:       model.ActionAlterTTLInfo,
 		BinlogInfo: &model.HistoryInfo{},
-		Args:       []interface{}{ttlInfo, ttlEnable, ttlCronJobSchedule},
+		Args: []interface{}{opts.info, opts.enable, opts.jobInterval, opts.filter, opts.archiveTo, opts.jobWindow,
+			opts.deleteRate},
 	}
 
 	err = d.DoDDLJob(ctx, job)
@@ -6552,6 +6667,9 @@ func (d *ddl) dropTableObject(
 			if referredFK := checkTableHasForeignKeyReferred(is, tn.Schema.L, tn.Name.L, objectIdents, fkCheck); referredFK != nil {
 				return errors.Trace(dbterror.ErrForeignKeyCannotDropParent.GenWithStackByArgs(tn.Name, referredFK.ChildFKName, referredFK.ChildTable))
 			}
+			if err := checkTTLArchiveTableInUse(is, tn.Schema, tn.Name, objectIdents); err != nil {
+				return err
+			}
 		}
 	case viewObject:
 		dropExistErr = infoschema.ErrTableDropExists
@@ -6608,6 +6726,9 @@ func (d *ddl) dropTableObject(
 			if tableInfo.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 				return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Drop Table")
 			}
+			if err = checkMViewTable(is, schema.Name, tableInfo.Meta()); err != nil {
+				return err
+			}
 		case viewObject:
 			if !tableInfo.Meta().IsView() {
 				return dbterror.ErrWrongObject.GenWithStackByArgs(fullti.Schema, fullti.Name, "VIEW")
@@ -6676,6 +6797,308 @@ func (d *ddl) DropView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err err
 	return d.dropTableObject(ctx, stmt.Tables, stmt.IfExists, viewObject)
 }
 
+// CreateTrigger creates a row-level trigger, the trigger is stored in the meta of its subject table.
+func (d *ddl) CreateTrigger(ctx sessionctx.Context, s *ast.CreateTriggerStmt) error {
+	if s.TriggerName.Schema.L != s.Table.Schema.L {
+		return errors.Trace(dbterror.ErrTrgInWrongSchema)
+	}
+	if util.IsMemOrSysDB(s.Table.Schema.L) {
+		return errors.Trace(dbterror.ErrNoTriggersOnSystemSchema)
+	}
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.Table.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.Table.Schema)
+	}
+	tb, err := is.TableByName(s.Table.Schema, s.Table.Name)
+	if err != nil {
+		return errors.Trace(infoschema.ErrTableNotExists.GenWithStackByArgs(s.Table.Schema, s.Table.Name))
+	}
+	tblInfo := tb.Meta()
+	if tblInfo.IsView() || tblInfo.IsSequence() || tblInfo.TempTableType != model.TempTableNone {
+		return errors.Trace(dbterror.ErrTrgOnViewOrTempTable.GenWithStackByArgs(s.Table.Name.O))
+	}
+	if findTrigger(is, schema.Name, s.TriggerName.Name) != nil {
+		err = dbterror.ErrTrgAlreadyExists.GenWithStackByArgs()
+		if s.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	sqlMode, _ := ctx.GetSessionVars().GetSystemVar(variable.SQLModeVar)
+	charsetClient, _ := ctx.GetSessionVars().GetSystemVar(variable.CharacterSetClient)
+	_, collationConnection := ctx.GetSessionVars().GetCharsetInfo()
+	triggerInfo := &model.TriggerInfo{
+		Name:       s.TriggerName.Name,
+		Timing:     s.Timing,
+		Event:      s.Event,
+		Definition: s.Body.Text(),
+		Definer:    s.Definer,
+		SQLMode:    sqlMode,
+		Charset:    charsetClient,
+		Collate:    collationConnection,
+		CreatedAt:  time.Now(),
+	}
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionCreateTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{triggerInfo},
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// DropTrigger drops a row-level trigger from its subject table.
+func (d *ddl) DropTrigger(ctx sessionctx.Context, s *ast.DropTriggerStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.TriggerName.Schema)
+	var tblInfo *model.TableInfo
+	if ok {
+		tblInfo = findTrigger(is, schema.Name, s.TriggerName.Name)
+	}
+	if tblInfo == nil {
+		err := dbterror.ErrTrgDoesNotExist.GenWithStackByArgs()
+		if s.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return errors.Trace(err)
+	}
+
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionDropTrigger,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{s.TriggerName.Name},
+	}
+	err := d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// CreateMaterializedView creates a materialized view, its result is stored in a table. If the view is refreshed fast,
+// a log table is created to record the changes of the base table.
+func (d *ddl) CreateMaterializedView(ctx sessionctx.Context, s *ast.CreateMaterializedViewStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.ViewName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ViewName.Schema)
+	}
+	if is.TableExists(schema.Name, s.ViewName.Name) {
+		err := infoschema.ErrTableExists.GenWithStackByArgs(ast.Ident{Schema: schema.Name, Name: s.ViewName.Name})
+		if s.IfNotExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	interval, err := mviewRefreshInterval(s.RefreshInterval, s.RefreshUnit)
+	if err != nil {
+		return err
+	}
+	restoreFlag := format.RestoreStringSingleQuotes | format.RestoreKeyWordUppercase | format.RestoreNameBackQuotes
+	var sb strings.Builder
+	if err := s.Select.Restore(format.NewRestoreCtx(restoreFlag, &sb)); err != nil {
+		return err
+	}
+	mvInfo := &model.MaterializedViewInfo{
+		SelectStmt:      sb.String(),
+		Refresh:         s.Refresh,
+		RefreshInterval: interval,
+	}
+
+	cols := make([]*ast.ColumnDef, len(s.Cols))
+	for i, name := range s.Cols {
+		cols[i] = mviewColumnDef(name, s.ColTypes[i])
+	}
+	tbInfo, err := buildMViewTableInfo(ctx, schema, s.ViewName.Name, cols)
+	if err != nil {
+		return err
+	}
+	tbInfo.MaterializedView = mvInfo
+
+	var logInfo *model.TableInfo
+	var baseSchemaID int64
+	if s.Refresh == model.MViewRefreshFast {
+		base, err := mview.CheckFastRefresh(s.Select)
+		if err != nil {
+			return err
+		}
+		baseSchema, ok := is.SchemaByName(base.Schema)
+		if !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(base.Schema)
+		}
+		baseTbl, err := is.TableByName(base.Schema, base.Name)
+		if err != nil {
+			return err
+		}
+		baseInfo := baseTbl.Meta()
+		if !baseInfo.IsBaseTable() || baseInfo.TempTableType != model.TempTableNone ||
+			baseInfo.MaterializedView != nil || baseInfo.MViewLogOf != 0 || util.IsMemOrSysDB(baseSchema.Name.L) {
+			return dbterror.ErrMViewFastRefreshUnsupported.GenWithStackByArgs(
+				fmt.Sprintf("%s.%s is not a normal table", baseSchema.Name.O, baseInfo.Name.O))
+		}
+		logName := mview.LogTableName(s.ViewName.Name)
+		if err = checkTooLongTable(logName); err != nil {
+			return err
+		}
+		if is.TableExists(schema.Name, logName) {
+			return infoschema.ErrTableExists.GenWithStackByArgs(ast.Ident{Schema: schema.Name, Name: logName})
+		}
+		logCols := make([]*ast.ColumnDef, 0, len(baseInfo.Columns)+1)
+		for _, col := range baseInfo.Cols() {
+			logCols = append(logCols, mviewColumnDef(col.Name, &col.FieldType))
+		}
+		dmlType := types.NewFieldType(mysql.TypeTiny)
+		dmlType.AddFlag(mysql.NotNullFlag)
+		logCols = append(logCols, mviewColumnDef(model.NewCIStr(mview.LogDMLColumn), dmlType))
+		if logInfo, err = buildMViewTableInfo(ctx, schema, logName, logCols); err != nil {
+			return err
+		}
+		mvInfo.BaseTableID = baseInfo.ID
+		baseSchemaID = baseSchema.ID
+	}
+
+	genIDs, err := d.genGlobalIDs(2)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	tbInfo.ID = genIDs[0]
+	if logInfo != nil {
+		logInfo.ID = genIDs[1]
+		logInfo.MViewLogOf = tbInfo.ID
+		mvInfo.LogTableID = logInfo.ID
+	}
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tbInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tbInfo.Name.L,
+		Type:       model.ActionCreateMaterializedView,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{tbInfo, logInfo, baseSchemaID},
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
+// mviewRefreshInterval converts the refresh interval of a materialized view to the format of the timer framework.
+func mviewRefreshInterval(interval uint64, unit ast.TimeUnitType) (string, error) {
+	if interval == 0 {
+		return "", nil
+	}
+	switch unit {
+	case ast.TimeUnitSecond:
+		return fmt.Sprintf("%ds", interval), nil
+	case ast.TimeUnitMinute:
+		return fmt.Sprintf("%dm", interval), nil
+	case ast.TimeUnitHour:
+		return fmt.Sprintf("%dh", interval), nil
+	case ast.TimeUnitDay:
+		return fmt.Sprintf("%dd", interval), nil
+	case ast.TimeUnitWeek:
+		return fmt.Sprintf("%dd", interval*7), nil
+	}
+	return "", dbterror.ErrGeneralUnsupportedDDL.GenWithStackByArgs(fmt.Sprintf("refresh interval in %s", unit.String()))
+}
+
+// mviewColumnDef builds the definition of a column to store the values of the field type.
+func mviewColumnDef(name model.CIStr, ft *types.FieldType) *ast.ColumnDef {
+	tp := ft.Clone()
+	switch tp.GetType() {
+	case mysql.TypeVarString:
+		tp.SetType(mysql.TypeVarchar)
+	case mysql.TypeNull:
+		tp.SetType(mysql.TypeTiny)
+	}
+	if tp.GetFlen() == types.UnspecifiedLength || tp.GetDecimal() == types.UnspecifiedLength {
+		flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+		if tp.GetFlen() == types.UnspecifiedLength {
+			tp.SetFlen(flen)
+		}
+		if tp.GetDecimal() == types.UnspecifiedLength {
+			tp.SetDecimal(decimal)
+		}
+	}
+	if tp.GetType() == mysql.TypeVarchar && (tp.GetFlen() == types.UnspecifiedLength || tp.GetFlen() > mysql.MaxFieldVarCharLength) {
+		tp.SetType(mysql.TypeLongBlob)
+		tp.SetFlen(types.UnspecifiedLength)
+	}
+	tp.SetFlag(tp.GetFlag() & (mysql.UnsignedFlag | mysql.BinaryFlag | mysql.ZerofillFlag))
+	def := &ast.ColumnDef{Name: &ast.ColumnName{Name: name}, Tp: tp}
+	if mysql.HasNotNullFlag(ft.GetFlag()) {
+		def.Options = append(def.Options, &ast.ColumnOption{Tp: ast.ColumnOptionNotNull})
+	}
+	return def
+}
+
+func buildMViewTableInfo(ctx sessionctx.Context, schema *model.DBInfo, name model.CIStr, cols []*ast.ColumnDef) (*model.TableInfo, error) {
+	s := &ast.CreateTableStmt{
+		Table: &ast.TableName{Schema: schema.Name, Name: name},
+		Cols:  cols,
+	}
+	tbInfo, err := BuildTableInfoWithStmt(ctx, s, schema.Charset, schema.Collate, schema.PlacementPolicyRef)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkTableInfoValidWithStmt(ctx, tbInfo, s); err != nil {
+		return nil, err
+	}
+	return tbInfo, nil
+}
+
+// DropMaterializedView drops a materialized view and its log table.
+func (d *ddl) DropMaterializedView(ctx sessionctx.Context, s *ast.DropMaterializedViewStmt) error {
+	is := d.GetInfoSchemaWithInterceptor(ctx)
+	schema, ok := is.SchemaByName(s.ViewName.Schema)
+	tbl, err := is.TableByName(s.ViewName.Schema, s.ViewName.Name)
+	if !ok || err != nil {
+		err = infoschema.ErrTableDropExists.GenWithStackByArgs(ast.Ident{Schema: s.ViewName.Schema, Name: s.ViewName.Name})
+		if s.IfExists {
+			ctx.GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	tblInfo := tbl.Meta()
+	if tblInfo.MaterializedView == nil {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(schema.Name, tblInfo.Name, "MATERIALIZED VIEW")
+	}
+	// The log table and the base table may be moved to other schemas by RENAME TABLE.
+	var logSchemaID, baseSchemaID int64
+	if logTbl, ok := is.TableByID(tblInfo.MaterializedView.LogTableID); ok {
+		logSchema, _ := is.SchemaByTable(logTbl.Meta())
+		logSchemaID = logSchema.ID
+	}
+	if baseTbl, ok := is.TableByID(tblInfo.MaterializedView.BaseTableID); ok {
+		baseSchema, _ := is.SchemaByTable(baseTbl.Meta())
+		baseSchemaID = baseSchema.ID
+	}
+	job := &model.Job{
+		SchemaID:   schema.ID,
+		TableID:    tblInfo.ID,
+		SchemaName: schema.Name.L,
+		TableName:  tblInfo.Name.L,
+		Type:       model.ActionDropMaterializedView,
+		BinlogInfo: &model.HistoryInfo{},
+		Args:       []interface{}{logSchemaID, baseSchemaID},
+	}
+	err = d.DoDDLJob(ctx, job)
+	err = d.callHookOnChanged(job, err)
+	return errors.Trace(err)
+}
+
 func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	schema, tb, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
@@ -6687,6 +7110,9 @@ func (d *ddl) TruncateTable(ctx sessionctx.Context, ti ast.Ident) error {
 	if tb.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Truncate Table")
 	}
+	if err = checkMViewTable(d.GetInfoSchemaWithInterceptor(ctx), schema.Name, tb.Meta()); err != nil {
+		return err
+	}
 	fkCheck := ctx.GetSessionVars().ForeignKeyChecks
 	referredFK := checkTableHasForeignKeyReferred(d.GetInfoSchemaWithInterceptor(ctx), ti.Schema.L, ti.Name.L, []ast.Ident{{Name: ti.Name, Schema: ti.Schema}}, fkCheck)
 	if referredFK != nil {
@@ -6774,6 +7200,9 @@ func (d *ddl) renameTable(ctx sessionctx.Context, oldIdent, newIdent ast.Ident,
 		if tbl.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 			return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Rename Table"))
 		}
+		if err = checkTTLArchiveTableInUse(is, schemas[0].Name, tbl.Meta().Name, nil); err != nil {
+			return err
+		}
 	}
 
 	job := &model.Job{
@@ -6816,6 +7245,9 @@ func (d *ddl) renameTables(ctx sessionctx.Context, oldIdents, newIdents []ast.Id
 			if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 				return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Rename Tables"))
 			}
+			if err = checkTTLArchiveTableInUse(is, schemas[0].Name, t.Meta().Name, nil); err != nil {
+				return err
+			}
 		}
 
 		tableIDs = append(tableIDs, tableID)
@@ -6872,6 +7304,12 @@ func ExtractTblInfos(is infoschema.InfoSchema, oldIdent, newIdent ast.Ident, isA
 			return nil, 0, infoschema.ErrForbidSchemaChange.GenWithStackByArgs(oldIdent.Schema, newIdent.Schema)
 		}
 	}
+	// The triggers belong to the schema of their table, so the table with triggers can't be moved to another schema.
+	if oldIdent.Schema.L != newIdent.Schema.L {
+		if tbl, err := is.TableByName(oldIdent.Schema, oldIdent.Name); err == nil && len(tbl.Meta().Triggers) > 0 {
+			return nil, 0, dbterror.ErrTrgInWrongSchema.GenWithStackByArgs()
+		}
+	}
 
 	newSchema, ok := is.SchemaByName(newIdent.Schema)
 	if !ok {
@@ -6964,6 +7402,10 @@ func (d *ddl) CreatePrimaryKey(ctx sessionctx.Context, ti ast.Ident, indexName m
 		return dbterror.ErrTooLongIdent.GenWithStackByArgs(mysql.PrimaryKeyName)
 	}
 
+	if err = checkNotCustomEngineTable(t.Meta(), "primary key"); err != nil {
+		return err
+	}
+
 	indexName = model.NewCIStr(mysql.PrimaryKeyName)
 	if indexInfo := t.Meta().FindIndexByName(indexName.L); indexInfo != nil ||
 		// If the table's PKIsHandle is true, it also means that this table has a primary key.
@@ -7169,21 +7611,39 @@ func (*ddl) addHypoIndexIntoCtx(ctx sessionctx.Context, schemaName, tableName mo
 	return nil
 }
 
+// spatialIndexOption returns the index option of a spatial index, a spatial index is always an R-tree index.
+func spatialIndexOption(indexOption *ast.IndexOption) *ast.IndexOption {
+	if indexOption == nil {
+		indexOption = &ast.IndexOption{}
+	}
+	indexOption.Tp = model.IndexTypeRtree
+	return indexOption
+}
+
 func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.IndexKeyType, indexName model.CIStr,
 	indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption, ifNotExists bool) error {
-	// not support Spatial and FullText index
-	if keyType == ast.IndexKeyTypeFullText || keyType == ast.IndexKeyTypeSpatial {
-		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT and SPATIAL index is not supported")
+	// not support FullText index
+	if keyType == ast.IndexKeyTypeFullText {
+		return dbterror.ErrUnsupportedIndexType.GenWithStack("FULLTEXT index is not supported")
 	}
 	unique := keyType == ast.IndexKeyTypeUnique
 	schema, t, err := d.getSchemaAndTableByIdent(ctx, ti)
 	if err != nil {
 		return errors.Trace(err)
 	}
+	if keyType == ast.IndexKeyTypeSpatial {
+		if err = checkSpatialIndex(t.Meta().Columns, indexPartSpecifications); err != nil {
+			return errors.Trace(err)
+		}
+		indexOption = spatialIndexOption(indexOption)
+	}
 
 	if t.Meta().TableCacheStatusType != model.TableCacheStatusDisable {
 		return errors.Trace(dbterror.ErrOptOnCacheTable.GenWithStackByArgs("Create Index"))
 	}
+	if err = checkNotCustomEngineTable(t.Meta(), "index"); err != nil {
+		return err
+	}
 	// Deal with anonymous index.
 	if len(indexName.L) == 0 {
 		colName := model.NewCIStr("expression_index")
@@ -7233,9 +7693,13 @@ func (d *ddl) createIndex(ctx sessionctx.Context, ti ast.Ident, keyType ast.Inde
 	// After DDL job is put to the queue, and if the check fail, TiDB will run the DDL cancel logic.
 	// The recover step causes DDL wait a few seconds, makes the unit test painfully slow.
 	// For same reason, decide whether index is global here.
-	indexColumns, _, err := buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
-	if err != nil {
-		return errors.Trace(err)
+	// The spatial index has been checked by checkSpatialIndex.
+	var indexColumns []*model.IndexColumn
+	if keyType != ast.IndexKeyTypeSpatial {
+		indexColumns, _, err = buildIndexColumns(ctx, finalColumns, indexPartSpecifications)
+		if err != nil {
+			return errors.Trace(err)
+		}
 	}
 
 	global := false
@@ -8459,7 +8923,7 @@ func (d *ddl) AddResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceG
 		return infoschema.ErrResourceGroupExists.GenWithStackByArgs(groupName)
 	}
 
-	if err := d.checkResourceGroupValidation(groupInfo); err != nil {
+	if err := d.checkResourceGroupValidation(d.GetInfoSchemaWithInterceptor(ctx), groupInfo); err != nil {
 		return err
 	}
 
@@ -8481,9 +8945,18 @@ func (d *ddl) AddResourceGroup(ctx sessionctx.Context, stmt *ast.CreateResourceG
 	return err
 }
 
-func (*ddl) checkResourceGroupValidation(groupInfo *model.ResourceGroupInfo) error {
+func (*ddl) checkResourceGroupValidation(is infoschema.InfoSchema, groupInfo *model.ResourceGroupInfo) error {
 	_, err := resourcegroup.NewGroupFromOptions(groupInfo.Name.L, groupInfo.ResourceGroupSettings)
-	return err
+	if err != nil {
+		return err
+	}
+	// The runaway queries can only be switched to an existing resource group.
+	if runaway := groupInfo.Runaway; runaway != nil && runaway.Action == model.RunawayActionSwitchGroup {
+		if _, ok := is.ResourceGroupByName(model.NewCIStr(runaway.SwitchGroupName)); !ok {
+			return infoschema.ErrResourceGroupNotExists.GenWithStackByArgs(runaway.SwitchGroupName)
+		}
+	}
+	return nil
 }
 
 // DropResourceGroup implements the DDL interface.
@@ -8514,6 +8987,25 @@ func (d *ddl) DropResourceGroup(ctx sessionctx.Context, stmt *ast.DropResourceGr
 		err = errors.Errorf("user [%s] depends on the resource group to drop", user)
 		return err
 	}
+	// check to see if the runaway queries of other groups or the active watches are switched to the group
+	for _, other := range is.AllResourceGroups() {
+		if runaway := other.Runaway; runaway != nil && runaway.Action == model.RunawayActionSwitchGroup &&
+			runaway.SwitchGroupName == groupName.L {
+			err = errors.Errorf("resource group [%s] depends on the resource group to drop", other.Name.O)
+			return err
+		}
+	}
+	internalCtx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnDDL)
+	rows, _, err := ctx.(sqlexec.RestrictedSQLExecutor).ExecRestrictedSQL(internalCtx, nil,
+		"select id from mysql.tidb_runaway_watch where switch_group_name = %? and (end_time is null or end_time > UTC_TIMESTAMP(6)) limit 1",
+		groupName.L)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	if len(rows) > 0 {
+		err = errors.Errorf("runaway watch [%d] depends on the resource group to drop", rows[0].GetInt64(0))
+		return err
+	}
 
 	job := &model.Job{
 		SchemaID:   group.ID,
@@ -8561,7 +9053,7 @@ func (d *ddl) AlterResourceGroup(ctx sessionctx.Context, stmt *ast.AlterResource
 		return errors.Trace(err)
 	}
 
-	if err := d.checkResourceGroupValidation(newGroupInfo); err != nil {
+	if err := d.checkResourceGroupValidation(is, newGroupInfo); err != nil {
 		return err
 	}
 
@@ -8690,6 +9182,10 @@ func (d *ddl) AlterTableCache(sctx sessionctx.Context, ti ast.Ident) (err error)
 		return dbterror.ErrOptOnTemporaryTable.GenWithStackByArgs("alter temporary table cache")
 	}
 
+	if err = checkNotCustomEngineTable(t.Meta(), "cache"); err != nil {
+		return err
+	}
+
 	if t.Meta().Partition != nil {
 		return dbterror.ErrOptOnCacheTable.GenWithStackByArgs("partition mode")
 	}
@@ -558,7 +558,7 @@ func jobNeedGC(job *model.Job) bool {
 			model.ActionDropColumn, model.ActionModifyColumn,
 			model.ActionAddIndex, model.ActionAddPrimaryKey,
 			model.ActionReorganizePartition, model.ActionRemovePartitioning,
-			model.ActionAlterTablePartitioning:
+			model.ActionAlterTablePartitioning, model.ActionDropMaterializedView:
 			return true
 		case model.ActionMultiSchemaChange:
 			for _, sub := range job.MultiSchemaInfo.SubJobs {
@@ -1145,6 +1145,14 @@ func (w *worker) runDDLJob(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64,
 		ver, err = onTTLInfoChange(d, t, job)
 	case model.ActionAlterTTLRemove:
 		ver, err = onTTLInfoRemove(d, t, job)
+	case model.ActionCreateTrigger:
+		ver, err = onCreateTrigger(d, t, job)
+	case model.ActionDropTrigger:
+		ver, err = onDropTrigger(d, t, job)
+	case model.ActionCreateMaterializedView:
+		ver, err = onCreateMaterializedView(d, t, job)
+	case model.ActionDropMaterializedView:
+		ver, err = onDropMaterializedView(d, t, job)
 	case model.ActionAddCheckConstraint:
 		ver, err = w.onAddCheckConstraint(d, t, job)
 	case model.ActionDropCheckConstraint:
@@ -1515,6 +1523,17 @@ func updateSchemaVersion(d *ddlCtx, t *meta.Meta, job *model.Job, multiInfos ...
 				OldTableID:  recoverTabsInfo[i].TableInfo.ID,
 			}
 		}
+	case model.ActionCreateMaterializedView, model.ActionDropMaterializedView:
+		// The view, its log table and its base table are changed together, the dropped tables
+		// only have the old table IDs.
+		if job.Type == model.ActionCreateMaterializedView {
+			diff.TableID = job.TableID
+		} else {
+			diff.OldTableID = job.TableID
+		}
+		if len(job.CtxVars) > 0 {
+			diff.AffectedOpts = job.CtxVars[0].([]*model.AffectedOption)
+		}
 	case model.ActionFlashbackCluster:
 		diff.TableID = -1
 		if job.SchemaState == model.StatePublic {
@@ -313,6 +313,20 @@ func insertJobIntoDeleteRangeTable(ctx context.Context, sctx sessionctx.Context,
 		endKey := tablecodec.EncodeTablePrefix(tableID + 1)
 		elemID := ea.allocForPhysicalID(tableID)
 		return doInsert(ctx, s, job.ID, elemID, startKey, endKey, now, fmt.Sprintf("table ID is %d", tableID))
+	case model.ActionDropMaterializedView:
+		var logSchemaID, baseSchemaID int64
+		var tableIDs []int64
+		if err := job.DecodeArgs(&logSchemaID, &baseSchemaID, &tableIDs); err != nil {
+			return errors.Trace(err)
+		}
+		for _, tableID := range tableIDs {
+			startKey := tablecodec.EncodeTablePrefix(tableID)
+			endKey := tablecodec.EncodeTablePrefix(tableID + 1)
+			elemID := ea.allocForPhysicalID(tableID)
+			if err := doInsert(ctx, s, job.ID, elemID, startKey, endKey, now, fmt.Sprintf("table ID is %d", tableID)); err != nil {
+				return errors.Trace(err)
+			}
+		}
 	case model.ActionDropTablePartition, model.ActionTruncateTablePartition,
 		model.ActionReorganizePartition, model.ActionRemovePartitioning,
 		model.ActionAlterTablePartitioning:
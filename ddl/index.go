@@ -89,6 +89,10 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 			return nil, false, dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
 		}
 
+		// A geometry column can only be indexed by a spatial index.
+		if col.GetType() == mysql.TypeGeometry {
+			return nil, false, dbterror.ErrBlobKeyWithoutLength.GenWithStackByArgs(col.Name.O)
+		}
 		if err := checkIndexColumn(ctx, col, ip.Length); err != nil {
 			return nil, false, err
 		}
@@ -132,6 +136,45 @@ func buildIndexColumns(ctx sessionctx.Context, columns []*model.ColumnInfo, inde
 	return idxParts, mvIndex, nil
 }
 
+// checkSpatialIndex checks the specification of a spatial index is valid,
+// a spatial index must be on a single NOT NULL geometry column.
+func checkSpatialIndex(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification) error {
+	if len(indexPartSpecifications) != 1 {
+		return dbterror.ErrTooManyKeyParts.GenWithStackByArgs(1)
+	}
+	ip := indexPartSpecifications[0]
+	if ip.Expr != nil {
+		return dbterror.ErrSpatialFunctionalIndex
+	}
+	col := model.FindColumnInfo(columns, ip.Column.Name.L)
+	if col == nil {
+		return dbterror.ErrKeyColumnDoesNotExits.GenWithStack("column does not exist: %s", ip.Column.Name)
+	}
+	if col.GetType() != mysql.TypeGeometry {
+		return dbterror.ErrSpatialMustHaveGeomCol
+	}
+	if ip.Length != types.UnspecifiedLength {
+		return dbterror.ErrIncorrectPrefixKey
+	}
+	if !mysql.HasNotNullFlag(col.GetFlag()) {
+		return dbterror.ErrSpatialCantHaveNull
+	}
+	return nil
+}
+
+// isSpatialIndex returns whether the index to build is a spatial index, that is an R-tree index on a geometry column.
+func isSpatialIndex(columns []*model.ColumnInfo, indexPartSpecifications []*ast.IndexPartSpecification, indexOption *ast.IndexOption) bool {
+	if indexOption == nil || indexOption.Tp != model.IndexTypeRtree || len(indexPartSpecifications) == 0 {
+		return false
+	}
+	ip := indexPartSpecifications[0]
+	if ip.Column == nil {
+		return false
+	}
+	col := model.FindColumnInfo(columns, ip.Column.Name.L)
+	return col != nil && col.GetType() == mysql.TypeGeometry
+}
+
 // CheckPKOnGeneratedColumn checks the specification of PK is valid.
 func CheckPKOnGeneratedColumn(tblInfo *model.TableInfo, indexPartSpecifications []*ast.IndexPartSpecification) (*model.ColumnInfo, error) {
 	var lastCol *model.ColumnInfo
@@ -281,9 +324,23 @@ func BuildIndexInfo(
 		return nil, errors.Trace(err)
 	}
 
-	idxColumns, mvIndex, err := buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
-	if err != nil {
-		return nil, errors.Trace(err)
+	var (
+		idxColumns []*model.IndexColumn
+		mvIndex    bool
+		err        error
+	)
+	spatial := isSpatialIndex(allTableColumns, indexPartSpecifications, indexOption)
+	if spatial {
+		if err = checkSpatialIndex(allTableColumns, indexPartSpecifications); err != nil {
+			return nil, errors.Trace(err)
+		}
+		col := model.FindColumnInfo(allTableColumns, indexPartSpecifications[0].Column.Name.L)
+		idxColumns = []*model.IndexColumn{{Name: col.Name, Offset: col.Offset, Length: types.UnspecifiedLength}}
+	} else {
+		idxColumns, mvIndex, err = buildIndexColumns(ctx, allTableColumns, indexPartSpecifications)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
 	}
 
 	// Create index info.
@@ -295,6 +352,7 @@ func BuildIndexInfo(
 		Unique:  isUnique,
 		Global:  isGlobal,
 		MVIndex: mvIndex,
+		Spatial: spatial,
 	}
 
 	if indexOption != nil {
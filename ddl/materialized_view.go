@@ -0,0 +1,171 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/ddl/util"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// checkMViewTable checks whether the table can be dropped or truncated directly. The tables of the materialized
+// views and the log tables are maintained by the views, and the base tables with log tables can't lose the changes.
+func checkMViewTable(is infoschema.InfoSchema, schema model.CIStr, tblInfo *model.TableInfo) error {
+	if tblInfo.MaterializedView != nil || tblInfo.MViewLogOf != 0 {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(schema, tblInfo.Name, "BASE TABLE")
+	}
+	for _, id := range tblInfo.MViewLogTableIDs {
+		logTbl, ok := is.TableByID(id)
+		if !ok {
+			continue
+		}
+		if mv, ok := is.TableByID(logTbl.Meta().MViewLogOf); ok {
+			return dbterror.ErrMViewBaseTableInUse.GenWithStackByArgs(tblInfo.Name, mv.Meta().Name)
+		}
+	}
+	return nil
+}
+
+func onCreateMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	schemaID := job.SchemaID
+	tbInfo, logInfo := &model.TableInfo{}, &model.TableInfo{}
+	var baseSchemaID int64
+	if err := job.DecodeArgs(tbInfo, &logInfo, &baseSchemaID); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tbInfo.State = model.StatePublic
+	if err = checkTableNotExists(d, t, schemaID, tbInfo.Name.L); err != nil {
+		if infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableExists.Equal(err) {
+			job.State = model.JobStateCancelled
+		}
+		return ver, errors.Trace(err)
+	}
+	var baseInfo *model.TableInfo
+	if logInfo != nil {
+		logInfo.State = model.StatePublic
+		if err = checkTableNotExists(d, t, schemaID, logInfo.Name.L); err != nil {
+			if infoschema.ErrDatabaseNotExists.Equal(err) || infoschema.ErrTableExists.Equal(err) {
+				job.State = model.JobStateCancelled
+			}
+			return ver, errors.Trace(err)
+		}
+		baseInfo, err = getTableInfo(t, tbInfo.MaterializedView.BaseTableID, baseSchemaID)
+		if err != nil {
+			job.State = model.JobStateCancelled
+			return ver, errors.Trace(err)
+		}
+	}
+
+	if err = createTableOrViewWithCheck(t, job, schemaID, tbInfo); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if baseInfo != nil {
+		if err = createTableOrViewWithCheck(t, job, schemaID, logInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+		// The base table records its log tables, so that the DML on it can write the changes.
+		baseInfo.MViewLogTableIDs = append(baseInfo.MViewLogTableIDs, logInfo.ID)
+		if err = updateTable(t, baseSchemaID, baseInfo); err != nil {
+			return ver, errors.Trace(err)
+		}
+		job.CtxVars = []interface{}{[]*model.AffectedOption{
+			{SchemaID: schemaID, TableID: logInfo.ID},
+			{SchemaID: baseSchemaID, TableID: baseInfo.ID, OldSchemaID: baseSchemaID, OldTableID: baseInfo.ID},
+		}}
+	}
+
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tbInfo)
+	asyncNotifyEvent(d, &util.Event{Tp: model.ActionCreateTable, TableInfo: tbInfo})
+	if baseInfo != nil {
+		asyncNotifyEvent(d, &util.Event{Tp: model.ActionCreateTable, TableInfo: logInfo})
+	}
+	return ver, nil
+}
+
+func onDropMaterializedView(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	schemaID := job.SchemaID
+	var logSchemaID, baseSchemaID int64
+	if err := job.DecodeArgs(&logSchemaID, &baseSchemaID); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+	tblInfo, err := checkTableExistAndCancelNonExistJob(t, job, schemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if tblInfo.MaterializedView == nil {
+		job.State = model.JobStateCancelled
+		return ver, dbterror.ErrWrongObject.GenWithStackByArgs(job.SchemaName, tblInfo.Name, "MATERIALIZED VIEW")
+	}
+
+	affects := make([]*model.AffectedOption, 0, 2)
+	tableIDs := []int64{tblInfo.ID}
+	if logID := tblInfo.MaterializedView.LogTableID; logID != 0 {
+		// The base table may be dropped with its schema, it's not an error.
+		if baseInfo, err := getTableInfo(t, tblInfo.MaterializedView.BaseTableID, baseSchemaID); err == nil {
+			for i, id := range baseInfo.MViewLogTableIDs {
+				if id == logID {
+					baseInfo.MViewLogTableIDs = append(baseInfo.MViewLogTableIDs[:i], baseInfo.MViewLogTableIDs[i+1:]...)
+					break
+				}
+			}
+			if len(baseInfo.MViewLogTableIDs) == 0 {
+				baseInfo.MViewLogTableIDs = nil
+			}
+			if err = updateTable(t, baseSchemaID, baseInfo); err != nil {
+				return ver, errors.Trace(err)
+			}
+			affects = append(affects, &model.AffectedOption{
+				SchemaID: baseSchemaID, TableID: baseInfo.ID, OldSchemaID: baseSchemaID, OldTableID: baseInfo.ID,
+			})
+		}
+		if logSchemaID != 0 {
+			if err = t.DropTableOrView(logSchemaID, logID); err != nil {
+				return ver, errors.Trace(err)
+			}
+			if err = t.GetAutoIDAccessors(logSchemaID, logID).Del(); err != nil {
+				return ver, errors.Trace(err)
+			}
+			affects = append(affects, &model.AffectedOption{SchemaID: logSchemaID, OldSchemaID: logSchemaID, OldTableID: logID})
+			tableIDs = append(tableIDs, logID)
+		}
+	}
+	if err = t.DropTableOrView(schemaID, tblInfo.ID); err != nil {
+		return ver, errors.Trace(err)
+	}
+	if err = t.GetAutoIDAccessors(schemaID, tblInfo.ID).Del(); err != nil {
+		return ver, errors.Trace(err)
+	}
+
+	job.CtxVars = []interface{}{affects}
+	ver, err = updateSchemaVersion(d, t, job)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	tblInfo.State = model.StateNone
+	job.FinishTableJob(model.JobStateDone, model.StateNone, ver, tblInfo)
+	// Set the IDs of the dropped tables to delete their ranges.
+	job.Args = append(job.Args, tableIDs)
+	return ver, nil
+}
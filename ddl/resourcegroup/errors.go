@@ -35,4 +35,6 @@ var (
 	ErrInvalidResourceGroupRunawayExecElapsedTime = errors.New("invalid exec elapsed time")
 	// ErrUnknownResourceGroupRunawayAction is from group.go.
 	ErrUnknownResourceGroupRunawayAction = errors.New("unknown resource group runaway action")
+	// ErrInvalidResourceGroupRunawaySwitchGroup is from group.go.
+	ErrInvalidResourceGroupRunawaySwitchGroup = errors.New("can not switch the runaway queries to the resource group itself")
 )
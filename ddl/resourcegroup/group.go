@@ -40,7 +40,10 @@ func NewGroupFromOptions(groupName string, options *model.ResourceGroupSettings)
 		runaway := &rmpb.RunawaySettings{
 			Rule: &rmpb.RunawayRule{},
 		}
-		if options.Runaway.ExecElapsedTimeMs == 0 {
+		// The processed keys, RU and memory rules are only checked by TiDB, so at least one of
+		// the rules should be specified.
+		if options.Runaway.ExecElapsedTimeMs == 0 && options.Runaway.ProcessedKeys == 0 &&
+			options.Runaway.RequestUnit == 0 && options.Runaway.MemoryBytes == 0 {
 			return nil, ErrInvalidResourceGroupRunawayExecElapsedTime
 		}
 		runaway.Rule.ExecElapsedTimeMs = options.Runaway.ExecElapsedTimeMs
@@ -48,6 +51,14 @@ func NewGroupFromOptions(groupName string, options *model.ResourceGroupSettings)
 			return nil, ErrUnknownResourceGroupRunawayAction
 		}
 		runaway.Action = rmpb.RunawayAction(options.Runaway.Action)
+		if options.Runaway.Action == model.RunawayActionSwitchGroup {
+			if options.Runaway.SwitchGroupName == groupName {
+				return nil, ErrInvalidResourceGroupRunawaySwitchGroup
+			}
+			// Resource manager doesn't know SWITCH_GROUP, the queries are switched by TiDB with the runaway
+			// settings in schema, so it only records the runaway queries.
+			runaway.Action = rmpb.RunawayAction_DryRun
+		}
 		if options.Runaway.WatchType != model.WatchNone {
 			runaway.Watch = &rmpb.RunawayWatch{}
 			runaway.Watch.Type = rmpb.RunawayWatchType(options.Runaway.WatchType)
@@ -99,6 +99,13 @@ func expectedDeleteRangeCnt(ctx delRangeCntCtx, job *model.Job) (int, error) {
 			return 0, errors.Trace(err)
 		}
 		return len(physicalTableIDs) + 1, nil
+	case model.ActionDropMaterializedView:
+		var logSchemaID, baseSchemaID int64
+		var tableIDs []int64
+		if err := job.DecodeArgs(&logSchemaID, &baseSchemaID, &tableIDs); err != nil {
+			return 0, errors.Trace(err)
+		}
+		return len(tableIDs), nil
 	case model.ActionDropTablePartition, model.ActionTruncateTablePartition,
 		model.ActionReorganizePartition, model.ActionRemovePartitioning,
 		model.ActionAlterTablePartitioning:
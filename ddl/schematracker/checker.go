@@ -297,6 +297,28 @@ func (d *Checker) DropView(ctx sessionctx.Context, stmt *ast.DropTableStmt) (err
 	return nil
 }
 
+// CreateTrigger implements the DDL interface.
+// The triggers are not tracked by the SchemaTracker, and they do not affect SHOW CREATE TABLE.
+func (d *Checker) CreateTrigger(ctx sessionctx.Context, stmt *ast.CreateTriggerStmt) error {
+	return d.realDDL.CreateTrigger(ctx, stmt)
+}
+
+// DropTrigger implements the DDL interface.
+func (d *Checker) DropTrigger(ctx sessionctx.Context, stmt *ast.DropTriggerStmt) error {
+	return d.realDDL.DropTrigger(ctx, stmt)
+}
+
+// CreateMaterializedView implements the DDL interface.
+// The materialized views are not tracked by the SchemaTracker.
+func (d *Checker) CreateMaterializedView(ctx sessionctx.Context, stmt *ast.CreateMaterializedViewStmt) error {
+	return d.realDDL.CreateMaterializedView(ctx, stmt)
+}
+
+// DropMaterializedView implements the DDL interface.
+func (d *Checker) DropMaterializedView(ctx sessionctx.Context, stmt *ast.DropMaterializedViewStmt) error {
+	return d.realDDL.DropMaterializedView(ctx, stmt)
+}
+
 // CreateIndex implements the DDL interface.
 func (d *Checker) CreateIndex(ctx sessionctx.Context, stmt *ast.CreateIndexStmt) error {
 	err := d.realDDL.CreateIndex(ctx, stmt)
@@ -387,6 +387,13 @@ func (d SchemaTracker) createIndex(
 	ifNotExists bool,
 ) (err error) {
 	unique := keyType == ast.IndexKeyTypeUnique
+	if keyType == ast.IndexKeyTypeSpatial {
+		// A spatial index is always an R-tree index.
+		if indexOption == nil {
+			indexOption = &ast.IndexOption{}
+		}
+		indexOption.Tp = model.IndexTypeRtree
+	}
 	tblInfo, err := d.TableClonedByName(ti.Schema, ti.Name)
 	if err != nil {
 		return err
@@ -943,6 +950,9 @@ func (d SchemaTracker) AlterTable(ctx context.Context, sctx sessionctx.Context,
 			case ast.ConstraintUniq, ast.ConstraintUniqIndex, ast.ConstraintUniqKey:
 				err = d.createIndex(sctx, ident, ast.IndexKeyTypeUnique, model.NewCIStr(constr.Name),
 					spec.Constraint.Keys, constr.Option, false) // IfNotExists should be not applied
+			case ast.ConstraintSpatial:
+				err = d.createIndex(sctx, ident, ast.IndexKeyTypeSpatial, model.NewCIStr(constr.Name),
+					spec.Constraint.Keys, constr.Option, constr.IfNotExists)
 			case ast.ConstraintPrimaryKey:
 				err = d.createPrimaryKey(sctx, ident, model.NewCIStr(constr.Name), spec.Constraint.Keys, constr.Option)
 			case ast.ConstraintForeignKey,
@@ -1168,6 +1178,26 @@ func (SchemaTracker) AlterPlacementPolicy(_ sessionctx.Context, _ *ast.AlterPlac
 	return nil
 }
 
+// CreateTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateTrigger(_ sessionctx.Context, _ *ast.CreateTriggerStmt) error {
+	return nil
+}
+
+// DropTrigger implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropTrigger(_ sessionctx.Context, _ *ast.DropTriggerStmt) error {
+	return nil
+}
+
+// CreateMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) CreateMaterializedView(_ sessionctx.Context, _ *ast.CreateMaterializedViewStmt) error {
+	return nil
+}
+
+// DropMaterializedView implements the DDL interface, it's no-op in DM's case.
+func (SchemaTracker) DropMaterializedView(_ sessionctx.Context, _ *ast.DropMaterializedViewStmt) error {
+	return nil
+}
+
 // AddResourceGroup implements the DDL interface, it's no-op in DM's case.
 func (SchemaTracker) AddResourceGroup(_ sessionctx.Context, _ *ast.CreateResourceGroupStmt) error {
 	return nil
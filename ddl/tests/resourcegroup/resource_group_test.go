@@ -17,6 +17,7 @@ package resourcegrouptest_test
 import (
 	"context"
 	"fmt"
+	"sync"
 	"sync/atomic"
 	"testing"
 	"time"
@@ -33,6 +34,8 @@ import (
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikvrpc"
+	"github.com/tikv/client-go/v2/tikvrpc/interceptor"
 )
 
 func TestResourceGroupBasic(t *testing.T) {
@@ -171,6 +174,7 @@ func TestResourceGroupBasic(t *testing.T) {
 	tk.MustContainErrMsg("create resource group x ru_per_sec=1000 QUERY_LIMIT=(EXEC_ELAPSED='15s')", "unknown resource group runaway action")
 	tk.MustGetErrCode("create resource group x ru_per_sec=1000 EXEC_ELAPSED='15s' action kill", mysql.ErrParse)
 	tk.MustContainErrMsg("create resource group x ru_per_sec=1000 QUERY_LIMIT=(EXEC_ELAPSED='15d' action kill)", "unknown unit \"d\"")
+	tk.MustContainErrMsg("create resource group x ru_per_sec=1000 QUERY_LIMIT=(MEMORY='1XB' action kill)", "invalid size: '1XB'")
 	groups, err := infosync.ListResourceGroups(context.TODO())
 	re.Equal(1, len(groups))
 	re.NoError(err)
@@ -198,6 +202,14 @@ func TestResourceGroupBasic(t *testing.T) {
 	tk.MustExec("CREATE RESOURCE GROUP `z` RU_PER_SEC=2000 PRIORITY=MEDIUM QUERY_LIMIT=(EXEC_ELAPSED=\"1s\" ACTION=COOLDOWN WATCH PLAN DURATION=\"1h0m0s\")")
 	tk.MustQuery("select * from information_schema.resource_groups where name = 'z'").Check(testkit.Rows("z 2000 MEDIUM NO EXEC_ELAPSED='1s', ACTION=COOLDOWN, WATCH=PLAN DURATION='1h0m0s' <nil>"))
 
+	tk.MustExec("create resource group w RU_PER_SEC=2000 QUERY_LIMIT=(PROCESSED_KEYS=10000 RU=500 MEMORY='1MiB' ACTION=KILL)")
+	tk.MustQuery("select * from information_schema.resource_groups where name = 'w'").Check(testkit.Rows("w 2000 MEDIUM NO PROCESSED_KEYS=10000, RU=500, MEMORY=1048576, ACTION=KILL <nil>"))
+	tk.MustQuery("show create resource group w").Check(testkit.Rows("w CREATE RESOURCE GROUP `w` RU_PER_SEC=2000, PRIORITY=MEDIUM, QUERY_LIMIT=(PROCESSED_KEYS=10000 RU=500 MEMORY=1048576 ACTION=KILL)"))
+	tk.MustExec("alter resource group w QUERY_LIMIT=(EXEC_ELAPSED='1s' MEMORY=1024 ACTION=DRYRUN WATCH SIMILAR)")
+	tk.MustQuery("select * from information_schema.resource_groups where name = 'w'").Check(testkit.Rows("w 2000 MEDIUM NO EXEC_ELAPSED='1s', PROCESSED_KEYS=10000, RU=500, MEMORY=1024, ACTION=DRYRUN, WATCH=SIMILAR DURATION=UNLIMITED <nil>"))
+	tk.MustQuery("show create resource group w").Check(testkit.Rows("w CREATE RESOURCE GROUP `w` RU_PER_SEC=2000, PRIORITY=MEDIUM, QUERY_LIMIT=(EXEC_ELAPSED=\"1s\" PROCESSED_KEYS=10000 RU=500 MEMORY=1024 ACTION=DRYRUN WATCH=SIMILAR DURATION=UNLIMITED)"))
+	tk.MustExec("drop resource group w")
+
 	tk.MustExec("alter resource group y RU_PER_SEC=4000")
 	tk.MustQuery("select * from information_schema.resource_groups where name = 'y'").Check(testkit.Rows("y 4000 MEDIUM YES EXEC_ELAPSED='1s', ACTION=COOLDOWN, WATCH=EXACT DURATION='1h0m0s' <nil>"))
 	tk.MustQuery("show create resource group y").Check(testkit.Rows("y CREATE RESOURCE GROUP `y` RU_PER_SEC=4000, PRIORITY=MEDIUM, BURSTABLE, QUERY_LIMIT=(EXEC_ELAPSED=\"1s\" ACTION=COOLDOWN WATCH=EXACT DURATION=\"1h0m0s\")"))
@@ -311,6 +323,124 @@ func TestResourceGroupRunaway(t *testing.T) {
 	tk.MustGetErrCode("select /*+ resource_group(rg3) */ * from t", mysql.ErrResourceGroupQueryRunawayQuarantine)
 }
 
+func TestResourceGroupRunawaySwitchGroup(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "localhost"}, nil, nil, nil))
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int primary key, b int)")
+	tk.MustExec("insert into t values(1, 1), (2, 2)")
+
+	tk.MustExec("set global tidb_enable_resource_control='on'")
+	tk.MustExec("create resource group rg_low RU_PER_SEC=100 PRIORITY=LOW")
+	tk.MustGetErrMsg("create resource group rg1 RU_PER_SEC=1000 QUERY_LIMIT=(EXEC_ELAPSED='50ms' ACTION=SWITCH_GROUP(rg2))",
+		"[schema:8249]Unknown resource group 'rg2'")
+	tk.MustContainErrMsg("create resource group rg1 RU_PER_SEC=1000 QUERY_LIMIT=(EXEC_ELAPSED='50ms' ACTION=SWITCH_GROUP(rg1))",
+		"can not switch the runaway queries to the resource group itself")
+	tk.MustExec("create resource group rg1 RU_PER_SEC=1000 QUERY_LIMIT=(EXEC_ELAPSED='50ms' ACTION=SWITCH_GROUP(rg_low) WATCH EXACT DURATION='10m')")
+	tk.MustQuery("select * from information_schema.resource_groups where name = 'rg1'").Check(testkit.Rows("rg1 1000 MEDIUM NO EXEC_ELAPSED='50ms', ACTION=SWITCH_GROUP(rg_low), WATCH=EXACT DURATION='10m0s' <nil>"))
+
+	// record the resource group which each kind of request is sent with.
+	var mu sync.Mutex
+	groups := make(map[tikvrpc.CmdType]string)
+	ctx := interceptor.WithRPCInterceptor(context.Background(), interceptor.NewRPCInterceptor("record-rg-name", func(next interceptor.RPCInterceptorFunc) interceptor.RPCInterceptorFunc {
+		return func(target string, req *tikvrpc.Request) (*tikvrpc.Response, error) {
+			mu.Lock()
+			groups[req.Type] = req.GetResourceControlContext().GetResourceGroupName()
+			mu.Unlock()
+			return next(target, req)
+		}
+	}))
+	checkGroup := func(cmd tikvrpc.CmdType, expected string) {
+		mu.Lock()
+		defer mu.Unlock()
+		require.Equal(t, expected, groups[cmd], cmd.String())
+	}
+	resetGroups := func() {
+		mu.Lock()
+		defer mu.Unlock()
+		groups = make(map[tikvrpc.CmdType]string)
+	}
+
+	tk.MustExec("set resource group rg1")
+	tk.MustQueryWithContext(ctx, "select * from t where a = 1").Check(testkit.Rows("1 1"))
+	checkGroup(tikvrpc.CmdGet, "rg1")
+
+	// the query is switched to rg_low by the rule once it's identified as runaway.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/store/copr/sleepCoprRequest", fmt.Sprintf("return(%d)", 60)))
+	tk.MustQueryWithContext(ctx, "select * from t where b > 0 order by a").Check(testkit.Rows("1 1", "2 2"))
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/store/copr/sleepCoprRequest"))
+	checkGroup(tikvrpc.CmdCop, "rg_low")
+	tryInterval := time.Millisecond * 200
+	maxWaitDuration := time.Second * 5
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE resource_group_name, original_sql, match_type, action from mysql.tidb_runaway_queries", nil,
+		testkit.Rows("rg1 select * from t where b > 0 order by a identify switchgroup"), maxWaitDuration, tryInterval)
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE resource_group_name, watch_text, action, switch_group_name from mysql.tidb_runaway_watch", nil,
+		testkit.Rows("rg1 select * from t where b > 0 order by a 4 rg_low"), maxWaitDuration, tryInterval)
+
+	// the watch items without action inherit the action of the rule, and all the requests of
+	// the watched queries are sent with the resource group which they are switched to.
+	tk.MustExec("query watch add resource group rg1 sql text exact to 'select * from t where a = 2'")
+	tk.MustExec("query watch add resource group rg1 sql text exact to 'select * from t where a in (1, 2)'")
+	tk.MustExec("query watch add resource group rg1 sql text similar to 'insert into t values(3, 3)'")
+	tk.MustExec("query watch add resource group rg1 sql text exact to 'update t set b = b + 1 where a = 1'")
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE count(*) from information_schema.runaway_watches", nil,
+		testkit.Rows("5"), maxWaitDuration, tryInterval)
+	resetGroups()
+	tk.MustQueryWithContext(ctx, "select * from t where a = 2").Check(testkit.Rows("2 2"))
+	checkGroup(tikvrpc.CmdGet, "rg_low")
+	tk.MustQueryWithContext(ctx, "select * from t where a in (1, 2)").Check(testkit.Rows("1 1", "2 2"))
+	checkGroup(tikvrpc.CmdBatchGet, "rg_low")
+	tk.MustExecWithContext(ctx, "insert into t values(3, 3)")
+	checkGroup(tikvrpc.CmdPrewrite, "rg_low")
+	tk.MustExec("begin pessimistic")
+	tk.MustExecWithContext(ctx, "update t set b = b + 1 where a = 1")
+	tk.MustExecWithContext(ctx, "commit")
+	checkGroup(tikvrpc.CmdPessimisticLock, "rg_low")
+	checkGroup(tikvrpc.CmdPrewrite, "rg_low")
+	checkGroup(tikvrpc.CmdCommit, "rg_low")
+	tk.MustQueryWithContext(ctx, "select * from t where a = 3").Check(testkit.Rows("3 3"))
+	checkGroup(tikvrpc.CmdGet, "rg1")
+
+	// the group can't be dropped while the runaway queries of other groups or the active watches are switched to it.
+	tk.MustContainErrMsg("drop resource group rg_low", "resource group [rg1] depends on the resource group to drop")
+	tk.MustExec("alter resource group rg1 QUERY_LIMIT=NULL")
+	tk.MustContainErrMsg("drop resource group rg_low", "depends on the resource group to drop")
+	rows := tk.MustQuery("select SQL_NO_CACHE id from mysql.tidb_runaway_watch where switch_group_name = 'rg_low'").Rows()
+	require.Len(t, rows, 5)
+	for _, row := range rows {
+		tk.MustExec(fmt.Sprintf("query watch remove %v", row[0]))
+	}
+	tk.MustExec("drop resource group rg_low")
+}
+
+func TestResourceGroupRunawayRules(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "localhost"}, nil, nil, nil))
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int)")
+	tk.MustExec("insert into t values(3), (1), (2)")
+
+	tk.MustExec("set global tidb_enable_resource_control='on'")
+	tk.MustExec("create resource group rg1 RU_PER_SEC=1000 QUERY_LIMIT=(MEMORY=1 ACTION=KILL)")
+	tk.MustExec("create resource group rg2 RU_PER_SEC=1000 QUERY_LIMIT=(MEMORY=1 ACTION=DRYRUN)")
+	tk.MustExec("create resource group rg3 RU_PER_SEC=1000 QUERY_LIMIT=(MEMORY='1GiB' PROCESSED_KEYS=1000000 ACTION=KILL)")
+
+	err := tk.QueryToErr("select /*+ resource_group(rg1) */ * from t order by a")
+	require.ErrorContains(t, err, "Query execution was interrupted, identified as runaway query")
+	tk.MustQuery("select /*+ resource_group(rg2) */ * from t order by a").Check(testkit.Rows("1", "2", "3"))
+	tk.MustQuery("select /*+ resource_group(rg3) */ * from t order by a").Check(testkit.Rows("1", "2", "3"))
+
+	tryInterval := time.Millisecond * 200
+	maxWaitDuration := time.Second * 5
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE resource_group_name, original_sql, match_type, action, substring_index(rule, '(', 1) from mysql.tidb_runaway_queries order by resource_group_name", nil,
+		testkit.Rows("rg1 select /*+ resource_group(rg1) */ * from t order by a identify kill Memory = 1",
+			"rg2 select /*+ resource_group(rg2) */ * from t order by a identify dryrun Memory = 1"), maxWaitDuration, tryInterval)
+}
+
 func TestResourceGroupHint(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -0,0 +1,98 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/meta"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// findTrigger returns the subject table of the trigger, or nil if the trigger doesn't exist.
+// The trigger names are unique in a schema.
+func findTrigger(is infoschema.InfoSchema, schema, name model.CIStr) *model.TableInfo {
+	for _, tbl := range is.SchemaTables(schema) {
+		if triggerOffset(tbl.Meta(), name) >= 0 {
+			return tbl.Meta()
+		}
+	}
+	return nil
+}
+
+// triggerOffset returns the offset of the trigger in tblInfo.Triggers, or -1 if it doesn't exist.
+func triggerOffset(tblInfo *model.TableInfo, name model.CIStr) int {
+	for i, trigger := range tblInfo.Triggers {
+		if trigger.Name.L == name.L {
+			return i
+		}
+	}
+	return -1
+}
+
+func onCreateTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	triggerInfo := &model.TriggerInfo{}
+	if err := job.DecodeArgs(triggerInfo); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	if triggerOffset(tblInfo, triggerInfo.Name) >= 0 {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(dbterror.ErrTrgAlreadyExists)
+	}
+
+	tblInfo.Triggers = append(tblInfo.Triggers, triggerInfo)
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
+
+func onDropTrigger(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err error) {
+	var name model.CIStr
+	if err := job.DecodeArgs(&name); err != nil {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(err)
+	}
+
+	tblInfo, err := GetTableInfoAndCancelFaultJob(t, job, job.SchemaID)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	offset := triggerOffset(tblInfo, name)
+	if offset < 0 {
+		job.State = model.JobStateCancelled
+		return ver, errors.Trace(dbterror.ErrTrgDoesNotExist)
+	}
+
+	tblInfo.Triggers = append(tblInfo.Triggers[:offset], tblInfo.Triggers[offset+1:]...)
+	if len(tblInfo.Triggers) == 0 {
+		tblInfo.Triggers = nil
+	}
+	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
+	if err != nil {
+		return ver, errors.Trace(err)
+	}
+	job.FinishTableJob(model.JobStateDone, model.StatePublic, ver, tblInfo)
+	return ver, nil
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
@@ -52,8 +53,13 @@ func onTTLInfoChange(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err er
 	var ttlInfo *model.TTLInfo
 	var ttlInfoEnable *bool
 	var ttlInfoJobInterval *string
+	var ttlInfoFilter *string
+	var ttlInfoArchiveTo *model.TTLArchiveTable
+	var ttlInfoJobWindow *string
+	var ttlInfoDeleteRate *uint64
 
-	if err := job.DecodeArgs(&ttlInfo, &ttlInfoEnable, &ttlInfoJobInterval); err != nil {
+	if err := job.DecodeArgs(&ttlInfo, &ttlInfoEnable, &ttlInfoJobInterval, &ttlInfoFilter, &ttlInfoArchiveTo,
+		&ttlInfoJobWindow, &ttlInfoDeleteRate); err != nil {
 		job.State = model.JobStateCancelled
 		return ver, errors.Trace(err)
 	}
@@ -71,6 +77,18 @@ func onTTLInfoChange(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err er
 		if ttlInfoJobInterval == nil && tblInfo.TTLInfo != nil {
 			ttlInfo.JobInterval = tblInfo.TTLInfo.JobInterval
 		}
+		if ttlInfoFilter == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.FilterExprStr = tblInfo.TTLInfo.FilterExprStr
+		}
+		if ttlInfoArchiveTo == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.ArchiveTo = tblInfo.TTLInfo.ArchiveTo
+		}
+		if ttlInfoJobWindow == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.JobWindow = tblInfo.TTLInfo.JobWindow
+		}
+		if ttlInfoDeleteRate == nil && tblInfo.TTLInfo != nil {
+			ttlInfo.DeleteRate = tblInfo.TTLInfo.DeleteRate
+		}
 		tblInfo.TTLInfo = ttlInfo
 	}
 	if ttlInfoEnable != nil {
@@ -87,6 +105,37 @@ func onTTLInfoChange(d *ddlCtx, t *meta.Meta, job *model.Job) (ver int64, err er
 
 		tblInfo.TTLInfo.JobInterval = *ttlInfoJobInterval
 	}
+	if ttlInfoFilter != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_FILTER"))
+		}
+
+		tblInfo.TTLInfo.FilterExprStr = *ttlInfoFilter
+	}
+	if ttlInfoArchiveTo != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_TO"))
+		}
+
+		tblInfo.TTLInfo.ArchiveTo = nil
+		if ttlInfoArchiveTo.Table.L != "" {
+			tblInfo.TTLInfo.ArchiveTo = ttlInfoArchiveTo
+		}
+	}
+	if ttlInfoJobWindow != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_WINDOW"))
+		}
+
+		tblInfo.TTLInfo.JobWindow = *ttlInfoJobWindow
+	}
+	if ttlInfoDeleteRate != nil {
+		if tblInfo.TTLInfo == nil {
+			return ver, errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_DELETE_RATE"))
+		}
+
+		tblInfo.TTLInfo.DeleteRate = *ttlInfoDeleteRate
+	}
 
 	ver, err = updateVersionAndTableInfo(d, t, job, tblInfo, true)
 	if err != nil {
@@ -105,7 +154,15 @@ func checkTTLInfoValid(ctx sessionctx.Context, schema model.CIStr, tblInfo *mode
 		return err
 	}
 
-	return checkTTLInfoColumnType(tblInfo)
+	if err := checkTTLInfoColumnType(tblInfo); err != nil {
+		return err
+	}
+
+	if err := checkTTLFilterExpr(ctx, tblInfo); err != nil {
+		return err
+	}
+
+	return checkTTLArchiveTable(ctx, schema, tblInfo)
 }
 
 func checkTTLIntervalExpr(ctx sessionctx.Context, ttlInfo *model.TTLInfo) error {
@@ -136,6 +193,145 @@ func checkTTLInfoColumnType(tblInfo *model.TableInfo) error {
 	return nil
 }
 
+// checkTTLFilterExpr checks the filter expression only references the columns in the TTL table
+func checkTTLFilterExpr(ctx sessionctx.Context, tblInfo *model.TableInfo) error {
+	if len(tblInfo.TTLInfo.FilterExprStr) == 0 {
+		return nil
+	}
+
+	_, err := expression.ParseSimpleExprWithTableInfo(ctx, tblInfo.TTLInfo.FilterExprStr, tblInfo)
+	return errors.Trace(err)
+}
+
+// checkTTLArchiveTable checks the archive table exists and is able to accept the rows of the TTL table.
+// The schema of the archive table will be set to the schema of the TTL table if it's not specified.
+func checkTTLArchiveTable(ctx sessionctx.Context, schema model.CIStr, tblInfo *model.TableInfo) error {
+	archiveTo := tblInfo.TTLInfo.ArchiveTo
+	if archiveTo == nil {
+		return nil
+	}
+
+	if archiveTo.Schema.L == "" {
+		archiveTo.Schema = schema
+	}
+
+	archiveName := fmt.Sprintf("%s.%s", archiveTo.Schema.O, archiveTo.Table.O)
+	is := sessiontxn.GetTxnManager(ctx).GetTxnInfoSchema()
+	archiveTbl, err := is.TableByName(archiveTo.Schema, archiveTo.Table)
+	if err != nil {
+		return errors.Trace(err)
+	}
+
+	archiveTblInfo := archiveTbl.Meta()
+	switch {
+	case archiveTblInfo.ID == tblInfo.ID || (archiveTo.Schema.L == schema.L && archiveTo.Table.L == tblInfo.Name.L):
+		return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, "it is the TTL table itself")
+	case archiveTblInfo.IsView() || archiveTblInfo.IsSequence():
+		return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, "it is not a base table")
+	case archiveTblInfo.TempTableType != model.TempTableNone:
+		return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, "it is a temporary table")
+	case archiveTblInfo.TTLInfo != nil:
+		return dbterror.ErrUnsupportedTTLArchiveTable.GenWithStackByArgs(archiveName, "it is a TTL table")
+	}
+
+	return nil
+}
+
+// restoreTTLFilterExpr parses the filter of TTL and restores it to a normalized expression, which could be
+// put into the SQLs of TTL jobs directly.
+func restoreTTLFilterExpr(filter string) (string, error) {
+	if len(filter) == 0 {
+		return "", nil
+	}
+
+	expr, err := parseTTLFilterExpr(filter)
+	if err != nil {
+		return "", err
+	}
+	return restoreTTLFilterExprNode(expr)
+}
+
+func parseTTLFilterExpr(filter string) (ast.ExprNode, error) {
+	stmts, _, err := parser.New().ParseSQL("select " + filter)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+
+	sel, ok := stmts[0].(*ast.SelectStmt)
+	if len(stmts) != 1 || !ok || len(sel.Fields.Fields) != 1 || sel.Fields.Fields[0].Expr == nil ||
+		sel.Fields.Fields[0].AsName.L != "" || sel.From != nil || sel.Where != nil || sel.GroupBy != nil ||
+		sel.Having != nil || sel.WindowSpecs != nil || sel.OrderBy != nil || sel.Limit != nil || sel.LockInfo != nil {
+		return nil, errors.Errorf("The TTL_FILTER option is not a valid expression: %s", filter)
+	}
+	return sel.Fields.Fields[0].Expr, nil
+}
+
+func restoreTTLFilterExprNode(expr ast.ExprNode) (string, error) {
+	var sb strings.Builder
+	restoreCtx := format.NewRestoreCtx(format.RestoreStringSingleQuotes|format.RestoreStringWithoutCharset|format.RestoreNameBackQuotes, &sb)
+	if err := expr.Restore(restoreCtx); err != nil {
+		return "", errors.Trace(err)
+	}
+	return sb.String(), nil
+}
+
+// ttlFilterColumnVisitor finds the column in the TTL filter, and renames it if `newName` is not empty.
+type ttlFilterColumnVisitor struct {
+	name    model.CIStr
+	newName model.CIStr
+	found   bool
+}
+
+// Enter implements ast.Visitor interface.
+func (v *ttlFilterColumnVisitor) Enter(in ast.Node) (ast.Node, bool) {
+	if col, ok := in.(*ast.ColumnName); ok && col.Name.L == v.name.L {
+		v.found = true
+		if v.newName.L != "" {
+			col.Name = v.newName
+		}
+	}
+	return in, false
+}
+
+// Leave implements ast.Visitor interface.
+func (*ttlFilterColumnVisitor) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// renameColumnInTTLFilter returns the TTL filter with the column renamed. The filter is put into the SQLs of TTL
+// jobs verbatim, so it has to follow the renamed column.
+func renameColumnInTTLFilter(filter string, oldName, newName model.CIStr) (string, error) {
+	if len(filter) == 0 {
+		return filter, nil
+	}
+
+	expr, err := parseTTLFilterExpr(filter)
+	if err != nil {
+		return "", err
+	}
+	v := &ttlFilterColumnVisitor{name: oldName, newName: newName}
+	expr.Accept(v)
+	if !v.found {
+		return filter, nil
+	}
+	return restoreTTLFilterExprNode(expr)
+}
+
+// ttlFilterHasColumn returns whether the column is referenced by the TTL filter.
+func ttlFilterHasColumn(filter string, colName string) (bool, error) {
+	if len(filter) == 0 {
+		return false, nil
+	}
+
+	expr, err := parseTTLFilterExpr(filter)
+	if err != nil {
+		return false, err
+	}
+	v := &ttlFilterColumnVisitor{name: model.NewCIStr(colName)}
+	expr.Accept(v)
+	return v.found, nil
+}
+
 // checkTTLTableSuitable returns whether this table is suitable to be a TTL table
 // A temporary table or a parent table referenced by a foreign key cannot be TTL table
 func checkTTLTableSuitable(ctx sessionctx.Context, schema model.CIStr, tblInfo *model.TableInfo) error {
@@ -161,11 +357,46 @@ func checkDropColumnWithTTLConfig(tblInfo *model.TableInfo, colName string) erro
 		if tblInfo.TTLInfo.ColumnName.L == colName {
 			return dbterror.ErrTTLColumnCannotDrop.GenWithStackByArgs(colName)
 		}
+		inFilter, err := ttlFilterHasColumn(tblInfo.TTLInfo.FilterExprStr, colName)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		if inFilter {
+			return dbterror.ErrTTLColumnCannotDrop.GenWithStackByArgs(colName)
+		}
 	}
 
 	return nil
 }
 
+// checkTTLArchiveTableInUse returns an error if the table is the archive table of a TTL table, which is not in
+// `ignoreTables`. The TTL jobs archive the expired rows into the table by its name, so it can't be dropped or renamed.
+func checkTTLArchiveTableInUse(is infoschema.InfoSchema, schema, tblName model.CIStr, ignoreTables []ast.Ident) error {
+	for _, db := range is.AllSchemas() {
+		for _, tbl := range is.SchemaTables(db.Name) {
+			ttlInfo := tbl.Meta().TTLInfo
+			if ttlInfo == nil || ttlInfo.ArchiveTo == nil {
+				continue
+			}
+			if ttlInfo.ArchiveTo.Schema.L != schema.L || ttlInfo.ArchiveTo.Table.L != tblName.L {
+				continue
+			}
+			ignored := false
+			for _, ident := range ignoreTables {
+				if ident.Schema.L == db.Name.L && ident.Name.L == tbl.Meta().Name.L {
+					ignored = true
+					break
+				}
+			}
+			if !ignored {
+				return dbterror.ErrTTLArchiveTableInUse.GenWithStackByArgs(
+					fmt.Sprintf("%s.%s", schema.O, tblName.O), fmt.Sprintf("%s.%s", db.Name.O, tbl.Meta().Name.O))
+			}
+		}
+	}
+	return nil
+}
+
 // We should forbid creating a TTL table with clustered primary key that contains a column with type float/double.
 // This is because currently we are using SQL to delete expired rows and when the primary key contains float/double column,
 // it is hard to use condition `WHERE PK in (...)` to delete specified rows because some precision will be lost when comparing.
@@ -191,11 +422,43 @@ func checkPrimaryKeyForTTLTable(tblInfo *model.TableInfo) error {
 	return nil
 }
 
-// getTTLInfoInOptions returns the aggregated ttlInfo, the ttlEnable, or an error.
-// if TTL, TTL_ENABLE or TTL_JOB_INTERVAL is not set in the config, the corresponding return value will be nil.
-// if both of TTL and TTL_ENABLE are set, the `ttlInfo.Enable` will be equal with `ttlEnable`.
-// if both of TTL and TTL_JOB_INTERVAL are set, the `ttlInfo.JobInterval` will be equal with `ttlCronJobSchedule`.
-func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, ttlEnable *bool, ttlCronJobSchedule *string, err error) {
+// ttlOptions is the TTL related table options in a statement. A field is nil if the corresponding option is not set.
+type ttlOptions struct {
+	// info is the aggregated TTL info, it's not nil only if the TTL option is set.
+	info        *model.TTLInfo
+	enable      *bool
+	jobInterval *string
+	filter      *string
+	// archiveTo with an empty `Table` means the archive table should be removed.
+	archiveTo  *model.TTLArchiveTable
+	jobWindow  *string
+	deleteRate *uint64
+}
+
+// checkForNonTTLTable returns an error if any TTL option other than TTL is set, it's used when the table
+// doesn't have TTL info.
+func (o *ttlOptions) checkForNonTTLTable() error {
+	switch {
+	case o.enable != nil:
+		return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ENABLE"))
+	case o.jobInterval != nil:
+		return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_INTERVAL"))
+	case o.filter != nil:
+		return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_FILTER"))
+	case o.archiveTo != nil:
+		return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_ARCHIVE_TO"))
+	case o.jobWindow != nil:
+		return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_JOB_WINDOW"))
+	case o.deleteRate != nil:
+		return errors.Trace(dbterror.ErrSetTTLOptionForNonTTLTable.FastGenByArgs("TTL_DELETE_RATE"))
+	}
+	return nil
+}
+
+// getTTLInfoInOptions returns the TTL related options in the table options, or an error.
+// if both of TTL and other TTL options are set, the aggregated `ttlInfo` will be set accordingly, e.g. the
+// `ttlInfo.Enable` will be equal with `enable`.
+func getTTLInfoInOptions(options []*ast.TableOption) (opts ttlOptions, err error) {
 	for _, op := range options {
 		switch op.Tp {
 		case ast.TableOptionTTL:
@@ -204,11 +467,11 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 			restoreCtx := format.NewRestoreCtx(restoreFlags, &sb)
 			err := op.Value.Restore(restoreCtx)
 			if err != nil {
-				return nil, nil, nil, err
+				return ttlOptions{}, err
 			}
 
 			intervalExpr := sb.String()
-			ttlInfo = &model.TTLInfo{
+			opts.info = &model.TTLInfo{
 				ColumnName:       op.ColumnName.Name,
 				IntervalExprStr:  intervalExpr,
 				IntervalTimeUnit: int(op.TimeUnitValue.Unit),
@@ -216,19 +479,47 @@ func getTTLInfoInOptions(options []*ast.TableOption) (ttlInfo *model.TTLInfo, tt
 				JobInterval:      "1h",
 			}
 		case ast.TableOptionTTLEnable:
-			ttlEnable = &op.BoolValue
+			opts.enable = &op.BoolValue
 		case ast.TableOptionTTLJobInterval:
-			ttlCronJobSchedule = &op.StrValue
+			opts.jobInterval = &op.StrValue
+		case ast.TableOptionTTLFilter:
+			filter, err := restoreTTLFilterExpr(op.StrValue)
+			if err != nil {
+				return ttlOptions{}, err
+			}
+			opts.filter = &filter
+		case ast.TableOptionTTLArchiveTo:
+			opts.archiveTo = &model.TTLArchiveTable{}
+			if len(op.TableNames) > 0 {
+				opts.archiveTo.Schema = op.TableNames[0].Schema
+				opts.archiveTo.Table = op.TableNames[0].Name
+			}
+		case ast.TableOptionTTLJobWindow:
+			opts.jobWindow = &op.StrValue
+		case ast.TableOptionTTLDeleteRate:
+			opts.deleteRate = &op.UintValue
 		}
 	}
 
-	if ttlInfo != nil {
-		if ttlEnable != nil {
-			ttlInfo.Enable = *ttlEnable
+	if ttlInfo := opts.info; ttlInfo != nil {
+		if opts.enable != nil {
+			ttlInfo.Enable = *opts.enable
+		}
+		if opts.jobInterval != nil {
+			ttlInfo.JobInterval = *opts.jobInterval
+		}
+		if opts.filter != nil {
+			ttlInfo.FilterExprStr = *opts.filter
+		}
+		if opts.archiveTo != nil && opts.archiveTo.Table.L != "" {
+			ttlInfo.ArchiveTo = opts.archiveTo
+		}
+		if opts.jobWindow != nil {
+			ttlInfo.JobWindow = *opts.jobWindow
 		}
-		if ttlCronJobSchedule != nil {
-			ttlInfo.JobInterval = *ttlCronJobSchedule
+		if opts.deleteRate != nil {
+			ttlInfo.DeleteRate = *opts.deleteRate
 		}
 	}
-	return ttlInfo, ttlEnable, ttlCronJobSchedule, nil
+	return opts, nil
 }
@@ -26,12 +26,20 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 	falseValue := false
 	trueValue := true
 	twentyFourHours := "24h"
+	filter := "`status` IN (1,'b')"
+	emptyFilter := ""
+	jobWindow := "22:00 +0800-06:00 +0800"
+	deleteRate := uint64(10)
 
 	cases := []struct {
 		options            []*ast.TableOption
 		ttlInfo            *model.TTLInfo
 		ttlEnable          *bool
 		ttlCronJobSchedule *string
+		ttlFilter          *string
+		ttlArchiveTo       *model.TTLArchiveTable
+		ttlJobWindow       *string
+		ttlDeleteRate      *uint64
 		err                error
 	}{
 		{
@@ -40,6 +48,10 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -60,6 +72,10 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			nil,
 			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -84,6 +100,10 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			&falseValue,
 			nil,
 			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -112,6 +132,10 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			&trueValue,
 			nil,
 			nil,
+			nil,
+			nil,
+			nil,
+			nil,
 		},
 		{
 			[]*ast.TableOption{
@@ -136,15 +160,110 @@ func Test_getTTLInfoInOptions(t *testing.T) {
 			nil,
 			&twentyFourHours,
 			nil,
+			nil,
+			nil,
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:            ast.TableOptionTTL,
+					ColumnName:    &ast.ColumnName{Name: model.NewCIStr("test_column")},
+					Value:         ast.NewValueExpr(5, "", ""),
+					TimeUnitValue: &ast.TimeUnitExpr{Unit: ast.TimeUnitYear},
+				},
+				{
+					Tp:       ast.TableOptionTTLFilter,
+					StrValue: "status in (1, 'b')",
+				},
+				{
+					Tp:         ast.TableOptionTTLArchiveTo,
+					TableNames: []*ast.TableName{{Schema: model.NewCIStr("test"), Name: model.NewCIStr("t_archive")}},
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:       model.NewCIStr("test_column"),
+				IntervalExprStr:  "5",
+				IntervalTimeUnit: int(ast.TimeUnitYear),
+				Enable:           true,
+				JobInterval:      "1h",
+				FilterExprStr:    "`status` IN (1,'b')",
+				ArchiveTo:        &model.TTLArchiveTable{Schema: model.NewCIStr("test"), Table: model.NewCIStr("t_archive")},
+			},
+			nil,
+			nil,
+			&filter,
+			&model.TTLArchiveTable{Schema: model.NewCIStr("test"), Table: model.NewCIStr("t_archive")},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:       ast.TableOptionTTLFilter,
+					StrValue: "",
+				},
+				{
+					Tp: ast.TableOptionTTLArchiveTo,
+				},
+			},
+			nil,
+			nil,
+			nil,
+			&emptyFilter,
+			&model.TTLArchiveTable{},
+			nil,
+			nil,
+			nil,
+		},
+		{
+			[]*ast.TableOption{
+				{
+					Tp:            ast.TableOptionTTL,
+					ColumnName:    &ast.ColumnName{Name: model.NewCIStr("test_column")},
+					Value:         ast.NewValueExpr(5, "", ""),
+					TimeUnitValue: &ast.TimeUnitExpr{Unit: ast.TimeUnitYear},
+				},
+				{
+					Tp:       ast.TableOptionTTLJobWindow,
+					StrValue: "22:00 +0800-06:00 +0800",
+				},
+				{
+					Tp:        ast.TableOptionTTLDeleteRate,
+					UintValue: 10,
+				},
+			},
+			&model.TTLInfo{
+				ColumnName:       model.NewCIStr("test_column"),
+				IntervalExprStr:  "5",
+				IntervalTimeUnit: int(ast.TimeUnitYear),
+				Enable:           true,
+				JobInterval:      "1h",
+				JobWindow:        "22:00 +0800-06:00 +0800",
+				DeleteRate:       10,
+			},
+			nil,
+			nil,
+			nil,
+			nil,
+			&jobWindow,
+			&deleteRate,
+			nil,
 		},
 	}
 
 	for _, c := range cases {
-		ttlInfo, ttlEnable, ttlCronJobSchedule, err := getTTLInfoInOptions(c.options)
+		opts, err := getTTLInfoInOptions(c.options)
 
-		assert.Equal(t, c.ttlInfo, ttlInfo)
-		assert.Equal(t, c.ttlEnable, ttlEnable)
-		assert.Equal(t, c.ttlCronJobSchedule, ttlCronJobSchedule)
+		assert.Equal(t, c.ttlInfo, opts.info)
+		assert.Equal(t, c.ttlEnable, opts.enable)
+		assert.Equal(t, c.ttlCronJobSchedule, opts.jobInterval)
+		assert.Equal(t, c.ttlFilter, opts.filter)
+		assert.Equal(t, c.ttlArchiveTo, opts.archiveTo)
+		assert.Equal(t, c.ttlJobWindow, opts.jobWindow)
+		assert.Equal(t, c.ttlDeleteRate, opts.deleteRate)
 		assert.Equal(t, c.err, err)
 	}
 }
@@ -47,12 +47,14 @@ import (
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/domain/resourcegroup"
 	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/eventsched"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/infoschema/perfschema"
 	"github.com/pingcap/tidb/keyspace"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta"
 	"github.com/pingcap/tidb/metrics"
+	"github.com/pingcap/tidb/mview"
 	"github.com/pingcap/tidb/owner"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/model"
@@ -80,6 +82,7 @@ import (
 	"github.com/pingcap/tidb/util/logutil"
 	"github.com/pingcap/tidb/util/memory"
 	"github.com/pingcap/tidb/util/memoryusagealarm"
+	utilpc "github.com/pingcap/tidb/util/plancache"
 	"github.com/pingcap/tidb/util/replayer"
 	"github.com/pingcap/tidb/util/servermemorylimit"
 	"github.com/pingcap/tidb/util/sqlexec"
@@ -166,6 +169,9 @@ type Domain struct {
 	logBackupAdvancer        *daemon.OwnerDaemon
 	historicalStatsWorker    *HistoricalStatsWorker
 	ttlJobManager            atomic.Pointer[ttlworker.JobManager]
+	eventManager             atomic.Pointer[eventsched.Manager]
+	planCacheSnapshot        *utilpc.Snapshot
+	instancePlanCache        *utilpc.InstanceCache
 	runawayManager           *resourcegroup.RunawayManager
 	runawaySyncer            *runawaySyncer
 	resourceGroupsController *rmclient.ResourceGroupsController
@@ -1055,7 +1061,9 @@ func NewDomain(store kv.Storage, ddlLease time.Duration, statsLease time.Duratio
 			jobsVerMap: make(map[int64]int64),
 			jobsIdsMap: make(map[int64]string),
 		},
-		mdlCheckCh: make(chan struct{}),
+		mdlCheckCh:        make(chan struct{}),
+		planCacheSnapshot: utilpc.NewSnapshot(),
+		instancePlanCache: utilpc.NewInstanceCache(),
 	}
 	do.stopAutoAnalyze.Store(false)
 	do.wg = util.NewWaitGroupEnhancedWrapper("domain", do.exit, config.GetGlobalConfig().TiDBEnableExitCheck)
@@ -1859,20 +1867,12 @@ func (do *Domain) handleEvolvePlanTasksLoop(ctx sessionctx.Context, owner owner.
 		}()
 		defer util.Recover(metrics.LabelDomain, "handleEvolvePlanTasksLoop", nil, false)
 
-		for {
-			select {
-			case <-do.exit:
-				owner.Cancel()
-				return
-			case <-time.After(bindinfo.Lease):
-			}
-			if owner.IsOwner() {
-				err := do.bindHandle.Load().HandleEvolvePlanTask(ctx, false)
-				if err != nil {
-					logutil.BgLogger().Info("evolve plan failed", zap.Error(err))
-				}
-			}
-		}
+		manager := bindinfo.NewEvolveManager(do.bindHandle.Load(), ctx, do.sysSessionPool, do.etcdClient, owner.IsOwner)
+		manager.Start()
+
+		<-do.exit
+		manager.Stop()
+		owner.Cancel()
 	}, "handleEvolvePlanTasksLoop")
 }
 
@@ -2912,6 +2912,53 @@ func (do *Domain) StartTTLJobManager() {
 	}, "ttlJobManager")
 }
 
+// StartMViewRefreshManager creates and starts the manager to refresh the materialized views periodically.
+func (do *Domain) StartMViewRefreshManager() {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("mviewRefreshManager exited.")
+		}()
+
+		manager := mview.NewRefreshManager(do.sysSessionPool, do.etcdClient, do.ddl.OwnerManager().IsOwner)
+		manager.Start()
+
+		<-do.exit
+		manager.Stop()
+	}, "mviewRefreshManager")
+}
+
+// StartEventManager creates and starts the manager to schedule the events, the bodies of
+// the events are executed by the sessions created by `factory`.
+func (do *Domain) StartEventManager(factory eventsched.SessionFactory) {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("eventManager exited.")
+		}()
+
+		manager := eventsched.NewManager(do.sysSessionPool, factory, do.etcdClient, do.ddl.OwnerManager().IsOwner)
+		do.eventManager.Store(manager)
+		manager.Start()
+
+		<-do.exit
+		manager.Stop()
+	}, "eventManager")
+}
+
+// EventManager returns the event manager on this domain.
+func (do *Domain) EventManager() *eventsched.Manager {
+	return do.eventManager.Load()
+}
+
+// PlanCacheSnapshot returns the plan cache snapshot of this instance.
+func (do *Domain) PlanCacheSnapshot() *utilpc.Snapshot {
+	return do.planCacheSnapshot
+}
+
+// InstancePlanCache returns the read-only plan cache shared by all the sessions of this instance.
+func (do *Domain) InstancePlanCache() *utilpc.InstanceCache {
+	return do.instancePlanCache
+}
+
 // TTLJobManager returns the ttl job manager on this domain
 func (do *Domain) TTLJobManager() *ttlworker.JobManager {
 	return do.ttlJobManager.Load()
@@ -0,0 +1,200 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package domain
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/config"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	utilpc "github.com/pingcap/tidb/util/plancache"
+	"github.com/pingcap/tidb/util/sqlexec"
+	stmtsummaryv2 "github.com/pingcap/tidb/util/stmtsummary/v2"
+	"go.uber.org/zap"
+)
+
+const (
+	planCacheSnapshotSaveInterval = time.Minute
+	planCacheSnapshotSaveTimeout  = 10 * time.Second
+	planCacheSnapshotInsertBatch  = 100
+)
+
+// StartPlanCacheSnapshotLoop starts a loop to save the plan cache snapshot of this instance periodically,
+// the snapshot is also saved when the domain is closed. `warmUp` is called once the initial statistics are
+// loaded to warm up the plan cache with the snapshot saved before restarting.
+func (do *Domain) StartPlanCacheSnapshotLoop(warmUp func()) {
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("planCacheSnapshotLoop exited.")
+		}()
+
+		if variable.PlanCacheSnapshotSize.Load() > 0 {
+			// Negative stats lease indicates that it is in test, the initial statistics are not loaded.
+			if statsHandle := do.StatsHandle(); statsHandle != nil && do.statsLease >= 0 {
+				select {
+				case <-statsHandle.InitStatsDone:
+				case <-do.exit:
+					return
+				}
+			}
+			warmUp()
+		}
+
+		ticker := time.NewTicker(planCacheSnapshotSaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+			case <-do.exit:
+				do.trySavePlanCacheSnapshot()
+				return
+			}
+			do.trySavePlanCacheSnapshot()
+		}
+	}, "planCacheSnapshotLoop")
+}
+
+func (do *Domain) trySavePlanCacheSnapshot() {
+	if variable.PlanCacheSnapshotSize.Load() <= 0 {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), planCacheSnapshotSaveTimeout)
+	defer cancel()
+	if err := do.SavePlanCacheSnapshot(ctx); err != nil {
+		logutil.BgLogger().Warn("save plan cache snapshot failed", zap.Error(err))
+	}
+}
+
+// planCacheSnapshotInstance returns the name of this instance in mysql.tidb_plan_cache_snapshot. It's the host
+// name and the port like the instance name in the metrics, which is kept across restarts unlike the IP address
+// or the server ID.
+func planCacheSnapshotInstance() string {
+	hostname, err := os.Hostname()
+	if err != nil {
+		logutil.BgLogger().Error("failed to get host name", zap.Error(err))
+		return "unknown"
+	}
+	return fmt.Sprintf("%s_%d", hostname, config.GetGlobalConfig().Port)
+}
+
+func planCacheSnapshotDigest(stmtText string) string {
+	digest := sha256.Sum256([]byte(stmtText))
+	return hex.EncodeToString(digest[:])
+}
+
+// SavePlanCacheSnapshot replaces the saved plan cache snapshot of this instance in mysql.tidb_plan_cache_snapshot
+// with the top-N prepared statements executed most in the statement summary.
+func (do *Domain) SavePlanCacheSnapshot(ctx context.Context) (err error) {
+	size := int(variable.PlanCacheSnapshotSize.Load())
+	execCounts := make(map[string]int64, size)
+	for _, stmt := range stmtsummaryv2.GetTopNPreparedStmts(size) {
+		execCounts[utilpc.SnapshotExecCountKey(stmt.Schema, stmt.Digest)] = stmt.ExecCount
+	}
+	entries := do.planCacheSnapshot.TopN(size, execCounts)
+	instance := planCacheSnapshotInstance()
+
+	se, err := do.sysSessionPool.Get()
+	defer func() {
+		do.sysSessionPool.Put(se)
+	}()
+	if err != nil {
+		return errors.Annotate(err, "get session failed")
+	}
+	exec, _ := se.(sqlexec.SQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, err = exec.ExecuteInternal(ctx, "BEGIN")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer func() {
+		if err != nil {
+			_, err1 := exec.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(err1)
+			return
+		}
+		_, err = exec.ExecuteInternal(ctx, "COMMIT")
+	}()
+	_, err = exec.ExecuteInternal(ctx, "DELETE FROM mysql.tidb_plan_cache_snapshot WHERE instance = %?", instance)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	for start := 0; start < len(entries); start += planCacheSnapshotInsertBatch {
+		end := min(start+planCacheSnapshotInsertBatch, len(entries))
+		var sql strings.Builder
+		sqlexec.MustFormatSQL(&sql, "INSERT INTO mysql.tidb_plan_cache_snapshot "+
+			"(instance, db, stmt_digest, stmt_text, param_types, bind_sql, table_version, stats_version, hits) VALUES ")
+		for i, entry := range entries[start:end] {
+			paramTypes, err := json.Marshal(entry.ParamTypes)
+			if err != nil {
+				return errors.Trace(err)
+			}
+			if i > 0 {
+				sql.WriteString(", ")
+			}
+			sqlexec.MustFormatSQL(&sql, "(%?, %?, %?, %?, %?, %?, %?, %?, %?)", instance, entry.DB,
+				planCacheSnapshotDigest(entry.StmtText), entry.StmtText, string(paramTypes), entry.BindSQL,
+				entry.TableVersion, entry.StatsVersion, entry.Hits)
+		}
+		if _, err = exec.ExecuteInternal(ctx, sql.String()); err != nil {
+			return errors.Trace(err)
+		}
+	}
+	return nil
+}
+
+// LoadPlanCacheSnapshot loads at most n statements with the most hits from the saved plan cache snapshot
+// of this instance.
+func (do *Domain) LoadPlanCacheSnapshot(n int) ([]utilpc.SnapshotEntry, error) {
+	rows, err := do.execRestrictedSQL("SELECT db, stmt_text, param_types, bind_sql, table_version, "+
+		"stats_version, hits FROM mysql.tidb_plan_cache_snapshot WHERE instance = %? ORDER BY hits DESC LIMIT %?",
+		[]interface{}{planCacheSnapshotInstance(), n})
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]utilpc.SnapshotEntry, 0, len(rows))
+	for _, row := range rows {
+		var paramTypes []*types.FieldType
+		if err := json.Unmarshal(row.GetBytes(2), &paramTypes); err != nil {
+			logutil.BgLogger().Warn("skip the plan cache snapshot entry with invalid parameter types",
+				zap.String("stmt", row.GetString(1)), zap.Error(err))
+			continue
+		}
+		entry := utilpc.SnapshotEntry{
+			DB:           row.GetString(0),
+			StmtText:     row.GetString(1),
+			ParamTypes:   paramTypes,
+			TableVersion: row.GetUint64(4),
+			StatsVersion: row.GetUint64(5),
+			Hits:         row.GetUint64(6),
+		}
+		if !row.IsNull(3) {
+			entry.BindSQL = row.GetString(3)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
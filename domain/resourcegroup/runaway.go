@@ -16,18 +16,24 @@ package resourcegroup
 
 import (
 	"context"
+	"fmt"
+	"regexp"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/jellydator/ttlcache/v3"
+	"github.com/pingcap/kvproto/pkg/coprocessor"
 	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/memory"
 	"github.com/tikv/client-go/v2/tikv"
 	"github.com/tikv/client-go/v2/tikvrpc"
 	rmclient "github.com/tikv/pd/client/resource_group/controller"
+	atomicutil "go.uber.org/atomic"
 	"go.uber.org/zap"
 )
 
@@ -50,6 +56,31 @@ const (
 // NullTime is a zero time.Time.
 var NullTime time.Time
 
+// The following watch type and action are only used by the watch items added by QUERY WATCH, so they are
+// not defined in the protocol of resource manager.
+const (
+	// RunawayWatchTypeRegexp matches the SQL text with a regular expression.
+	RunawayWatchTypeRegexp = rmpb.RunawayWatchType(model.WatchRegexp)
+	// RunawayActionSwitchGroup switches the query to another resource group.
+	RunawayActionSwitchGroup = rmpb.RunawayAction(model.RunawayActionSwitchGroup)
+)
+
+// RunawayWatchTypeName returns the name of runaway watch type.
+func RunawayWatchTypeName(t rmpb.RunawayWatchType) string {
+	if t == RunawayWatchTypeRegexp {
+		return "Regexp"
+	}
+	return rmpb.RunawayWatchType_name[int32(t)]
+}
+
+// RunawayActionName returns the name of runaway action.
+func RunawayActionName(action rmpb.RunawayAction) string {
+	if action == RunawayActionSwitchGroup {
+		return "SwitchGroup"
+	}
+	return rmpb.RunawayAction_name[int32(action)]
+}
+
 // RunawayMatchType is used to indicate whether query was interrupted by runaway identification or quarantine watch.
 type RunawayMatchType uint
 
@@ -80,18 +111,20 @@ type RunawayRecord struct {
 	SQLText           string
 	PlanDigest        string
 	Source            string
+	// Rule is the runaway rule which the query matches, and it's empty if the query hits the watch list.
+	Rule string
 }
 
 // GenRunawayQueriesStmt generates statement with given RunawayRecords.
 func GenRunawayQueriesStmt(records []*RunawayRecord) (string, []interface{}) {
 	var builder strings.Builder
-	params := make([]interface{}, 0, len(records)*7)
+	params := make([]interface{}, 0, len(records)*8)
 	builder.WriteString("insert into mysql.tidb_runaway_queries VALUES ")
 	for count, r := range records {
 		if count > 0 {
 			builder.WriteByte(',')
 		}
-		builder.WriteString("(%?, %?, %?, %?, %?, %?, %?)")
+		builder.WriteString("(%?, %?, %?, %?, %?, %?, %?, %?)")
 		params = append(params, r.ResourceGroupName)
 		params = append(params, r.Time)
 		params = append(params, r.Match)
@@ -99,6 +132,7 @@ func GenRunawayQueriesStmt(records []*RunawayRecord) (string, []interface{}) {
 		params = append(params, r.SQLText)
 		params = append(params, r.PlanDigest)
 		params = append(params, r.Source)
+		params = append(params, r.Rule)
 	}
 	return builder.String(), params
 }
@@ -113,6 +147,9 @@ type QuarantineRecord struct {
 	WatchText         string
 	Source            string
 	Action            rmpb.RunawayAction
+	// SwitchGroupName is the resource group which the watched queries are switched to
+	// if the action is RunawayActionSwitchGroup.
+	SwitchGroupName string
 }
 
 // GetRecordKey is used to get the key in ttl cache.
@@ -129,9 +166,9 @@ func writeInsert(builder *strings.Builder, tableName string) {
 // GenInsertionStmt is used to generate insertion sql.
 func (r *QuarantineRecord) GenInsertionStmt() (string, []interface{}) {
 	var builder strings.Builder
-	params := make([]interface{}, 0, 6)
+	params := make([]interface{}, 0, 8)
 	writeInsert(&builder, RunawayWatchTableName)
-	builder.WriteString("(null, %?, %?, %?, %?, %?, %?, %?)")
+	builder.WriteString("(null, %?, %?, %?, %?, %?, %?, %?, %?)")
 	params = append(params, r.ResourceGroupName)
 	params = append(params, r.StartTime)
 	if r.EndTime.Equal(NullTime) {
@@ -143,15 +180,16 @@ func (r *QuarantineRecord) GenInsertionStmt() (string, []interface{}) {
 	params = append(params, r.WatchText)
 	params = append(params, r.Source)
 	params = append(params, r.Action)
+	params = append(params, r.SwitchGroupName)
 	return builder.String(), params
 }
 
 // GenInsertionDoneStmt is used to generate insertion sql for runaway watch done record.
 func (r *QuarantineRecord) GenInsertionDoneStmt() (string, []interface{}) {
 	var builder strings.Builder
-	params := make([]interface{}, 0, 9)
+	params := make([]interface{}, 0, 10)
 	writeInsert(&builder, RunawayWatchDoneTableName)
-	builder.WriteString("(null, %?, %?, %?, %?, %?, %?, %?, %?, %?)")
+	builder.WriteString("(null, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)")
 	params = append(params, r.ID)
 	params = append(params, r.ResourceGroupName)
 	params = append(params, r.StartTime)
@@ -165,6 +203,7 @@ func (r *QuarantineRecord) GenInsertionDoneStmt() (string, []interface{}) {
 	params = append(params, r.Source)
 	params = append(params, r.Action)
 	params = append(params, time.Now().UTC())
+	params = append(params, r.SwitchGroupName)
 	return builder.String(), params
 }
 
@@ -188,7 +227,10 @@ type RunawayManager struct {
 	watchList *ttlcache.Cache[string, *QuarantineRecord]
 	// activeGroup is used to manage the active runaway watches of resource group
 	activeGroup map[string]int64
-	activeLock  sync.RWMutex
+	// regexpWatches is used to manage the compiled patterns of the regular expression watches of resource group,
+	// which can't be looked up by key. It's also protected by activeLock.
+	regexpWatches map[string]map[string]*regexp.Regexp
+	activeLock    sync.RWMutex
 
 	resourceGroupCtl   *rmclient.ResourceGroupsController
 	serverID           string
@@ -222,15 +264,20 @@ func NewRunawayManager(resourceGroupCtl *rmclient.ResourceGroupsController, serv
 		quarantineChan:        make(chan *QuarantineRecord, maxWatchRecordChannelSize),
 		staleQuarantineRecord: staleQuarantineChan,
 		activeGroup:           make(map[string]int64),
+		regexpWatches:         make(map[string]map[string]*regexp.Regexp),
 	}
 	m.insertionCancel = watchList.OnInsertion(func(ctx context.Context, i *ttlcache.Item[string, *QuarantineRecord]) {
 		m.activeLock.Lock()
 		m.activeGroup[i.Value().ResourceGroupName]++
+		m.addRegexpWatch(i.Key(), i.Value())
 		m.activeLock.Unlock()
 	})
 	m.evictionCancel = watchList.OnEviction(func(ctx context.Context, er ttlcache.EvictionReason, i *ttlcache.Item[string, *QuarantineRecord]) {
 		m.activeLock.Lock()
 		m.activeGroup[i.Value().ResourceGroupName]--
+		if watches, ok := m.regexpWatches[i.Value().ResourceGroupName]; ok {
+			delete(watches, i.Key())
+		}
 		m.activeLock.Unlock()
 		if i.Value().ID == 0 {
 			return
@@ -240,8 +287,26 @@ func NewRunawayManager(resourceGroupCtl *rmclient.ResourceGroupsController, serv
 	return m
 }
 
-// DeriveChecker derives a RunawayChecker from the given resource group
-func (rm *RunawayManager) DeriveChecker(resourceGroupName, originalSQL, sqlDigest, planDigest string) *RunawayChecker {
+func (rm *RunawayManager) addRegexpWatch(key string, record *QuarantineRecord) {
+	if record.Watch != RunawayWatchTypeRegexp {
+		return
+	}
+	pattern, err := regexp.Compile(record.WatchText)
+	if err != nil {
+		logutil.BgLogger().Warn("invalid regular expression of runaway watch", zap.String("pattern", record.WatchText), zap.Error(err))
+		return
+	}
+	watches, ok := rm.regexpWatches[record.ResourceGroupName]
+	if !ok {
+		watches = make(map[string]*regexp.Regexp)
+		rm.regexpWatches[record.ResourceGroupName] = watches
+	}
+	watches[key] = pattern
+}
+
+// DeriveChecker derives a RunawayChecker from the given resource group. The rules which are unknown to
+// resource manager, such as processed keys, RU and memory, are taken from the runaway settings in schema.
+func (rm *RunawayManager) DeriveChecker(resourceGroupName string, rules *model.ResourceGroupRunawaySettings, originalSQL, sqlDigest, planDigest string) *RunawayChecker {
 	group, err := rm.resourceGroupCtl.GetResourceGroup(resourceGroupName)
 	if err != nil || group == nil {
 		logutil.BgLogger().Warn("cannot setup up runaway checker", zap.Error(err))
@@ -252,10 +317,13 @@ func (rm *RunawayManager) DeriveChecker(resourceGroupName, originalSQL, sqlDiges
 	if group.RunawaySettings == nil && rm.activeGroup[resourceGroupName] == 0 {
 		return nil
 	}
-	return newRunawayChecker(rm, resourceGroupName, group.RunawaySettings, originalSQL, sqlDigest, planDigest)
+	if group.RunawaySettings == nil {
+		rules = nil
+	}
+	return newRunawayChecker(rm, resourceGroupName, group.RunawaySettings, rules, rm.resourceGroupCtl.GetConfig(), originalSQL, sqlDigest, planDigest)
 }
 
-func (rm *RunawayManager) markQuarantine(resourceGroupName, convict string, watchType rmpb.RunawayWatchType, action rmpb.RunawayAction, ttl time.Duration, now *time.Time) {
+func (rm *RunawayManager) markQuarantine(resourceGroupName, convict string, watchType rmpb.RunawayWatchType, action rmpb.RunawayAction, switchGroupName string, ttl time.Duration, now *time.Time) {
 	var endTime time.Time
 	if ttl > 0 {
 		endTime = now.UTC().Add(ttl)
@@ -268,6 +336,7 @@ func (rm *RunawayManager) markQuarantine(resourceGroupName, convict string, watc
 		WatchText:         convict,
 		Source:            rm.serverID,
 		Action:            action,
+		SwitchGroupName:   switchGroupName,
 	}
 	// Add record without ID into watch list in this TiDB right now.
 	rm.addWatchList(record, ttl, false)
@@ -367,7 +436,7 @@ func (rm *RunawayManager) getWatchFromWatchList(key string) *QuarantineRecord {
 	return nil
 }
 
-func (rm *RunawayManager) markRunaway(resourceGroupName, originalSQL, planDigest string, action string, matchType RunawayMatchType, now *time.Time) {
+func (rm *RunawayManager) markRunaway(resourceGroupName, originalSQL, planDigest string, action string, matchType RunawayMatchType, rule string, now *time.Time) {
 	source := rm.serverID
 	select {
 	case rm.runawayQueriesChan <- &RunawayRecord{
@@ -378,6 +447,7 @@ func (rm *RunawayManager) markRunaway(resourceGroupName, originalSQL, planDigest
 		SQLText:           originalSQL,
 		PlanDigest:        planDigest,
 		Source:            source,
+		Rule:              rule,
 	}:
 	default:
 		// TODO: add warning for discard flush records
@@ -405,12 +475,27 @@ func (rm *RunawayManager) StaleQuarantineRecordChan() <-chan *QuarantineRecord {
 }
 
 // examineWatchList check whether the query is in watch list.
-func (rm *RunawayManager) examineWatchList(resourceGroupName string, convict string) (bool, rmpb.RunawayAction) {
-	item := rm.getWatchFromWatchList(resourceGroupName + "/" + convict)
-	if item == nil {
-		return false, 0
+func (rm *RunawayManager) examineWatchList(resourceGroupName string, convict string) *QuarantineRecord {
+	return rm.getWatchFromWatchList(resourceGroupName + "/" + convict)
+}
+
+// examineRegexpWatchList check whether the SQL text matches any regular expression watch of the resource group.
+func (rm *RunawayManager) examineRegexpWatchList(resourceGroupName string, sql string) *QuarantineRecord {
+	var matched []string
+	rm.activeLock.RLock()
+	for key, pattern := range rm.regexpWatches[resourceGroupName] {
+		if pattern.MatchString(sql) {
+			matched = append(matched, key)
+		}
+	}
+	rm.activeLock.RUnlock()
+	// get the record out of activeLock because the expired item may be evicted when getting.
+	for _, key := range matched {
+		if item := rm.getWatchFromWatchList(key); item != nil {
+			return item
+		}
 	}
-	return true, item.Action
+	return nil
 }
 
 // Stop stops the watchList which is a ttlcache.
@@ -430,11 +515,22 @@ type RunawayChecker struct {
 
 	deadline time.Time
 	setting  *rmpb.RunawaySettings
+	// rules is the runaway settings in schema, which holds the rules that are only checked by TiDB.
+	rules    *model.ResourceGroupRunawaySettings
+	ruConfig *rmclient.RUConfig
+	// switchGroupName is the resource group which the query is switched to by the watch item or the rule.
+	// It's set when the query is running, so it's accessed atomically.
+	switchGroupName atomicutil.String
+
+	// processedKeys and requestUnit are accumulated from the coprocessor responses.
+	processedKeys atomic.Uint64
+	requestUnit   atomicutil.Float64
 
 	marked atomic.Bool
 }
 
-func newRunawayChecker(manager *RunawayManager, resourceGroupName string, setting *rmpb.RunawaySettings, originalSQL, sqlDigest, planDigest string) *RunawayChecker {
+func newRunawayChecker(manager *RunawayManager, resourceGroupName string, setting *rmpb.RunawaySettings, rules *model.ResourceGroupRunawaySettings,
+	ruConfig *rmclient.RUConfig, originalSQL, sqlDigest, planDigest string) *RunawayChecker {
 	c := &RunawayChecker{
 		manager:           manager,
 		resourceGroupName: resourceGroupName,
@@ -442,9 +538,11 @@ func newRunawayChecker(manager *RunawayManager, resourceGroupName string, settin
 		sqlDigest:         sqlDigest,
 		planDigest:        planDigest,
 		setting:           setting,
+		rules:             rules,
+		ruConfig:          ruConfig,
 		marked:            atomic.Bool{},
 	}
-	if setting != nil {
+	if setting != nil && setting.Rule.GetExecElapsedTimeMs() > 0 {
 		c.deadline = time.Now().Add(time.Duration(setting.Rule.ExecElapsedTimeMs) * time.Millisecond)
 	}
 	return c
@@ -456,38 +554,82 @@ func (r *RunawayChecker) BeforeExecutor() error {
 		return nil
 	}
 	for _, convict := range r.getConvictIdentifiers() {
-		watched, action := r.manager.examineWatchList(r.resourceGroupName, convict)
-		if watched {
-			if action == rmpb.RunawayAction_NoneAction && r.setting != nil {
-				action = r.setting.Action
-			}
-			if r.marked.CompareAndSwap(false, true) {
-				now := time.Now()
-				r.markRunaway(RunawayMatchTypeWatch, action, &now)
-			}
-			// If no match action, it will do nothing.
-			switch action {
-			case rmpb.RunawayAction_Kill:
-				return exeerrors.ErrResourceGroupQueryRunawayQuarantine
-			case rmpb.RunawayAction_CoolDown:
-				return nil
-			case rmpb.RunawayAction_DryRun:
-				return nil
-			default:
+		if record := r.manager.examineWatchList(r.resourceGroupName, convict); record != nil {
+			if done, err := r.actOnWatch(record); done {
+				return err
 			}
 		}
 	}
+	if record := r.manager.examineRegexpWatchList(r.resourceGroupName, r.originalSQL); record != nil {
+		_, err := r.actOnWatch(record)
+		return err
+	}
 	return nil
 }
 
+// actOnWatch performs the action of the watch item which the query hits. It returns whether the action is done.
+func (r *RunawayChecker) actOnWatch(record *QuarantineRecord) (bool, error) {
+	action, switchGroupName := record.Action, record.SwitchGroupName
+	if action == rmpb.RunawayAction_NoneAction && r.setting != nil {
+		action, switchGroupName = r.action()
+	}
+	if r.marked.CompareAndSwap(false, true) {
+		now := time.Now()
+		r.markRunaway(RunawayMatchTypeWatch, action, "", &now)
+	}
+	// If no match action, it will do nothing.
+	switch action {
+	case rmpb.RunawayAction_Kill:
+		return true, exeerrors.ErrResourceGroupQueryRunawayQuarantine
+	case rmpb.RunawayAction_CoolDown:
+		return true, nil
+	case rmpb.RunawayAction_DryRun:
+		return true, nil
+	case RunawayActionSwitchGroup:
+		r.switchGroupName.Store(switchGroupName)
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// action returns the action of the runaway settings and the resource group which the query is switched to by it.
+// SWITCH_GROUP is unknown to resource manager, so it's taken from the runaway settings in schema.
+func (r *RunawayChecker) action() (rmpb.RunawayAction, string) {
+	if r.rules != nil && r.rules.Action == model.RunawayActionSwitchGroup {
+		return RunawayActionSwitchGroup, r.rules.SwitchGroupName
+	}
+	return r.setting.Action, ""
+}
+
+// SwitchGroupName returns the resource group which the query is switched to by the watch item or the rule,
+// or an empty string if the query isn't switched. All the requests of the query, including point get,
+// batch get, coprocessor and transaction requests, are sent with it after the query is switched.
+func (r *RunawayChecker) SwitchGroupName() string {
+	if r == nil {
+		return ""
+	}
+	return r.switchGroupName.Load()
+}
+
 // BeforeCopRequest checks runaway and modifies the request if necessary before sending coprocessor request.
 func (r *RunawayChecker) BeforeCopRequest(req *tikvrpc.Request) error {
+	if name := r.SwitchGroupName(); len(name) > 0 {
+		// the rest of the query is throttled by the resource group which it's switched to,
+		// so the runaway settings of the original resource group don't work any more.
+		req.ResourceControlContext.ResourceGroupName = name
+		return nil
+	}
 	if r.setting == nil {
 		return nil
 	}
 	marked := r.marked.Load()
 	if !marked {
 		// note: now we don't check whether query is in watch list again.
+		// The other rules are checked after receiving coprocessor response or consuming memory.
+		if r.deadline.IsZero() {
+			return nil
+		}
 		until := time.Until(r.deadline)
 		if until > 0 {
 			if r.setting.Action == rmpb.RunawayAction_Kill {
@@ -499,13 +641,9 @@ func (r *RunawayChecker) BeforeCopRequest(req *tikvrpc.Request) error {
 			return nil
 		}
 		// execution time exceeds the threshold, mark the query as runaway
-		if r.marked.CompareAndSwap(false, true) {
-			now := time.Now()
-			r.markRunaway(RunawayMatchTypeIdentify, r.setting.Action, &now)
-			r.markQuarantine(&now)
-		}
+		r.markRunawayByRule(r.elapsedRule())
 	}
-	switch r.setting.Action {
+	switch action, switchGroupName := r.action(); action {
 	case rmpb.RunawayAction_Kill:
 		return exeerrors.ErrResourceGroupQueryRunawayInterrupted
 	case rmpb.RunawayAction_CoolDown:
@@ -513,38 +651,105 @@ func (r *RunawayChecker) BeforeCopRequest(req *tikvrpc.Request) error {
 		return nil
 	case rmpb.RunawayAction_DryRun:
 		return nil
+	case RunawayActionSwitchGroup:
+		r.switchGroupName.Store(switchGroupName)
+		req.ResourceControlContext.ResourceGroupName = switchGroupName
+		return nil
 	default:
 		return nil
 	}
 }
 
 // AfterCopRequest checks runaway after receiving coprocessor response.
-func (r *RunawayChecker) AfterCopRequest() {
+func (r *RunawayChecker) AfterCopRequest(resp *coprocessor.Response) {
 	if r.setting == nil {
 		return
 	}
+	var processedKeys uint64
+	var requestUnit float64
+	if r.rules != nil && (r.rules.ProcessedKeys > 0 || r.rules.RequestUnit > 0) {
+		processedKeys = r.processedKeys.Add(resp.GetExecDetailsV2().GetScanDetailV2().GetProcessedVersions())
+		requestUnit = r.requestUnit.Add(r.estimateRU(resp))
+	}
 	// Do not perform action here as it may be the last cop request and just let it finish. If it's not the last cop request, action would be performed in `BeforeCopRequest` when handling the next cop request.
 	// Here only marks the query as runaway
-	if !r.marked.Load() && r.deadline.Before(time.Now()) {
-		if r.marked.CompareAndSwap(false, true) {
-			now := time.Now()
-			r.markRunaway(RunawayMatchTypeIdentify, r.setting.Action, &now)
-			r.markQuarantine(&now)
+	if r.marked.Load() {
+		return
+	}
+	if !r.deadline.IsZero() && r.deadline.Before(time.Now()) {
+		r.markRunawayByRule(r.elapsedRule())
+		return
+	}
+	if r.rules == nil {
+		return
+	}
+	if r.rules.ProcessedKeys > 0 && processedKeys > r.rules.ProcessedKeys {
+		r.markRunawayByRule(fmt.Sprintf("ProcessedKeys = %d(%d)", r.rules.ProcessedKeys, processedKeys))
+		return
+	}
+	if r.rules.RequestUnit > 0 && requestUnit > float64(r.rules.RequestUnit) {
+		r.markRunawayByRule(fmt.Sprintf("RequestUnit = %d(%.2f)", r.rules.RequestUnit, requestUnit))
+	}
+}
+
+// estimateRU estimates the read RU consumed by the coprocessor response in the same way as resource controller.
+func (r *RunawayChecker) estimateRU(resp *coprocessor.Response) float64 {
+	if r.ruConfig == nil || resp == nil {
+		return 0
+	}
+	detailsV2 := resp.GetExecDetailsV2()
+	readBytes := uint64(resp.Data.Size())
+	if scanDetail := detailsV2.GetScanDetailV2(); scanDetail != nil {
+		readBytes = scanDetail.GetProcessedVersionsSize()
+	}
+	var kvCPUMs float64
+	if timeDetail := detailsV2.GetTimeDetailV2(); timeDetail != nil {
+		kvCPUMs = float64(timeDetail.GetProcessWallTimeNs()) / float64(time.Millisecond)
+	} else if timeDetail := detailsV2.GetTimeDetail(); timeDetail != nil {
+		kvCPUMs = float64(timeDetail.GetProcessWallTimeMs())
+	}
+	return float64(r.ruConfig.ReadBaseCost) + float64(r.ruConfig.ReadBytesCost)*float64(readBytes) + float64(r.ruConfig.CPUMsCost)*kvCPUMs
+}
+
+// AttachMemoryTracker sets the memory rule to the memory tracker of the statement, so that the query is
+// identified as runaway once its memory usage exceeds the limit.
+func (r *RunawayChecker) AttachMemoryTracker(tracker *memory.Tracker) {
+	if r == nil || r.setting == nil || r.rules == nil || r.rules.MemoryBytes == 0 || tracker == nil {
+		return
+	}
+	tracker.SetBytesLimit(int64(r.rules.MemoryBytes))
+	tracker.FallbackOldAndSetNewAction(&runawayMemoryAction{checker: r})
+}
+
+func (r *RunawayChecker) elapsedRule() string {
+	return fmt.Sprintf("ElapsedTime = %s", time.Duration(r.setting.Rule.ExecElapsedTimeMs)*time.Millisecond)
+}
+
+// markRunawayByRule marks the query as runaway which is identified by the given rule.
+// If the action is SWITCH_GROUP, the rest of the query is switched to the resource group.
+func (r *RunawayChecker) markRunawayByRule(rule string) {
+	if r.marked.CompareAndSwap(false, true) {
+		now := time.Now()
+		action, switchGroupName := r.action()
+		if action == RunawayActionSwitchGroup {
+			r.switchGroupName.Store(switchGroupName)
 		}
+		r.markRunaway(RunawayMatchTypeIdentify, action, rule, &now)
+		r.markQuarantine(action, switchGroupName, &now)
 	}
 }
 
-func (r *RunawayChecker) markQuarantine(now *time.Time) {
+func (r *RunawayChecker) markQuarantine(action rmpb.RunawayAction, switchGroupName string, now *time.Time) {
 	if r.setting.Watch == nil {
 		return
 	}
 	ttl := time.Duration(r.setting.Watch.LastingDurationMs) * time.Millisecond
 
-	r.manager.markQuarantine(r.resourceGroupName, r.getSettingConvictIdentifier(), r.setting.Watch.Type, r.setting.Action, ttl, now)
+	r.manager.markQuarantine(r.resourceGroupName, r.getSettingConvictIdentifier(), r.setting.Watch.Type, action, switchGroupName, ttl, now)
 }
 
-func (r *RunawayChecker) markRunaway(matchType RunawayMatchType, action rmpb.RunawayAction, now *time.Time) {
-	r.manager.markRunaway(r.resourceGroupName, r.originalSQL, r.planDigest, strings.ToLower(rmpb.RunawayAction_name[int32(action)]), matchType, now)
+func (r *RunawayChecker) markRunaway(matchType RunawayMatchType, action rmpb.RunawayAction, rule string, now *time.Time) {
+	r.manager.markRunaway(r.resourceGroupName, r.originalSQL, r.planDigest, strings.ToLower(RunawayActionName(action)), matchType, rule, now)
 }
 
 func (r *RunawayChecker) getSettingConvictIdentifier() string {
@@ -566,3 +771,24 @@ func (r *RunawayChecker) getSettingConvictIdentifier() string {
 func (r *RunawayChecker) getConvictIdentifiers() []string {
 	return []string{r.originalSQL, r.sqlDigest, r.planDigest}
 }
+
+// runawayMemoryAction identifies the query as runaway when the memory usage exceeds the limit of the memory rule.
+type runawayMemoryAction struct {
+	memory.BaseOOMAction
+	checker *RunawayChecker
+}
+
+// Action implements memory.ActionOnExceed.
+func (a *runawayMemoryAction) Action(t *memory.Tracker) {
+	a.checker.markRunawayByRule(fmt.Sprintf("Memory = %d(%d)", a.checker.rules.MemoryBytes, t.BytesConsumed()))
+	if a.checker.setting.Action == rmpb.RunawayAction_Kill {
+		panic(exeerrors.ErrResourceGroupQueryRunawayInterrupted)
+	}
+	// the other actions are performed in `BeforeCopRequest`, so the action is only triggered once.
+	a.SetFinished()
+}
+
+// GetPriority implements memory.ActionOnExceed.
+func (*runawayMemoryAction) GetPriority() int64 {
+	return memory.DefLogPriority
+}
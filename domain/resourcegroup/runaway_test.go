@@ -0,0 +1,128 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resourcegroup
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pingcap/kvproto/pkg/coprocessor"
+	"github.com/pingcap/kvproto/pkg/kvrpcpb"
+	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/memory"
+	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/tikvrpc"
+	rmclient "github.com/tikv/pd/client/resource_group/controller"
+)
+
+func newCopResponse(processedKeys uint64, processTimeNs uint64) *coprocessor.Response {
+	return &coprocessor.Response{
+		ExecDetailsV2: &kvrpcpb.ExecDetailsV2{
+			ScanDetailV2: &kvrpcpb.ScanDetailV2{
+				ProcessedVersions:     processedKeys,
+				ProcessedVersionsSize: processedKeys * 16,
+			},
+			TimeDetailV2: &kvrpcpb.TimeDetailV2{
+				ProcessWallTimeNs: processTimeNs,
+			},
+		},
+	}
+}
+
+func newCopRequest() *tikvrpc.Request {
+	return tikvrpc.NewRequest(tikvrpc.CmdCop, &coprocessor.Request{}, kvrpcpb.Context{
+		ResourceControlContext: &kvrpcpb.ResourceControlContext{},
+	})
+}
+
+func TestRunawayCheckerWithRules(t *testing.T) {
+	rm := NewRunawayManager(nil, "tidb-0")
+	defer rm.Stop()
+
+	// processed keys
+	setting := &rmpb.RunawaySettings{Rule: &rmpb.RunawayRule{}, Action: rmpb.RunawayAction_Kill}
+	checker := newRunawayChecker(rm, "rg1", setting, &model.ResourceGroupRunawaySettings{ProcessedKeys: 100},
+		rmclient.DefaultRUConfig(), "select * from t", "sql_digest", "plan_digest")
+	require.NoError(t, checker.BeforeCopRequest(newCopRequest()))
+	checker.AfterCopRequest(newCopResponse(60, 0))
+	require.NoError(t, checker.BeforeCopRequest(newCopRequest()))
+	checker.AfterCopRequest(newCopResponse(60, 0))
+	require.ErrorIs(t, checker.BeforeCopRequest(newCopRequest()), exeerrors.ErrResourceGroupQueryRunawayInterrupted)
+	record := <-rm.RunawayRecordChan()
+	require.Equal(t, "rg1", record.ResourceGroupName)
+	require.Equal(t, "identify", record.Match)
+	require.Equal(t, "kill", record.Action)
+	require.Equal(t, "ProcessedKeys = 100(120)", record.Rule)
+
+	// RU
+	setting = &rmpb.RunawaySettings{Rule: &rmpb.RunawayRule{}, Action: rmpb.RunawayAction_CoolDown}
+	checker = newRunawayChecker(rm, "rg1", setting, &model.ResourceGroupRunawaySettings{RequestUnit: 1},
+		rmclient.DefaultRUConfig(), "select * from t", "sql_digest", "plan_digest")
+	checker.AfterCopRequest(newCopResponse(1, 10000000))
+	req := newCopRequest()
+	require.NoError(t, checker.BeforeCopRequest(req))
+	require.Equal(t, uint64(1), req.ResourceControlContext.OverridePriority)
+	record = <-rm.RunawayRecordChan()
+	require.Equal(t, "cooldown", record.Action)
+	require.True(t, strings.HasPrefix(record.Rule, "RequestUnit = 1("), record.Rule)
+
+	// the rules are not checked if the elapsed time rule is matched first
+	setting = &rmpb.RunawaySettings{Rule: &rmpb.RunawayRule{ExecElapsedTimeMs: 1}, Action: rmpb.RunawayAction_DryRun}
+	checker = newRunawayChecker(rm, "rg1", setting, &model.ResourceGroupRunawaySettings{ExecElapsedTimeMs: 1, ProcessedKeys: 1},
+		rmclient.DefaultRUConfig(), "select * from t", "sql_digest", "plan_digest")
+	require.Eventually(t, func() bool {
+		checker.AfterCopRequest(newCopResponse(10, 0))
+		return checker.marked.Load()
+	}, time.Second, 10*time.Millisecond)
+	record = <-rm.RunawayRecordChan()
+	require.Equal(t, "ElapsedTime = 1ms", record.Rule)
+
+	// memory
+	setting = &rmpb.RunawaySettings{Rule: &rmpb.RunawayRule{}, Action: rmpb.RunawayAction_DryRun,
+		Watch: &rmpb.RunawayWatch{Type: rmpb.RunawayWatchType_Similar}}
+	checker = newRunawayChecker(rm, "rg1", setting, &model.ResourceGroupRunawaySettings{MemoryBytes: 1000},
+		rmclient.DefaultRUConfig(), "select * from t", "sql_digest", "plan_digest")
+	tracker := memory.NewTracker(memory.LabelForSQLText, -1)
+	checker.AttachMemoryTracker(tracker)
+	tracker.Consume(500)
+	require.False(t, checker.marked.Load())
+	tracker.Consume(600)
+	require.True(t, checker.marked.Load())
+	record = <-rm.RunawayRecordChan()
+	require.Equal(t, "dryrun", record.Action)
+	require.Equal(t, "Memory = 1000(1100)", record.Rule)
+	quarantine := <-rm.QuarantineRecordChan()
+	require.Equal(t, "sql_digest", quarantine.WatchText)
+	// the action is only triggered once
+	tracker.Consume(100)
+	select {
+	case record = <-rm.RunawayRecordChan():
+		require.FailNow(t, "unexpected runaway record", "%v", record)
+	default:
+	}
+
+	setting = &rmpb.RunawaySettings{Rule: &rmpb.RunawayRule{}, Action: rmpb.RunawayAction_Kill}
+	checker = newRunawayChecker(rm, "rg1", setting, &model.ResourceGroupRunawaySettings{MemoryBytes: 1000},
+		rmclient.DefaultRUConfig(), "select * from t", "sql_digest", "plan_digest")
+	tracker = memory.NewTracker(memory.LabelForSQLText, -1)
+	checker.AttachMemoryTracker(tracker)
+	require.Panics(t, func() { tracker.Consume(2000) })
+	record = <-rm.RunawayRecordChan()
+	require.Equal(t, "kill", record.Action)
+	require.Equal(t, "Memory = 1000(2000)", record.Rule)
+}
@@ -500,6 +500,7 @@ func getRunawayWatchRecord(exec sqlexec.RestrictedSQLExecutor, reader *SystemTab
 			WatchText:         r.GetString(5),
 			Source:            r.GetString(6),
 			Action:            rmpb.RunawayAction(r.GetInt64(7)),
+			SwitchGroupName:   r.GetString(8),
 		}
 		// If a TiDB write record slow, it will occur that the record which has earlier start time is inserted later than others.
 		// So we start the scan a little earlier.
@@ -540,6 +541,7 @@ func getRunawayWatchDoneRecord(exec sqlexec.RestrictedSQLExecutor, reader *Syste
 			WatchText:         r.GetString(6),
 			Source:            r.GetString(7),
 			Action:            rmpb.RunawayAction(r.GetInt64(8)),
+			SwitchGroupName:   r.GetString(10),
 		}
 		// Ditto as getRunawayWatchRecord.
 		if push {
@@ -828,6 +828,8 @@ const (
 	ErrInvalidArgumentForLogarithm                           = 3020
 	ErrMaxExecTimeExceeded                                   = 3024
 	ErrAggregateOrderNonAggQuery                             = 3029
+	ErrGISDifferentSRIDs                                     = 3033
+	ErrGISInvalidData                                        = 3037
 	ErrUserLockWrongName                                     = 3057
 	ErrUserLockDeadlock                                      = 3058
 	ErrIncorrectType                                         = 3064
@@ -896,6 +898,9 @@ const (
 	ErrCTEMaxRecursionDepth                                  = 3636
 	ErrNotHintUpdatable                                      = 3637
 	ErrExistsInHistoryPassword                               = 3638
+	ErrMissingJSONTableValue                                 = 3665
+	ErrWrongJSONTableValue                                   = 3666
+	ErrTFForbiddenJoinType                                   = 3668
 	ErrForeignKeyCannotDropParent                            = 3730
 	ErrForeignKeyCannotUseVirtualColumn                      = 3733
 	ErrForeignKeyNoColumnInParent                            = 3734
@@ -1133,6 +1138,14 @@ const (
 	ErrCannotResumeDDLJob = 8261
 	ErrPausedDDLJob       = 8262
 
+	ErrMViewFastRefreshUnsupported = 8263
+	ErrMViewBaseTableInUse         = 8264
+
+	ErrUnsupportedTTLArchiveTable = 8265
+	ErrTTLArchiveTableInUse       = 8267
+
+	ErrWindowGroupsFrameOrderBy = 8266
+
 	// Resource group errors.
 	ErrResourceGroupExists                    = 8248
 	ErrResourceGroupNotExists                 = 8249
@@ -844,6 +844,8 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrPasswordExpireAnonymousUser:                           mysql.Message("The password for anonymous user cannot be expired.", nil),
 	ErrInvalidArgumentForLogarithm:                           mysql.Message("Invalid argument for logarithm", nil),
 	ErrAggregateOrderNonAggQuery:                             mysql.Message("Expression #%d of ORDER BY contains aggregate function and applies to the result of a non-aggregated query", nil),
+	ErrGISDifferentSRIDs:                                     mysql.Message("Binary geometry function %s given two geometries of different srids: %d and %d, which should have been identical.", nil),
+	ErrGISInvalidData:                                        mysql.Message("Invalid GIS data provided to function %s.", nil),
 	ErrIncorrectType:                                         mysql.Message("Incorrect type for argument %s in function %s.", nil),
 	ErrFieldInOrderNotSelect:                                 mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, references column '%s' which is not in SELECT list; this is incompatible with %s", nil),
 	ErrAggregateInOrderNotSelect:                             mysql.Message("Expression #%d of ORDER BY clause is not in SELECT list, contains aggregate function; this is incompatible with %s", nil),
@@ -892,6 +894,9 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrLockAcquireFailAndNoWaitSet:                           mysql.Message("Statement aborted because lock(s) could not be acquired immediately and NOWAIT is set.", nil),
 	ErrNotHintUpdatable:                                      mysql.Message("Variable '%s' cannot be set using SET_VAR hint.", nil),
 	ErrExistsInHistoryPassword:                               mysql.Message("Cannot use these credentials for '%s@%s' because they contradict the password history policy.", nil),
+	ErrMissingJSONTableValue:                                 mysql.Message("Missing value for JSON_TABLE column '%s'", nil),
+	ErrWrongJSONTableValue:                                   mysql.Message("Can't store an array or an object in the scalar column '%s' of JSON_TABLE '%s'.", nil),
+	ErrTFForbiddenJoinType:                                   mysql.Message("INNER or LEFT JOIN must be used for LATERAL references made by '%s'", nil),
 	ErrForeignKeyCannotDropParent:                            mysql.Message("Cannot drop table '%s' referenced by a foreign key constraint '%s' on table '%s'.", nil),
 	ErrForeignKeyCannotUseVirtualColumn:                      mysql.Message("Foreign key '%s' uses virtual column '%s' which is not supported.", nil),
 	ErrForeignKeyNoColumnInParent:                            mysql.Message("Failed to add the foreign key constraint. Missing column '%s' for constraint '%s' in the referenced table '%s'", nil),
@@ -1161,4 +1166,12 @@ var MySQLErrName = map[uint16]*mysql.ErrMessage{
 	ErrCannotPauseDDLJob:  mysql.Message("Job [%v] can't be paused: %s", nil),
 	ErrCannotResumeDDLJob: mysql.Message("Job [%v] can't be resumed: %s", nil),
 	ErrPausedDDLJob:       mysql.Message("Job [%v] has already been paused", nil),
+
+	ErrMViewFastRefreshUnsupported: mysql.Message("Materialized view can't be refreshed fast: %s", nil),
+	ErrMViewBaseTableInUse:         mysql.Message("Table '%s' is the base table of materialized view '%s'", nil),
+
+	ErrUnsupportedTTLArchiveTable: mysql.Message("Table '%s' cannot be used as the archive table of TTL: %s", nil),
+	ErrTTLArchiveTableInUse:       mysql.Message("Table '%s' is the archive table of TTL table '%s'", nil),
+
+	ErrWindowGroupsFrameOrderBy: mysql.Message("Window '%s' with GROUPS N PRECEDING/FOLLOWING frame requires an ORDER BY clause", nil),
 }
@@ -0,0 +1,178 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsched
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pingcap/tidb/parser/auth"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+)
+
+// Event statuses, they are the values shown in `SHOW EVENTS` and `information_schema.EVENTS`.
+const (
+	StatusEnabled           = "ENABLED"
+	StatusDisabled          = "DISABLED"
+	StatusSlavesideDisabled = "SLAVESIDE_DISABLED"
+)
+
+// The statuses of an execution of an event in `mysql.tidb_event_history`.
+const (
+	execStatusSuccess = "success"
+	execStatusFailed  = "failed"
+)
+
+// maxInterval is the max interval of a recurring event, which is the same as MySQL.
+const maxInterval = 10 * 365 * 24 * time.Hour
+
+// EventInfo is the definition of an event, it's stored as the data of the event's timer.
+type EventInfo struct {
+	Schema      string `json:"schema"`
+	Name        string `json:"name"`
+	DefinerUser string `json:"definer_user"`
+	DefinerHost string `json:"definer_host"`
+	// Body is the text of the statement executed by the event.
+	Body                string `json:"body"`
+	SQLMode             string `json:"sql_mode"`
+	TimeZone            string `json:"time_zone"`
+	CharsetClient       string `json:"character_set_client"`
+	CollationConnection string `json:"collation_connection"`
+	DBCollation         string `json:"db_collation"`
+	// ExecuteAt is the time to execute a one-time event, it's zero for a recurring event.
+	ExecuteAt time.Time `json:"execute_at"`
+	// IntervalValue and IntervalField are the interval of a recurring event, e.g. '1' and 'DAY'.
+	IntervalValue string    `json:"interval_value"`
+	IntervalField string    `json:"interval_field"`
+	Starts        time.Time `json:"starts"`
+	// Ends is zero if the recurring event never ends.
+	Ends        time.Time `json:"ends"`
+	Preserve    bool      `json:"preserve"`
+	Status      string    `json:"status"`
+	Comment     string    `json:"comment"`
+	Created     time.Time `json:"created"`
+	LastAltered time.Time `json:"last_altered"`
+}
+
+// IsRecurring returns whether the event is a recurring event.
+func (e *EventInfo) IsRecurring() bool {
+	return e.ExecuteAt.IsZero()
+}
+
+// Definer returns the definer of the event in the form of `user@host`.
+func (e *EventInfo) Definer() string {
+	return (&auth.UserIdentity{Username: e.DefinerUser, Hostname: e.DefinerHost}).String()
+}
+
+// Interval returns the interval of a recurring event.
+func (e *EventInfo) Interval() (time.Duration, error) {
+	return ParseInterval(e.IntervalValue, e.IntervalField)
+}
+
+// ParseInterval parses the interval of a recurring event. The intervals based on months
+// are not supported because the length of them are not fixed.
+func ParseInterval(value, field string) (time.Duration, error) {
+	field = strings.ToUpper(field)
+	if strings.Contains(field, "MICROSECOND") {
+		return 0, dbterror.ErrNotSupportedYet.GenWithStackByArgs("MICROSECOND in the interval of an event")
+	}
+	years, months, days, nanos, _, err := types.ParseDurationValue(field, value)
+	if err != nil {
+		return 0, err
+	}
+	if years != 0 || months != 0 {
+		return 0, dbterror.ErrNotSupportedYet.GenWithStackByArgs(field + " in the interval of an event")
+	}
+	if days < 0 || nanos < 0 || days > int64(maxInterval/(24*time.Hour)) {
+		return 0, exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	interval := time.Duration(days)*24*time.Hour + time.Duration(nanos)
+	if interval <= 0 || interval > maxInterval {
+		return 0, exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	return interval, nil
+}
+
+// timerSchedule returns the interval expression and the watermark of the event's timer.
+// The timer triggers at `watermark + interval`, so for a recurring event the watermark is
+// anchored at one interval before STARTS. A one-time event uses an interval from now to AT.
+func (e *EventInfo) timerSchedule(now time.Time) (string, time.Time, error) {
+	if !e.IsRecurring() {
+		watermark := now.Truncate(time.Second)
+		interval := e.ExecuteAt.Sub(watermark)
+		if interval < time.Second {
+			interval = time.Second
+		}
+		return formatInterval(interval), watermark, nil
+	}
+	interval, err := e.Interval()
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	starts := e.Starts
+	if starts.IsZero() {
+		starts = now
+	}
+	return formatInterval(interval), starts.Add(-interval), nil
+}
+
+// timerSchedOptions returns the schedule options of the event's timer. A recurring event is only scheduled between
+// STARTS and ENDS, and the executions missed when the cluster is down or the event is disabled are skipped.
+func (e *EventInfo) timerSchedOptions(now time.Time) timerapi.SchedOptions {
+	if !e.IsRecurring() {
+		return timerapi.SchedOptions{}
+	}
+	starts := e.Starts
+	if starts.IsZero() {
+		starts = now
+	}
+	return timerapi.SchedOptions{
+		SchedStartTime:     starts,
+		SchedEndTime:       e.Ends,
+		SchedMisfirePolicy: timerapi.MisfireSkip,
+	}
+}
+
+// nextWatermark returns the watermark after an execution of a recurring event, it's kept
+// on the schedule of the event even if the executions are missed.
+func nextWatermark(watermark time.Time, interval time.Duration, now time.Time) time.Time {
+	watermark = watermark.Add(interval)
+	if behind := now.Sub(watermark); behind >= interval {
+		watermark = watermark.Add(behind / interval * interval)
+	}
+	return watermark
+}
+
+func formatInterval(interval time.Duration) string {
+	return strconv.FormatFloat(interval.Seconds(), 'f', -1, 64) + "s"
+}
+
+// EventSummary is the summary of the last execution of an event, it's stored as the summary data of the timer.
+type EventSummary struct {
+	LastExecuted time.Time `json:"last_executed"`
+	LastStatus   string    `json:"last_status"`
+	LastError    string    `json:"last_error,omitempty"`
+}
+
+// Event is an event with the status of its timer.
+type Event struct {
+	EventInfo
+	Summary EventSummary
+	timerID string
+}
@@ -0,0 +1,124 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsched
+
+import (
+	"testing"
+	"time"
+
+	timerapi "github.com/pingcap/tidb/timer/api"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInterval(t *testing.T) {
+	for _, c := range []struct {
+		value    string
+		field    string
+		interval time.Duration
+	}{
+		{"1", "SECOND", time.Second},
+		{"30", "minute", 30 * time.Minute},
+		{"2", "DAY", 48 * time.Hour},
+		{"1", "WEEK", 7 * 24 * time.Hour},
+		{"1:30", "HOUR_MINUTE", 90 * time.Minute},
+		{"1 12", "DAY_HOUR", 36 * time.Hour},
+	} {
+		interval, err := ParseInterval(c.value, c.field)
+		require.NoError(t, err)
+		require.Equal(t, c.interval, interval)
+	}
+
+	_, err := ParseInterval("0", "DAY")
+	require.True(t, exeerrors.ErrEventIntervalNotPositiveOrTooBig.Equal(err))
+	_, err = ParseInterval("-1", "HOUR")
+	require.True(t, exeerrors.ErrEventIntervalNotPositiveOrTooBig.Equal(err))
+	_, err = ParseInterval("100", "YEAR")
+	require.True(t, dbterror.ErrNotSupportedYet.Equal(err))
+	_, err = ParseInterval("1", "MONTH")
+	require.True(t, dbterror.ErrNotSupportedYet.Equal(err))
+	_, err = ParseInterval("10", "MICROSECOND")
+	require.True(t, dbterror.ErrNotSupportedYet.Equal(err))
+}
+
+func TestTimerSchedule(t *testing.T) {
+	now := time.Date(2023, 7, 1, 10, 0, 0, 500, time.UTC)
+
+	info := &EventInfo{ExecuteAt: time.Date(2023, 7, 1, 11, 0, 0, 0, time.UTC)}
+	expr, watermark, err := info.timerSchedule(now)
+	require.NoError(t, err)
+	require.Equal(t, "3600s", expr)
+	require.Equal(t, now.Truncate(time.Second), watermark)
+
+	info = &EventInfo{IntervalValue: "10", IntervalField: "MINUTE"}
+	expr, watermark, err = info.timerSchedule(now)
+	require.NoError(t, err)
+	require.Equal(t, "600s", expr)
+	require.Equal(t, now.Add(-10*time.Minute), watermark)
+
+	info.Starts = now.Add(time.Hour)
+	_, watermark, err = info.timerSchedule(now)
+	require.NoError(t, err)
+	require.Equal(t, now.Add(50*time.Minute), watermark)
+}
+
+func TestTimerSchedOptions(t *testing.T) {
+	now := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+
+	info := &EventInfo{ExecuteAt: now.Add(time.Hour)}
+	require.Equal(t, timerapi.SchedOptions{}, info.timerSchedOptions(now))
+
+	info = &EventInfo{IntervalValue: "10", IntervalField: "MINUTE"}
+	require.Equal(t, timerapi.SchedOptions{
+		SchedStartTime:     now,
+		SchedMisfirePolicy: timerapi.MisfireSkip,
+	}, info.timerSchedOptions(now))
+
+	info.Starts, info.Ends = now.Add(time.Hour), now.Add(2*time.Hour)
+	opts := info.timerSchedOptions(now)
+	require.Equal(t, timerapi.SchedOptions{
+		SchedStartTime:     info.Starts,
+		SchedEndTime:       info.Ends,
+		SchedMisfirePolicy: timerapi.MisfireSkip,
+	}, opts)
+
+	// The timer has no event to schedule after ENDS.
+	expr, watermark, err := info.timerSchedule(now)
+	require.NoError(t, err)
+	timer := &timerapi.TimerRecord{TimerSpec: timerapi.TimerSpec{
+		SchedPolicyType: timerapi.SchedEventInterval,
+		SchedPolicyExpr: expr,
+		SchedOptions:    opts,
+		Watermark:       watermark,
+		Enable:          true,
+	}}
+	next, ok, err := timer.ScheduledEventTime()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, info.Starts, next)
+	timer.Watermark = info.Ends
+	_, ok, err = timer.ScheduledEventTime()
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestNextWatermark(t *testing.T) {
+	watermark := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+	require.Equal(t, watermark.Add(time.Minute), nextWatermark(watermark, time.Minute, watermark.Add(time.Minute)))
+	require.Equal(t, watermark.Add(time.Minute), nextWatermark(watermark, time.Minute, watermark.Add(90*time.Second)))
+	// The missed executions are skipped.
+	require.Equal(t, watermark.Add(5*time.Minute), nextWatermark(watermark, time.Minute, watermark.Add(330*time.Second)))
+}
@@ -0,0 +1,191 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsched
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const insertHistorySQL = `INSERT INTO mysql.tidb_event_history
+	(event_id, event_schema, event_name, definer, start_time, end_time, status, error_message)
+	VALUES (%?, %?, %?, %?, FROM_UNIXTIME(%?), FROM_UNIXTIME(%?), %?, %?)`
+
+type eventHook struct {
+	pool    sessionPool
+	factory SessionFactory
+	cli     timerapi.TimerClient
+	ctx     context.Context
+	cancel  func()
+	wg      sync.WaitGroup
+}
+
+func newEventHook(pool sessionPool, factory SessionFactory, cli timerapi.TimerClient) *eventHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &eventHook{
+		pool:    pool,
+		factory: factory,
+		cli:     cli,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+func (*eventHook) Start() {}
+
+func (h *eventHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*eventHook) OnPreSchedEvent(context.Context, timerapi.TimerShedEvent) (timerapi.PreSchedEventResult, error) {
+	return timerapi.PreSchedEventResult{}, nil
+}
+
+// OnSchedEvent executes the body of the event in the background as the definer. The result
+// is recorded in `mysql.tidb_event_history`, and the event is closed when the execution is done.
+func (h *eventHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	var info EventInfo
+	if err := json.Unmarshal(timer.Data, &info); err != nil {
+		return err
+	}
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		logger := logutil.BgLogger().With(zap.String("key", timer.Key), zap.String("eventID", event.EventID()))
+		if err := h.onEvent(timer, event.EventID(), &info); err != nil {
+			logger.Warn("failed to close event", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (h *eventHook) onEvent(timer *timerapi.TimerRecord, eventID string, info *EventInfo) error {
+	start := time.Now()
+	summary := EventSummary{LastExecuted: start, LastStatus: execStatusSuccess}
+	var errMsg interface{}
+	if err := h.execute(info); err != nil {
+		logutil.BgLogger().Warn("failed to execute event", zap.String("key", timer.Key), zap.Error(err))
+		summary.LastStatus, summary.LastError = execStatusFailed, err.Error()
+		errMsg = summary.LastError
+	}
+	err := execSQL(h.ctx, h.pool, insertHistorySQL, eventID, info.Schema, info.Name, info.Definer(),
+		start.Unix(), time.Now().Unix(), summary.LastStatus, errMsg)
+	if err != nil {
+		logutil.BgLogger().Warn("failed to record event history", zap.String("key", timer.Key), zap.Error(err))
+	}
+	summaryData, err := json.Marshal(&summary)
+	if err != nil {
+		return err
+	}
+
+	watermark := timer.EventStart
+	completed := !info.IsRecurring()
+	if !completed {
+		interval, err := info.Interval()
+		if err != nil {
+			return err
+		}
+		watermark = nextWatermark(timer.Watermark, interval, time.Now())
+		// The event is completed if the timer has no event to schedule after the watermark, e.g. it's after ENDS.
+		next := timer.Clone()
+		next.Enable, next.Watermark = true, watermark
+		_, ok, err := next.ScheduledEventTime()
+		if err != nil {
+			return err
+		}
+		completed = !ok
+	}
+	if completed && !info.Preserve {
+		return completeEvent(h.ctx, h.cli, timer.ID, info)
+	}
+	err = h.cli.CloseTimerEvent(h.ctx, timer.ID, eventID,
+		timerapi.WithSetWatermark(watermark), timerapi.WithSetSummaryData(summaryData))
+	if err != nil || !completed {
+		return err
+	}
+	return completeEvent(h.ctx, h.cli, timer.ID, info)
+}
+
+// completeEvent drops the completed event, or disables it if ON COMPLETION PRESERVE is specified.
+func completeEvent(ctx context.Context, cli timerapi.TimerClient, timerID string, info *EventInfo) error {
+	if !info.Preserve {
+		_, err := cli.DeleteTimer(ctx, timerID)
+		return err
+	}
+	info.Status = StatusDisabled
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return cli.UpdateTimer(ctx, timerID, timerapi.WithSetData(data), timerapi.WithSetEnable(false))
+}
+
+// execute executes the body of the event with the session environment when it's created.
+func (h *eventHook) execute(info *EventInfo) error {
+	sess, err := h.factory(info.DefinerUser, info.DefinerHost)
+	if err != nil {
+		return err
+	}
+	defer sess.Close()
+
+	vars := sess.GetSessionVars()
+	vars.CurrentDB = info.Schema
+	for name, val := range map[string]string{
+		variable.SQLModeVar:          info.SQLMode,
+		variable.TimeZone:            info.TimeZone,
+		variable.CharacterSetClient:  info.CharsetClient,
+		variable.CollationConnection: info.CollationConnection,
+	} {
+		if val == "" {
+			continue
+		}
+		if err = vars.SetSystemVar(name, val); err != nil {
+			return err
+		}
+	}
+
+	ctx := kv.WithInternalSourceType(h.ctx, kv.InternalTxnOthers)
+	stmts, err := sess.Parse(ctx, info.Body)
+	if err != nil {
+		return err
+	}
+	if len(stmts) != 1 {
+		return errors.Errorf("the body of event '%s' should be a single statement", info.Name)
+	}
+	rs, err := sess.ExecuteStmt(ctx, stmts[0])
+	if err != nil {
+		return err
+	}
+	if rs == nil {
+		return nil
+	}
+	_, err = sqlexec.DrainRecordSet(ctx, rs, 1024)
+	if closeErr := rs.Close(); err == nil {
+		err = closeErr
+	}
+	return errors.Trace(err)
+}
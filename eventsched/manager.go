@@ -0,0 +1,351 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package eventsched
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	timerrt "github.com/pingcap/tidb/timer/runtime"
+	"github.com/pingcap/tidb/timer/tablestore"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/logutil"
+	"github.com/pingcap/tidb/util/sqlexec"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	timerKeyPrefix      = "/tidb/event/"
+	timerHookClass      = "tidb.event"
+	managerLoopInterval = 5 * time.Second
+	// historyGCInterval is the interval to delete the expired execution history.
+	historyGCInterval = time.Hour
+	// historyRetention is how long the execution history of events is kept.
+	historyRetention = 90 * 24 * time.Hour
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// Session is the session to execute the body of an event.
+type Session interface {
+	sqlexec.SQLExecutor
+	Parse(ctx context.Context, sql string) ([]ast.StmtNode, error)
+	Close()
+}
+
+// SessionFactory creates a session with the privileges of the definer of an event.
+// An empty user means the event is created by an internal session, and the session
+// is created without a user.
+type SessionFactory func(user, host string) (Session, error)
+
+// Manager manages the events. The events are stored as timers, and they are scheduled
+// by a timer runtime on the DDL owner.
+type Manager struct {
+	pool    sessionPool
+	factory SessionFactory
+	store   *timerapi.TimerStore
+	cli     timerapi.TimerClient
+	isOwner func() bool
+	rt      *timerrt.TimerGroupRuntime
+
+	lastGCTime time.Time
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// NewManager creates a new Manager.
+func NewManager(pool sessionPool, factory SessionFactory, etcd *clientv3.Client, isOwner func() bool) *Manager {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		pool:    pool,
+		factory: factory,
+		store:   store,
+		cli:     timerapi.NewDefaultTimerClient(store),
+		isOwner: isOwner,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start starts the loop of the manager.
+func (m *Manager) Start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(managerLoopInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.ctx.Done():
+				m.pause()
+				m.store.Close()
+				return
+			case <-ticker.C:
+				m.onTick()
+			}
+		}
+	}()
+}
+
+// Stop stops the manager and waits for it to exit.
+func (m *Manager) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+func (m *Manager) onTick() {
+	if !m.isOwner() {
+		m.pause()
+		return
+	}
+	m.resume()
+
+	if time.Since(m.lastGCTime) > historyGCInterval {
+		if err := m.gcHistory(m.ctx); err != nil {
+			logutil.BgLogger().Warn("failed to delete the expired event history", zap.Error(err))
+			return
+		}
+		if err := m.completeEndedEvents(m.ctx); err != nil {
+			logutil.BgLogger().Warn("failed to complete the ended events", zap.Error(err))
+			return
+		}
+		m.lastGCTime = time.Now()
+	}
+}
+
+func (m *Manager) resume() {
+	if m.rt != nil {
+		return
+	}
+	m.rt = timerrt.NewTimerRuntimeBuilder("event", m.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(_ string, cli timerapi.TimerClient) timerapi.Hook {
+			return newEventHook(m.pool, m.factory, cli)
+		}).
+		Build()
+	m.rt.Start()
+}
+
+func (m *Manager) pause() {
+	if rt := m.rt; rt != nil {
+		m.rt = nil
+		rt.Stop()
+	}
+}
+
+func (m *Manager) gcHistory(ctx context.Context) error {
+	return execSQL(ctx, m.pool, "DELETE FROM mysql.tidb_event_history WHERE start_time < FROM_UNIXTIME(%?)",
+		time.Now().Add(-historyRetention).Unix())
+}
+
+// completeEndedEvents completes the events whose timers will never be triggered again. The events are completed by
+// their last executions normally, but the executions are skipped if ENDS passes when the cluster is down.
+func (m *Manager) completeEndedEvents(ctx context.Context) error {
+	timers, err := m.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	for _, timer := range timers {
+		if !timer.Enable || timer.EventStatus != timerapi.SchedEventIdle {
+			continue
+		}
+		if _, ok, err := timer.NextEventTimeAt(now); err != nil || ok {
+			continue
+		}
+		var info EventInfo
+		if err = json.Unmarshal(timer.Data, &info); err != nil {
+			continue
+		}
+		if err = completeEvent(ctx, m.cli, timer.ID, &info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func schemaKeyPrefix(schema string) string {
+	return timerKeyPrefix + strings.ToLower(schema) + "/"
+}
+
+func timerKey(schema, name string) string {
+	return schemaKeyPrefix(schema) + strings.ToLower(name)
+}
+
+// CreateEvent creates an event, it returns `ErrEventAlreadyExists` if the event exists.
+func (m *Manager) CreateEvent(ctx context.Context, info *EventInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	expr, watermark, err := info.timerSchedule(now)
+	if err != nil {
+		return err
+	}
+	_, err = m.cli.CreateTimer(ctx, timerapi.TimerSpec{
+		Key:             timerKey(info.Schema, info.Name),
+		Data:            data,
+		SchedPolicyType: timerapi.SchedEventInterval,
+		SchedPolicyExpr: expr,
+		SchedOptions:    info.timerSchedOptions(now),
+		HookClass:       timerHookClass,
+		Watermark:       watermark,
+		Enable:          info.Status == StatusEnabled,
+	})
+	if kv.ErrKeyExists.Equal(err) || errors.ErrorEqual(err, timerapi.ErrTimerExists) {
+		return exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(info.Name)
+	}
+	return err
+}
+
+// GetEvent returns the event, it returns nil if the event does not exist.
+func (m *Manager) GetEvent(ctx context.Context, schema, name string) (*Event, error) {
+	timer, err := m.cli.GetTimerByKey(ctx, timerKey(schema, name))
+	if err != nil {
+		if errors.ErrorEqual(err, timerapi.ErrTimerNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return eventFromTimer(timer)
+}
+
+// GetEvents returns the events of a schema, or all the events if the schema is empty.
+func (m *Manager) GetEvents(ctx context.Context, schema string) ([]*Event, error) {
+	prefix := timerKeyPrefix
+	if schema != "" {
+		prefix = schemaKeyPrefix(schema)
+	}
+	timers, err := m.cli.GetTimers(ctx, timerapi.WithKeyPrefix(prefix))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]*Event, 0, len(timers))
+	for _, timer := range timers {
+		event, err := eventFromTimer(timer)
+		if err != nil {
+			return nil, err
+		}
+		// The names of schemas may contain '/', so the prefix may match the events of other schemas.
+		if schema != "" && !strings.EqualFold(event.Schema, schema) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// AlterEvent replaces the definition of an event with `info`. The schedule of the event is
+// reset if `resetSchedule` is true, otherwise the next execution is not changed.
+func (m *Manager) AlterEvent(ctx context.Context, event *Event, info *EventInfo, resetSchedule bool) error {
+	if timerKey(event.Schema, event.Name) != timerKey(info.Schema, info.Name) {
+		// Renaming an event changes the key of the timer, so create a new timer and drop the old one.
+		if err := m.CreateEvent(ctx, info); err != nil {
+			return err
+		}
+		_, err := m.cli.DeleteTimer(ctx, event.timerID)
+		return err
+	}
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	opts := []timerapi.UpdateTimerOption{
+		timerapi.WithSetData(data),
+		timerapi.WithSetEnable(info.Status == StatusEnabled),
+	}
+	if resetSchedule {
+		now := time.Now()
+		expr, watermark, err := info.timerSchedule(now)
+		if err != nil {
+			return err
+		}
+		opts = append(opts,
+			timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, expr),
+			timerapi.WithSetSchedOptions(info.timerSchedOptions(now)),
+			timerapi.WithSetWatermark(watermark),
+		)
+	}
+	return m.cli.UpdateTimer(ctx, event.timerID, opts...)
+}
+
+// DropEvent drops an event, it returns false if the event does not exist.
+func (m *Manager) DropEvent(ctx context.Context, schema, name string) (bool, error) {
+	event, err := m.GetEvent(ctx, schema, name)
+	if err != nil || event == nil {
+		return false, err
+	}
+	return m.cli.DeleteTimer(ctx, event.timerID)
+}
+
+// DropEventsOfSchema drops all the events of a schema.
+func (m *Manager) DropEventsOfSchema(ctx context.Context, schema string) error {
+	events, err := m.GetEvents(ctx, schema)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		if _, err = m.cli.DeleteTimer(ctx, event.timerID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func eventFromTimer(timer *timerapi.TimerRecord) (*Event, error) {
+	event := &Event{timerID: timer.ID}
+	if err := json.Unmarshal(timer.Data, &event.EventInfo); err != nil {
+		return nil, err
+	}
+	if len(timer.SummaryData) > 0 {
+		if err := json.Unmarshal(timer.SummaryData, &event.Summary); err != nil {
+			return nil, err
+		}
+	}
+	return event, nil
+}
+
+func execSQL(ctx context.Context, pool sessionPool, sql string, args ...interface{}) error {
+	resource, err := pool.Get()
+	if err != nil {
+		return err
+	}
+	defer pool.Put(resource)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rs, err := resource.(sqlexec.SQLExecutor).ExecuteInternal(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	if rs != nil {
+		return rs.Close()
+	}
+	return nil
+}
@@ -534,10 +534,21 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 		stmtCtx := sctx.GetSessionVars().StmtCtx
 		_, planDigest := GetPlanDigest(stmtCtx)
 		_, digest := stmtCtx.SQLDigest()
-		stmtCtx.RunawayChecker = domain.GetDomain(sctx).RunawayManager().DeriveChecker(sctx.GetSessionVars().ResourceGroupName, stmtCtx.OriginalSQL, digest.String(), planDigest.String())
+		var runawayRules *model.ResourceGroupRunawaySettings
+		if group, ok := a.InfoSchema.ResourceGroupByName(model.NewCIStr(sctx.GetSessionVars().ResourceGroupName)); ok {
+			runawayRules = group.Runaway
+		}
+		stmtCtx.RunawayChecker = domain.GetDomain(sctx).RunawayManager().DeriveChecker(sctx.GetSessionVars().ResourceGroupName, runawayRules, stmtCtx.OriginalSQL, digest.String(), planDigest.String())
 		if err := stmtCtx.RunawayChecker.BeforeExecutor(); err != nil {
 			return nil, err
 		}
+		// the locks and the commit of the query switched by the watch item use the resource group it's switched to.
+		if name := stmtCtx.RunawayChecker.SwitchGroupName(); len(name) > 0 {
+			if txn, err := sctx.Txn(false); err == nil && txn.Valid() {
+				kv.SetTxnResourceGroup(txn, name)
+			}
+		}
+		stmtCtx.RunawayChecker.AttachMemoryTracker(stmtCtx.MemTracker)
 	}
 
 	breakpoint.Inject(a.Ctx, sessiontxn.BreakPointBeforeExecutorFirstRun)
@@ -572,6 +583,9 @@ func (a *ExecStmt) Exec(ctx context.Context) (_ sqlexec.RecordSet, err error) {
 	}
 
 	a.prepareFKCascadeContext(e)
+	if name := sctx.GetSessionVars().StmtCtx.ForeignKeyTriggerCtx.SavepointName; name != "" {
+		defer sctx.GetSessionVars().TxnCtx.ReleaseSavepoint(name)
+	}
 	if handled, result, err := a.handleNoDelay(ctx, e, isPessimistic); handled || err != nil {
 		return result, err
 	}
@@ -599,13 +613,23 @@ func (a *ExecStmt) getSQLForProcessInfo() string {
 			// Use SecureText to avoid leak password information.
 			sql = ss.SecureText()
 		}
-	} else if sn, ok2 := a.StmtNode.(ast.SensitiveStmtNode); ok2 {
+	} else if sn, ok2 := getSensitiveStmt(a.StmtNode); ok2 {
 		// such as import into statement
 		sql = sn.SecureText()
 	}
 	return sql
 }
 
+// getSensitiveStmt returns the statement if its text should be redacted before being shown or logged.
+// SELECT statements are sensitive only when they write into an URI, which may contain credentials.
+func getSensitiveStmt(node ast.StmtNode) (ast.SensitiveStmtNode, bool) {
+	if sel, ok := node.(*ast.SelectStmt); ok && (sel.SelectIntoOpt == nil || !sel.SelectIntoOpt.IsURI()) {
+		return nil, false
+	}
+	sn, ok := node.(ast.SensitiveStmtNode)
+	return sn, ok
+}
+
 func (a *ExecStmt) handleStmtForeignKeyTrigger(ctx context.Context, e exec.Executor) error {
 	stmtCtx := a.Ctx.GetSessionVars().StmtCtx
 	if stmtCtx.ForeignKeyTriggerCtx.HasFKCascades {
@@ -615,7 +639,7 @@ func (a *ExecStmt) handleStmtForeignKeyTrigger(ctx context.Context, e exec.Execu
 		// then the fk cascade executor can't read the mem-buffer changed by the ExecStmt.
 		a.Ctx.StmtCommit(ctx)
 	}
-	err := a.handleForeignKeyTrigger(ctx, e, 1)
+	err := handleForeignKeyTrigger(ctx, a.Ctx, e, 1)
 	if err != nil {
 		err1 := a.handleFKTriggerError(stmtCtx)
 		if err1 != nil {
@@ -623,15 +647,13 @@ func (a *ExecStmt) handleStmtForeignKeyTrigger(ctx context.Context, e exec.Execu
 		}
 		return err
 	}
-	if stmtCtx.ForeignKeyTriggerCtx.SavepointName != "" {
-		a.Ctx.GetSessionVars().TxnCtx.ReleaseSavepoint(stmtCtx.ForeignKeyTriggerCtx.SavepointName)
-	}
 	return nil
 }
 
 var maxForeignKeyCascadeDepth = 15
 
-func (a *ExecStmt) handleForeignKeyTrigger(ctx context.Context, e exec.Executor, depth int) error {
+// handleForeignKeyTrigger handles the foreign key checks and cascades of the executor.
+func handleForeignKeyTrigger(ctx context.Context, sctx sessionctx.Context, e exec.Executor, depth int) error {
 	exec, ok := e.(WithForeignKeyTrigger)
 	if !ok {
 		return nil
@@ -645,7 +667,7 @@ func (a *ExecStmt) handleForeignKeyTrigger(ctx context.Context, e exec.Executor,
 	}
 	fkCascades := exec.GetFKCascades()
 	for _, fkCascade := range fkCascades {
-		err := a.handleForeignKeyCascade(ctx, fkCascade, depth)
+		err := handleForeignKeyCascade(ctx, sctx, fkCascade, depth)
 		if err != nil {
 			return err
 		}
@@ -664,10 +686,10 @@ func (a *ExecStmt) handleForeignKeyTrigger(ctx context.Context, e exec.Executor,
 //  3. Close the executor.
 //  4. `StmtCommit` to commit the kv change to transaction mem-buffer.
 //  5. If the foreign key cascade behaviour has more fk value need to be cascaded, go to step 1.
-func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeExec, depth int) error {
-	if a.Ctx.GetSessionVars().StmtCtx.RuntimeStatsColl != nil {
+func handleForeignKeyCascade(ctx context.Context, sctx sessionctx.Context, fkc *FKCascadeExec, depth int) error {
+	if sctx.GetSessionVars().StmtCtx.RuntimeStatsColl != nil {
 		fkc.stats = &FKCascadeRuntimeStats{}
-		defer a.Ctx.GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(fkc.plan.ID(), fkc.stats)
+		defer sctx.GetSessionVars().StmtCtx.RuntimeStatsColl.RegisterStats(fkc.plan.ID(), fkc.stats)
 	}
 	if len(fkc.fkValues) == 0 && len(fkc.fkUpdatedValuesMap) == 0 {
 		return nil
@@ -675,9 +697,11 @@ func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeEx
 	if depth > maxForeignKeyCascadeDepth {
 		return exeerrors.ErrForeignKeyCascadeDepthExceeded.GenWithStackByArgs(maxForeignKeyCascadeDepth)
 	}
-	a.Ctx.GetSessionVars().StmtCtx.InHandleForeignKeyTrigger = true
+	// The cascades may be executed by the statements in triggers, which are also handled as foreign key triggers.
+	inHandleForeignKeyTrigger := sctx.GetSessionVars().StmtCtx.InHandleForeignKeyTrigger
+	sctx.GetSessionVars().StmtCtx.InHandleForeignKeyTrigger = true
 	defer func() {
-		a.Ctx.GetSessionVars().StmtCtx.InHandleForeignKeyTrigger = false
+		sctx.GetSessionVars().StmtCtx.InHandleForeignKeyTrigger = inHandleForeignKeyTrigger
 	}()
 	if fkc.stats != nil {
 		start := time.Now()
@@ -704,8 +728,8 @@ func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeEx
 		}
 		// Call `StmtCommit` uses to flush the fk cascade executor change into txn mem-buffer,
 		// then the later fk cascade executors can see the mem-buffer changes.
-		a.Ctx.StmtCommit(ctx)
-		err = a.handleForeignKeyTrigger(ctx, e, depth+1)
+		sctx.StmtCommit(ctx)
+		err = handleForeignKeyTrigger(ctx, sctx, e, depth+1)
 		if err != nil {
 			return err
 		}
@@ -713,29 +737,33 @@ func (a *ExecStmt) handleForeignKeyCascade(ctx context.Context, fkc *FKCascadeEx
 }
 
 // prepareFKCascadeContext records a transaction savepoint for foreign key cascade when this ExecStmt has foreign key
-// cascade behaviour and this ExecStmt is in transaction.
+// cascade behaviour and this ExecStmt is in transaction, or when this ExecStmt has triggers.
 func (a *ExecStmt) prepareFKCascadeContext(e exec.Executor) {
 	exec, ok := e.(WithForeignKeyTrigger)
-	if !ok || !exec.HasFKCascades() {
+	hasTriggers := ok && len(exec.GetTriggers()) > 0
+	if !ok || (!exec.HasFKCascades() && !hasTriggers) {
 		return
 	}
 	sessVar := a.Ctx.GetSessionVars()
 	sessVar.StmtCtx.ForeignKeyTriggerCtx.HasFKCascades = true
-	if !sessVar.InTxn() {
+	// The triggers flush the changes of the statement before they are fired, so the savepoint is also required to
+	// roll back the statement before it's retried for the pessimistic lock errors, even if it's not in transaction.
+	if !sessVar.InTxn() && !hasTriggers {
 		return
 	}
-	txn, err := a.Ctx.Txn(false)
+	txn, err := a.Ctx.Txn(hasTriggers)
 	if err != nil || !txn.Valid() {
 		return
 	}
-	// Record a txn savepoint if ExecStmt in transaction, the savepoint is use to do rollback when handle foreign key
-	// cascade failed.
+	// Record a txn savepoint, the savepoint is use to do rollback when handle foreign key cascade or triggers failed.
 	savepointName := "fk_sp_" + strconv.FormatUint(txn.StartTS(), 10)
 	memDBCheckpoint := txn.GetMemDBCheckpoint()
 	sessVar.TxnCtx.AddSavepoint(savepointName, memDBCheckpoint)
 	sessVar.StmtCtx.ForeignKeyTriggerCtx.SavepointName = savepointName
 }
 
+// handleFKTriggerError rolls back the changes of the statement to the savepoint recorded by prepareFKCascadeContext.
+// The savepoint is kept until the statement finishes, because the statement may be retried for pessimistic lock errors.
 func (a *ExecStmt) handleFKTriggerError(sc *stmtctx.StatementContext) error {
 	if sc.ForeignKeyTriggerCtx.SavepointName == "" {
 		return nil
@@ -754,7 +782,6 @@ func (a *ExecStmt) handleFKTriggerError(sc *stmtctx.StatementContext) error {
 		return errors.Errorf("foreign key cascade savepoint '%s' not found, transaction is rollback, should never happen", sc.ForeignKeyTriggerCtx.SavepointName)
 	}
 	txn.RollbackMemDBToCheckpoint(savepointRecord.MemDBCheckpoint)
-	a.Ctx.GetSessionVars().TxnCtx.ReleaseSavepoint(sc.ForeignKeyTriggerCtx.SavepointName)
 	return nil
 }
 
@@ -967,6 +994,11 @@ func (a *ExecStmt) handleNoDelayExecutor(ctx context.Context, e exec.Executor) (
 
 	err = a.next(ctx, e, exec.TryNewCacheChunk(e))
 	if err != nil {
+		// The triggers flush the changes of the statement before they are fired, so the changes have to be rolled
+		// back to the savepoint.
+		if err1 := a.handleFKTriggerError(sctx.GetSessionVars().StmtCtx); err1 != nil {
+			return nil, errors.Errorf("handle trigger error failed, err: %v, original_err: %v", err1, err)
+		}
 		return nil, err
 	}
 	err = a.handleStmtForeignKeyTrigger(ctx, e)
@@ -1091,6 +1123,10 @@ func (a *ExecStmt) handlePessimisticLockError(ctx context.Context, lockErr error
 	if lockErr == nil {
 		return nil, nil
 	}
+	// The changes flushed by the triggers are rolled back to the savepoint, whether the statement is retried or not.
+	if err = a.handleFKTriggerError(a.Ctx.GetSessionVars().StmtCtx); err != nil {
+		return nil, err
+	}
 	failpoint.Inject("assertPessimisticLockErr", func() {
 		if terror.ErrorEqual(kv.ErrWriteConflict, lockErr) {
 			sessiontxn.AddAssertEntranceForLockError(a.Ctx, "errWriteConflict")
@@ -1945,7 +1981,7 @@ func (a *ExecStmt) GetTextToLog(keepHint bool) string {
 		} else {
 			sql, _ = sessVars.StmtCtx.SQLDigest()
 		}
-	} else if sensitiveStmt, ok := a.StmtNode.(ast.SensitiveStmtNode); ok {
+	} else if sensitiveStmt, ok := getSensitiveStmt(a.StmtNode); ok {
 		sql = sensitiveStmt.SecureText()
 	} else {
 		sql = sessVars.StmtCtx.OriginalSQL + sessVars.PlanCacheParams.String()
@@ -109,6 +109,22 @@ var (
 
 	// All the AggFunc implementations for "JSON_OBJECTAGG" are listed here
 	_ AggFunc = (*jsonObjectAgg)(nil)
+
+	// All the AggFunc implementations for "HLL_SKETCH" and "HLL_MERGE" are listed here.
+	_ AggFunc = (*hllSketchOriginal)(nil)
+	_ AggFunc = (*hllSketchMerge)(nil)
+
+	// All the AggFunc implementations for "APPROX_TOP_K" are listed here.
+	_ AggFunc = (*approxTopKOriginal)(nil)
+	_ AggFunc = (*approxTopKPartial1)(nil)
+	_ AggFunc = (*approxTopKPartial2)(nil)
+	_ AggFunc = (*approxTopKFinal)(nil)
+
+	// All the AggFunc implementations for the custom aggregate functions registered by extension are listed here.
+	_ AggFunc = (*extensionAggOriginal)(nil)
+	_ AggFunc = (*extensionAggPartial1)(nil)
+	_ AggFunc = (*extensionAggPartial2)(nil)
+	_ AggFunc = (*extensionAggFinal)(nil)
 )
 
 const (
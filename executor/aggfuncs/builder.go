@@ -21,6 +21,7 @@ import (
 
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/extension"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/sessionctx"
@@ -68,11 +69,18 @@ func Build(ctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal
 		return buildApproxCountDistinct(aggFuncDesc, ordinal)
 	case ast.AggFuncApproxPercentile:
 		return buildApproxPercentile(ctx, aggFuncDesc, ordinal)
+	case ast.AggFuncApproxTopK:
+		return buildApproxTopK(ctx, aggFuncDesc, ordinal)
+	case ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
+		return buildHLLSketch(aggFuncDesc, ordinal)
 	case ast.AggFuncVarSamp:
 		return buildVarSamp(aggFuncDesc, ordinal)
 	case ast.AggFuncStddevSamp:
 		return buildStddevSamp(aggFuncDesc, ordinal)
 	}
+	if def, ok := aggregation.GetExtensionAggFunc(aggFuncDesc.Name); ok {
+		return buildExtensionAgg(aggFuncDesc, def, ordinal)
+	}
 	return nil
 }
 
@@ -185,6 +193,87 @@ func buildApproxPercentile(sctx sessionctx.Context, aggFuncDesc *aggregation.Agg
 	return nil
 }
 
+// buildHLLSketch builds the AggFunc implementation for function "HLL_SKETCH" and "HLL_MERGE".
+func buildHLLSketch(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	base := approxCountDistinctOriginal{baseApproxCountDistinct{baseAggFunc{
+		args:    aggFuncDesc.Args,
+		ordinal: ordinal,
+	}}}
+	switch aggFuncDesc.Mode {
+	case aggregation.CompleteMode, aggregation.Partial1Mode:
+		if aggFuncDesc.Name == ast.AggFuncHLLSketch {
+			return &hllSketchOriginal{base}
+		}
+		return &hllSketchMerge{approxCountDistinctPartial2{approxCountDistinctPartial1{base}}}
+	case aggregation.Partial2Mode, aggregation.FinalMode:
+		// The input of both functions is a serialized sketch in these modes.
+		return &hllSketchMerge{approxCountDistinctPartial2{approxCountDistinctPartial1{base}}}
+	}
+	return nil
+}
+
+// buildApproxTopK builds the AggFunc implementation for function "APPROX_TOP_K".
+func buildApproxTopK(sctx sessionctx.Context, aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
+	// Checked while building descriptor
+	k, _, err := aggFuncDesc.Args[len(aggFuncDesc.Args)-1].EvalInt(sctx, chunk.Row{})
+	if err != nil {
+		// Should not reach here
+		logutil.BgLogger().Error("Error happened when buildApproxTopK", zap.Error(err))
+		return nil
+	}
+	base := baseApproxTopK{baseAggFunc: baseAggFunc{args: aggFuncDesc.Args, ordinal: ordinal}, k: int(k)}
+
+	// Like approx_count_distinct, the partial result is passed as a string when
+	// the aggregation is pushed below a union.
+	switch aggFuncDesc.RetTp.GetType() {
+	case mysql.TypeJSON:
+		switch aggFuncDesc.Mode {
+		case aggregation.CompleteMode:
+			return &approxTopKOriginal{base}
+		case aggregation.Partial1Mode:
+			return &approxTopKPartial1{approxTopKOriginal{base}}
+		case aggregation.Partial2Mode:
+			return &approxTopKPartial2{approxTopKPartial1{approxTopKOriginal{base}}}
+		case aggregation.FinalMode:
+			return &approxTopKFinal{approxTopKPartial2{approxTopKPartial1{approxTopKOriginal{base}}}}
+		}
+	case mysql.TypeString:
+		switch aggFuncDesc.Mode {
+		case aggregation.CompleteMode, aggregation.Partial1Mode:
+			return &approxTopKPartial1{approxTopKOriginal{base}}
+		case aggregation.Partial2Mode, aggregation.FinalMode:
+			return &approxTopKPartial2{approxTopKPartial1{approxTopKOriginal{base}}}
+		}
+	}
+	return nil
+}
+
+// buildExtensionAgg builds the AggFunc implementation for the custom aggregate function registered by extension.
+func buildExtensionAgg(aggFuncDesc *aggregation.AggFuncDesc, def *extension.AggFunctionDef, ordinal int) AggFunc {
+	if aggFuncDesc.HasDistinct || len(aggFuncDesc.OrderByItems) > 0 {
+		return nil
+	}
+	base := extensionAggOriginal{
+		baseAggFunc: baseAggFunc{
+			args:    aggFuncDesc.Args,
+			ordinal: ordinal,
+			retTp:   aggFuncDesc.RetTp,
+		},
+		def: def,
+	}
+	switch aggFuncDesc.Mode {
+	case aggregation.CompleteMode:
+		return &base
+	case aggregation.Partial1Mode:
+		return &extensionAggPartial1{base}
+	case aggregation.Partial2Mode:
+		return &extensionAggPartial2{extensionAggPartial1{base}}
+	case aggregation.FinalMode:
+		return &extensionAggFinal{extensionAggPartial2{extensionAggPartial1{base}}}
+	}
+	return nil
+}
+
 // buildCount builds the AggFunc implementation for function "COUNT".
 func buildCount(aggFuncDesc *aggregation.AggFuncDesc, ordinal int) AggFunc {
 	// If mode is DedupMode, we return nil for not implemented.
@@ -0,0 +1,363 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"container/heap"
+	"sort"
+	"unsafe"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+const (
+	// DefPartialResult4ApproxTopKSize is the size of partialResult4ApproxTopK
+	DefPartialResult4ApproxTopKSize = int64(unsafe.Sizeof(partialResult4ApproxTopK{}))
+	// DefApproxTopKCounterSize is the size of approxTopKCounter
+	DefApproxTopKCounterSize = int64(unsafe.Sizeof(approxTopKCounter{}))
+
+	// approxTopKCapacityFactor is the ratio between the number of counters kept by the
+	// sketch and k. Keeping more counters than k makes the reported top k more accurate.
+	approxTopKCapacityFactor = 4
+)
+
+// approxTopKCounter is a monitored item of the space-saving sketch. key is the
+// encoded JSON value of the item, err is the over-estimation upper bound of count.
+type approxTopKCounter struct {
+	key   string
+	count uint64
+	err   uint64
+	index int
+}
+
+// approxTopKHeap is a min-heap of counters ordered by count.
+type approxTopKHeap []*approxTopKCounter
+
+func (h approxTopKHeap) Len() int { return len(h) }
+
+func (h approxTopKHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+
+func (h approxTopKHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *approxTopKHeap) Push(x interface{}) {
+	c := x.(*approxTopKCounter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *approxTopKHeap) Pop() interface{} {
+	old := *h
+	n := len(old)
+	c := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+	return c
+}
+
+// partialResult4ApproxTopK uses the `Space-Saving` algorithm to find the most frequent
+// items, see "Efficient Computation of Frequent and Top-k Elements in Data Streams"
+// by Metwally et al. At most `capacity` counters are kept; a new item evicts the
+// counter with the smallest count and inherits its count as error.
+// Two sketches are merged as described in "Mergeable Summaries" by Agarwal et al.
+type partialResult4ApproxTopK struct {
+	capacity int
+	counters map[string]*approxTopKCounter
+	minHeap  approxTopKHeap
+}
+
+func newPartialResult4ApproxTopK(capacity int) *partialResult4ApproxTopK {
+	return &partialResult4ApproxTopK{
+		capacity: capacity,
+		counters: make(map[string]*approxTopKCounter),
+	}
+}
+
+func (p *partialResult4ApproxTopK) reset() {
+	p.counters = make(map[string]*approxTopKCounter)
+	p.minHeap = p.minHeap[:0]
+}
+
+func (p *partialResult4ApproxTopK) full() bool {
+	return len(p.minHeap) >= p.capacity
+}
+
+// minCount returns the count every unmonitored item may have at most.
+func (p *partialResult4ApproxTopK) minCount() uint64 {
+	if !p.full() || len(p.minHeap) == 0 {
+		return 0
+	}
+	return p.minHeap[0].count
+}
+
+func (p *partialResult4ApproxTopK) insert(key string) (memDelta int64) {
+	if c, ok := p.counters[key]; ok {
+		c.count++
+		heap.Fix(&p.minHeap, c.index)
+		return 0
+	}
+	if !p.full() {
+		c := &approxTopKCounter{key: key, count: 1}
+		p.counters[key] = c
+		heap.Push(&p.minHeap, c)
+		return DefApproxTopKCounterSize + int64(len(key))
+	}
+	evicted := p.minHeap[0]
+	delete(p.counters, evicted.key)
+	c := &approxTopKCounter{key: key, count: evicted.count + 1, err: evicted.count}
+	p.counters[key] = c
+	p.minHeap[0] = c
+	heap.Fix(&p.minHeap, 0)
+	return int64(len(key)) - int64(len(evicted.key))
+}
+
+func (p *partialResult4ApproxTopK) memUsage() (sum int64) {
+	for _, c := range p.minHeap {
+		sum += DefApproxTopKCounterSize + int64(len(c.key))
+	}
+	return sum
+}
+
+// merge merges src into p. An item missing from one side may have appeared there
+// at most minCount() times, so that bound is added to its count and error.
+func (p *partialResult4ApproxTopK) merge(src *partialResult4ApproxTopK) (memDelta int64) {
+	oldMemUsage := p.memUsage()
+	dstMin, srcMin := p.minCount(), src.minCount()
+	merged := make([]*approxTopKCounter, 0, len(p.counters)+len(src.counters))
+	for key, c := range p.counters {
+		if s, ok := src.counters[key]; ok {
+			merged = append(merged, &approxTopKCounter{key: key, count: c.count + s.count, err: c.err + s.err})
+		} else {
+			merged = append(merged, &approxTopKCounter{key: key, count: c.count + srcMin, err: c.err + srcMin})
+		}
+	}
+	for key, s := range src.counters {
+		if _, ok := p.counters[key]; !ok {
+			merged = append(merged, &approxTopKCounter{key: key, count: s.count + dstMin, err: s.err + dstMin})
+		}
+	}
+	sortApproxTopKCounters(merged)
+	if len(merged) > p.capacity {
+		merged = merged[:p.capacity]
+	}
+	p.counters = make(map[string]*approxTopKCounter, len(merged))
+	p.minHeap = approxTopKHeap(merged)
+	for i, c := range merged {
+		c.index = i
+		p.counters[c.key] = c
+	}
+	heap.Init(&p.minHeap)
+	return p.memUsage() - oldMemUsage
+}
+
+// sortApproxTopKCounters sorts counters by count in descending order, ties are
+// broken by key to make the result stable.
+func sortApproxTopKCounters(counters []*approxTopKCounter) {
+	sort.Slice(counters, func(i, j int) bool {
+		if counters[i].count != counters[j].count {
+			return counters[i].count > counters[j].count
+		}
+		return counters[i].key < counters[j].key
+	})
+}
+
+// Serialize encodes the sketch as the capacity, the number of counters, and then
+// the key, count and error of every counter.
+func (p *partialResult4ApproxTopK) Serialize() []byte {
+	res := make([]byte, 0, 16+len(p.minHeap)*16)
+	res = codec.EncodeUvarint(res, uint64(p.capacity))
+	res = codec.EncodeUvarint(res, uint64(len(p.minHeap)))
+	for _, c := range p.minHeap {
+		res = codec.EncodeUvarint(res, uint64(len(c.key)))
+		res = append(res, c.key...)
+		res = codec.EncodeUvarint(res, c.count)
+		res = codec.EncodeUvarint(res, c.err)
+	}
+	return res
+}
+
+func (p *partialResult4ApproxTopK) readAndMerge(rb []byte) (memDelta int64, err error) {
+	rb, capacity, err := codec.DecodeUvarint(rb)
+	if err != nil {
+		return 0, err
+	}
+	if capacity != uint64(p.capacity) {
+		return 0, errors.Errorf("Cannot read partialResult4ApproxTopK: capacity %d mismatches %d", capacity, p.capacity)
+	}
+	rb, n, err := codec.DecodeUvarint(rb)
+	if err != nil {
+		return 0, err
+	}
+	if n > capacity {
+		return 0, errors.New("Cannot read partialResult4ApproxTopK: too many counters")
+	}
+	src := newPartialResult4ApproxTopK(p.capacity)
+	for i := uint64(0); i < n; i++ {
+		var keyLen uint64
+		rb, keyLen, err = codec.DecodeUvarint(rb)
+		if err != nil {
+			return 0, err
+		}
+		if keyLen == 0 || uint64(len(rb)) < keyLen {
+			return 0, errors.New("Cannot read partialResult4ApproxTopK: invalid counter key")
+		}
+		c := &approxTopKCounter{key: string(rb[:keyLen])}
+		rb = rb[keyLen:]
+		if rb, c.count, err = codec.DecodeUvarint(rb); err != nil {
+			return 0, err
+		}
+		if rb, c.err, err = codec.DecodeUvarint(rb); err != nil {
+			return 0, err
+		}
+		src.counters[c.key] = c
+		heap.Push(&src.minHeap, c)
+	}
+	return p.merge(src), nil
+}
+
+// encodeApproxTopKKey encodes a JSON value as the key of a counter.
+func encodeApproxTopKKey(j types.BinaryJSON) string {
+	key := make([]byte, 0, 1+len(j.Value))
+	key = append(key, j.TypeCode)
+	key = append(key, j.Value...)
+	return string(hack.String(key))
+}
+
+func decodeApproxTopKKey(key string) types.BinaryJSON {
+	return types.BinaryJSON{TypeCode: key[0], Value: hack.Slice(key[1:])}
+}
+
+type baseApproxTopK struct {
+	baseAggFunc
+	k int
+}
+
+func (e *baseApproxTopK) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	return PartialResult(newPartialResult4ApproxTopK(e.k * approxTopKCapacityFactor)), DefPartialResult4ApproxTopKSize
+}
+
+func (*baseApproxTopK) ResetPartialResult(pr PartialResult) {
+	(*partialResult4ApproxTopK)(pr).reset()
+}
+
+func (*baseApproxTopK) MergePartialResult(_ sessionctx.Context, src, dst PartialResult) (memDelta int64, err error) {
+	p1, p2 := (*partialResult4ApproxTopK)(src), (*partialResult4ApproxTopK)(dst)
+	return p2.merge(p1), nil
+}
+
+// AppendFinalResult2Chunk outputs the k most frequent items as a JSON array like
+// `[{"value": "a", "count": 10}, {"value": "b", "count": 4}]`.
+func (e *baseApproxTopK) AppendFinalResult2Chunk(_ sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4ApproxTopK)(pr)
+	if len(p.minHeap) == 0 {
+		chk.AppendNull(e.ordinal)
+		return nil
+	}
+	counters := make([]*approxTopKCounter, len(p.minHeap))
+	copy(counters, p.minHeap)
+	sortApproxTopKCounters(counters)
+	if len(counters) > e.k {
+		counters = counters[:e.k]
+	}
+	entries := make([]interface{}, 0, len(counters))
+	for _, c := range counters {
+		entries = append(entries, map[string]interface{}{
+			"value": decodeApproxTopKKey(c.key),
+			"count": c.count,
+		})
+	}
+	json, err := types.CreateBinaryJSONWithCheck(entries)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	chk.AppendJSON(e.ordinal, json)
+	return nil
+}
+
+type approxTopKOriginal struct {
+	baseApproxTopK
+}
+
+func (e *approxTopKOriginal) UpdatePartialResult(_ sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4ApproxTopK)(pr)
+	for _, row := range rowsInGroup {
+		item, err := e.args[0].Eval(row)
+		if err != nil {
+			return memDelta, errors.Trace(err)
+		}
+		if item.IsNull() {
+			continue
+		}
+		realItem, err := getRealJSONValue(item, e.args[0].GetType())
+		if err != nil {
+			return memDelta, errors.Trace(err)
+		}
+		json, err := types.CreateBinaryJSONWithCheck(realItem)
+		if err != nil {
+			return memDelta, errors.Trace(err)
+		}
+		memDelta += p.insert(encodeApproxTopKKey(json))
+	}
+	return memDelta, nil
+}
+
+type approxTopKPartial1 struct {
+	approxTopKOriginal
+}
+
+func (e *approxTopKPartial1) AppendFinalResult2Chunk(_ sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4ApproxTopK)(pr)
+	chk.AppendBytes(e.ordinal, p.Serialize())
+	return nil
+}
+
+type approxTopKPartial2 struct {
+	approxTopKPartial1
+}
+
+func (e *approxTopKPartial2) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4ApproxTopK)(pr)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalString(sctx, row)
+		if err != nil {
+			return memDelta, err
+		}
+		if isNull {
+			continue
+		}
+		delta, err := p.readAndMerge(hack.Slice(input))
+		if err != nil {
+			return memDelta, err
+		}
+		memDelta += delta
+	}
+	return memDelta, nil
+}
+
+type approxTopKFinal struct {
+	approxTopKPartial2
+}
+
+func (e *approxTopKFinal) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	return e.baseApproxTopK.AppendFinalResult2Chunk(sctx, pr, chk)
+}
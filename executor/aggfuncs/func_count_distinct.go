@@ -465,15 +465,6 @@ func appendDuration(encodedBytes, buf []byte, val types.Duration) []byte {
 	return encodedBytes
 }
 
-func intHash64(x uint64) uint64 {
-	x ^= x >> 33
-	x *= 0xff51afd7ed558ccd
-	x ^= x >> 33
-	x *= 0xc4ceb9fe1a85ec53
-	x ^= x >> 33
-	return x
-}
-
 type baseApproxCountDistinct struct {
 	baseAggFunc
 }
@@ -571,6 +562,9 @@ func (p *partialResult4ApproxCountDistinct) resize(newSizeDegree uint8) {
 }
 
 func (p *partialResult4ApproxCountDistinct) readAndMerge(rb []byte) error {
+	if len(rb) == 0 {
+		return errors.New("Cannot read partialResult4ApproxCountDistinct: empty input")
+	}
 	rhsSkipDegree := rb[0]
 	rb = rb[1:]
 
@@ -588,6 +582,9 @@ func (p *partialResult4ApproxCountDistinct) readAndMerge(rb []byte) error {
 	if rhsSize > uint64(uniquesHashMaxSize) {
 		return errors.New("Cannot read partialResult4ApproxCountDistinct: too large size degree")
 	}
+	if uint64(len(rb)) < rhsSize*4 {
+		return errors.New("Cannot read partialResult4ApproxCountDistinct: truncated input")
+	}
 
 	if p.bufSize() < uint32(rhsSize) {
 		newSizeDegree := mathutil.Max(uniquesHashSetInitialSizeDegree, uint8(math.Log2(float64(rhsSize-1)))+2)
@@ -605,19 +602,7 @@ func (p *partialResult4ApproxCountDistinct) readAndMerge(rb []byte) error {
 
 // Correct system errors due to collisions during hashing in uint32.
 func (p *partialResult4ApproxCountDistinct) fixedSize() uint64 {
-	if 0 == p.skipDegree {
-		return uint64(p.size)
-	}
-
-	res := uint64(p.size) * (uint64(1) << p.skipDegree)
-
-	// Pseudo-random remainder.
-	res += intHash64(uint64(p.size)) & ((uint64(1) << p.skipDegree) - 1)
-
-	// When different elements randomly scattered across 2^32 buckets, filled buckets with average of `res` obtained.
-	p32 := uint64(1) << 32
-	fixedRes := math.Round(float64(p32) * (math.Log(float64(p32)) - math.Log(float64(p32-res))))
-	return uint64(fixedRes)
+	return expression.EstimateHLLSketchSize(uint64(p.size), p.skipDegree)
 }
 
 func (p *partialResult4ApproxCountDistinct) insertHash(hashValue approxCountDistinctHashValue) {
@@ -0,0 +1,152 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"unsafe"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+const (
+	// DefPartialResult4ExtensionSize is the size of partialResult4Extension
+	DefPartialResult4ExtensionSize = int64(unsafe.Sizeof(partialResult4Extension{}))
+)
+
+// partialResult4Extension holds the state of a custom aggregate function, which is opaque to TiDB.
+type partialResult4Extension struct {
+	state any
+}
+
+// extensionAggOriginal implements the custom aggregate function registered by extension over raw values.
+type extensionAggOriginal struct {
+	baseAggFunc
+	def *extension.AggFunctionDef
+}
+
+func (e *extensionAggOriginal) AllocPartialResult() (pr PartialResult, memDelta int64) {
+	p := &partialResult4Extension{state: e.def.Init()}
+	return PartialResult(p), DefPartialResult4ExtensionSize + e.def.StateMemUsage(p.state)
+}
+
+func (e *extensionAggOriginal) ResetPartialResult(pr PartialResult) {
+	p := (*partialResult4Extension)(pr)
+	p.state = e.def.Init()
+}
+
+func (e *extensionAggOriginal) UpdatePartialResult(_ sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	p := (*partialResult4Extension)(pr)
+	oldSize := e.def.StateMemUsage(p.state)
+	args := make([]types.Datum, len(e.args))
+	for _, row := range rowsInGroup {
+		for i, arg := range e.args {
+			if args[i], err = arg.Eval(row); err != nil {
+				return 0, err
+			}
+		}
+		if p.state, err = e.def.Update(p.state, args); err != nil {
+			return 0, err
+		}
+	}
+	return e.def.StateMemUsage(p.state) - oldSize, nil
+}
+
+func (e *extensionAggOriginal) MergePartialResult(_ sessionctx.Context, src, dst PartialResult) (memDelta int64, err error) {
+	p1, p2 := (*partialResult4Extension)(src), (*partialResult4Extension)(dst)
+	oldSize := e.def.StateMemUsage(p2.state)
+	if p2.state, err = e.def.Merge(p2.state, p1.state); err != nil {
+		return 0, err
+	}
+	return e.def.StateMemUsage(p2.state) - oldSize, nil
+}
+
+func (e *extensionAggOriginal) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	p := (*partialResult4Extension)(pr)
+	d, err := e.def.Final(p.state)
+	if err != nil {
+		return err
+	}
+	d, err = d.ConvertTo(sctx.GetSessionVars().StmtCtx, e.retTp)
+	if err != nil {
+		return err
+	}
+	chk.AppendDatum(e.ordinal, &d)
+	return nil
+}
+
+// extensionAggPartial1 updates the state with raw values and outputs the encoded state.
+type extensionAggPartial1 struct {
+	extensionAggOriginal
+}
+
+func (e *extensionAggPartial1) AppendFinalResult2Chunk(_ sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	if !e.def.CanSplit() {
+		return errors.Errorf("the state of extension aggregate function '%s' can not be encoded", e.def.Name)
+	}
+	p := (*partialResult4Extension)(pr)
+	data, err := e.def.EncodeState(p.state)
+	if err != nil {
+		return err
+	}
+	chk.AppendBytes(e.ordinal, data)
+	return nil
+}
+
+// extensionAggPartial2 merges the encoded states and outputs the encoded state.
+type extensionAggPartial2 struct {
+	extensionAggPartial1
+}
+
+func (e *extensionAggPartial2) UpdatePartialResult(sctx sessionctx.Context, rowsInGroup []chunk.Row, pr PartialResult) (memDelta int64, err error) {
+	if !e.def.CanSplit() {
+		return 0, errors.Errorf("the state of extension aggregate function '%s' can not be encoded", e.def.Name)
+	}
+	p := (*partialResult4Extension)(pr)
+	oldSize := e.def.StateMemUsage(p.state)
+	for _, row := range rowsInGroup {
+		input, isNull, err := e.args[0].EvalString(sctx, row)
+		if err != nil {
+			return 0, err
+		}
+
+		if isNull {
+			continue
+		}
+
+		state, err := e.def.DecodeState(hack.Slice(input))
+		if err != nil {
+			return 0, err
+		}
+
+		if p.state, err = e.def.Merge(p.state, state); err != nil {
+			return 0, err
+		}
+	}
+	return e.def.StateMemUsage(p.state) - oldSize, nil
+}
+
+// extensionAggFinal merges the encoded states and outputs the final result.
+type extensionAggFinal struct {
+	extensionAggPartial2
+}
+
+func (e *extensionAggFinal) AppendFinalResult2Chunk(sctx sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	return e.extensionAggOriginal.AppendFinalResult2Chunk(sctx, pr, chk)
+}
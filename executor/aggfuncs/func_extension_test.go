@@ -0,0 +1,96 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs_test
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/executor/aggfuncs"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const distinctSetEntrySize = int64(16)
+
+// distinctSet counts the distinct values, and reports the memory of its state by the number of the values.
+var distinctSet = &extension.AggFunctionDef{
+	Name:   "custom_distinct_set",
+	EvalTp: types.ETInt,
+	ArgTps: []types.EvalType{types.ETInt},
+	Init: func() any {
+		return map[int64]struct{}{}
+	},
+	Update: func(state any, args []types.Datum) (any, error) {
+		s := state.(map[int64]struct{})
+		if !args[0].IsNull() {
+			s[args[0].GetInt64()] = struct{}{}
+		}
+		return s, nil
+	},
+	Merge: func(dst, src any) (any, error) {
+		d := dst.(map[int64]struct{})
+		for k := range src.(map[int64]struct{}) {
+			d[k] = struct{}{}
+		}
+		return d, nil
+	},
+	Final: func(state any) (types.Datum, error) {
+		return types.NewIntDatum(int64(len(state.(map[int64]struct{})))), nil
+	},
+	StateSize: func(state any) int64 {
+		return int64(len(state.(map[int64]struct{}))) * distinctSetEntrySize
+	},
+}
+
+func TestMemExtensionAggFunc(t *testing.T) {
+	require.NoError(t, extension.RegisterExtensionAggFunc(distinctSet))
+	defer extension.RemoveExtensionAggFunc(distinctSet.Name)
+
+	ctx := mock.NewContext()
+	tp := types.NewFieldType(mysql.TypeLonglong)
+	desc, err := aggregation.NewAggFuncDesc(ctx, distinctSet.Name, []expression.Expression{&expression.Column{RetType: tp, Index: 0}}, false)
+	require.NoError(t, err)
+	aggFunc := aggfuncs.Build(ctx, desc, 0)
+
+	pr1, memDelta := aggFunc.AllocPartialResult()
+	require.Equal(t, aggfuncs.DefPartialResult4ExtensionSize, memDelta)
+	pr2, _ := aggFunc.AllocPartialResult()
+
+	srcChk := chunk.NewChunkWithCapacity([]*types.FieldType{tp}, 4)
+	for _, v := range []int64{1, 2, 1} {
+		srcChk.AppendInt64(0, v)
+	}
+	srcChk.AppendNull(0)
+	expected := []int64{distinctSetEntrySize, distinctSetEntrySize, 0, 0}
+	for i := 0; i < srcChk.NumRows(); i++ {
+		memDelta, err = aggFunc.UpdatePartialResult(ctx, []chunk.Row{srcChk.GetRow(i)}, pr1)
+		require.NoError(t, err)
+		require.Equal(t, expected[i], memDelta)
+	}
+
+	memDelta, err = aggFunc.UpdatePartialResult(ctx, []chunk.Row{srcChk.GetRow(1)}, pr2)
+	require.NoError(t, err)
+	require.Equal(t, distinctSetEntrySize, memDelta)
+	// merging the states of {1, 2} into {2} adds one value
+	memDelta, err = aggFunc.MergePartialResult(ctx, pr1, pr2)
+	require.NoError(t, err)
+	require.Equal(t, distinctSetEntrySize, memDelta)
+}
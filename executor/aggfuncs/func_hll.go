@@ -0,0 +1,52 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggfuncs
+
+import (
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// hllSketchOriginal implements HLL_SKETCH over raw values. It shares the sketch of
+// APPROX_COUNT_DISTINCT, but always outputs the serialized sketch so that users can
+// store it and merge it later with HLL_MERGE.
+type hllSketchOriginal struct {
+	approxCountDistinctOriginal
+}
+
+func (e *hllSketchOriginal) AppendFinalResult2Chunk(_ sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	appendHLLSketch(chk, e.ordinal, (*partialResult4ApproxCountDistinct)(pr))
+	return nil
+}
+
+// hllSketchMerge implements HLL_MERGE, and the final phase of HLL_SKETCH. Its input
+// is a serialized sketch and its output is the union of all the input sketches.
+type hllSketchMerge struct {
+	approxCountDistinctPartial2
+}
+
+func (e *hllSketchMerge) AppendFinalResult2Chunk(_ sessionctx.Context, pr PartialResult, chk *chunk.Chunk) error {
+	appendHLLSketch(chk, e.ordinal, (*partialResult4ApproxCountDistinct)(pr))
+	return nil
+}
+
+// appendHLLSketch appends the serialized sketch, or NULL if no value has been inserted.
+func appendHLLSketch(chk *chunk.Chunk, ordinal int, p *partialResult4ApproxCountDistinct) {
+	if p.size == 0 {
+		chk.AppendNull(ordinal)
+		return
+	}
+	chk.AppendBytes(ordinal, p.Serialize())
+}
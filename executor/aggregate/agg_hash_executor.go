@@ -112,6 +112,9 @@ type HashAggExec struct {
 	DefaultVal       *chunk.Chunk
 	childResult      *chunk.Chunk
 
+	// DefaultValErr is the error of getting the default values, it's returned only when DefaultVal is used.
+	DefaultValErr error
+
 	// IsChildReturnEmpty indicates whether the child executor only returns an empty input.
 	IsChildReturnEmpty bool
 	// After we support parallel execution for aggregation functions with distinct,
@@ -471,6 +474,9 @@ func (e *HashAggExec) parallelExec(ctx context.Context, chk *chunk.Chunk) error
 		if !ok {
 			e.executed = true
 			if e.IsChildReturnEmpty && e.DefaultVal != nil {
+				if e.DefaultValErr != nil {
+					return e.DefaultValErr
+				}
 				chk.Append(e.DefaultVal, 0, 1)
 			}
 			return nil
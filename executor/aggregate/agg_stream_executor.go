@@ -44,6 +44,9 @@ type StreamAggExec struct {
 	groupRows          []chunk.Row
 	childResult        *chunk.Chunk
 
+	// DefaultValErr is the error of getting the default values, it's returned only when DefaultVal is used.
+	DefaultValErr error
+
 	memTracker *memory.Tracker // track memory usage.
 	// memUsageOfInitialPartialResult indicates the memory usage of all partial results after initialization.
 	// All partial results will be reset after processing one group data, and the memory usage should also be reset.
@@ -200,6 +203,9 @@ func (e *StreamAggExec) consumeCurGroupRowsAndFetchChild(ctx context.Context, ch
 		if !e.IsChildReturnEmpty {
 			err = e.appendResult2Chunk(chk)
 		} else if e.DefaultVal != nil {
+			if e.DefaultValErr != nil {
+				return e.DefaultValErr
+			}
 			chk.Append(e.DefaultVal, 0, 1)
 		}
 		e.executed = true
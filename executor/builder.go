@@ -102,6 +102,8 @@ type executorBuilder struct {
 	inDeleteStmt     bool
 	inInsertStmt     bool
 	inSelectLockStmt bool
+	// inForeignKeyCascade indicates the executor is built for a foreign key cascade, which doesn't fire triggers.
+	inForeignKeyCascade bool
 
 	// forDataReaderBuilder indicates whether the builder is used by a dataReaderBuilder.
 	// When forDataReader is true, the builder should use the dataReaderTS as the executor read ts. This is because
@@ -275,10 +277,14 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildStreamAgg(v)
 	case *plannercore.PhysicalProjection:
 		return b.buildProjection(v)
+	case *plannercore.PhysicalExpand:
+		return b.buildExpand(v)
 	case *plannercore.PhysicalMemTable:
 		return b.buildMemTable(v)
 	case *plannercore.PhysicalTableDual:
 		return b.buildTableDual(v)
+	case *plannercore.PhysicalJSONTable:
+		return b.buildJSONTable(v)
 	case *plannercore.PhysicalApply:
 		return b.buildApply(v)
 	case *plannercore.PhysicalMaxOneRow:
@@ -858,6 +864,8 @@ func (b *executorBuilder) buildShow(v *plannercore.PhysicalShow) exec.Executor {
 		CountWarningsOrErrors: v.CountWarningsOrErrors,
 		DBName:                model.NewCIStr(v.DBName),
 		Table:                 v.Table,
+		Procedure:             v.Procedure,
+		Event:                 v.Event,
 		Partition:             v.Partition,
 		Column:                v.Column,
 		IndexName:             v.IndexName,
@@ -1008,6 +1016,10 @@ func (b *executorBuilder) buildInsert(v *plannercore.Insert) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	ivs.triggers, b.err = b.buildTriggerExec(ivs.Table)
+	if b.err != nil {
+		return nil
+	}
 
 	if v.IsReplace {
 		return b.buildReplace(ivs)
@@ -1345,6 +1357,8 @@ func (b *executorBuilder) buildSelectInto(v *plannercore.SelectInto) exec.Execut
 	return &SelectIntoExec{
 		BaseExecutor:   exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), child),
 		intoOpt:        v.IntoOpt,
+		options:        v.Options,
+		fieldNames:     v.TargetNames,
 		LineFieldsInfo: v.LineFieldsInfo,
 	}
 }
@@ -1545,8 +1559,9 @@ func (b *executorBuilder) buildMergeJoin(v *plannercore.PhysicalMergeJoin) exec.
 			markChildrenUsedCols(colsFromChildren, v.Children()[0].Schema(), v.Children()[1].Schema()),
 			false,
 		),
-		isOuterJoin: v.JoinType.IsOuterJoin(),
-		desc:        v.Desc,
+		isOuterJoin:     v.JoinType.IsOuterJoin(),
+		isFullOuterJoin: v.JoinType == plannercore.FullOuterJoin,
+		desc:            v.Desc,
 	}
 
 	leftTable := &mergeJoinTable{
@@ -1568,6 +1583,7 @@ func (b *executorBuilder) buildMergeJoin(v *plannercore.PhysicalMergeJoin) exec.
 		e.outerTable = leftTable
 	}
 	e.innerTable.isInner = true
+	e.innerTable.keepNullKeys = e.isFullOuterJoin
 
 	// optimizer should guarantee that filters on inner table are pushed down
 	// to tikv or extracted to a Selection.
@@ -1812,7 +1828,11 @@ func (b *executorBuilder) buildHashAgg(v *plannercore.PhysicalHashAgg) exec.Exec
 			}
 		}
 		if e.DefaultVal != nil {
-			value := aggDesc.GetDefaultValue()
+			value, err := aggDesc.GetDefaultValue()
+			if err != nil && e.DefaultValErr == nil {
+				// Only fail the query when the default values are used, i.e. the child returns an empty input.
+				e.DefaultValErr = err
+			}
 			e.DefaultVal.AppendDatum(i, &value)
 		}
 	}
@@ -1844,7 +1864,11 @@ func (b *executorBuilder) buildStreamAgg(v *plannercore.PhysicalStreamAgg) exec.
 		aggFunc := aggfuncs.Build(b.ctx, aggDesc, i)
 		e.AggFuncs = append(e.AggFuncs, aggFunc)
 		if e.DefaultVal != nil {
-			value := aggDesc.GetDefaultValue()
+			value, err := aggDesc.GetDefaultValue()
+			if err != nil && e.DefaultValErr == nil {
+				// Only fail the query when the default values are used, i.e. the child returns an empty input.
+				e.DefaultValErr = err
+			}
 			e.DefaultVal.AppendDatum(i, &value)
 		}
 	}
@@ -1892,6 +1916,17 @@ func (b *executorBuilder) buildProjection(v *plannercore.PhysicalProjection) exe
 	return e
 }
 
+func (b *executorBuilder) buildExpand(v *plannercore.PhysicalExpand) exec.Executor {
+	childExec := b.build(v.Children()[0])
+	if b.err != nil {
+		return nil
+	}
+	return &ExpandExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID(), childExec),
+		levelExprs:   v.LevelExprs,
+	}
+}
+
 func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.Executor {
 	if v.RowCount != 0 && v.RowCount != 1 {
 		b.err = errors.Errorf("buildTableDual failed, invalid row count for dual table: %v", v.RowCount)
@@ -1906,6 +1941,15 @@ func (b *executorBuilder) buildTableDual(v *plannercore.PhysicalTableDual) exec.
 	return e
 }
 
+func (b *executorBuilder) buildJSONTable(v *plannercore.PhysicalJSONTable) exec.Executor {
+	return &JSONTableExec{
+		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+		expr:         v.Expr,
+		root:         v.Root,
+		asName:       v.AsName,
+	}
+}
+
 // `getSnapshotTS` returns for-update-ts if in insert/update/delete/lock statement otherwise the isolation read ts
 // Please notice that in RC isolation, the above two ts are the same
 func (b *executorBuilder) getSnapshotTS() (ts uint64, err error) {
@@ -2079,6 +2123,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.TableStatistics),
 			strings.ToLower(infoschema.TableTiDBIndexes),
 			strings.ToLower(infoschema.TableViews),
+			strings.ToLower(infoschema.TableRoutines),
+			strings.ToLower(infoschema.TableEvents),
+			strings.ToLower(infoschema.TableTriggers),
 			strings.ToLower(infoschema.TableTables),
 			strings.ToLower(infoschema.TableReferConst),
 			strings.ToLower(infoschema.TableSequences),
@@ -2116,7 +2163,9 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.ClusterTableMemoryUsage),
 			strings.ToLower(infoschema.ClusterTableMemoryUsageOpsHistory),
 			strings.ToLower(infoschema.TableResourceGroups),
-			strings.ToLower(infoschema.TableRunawayWatches):
+			strings.ToLower(infoschema.TableRunawayWatches),
+			strings.ToLower(infoschema.TableTiDBTTLTableStatus),
+			strings.ToLower(infoschema.TableTiDBEventHistory):
 			return &MemTableReaderExec{
 				BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 				table:        v.Table,
@@ -2227,6 +2276,22 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 		}
 	}
 	tb, _ := b.is.TableByID(v.Table.ID)
+	if v.Table.Engine != "" {
+		customTbl, ok := tb.(*tables.CustomEngineTable)
+		if !ok {
+			b.err = errors.Errorf("table '%s' is not a table with custom engine", v.Table.Name.O)
+			return nil
+		}
+		return &MemTableReaderExec{
+			BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
+			table:        v.Table,
+			retriever: &customEngineTableRetriever{
+				table:     customTbl,
+				columns:   v.Columns,
+				extractor: v.Extractor.(*plannercore.CustomEngineTableExtractor),
+			},
+		}
+	}
 	return &TableScanExec{
 		BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 		t:            tb,
@@ -2504,6 +2569,10 @@ func (b *executorBuilder) buildUpdate(v *plannercore.Update) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	updateExec.triggers, b.err = b.buildTblID2TriggerExecs(tblID2table)
+	if b.err != nil {
+		return nil
+	}
 	return updateExec
 }
 
@@ -2556,6 +2625,10 @@ func (b *executorBuilder) buildDelete(v *plannercore.Delete) exec.Executor {
 	if b.err != nil {
 		return nil
 	}
+	deleteExec.triggers, b.err = b.buildTblID2TriggerExecs(tblID2table)
+	if b.err != nil {
+		return nil
+	}
 	return deleteExec
 }
 
@@ -3945,6 +4018,11 @@ func buildIndexReq(ctx sessionctx.Context, columns []*model.IndexColumn, handleL
 		}
 	}
 
+	if idxScan.SpatialWindow != nil {
+		// Output the MBR stored in the spatial index to filter the entries.
+		indexReq.OutputOffsets = append(indexReq.OutputOffsets, 0)
+	}
+
 	for i := 0; i < handleLen; i++ {
 		indexReq.OutputOffsets = append(indexReq.OutputOffsets, uint32(len(columns)+i))
 	}
@@ -4011,6 +4089,10 @@ func buildNoRangeIndexLookUpReader(b *executorBuilder, v *plannercore.PhysicalIn
 		idxNetDataSize:    v.GetAvgTableRowSize(),
 		avgRowSize:        v.GetAvgTableRowSize(),
 	}
+	if is.SpatialWindow != nil {
+		e.spatialWindow = is.SpatialWindow
+		e.spatialColTp = is.Schema().Columns[0].RetType
+	}
 
 	if v.ExtraHandleCol != nil {
 		e.handleIdx = append(e.handleIdx, v.ExtraHandleCol.Index)
@@ -4915,6 +4997,16 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 		partialResults = append(partialResults, partialResult)
 		resultColIdx++
 	}
+	var (
+		peers    *peerGroups
+		excluder *frameExcluder
+	)
+	if v.Frame != nil && (v.Frame.Type == ast.Groups || v.Frame.Exclude != ast.ExcludeNoOthers) {
+		peers = newPeerGroups(b.ctx, orderByCols)
+		if v.Frame.Exclude != ast.ExcludeNoOthers {
+			excluder = &frameExcluder{exclude: v.Frame.Exclude, peers: peers}
+		}
+	}
 
 	if b.ctx.GetSessionVars().EnablePipelinedWindowExec {
 		exec := &PipelinedWindowExec{
@@ -4946,6 +5038,9 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 				exec.expectedCmpResult = cmpResult
 				exec.isRangeFrame = true
 			}
+			exec.peers = peers
+			exec.isGroupsFrame = v.Frame.Type == ast.Groups
+			exec.excluder = excluder
 		}
 		return exec
 	}
@@ -4961,6 +5056,16 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 			partialResults: partialResults,
 			start:          v.Frame.Start,
 			end:            v.Frame.End,
+			excluder:       excluder,
+		}
+	} else if v.Frame.Type == ast.Groups {
+		processor = &groupsFrameWindowProcessor{
+			windowFuncs:    windowFuncs,
+			partialResults: partialResults,
+			start:          v.Frame.Start,
+			end:            v.Frame.End,
+			peers:          peers,
+			excluder:       excluder,
 		}
 	} else {
 		cmpResult := int64(-1)
@@ -4974,6 +5079,7 @@ func (b *executorBuilder) buildWindow(v *plannercore.PhysicalWindow) exec.Execut
 			end:               v.Frame.End,
 			orderByCols:       orderByCols,
 			expectedCmpResult: cmpResult,
+			excluder:          excluder,
 		}
 	}
 	return &WindowExec{BaseExecutor: base,
@@ -157,10 +157,18 @@ func (e *DDLExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeCreateTable(x)
 	case *ast.CreateViewStmt:
 		err = e.executeCreateView(ctx, x)
+	case *ast.CreateTriggerStmt:
+		err = e.executeCreateTrigger(x)
+	case *ast.DropTriggerStmt:
+		err = e.executeDropTrigger(x)
+	case *ast.CreateMaterializedViewStmt:
+		err = e.executeCreateMaterializedView(ctx, x)
+	case *ast.DropMaterializedViewStmt:
+		err = e.executeDropMaterializedView(x)
 	case *ast.DropIndexStmt:
 		err = e.executeDropIndex(x)
 	case *ast.DropDatabaseStmt:
-		err = e.executeDropDatabase(x)
+		err = e.executeDropDatabase(ctx, x)
 	case *ast.DropTableStmt:
 		if x.IsView {
 			err = e.executeDropView(x)
@@ -317,7 +325,7 @@ func (e *DDLExec) executeCreateIndex(s *ast.CreateIndexStmt) error {
 	return domain.GetDomain(e.Ctx()).DDL().CreateIndex(e.Ctx(), s)
 }
 
-func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
+func (e *DDLExec) executeDropDatabase(ctx context.Context, s *ast.DropDatabaseStmt) error {
 	dbName := s.Name
 
 	// Protect important system table from been dropped by a mistake.
@@ -327,6 +335,16 @@ func (e *DDLExec) executeDropDatabase(s *ast.DropDatabaseStmt) error {
 	}
 
 	err := domain.GetDomain(e.Ctx()).DDL().DropSchema(e.Ctx(), s)
+	if err == nil {
+		// The stored procedures of the database are dropped together.
+		if err1 := dropProceduresOfSchema(ctx, e.Ctx(), dbName); err1 != nil {
+			logutil.Logger(ctx).Warn("drop stored procedures failed", zap.String("database", dbName.O), zap.Error(err1))
+		}
+		// So are the events.
+		if err1 := dropEventsOfSchema(ctx, e.Ctx(), dbName); err1 != nil {
+			logutil.Logger(ctx).Warn("drop events failed", zap.String("database", dbName.O), zap.Error(err1))
+		}
+	}
 	sessionVars := e.Ctx().GetSessionVars()
 	if err == nil && strings.ToLower(sessionVars.CurrentDB) == dbName.L {
 		sessionVars.CurrentDB = ""
@@ -471,6 +471,12 @@ type IndexLookUpExecutor struct {
 	colLens         []int
 	// PushedLimit is used to skip the preceding and tailing handles when Limit is sunk into IndexLookUpReader.
 	PushedLimit *plannercore.PushedDownLimit
+	// spatialWindow is used to skip the entries of a spatial index whose MBR doesn't intersect
+	// spatialMBR, which is evaluated when opening the executor. spatialColTp is the type of the
+	// index column which holds the MBR.
+	spatialWindow *expression.SpatialWindow
+	spatialColTp  *types.FieldType
+	spatialMBR    types.MBR
 
 	stats *IndexLookUpRunTimeStats
 
@@ -513,6 +519,12 @@ func (e *IndexLookUpExecutor) Open(ctx context.Context) error {
 			return err
 		}
 	}
+	if e.spatialWindow != nil {
+		e.spatialMBR, err = e.spatialWindow.Eval(e.Ctx())
+		if err != nil {
+			return err
+		}
+	}
 	err = e.buildTableKeyRanges()
 	if err != nil {
 		return err
@@ -641,6 +653,12 @@ func (e *IndexLookUpExecutor) isCommonHandle() bool {
 	return !(len(e.handleCols) == 1 && e.handleCols[0].ID == model.ExtraHandleID) && e.table.Meta() != nil && e.table.Meta().IsCommonHandle
 }
 
+// matchSpatialWindow checks whether the MBR of the spatial index entry intersects the window.
+func (e *IndexLookUpExecutor) matchSpatialWindow(row chunk.Row) bool {
+	mbr, ok := types.DecodeMBRKey(row.GetBytes(len(e.byItems)))
+	return !ok || mbr.Intersects(e.spatialMBR)
+}
+
 func (e *IndexLookUpExecutor) getRetTpsForIndexReader() []*types.FieldType {
 	if e.checkIndexValue != nil {
 		return e.idxColTps
@@ -651,6 +669,9 @@ func (e *IndexLookUpExecutor) getRetTpsForIndexReader() []*types.FieldType {
 			tps = append(tps, item.Expr.GetType())
 		}
 	}
+	if e.spatialWindow != nil {
+		tps = append(tps, e.spatialColTp)
+	}
 	if e.isCommonHandle() {
 		for _, handleCol := range e.handleCols {
 			tps = append(tps, handleCol.RetType)
@@ -1087,6 +1108,9 @@ func (w *indexWorker) extractTaskHandles(ctx context.Context, chk *chunk.Chunk,
 					return handles, nil, nil
 				}
 			}
+			if w.idxLookup.spatialWindow != nil && !w.idxLookup.matchSpatialWindow(chk.GetRow(i)) {
+				continue
+			}
 			h, err := w.idxLookup.getHandle(chk.GetRow(i), handleOffset, w.idxLookup.isCommonHandle(), getHandleFromIndex)
 			if err != nil {
 				return handles, retChk, err
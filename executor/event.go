@@ -0,0 +1,535 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/eventsched"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/stringutil"
+	"github.com/pingcap/tidb/util/timeutil"
+)
+
+const (
+	eventTypeOneTime   = "ONE TIME"
+	eventTypeRecurring = "RECURRING"
+)
+
+func getEventManager(sctx sessionctx.Context) (*eventsched.Manager, error) {
+	manager := domain.GetDomain(sctx).EventManager()
+	if manager == nil {
+		return nil, errors.New("the event scheduler is not started")
+	}
+	return manager, nil
+}
+
+// checkEventBody checks the body of an event is a single statement, the compound statements are not supported yet.
+func checkEventBody(body ast.StmtNode) error {
+	switch body.(type) {
+	case *ast.ProcedureBlock, *ast.ProcedureLabelBlock, *ast.ProcedureIfInfo, *ast.SimpleCaseStmt, *ast.SearchCaseStmt,
+		*ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt, *ast.ProcedureLoopStmt, *ast.ProcedureLabelLoop,
+		*ast.ProcedureOpenCur, *ast.ProcedureCloseCur, *ast.ProcedureFetchInto, *ast.ProcedureJump:
+		return dbterror.ErrNotSupportedYet.GenWithStackByArgs("compound statements in the event body")
+	}
+	return nil
+}
+
+func eventStatus(status ast.EventStatusType) string {
+	switch status {
+	case ast.EventStatusDisable:
+		return eventsched.StatusDisabled
+	case ast.EventStatusDisableOnSlave:
+		return eventsched.StatusSlavesideDisabled
+	default:
+		return eventsched.StatusEnabled
+	}
+}
+
+// setEventDefiner sets the definer of an event, it's resolved to the current user by the planner
+// unless the statement is executed by an internal session without a user.
+func setEventDefiner(info *eventsched.EventInfo, definer *auth.UserIdentity) {
+	info.DefinerUser, info.DefinerHost = "", ""
+	if definer != nil && !definer.CurrentUser {
+		info.DefinerUser, info.DefinerHost = definer.Username, definer.Hostname
+	}
+}
+
+// setEventEnv records the session environment to execute the event.
+func (e *SimpleExec) setEventEnv(ctx context.Context, info *eventsched.EventInfo, dbInfo *model.DBInfo) error {
+	vars := e.Ctx().GetSessionVars()
+	var err error
+	if info.SQLMode, err = vars.GetSessionOrGlobalSystemVar(ctx, variable.SQLModeVar); err != nil {
+		return err
+	}
+	if info.TimeZone, err = vars.GetSessionOrGlobalSystemVar(ctx, variable.TimeZone); err != nil {
+		return err
+	}
+	if info.CharsetClient, err = vars.GetSessionOrGlobalSystemVar(ctx, variable.CharacterSetClient); err != nil {
+		return err
+	}
+	_, info.CollationConnection = vars.GetCharsetInfo()
+	info.DBCollation = dbInfo.Collate
+	if info.DBCollation == "" {
+		info.DBCollation = getDefaultCollate(dbInfo.Charset)
+	}
+	return nil
+}
+
+func (e *SimpleExec) evalEventTime(expr ast.ExprNode, clause string) (time.Time, error) {
+	d, err := expression.EvalAstExpr(e.Ctx(), expr)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if d.IsNull() {
+		return time.Time{}, types.ErrWrongValue.GenWithStackByArgs(clause, "NULL")
+	}
+	vars := e.Ctx().GetSessionVars()
+	d, err = d.ConvertTo(vars.StmtCtx, types.NewFieldType(mysql.TypeDatetime))
+	if err != nil {
+		return time.Time{}, err
+	}
+	return d.GetMysqlTime().GoTime(vars.Location())
+}
+
+// setEventSchedule sets the schedule of an event by the `ON SCHEDULE` clause.
+func (e *SimpleExec) setEventSchedule(info *eventsched.EventInfo, schedule *ast.EventSchedule, now time.Time) error {
+	info.ExecuteAt, info.Starts, info.Ends = time.Time{}, time.Time{}, time.Time{}
+	info.IntervalValue, info.IntervalField = "", ""
+	var err error
+	if schedule.At != nil {
+		info.ExecuteAt, err = e.evalEventTime(schedule.At, "AT")
+		return err
+	}
+	d, err := expression.EvalAstExpr(e.Ctx(), schedule.Every)
+	if err != nil {
+		return err
+	}
+	if d.IsNull() {
+		return exeerrors.ErrEventIntervalNotPositiveOrTooBig
+	}
+	if info.IntervalValue, err = d.ToString(); err != nil {
+		return err
+	}
+	info.IntervalField = schedule.Unit.String()
+	if _, err = info.Interval(); err != nil {
+		return err
+	}
+	// The event starts at the time it's created if STARTS is not specified.
+	info.Starts = now
+	if schedule.Starts != nil {
+		if info.Starts, err = e.evalEventTime(schedule.Starts, "STARTS"); err != nil {
+			return err
+		}
+	}
+	if schedule.Ends != nil {
+		if info.Ends, err = e.evalEventTime(schedule.Ends, "ENDS"); err != nil {
+			return err
+		}
+		if !info.Ends.After(info.Starts) {
+			return exeerrors.ErrEventEndsBeforeStarts
+		}
+	}
+	return nil
+}
+
+// isEventExpired returns whether the event will never be executed because its schedule is in the past.
+func isEventExpired(info *eventsched.EventInfo, now time.Time) bool {
+	if info.IsRecurring() {
+		return !info.Ends.IsZero() && !info.Ends.After(now)
+	}
+	return !info.ExecuteAt.After(now)
+}
+
+func (e *SimpleExec) executeCreateEvent(ctx context.Context, s *ast.CreateEventStmt) error {
+	dbInfo, ok := e.is.SchemaByName(s.EventName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.EventName.Schema.O)
+	}
+	if err := checkEventBody(s.Body); err != nil {
+		return err
+	}
+	manager, err := getEventManager(e.Ctx())
+	if err != nil {
+		return err
+	}
+	name := s.EventName.Name
+	event, err := manager.GetEvent(ctx, dbInfo.Name.O, name.O)
+	if err != nil {
+		return err
+	}
+	if event != nil {
+		err = exeerrors.ErrEventAlreadyExists.GenWithStackByArgs(name.O)
+		if s.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	info := &eventsched.EventInfo{
+		Schema:      dbInfo.Name.O,
+		Name:        name.O,
+		Body:        s.Body.Text(),
+		Preserve:    s.OnCompletion == ast.EventCompletionPreserve,
+		Status:      eventStatus(s.Status),
+		Created:     now,
+		LastAltered: now,
+	}
+	if s.Comment != nil {
+		info.Comment = *s.Comment
+	}
+	setEventDefiner(info, s.Definer)
+	if err = e.setEventEnv(ctx, info, dbInfo); err != nil {
+		return err
+	}
+	if err = e.setEventSchedule(info, s.Schedule, now); err != nil {
+		return err
+	}
+	if isEventExpired(info, now) {
+		if !info.Preserve {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventCannotCreateInThePast)
+			return nil
+		}
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast)
+		info.Status = eventsched.StatusDisabled
+	}
+	return manager.CreateEvent(ctx, info)
+}
+
+func (e *SimpleExec) executeAlterEvent(ctx context.Context, s *ast.AlterEventStmt) error {
+	manager, err := getEventManager(e.Ctx())
+	if err != nil {
+		return err
+	}
+	schema, name := s.EventName.Schema, s.EventName.Name
+	event, err := manager.GetEvent(ctx, schema.O, name.O)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(name.O)
+	}
+
+	now := time.Now()
+	info := event.EventInfo
+	info.LastAltered = now
+	setEventDefiner(&info, s.Definer)
+	if s.NewName != nil {
+		if s.NewName.Schema.L == schema.L && s.NewName.Name.L == name.L {
+			return exeerrors.ErrEventSameName
+		}
+		dbInfo, ok := e.is.SchemaByName(s.NewName.Schema)
+		if !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.NewName.Schema.O)
+		}
+		info.Schema, info.Name = dbInfo.Name.O, s.NewName.Name.O
+	}
+	if s.Body != nil {
+		if err = checkEventBody(s.Body); err != nil {
+			return err
+		}
+		info.Body = s.Body.Text()
+		dbInfo, ok := e.is.SchemaByName(model.NewCIStr(info.Schema))
+		if !ok {
+			return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(info.Schema)
+		}
+		if err = e.setEventEnv(ctx, &info, dbInfo); err != nil {
+			return err
+		}
+	}
+	if s.Schedule != nil {
+		if err = e.setEventSchedule(&info, s.Schedule, now); err != nil {
+			return err
+		}
+	}
+	if s.OnCompletion != ast.EventCompletionUnspecified {
+		info.Preserve = s.OnCompletion == ast.EventCompletionPreserve
+	}
+	if s.Status != ast.EventStatusUnspecified {
+		info.Status = eventStatus(s.Status)
+	}
+	if s.Comment != nil {
+		info.Comment = *s.Comment
+	}
+	if s.Schedule != nil && isEventExpired(&info, now) {
+		if !info.Preserve {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventCannotAlterInThePast)
+			return nil
+		}
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(exeerrors.ErrEventExecTimeInThePast)
+		info.Status = eventsched.StatusDisabled
+	}
+	return manager.AlterEvent(ctx, event, &info, s.Schedule != nil)
+}
+
+func (e *SimpleExec) executeDropEvent(ctx context.Context, s *ast.DropEventStmt) error {
+	manager, err := getEventManager(e.Ctx())
+	if err != nil {
+		return err
+	}
+	name := s.EventName.Name
+	dropped, err := manager.DropEvent(ctx, s.EventName.Schema.O, name.O)
+	if err != nil || dropped {
+		return err
+	}
+	err = exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(name.O)
+	if s.IfExists {
+		e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+		return nil
+	}
+	return err
+}
+
+// dropEventsOfSchema removes the events of a dropped database.
+func dropEventsOfSchema(ctx context.Context, sctx sessionctx.Context, schema model.CIStr) error {
+	manager := domain.GetDomain(sctx).EventManager()
+	if manager == nil {
+		return nil
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	return manager.DropEventsOfSchema(ctx, schema.O)
+}
+
+// loadVisibleEvents returns the events of a schema, or all the events if the schema is empty. Only the
+// events in the schemas where the current user has the EVENT privilege are returned.
+func loadVisibleEvents(ctx context.Context, sctx sessionctx.Context, schema string) ([]*eventsched.Event, error) {
+	manager := domain.GetDomain(sctx).EventManager()
+	if manager == nil {
+		return nil, nil
+	}
+	events, err := manager.GetEvents(ctx, schema)
+	if err != nil {
+		return nil, err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	visible := events[:0]
+	for _, event := range events {
+		if checker == nil || checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, strings.ToLower(event.Schema), "", "", mysql.EventPriv) {
+			visible = append(visible, event)
+		}
+	}
+	return visible, nil
+}
+
+// eventLocation returns the time zone of an event, the times of the event are shown in it.
+func eventLocation(info *eventsched.EventInfo) *time.Location {
+	loc, err := timeutil.ParseTimeZone(info.TimeZone)
+	if err != nil {
+		return timeutil.SystemLocation()
+	}
+	return loc
+}
+
+// eventTime converts a time of an event to a DATETIME value, it returns nil for a zero time.
+func eventTime(t time.Time, loc *time.Location) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+	return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, types.DefaultFsp)
+}
+
+// eventSchedule returns the type, execute at, interval value, interval field, starts and ends of an event.
+func eventSchedule(info *eventsched.EventInfo, loc *time.Location) []interface{} {
+	if !info.IsRecurring() {
+		return []interface{}{eventTypeOneTime, eventTime(info.ExecuteAt, loc), nil, nil, nil, nil}
+	}
+	return []interface{}{eventTypeRecurring, nil, info.IntervalValue, info.IntervalField,
+		eventTime(info.Starts, loc), eventTime(info.Ends, loc)}
+}
+
+// eventCreateStmt returns the statement to create the event.
+func eventCreateStmt(info *eventsched.EventInfo, sqlMode mysql.SQLMode, loc *time.Location) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "CREATE DEFINER=%s@%s EVENT %s ON SCHEDULE ", stringutil.Escape(info.DefinerUser, sqlMode),
+		stringutil.Escape(info.DefinerHost, sqlMode), stringutil.Escape(info.Name, sqlMode))
+	if info.IsRecurring() {
+		if strings.Contains(info.IntervalField, "_") {
+			fmt.Fprintf(&sb, "EVERY '%s' %s", info.IntervalValue, info.IntervalField)
+		} else {
+			fmt.Fprintf(&sb, "EVERY %s %s", info.IntervalValue, info.IntervalField)
+		}
+		fmt.Fprintf(&sb, " STARTS '%s'", eventTime(info.Starts, loc))
+		if !info.Ends.IsZero() {
+			fmt.Fprintf(&sb, " ENDS '%s'", eventTime(info.Ends, loc))
+		}
+	} else {
+		fmt.Fprintf(&sb, "AT '%s'", eventTime(info.ExecuteAt, loc))
+	}
+	if info.Preserve {
+		sb.WriteString(" ON COMPLETION PRESERVE")
+	} else {
+		sb.WriteString(" ON COMPLETION NOT PRESERVE")
+	}
+	switch info.Status {
+	case eventsched.StatusDisabled:
+		sb.WriteString(" DISABLE")
+	case eventsched.StatusSlavesideDisabled:
+		sb.WriteString(" DISABLE ON SLAVE")
+	default:
+		sb.WriteString(" ENABLE")
+	}
+	if info.Comment != "" {
+		fmt.Fprintf(&sb, " COMMENT '%s'", sqlexec.EscapeString(info.Comment))
+	}
+	sb.WriteString(" DO ")
+	sb.WriteString(info.Body)
+	return sb.String()
+}
+
+// fetchShowEvents fills the result of `SHOW EVENTS`.
+func (e *ShowExec) fetchShowEvents(ctx context.Context) error {
+	if e.DBName.L == "" {
+		return plannercore.ErrNoDB
+	}
+	events, err := loadVisibleEvents(ctx, e.Ctx(), e.DBName.O)
+	if err != nil {
+		return err
+	}
+	for _, event := range events {
+		loc := eventLocation(&event.EventInfo)
+		row := []interface{}{event.Schema, event.Name, event.TimeZone, event.Definer()}
+		row = append(row, eventSchedule(&event.EventInfo, loc)...)
+		row = append(row, event.Status, 0, event.CharsetClient, event.CollationConnection, event.DBCollation)
+		e.appendRow(row)
+	}
+	return nil
+}
+
+// fetchShowCreateEvent fills the result of `SHOW CREATE EVENT`.
+func (e *ShowExec) fetchShowCreateEvent(ctx context.Context) error {
+	manager, err := getEventManager(e.Ctx())
+	if err != nil {
+		return err
+	}
+	event, err := manager.GetEvent(ctx, e.Event.Schema.O, e.Event.Name.O)
+	if err != nil {
+		return err
+	}
+	if event == nil {
+		return exeerrors.ErrEventDoesNotExist.GenWithStackByArgs(e.Event.Name.O)
+	}
+	sqlMode, err := mysql.GetSQLMode(event.SQLMode)
+	if err != nil {
+		return err
+	}
+	e.appendRow([]interface{}{
+		event.Name, event.SQLMode, event.TimeZone, eventCreateStmt(&event.EventInfo, sqlMode, eventLocation(&event.EventInfo)),
+		event.CharsetClient, event.CollationConnection, event.DBCollation,
+	})
+	return nil
+}
+
+// setDataFromEvents fills the rows of `information_schema.EVENTS`.
+func (e *memtableRetriever) setDataFromEvents(ctx context.Context, sctx sessionctx.Context) error {
+	events, err := loadVisibleEvents(ctx, sctx, "")
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(events))
+	for _, event := range events {
+		loc := eventLocation(&event.EventInfo)
+		onCompletion := ast.EventCompletionNotPreserve
+		if event.Preserve {
+			onCompletion = ast.EventCompletionPreserve
+		}
+		schedule := eventSchedule(&event.EventInfo, loc)
+		rows = append(rows, types.MakeDatums(
+			infoschema.CatalogVal,             // EVENT_CATALOG
+			event.Schema,                      // EVENT_SCHEMA
+			event.Name,                        // EVENT_NAME
+			event.Definer(),                   // DEFINER
+			event.TimeZone,                    // TIME_ZONE
+			"SQL",                             // EVENT_BODY
+			event.Body,                        // EVENT_DEFINITION
+			schedule[0],                       // EVENT_TYPE
+			schedule[1],                       // EXECUTE_AT
+			schedule[2],                       // INTERVAL_VALUE
+			schedule[3],                       // INTERVAL_FIELD
+			event.SQLMode,                     // SQL_MODE
+			schedule[4],                       // STARTS
+			schedule[5],                       // ENDS
+			event.Status,                      // STATUS
+			onCompletion.String(),             // ON_COMPLETION
+			eventTime(event.Created, loc),     // CREATED
+			eventTime(event.LastAltered, loc), // LAST_ALTERED
+			eventTime(event.Summary.LastExecuted, loc), // LAST_EXECUTED
+			event.Comment,             // EVENT_COMMENT
+			0,                         // ORIGINATOR
+			event.CharsetClient,       // CHARACTER_SET_CLIENT
+			event.CollationConnection, // COLLATION_CONNECTION
+			event.DBCollation,         // DATABASE_COLLATION
+		))
+	}
+	e.rows = rows
+	return nil
+}
+
+// setDataFromEventHistory fills the rows of `information_schema.TIDB_EVENT_HISTORY`.
+func (e *memtableRetriever) setDataFromEventHistory(ctx context.Context, sctx sessionctx.Context) error {
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	chunkRows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT event_id, event_schema, event_name, definer,
+		UNIX_TIMESTAMP(start_time), UNIX_TIMESTAMP(end_time), status, error_message
+		FROM mysql.tidb_event_history ORDER BY start_time DESC`)
+	if err != nil {
+		return err
+	}
+	loc := sctx.GetSessionVars().Location()
+	checker := privilege.GetPrivilegeManager(sctx)
+	rows := make([][]types.Datum, 0, len(chunkRows))
+	for _, row := range chunkRows {
+		schema := row.GetString(1)
+		if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, strings.ToLower(schema), "", "", mysql.EventPriv) {
+			continue
+		}
+		var errMsg interface{}
+		if !row.IsNull(7) {
+			errMsg = row.GetString(7)
+		}
+		rows = append(rows, types.MakeDatums(
+			row.GetString(0), // EVENT_ID
+			schema,           // EVENT_SCHEMA
+			row.GetString(2), // EVENT_NAME
+			row.GetString(3), // DEFINER
+			eventTime(time.Unix(row.GetInt64(4), 0), loc), // START_TIME
+			eventTime(time.Unix(row.GetInt64(5), 0), loc), // END_TIME
+			row.GetString(6), // STATUS
+			errMsg,           // ERROR_MESSAGE
+		))
+	}
+	e.rows = rows
+	return nil
+}
@@ -2211,6 +2211,17 @@ func setOptionForTopSQL(sc *stmtctx.StatementContext, snapshot kv.Snapshot) {
 	}
 }
 
+// setOptionForRunaway sends the requests of the snapshot or transaction with the resource group
+// which the runaway query is switched to, if any.
+func setOptionForRunaway(sc *stmtctx.StatementContext, snapshot kv.Snapshot) {
+	if snapshot == nil {
+		return
+	}
+	if name := sc.RunawayChecker.SwitchGroupName(); len(name) > 0 {
+		snapshot.SetOption(kv.ResourceGroupName, name)
+	}
+}
+
 func isWeakConsistencyRead(ctx sessionctx.Context, node ast.Node) bool {
 	sessionVars := ctx.GetSessionVars()
 	return sessionVars.ConnectionID > 0 && sessionVars.ReadConsistency.IsWeak() &&
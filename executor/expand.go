@@ -0,0 +1,69 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/util/chunk"
+)
+
+// ExpandExec replicates the rows of its child for every grouping set. Each grouping set has a level
+// projection, which fills the columns not in the set with NULL and appends the grouping id.
+type ExpandExec struct {
+	exec.BaseExecutor
+
+	levelExprs [][]expression.Expression
+
+	childResult *chunk.Chunk
+	// level is the offset of the next level projection evaluated on childResult.
+	level int
+}
+
+// Open implements the Executor Open interface.
+func (e *ExpandExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.childResult = exec.TryNewCacheChunk(e.Children(0))
+	e.level = len(e.levelExprs)
+	return nil
+}
+
+// Next implements the Executor Next interface.
+// Every chunk of the child is projected by all the levels in turn, one level for each call.
+func (e *ExpandExec) Next(ctx context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.level == len(e.levelExprs) {
+		if err := exec.Next(ctx, e.Children(0), e.childResult); err != nil {
+			return err
+		}
+		if e.childResult.NumRows() == 0 {
+			return nil
+		}
+		e.level = 0
+	}
+	err := expression.VectorizedExecute(e.Ctx(), e.levelExprs[e.level], chunk.NewIterator4Chunk(e.childResult), req)
+	e.level++
+	return err
+}
+
+// Close implements the Executor Close interface.
+func (e *ExpandExec) Close() error {
+	e.childResult = nil
+	return e.BaseExecutor.Close()
+}
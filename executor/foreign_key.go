@@ -41,11 +41,12 @@ import (
 	"github.com/tikv/client-go/v2/txnkv/txnsnapshot"
 )
 
-// WithForeignKeyTrigger indicates the executor has foreign key check or cascade.
+// WithForeignKeyTrigger indicates the executor has foreign key check or cascade, or triggers.
 type WithForeignKeyTrigger interface {
 	GetFKChecks() []*FKCheckExec
 	GetFKCascades() []*FKCascadeExec
 	HasFKCascades() bool
+	GetTriggers() []*TriggerExec
 }
 
 // FKCheckExec uses to check foreign key constraint.
@@ -708,7 +709,9 @@ func (fkc *FKCascadeExec) buildExecutor(ctx context.Context) (exec.Executor, err
 		return nil, err
 	}
 	fkc.plan.CascadePlans = append(fkc.plan.CascadePlans, p)
+	fkc.b.inForeignKeyCascade = true
 	e := fkc.b.build(p)
+	fkc.b.inForeignKeyCascade = false
 	return e, fkc.b.err
 }
 
@@ -164,6 +164,22 @@ func TestImportIntoOptionsNegativeCase(t *testing.T) {
 		}
 	}
 
+	jsonPathsCases := []struct {
+		sql string
+		Err error
+	}{
+		{sql: `import into t from '/file.csv' with json_paths='{"id": "$.a"}'`, Err: exeerrors.ErrLoadDataUnsupportedOption},
+		{sql: `import into t from '/file.jsonl' format 'jsonl' with json_paths='aa'`, Err: exeerrors.ErrInvalidOptionVal},
+		{sql: `import into t from '/file.jsonl' format 'jsonl' with json_paths='{"id": 1}'`, Err: exeerrors.ErrInvalidOptionVal},
+		{sql: `import into t from '/file.jsonl' format 'jsonl' with json_paths='{"id": "a"}'`, Err: exeerrors.ErrInvalidOptionVal},
+		{sql: `import into t from '/file.jsonl' format 'jsonl' with json_paths=1`, Err: exeerrors.ErrInvalidOptionVal},
+		{sql: `import into t from '/file.jsonl' format 'jsonl' with json_paths='{"xx": "$.a"}'`, Err: exeerrors.ErrLoadDataWrongFormatConfig},
+	}
+	for _, c := range jsonPathsCases {
+		err := tk.ExecToErr(c.sql)
+		require.ErrorIs(t, err, c.Err, c.sql)
+	}
+
 	parameterCheck := []struct {
 		sql string
 		Err error
@@ -16,6 +16,8 @@ package importer
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"io"
 	"math"
 	"os"
@@ -49,6 +51,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/dbterror"
 	"github.com/pingcap/tidb/util/dbterror/exeerrors"
@@ -70,6 +73,10 @@ const (
 	DataFormatSQL = "sql"
 	// DataFormatParquet represents the data source file of IMPORT INTO is parquet.
 	DataFormatParquet = "parquet"
+	// DataFormatJSONL represents the data source file of IMPORT INTO is JSON Lines.
+	DataFormatJSONL = "jsonl"
+	// DataFormatAvro represents the data source file of IMPORT INTO is avro object container file.
+	DataFormatAvro = "avro"
 
 	// DefaultDiskQuota is the default disk quota for IMPORT INTO
 	DefaultDiskQuota = config.ByteSize(50 << 30) // 50GiB
@@ -92,6 +99,7 @@ const (
 	recordErrorsOption          = "record_errors"
 	detachedOption              = "detached"
 	disableTiKVImportModeOption = "disable_tikv_import_mode"
+	jsonPathsOption             = "json_paths"
 	// used for test
 	maxEngineSizeOption = "__max_engine_size"
 )
@@ -114,6 +122,7 @@ var (
 		recordErrorsOption:          true,
 		detachedOption:              false,
 		disableTiKVImportModeOption: false,
+		jsonPathsOption:             true,
 		maxEngineSizeOption:         true,
 	}
 
@@ -195,6 +204,9 @@ type Plan struct {
 	Detached              bool
 	DisableTiKVImportMode bool
 	MaxEngineSize         config.ByteSize
+	// JSONPaths maps the lower-case field name to the JSON path to extract its value,
+	// only used for JSON Lines format.
+	JSONPaths map[string]string
 
 	// used for checksum in physical mode
 	DistSQLScanConcurrency int
@@ -449,6 +461,9 @@ func NewLoadDataController(plan *Plan, tbl table.Table, astArgs *ASTArgs) (*Load
 	if err := c.initLoadColumns(columnNames); err != nil {
 		return nil, err
 	}
+	if err := c.checkJSONPaths(); err != nil {
+		return nil, err
+	}
 	return c, nil
 }
 
@@ -457,7 +472,7 @@ func (e *LoadDataController) checkFieldParams() error {
 		return exeerrors.ErrLoadDataEmptyPath
 	}
 	if e.InImportInto {
-		if e.Format != DataFormatCSV && e.Format != DataFormatParquet && e.Format != DataFormatSQL {
+		if !slices.Contains([]string{DataFormatCSV, DataFormatParquet, DataFormatSQL, DataFormatJSONL, DataFormatAvro}, e.Format) {
 			return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(e.Format)
 		}
 	} else {
@@ -482,6 +497,24 @@ func (e *LoadDataController) checkFieldParams() error {
 	return nil
 }
 
+// checkJSONPaths checks that each field in the json_paths option is an input field.
+func (e *LoadDataController) checkJSONPaths() error {
+	if len(e.JSONPaths) == 0 {
+		return nil
+	}
+	fieldNames := make(map[string]struct{}, len(e.FieldMappings))
+	for _, name := range e.getFieldNames() {
+		fieldNames[strings.ToLower(name)] = struct{}{}
+	}
+	for name := range e.JSONPaths {
+		if _, ok := fieldNames[name]; !ok {
+			return exeerrors.ErrLoadDataWrongFormatConfig.GenWithStackByArgs(
+				fmt.Sprintf("field '%s' in json_paths is not in the column list", name))
+		}
+	}
+	return nil
+}
+
 func (p *Plan) initDefaultOptions() {
 	threadCnt := runtime.GOMAXPROCS(0)
 	failpoint.Inject("mockNumCpu", func(val failpoint.Value) {
@@ -528,6 +561,10 @@ func (p *Plan) initOptions(seCtx sessionctx.Context, options []*plannercore.Load
 		}
 	}
 
+	if _, ok := specifiedOptions[jsonPathsOption]; ok && p.Format != DataFormatJSONL {
+		return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs(jsonPathsOption, "non-JSONL format")
+	}
+
 	optAsString := func(opt *plannercore.LoadDataOpt) (string, error) {
 		if opt.Value.GetType().GetType() != mysql.TypeVarString {
 			return "", exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
@@ -654,6 +691,23 @@ func (p *Plan) initOptions(seCtx sessionctx.Context, options []*plannercore.Load
 	if _, ok := specifiedOptions[disableTiKVImportModeOption]; ok {
 		p.DisableTiKVImportMode = true
 	}
+	if opt, ok := specifiedOptions[jsonPathsOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		paths := make(map[string]string)
+		if err = json.Unmarshal([]byte(v), &paths); err != nil || len(paths) == 0 {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		p.JSONPaths = make(map[string]string, len(paths))
+		for name, path := range paths {
+			if _, err = types.ParseJSONPathExpr(path); err != nil {
+				return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+			}
+			p.JSONPaths[strings.ToLower(name)] = path
+		}
+	}
 	if opt, ok := specifiedOptions[maxEngineSizeOption]; ok {
 		v, err := optAsString(opt)
 		if err != nil {
@@ -835,6 +889,21 @@ func (e *LoadDataController) reorderColumns(columnNames []string) error {
 	return nil
 }
 
+// getFieldNames returns the name of each input field, which is either the column
+// name or the user variable name. The JSON Lines and avro parser use them to
+// locate the values, since the values are not positional in those formats.
+func (e *LoadDataController) getFieldNames() []string {
+	names := make([]string, 0, len(e.FieldMappings))
+	for _, fm := range e.FieldMappings {
+		if fm.Column != nil {
+			names = append(names, fm.Column.Name.O)
+		} else {
+			names = append(names, fm.UserVar.Name)
+		}
+	}
+	return names
+}
+
 // GetFieldCount get field count.
 func (e *LoadDataController) GetFieldCount() int {
 	return len(e.FieldMappings)
@@ -882,7 +951,7 @@ func (e *LoadDataController) InitDataFiles(ctx context.Context) error {
 		}
 		// we add this check for security, we don't want user import any sensitive system files,
 		// most of which is readable text file and don't have a suffix, such as /etc/passwd
-		if !slices.Contains([]string{".csv", ".sql", ".parquet"}, strings.ToLower(filepath.Ext(e.Path))) {
+		if !slices.Contains([]string{".csv", ".sql", ".parquet", ".jsonl", ".avro"}, strings.ToLower(filepath.Ext(e.Path))) {
 			return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs("the file suffix is not supported when import from server disk")
 		}
 		dir := filepath.Dir(e.Path)
@@ -990,6 +1059,10 @@ func (e *LoadDataController) getSourceType() mydump.SourceType {
 	switch e.Format {
 	case DataFormatParquet:
 		return mydump.SourceTypeParquet
+	case DataFormatJSONL:
+		return mydump.SourceTypeJSONL
+	case DataFormatAvro:
+		return mydump.SourceTypeAvro
 	case DataFormatDelimitedData, DataFormatCSV:
 		return mydump.SourceTypeCSV
 	default:
@@ -1068,6 +1141,21 @@ func (e *LoadDataController) GetParser(
 			reader,
 			dataFileInfo.Remote.Path,
 		)
+	case DataFormatJSONL:
+		parser, err = mydump.NewJSONLParser(
+			ctx,
+			&config.JSONLConfig{ColumnPaths: e.JSONPaths},
+			reader,
+			LoadDataReadBlockSize,
+			nil,
+			e.getFieldNames(),
+		)
+	case DataFormatAvro:
+		parser, err = mydump.NewAvroParser(
+			ctx,
+			reader,
+			e.getFieldNames(),
+		)
 	}
 	if err != nil {
 		return nil, exeerrors.ErrLoadDataWrongFormatConfig.GenWithStack(err.Error())
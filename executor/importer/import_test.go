@@ -111,6 +111,14 @@ func TestInitOptionsPositiveCase(t *testing.T) {
 	require.True(t, plan.Detached, sql)
 	require.True(t, plan.DisableTiKVImportMode, sql)
 	require.Equal(t, config.ByteSize(100<<30), plan.MaxEngineSize, sql)
+
+	plan = &Plan{Format: DataFormatJSONL}
+	sql = fmt.Sprintf(sqlTemplate, jsonPathsOption+`='{"ID": "$.user.id", "name": "$.user.name"}'`)
+	stmt, err = p.ParseOneStmt(sql, "", "")
+	require.NoError(t, err, sql)
+	err = plan.initOptions(ctx, convertOptions(stmt.(*ast.ImportIntoStmt).Options))
+	require.NoError(t, err, sql)
+	require.Equal(t, map[string]string{"id": "$.user.id", "name": "$.user.name"}, plan.JSONPaths, sql)
 }
 
 func TestAdjustOptions(t *testing.T) {
@@ -132,6 +132,8 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			e.setDataFromIndexes(sctx, dbs)
 		case infoschema.TableViews:
 			e.setDataFromViews(sctx, dbs)
+		case infoschema.TableTriggers:
+			e.setDataFromTriggers(sctx, dbs)
 		case infoschema.TableEngines:
 			e.setDataFromEngines()
 		case infoschema.TableCharacterSets:
@@ -191,9 +193,17 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 		case infoschema.ClusterTableMemoryUsageOpsHistory:
 			err = e.setDataForClusterMemoryUsageOpsHistory(sctx)
 		case infoschema.TableResourceGroups:
-			err = e.setDataFromResourceGroups()
+			err = e.setDataFromResourceGroups(is)
 		case infoschema.TableRunawayWatches:
 			err = e.setDataFromRunawayWatches(sctx)
+		case infoschema.TableTiDBTTLTableStatus:
+			err = e.setDataForTiDBTTLTableStatus(ctx, sctx, dbs)
+		case infoschema.TableRoutines:
+			err = e.setDataFromRoutines(ctx, sctx)
+		case infoschema.TableEvents:
+			err = e.setDataFromEvents(ctx, sctx)
+		case infoschema.TableTiDBEventHistory:
+			err = e.setDataFromEventHistory(ctx, sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -485,6 +495,25 @@ func (e *memtableRetriever) setDataFromReferConst(sctx sessionctx.Context, schem
 	return nil
 }
 
+// appendTTLCreateOptions appends the per-table TTL job window and delete rate to the CREATE_OPTIONS of a table.
+func appendTTLCreateOptions(createOptions string, ttlInfo *model.TTLInfo) string {
+	if ttlInfo == nil {
+		return createOptions
+	}
+
+	options := make([]string, 0, 3)
+	if len(createOptions) > 0 {
+		options = append(options, createOptions)
+	}
+	if len(ttlInfo.JobWindow) > 0 {
+		options = append(options, fmt.Sprintf("ttl_job_window='%s'", ttlInfo.JobWindow))
+	}
+	if ttlInfo.DeleteRate > 0 {
+		options = append(options, fmt.Sprintf("ttl_delete_rate=%d", ttlInfo.DeleteRate))
+	}
+	return strings.Join(options, " ")
+}
+
 func (e *memtableRetriever) setDataFromTables(ctx context.Context, sctx sessionctx.Context, schemas []*model.DBInfo) error {
 	err := cache.TableRowStatsCache.Update(ctx, sctx)
 	if err != nil {
@@ -519,6 +548,7 @@ func (e *memtableRetriever) setDataFromTables(ctx context.Context, sctx sessionc
 				} else if table.TableCacheStatusType == model.TableCacheStatusEnable {
 					createOptions = "cached=on"
 				}
+				createOptions = appendTTLCreateOptions(createOptions, table.TTLInfo)
 				var autoIncID interface{}
 				hasAutoIncID, _ := infoschema.HasAutoIncrementColumn(table)
 				if hasAutoIncID {
@@ -558,6 +588,10 @@ func (e *memtableRetriever) setDataFromTables(ctx context.Context, sctx sessionc
 				if table.HasClusteredIndex() {
 					pkType = "CLUSTERED"
 				}
+				engine := "InnoDB"
+				if table.Engine != "" {
+					engine = table.Engine
+				}
 				shardingInfo := infoschema.GetShardingInfo(schema, table)
 				var policyName interface{}
 				if table.PlacementPolicyRef != nil {
@@ -568,7 +602,7 @@ func (e *memtableRetriever) setDataFromTables(ctx context.Context, sctx sessionc
 					schema.Name.O,         // TABLE_SCHEMA
 					table.Name.O,          // TABLE_NAME
 					tableType,             // TABLE_TYPE
-					"InnoDB",              // ENGINE
+					engine,                // ENGINE
 					uint64(10),            // VERSION
 					"Compact",             // ROW_FORMAT
 					rowCount,              // TABLE_ROWS
@@ -1143,6 +1177,98 @@ func (e *memtableRetriever) setDataFromViews(ctx sessionctx.Context, schemas []*
 	e.rows = rows
 }
 
+func (e *memtableRetriever) setDataFromTriggers(ctx sessionctx.Context, schemas []*model.DBInfo) {
+	checker := privilege.GetPrivilegeManager(ctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, table := range schema.Tables {
+			if len(table.Triggers) == 0 {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(ctx.GetSessionVars().ActiveRoles, schema.Name.L, table.Name.L, "", mysql.TriggerPriv) {
+				continue
+			}
+			actionOrders := make(map[string]int64)
+			for _, trigger := range table.Triggers {
+				key := trigger.Timing.String() + trigger.Event.String()
+				actionOrders[key]++
+				record := types.MakeDatums(
+					infoschema.CatalogVal,            // TRIGGER_CATALOG
+					schema.Name.O,                    // TRIGGER_SCHEMA
+					trigger.Name.O,                   // TRIGGER_NAME
+					trigger.Event.String(),           // EVENT_MANIPULATION
+					infoschema.CatalogVal,            // EVENT_OBJECT_CATALOG
+					schema.Name.O,                    // EVENT_OBJECT_SCHEMA
+					table.Name.O,                     // EVENT_OBJECT_TABLE
+					actionOrders[key],                // ACTION_ORDER
+					nil,                              // ACTION_CONDITION
+					trigger.Definition,               // ACTION_STATEMENT
+					"ROW",                            // ACTION_ORIENTATION
+					trigger.Timing.String(),          // ACTION_TIMING
+					nil,                              // ACTION_REFERENCE_OLD_TABLE
+					nil,                              // ACTION_REFERENCE_NEW_TABLE
+					"OLD",                            // ACTION_REFERENCE_OLD_ROW
+					"NEW",                            // ACTION_REFERENCE_NEW_ROW
+					triggerCreatedTime(ctx, trigger), // CREATED
+					trigger.SQLMode,                  // SQL_MODE
+					trigger.Definer.String(),         // DEFINER
+					trigger.Charset,                  // CHARACTER_SET_CLIENT
+					trigger.Collate,                  // COLLATION_CONNECTION
+					schemaCollation(schema),          // DATABASE_COLLATION
+				)
+				rows = append(rows, record)
+			}
+		}
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataFromRoutines(ctx context.Context, sctx sessionctx.Context) error {
+	routines, err := loadVisibleProcedures(ctx, sctx)
+	if err != nil {
+		return err
+	}
+	rows := make([][]types.Datum, 0, len(routines))
+	for _, r := range routines {
+		record := types.MakeDatums(
+			r.name,                // SPECIFIC_NAME
+			infoschema.CatalogVal, // ROUTINE_CATALOG
+			r.schema,              // ROUTINE_SCHEMA
+			r.name,                // ROUTINE_NAME
+			routineTypeProcedure,  // ROUTINE_TYPE
+			"",                    // DATA_TYPE
+			nil,                   // CHARACTER_MAXIMUM_LENGTH
+			nil,                   // CHARACTER_OCTET_LENGTH
+			nil,                   // NUMERIC_PRECISION
+			nil,                   // NUMERIC_SCALE
+			nil,                   // DATETIME_PRECISION
+			nil,                   // CHARACTER_SET_NAME
+			nil,                   // COLLATION_NAME
+			nil,                   // DTD_IDENTIFIER
+			"SQL",                 // ROUTINE_BODY
+			r.definition,          // ROUTINE_DEFINITION
+			nil,                   // EXTERNAL_NAME
+			nil,                   // EXTERNAL_LANGUAGE
+			"SQL",                 // PARAMETER_STYLE
+			r.isDeterministic,     // IS_DETERMINISTIC
+			r.sqlDataAccess,       // SQL_DATA_ACCESS
+			nil,                   // SQL_PATH
+			r.securityType,        // SECURITY_TYPE
+			r.created,             // CREATED
+			r.lastAltered,         // LAST_ALTERED
+			r.sqlMode,             // SQL_MODE
+			r.comment,             // ROUTINE_COMMENT
+			r.definer,             // DEFINER
+			r.charsetClient,       // CHARACTER_SET_CLIENT
+			r.collationConnection, // COLLATION_CONNECTION
+			r.dbCollation,         // DATABASE_COLLATION
+		)
+		rows = append(rows, record)
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *memtableRetriever) dataForTiKVStoreStatus(ctx sessionctx.Context) (err error) {
 	tikvStore, ok := ctx.GetStore().(helper.Storage)
 	if !ok {
@@ -3256,11 +3382,14 @@ func (e *memtableRetriever) setDataFromRunawayWatches(sctx sessionctx.Context) e
 			watch.ResourceGroupName,
 			watch.StartTime.Local().Format(time.DateTime),
 			watch.EndTime.Local().Format(time.DateTime),
-			rmpb.RunawayWatchType_name[int32(watch.Watch)],
+			resourcegroup.RunawayWatchTypeName(watch.Watch),
 			watch.WatchText,
 			watch.Source,
-			rmpb.RunawayAction_name[int32(action)],
+			resourcegroup.RunawayActionName(action),
 		)
+		if action == resourcegroup.RunawayActionSwitchGroup {
+			row[7].SetString(fmt.Sprintf("%s(%s)", row[7].GetString(), watch.SwitchGroupName), mysql.DefaultCollationName)
+		}
 		if watch.EndTime.Equal(resourcegroup.NullTime) {
 			row[3].SetString("UNLIMITED", mysql.DefaultCollationName)
 		}
@@ -3270,6 +3399,88 @@ func (e *memtableRetriever) setDataFromRunawayWatches(sctx sessionctx.Context) e
 	return nil
 }
 
+func (e *memtableRetriever) setDataForTiDBTTLTableStatus(ctx context.Context, sctx sessionctx.Context, schemas []*model.DBInfo) error {
+	exec, _ := sctx.(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnTTL)
+	chunkRows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT table_id, UNIX_TIMESTAMP(last_job_start_time),
+		UNIX_TIMESTAMP(last_job_finish_time), current_job_status FROM mysql.tidb_ttl_table_status`)
+	if err != nil {
+		return err
+	}
+	jobStatus := make(map[int64]chunk.Row, len(chunkRows))
+	for _, row := range chunkRows {
+		jobStatus[row.GetInt64(0)] = row
+	}
+
+	loc := sctx.GetSessionVars().Location()
+	getTime := func(row chunk.Row, idx int) any {
+		if row.IsNull(idx) {
+			return nil
+		}
+		return types.NewTime(types.FromGoTime(time.Unix(row.GetInt64(idx), 0).In(loc)), mysql.TypeDatetime, types.DefaultFsp)
+	}
+
+	checker := privilege.GetPrivilegeManager(sctx)
+	var rows [][]types.Datum
+	for _, schema := range schemas {
+		for _, tbl := range schema.Tables {
+			ttlInfo := tbl.TTLInfo
+			if ttlInfo == nil {
+				continue
+			}
+			if checker != nil && !checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, schema.Name.L, tbl.Name.L, "", mysql.AllPrivMask) {
+				continue
+			}
+
+			jobInterval := ttlInfo.JobInterval
+			if len(jobInterval) == 0 {
+				jobInterval = model.DefaultJobInterval.String()
+			}
+			var jobWindow, deleteRate any
+			if len(ttlInfo.JobWindow) > 0 {
+				jobWindow = ttlInfo.JobWindow
+			}
+			if ttlInfo.DeleteRate > 0 {
+				deleteRate = ttlInfo.DeleteRate
+			}
+
+			appendRow := func(partitionName any, physicalID int64) {
+				var lastJobStartTime, lastJobFinishTime, currentJobStatus any
+				if status, ok := jobStatus[physicalID]; ok {
+					lastJobStartTime, lastJobFinishTime = getTime(status, 1), getTime(status, 2)
+					if !status.IsNull(3) {
+						currentJobStatus = status.GetString(3)
+					}
+				}
+				record := types.MakeDatums(
+					schema.Name.O,     // TABLE_SCHEMA
+					tbl.Name.O,        // TABLE_NAME
+					partitionName,     // PARTITION_NAME
+					physicalID,        // TABLE_ID
+					ttlInfo.Enable,    // TTL_ENABLE
+					jobInterval,       // TTL_JOB_INTERVAL
+					jobWindow,         // TTL_JOB_WINDOW
+					deleteRate,        // TTL_DELETE_RATE
+					lastJobStartTime,  // LAST_JOB_START_TIME
+					lastJobFinishTime, // LAST_JOB_FINISH_TIME
+					currentJobStatus,  // CURRENT_JOB_STATUS
+				)
+				rows = append(rows, record)
+			}
+
+			if pi := tbl.GetPartitionInfo(); pi != nil {
+				for _, def := range pi.Definitions {
+					appendRow(def.Name.O, def.ID)
+				}
+			} else {
+				appendRow(nil, tbl.ID)
+			}
+		}
+	}
+	e.rows = rows
+	return nil
+}
+
 // used in resource_groups
 const (
 	burstableStr      = "YES"
@@ -3277,7 +3488,7 @@ const (
 	unlimitedFillRate = "UNLIMITED"
 )
 
-func (e *memtableRetriever) setDataFromResourceGroups() error {
+func (e *memtableRetriever) setDataFromResourceGroups(is infoschema.InfoSchema) error {
 	resourceGroups, err := infosync.ListResourceGroups(context.TODO())
 	if err != nil {
 		return errors.Errorf("failed to access resource group manager, error message is %s", err.Error())
@@ -3298,9 +3509,28 @@ func (e *memtableRetriever) setDataFromResourceGroups() error {
 			if setting.Rule == nil {
 				return errors.Errorf("unexpected runaway config in resource group")
 			}
-			dur := time.Duration(setting.Rule.ExecElapsedTimeMs) * time.Millisecond
-			fmt.Fprintf(limitBuilder, "EXEC_ELAPSED='%s'", dur.String())
-			fmt.Fprintf(limitBuilder, ", ACTION=%s", model.RunawayActionType(setting.Action).String())
+			if setting.Rule.ExecElapsedTimeMs > 0 {
+				dur := time.Duration(setting.Rule.ExecElapsedTimeMs) * time.Millisecond
+				fmt.Fprintf(limitBuilder, "EXEC_ELAPSED='%s', ", dur.String())
+			}
+			// the following rules and the SWITCH_GROUP action are only checked by TiDB, so they're not stored
+			// in resource manager.
+			action := model.RunawayActionType(setting.Action).String()
+			if info, ok := is.ResourceGroupByName(model.NewCIStr(group.Name)); ok && info.Runaway != nil {
+				if info.Runaway.ProcessedKeys > 0 {
+					fmt.Fprintf(limitBuilder, "PROCESSED_KEYS=%d, ", info.Runaway.ProcessedKeys)
+				}
+				if info.Runaway.RequestUnit > 0 {
+					fmt.Fprintf(limitBuilder, "RU=%d, ", info.Runaway.RequestUnit)
+				}
+				if info.Runaway.MemoryBytes > 0 {
+					fmt.Fprintf(limitBuilder, "MEMORY=%d, ", info.Runaway.MemoryBytes)
+				}
+				if info.Runaway.Action == model.RunawayActionSwitchGroup {
+					action = fmt.Sprintf("%s(%s)", info.Runaway.Action.String(), info.Runaway.SwitchGroupName)
+				}
+			}
+			fmt.Fprintf(limitBuilder, "ACTION=%s", action)
 			if setting.Watch != nil {
 				if setting.Watch.LastingDurationMs > 0 {
 					dur := time.Duration(setting.Watch.LastingDurationMs) * time.Millisecond
@@ -64,14 +64,32 @@ func (e *InsertExec) exec(ctx context.Context, rows [][]types.Datum) error {
 	// If tidb_batch_insert is ON and not in a transaction, we could use BatchInsert mode.
 	sessVars := e.Ctx().GetSessionVars()
 	defer sessVars.CleanBuffers()
-	ignoreErr := sessVars.StmtCtx.DupKeyAsWarning
 
 	txn, err := e.Ctx().Txn(true)
 	if err != nil {
 		return err
 	}
 	setOptionForTopSQL(sessVars.StmtCtx, txn)
+	setOptionForRunaway(sessVars.StmtCtx, txn)
 	sessVars.StmtCtx.AddRecordRows(uint64(len(rows)))
+	// The BEFORE triggers are fired for a row right before it's inserted, so the rows are inserted one by one.
+	if e.triggers.hasTriggers(model.TriggerTimingBefore, model.TriggerEventInsert) {
+		for _, row := range rows {
+			if err := e.triggers.fireBefore(ctx, model.TriggerEventInsert, nil, row); err != nil {
+				return err
+			}
+			if err := e.insertRows(ctx, [][]types.Datum{row}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return e.insertRows(ctx, rows)
+}
+
+func (e *InsertExec) insertRows(ctx context.Context, rows [][]types.Datum) error {
+	sessVars := e.Ctx().GetSessionVars()
+	ignoreErr := sessVars.StmtCtx.DupKeyAsWarning
 	// If you use the IGNORE keyword, duplicate-key error that occurs while executing the INSERT statement are ignored.
 	// For example, without IGNORE, a row that duplicates an existing UNIQUE index or PRIMARY KEY value in
 	// the table causes a duplicate-key error and the statement is aborted. With IGNORE, the row is discarded and no error occurs.
@@ -426,7 +444,7 @@ func (e *InsertExec) doDupRowUpdate(ctx context.Context, handle kv.Handle, oldRo
 	}
 
 	newData := e.row4Update[:len(oldRow)]
-	_, err := updateRecord(ctx, e.Ctx(), handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.fkChecks, e.fkCascades)
+	_, err := updateRecord(ctx, e.Ctx(), handle, oldRow, newData, assignFlag, e.Table, true, e.memTracker, e.fkChecks, e.fkCascades, e.triggers)
 	if err != nil {
 		return err
 	}
@@ -469,3 +487,11 @@ func (e *InsertExec) GetFKCascades() []*FKCascadeExec {
 func (e *InsertExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetTriggers implements WithForeignKeyTrigger interface.
+func (e *InsertExec) GetTriggers() []*TriggerExec {
+	if e.triggers == nil {
+		return nil
+	}
+	return []*TriggerExec{e.triggers}
+}
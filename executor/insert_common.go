@@ -96,6 +96,8 @@ type InsertValues struct {
 	// fkChecks contains the foreign key checkers.
 	fkChecks   []*FKCheckExec
 	fkCascades []*FKCascadeExec
+	// triggers fires the triggers of the table, it's nil if there are no triggers.
+	triggers *TriggerExec
 }
 
 type defaultVal struct {
@@ -1195,6 +1197,7 @@ func (e *InsertValues) batchCheckAndInsert(
 		return err
 	}
 	setOptionForTopSQL(e.Ctx().GetSessionVars().StmtCtx, txn)
+	setOptionForRunaway(e.Ctx().GetSessionVars().StmtCtx, txn)
 	if e.collectRuntimeStatsEnabled() {
 		if snapshot := txn.GetSnapshot(); snapshot != nil {
 			snapshot.SetOption(kv.CollectRuntimeStats, e.stats.SnapshotRuntimeStats)
@@ -1359,6 +1362,10 @@ func (e *InsertValues) removeRow(
 		return true, nil
 	}
 
+	err = e.triggers.fireBefore(ctx, model.TriggerEventDelete, oldRow, nil)
+	if err != nil {
+		return false, err
+	}
 	err = r.t.RemoveRecord(e.Ctx(), handle, oldRow)
 	if err != nil {
 		return false, err
@@ -1367,6 +1374,9 @@ func (e *InsertValues) removeRow(
 	if err != nil {
 		return false, err
 	}
+	if err = e.triggers.fireAfter(ctx, model.TriggerEventDelete, oldRow, nil); err != nil {
+		return false, err
+	}
 	if inReplace {
 		e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(1)
 	} else {
@@ -1417,6 +1427,9 @@ func (e *InsertValues) addRecordWithAutoIDHint(
 	if e.lastInsertID != 0 {
 		vars.SetLastInsertID(e.lastInsertID)
 	}
+	if err = e.triggers.fireAfter(ctx, model.TriggerEventInsert, nil, row); err != nil {
+		return err
+	}
 	if !vars.StmtCtx.BatchCheck {
 		for _, fkc := range e.fkChecks {
 			err = fkc.insertRowNeedToCheck(vars.StmtCtx, row)
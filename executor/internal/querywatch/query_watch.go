@@ -17,6 +17,7 @@ package querywatch
 import (
 	"context"
 	"fmt"
+	"regexp"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -61,6 +62,9 @@ func setWatchOption(ctx context.Context,
 		}
 	case ast.QueryWatchAction:
 		record.Action = rmpb.RunawayAction(op.IntValue)
+		if record.Action == resourcegroup.RunawayActionSwitchGroup {
+			record.SwitchGroupName = op.StrValue.L
+		}
 	case ast.QueryWatchType:
 		expr, err := expression.RewriteAstExpr(sctx, op.ExprValue, nil, nil, false)
 		if err != nil {
@@ -74,7 +78,12 @@ func setWatchOption(ctx context.Context,
 			return errors.Errorf("invalid watch text expression")
 		}
 		record.Watch = rmpb.RunawayWatchType(op.IntValue)
-		if op.BoolValue {
+		if record.Watch == resourcegroup.RunawayWatchTypeRegexp {
+			if _, err := regexp.Compile(strval); err != nil {
+				return errors.Annotate(err, "invalid regular expression")
+			}
+			record.WatchText = strval
+		} else if op.BoolValue {
 			p := parser.New()
 			stmts, _, err := p.ParseSQL(strval)
 			if err != nil {
@@ -131,7 +140,9 @@ func fromQueryWatchOptionList(ctx context.Context, sctx, newSctx sessionctx.Cont
 // validateWatchRecord follows several designs:
 //  1. If no resource group is set, the default resource group is used
 //  2. If no action is specified, the action of the resource group is used. If no, an error message is displayed.
-func validateWatchRecord(record *resourcegroup.QuarantineRecord, client *rmclient.ResourceGroupsController) error {
+//     SWITCH_GROUP is unknown to resource manager, so it's taken from the runaway settings in schema.
+//  3. The resource group which the query is switched to must exist and be different from the watched one.
+func validateWatchRecord(record *resourcegroup.QuarantineRecord, is infoschema.InfoSchema, client *rmclient.ResourceGroupsController) error {
 	if len(record.ResourceGroupName) == 0 {
 		record.ResourceGroupName = resourcegroup.DefaultResourceGroupName
 	}
@@ -147,10 +158,27 @@ func validateWatchRecord(record *resourcegroup.QuarantineRecord, client *rmclien
 			return errors.Errorf("must set runaway config for resource group `%s`", record.ResourceGroupName)
 		}
 		record.Action = rg.RunawaySettings.Action
+		if group, ok := is.ResourceGroupByName(model.NewCIStr(record.ResourceGroupName)); ok &&
+			group.Runaway != nil && group.Runaway.Action == model.RunawayActionSwitchGroup {
+			record.Action = resourcegroup.RunawayActionSwitchGroup
+			record.SwitchGroupName = group.Runaway.SwitchGroupName
+		}
 	}
 	if record.Watch == rmpb.RunawayWatchType_NoneWatch {
 		return errors.Errorf("must specify watch type")
 	}
+	if record.Action == resourcegroup.RunawayActionSwitchGroup {
+		if record.SwitchGroupName == record.ResourceGroupName {
+			return errors.Errorf("can not switch to the watched resource group `%s`", record.ResourceGroupName)
+		}
+		switchGroup, err := client.GetResourceGroup(record.SwitchGroupName)
+		if err != nil {
+			return err
+		}
+		if switchGroup == nil {
+			return infoschema.ErrResourceGroupNotExists.GenWithStackByArgs(record.SwitchGroupName)
+		}
+	}
 	return nil
 }
 
@@ -177,7 +205,7 @@ func (e *AddExecutor) Next(ctx context.Context, req *chunk.Chunk) error {
 		return err
 	}
 	do := domain.GetDomain(e.Ctx())
-	if err := validateWatchRecord(record, do.ResourceGroupsController()); err != nil {
+	if err := validateWatchRecord(record, do.InfoSchema(), do.ResourceGroupsController()); err != nil {
 		return err
 	}
 	err = do.AddRunawayWatch(record)
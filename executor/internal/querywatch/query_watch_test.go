@@ -125,3 +125,59 @@ func TestQueryWatch(t *testing.T) {
 	time.Sleep(1 * time.Second)
 	tk.MustGetErrCode("select * from test.t1", mysql.ErrResourceGroupQueryRunawayQuarantine)
 }
+
+func TestQueryWatchRegexpAndSwitchGroup(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(a int)")
+	tk.MustExec("insert into t1 values(1), (2)")
+	tk.MustExec("create table t2(a int)")
+	tk.MustExec("insert into t2 values(1), (2)")
+	tk.MustExec("create resource group rg1 RU_PER_SEC=1000 QUERY_LIMIT=(EXEC_ELAPSED='50ms' ACTION=KILL)")
+	tk.MustExec("create resource group rg_low RU_PER_SEC=100 PRIORITY=LOW")
+
+	_, err := tk.Exec("query watch add resource group rg1 sql text regexp to 'select ([0-9]'")
+	require.ErrorContains(t, err, "invalid regular expression")
+	_, err = tk.Exec("query watch add resource group rg1 action switch_group(rg2) sql text regexp to 'select .* from t1'")
+	require.ErrorContains(t, err, "the group rg2 does not exist")
+	_, err = tk.Exec("query watch add resource group rg1 action switch_group(rg1) sql text regexp to 'select .* from t1'")
+	require.ErrorContains(t, err, "can not switch to the watched resource group `rg1`")
+
+	tk.MustExec("query watch add resource group rg1 sql text regexp to '^select [*] from t1 where a = [0-9]+$'")
+	tk.MustExec("query watch add resource group rg1 action switch_group(rg_low) sql text regexp to '(?i)^select [*] from t2'")
+	tk.MustExec("query watch add resource group rg1 action switch_group(rg_low) sql text exact to 'select a from t1'")
+	tryInterval := time.Millisecond * 200
+	maxWaitDuration := time.Second * 5
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE resource_group_name, watch_text, action, watch, switch_group_name from mysql.tidb_runaway_watch order by id", nil,
+		testkit.Rows("rg1 ^select [*] from t1 where a = [0-9]+$ 3 4 ",
+			"rg1 (?i)^select [*] from t2 4 4 rg_low",
+			"rg1 select a from t1 4 1 rg_low",
+		), maxWaitDuration, tryInterval)
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE resource_group_name, watch_text, action, watch from information_schema.runaway_watches order by id", nil,
+		testkit.Rows("rg1 ^select [*] from t1 where a = [0-9]+$ Kill Regexp",
+			"rg1 (?i)^select [*] from t2 SwitchGroup(rg_low) Regexp",
+			"rg1 select a from t1 SwitchGroup(rg_low) Exact",
+		), maxWaitDuration, tryInterval)
+
+	// the watches only work for the queries of rg1
+	tk.MustQuery("select * from t1 where a = 1").Check(testkit.Rows("1"))
+	tk.MustExec("SET RESOURCE GROUP rg1")
+	tk.MustGetErrCode("select * from t1 where a = 1", mysql.ErrResourceGroupQueryRunawayQuarantine)
+	tk.MustGetErrCode("select * from t1 where a = 123", mysql.ErrResourceGroupQueryRunawayQuarantine)
+	tk.MustQuery("select * from t1 where a = 1 or a = 2").Check(testkit.Rows("1", "2"))
+	tk.MustQuery("SELECT * FROM t2 where a > 1").Check(testkit.Rows("2"))
+	tk.MustQuery("select a from t1").Check(testkit.Rows("1", "2"))
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE resource_group_name, original_sql, match_type, action from mysql.tidb_runaway_queries order by time", nil,
+		testkit.Rows("rg1 select * from t1 where a = 1 watch kill",
+			"rg1 select * from t1 where a = 123 watch kill",
+			"rg1 SELECT * FROM t2 where a > 1 watch switchgroup",
+			"rg1 select a from t1 watch switchgroup",
+		), maxWaitDuration, tryInterval)
+
+	// the removed regular expression watch doesn't work any more
+	tk.MustExec("query watch remove 1")
+	tk.EventuallyMustQueryAndCheck("select SQL_NO_CACHE count(*) from information_schema.runaway_watches", nil,
+		testkit.Rows("2"), maxWaitDuration, tryInterval)
+	tk.MustQuery("select * from t1 where a = 1").Check(testkit.Rows("1"))
+}
@@ -549,14 +549,19 @@ func (w *probeWorker) joinMatchedProbeSideRow2ChunkForOuterHashJoin(probeKey uin
 		joinResult.err = err
 		return false, joinResult
 	}
+	// For full outer join, the probe side row is also kept when it matches no build side row.
+	fullJoiner, isFullJoin := w.joiner.(*fullOuterJoiner)
 	if len(buildSideRows) == 0 {
+		if isFullJoin {
+			fullJoiner.onInnerMissMatch(probeSideRow, joinResult.chk)
+		}
 		return true, joinResult
 	}
 
 	iter := w.rowIters
 	iter.Reset(buildSideRows)
 	var outerMatchStatus []outerRowStatusFlag
-	rowIdx, ok := 0, false
+	rowIdx, ok, probeSideRowMatched := 0, false, false
 	for iter.Begin(); iter.Current() != iter.End(); {
 		outerMatchStatus, err = w.joiner.tryToMatchOuters(iter, probeSideRow, joinResult.chk, outerMatchStatus)
 		if err != nil {
@@ -565,6 +570,7 @@ func (w *probeWorker) joinMatchedProbeSideRow2ChunkForOuterHashJoin(probeKey uin
 		}
 		for i := range outerMatchStatus {
 			if outerMatchStatus[i] == outerRowMatched {
+				probeSideRowMatched = true
 				w.hashJoinCtx.outerMatchedStatus[rowsPtrs[rowIdx+i].ChkIdx].Set(int(rowsPtrs[rowIdx+i].RowIdx))
 			}
 		}
@@ -577,6 +583,9 @@ func (w *probeWorker) joinMatchedProbeSideRow2ChunkForOuterHashJoin(probeKey uin
 			}
 		}
 	}
+	if isFullJoin && !probeSideRowMatched {
+		fullJoiner.onInnerMissMatch(probeSideRow, joinResult.chk)
+	}
 	return true, joinResult
 }
 
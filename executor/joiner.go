@@ -125,6 +125,8 @@ func JoinerType(j joiner) plannercore.JoinType {
 		return plannercore.LeftOuterJoin
 	case *rightOuterJoiner:
 		return plannercore.RightOuterJoin
+	case *fullOuterJoiner:
+		return plannercore.FullOuterJoin
 	default:
 		return plannercore.InnerJoin
 	}
@@ -158,7 +160,7 @@ func newJoiner(ctx sessionctx.Context, joinType plannercore.JoinType,
 			zap.Ints("lUsed", base.lUsed), zap.Ints("rUsed", base.rUsed),
 			zap.Int("lCount", len(lhsColTypes)), zap.Int("rCount", len(rhsColTypes)))
 	}
-	if joinType == plannercore.LeftOuterJoin || joinType == plannercore.RightOuterJoin {
+	if joinType == plannercore.LeftOuterJoin || joinType == plannercore.RightOuterJoin || joinType == plannercore.FullOuterJoin {
 		innerColTypes := lhsColTypes
 		if !outerIsRight {
 			innerColTypes = rhsColTypes
@@ -202,6 +204,25 @@ func newJoiner(ctx sessionctx.Context, joinType plannercore.JoinType,
 		case plannercore.InnerJoin:
 			return &innerJoiner{base}
 		}
+	case plannercore.FullOuterJoin:
+		if len(base.conditions) > 0 {
+			base.chk = chunk.NewChunkWithCapacity(shallowRowType, ctx.GetSessionVars().MaxChunkSize)
+		}
+		outerColTypes := lhsColTypes
+		var outerJoiner joiner = &leftOuterJoiner{base}
+		if outerIsRight {
+			outerColTypes = rhsColTypes
+			outerJoiner = &rightOuterJoiner{base}
+		}
+		defaultOuter := chunk.MutRowFromTypes(outerColTypes)
+		defaultOuter.SetDatums(make([]types.Datum, len(outerColTypes))...)
+		return &fullOuterJoiner{
+			joiner:       outerJoiner,
+			defaultOuter: defaultOuter.ToRow(),
+			outerIsRight: outerIsRight,
+			lUsed:        base.lUsed,
+			rUsed:        base.rUsed,
+		}
 	}
 	panic("unsupported join type in func newJoiner()")
 }
@@ -956,6 +977,38 @@ func (j *rightOuterJoiner) Clone() joiner {
 	return &rightOuterJoiner{baseJoiner: j.baseJoiner.Clone()}
 }
 
+// fullOuterJoiner joins the outer rows as leftOuterJoiner or rightOuterJoiner does, and the
+// executor calls onInnerMissMatch for the inner rows which match no outer row.
+type fullOuterJoiner struct {
+	joiner
+	// defaultOuter is the row of NULLs appended to the unmatched inner rows.
+	defaultOuter chunk.Row
+	outerIsRight bool
+	lUsed, rUsed []int
+}
+
+// onInnerMissMatch appends the inner row which matches no outer row to chk, the columns
+// of the outer side are filled with NULL.
+func (j *fullOuterJoiner) onInnerMissMatch(inner chunk.Row, chk *chunk.Chunk) {
+	if j.outerIsRight {
+		lWide := chk.AppendRowByColIdxs(inner, j.lUsed)
+		chk.AppendPartialRowByColIdxs(lWide, j.defaultOuter, j.rUsed)
+		return
+	}
+	lWide := chk.AppendRowByColIdxs(j.defaultOuter, j.lUsed)
+	chk.AppendPartialRowByColIdxs(lWide, inner, j.rUsed)
+}
+
+func (j *fullOuterJoiner) Clone() joiner {
+	return &fullOuterJoiner{
+		joiner:       j.joiner.Clone(),
+		defaultOuter: j.defaultOuter.CopyConstruct(),
+		outerIsRight: j.outerIsRight,
+		lUsed:        j.lUsed,
+		rUsed:        j.rUsed,
+	}
+}
+
 type innerJoiner struct {
 	baseJoiner
 }
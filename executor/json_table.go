@@ -0,0 +1,172 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+)
+
+// JSONTableExec evaluates the JSON_TABLE table function. The JSON document is evaluated
+// once in Open, so the executor is reopened for every outer row when it's the inner side
+// of an Apply.
+type JSONTableExec struct {
+	exec.BaseExecutor
+
+	expr   expression.Expression
+	root   *plannercore.JSONTablePath
+	asName model.CIStr
+
+	rows   [][]types.Datum
+	cursor int
+}
+
+// Open implements the Executor Open interface.
+func (e *JSONTableExec) Open(ctx context.Context) error {
+	if err := e.BaseExecutor.Open(ctx); err != nil {
+		return err
+	}
+	e.rows = e.rows[:0]
+	e.cursor = 0
+	doc, isNull, err := e.expr.EvalJSON(e.Ctx(), chunk.Row{})
+	if err != nil || isNull {
+		return err
+	}
+	row := make([]types.Datum, e.Schema().Len())
+	return e.buildRows(e.root, doc, row)
+}
+
+// Next implements the Executor Next interface.
+func (e *JSONTableExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.GrowAndReset(e.MaxChunkSize())
+	for ; e.cursor < len(e.rows) && !req.IsFull(); e.cursor++ {
+		for i := range e.rows[e.cursor] {
+			req.AppendDatum(i, &e.rows[e.cursor][i])
+		}
+	}
+	return nil
+}
+
+// Close implements the Executor Close interface.
+func (e *JSONTableExec) Close() error {
+	e.rows = nil
+	return e.BaseExecutor.Close()
+}
+
+// buildRows produces the rows of every value matched by path in doc. The rows of the
+// sibling nested paths are combined in a union, and a row whose nested paths match
+// nothing is kept with the nested columns set to NULL.
+func (e *JSONTableExec) buildRows(path *plannercore.JSONTablePath, doc types.BinaryJSON, row []types.Datum) error {
+	for i, value := range doc.ExtractAll(path.Path) {
+		for _, col := range path.Columns {
+			d, err := e.evalColumn(col, value, i+1)
+			if err != nil {
+				return err
+			}
+			row[col.Offset] = d
+		}
+		produced := false
+		for _, nested := range path.Nested {
+			numRows := len(e.rows)
+			if err := e.buildRows(nested, value, row); err != nil {
+				return err
+			}
+			produced = produced || len(e.rows) > numRows
+			resetJSONTableColumns(nested, row)
+		}
+		if !produced {
+			e.rows = append(e.rows, append([]types.Datum(nil), row...))
+		}
+	}
+	return nil
+}
+
+func resetJSONTableColumns(path *plannercore.JSONTablePath, row []types.Datum) {
+	for _, col := range path.Columns {
+		row[col.Offset].SetNull()
+	}
+	for _, nested := range path.Nested {
+		resetJSONTableColumns(nested, row)
+	}
+}
+
+func (e *JSONTableExec) evalColumn(col *plannercore.JSONTableColumn, value types.BinaryJSON, ordinality int) (types.Datum, error) {
+	ft := e.Schema().Columns[col.Offset].RetType
+	switch col.Tp {
+	case ast.JSONTableColumnOrdinality:
+		return types.NewUintDatum(uint64(ordinality)), nil
+	case ast.JSONTableColumnExistsPath:
+		exists := int64(0)
+		if len(value.ExtractAll(col.Path)) > 0 {
+			exists = 1
+		}
+		d := types.NewIntDatum(exists)
+		return d.ConvertTo(e.Ctx().GetSessionVars().StmtCtx, ft)
+	}
+	matches := value.ExtractAll(col.Path)
+	if len(matches) == 0 {
+		return e.onResponse(col, col.OnEmpty, exeerrors.ErrMissingJSONTableValue.GenWithStackByArgs(col.Name.O))
+	}
+	if len(matches) > 1 {
+		return e.onResponse(col, col.OnError, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.asName.O))
+	}
+	d, err := e.convertValue(col, matches[0])
+	if err != nil {
+		return e.onResponse(col, col.OnError, err)
+	}
+	return d, nil
+}
+
+// onResponse handles the ON EMPTY or ON ERROR clause of col, the default response is NULL.
+func (e *JSONTableExec) onResponse(col *plannercore.JSONTableColumn, resp *plannercore.JSONTableOnResponse, err error) (types.Datum, error) {
+	if resp == nil || resp.Tp == ast.JSONTableOnResponseNull {
+		return types.Datum{}, nil
+	}
+	if resp.Tp == ast.JSONTableOnResponseError {
+		return types.Datum{}, err
+	}
+	return e.convertValue(col, resp.Default)
+}
+
+func (e *JSONTableExec) convertValue(col *plannercore.JSONTableColumn, value types.BinaryJSON) (types.Datum, error) {
+	ft := e.Schema().Columns[col.Offset].RetType
+	if ft.GetType() == mysql.TypeJSON {
+		return types.NewJSONDatum(value), nil
+	}
+	var d types.Datum
+	switch value.TypeCode {
+	case types.JSONTypeCodeObject, types.JSONTypeCodeArray:
+		return d, exeerrors.ErrWrongJSONTableValue.GenWithStackByArgs(col.Name.O, e.asName.O)
+	case types.JSONTypeCodeLiteral:
+		if value.Value[0] == types.JSONLiteralNil {
+			return d, nil
+		}
+		d = types.NewJSONDatum(value)
+	case types.JSONTypeCodeString:
+		d = types.NewStringDatum(string(value.GetString()))
+	default:
+		d = types.NewJSONDatum(value)
+	}
+	return d.ConvertTo(e.Ctx().GetSessionVars().StmtCtx, ft)
+}
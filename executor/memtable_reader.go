@@ -34,6 +34,7 @@ import (
 	"github.com/pingcap/sysutil"
 	"github.com/pingcap/tidb/config"
 	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/extension"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
@@ -43,6 +44,7 @@ import (
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/sessiontxn"
 	"github.com/pingcap/tidb/store/helper"
+	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util"
 	"github.com/pingcap/tidb/util/chunk"
@@ -995,3 +997,64 @@ func (e *tikvRegionPeersRetriever) packTiKVRegionPeersRows(
 	}
 	return rows, nil
 }
+
+// customEngineTableRetriever reads the rows of a table with custom engine from the table source provided by extension.
+type customEngineTableRetriever struct {
+	table     *tables.CustomEngineTable
+	columns   []*model.ColumnInfo
+	extractor *plannercore.CustomEngineTableExtractor
+	reader    extension.TableReader
+	count     uint64
+	retrieved bool
+}
+
+func (e *customEngineTableRetriever) retrieve(ctx context.Context, sctx sessionctx.Context) ([][]types.Datum, error) {
+	if e.retrieved {
+		return nil, nil
+	}
+
+	if e.reader == nil {
+		reader, err := e.table.OpenReader(ctx, sctx, &extension.TableReadHints{
+			Columns: e.columns,
+			Filters: e.extractor.Filters,
+			Limit:   e.extractor.Limit,
+		})
+		if err != nil {
+			return nil, err
+		}
+		e.reader = reader
+	}
+
+	rows, err := e.reader.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		if len(row) != len(e.columns) {
+			return nil, errors.Errorf("table source of engine '%s' returns %d columns, but %d expected",
+				e.table.Meta().Engine, len(row), len(e.columns))
+		}
+	}
+
+	if limit := e.extractor.Limit; limit > 0 && e.count+uint64(len(rows)) >= limit {
+		rows = rows[:limit-e.count]
+		e.retrieved = true
+	}
+	e.count += uint64(len(rows))
+	if len(rows) == 0 {
+		e.retrieved = true
+	}
+	return rows, nil
+}
+
+func (e *customEngineTableRetriever) close() error {
+	if e.reader != nil {
+		return e.reader.Close()
+	}
+	return nil
+}
+
+func (*customEngineTableRetriever) getRuntimeStats() execdetails.RuntimeStats {
+	return nil
+}
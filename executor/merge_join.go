@@ -44,11 +44,17 @@ type MergeJoinExec struct {
 	isOuterJoin  bool
 	desc         bool
 
+	// isFullOuterJoin indicates the rows of the inner table which match no outer row are also
+	// output, the joiner is a *fullOuterJoiner in this case.
+	isFullOuterJoin bool
+
 	innerTable *mergeJoinTable
 	outerTable *mergeJoinTable
 
 	hasMatch bool
 	hasNull  bool
+	// innerGroupMatched indicates whether the current inner group matches any outer row.
+	innerGroupMatched bool
 
 	memTracker  *memory.Tracker
 	diskTracker *disk.Tracker
@@ -60,6 +66,9 @@ type mergeJoinTable struct {
 	childIndex int
 	joinKeys   []*expression.Column
 	filters    []expression.Expression
+	// keepNullKeys indicates the inner rows having null in join keys are not skipped,
+	// it's set for the inner table of full outer join.
+	keepNullKeys bool
 
 	executed          bool
 	childChunk        *chunk.Chunk
@@ -148,7 +157,7 @@ func (t *mergeJoinTable) finish() error {
 func (t *mergeJoinTable) selectNextGroup() {
 	t.groupRowsSelected = t.groupRowsSelected[:0]
 	begin, end := t.groupChecker.GetNextGroup()
-	if t.isInner && t.hasNullInJoinKey(t.childChunk.GetRow(begin)) {
+	if t.isInner && !t.keepNullKeys && t.hasNullInJoinKey(t.childChunk.GetRow(begin)) {
 		return
 	}
 
@@ -294,6 +303,7 @@ func (e *MergeJoinExec) Close() error {
 
 	e.hasMatch = false
 	e.hasNull = false
+	e.innerGroupMatched = false
 	e.memTracker = nil
 	e.diskTracker = nil
 	return e.BaseExecutor.Close()
@@ -330,6 +340,7 @@ func (e *MergeJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error)
 				return err
 			}
 			innerIter = e.innerTable.groupRowsIter
+			e.innerGroupMatched = false
 		}
 		if outerIter.Current() == outerIter.End() {
 			if err := e.outerTable.fetchNextOuterGroup(ctx, e, req.RequiredRows()-req.NumRows()); err != nil {
@@ -337,7 +348,12 @@ func (e *MergeJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error)
 			}
 			outerIter = e.outerTable.groupRowsIter
 			if e.outerTable.executed {
-				return nil
+				if !e.isFullOuterJoin || innerIter.Current() == innerIter.End() {
+					return nil
+				}
+				// the remaining inner groups match no outer row
+				e.innerGroupMissMatch(innerIter, req)
+				continue
 			}
 		}
 
@@ -346,6 +362,11 @@ func (e *MergeJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error)
 			cmpResult = 1
 		}
 		if innerIter.Current() != innerIter.End() {
+			if e.isFullOuterJoin && e.innerTable.hasNullInJoinKey(innerIter.Current()) {
+				// the inner group having null in join keys matches no outer row
+				e.innerGroupMissMatch(innerIter, req)
+				continue
+			}
 			cmpResult, err = e.compare(outerIter.Current(), innerIter.Current())
 			if err != nil {
 				return err
@@ -353,6 +374,10 @@ func (e *MergeJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error)
 		}
 		// the inner group falls behind
 		if (cmpResult > 0 && !e.desc) || (cmpResult < 0 && e.desc) {
+			if e.isFullOuterJoin {
+				e.innerGroupMissMatch(innerIter, req)
+				continue
+			}
 			innerIter.ReachEnd()
 			continue
 		}
@@ -378,6 +403,7 @@ func (e *MergeJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error)
 				}
 				e.hasMatch = e.hasMatch || matched
 				e.hasNull = e.hasNull || isNull
+				e.innerGroupMatched = e.innerGroupMatched || matched
 				if req.IsFull() {
 					if innerIter.Current() == innerIter.End() {
 						break
@@ -397,6 +423,19 @@ func (e *MergeJoinExec) Next(ctx context.Context, req *chunk.Chunk) (err error)
 	return nil
 }
 
+// innerGroupMissMatch outputs the rest rows of the current inner group for full outer join
+// if the group matches no outer row, otherwise the group is skipped.
+func (e *MergeJoinExec) innerGroupMissMatch(innerIter chunk.Iterator, req *chunk.Chunk) {
+	if e.innerGroupMatched {
+		innerIter.ReachEnd()
+		return
+	}
+	fullJoiner := e.joiner.(*fullOuterJoiner)
+	for row := innerIter.Current(); row != innerIter.End() && !req.IsFull(); row = innerIter.Next() {
+		fullJoiner.onInnerMissMatch(row, req)
+	}
+}
+
 func (e *MergeJoinExec) compare(outerRow, innerRow chunk.Row) (int, error) {
 	outerJoinKeys := e.outerTable.joinKeys
 	innerJoinKeys := e.innerTable.joinKeys
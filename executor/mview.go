@@ -0,0 +1,64 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/mview"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+func (e *DDLExec) executeCreateMaterializedView(ctx context.Context, s *ast.CreateMaterializedViewStmt) error {
+	existed := e.is.TableExists(s.ViewName.Schema, s.ViewName.Name)
+	if err := domain.GetDomain(e.Ctx()).DDL().CreateMaterializedView(e.Ctx(), s); err != nil || existed {
+		return err
+	}
+	// The view is filled by a complete refresh after it's created.
+	return refreshMaterializedView(ctx, &e.BaseExecutor, s.ViewName, model.MViewRefreshComplete)
+}
+
+func (e *DDLExec) executeDropMaterializedView(s *ast.DropMaterializedViewStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropMaterializedView(e.Ctx(), s)
+}
+
+func (e *SimpleExec) executeRefreshMaterializedView(ctx context.Context, s *ast.RefreshMaterializedViewStmt) error {
+	return refreshMaterializedView(ctx, &e.BaseExecutor, s.ViewName, s.Method)
+}
+
+// refreshMaterializedView refreshes the view in a system session, so the transaction of the current session
+// is not affected.
+func refreshMaterializedView(ctx context.Context, e *exec.BaseExecutor, name *ast.TableName, method model.MViewRefreshMethod) error {
+	se, err := e.GetSysSession()
+	if err != nil {
+		return err
+	}
+	defer e.ReleaseSysSession(kv.WithInternalSourceType(ctx, kv.InternalTxnOthers), se)
+	is := se.GetDomainInfoSchema().(infoschema.InfoSchema)
+	tbl, err := is.TableByName(name.Schema, name.Name)
+	if err != nil {
+		return err
+	}
+	if tbl.Meta().MaterializedView == nil {
+		return dbterror.ErrWrongObject.GenWithStackByArgs(name.Schema, name.Name, "MATERIALIZED VIEW")
+	}
+	return mview.Refresh(ctx, se, is, tbl.Meta(), method)
+}
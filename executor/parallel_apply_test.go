@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/util/collate"
@@ -635,3 +636,32 @@ func TestIssue24930(t *testing.T) {
     then (select t2.a from t2 where t2.a = t1.a limit 1) else t1.a end a
 	from t1 where t1.a=1 order by a limit 1`).Check(testkit.Rows()) // can return an empty result instead of hanging forever
 }
+
+func TestApplyWithLateralDerivedTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1 (id int primary key)")
+	tk.MustExec("create table t2 (a int, b int)")
+	tk.MustExec("insert into t1 values (1), (2), (3)")
+	tk.MustExec("insert into t2 values (1, 1), (1, 2), (1, 3), (1, 4), (2, 5), (2, 6)")
+
+	// The LIMIT can't be decorrelated, so it runs as an Apply.
+	q := "select t1.id, dt.b from t1, lateral (select b from t2 where t2.a = t1.id order by b desc limit 3) as dt"
+	checkApplyPlan(t, tk, q, 0)
+	tk.MustQuery(q).Sort().Check(testkit.Rows("1 2", "1 3", "1 4", "2 5", "2 6"))
+	q = "select t1.id, dt.b from t1 left join lateral (select b from t2 where t2.a = t1.id order by b limit 1) as dt on true"
+	tk.MustQuery(q).Sort().Check(testkit.Rows("1 1", "2 5", "3 <nil>"))
+	tk.MustExec("set tidb_enable_parallel_apply=true")
+	checkApplyPlan(t, tk, q, 1)
+	tk.MustQuery(q).Sort().Check(testkit.Rows("1 1", "2 5", "3 <nil>"))
+
+	// The aggregation is decorrelated into a join.
+	q = "select t1.id, dt.m from t1, lateral (select max(b) as m from t2 where t2.a = t1.id) as dt"
+	require.False(t, tk.HasPlan(q, "Apply"))
+	tk.MustQuery(q).Sort().Check(testkit.Rows("1 4", "2 6", "3 <nil>"))
+
+	tk.MustGetErrCode("select * from t1 right join lateral (select b from t2 where t2.a = t1.id) as dt on true", errno.ErrTFForbiddenJoinType)
+	tk.MustGetErrCode("select * from t1, (select b from t2 where t2.a = t1.id) as dt", errno.ErrBadField)
+}
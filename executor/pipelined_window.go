@@ -76,6 +76,12 @@ type PipelinedWindowExec struct {
 	isRangeFrame             bool
 	emptyFrame               bool
 	initializedSlidingWindow bool
+
+	// peers is used to find the peer groups for GROUPS frame and the EXCLUDE clause.
+	peers         *peerGroups
+	isGroupsFrame bool
+	// excluder is not nil if the frame has an EXCLUDE clause.
+	excluder *frameExcluder
 }
 
 // Close implements the Executor Close interface.
@@ -258,6 +264,13 @@ func (e *PipelinedWindowExec) getStart(ctx sessionctx.Context) (uint64, error) {
 	if e.start.UnBounded {
 		return 0, nil
 	}
+	if e.isGroupsFrame {
+		if err := e.peers.split(ctx, e.getRow, e.rowCnt); err != nil {
+			return 0, err
+		}
+		start, _ := e.peers.frameOffsets(e.start, e.end, e.curRowIdx, e.rowCnt)
+		return start, nil
+	}
 	if e.isRangeFrame {
 		var start uint64
 		for start = mathutil.Max(e.lastStartRow, e.stagedStartRow); start < e.rowCnt; start++ {
@@ -298,6 +311,13 @@ func (e *PipelinedWindowExec) getEnd(ctx sessionctx.Context) (uint64, error) {
 	if e.end.UnBounded {
 		return e.rowCnt, nil
 	}
+	if e.isGroupsFrame {
+		if err := e.peers.split(ctx, e.getRow, e.rowCnt); err != nil {
+			return 0, err
+		}
+		_, end := e.peers.frameOffsets(e.start, e.end, e.curRowIdx, e.rowCnt)
+		return end, nil
+	}
 	if e.isRangeFrame {
 		var end uint64
 		for end = mathutil.Max(e.lastEndRow, e.stagedEndRow); end < e.rowCnt; end++ {
@@ -364,8 +384,13 @@ func (e *PipelinedWindowExec) produce(ctx sessionctx.Context, chk *chunk.Chunk,
 		if start >= e.rowCnt {
 			start = e.rowCnt
 		}
-		// if start >= end, we should return a default value, and we reset the frame to empty.
-		if start >= end {
+		if e.excluder != nil {
+			err = e.excluder.appendResult2Chunk(ctx, e.windowFuncs, e.partialResults, e.getRow, e.rowCnt, start, end, e.curRowIdx, chk)
+			if err != nil {
+				return
+			}
+		} else if start >= end {
+			// if start >= end, we should return a default value, and we reset the frame to empty.
 			for i, wf := range e.windowFuncs {
 				if !e.emptyFrame {
 					wf.ResetPartialResult(e.partialResults[i])
@@ -457,6 +482,9 @@ func (e *PipelinedWindowExec) reset() {
 	e.rowStart = 0
 	e.rowCnt = 0
 	e.initializedSlidingWindow = false
+	if e.peers != nil {
+		e.peers.reset()
+	}
 	for i, windowFunc := range e.windowFuncs {
 		windowFunc.ResetPartialResult(e.partialResults[i])
 	}
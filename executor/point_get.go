@@ -189,6 +189,7 @@ func (e *PointGetExecutor) Open(context.Context) error {
 		return err
 	}
 	setOptionForTopSQL(e.Ctx().GetSessionVars().StmtCtx, e.snapshot)
+	setOptionForRunaway(e.Ctx().GetSessionVars().StmtCtx, e.snapshot)
 	return nil
 }
 
@@ -0,0 +1,1404 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync/atomic"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/parser/terror"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/sqlexec"
+	"github.com/pingcap/tidb/util/stringutil"
+)
+
+const routineTypeProcedure = "PROCEDURE"
+
+// securityTypeInvoker is the only supported SQL SECURITY of the stored procedures, the statements of
+// a procedure are executed with the privileges of the user who calls it. The procedures are created as
+// SQL SECURITY INVOKER if the characteristic is omitted, and SQL SECURITY DEFINER is rejected. The stored
+// functions are not supported.
+const securityTypeInvoker = "INVOKER"
+
+// sqlDataAccessContainsSQL is the default data access characteristic of the stored procedures.
+const sqlDataAccessContainsSQL = "CONTAINS SQL"
+
+// storedRoutine is a routine definition stored in mysql.routines.
+type storedRoutine struct {
+	schema              string
+	name                string
+	paramStr            string
+	definition          string
+	definer             string
+	securityType        string
+	isDeterministic     string
+	sqlDataAccess       string
+	sqlMode             string
+	charsetClient       string
+	collationConnection string
+	dbCollation         string
+	comment             string
+	created             types.Time
+	lastAltered         types.Time
+}
+
+// fullName returns the qualified name used in error messages.
+func (r *storedRoutine) fullName() string {
+	return r.schema + "." + r.name
+}
+
+// definerString returns the definer in the format of `user`@`host`.
+func (r *storedRoutine) definerString(sqlMode mysql.SQLMode) string {
+	user, host := r.definer, ""
+	if idx := strings.LastIndexByte(r.definer, '@'); idx >= 0 {
+		user, host = r.definer[:idx], r.definer[idx+1:]
+	}
+	return stringutil.Escape(user, sqlMode) + "@" + stringutil.Escape(host, sqlMode)
+}
+
+// createStmt returns the statement to create the routine, the characteristics that are not default are
+// written in the same order as MySQL.
+func (r *storedRoutine) createStmt(sqlMode mysql.SQLMode) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "CREATE DEFINER=%s PROCEDURE %s(%s)\n", r.definerString(sqlMode), stringutil.Escape(r.name, sqlMode), r.paramStr)
+	if r.sqlDataAccess != sqlDataAccessContainsSQL {
+		fmt.Fprintf(&sb, "    %s\n", r.sqlDataAccess)
+	}
+	if r.isDeterministic == "YES" {
+		sb.WriteString("    DETERMINISTIC\n")
+	}
+	fmt.Fprintf(&sb, "    SQL SECURITY %s\n", r.securityType)
+	if r.comment != "" {
+		flags := format.DefaultRestoreFlags
+		if !sqlMode.HasNoBackslashEscapesMode() {
+			flags |= format.RestoreStringEscapeBackslash
+		}
+		sb.WriteString("    ")
+		comment := &ast.ProcedureCharacteristic{Tp: ast.ProcedureComment, Comment: r.comment}
+		comment.Restore(format.NewRestoreCtx(flags, &sb))
+		sb.WriteString("\n")
+	}
+	sb.WriteString(r.definition)
+	return sb.String()
+}
+
+// loadProcedures reads the stored procedures from mysql.routines. All the procedures are returned
+// if schema is empty, otherwise only the procedure with the given name is returned.
+func loadProcedures(ctx context.Context, sctx sessionctx.Context, schema, name model.CIStr) ([]*storedRoutine, error) {
+	sql := new(strings.Builder)
+	sqlexec.MustFormatSQL(sql, `SELECT routine_schema, routine_name, parameter_str, definition, definer, security_type, is_deterministic,
+		sql_data_access, sql_mode, character_set_client, collation_connection, database_collation, comment, created, last_altered
+		FROM mysql.routines WHERE routine_type = %?`, routineTypeProcedure)
+	if schema.L != "" {
+		sqlexec.MustFormatSQL(sql, " AND LOWER(routine_schema) = %? AND LOWER(routine_name) = %?", schema.L, name.L)
+	}
+	sql.WriteString(" ORDER BY routine_schema, routine_name")
+	exec := sctx.(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, sql.String())
+	if err != nil {
+		return nil, err
+	}
+	routines := make([]*storedRoutine, 0, len(rows))
+	for _, row := range rows {
+		routines = append(routines, &storedRoutine{
+			schema:              row.GetString(0),
+			name:                row.GetString(1),
+			paramStr:            row.GetString(2),
+			definition:          row.GetString(3),
+			definer:             row.GetString(4),
+			securityType:        row.GetEnum(5).String(),
+			isDeterministic:     row.GetEnum(6).String(),
+			sqlDataAccess:       row.GetEnum(7).String(),
+			sqlMode:             row.GetString(8),
+			charsetClient:       row.GetString(9),
+			collationConnection: row.GetString(10),
+			dbCollation:         row.GetString(11),
+			comment:             row.GetString(12),
+			created:             row.GetTime(13),
+			lastAltered:         row.GetTime(14),
+		})
+	}
+	return routines, nil
+}
+
+// loadProcedure reads a stored procedure from mysql.routines, it returns nil if the procedure doesn't exist.
+func loadProcedure(ctx context.Context, sctx sessionctx.Context, schema, name model.CIStr) (*storedRoutine, error) {
+	routines, err := loadProcedures(ctx, sctx, schema, name)
+	if err != nil || len(routines) == 0 {
+		return nil, err
+	}
+	return routines[0], nil
+}
+
+func (e *SimpleExec) executeCreateProcedure(ctx context.Context, s *ast.ProcedureInfo) error {
+	dbInfo, ok := e.is.SchemaByName(s.ProcedureName.Schema)
+	if !ok {
+		return infoschema.ErrDatabaseNotExists.GenWithStackByArgs(s.ProcedureName.Schema.O)
+	}
+	if err := checkProcedure(s); err != nil {
+		return err
+	}
+	characteristics, err := procedureCharacteristics(s.Characteristics)
+	if err != nil {
+		return err
+	}
+	name := s.ProcedureName.Name
+	routine, err := loadProcedure(ctx, e.Ctx(), dbInfo.Name, name)
+	if err != nil {
+		return err
+	}
+	if routine != nil {
+		err = exeerrors.ErrSpAlreadyExists.GenWithStackByArgs(routineTypeProcedure, name.O)
+		if s.IfNotExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+
+	vars := e.Ctx().GetSessionVars()
+	charsetClient, err := vars.GetSessionOrGlobalSystemVar(ctx, variable.CharacterSetClient)
+	if err != nil {
+		return err
+	}
+	sqlMode, err := vars.GetSessionOrGlobalSystemVar(ctx, variable.SQLModeVar)
+	if err != nil {
+		return err
+	}
+	_, collationConnection := vars.GetCharsetInfo()
+	dbCollation := dbInfo.Collate
+	if dbCollation == "" {
+		dbCollation = getDefaultCollate(dbInfo.Charset)
+	}
+	// The definer is resolved to the current user by the planner unless the statement is executed by
+	// an internal session without a user.
+	definer := ""
+	if s.Definer != nil && !s.Definer.CurrentUser {
+		definer = s.Definer.Username + "@" + s.Definer.Hostname
+	}
+	exec := e.Ctx().(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, `INSERT INTO mysql.routines (routine_schema, routine_name, routine_type,
+		parameter_str, definition, definer, security_type, is_deterministic, sql_data_access, sql_mode, character_set_client,
+		collation_connection, database_collation, comment)
+		VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		dbInfo.Name.O, name.O, routineTypeProcedure, s.ProcedureParamStr, s.ProcedureBody.Text(), definer,
+		securityTypeInvoker, characteristics.isDeterministic, characteristics.sqlDataAccess, sqlMode, charsetClient,
+		collationConnection, dbCollation, characteristics.comment)
+	return err
+}
+
+// procedureCharacteristics returns the characteristics of a stored procedure, the later ones override
+// the earlier ones of the same kind like MySQL. LANGUAGE SQL, DETERMINISTIC and the data access are only
+// recorded, they don't change how the procedure is executed.
+func procedureCharacteristics(list []*ast.ProcedureCharacteristic) (*storedRoutine, error) {
+	r := &storedRoutine{isDeterministic: "NO", sqlDataAccess: sqlDataAccessContainsSQL}
+	for _, c := range list {
+		switch c.Tp {
+		case ast.ProcedureComment:
+			r.comment = c.Comment
+		case ast.ProcedureDeterministic:
+			r.isDeterministic = "YES"
+		case ast.ProcedureNotDeterministic:
+			r.isDeterministic = "NO"
+		case ast.ProcedureContainsSQL:
+			r.sqlDataAccess = sqlDataAccessContainsSQL
+		case ast.ProcedureNoSQL:
+			r.sqlDataAccess = "NO SQL"
+		case ast.ProcedureReadsSQLData:
+			r.sqlDataAccess = "READS SQL DATA"
+		case ast.ProcedureModifiesSQLData:
+			r.sqlDataAccess = "MODIFIES SQL DATA"
+		case ast.ProcedureSQLSecurityDefiner:
+			return nil, dbterror.ErrNotSupportedYet.GenWithStackByArgs("SQL SECURITY DEFINER for stored procedures")
+		}
+	}
+	return r, nil
+}
+
+func (e *SimpleExec) executeDropProcedure(ctx context.Context, s *ast.DropProcedureStmt) error {
+	schema, name := s.ProcedureName.Schema, s.ProcedureName.Name
+	routine, err := loadProcedure(ctx, e.Ctx(), schema, name)
+	if err != nil {
+		return err
+	}
+	if routine == nil {
+		err = exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(routineTypeProcedure, schema.O+"."+name.O)
+		if s.IfExists {
+			e.Ctx().GetSessionVars().StmtCtx.AppendNote(err)
+			return nil
+		}
+		return err
+	}
+	exec := e.Ctx().(sqlexec.RestrictedSQLExecutor)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err = exec.ExecRestrictedSQL(ctx, nil, "DELETE FROM mysql.routines WHERE routine_schema = %? AND routine_name = %? AND routine_type = %?",
+		routine.schema, routine.name, routineTypeProcedure)
+	return err
+}
+
+// dropProceduresOfSchema removes the stored procedures of a dropped database.
+func dropProceduresOfSchema(ctx context.Context, sctx sessionctx.Context, schema model.CIStr) error {
+	exec, ok := sctx.(sqlexec.RestrictedSQLExecutor)
+	if !ok {
+		return nil
+	}
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	_, _, err := exec.ExecRestrictedSQL(ctx, nil, "DELETE FROM mysql.routines WHERE LOWER(routine_schema) = %?", schema.L)
+	return err
+}
+
+type procedureCallStackKeyType struct{}
+
+// procedureCallStackKey is the context key of the procedures being called, it's used to detect recursive calls.
+var procedureCallStackKey = procedureCallStackKeyType{}
+
+// executeCallProcedure executes the body of a stored procedure in the current session. The procedures are
+// SQL SECURITY INVOKER, so the privileges of the statements are checked with the current user.
+func (e *SimpleExec) executeCallProcedure(ctx context.Context, s *ast.CallStmt) error {
+	schema, name := s.Procedure.Schema, s.Procedure.FnName
+	routine, err := loadProcedure(ctx, e.Ctx(), schema, name)
+	if err != nil {
+		return err
+	}
+	if routine == nil {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(routineTypeProcedure, schema.O+"."+name.O)
+	}
+	fullName := routine.fullName()
+	callStack, _ := ctx.Value(procedureCallStackKey).([]string)
+	for _, called := range callStack {
+		if strings.EqualFold(called, fullName) {
+			return exeerrors.ErrSpRecursionLimit.GenWithStackByArgs(0, routine.name)
+		}
+	}
+	ctx = context.WithValue(ctx, procedureCallStackKey, append(callStack[:len(callStack):len(callStack)], fullName))
+
+	vars := e.Ctx().GetSessionVars()
+	sqlMode, err := mysql.GetSQLMode(routine.sqlMode)
+	if err != nil {
+		return err
+	}
+	p := parser.New()
+	p.SetSQLMode(sqlMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	charset, collation := vars.GetCharsetInfo()
+	createSQL := fmt.Sprintf("CREATE PROCEDURE %s(%s) %s", stringutil.Escape(routine.name, sqlMode), routine.paramStr, routine.definition)
+	stmt, err := p.ParseOneStmt(createSQL, charset, collation)
+	if err != nil {
+		return err
+	}
+	info := stmt.(*ast.ProcedureInfo)
+	if len(info.ProcedureParam) != len(s.Procedure.Args) {
+		return exeerrors.ErrSpWrongNoOfArgs.GenWithStackByArgs(routineTypeProcedure, fullName, len(info.ProcedureParam), len(s.Procedure.Args))
+	}
+
+	exec := &procedureExec{
+		sctx:   e.Ctx(),
+		parser: p,
+		sqls:   make(map[ast.Node]string),
+	}
+	scope := newProcedureScope(nil)
+	for i, param := range info.ProcedureParam {
+		arg := s.Procedure.Args[i]
+		if param.Paramstatus != ast.MODE_IN {
+			if v, ok := arg.(*ast.VariableExpr); !ok || v.IsSystem {
+				return exeerrors.ErrSpNotVarArg.GenWithStackByArgs(i+1, fullName)
+			}
+		}
+		v := &procedureVar{tp: procedureVarType(vars, param.ParamType)}
+		if param.Paramstatus != ast.MODE_OUT {
+			val, err := expression.EvalAstExpr(e.Ctx(), arg)
+			if err != nil {
+				return err
+			}
+			if err = v.set(vars.StmtCtx, val); err != nil {
+				return err
+			}
+		}
+		scope.vars[strings.ToLower(param.ParamName)] = v
+	}
+
+	// The procedure runs in its own database and with the SQL mode when it was created.
+	originDB, originSQLMode, originStrictSQLMode := vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode
+	defer func() {
+		vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode = originDB, originSQLMode, originStrictSQLMode
+	}()
+	vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode = routine.schema, sqlMode, sqlMode.HasStrictMode()
+	err = exec.execStmt(ctx, scope, info.ProcedureBody)
+	switch x := err.(type) {
+	case *procedureExit:
+		err = nil
+	case *procedureJump:
+		// It's checked when the procedure is created, so it should never happen.
+		return errors.Errorf("unexpected %s of label %s", x.kind(), x.label)
+	}
+	if err != nil {
+		return err
+	}
+
+	for i, param := range info.ProcedureParam {
+		if param.Paramstatus == ast.MODE_IN {
+			continue
+		}
+		v := scope.vars[strings.ToLower(param.ParamName)]
+		name := strings.ToLower(s.Procedure.Args[i].(*ast.VariableExpr).Name)
+		if v.val.IsNull() {
+			vars.UnsetUserVar(name)
+			continue
+		}
+		vars.SetUserVarVal(name, v.val)
+		vars.SetUserVarType(name, v.tp)
+	}
+	return nil
+}
+
+// procedureVarType returns the type of a procedure variable, the unspecified attributes are filled with defaults.
+func procedureVarType(vars *variable.SessionVars, tp *types.FieldType) *types.FieldType {
+	tp = tp.Clone()
+	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(tp.GetType())
+	if tp.GetFlen() == types.UnspecifiedLength {
+		tp.SetFlen(defaultFlen)
+	}
+	if tp.GetDecimal() == types.UnspecifiedLength {
+		tp.SetDecimal(defaultDecimal)
+	}
+	if tp.EvalType() == types.ETString && tp.GetCharset() == "" {
+		charset, collation := vars.GetCharsetInfo()
+		tp.SetCharset(charset)
+		tp.SetCollate(collation)
+	}
+	return tp
+}
+
+// procedureVar is a parameter or a local variable of a stored procedure.
+type procedureVar struct {
+	tp  *types.FieldType
+	val types.Datum
+}
+
+func (v *procedureVar) set(sc *stmtctx.StatementContext, val types.Datum) error {
+	converted, err := val.ConvertTo(sc, v.tp)
+	if err = sc.HandleTruncate(err); err != nil {
+		return err
+	}
+	v.val = converted
+	return nil
+}
+
+// procedureCursor is a cursor declared in a stored procedure, the result rows are read when it's opened.
+type procedureCursor struct {
+	stmt ast.StmtNode
+	rows [][]types.Datum
+	pos  int
+	open bool
+}
+
+// procedureScope holds the variables, cursors and handlers declared in a block.
+type procedureScope struct {
+	parent   *procedureScope
+	vars     map[string]*procedureVar
+	cursors  map[string]*procedureCursor
+	handlers []*ast.ProcedureErrorControl
+	// handling is set while a handler of the scope is running, the conditions raised
+	// by the handler are handled by the outer scopes.
+	handling bool
+}
+
+func newProcedureScope(parent *procedureScope) *procedureScope {
+	return &procedureScope{
+		parent:  parent,
+		vars:    make(map[string]*procedureVar),
+		cursors: make(map[string]*procedureCursor),
+	}
+}
+
+func (s *procedureScope) lookupVar(name string) *procedureVar {
+	for scope := s; scope != nil; scope = scope.parent {
+		if v, ok := scope.vars[name]; ok {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *procedureScope) lookupCursor(name string) (*procedureCursor, error) {
+	for scope := s; scope != nil; scope = scope.parent {
+		if c, ok := scope.cursors[strings.ToLower(name)]; ok {
+			return c, nil
+		}
+	}
+	return nil, exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+// findHandler finds the handler of a condition. The innermost scope that can handle the condition
+// is chosen, and the handler of an error code takes precedence over SQLSTATE and condition class.
+func (s *procedureScope) findHandler(code uint16, state string, isWarning bool) (*ast.ProcedureErrorControl, *procedureScope) {
+	for scope := s; scope != nil; scope = scope.parent {
+		if scope.handling {
+			continue
+		}
+		var handler *ast.ProcedureErrorControl
+		bestRank := 0
+		for _, h := range scope.handlers {
+			for _, cond := range h.ErrorCon {
+				if rank := matchProcedureCondition(cond, code, state, isWarning); rank > bestRank {
+					handler, bestRank = h, rank
+				}
+			}
+		}
+		if handler != nil {
+			return handler, scope
+		}
+	}
+	return nil, nil
+}
+
+func matchProcedureCondition(cond ast.ErrNode, code uint16, state string, isWarning bool) int {
+	switch x := cond.(type) {
+	case *ast.ProcedureErrorVal:
+		if x.ErrorNum == uint64(code) {
+			return 3
+		}
+	case *ast.ProcedureErrorState:
+		if x.CodeStatus == state {
+			return 2
+		}
+	case *ast.ProcedureErrorCon:
+		class := state[:2]
+		switch x.ErrorCon {
+		case ast.PROCEDUR_SQLWARNING:
+			if class == "01" || (isWarning && class != "02") {
+				return 1
+			}
+		case ast.PROCEDUR_NOT_FOUND:
+			if class == "02" {
+				return 1
+			}
+		case ast.PROCEDUR_SQLEXCEPTION:
+			if !isWarning && class != "00" && class != "01" && class != "02" {
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// procedureCondition returns the error code and SQLSTATE of a condition.
+func procedureCondition(err error) (uint16, string) {
+	if te, ok := errors.Cause(err).(*terror.Error); ok {
+		sqlErr := terror.ToSQLError(te)
+		return sqlErr.Code, sqlErr.State
+	}
+	return mysql.ErrUnknown, mysql.DefaultMySQLState
+}
+
+// procedureJump is returned by LEAVE and ITERATE, to unwind to the statement with the label.
+type procedureJump struct {
+	label string
+	leave bool
+}
+
+func (j *procedureJump) kind() string {
+	if j.leave {
+		return "LEAVE"
+	}
+	return "ITERATE"
+}
+
+func (j *procedureJump) Error() string {
+	return fmt.Sprintf("%s %s", j.kind(), j.label)
+}
+
+// procedureExit is returned after an EXIT handler runs, to leave the block declaring the handler.
+type procedureExit struct {
+	scope *procedureScope
+}
+
+func (*procedureExit) Error() string {
+	return "exit handler"
+}
+
+// procedureExec interprets the body of a stored procedure. The SQL statements in the body are
+// executed in the current session, with the references of local variables replaced by their values.
+type procedureExec struct {
+	sctx   sessionctx.Context
+	parser *parser.Parser
+	// sqls caches the restored SQL of statements and expressions in the procedure body.
+	sqls map[ast.Node]string
+	// row is the row referenced by NEW and OLD when the body of a trigger is executed.
+	row *triggerRow
+	// run executes the statements of the body, they are executed in the current session if it's nil.
+	run func(ctx context.Context, stmt ast.StmtNode, keepRows bool) ([][]types.Datum, int, error)
+}
+
+func (p *procedureExec) execStmts(ctx context.Context, scope *procedureScope, stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := p.execStmt(ctx, scope, stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execStmt executes a statement of the procedure body. The conditions raised by simple statements
+// and the conditions of control flow statements are passed to the handlers here, so the errors
+// returned by the nested statements are not handled again.
+func (p *procedureExec) execStmt(ctx context.Context, scope *procedureScope, stmt ast.StmtNode) error {
+	if atomic.LoadUint32(&p.sctx.GetSessionVars().Killed) == 1 {
+		return exeerrors.ErrQueryInterrupted
+	}
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return p.execBlock(ctx, scope, x)
+	case *ast.ProcedureLabelBlock:
+		err := p.execBlock(ctx, scope, x.Block)
+		if jump, ok := err.(*procedureJump); ok && jump.leave && jump.label == strings.ToLower(x.LabelName) {
+			return nil
+		}
+		return err
+	case *ast.ProcedureLabelLoop:
+		return p.execLoop(ctx, scope, x.Block, strings.ToLower(x.LabelName))
+	case *ast.ProcedureWhileStmt, *ast.ProcedureRepeatStmt, *ast.ProcedureLoopStmt:
+		return p.execLoop(ctx, scope, x, "")
+	case *ast.ProcedureIfInfo:
+		return p.execIf(ctx, scope, x.IfBody)
+	case *ast.SimpleCaseStmt:
+		return p.execSimpleCase(ctx, scope, x)
+	case *ast.SearchCaseStmt:
+		return p.execSearchCase(ctx, scope, x)
+	case *ast.ProcedureJump:
+		return &procedureJump{label: strings.ToLower(x.Name), leave: x.IsLeave}
+	}
+	sc := p.sctx.GetSessionVars().StmtCtx
+	warnCnt := sc.WarningCount()
+	if err := p.execSimpleStmt(ctx, scope, stmt); err != nil {
+		return p.handleError(ctx, scope, err)
+	}
+	return p.handleWarnings(ctx, scope, sc, int(warnCnt))
+}
+
+func (p *procedureExec) execBlock(ctx context.Context, parent *procedureScope, block *ast.ProcedureBlock) error {
+	scope := newProcedureScope(parent)
+	err := p.declare(ctx, scope, block.ProcedureVars)
+	if err == nil {
+		err = p.execStmts(ctx, scope, block.ProcedureProcStmts)
+	}
+	if exit, ok := err.(*procedureExit); ok && exit.scope == scope {
+		return nil
+	}
+	return err
+}
+
+func (p *procedureExec) declare(ctx context.Context, scope *procedureScope, decls []ast.DeclNode) error {
+	vars := p.sctx.GetSessionVars()
+	for _, decl := range decls {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			var val types.Datum
+			if x.DeclDefault != nil {
+				var err error
+				if val, err = p.evalExpr(ctx, scope, x.DeclDefault); err != nil {
+					return p.handleError(ctx, scope, err)
+				}
+			}
+			for _, name := range x.DeclNames {
+				v := &procedureVar{tp: procedureVarType(vars, x.DeclType)}
+				if err := v.set(vars.StmtCtx, val); err != nil {
+					return p.handleError(ctx, scope, err)
+				}
+				scope.vars[strings.ToLower(name)] = v
+			}
+		case *ast.ProcedureCursor:
+			scope.cursors[strings.ToLower(x.CurName)] = &procedureCursor{stmt: x.Selectstring}
+		case *ast.ProcedureErrorControl:
+			scope.handlers = append(scope.handlers, x)
+		}
+	}
+	return nil
+}
+
+func (p *procedureExec) execLoop(ctx context.Context, scope *procedureScope, loop ast.StmtNode, label string) error {
+	for {
+		if atomic.LoadUint32(&p.sctx.GetSessionVars().Killed) == 1 {
+			return exeerrors.ErrQueryInterrupted
+		}
+		var body []ast.StmtNode
+		switch x := loop.(type) {
+		case *ast.ProcedureWhileStmt:
+			ok, err := p.evalCond(ctx, scope, x.Condition)
+			if err != nil {
+				return p.handleError(ctx, scope, err)
+			}
+			if !ok {
+				return nil
+			}
+			body = x.Body
+		case *ast.ProcedureRepeatStmt:
+			body = x.Body
+		case *ast.ProcedureLoopStmt:
+			body = x.Body
+		}
+		err := p.execStmts(ctx, scope, body)
+		if jump, ok := err.(*procedureJump); ok && label != "" && jump.label == label {
+			if jump.leave {
+				return nil
+			}
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+		if repeat, ok := loop.(*ast.ProcedureRepeatStmt); ok {
+			done, err := p.evalCond(ctx, scope, repeat.Condition)
+			if err != nil {
+				return p.handleError(ctx, scope, err)
+			}
+			if done {
+				return nil
+			}
+		}
+	}
+}
+
+func (p *procedureExec) execIf(ctx context.Context, scope *procedureScope, block *ast.ProcedureIfBlock) error {
+	ok, err := p.evalCond(ctx, scope, block.IfExpr)
+	if err != nil {
+		return p.handleError(ctx, scope, err)
+	}
+	if ok {
+		return p.execStmts(ctx, scope, block.ProcedureIfStmts)
+	}
+	switch x := block.ProcedureElseStmt.(type) {
+	case *ast.ProcedureElseIfBlock:
+		return p.execIf(ctx, scope, x.ProcedureIfStmt)
+	case *ast.ProcedureElseBlock:
+		return p.execStmts(ctx, scope, x.ProcedureIfStmts)
+	}
+	return nil
+}
+
+func (p *procedureExec) execSimpleCase(ctx context.Context, scope *procedureScope, stmt *ast.SimpleCaseStmt) error {
+	val, err := p.evalExpr(ctx, scope, stmt.Condition)
+	if err != nil {
+		return p.handleError(ctx, scope, err)
+	}
+	charset, collation := p.sctx.GetSessionVars().GetCharsetInfo()
+	for _, when := range stmt.WhenCases {
+		whenVal, err := p.evalExpr(ctx, scope, when.Expr)
+		if err != nil {
+			return p.handleError(ctx, scope, err)
+		}
+		eq, err := expression.EvalAstExpr(p.sctx, &ast.BinaryOperationExpr{
+			Op: opcode.EQ,
+			L:  ast.NewValueExpr(val.GetValue(), charset, collation),
+			R:  ast.NewValueExpr(whenVal.GetValue(), charset, collation),
+		})
+		if err != nil {
+			return p.handleError(ctx, scope, err)
+		}
+		if ok, err := p.isTrue(eq); err != nil {
+			return p.handleError(ctx, scope, err)
+		} else if ok {
+			return p.execStmts(ctx, scope, when.ProcedureStmts)
+		}
+	}
+	if stmt.ElseCases == nil {
+		return p.handleError(ctx, scope, exeerrors.ErrSpCaseNotFound.GenWithStackByArgs())
+	}
+	return p.execStmts(ctx, scope, stmt.ElseCases)
+}
+
+func (p *procedureExec) execSearchCase(ctx context.Context, scope *procedureScope, stmt *ast.SearchCaseStmt) error {
+	for _, when := range stmt.WhenCases {
+		ok, err := p.evalCond(ctx, scope, when.Expr)
+		if err != nil {
+			return p.handleError(ctx, scope, err)
+		}
+		if ok {
+			return p.execStmts(ctx, scope, when.ProcedureStmts)
+		}
+	}
+	if stmt.ElseCases == nil {
+		return p.handleError(ctx, scope, exeerrors.ErrSpCaseNotFound.GenWithStackByArgs())
+	}
+	return p.execStmts(ctx, scope, stmt.ElseCases)
+}
+
+// execSimpleStmt executes a statement which is not a control flow statement.
+func (p *procedureExec) execSimpleStmt(ctx context.Context, scope *procedureScope, stmt ast.StmtNode) error {
+	switch x := stmt.(type) {
+	case *ast.ProcedureOpenCur:
+		cursor, err := scope.lookupCursor(x.CurName)
+		if err != nil {
+			return err
+		}
+		if cursor.open {
+			return exeerrors.ErrSpCursorAlreadyOpen.GenWithStackByArgs()
+		}
+		sel, err := p.prepareStmt(scope, cursor.stmt)
+		if err != nil {
+			return err
+		}
+		rows, _, err := p.runStmt(ctx, sel, true)
+		if err != nil {
+			return err
+		}
+		cursor.rows, cursor.pos, cursor.open = rows, 0, true
+		return nil
+	case *ast.ProcedureFetchInto:
+		cursor, err := scope.lookupCursor(x.CurName)
+		if err != nil {
+			return err
+		}
+		if !cursor.open {
+			return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		if cursor.pos >= len(cursor.rows) {
+			return exeerrors.ErrSpFetchNoData.GenWithStackByArgs()
+		}
+		row := cursor.rows[cursor.pos]
+		if len(row) != len(x.Variables) {
+			return exeerrors.ErrSpWrongNoOfFetchArgs.GenWithStackByArgs()
+		}
+		cursor.pos++
+		for i, name := range x.Variables {
+			v := scope.lookupVar(strings.ToLower(name))
+			if v == nil {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+			}
+			if err := v.set(p.sctx.GetSessionVars().StmtCtx, row[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	case *ast.ProcedureCloseCur:
+		cursor, err := scope.lookupCursor(x.CurName)
+		if err != nil {
+			return err
+		}
+		if !cursor.open {
+			return exeerrors.ErrSpCursorNotOpen.GenWithStackByArgs()
+		}
+		cursor.rows, cursor.pos, cursor.open = nil, 0, false
+		return nil
+	case *ast.SetStmt:
+		for _, assign := range x.Variables {
+			if isLocalVarAssignment(scope, assign) || p.row.assignedColumn(assign) != nil {
+				return p.execSet(ctx, scope, x)
+			}
+		}
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil && x.SelectIntoOpt.Tp == ast.SelectIntoVars {
+			return p.execSelectInto(ctx, scope, x)
+		}
+	}
+	node, err := p.prepareStmt(scope, stmt)
+	if err != nil {
+		return err
+	}
+	// The result sets of the statements in the procedure are discarded.
+	_, _, err = p.runStmt(ctx, node, false)
+	return err
+}
+
+func isLocalVarAssignment(scope *procedureScope, assign *ast.VariableAssignment) bool {
+	return assign.IsSystem && !assign.IsGlobal && scope.lookupVar(strings.ToLower(assign.Name)) != nil
+}
+
+// execSet executes a SET statement that assigns local variables or the NEW row of a trigger.
+// The assignments are executed in order.
+func (p *procedureExec) execSet(ctx context.Context, scope *procedureScope, stmt *ast.SetStmt) error {
+	for _, assign := range stmt.Variables {
+		if col := p.row.assignedColumn(assign); col != nil && !isLocalVarAssignment(scope, assign) {
+			val, err := p.evalExpr(ctx, scope, assign.Value)
+			if err != nil {
+				return err
+			}
+			if err = p.row.set(p.sctx, col, val); err != nil {
+				return err
+			}
+			continue
+		}
+		if isLocalVarAssignment(scope, assign) {
+			val, err := p.evalExpr(ctx, scope, assign.Value)
+			if err != nil {
+				return err
+			}
+			v := scope.lookupVar(strings.ToLower(assign.Name))
+			if err = v.set(p.sctx.GetSessionVars().StmtCtx, val); err != nil {
+				return err
+			}
+			continue
+		}
+		var sb strings.Builder
+		set := &ast.SetStmt{Variables: []*ast.VariableAssignment{assign}}
+		if err := set.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+			return err
+		}
+		node, err := p.parseStmt(scope, sb.String())
+		if err != nil {
+			return err
+		}
+		if _, _, err = p.runStmt(ctx, node, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// execSelectInto executes `SELECT ... INTO var_list`, the only result row is stored into the variables.
+func (p *procedureExec) execSelectInto(ctx context.Context, scope *procedureScope, stmt *ast.SelectStmt) error {
+	node, err := p.prepareStmt(scope, stmt)
+	if err != nil {
+		return err
+	}
+	rows, numCols, err := p.runStmt(ctx, node, true)
+	if err != nil {
+		return err
+	}
+	vars := p.sctx.GetSessionVars()
+	if numCols != len(stmt.SelectIntoOpt.Variables) {
+		return plannercore.ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+	}
+	if len(rows) == 0 {
+		// It's a NOT FOUND condition, which is passed to the handlers as a warning.
+		vars.StmtCtx.AppendWarning(exeerrors.ErrSpFetchNoData.GenWithStackByArgs())
+		return nil
+	}
+	if len(rows) > 1 {
+		return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+	}
+	for i, target := range stmt.SelectIntoOpt.Variables {
+		val := rows[0][i]
+		switch x := target.(type) {
+		case *ast.ColumnNameExpr:
+			v := scope.lookupVar(x.Name.Name.L)
+			if v == nil {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(x.Name.Name.O)
+			}
+			if err := v.set(vars.StmtCtx, val); err != nil {
+				return err
+			}
+		case *ast.VariableExpr:
+			name := strings.ToLower(x.Name)
+			if val.IsNull() {
+				vars.UnsetUserVar(name)
+				continue
+			}
+			vars.SetUserVarVal(name, val)
+			vars.SetUserVarType(name, types.NewFieldType(val.Kind()))
+		}
+	}
+	return nil
+}
+
+// handleError passes an error to the handlers. It returns nil if the error is handled by a CONTINUE handler.
+func (p *procedureExec) handleError(ctx context.Context, scope *procedureScope, err error) error {
+	switch err.(type) {
+	case *procedureJump, *procedureExit:
+		return err
+	}
+	code, state := procedureCondition(err)
+	if code == mysql.ErrQueryInterrupted {
+		return err
+	}
+	handler, handlerScope := scope.findHandler(code, state, false)
+	if handler == nil {
+		return err
+	}
+	return p.runHandler(ctx, handler, handlerScope)
+}
+
+// handleWarnings passes the warnings of the last executed statement to the handlers.
+func (p *procedureExec) handleWarnings(ctx context.Context, scope *procedureScope, prev *stmtctx.StatementContext, prevWarnCnt int) error {
+	sc := p.sctx.GetSessionVars().StmtCtx
+	warnings := sc.GetWarnings()
+	if sc == prev {
+		// The statement is executed in the statement context of the caller, e.g. the statements in triggers,
+		// only the new warnings are raised by it.
+		if prevWarnCnt >= len(warnings) {
+			return nil
+		}
+		warnings = warnings[prevWarnCnt:]
+	}
+	for _, warn := range warnings {
+		if warn.Level != stmtctx.WarnLevelWarning {
+			continue
+		}
+		code, state := procedureCondition(warn.Err)
+		if handler, handlerScope := scope.findHandler(code, state, true); handler != nil {
+			return p.runHandler(ctx, handler, handlerScope)
+		}
+	}
+	return nil
+}
+
+func (p *procedureExec) runHandler(ctx context.Context, handler *ast.ProcedureErrorControl, scope *procedureScope) error {
+	scope.handling = true
+	err := p.execStmt(ctx, scope, handler.Operate)
+	scope.handling = false
+	if err != nil {
+		return err
+	}
+	if handler.ControlHandle == ast.PROCEDUR_EXIT {
+		return &procedureExit{scope: scope}
+	}
+	return nil
+}
+
+func (p *procedureExec) evalCond(ctx context.Context, scope *procedureScope, expr ast.ExprNode) (bool, error) {
+	val, err := p.evalExpr(ctx, scope, expr)
+	if err != nil {
+		return false, err
+	}
+	return p.isTrue(val)
+}
+
+func (p *procedureExec) isTrue(val types.Datum) (bool, error) {
+	if val.IsNull() {
+		return false, nil
+	}
+	b, err := val.ToBool(p.sctx.GetSessionVars().StmtCtx)
+	return b != 0, err
+}
+
+// evalExpr evaluates an expression in the procedure body. The expressions with subqueries
+// are evaluated by executing `SELECT expr`, so that the privileges are checked.
+func (p *procedureExec) evalExpr(ctx context.Context, scope *procedureScope, expr ast.ExprNode) (types.Datum, error) {
+	stmt, err := p.prepareStmt(scope, expr)
+	if err != nil {
+		return types.Datum{}, err
+	}
+	sel := stmt.(*ast.SelectStmt)
+	checker := &subqueryChecker{}
+	sel.Fields.Fields[0].Expr.Accept(checker)
+	if !checker.hasSubquery {
+		return expression.EvalAstExpr(p.sctx, sel.Fields.Fields[0].Expr)
+	}
+	rows, _, err := p.runStmt(ctx, sel, true)
+	if err != nil || len(rows) == 0 {
+		return types.Datum{}, err
+	}
+	return rows[0][0], nil
+}
+
+// prepareStmt builds a new statement from a statement or an expression of the procedure body,
+// the references of local variables are replaced by their current values.
+func (p *procedureExec) prepareStmt(scope *procedureScope, node ast.Node) (ast.StmtNode, error) {
+	sql, ok := p.sqls[node]
+	if !ok {
+		var sb strings.Builder
+		restoreCtx := format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)
+		var err error
+		switch x := node.(type) {
+		case ast.ExprNode:
+			sb.WriteString("SELECT ")
+			err = x.Restore(restoreCtx)
+		case *ast.SelectStmt:
+			// The INTO clause is handled by the procedure itself.
+			into := x.SelectIntoOpt
+			if into != nil && into.Tp == ast.SelectIntoVars {
+				x.SelectIntoOpt = nil
+			}
+			err = x.Restore(restoreCtx)
+			x.SelectIntoOpt = into
+		default:
+			err = x.Restore(restoreCtx)
+		}
+		if err != nil {
+			return nil, err
+		}
+		sql = sb.String()
+		p.sqls[node] = sql
+	}
+	return p.parseStmt(scope, sql)
+}
+
+func (p *procedureExec) parseStmt(scope *procedureScope, sql string) (ast.StmtNode, error) {
+	charset, collation := p.sctx.GetSessionVars().GetCharsetInfo()
+	stmt, err := p.parser.ParseOneStmt(sql, charset, collation)
+	if err != nil {
+		return nil, err
+	}
+	stmt.Accept(&procedureVarBinder{scope: scope, row: p.row})
+	return stmt, nil
+}
+
+// runStmt executes a statement in the current session. It returns the result rows if keepRows
+// is set, and the number of the result columns.
+func (p *procedureExec) runStmt(ctx context.Context, stmt ast.StmtNode, keepRows bool) (rows [][]types.Datum, numCols int, err error) {
+	if p.run != nil {
+		return p.run(ctx, stmt, keepRows)
+	}
+	rs, err := p.sctx.(sqlexec.SQLExecutor).ExecuteStmt(ctx, stmt)
+	if err != nil || rs == nil {
+		return nil, 0, err
+	}
+	defer func() {
+		if closeErr := rs.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	fields := rs.Fields()
+	retTypes := make([]*types.FieldType, 0, len(fields))
+	for _, field := range fields {
+		retTypes = append(retTypes, &field.Column.FieldType)
+	}
+	chk := rs.NewChunk(nil)
+	for {
+		if err = rs.Next(ctx, chk); err != nil {
+			return nil, 0, err
+		}
+		if chk.NumRows() == 0 {
+			return rows, len(fields), nil
+		}
+		if !keepRows {
+			continue
+		}
+		for i := 0; i < chk.NumRows(); i++ {
+			rows = append(rows, types.CloneRow(chk.GetRow(i).GetDatumRow(retTypes)))
+		}
+	}
+}
+
+// procedureVarBinder replaces the references of local variables and the NEW and OLD rows of triggers with their values.
+type procedureVarBinder struct {
+	scope *procedureScope
+	row   *triggerRow
+}
+
+func (b *procedureVarBinder) Enter(in ast.Node) (ast.Node, bool) {
+	switch x := in.(type) {
+	case *ast.ValuesExpr:
+		// The argument of VALUES() is always a column.
+		return in, true
+	case *ast.ColumnNameExpr:
+		if val := b.row.lookup(x.Name); val != nil {
+			return val, true
+		}
+		if x.Name.Schema.L != "" || x.Name.Table.L != "" {
+			return in, true
+		}
+		if v := b.scope.lookupVar(x.Name.Name.L); v != nil {
+			val := &driver.ValueExpr{Datum: v.val}
+			val.Type = *v.tp
+			return val, true
+		}
+	}
+	return in, false
+}
+
+func (*procedureVarBinder) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+type subqueryChecker struct {
+	hasSubquery bool
+}
+
+func (c *subqueryChecker) Enter(in ast.Node) (ast.Node, bool) {
+	if _, ok := in.(*ast.SubqueryExpr); ok {
+		c.hasSubquery = true
+		return in, true
+	}
+	return in, c.hasSubquery
+}
+
+func (*subqueryChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, true
+}
+
+// procedureChecker checks the definition of a stored procedure when it's created.
+type procedureChecker struct {
+	labels []procedureLabel
+	scopes []*procedureCheckScope
+	// trigger is set when the body of a trigger is checked.
+	trigger *triggerChecker
+}
+
+type procedureLabel struct {
+	name   string
+	isLoop bool
+}
+
+type procedureCheckScope struct {
+	vars    map[string]struct{}
+	cursors map[string]struct{}
+}
+
+func checkProcedure(s *ast.ProcedureInfo) error {
+	params := make(map[string]struct{}, len(s.ProcedureParam))
+	for _, param := range s.ProcedureParam {
+		name := strings.ToLower(param.ParamName)
+		if _, ok := params[name]; ok {
+			return exeerrors.ErrSpDupParam.GenWithStackByArgs(param.ParamName)
+		}
+		params[name] = struct{}{}
+	}
+	c := &procedureChecker{
+		scopes: []*procedureCheckScope{{vars: params, cursors: map[string]struct{}{}}},
+	}
+	return c.checkStmt(s.ProcedureBody)
+}
+
+func (c *procedureChecker) checkStmts(stmts []ast.StmtNode) error {
+	for _, stmt := range stmts {
+		if err := c.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkStmt(stmt ast.StmtNode) error {
+	if c.trigger != nil {
+		if err := c.trigger.checkStmt(stmt); err != nil {
+			return err
+		}
+	}
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		return c.checkBlock(x)
+	case *ast.ProcedureLabelBlock, *ast.ProcedureLabelLoop:
+		label := x.(ast.LabelInfo)
+		if end, mismatch := label.GetErrorStatus(); mismatch {
+			return exeerrors.ErrSpLabelMismatch.GenWithStackByArgs(end)
+		}
+		name := strings.ToLower(label.GetLabelName())
+		for _, l := range c.labels {
+			if l.name == name {
+				return exeerrors.ErrSpLabelRedefine.GenWithStackByArgs(label.GetLabelName())
+			}
+		}
+		c.labels = append(c.labels, procedureLabel{name: name, isLoop: !label.IsBlock()})
+		err := c.checkStmt(label.GetBlock())
+		c.labels = c.labels[:len(c.labels)-1]
+		return err
+	case *ast.ProcedureWhileStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureRepeatStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureLoopStmt:
+		return c.checkStmts(x.Body)
+	case *ast.ProcedureIfInfo:
+		for block := x.IfBody; block != nil; {
+			if err := c.checkStmts(block.ProcedureIfStmts); err != nil {
+				return err
+			}
+			switch elseStmt := block.ProcedureElseStmt.(type) {
+			case *ast.ProcedureElseIfBlock:
+				block = elseStmt.ProcedureIfStmt
+			case *ast.ProcedureElseBlock:
+				return c.checkStmts(elseStmt.ProcedureIfStmts)
+			default:
+				block = nil
+			}
+		}
+	case *ast.SimpleCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			if err := c.checkStmts(when.ProcedureStmts); err != nil {
+				return err
+			}
+		}
+		return c.checkStmts(x.ElseCases)
+	case *ast.ProcedureJump:
+		return c.checkJump(x)
+	case *ast.ProcedureOpenCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureCloseCur:
+		return c.checkCursor(x.CurName)
+	case *ast.ProcedureFetchInto:
+		if err := c.checkCursor(x.CurName); err != nil {
+			return err
+		}
+		for _, name := range x.Variables {
+			if err := c.checkVar(name); err != nil {
+				return err
+			}
+		}
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt != nil && x.SelectIntoOpt.Tp == ast.SelectIntoVars {
+			for _, v := range x.SelectIntoOpt.Variables {
+				if name, ok := v.(*ast.ColumnNameExpr); ok {
+					if err := c.checkVar(name.Name.Name.O); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (c *procedureChecker) checkBlock(block *ast.ProcedureBlock) error {
+	scope := &procedureCheckScope{vars: map[string]struct{}{}, cursors: map[string]struct{}{}}
+	c.scopes = append(c.scopes, scope)
+	defer func() {
+		c.scopes = c.scopes[:len(c.scopes)-1]
+	}()
+	var hasCursor, hasHandler bool
+	for _, decl := range block.ProcedureVars {
+		switch x := decl.(type) {
+		case *ast.ProcedureDecl:
+			if hasCursor || hasHandler {
+				return exeerrors.ErrSpVarcondAfterCurshndlr.GenWithStackByArgs()
+			}
+			for _, name := range x.DeclNames {
+				if _, ok := scope.vars[strings.ToLower(name)]; ok {
+					return exeerrors.ErrSpDupVar.GenWithStackByArgs(name)
+				}
+				scope.vars[strings.ToLower(name)] = struct{}{}
+			}
+		case *ast.ProcedureCursor:
+			if hasHandler {
+				return exeerrors.ErrSpCursorAfterHandler.GenWithStackByArgs()
+			}
+			hasCursor = true
+			if _, ok := scope.cursors[strings.ToLower(x.CurName)]; ok {
+				return exeerrors.ErrSpDupCurs.GenWithStackByArgs(x.CurName)
+			}
+			scope.cursors[strings.ToLower(x.CurName)] = struct{}{}
+		case *ast.ProcedureErrorControl:
+			hasHandler = true
+			for _, cond := range x.ErrorCon {
+				if state, ok := cond.(*ast.ProcedureErrorState); ok {
+					if len(state.CodeStatus) != 5 || strings.HasPrefix(state.CodeStatus, "00") {
+						return exeerrors.ErrSpBadSQLstate.GenWithStackByArgs(state.CodeStatus)
+					}
+				}
+			}
+			if err := c.checkStmt(x.Operate); err != nil {
+				return err
+			}
+		}
+	}
+	return c.checkStmts(block.ProcedureProcStmts)
+}
+
+func (c *procedureChecker) checkJump(jump *ast.ProcedureJump) error {
+	name := strings.ToLower(jump.Name)
+	for i := len(c.labels) - 1; i >= 0; i-- {
+		if c.labels[i].name == name && (jump.IsLeave || c.labels[i].isLoop) {
+			return nil
+		}
+	}
+	kind := "ITERATE"
+	if jump.IsLeave {
+		kind = "LEAVE"
+	}
+	return exeerrors.ErrSpLilabelMismatch.GenWithStackByArgs(kind, jump.Name)
+}
+
+func (c *procedureChecker) checkCursor(name string) error {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].cursors[strings.ToLower(name)]; ok {
+			return nil
+		}
+	}
+	return exeerrors.ErrSpCursorMismatch.GenWithStackByArgs(name)
+}
+
+func (c *procedureChecker) checkVar(name string) error {
+	for i := len(c.scopes) - 1; i >= 0; i-- {
+		if _, ok := c.scopes[i].vars[strings.ToLower(name)]; ok {
+			return nil
+		}
+	}
+	return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name)
+}
+
+// fetchShowProcedureStatus fills the result of `SHOW PROCEDURE STATUS`.
+func (e *ShowExec) fetchShowProcedureStatus(ctx context.Context) error {
+	routines, err := loadVisibleProcedures(ctx, e.Ctx())
+	if err != nil {
+		return err
+	}
+	for _, r := range routines {
+		e.appendRow([]interface{}{
+			r.schema, r.name, routineTypeProcedure, r.definer, r.lastAltered, r.created, r.securityType,
+			r.comment, r.charsetClient, r.collationConnection, r.dbCollation,
+		})
+	}
+	return nil
+}
+
+// fetchShowCreateProcedure fills the result of `SHOW CREATE PROCEDURE`.
+func (e *ShowExec) fetchShowCreateProcedure(ctx context.Context) error {
+	schema, name := e.Procedure.Schema, e.Procedure.Name
+	routine, err := loadProcedure(ctx, e.Ctx(), schema, name)
+	if err != nil {
+		return err
+	}
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	if routine == nil || (checker != nil && !checker.RequestVerification(e.Ctx().GetSessionVars().ActiveRoles, strings.ToLower(routine.schema), "", "", mysql.AllPrivMask)) {
+		return exeerrors.ErrSpDoesNotExist.GenWithStackByArgs(routineTypeProcedure, schema.O+"."+name.O)
+	}
+	sqlMode, err := mysql.GetSQLMode(routine.sqlMode)
+	if err != nil {
+		return err
+	}
+	e.appendRow([]interface{}{
+		routine.name, routine.sqlMode, routine.createStmt(sqlMode),
+		routine.charsetClient, routine.collationConnection, routine.dbCollation,
+	})
+	return nil
+}
+
+// loadVisibleProcedures returns the stored procedures in the databases visible to the current user.
+func loadVisibleProcedures(ctx context.Context, sctx sessionctx.Context) ([]*storedRoutine, error) {
+	routines, err := loadProcedures(ctx, sctx, model.CIStr{}, model.CIStr{})
+	if err != nil {
+		return nil, err
+	}
+	checker := privilege.GetPrivilegeManager(sctx)
+	if checker == nil {
+		return routines, nil
+	}
+	visible := routines[:0]
+	for _, r := range routines {
+		if checker.RequestVerification(sctx.GetSessionVars().ActiveRoles, strings.ToLower(r.schema), "", "", mysql.AllPrivMask) {
+			visible = append(visible, r)
+		}
+	}
+	return visible, nil
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/table/tables"
 	"github.com/pingcap/tidb/tablecodec"
@@ -150,6 +151,22 @@ func (e *ReplaceExec) exec(ctx context.Context, newRows [][]types.Datum) error {
 	 */
 
 	defer trace.StartRegion(ctx, "ReplaceExec").End()
+	// The BEFORE triggers are fired for a row right before it's inserted, so the rows are replaced one by one.
+	if e.triggers.hasTriggers(model.TriggerTimingBefore, model.TriggerEventInsert) {
+		for _, row := range newRows {
+			if err := e.triggers.fireBefore(ctx, model.TriggerEventInsert, nil, row); err != nil {
+				return err
+			}
+			if err := e.replaceRows(ctx, [][]types.Datum{row}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return e.replaceRows(ctx, newRows)
+}
+
+func (e *ReplaceExec) replaceRows(ctx context.Context, newRows [][]types.Datum) error {
 	// Get keys need to be checked.
 	toBeCheckedRows, err := getKeysNeedCheck(e.Ctx(), e.Table, newRows)
 	if err != nil {
@@ -169,6 +186,7 @@ func (e *ReplaceExec) exec(ctx context.Context, newRows [][]types.Datum) error {
 		}
 	}
 	setOptionForTopSQL(e.Ctx().GetSessionVars().StmtCtx, txn)
+	setOptionForRunaway(e.Ctx().GetSessionVars().StmtCtx, txn)
 	prefetchStart := time.Now()
 	// Use BatchGet to fill cache.
 	// It's an optimization and could be removed without affecting correctness.
@@ -229,3 +247,11 @@ func (e *ReplaceExec) GetFKCascades() []*FKCascadeExec {
 func (e *ReplaceExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetTriggers implements WithForeignKeyTrigger interface.
+func (e *ReplaceExec) GetTriggers() []*TriggerExec {
+	if e.triggers == nil {
+		return nil
+	}
+	return []*TriggerExec{e.triggers}
+}
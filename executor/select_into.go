@@ -18,51 +18,105 @@ import (
 	"bufio"
 	"bytes"
 	"context"
+	"fmt"
 	"math"
 	"os"
+	"path"
+	"path/filepath"
 	"strconv"
+	"strings"
 
+	"github.com/docker/go-units"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/executor/importer"
 	"github.com/pingcap/tidb/executor/internal/exec"
 	"github.com/pingcap/tidb/parser/ast"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/types"
 	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/intest"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+const (
+	selectIntoCompressionOption = "compression"
+	selectIntoMaxFileSizeOption = "max_file_size"
+
+	// the chunk size of the compressed data written to the outfile.
+	selectIntoCompressChunkSize = 5 * 1024 * 1024
+	// the max size of the parquet row group, parquet-go buffers a whole row group in memory.
+	selectIntoParquetRowGroupSize = 128 * 1024 * 1024
 )
 
 // SelectIntoExec represents a SelectInto executor.
 type SelectIntoExec struct {
 	exec.BaseExecutor
 	intoOpt *ast.SelectIntoOption
+	options []*core.LoadDataOpt
+	// fieldNames are the names of the output columns, which are used as the parquet column names.
+	fieldNames types.NameSlice
 	core.LineFieldsInfo
 
+	format      string
+	compression storage.CompressType
+	// maxFileSize is the max size of each outfile, 0 means no splitting.
+	maxFileSize int64
+
 	lineBuf   []byte
 	realBuf   []byte
 	fieldBuf  []byte
 	escapeBuf []byte
 	enclosed  bool
-	writer    *bufio.Writer
-	dstFile   *os.File
 	chk       *chunk.Chunk
 	started   bool
+
+	// store is nil when the outfile is written to the local filesystem of TiDB.
+	store storage.ExternalStorage
+	// dir and fileName are the location of the outfile in the store.
+	dir      string
+	fileName string
+	fileIdx  int
+	fileSize int64
+	writer   storage.ExternalFileWriter
+	// parquetWriter is not nil only when writing parquet outfile.
+	parquetWriter *writer.CSVWriter
+	parquetSchema []string
 }
 
 // Open implements the Executor Open interface.
 func (s *SelectIntoExec) Open(ctx context.Context) error {
-	// only 'select ... into outfile' is supported now
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		for _, v := range s.intoOpt.Variables {
+			// Local variables only exist in stored programs.
+			if name, ok := v.(*ast.ColumnNameExpr); ok {
+				return exeerrors.ErrSpUndeclaredVar.GenWithStackByArgs(name.Name.Name.O)
+			}
+		}
+		s.chk = exec.TryNewCacheChunk(s.Children(0))
+		return s.BaseExecutor.Open(ctx)
+	}
+	// only 'select ... into outfile' and 'select ... into var_list' are supported now
 	if s.intoOpt.Tp != ast.SelectIntoOutfile {
 		return errors.New("unsupported SelectInto type")
 	}
 
-	// MySQL-compatible behavior: allow files to be group-readable
-	f, err := os.OpenFile(s.intoOpt.FileName, os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
-	if err != nil {
-		return errors.Trace(err)
+	if err := s.initOptions(); err != nil {
+		return err
+	}
+	if err := s.initStore(ctx); err != nil {
+		return err
+	}
+	if s.format == importer.DataFormatParquet {
+		s.initParquetSchema()
 	}
 	s.started = true
-	s.dstFile = f
-	s.writer = bufio.NewWriter(s.dstFile)
+	if err := s.openFile(ctx); err != nil {
+		return err
+	}
 	s.chk = exec.TryNewCacheChunk(s.Children(0))
 	s.lineBuf = make([]byte, 0, 1024)
 	s.fieldBuf = make([]byte, 0, 64)
@@ -70,8 +124,193 @@ func (s *SelectIntoExec) Open(ctx context.Context) error {
 	return s.BaseExecutor.Open(ctx)
 }
 
+func (s *SelectIntoExec) initOptions() error {
+	s.format = importer.DataFormatCSV
+	if s.intoOpt.Format != nil {
+		s.format = strings.ToLower(*s.intoOpt.Format)
+	}
+	switch s.format {
+	case importer.DataFormatCSV:
+	case importer.DataFormatParquet:
+		if s.intoOpt.FieldsInfo != nil || s.intoOpt.LinesInfo != nil {
+			return exeerrors.ErrLoadDataUnsupportedOption.FastGenByArgs("FIELDS or LINES", "parquet format")
+		}
+	default:
+		return exeerrors.ErrLoadDataUnsupportedFormat.GenWithStackByArgs(*s.intoOpt.Format)
+	}
+
+	specifiedOptions := make(map[string]*core.LoadDataOpt, len(s.options))
+	for _, opt := range s.options {
+		if opt.Name != selectIntoCompressionOption && opt.Name != selectIntoMaxFileSizeOption {
+			return exeerrors.ErrUnknownOption.FastGenByArgs(opt.Name)
+		}
+		if _, ok := specifiedOptions[opt.Name]; ok {
+			return exeerrors.ErrDuplicateOption.FastGenByArgs(opt.Name)
+		}
+		specifiedOptions[opt.Name] = opt
+	}
+	optAsString := func(opt *core.LoadDataOpt) (string, error) {
+		if opt.Value == nil || opt.Value.GetType().GetType() != mysql.TypeVarString {
+			return "", exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		val, isNull, err := opt.Value.EvalString(s.Ctx(), chunk.Row{})
+		if err != nil || isNull {
+			return "", exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+		return val, nil
+	}
+	if opt, ok := specifiedOptions[selectIntoCompressionOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(v) {
+		case "gzip":
+			s.compression = storage.Gzip
+		case "zstd":
+			s.compression = storage.Zstd
+		default:
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+	}
+	if opt, ok := specifiedOptions[selectIntoMaxFileSizeOption]; ok {
+		v, err := optAsString(opt)
+		if err != nil {
+			return err
+		}
+		if s.maxFileSize, err = units.RAMInBytes(v); err != nil || s.maxFileSize <= 0 {
+			return exeerrors.ErrInvalidOptionVal.FastGenByArgs(opt.Name)
+		}
+	}
+	return nil
+}
+
+// initStore initializes the external storage when the outfile is an URI, such as 's3://bucket/prefix/result.csv'.
+func (s *SelectIntoExec) initStore(ctx context.Context) error {
+	if !s.intoOpt.IsURI() {
+		// keep the MySQL-compatible behavior for the plain file path.
+		s.dir, s.fileName = filepath.Split(s.intoOpt.FileName)
+		return nil
+	}
+	u, err := storage.ParseRawURL(s.intoOpt.FileName)
+	if err != nil {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(err.Error())
+	}
+	s.dir, s.fileName = path.Split(u.Path)
+	if s.fileName == "" {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs("the file name of the outfile is empty")
+	}
+	u.Path = s.dir
+	b, err := storage.ParseBackendFromURL(u, nil)
+	if err != nil {
+		return exeerrors.ErrLoadDataInvalidURI.GenWithStackByArgs(importer.GetMsgFromBRError(err))
+	}
+	opt := &storage.ExternalStorageOptions{}
+	if intest.InTest {
+		opt.NoCredentials = true
+	}
+	s.store, err = storage.New(ctx, b, opt)
+	if err != nil {
+		return exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(importer.GetMsgFromBRError(err))
+	}
+	return nil
+}
+
+// splitFileName returns the name of the idx-th outfile when the outfile is split by size.
+// The index is inserted before the extensions, so that "result.csv.gz" becomes "result.0.csv.gz".
+func splitFileName(name string, idx int) string {
+	if pos := strings.IndexByte(name, '.'); pos > 0 {
+		return fmt.Sprintf("%s.%d%s", name[:pos], idx, name[pos:])
+	}
+	return fmt.Sprintf("%s.%d", name, idx)
+}
+
+// openFile creates the next outfile, the existing files are never overwritten.
+func (s *SelectIntoExec) openFile(ctx context.Context) error {
+	name := s.fileName
+	if s.maxFileSize > 0 {
+		name = splitFileName(name, s.fileIdx)
+	}
+	var w storage.ExternalFileWriter
+	if s.store == nil {
+		// MySQL-compatible behavior: allow files to be group-readable
+		f, err := os.OpenFile(filepath.Join(s.dir, name), os.O_RDWR|os.O_CREATE|os.O_EXCL, 0640) // #nosec G302
+		if err != nil {
+			return errors.Trace(err)
+		}
+		w = &localFileWriter{file: f, buf: bufio.NewWriter(f)}
+	} else {
+		exists, err := s.store.FileExists(ctx, name)
+		if err != nil {
+			return exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(importer.GetMsgFromBRError(err))
+		}
+		if exists {
+			return exeerrors.ErrFileExists.GenWithStackByArgs(ast.RedactURL(s.store.URI() + "/" + name))
+		}
+		if w, err = s.store.Create(ctx, name, nil); err != nil {
+			return exeerrors.ErrLoadDataCantAccess.GenWithStackByArgs(importer.GetMsgFromBRError(err))
+		}
+	}
+	if s.compression != storage.NoCompression && s.format != importer.DataFormatParquet {
+		w = storage.NewUploaderWriter(w, selectIntoCompressChunkSize, s.compression)
+	}
+	s.writer = w
+	s.fileIdx++
+	s.fileSize = 0
+	if s.format == importer.DataFormatParquet {
+		pw, err := writer.NewCSVWriterFromWriter(s.parquetSchema, &fileWriterAdapter{ctx: ctx, w: w}, 1)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		// the compression of parquet is done by its pages, the file is not compressed again.
+		switch s.compression {
+		case storage.Gzip:
+			pw.CompressionType = parquet.CompressionCodec_GZIP
+		case storage.Zstd:
+			pw.CompressionType = parquet.CompressionCodec_ZSTD
+		}
+		pw.RowGroupSize = selectIntoParquetRowGroupSize
+		if s.maxFileSize > 0 && s.maxFileSize < pw.RowGroupSize {
+			pw.RowGroupSize = s.maxFileSize
+		}
+		s.parquetWriter = pw
+	}
+	return nil
+}
+
+// closeFile finishes the current outfile.
+func (s *SelectIntoExec) closeFile(ctx context.Context) error {
+	if s.writer == nil {
+		return nil
+	}
+	var err error
+	if s.parquetWriter != nil {
+		err = s.parquetWriter.WriteStop()
+		s.parquetWriter = nil
+	}
+	if err1 := s.writer.Close(ctx); err == nil {
+		err = err1
+	}
+	s.writer = nil
+	return errors.Trace(err)
+}
+
+// rotateFileIfNeeded closes the current outfile and opens a new one if its size exceeds the max file size.
+func (s *SelectIntoExec) rotateFileIfNeeded(ctx context.Context) error {
+	if s.maxFileSize <= 0 || s.fileSize < s.maxFileSize {
+		return nil
+	}
+	if err := s.closeFile(ctx); err != nil {
+		return err
+	}
+	return s.openFile(ctx)
+}
+
 // Next implements the Executor Next interface.
 func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.storeIntoVars(ctx)
+	}
 	for {
 		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
 			return err
@@ -79,10 +318,50 @@ func (s *SelectIntoExec) Next(ctx context.Context, _ *chunk.Chunk) error {
 		if s.chk.NumRows() == 0 {
 			break
 		}
-		if err := s.dumpToOutfile(); err != nil {
+		var err error
+		if s.format == importer.DataFormatParquet {
+			err = s.dumpToParquet(ctx)
+		} else {
+			err = s.dumpToOutfile(ctx)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// storeIntoVars stores the only result row into the user variables.
+func (s *SelectIntoExec) storeIntoVars(ctx context.Context) error {
+	var row []types.Datum
+	for {
+		if err := exec.Next(ctx, s.Children(0), s.chk); err != nil {
 			return err
 		}
+		if s.chk.NumRows() == 0 {
+			break
+		}
+		if row != nil || s.chk.NumRows() > 1 {
+			return exeerrors.ErrTooManyRows.GenWithStackByArgs()
+		}
+		row = types.CloneRow(s.chk.GetRow(0).GetDatumRow(exec.RetTypes(s.Children(0))))
+	}
+	sessionVars := s.Ctx().GetSessionVars()
+	if row == nil {
+		sessionVars.StmtCtx.AppendWarning(exeerrors.ErrSpFetchNoData.GenWithStackByArgs())
+		return nil
+	}
+	retTypes := exec.RetTypes(s.Children(0))
+	for i, v := range s.intoOpt.Variables {
+		name := strings.ToLower(v.(*ast.VariableExpr).Name)
+		if row[i].IsNull() {
+			sessionVars.UnsetUserVar(name)
+			continue
+		}
+		sessionVars.SetUserVarVal(name, row[i])
+		sessionVars.SetUserVarType(name, retTypes[i])
 	}
+	sessionVars.StmtCtx.AddAffectedRows(1)
 	return nil
 }
 
@@ -121,7 +400,44 @@ func (s *SelectIntoExec) escapeField(f []byte) []byte {
 	return s.escapeBuf
 }
 
-func (s *SelectIntoExec) dumpToOutfile() error {
+// appendField appends the text representation of the j-th column of the row to buf.
+func (s *SelectIntoExec) appendField(buf []byte, row chunk.Row, j int, tp *types.FieldType) []byte {
+	switch tp.GetType() {
+	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
+		buf = strconv.AppendInt(buf, row.GetInt64(j), 10)
+	case mysql.TypeLonglong:
+		if mysql.HasUnsignedFlag(tp.GetFlag()) {
+			buf = strconv.AppendUint(buf, row.GetUint64(j), 10)
+		} else {
+			buf = strconv.AppendInt(buf, row.GetInt64(j), 10)
+		}
+	case mysql.TypeFloat:
+		s.realBuf, buf = DumpRealOutfile(s.realBuf, buf, float64(row.GetFloat32(j)), tp)
+	case mysql.TypeDouble:
+		s.realBuf, buf = DumpRealOutfile(s.realBuf, buf, row.GetFloat64(j), tp)
+	case mysql.TypeNewDecimal:
+		buf = append(buf, row.GetMyDecimal(j).String()...)
+	case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
+		mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
+		buf = append(buf, row.GetBytes(j)...)
+	case mysql.TypeBit:
+		// bit value won't be escaped anyway (verified on MySQL, test case added)
+		buf = append(buf, row.GetBytes(j)...)
+	case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
+		buf = append(buf, row.GetTime(j).String()...)
+	case mysql.TypeDuration:
+		buf = append(buf, row.GetDuration(j, tp.GetDecimal()).String()...)
+	case mysql.TypeEnum:
+		buf = append(buf, row.GetEnum(j).String()...)
+	case mysql.TypeSet:
+		buf = append(buf, row.GetSet(j).String()...)
+	case mysql.TypeJSON:
+		buf = append(buf, row.GetJSON(j).String()...)
+	}
+	return buf
+}
+
+func (s *SelectIntoExec) dumpToOutfile(ctx context.Context) error {
 	encloseFlag := false
 	var encloseByte byte
 	encloseOpt := false
@@ -157,41 +473,8 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			} else {
 				s.enclosed = false
 			}
-			s.fieldBuf = s.fieldBuf[:0]
-			switch col.GetType().GetType() {
-			case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeYear:
-				s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
-			case mysql.TypeLonglong:
-				if mysql.HasUnsignedFlag(col.GetType().GetFlag()) {
-					s.fieldBuf = strconv.AppendUint(s.fieldBuf, row.GetUint64(j), 10)
-				} else {
-					s.fieldBuf = strconv.AppendInt(s.fieldBuf, row.GetInt64(j), 10)
-				}
-			case mysql.TypeFloat:
-				s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, float64(row.GetFloat32(j)), col.RetType)
-			case mysql.TypeDouble:
-				s.realBuf, s.fieldBuf = DumpRealOutfile(s.realBuf, s.fieldBuf, row.GetFloat64(j), col.RetType)
-			case mysql.TypeNewDecimal:
-				s.fieldBuf = append(s.fieldBuf, row.GetMyDecimal(j).String()...)
-			case mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar,
-				mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob:
-				s.fieldBuf = append(s.fieldBuf, row.GetBytes(j)...)
-			case mysql.TypeBit:
-				// bit value won't be escaped anyway (verified on MySQL, test case added)
-				s.lineBuf = append(s.lineBuf, row.GetBytes(j)...)
-			case mysql.TypeDate, mysql.TypeDatetime, mysql.TypeTimestamp:
-				s.fieldBuf = append(s.fieldBuf, row.GetTime(j).String()...)
-			case mysql.TypeDuration:
-				s.fieldBuf = append(s.fieldBuf, row.GetDuration(j, col.GetType().GetDecimal()).String()...)
-			case mysql.TypeEnum:
-				s.fieldBuf = append(s.fieldBuf, row.GetEnum(j).String()...)
-			case mysql.TypeSet:
-				s.fieldBuf = append(s.fieldBuf, row.GetSet(j).String()...)
-			case mysql.TypeJSON:
-				s.fieldBuf = append(s.fieldBuf, row.GetJSON(j).String()...)
-			}
-
-			switch col.GetType().EvalType() {
+			s.fieldBuf = s.appendField(s.fieldBuf[:0], row, j, col.GetType())
+			switch et {
 			case types.ETString, types.ETJson:
 				s.lineBuf = append(s.lineBuf, s.escapeField(s.fieldBuf)...)
 			default:
@@ -203,9 +486,119 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 			}
 		}
 		s.lineBuf = append(s.lineBuf, s.LinesTerminatedBy...)
-		if _, err := s.writer.Write(s.lineBuf); err != nil {
+		if err := s.rotateFileIfNeeded(ctx); err != nil {
+			return err
+		}
+		if _, err := s.writer.Write(ctx, s.lineBuf); err != nil {
 			return errors.Trace(err)
 		}
+		s.fileSize += int64(len(s.lineBuf))
+	}
+	s.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
+	return nil
+}
+
+// initParquetSchema builds the parquet schema from the output columns. Integers and floats
+// keep their types, and the others are written in the same text representation as csv.
+func (s *SelectIntoExec) initParquetSchema() {
+	fieldNames := s.fieldNames
+	cols := s.Children(0).Schema().Columns
+	s.parquetSchema = make([]string, 0, len(cols))
+	usedNames := make(map[string]struct{}, len(cols))
+	for i, col := range cols {
+		name := fmt.Sprintf("col%d", i)
+		if i < len(fieldNames) && fieldNames[i] != nil {
+			name = parquetColumnName(fieldNames[i].ColName.O)
+		}
+		// parquet column names are case-insensitive in most readers.
+		for j := 0; ; j++ {
+			candidate := name
+			if j > 0 {
+				candidate = fmt.Sprintf("%s_%d", name, j)
+			}
+			if _, ok := usedNames[strings.ToLower(candidate)]; !ok {
+				name = candidate
+				break
+			}
+		}
+		usedNames[strings.ToLower(name)] = struct{}{}
+
+		var tp string
+		switch col.GetType().GetType() {
+		case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+			tp = "INT64"
+			if mysql.HasUnsignedFlag(col.GetType().GetFlag()) {
+				tp = "UINT_64"
+			}
+		case mysql.TypeFloat:
+			tp = "FLOAT"
+		case mysql.TypeDouble:
+			tp = "DOUBLE"
+		case mysql.TypeTinyBlob, mysql.TypeMediumBlob, mysql.TypeLongBlob, mysql.TypeBlob,
+			mysql.TypeString, mysql.TypeVarString, mysql.TypeVarchar, mysql.TypeBit:
+			tp = "UTF8"
+			if mysql.HasBinaryFlag(col.GetType().GetFlag()) || col.GetType().GetType() == mysql.TypeBit {
+				tp = "BYTE_ARRAY"
+			}
+		default:
+			tp = "UTF8"
+		}
+		s.parquetSchema = append(s.parquetSchema, fmt.Sprintf("name=%s, type=%s, repetitiontype=OPTIONAL", name, tp))
+	}
+}
+
+// parquetColumnName replaces the characters which are not allowed in the parquet schema of parquet-go.
+func parquetColumnName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_') {
+			b[i] = '_'
+		}
+	}
+	if len(b) == 0 || b[0] >= '0' && b[0] <= '9' {
+		return "_" + string(b)
+	}
+	return string(b)
+}
+
+func (s *SelectIntoExec) dumpToParquet(ctx context.Context) error {
+	cols := s.Children(0).Schema().Columns
+	for i := 0; i < s.chk.NumRows(); i++ {
+		row := s.chk.GetRow(i)
+		// parquet-go keeps the reference of the row until the row group is flushed, so it can't be reused.
+		parquetRow := make([]interface{}, len(cols))
+		var rowSize int64
+		for j, col := range cols {
+			if row.IsNull(j) {
+				continue
+			}
+			tp := col.GetType()
+			switch tp.GetType() {
+			case mysql.TypeTiny, mysql.TypeShort, mysql.TypeInt24, mysql.TypeLong, mysql.TypeLonglong, mysql.TypeYear:
+				// unsigned integers are stored in the same bits of int64, see the UINT_64 logical type of parquet.
+				parquetRow[j] = row.GetInt64(j)
+				rowSize += 8
+			case mysql.TypeFloat:
+				parquetRow[j] = row.GetFloat32(j)
+				rowSize += 4
+			case mysql.TypeDouble:
+				parquetRow[j] = row.GetFloat64(j)
+				rowSize += 8
+			default:
+				s.fieldBuf = s.appendField(s.fieldBuf[:0], row, j, tp)
+				parquetRow[j] = string(s.fieldBuf)
+				rowSize += int64(len(s.fieldBuf))
+			}
+		}
+		if err := s.rotateFileIfNeeded(ctx); err != nil {
+			return err
+		}
+		if err := s.parquetWriter.Write(parquetRow); err != nil {
+			return errors.Trace(err)
+		}
+		// the size is estimated by the uncompressed data, because parquet-go buffers the
+		// whole row group before writing it out.
+		s.fileSize += rowSize
 	}
 	s.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(uint64(s.chk.NumRows()))
 	return nil
@@ -213,18 +606,50 @@ func (s *SelectIntoExec) dumpToOutfile() error {
 
 // Close implements the Executor Close interface.
 func (s *SelectIntoExec) Close() error {
+	if s.intoOpt.Tp == ast.SelectIntoVars {
+		return s.BaseExecutor.Close()
+	}
 	if !s.started {
 		return nil
 	}
-	err1 := s.writer.Flush()
-	err2 := s.dstFile.Close()
-	err3 := s.BaseExecutor.Close()
+	err1 := s.closeFile(context.Background())
+	err2 := s.BaseExecutor.Close()
+	if err1 != nil {
+		return err1
+	}
+	return err2
+}
+
+// localFileWriter writes the outfile to the local filesystem of TiDB.
+type localFileWriter struct {
+	file *os.File
+	buf  *bufio.Writer
+}
+
+// Write implements the storage.ExternalFileWriter interface.
+func (w *localFileWriter) Write(_ context.Context, p []byte) (int, error) {
+	return w.buf.Write(p)
+}
+
+// Close implements the storage.ExternalFileWriter interface.
+func (w *localFileWriter) Close(_ context.Context) error {
+	err1 := w.buf.Flush()
+	err2 := w.file.Close()
 	if err1 != nil {
 		return errors.Trace(err1)
-	} else if err2 != nil {
-		return errors.Trace(err2)
 	}
-	return err3
+	return errors.Trace(err2)
+}
+
+// fileWriterAdapter adapts storage.ExternalFileWriter to io.Writer.
+type fileWriterAdapter struct {
+	ctx context.Context
+	w   storage.ExternalFileWriter
+}
+
+// Write implements the io.Writer interface.
+func (a *fileWriterAdapter) Write(p []byte) (int, error) {
+	return a.w.Write(a.ctx, p)
 }
 
 const (
@@ -15,17 +15,25 @@
 package executor_test
 
 import (
+	"context"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/br/pkg/lightning/mydump"
+	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/testkit"
 	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
 	"github.com/stretchr/testify/require"
 )
 
@@ -290,3 +298,117 @@ func TestYearType(t *testing.T) {
 	tk.MustExec(fmt.Sprintf("select * from t into outfile '%v' fields terminated by ',' optionally enclosed by '\"' lines terminated by '\\n';", outfile))
 	cmpAndRm("2010\n2011\n2012\n2030\n", outfile, t)
 }
+
+func TestSelectIntoExternalStorage(t *testing.T) {
+	dir := t.TempDir()
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int, v double, s varchar(10), u bigint unsigned, d decimal(10, 2))")
+	tk.MustExec("insert into t values (1, 1.5, 'a', 18446744073709551615, 1.25), (2, null, 'b,c', 1, null), (3, 3.5, null, null, -2.5)")
+
+	ctx := context.Background()
+	localStore, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	readFile := func(name string, compression storage.CompressType) string {
+		content, err := storage.WithCompression(localStore, compression, storage.DecompressConfig{}).ReadFile(ctx, name)
+		require.NoError(t, err)
+		return string(content)
+	}
+
+	// csv with compression
+	expected := "1,\"a\"\n2,\"b,c\"\n3,\\N\n"
+	sql := fmt.Sprintf("select id, s from t order by id into outfile 'local://%s/result.csv.gz' fields terminated by ',' optionally enclosed by '\"' with compression='gzip'", dir)
+	tk.MustExec(sql)
+	require.Equal(t, expected, readFile("result.csv.gz", storage.Gzip))
+	err = tk.ExecToErr(sql)
+	require.True(t, exeerrors.ErrFileExists.Equal(err), "err: %v", err)
+	require.ErrorContains(t, err, "result.csv.gz")
+	tk.MustExec(fmt.Sprintf("select id, s from t order by id into outfile 'local://%s/result.csv.zst' fields terminated by ',' optionally enclosed by '\"' with compression='zstd'", dir))
+	require.Equal(t, expected, readFile("result.csv.zst", storage.Zstd))
+	tk.MustExec(fmt.Sprintf("select id, s from t order by id into outfile '%s' fields terminated by ',' optionally enclosed by '\"' with compression='gzip'", filepath.Join(dir, "local.csv.gz")))
+	require.Equal(t, expected, readFile("local.csv.gz", storage.Gzip))
+	// the file names without a scheme are plain paths even if they are not valid URIs
+	for _, name := range []string{"50%off.csv", "a:b.csv"} {
+		tk.MustExec(fmt.Sprintf("select id, s from t order by id into outfile '%s' fields terminated by ',' optionally enclosed by '\"'", filepath.Join(dir, name)))
+		require.Equal(t, expected, readFile(name, storage.NoCompression))
+	}
+
+	// the credentials in the URI are redacted in the logs
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/parser/ast/forceRedactURL", "return(true)"))
+	tk.MustExec(fmt.Sprintf("select id, s from t order by id into outfile 'local://%s/secret.csv?access-key=aaaaa&secret-access-key=bbbbb'", dir))
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/parser/ast/forceRedactURL"))
+	require.Equal(t, "1\ta\n2\tb,c\n3\t\\N\n", readFile("secret.csv", storage.NoCompression))
+	prevStmt := tk.Session().GetSessionVars().PrevStmt.String()
+	require.Contains(t, prevStmt, "secret-access-key=xxxxxx")
+	require.NotContains(t, prevStmt, "bbbbb")
+
+	// split the outfile by size
+	tk.MustExec(fmt.Sprintf("select id, s from t order by id into outfile '%s' with max_file_size='10B'", filepath.Join(dir, "split.txt")))
+	require.Equal(t, "1\ta\n2\tb,c\n", readFile("split.0.txt", storage.NoCompression))
+	require.Equal(t, "3\t\\N\n", readFile("split.1.txt", storage.NoCompression))
+	exists, err := localStore.FileExists(ctx, "split.2.txt")
+	require.NoError(t, err)
+	require.False(t, exists)
+	tk.MustExec(fmt.Sprintf("select id from t order by id into outfile 'local://%s/split' with max_file_size='1B', compression='gzip'", dir))
+	for i := 0; i < 3; i++ {
+		require.Equal(t, fmt.Sprintf("%d\n", i+1), readFile(fmt.Sprintf("split.%d", i), storage.Gzip))
+	}
+
+	// parquet
+	readParquet := func(name string) ([]string, [][]types.Datum) {
+		reader, err := localStore.Open(ctx, name)
+		require.NoError(t, err)
+		parser, err := mydump.NewParquetParser(ctx, localStore, reader, name)
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, parser.Close())
+		}()
+		var rows [][]types.Datum
+		for {
+			err := parser.ReadRow()
+			if errors.Cause(err) == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			rows = append(rows, append([]types.Datum{}, parser.LastRow().Row...))
+		}
+		return parser.Columns(), rows
+	}
+	tk.MustExec(fmt.Sprintf("select *, id + 1 as `next id`, id + 1 as `NEXT_ID` from t order by id into outfile 'local://%s/result.parquet' format 'parquet'", dir))
+	columns, rows := readParquet("result.parquet")
+	require.Equal(t, []string{"id", "v", "s", "u", "d", "next_id", "next_id_1"}, columns)
+	require.Len(t, rows, 3)
+	require.Equal(t, int64(1), rows[0][0].GetInt64())
+	require.Equal(t, 1.5, rows[0][1].GetFloat64())
+	require.Equal(t, "a", rows[0][2].GetString())
+	require.Equal(t, uint64(18446744073709551615), rows[0][3].GetUint64())
+	require.Equal(t, "1.25", rows[0][4].GetString())
+	require.Equal(t, int64(2), rows[0][5].GetInt64())
+	require.True(t, rows[1][1].IsNull())
+	require.Equal(t, "b,c", rows[1][2].GetString())
+	require.True(t, rows[1][4].IsNull())
+	require.True(t, rows[2][2].IsNull())
+	require.Equal(t, "-2.50", rows[2][4].GetString())
+
+	tk.MustExec(fmt.Sprintf("select id, s from t order by id into outfile 'local://%s/split.parquet' format 'parquet' with max_file_size='8B', compression='zstd'", dir))
+	for i := 0; i < 3; i++ {
+		_, rows = readParquet(fmt.Sprintf("split.%d.parquet", i))
+		require.Len(t, rows, 1)
+		require.Equal(t, int64(i+1), rows[0][0].GetInt64())
+	}
+
+	// invalid options
+	outfile := fmt.Sprintf("local://%s/invalid", dir)
+	tk.MustGetErrCode(fmt.Sprintf("select * from t into outfile '%s' format 'json'", outfile), errno.ErrLoadDataUnsupportedFormat)
+	tk.MustGetErrCode(fmt.Sprintf("select * from t into outfile '%s' format 'parquet' fields terminated by ','", outfile), errno.ErrLoadDataUnsupportedOption)
+	tk.MustGetErrCode(fmt.Sprintf("select * from t into outfile '%s' with compression='lz4'", outfile), errno.ErrInvalidOptionVal)
+	tk.MustGetErrCode(fmt.Sprintf("select * from t into outfile '%s' with compression=1", outfile), errno.ErrInvalidOptionVal)
+	tk.MustGetErrCode(fmt.Sprintf("select * from t into outfile '%s' with max_file_size='-1'", outfile), errno.ErrInvalidOptionVal)
+	tk.MustGetErrCode(fmt.Sprintf("select * from t into outfile '%s' with compression='gzip', compression='zstd'", outfile), errno.ErrDuplicateOption)
+	tk.MustGetErrCode(fmt.Sprintf("select * from t into outfile '%s' with thread=1", outfile), errno.ErrUnknownOption)
+	tk.MustGetErrCode("select * from t into outfile 'local:///'", errno.ErrLoadDataInvalidURI)
+	exists, err = localStore.FileExists(ctx, "invalid")
+	require.NoError(t, err)
+	require.False(t, exists)
+}
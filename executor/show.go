@@ -93,6 +93,8 @@ type ShowExec struct {
 	Tp                ast.ShowStmtType // Databases/Tables/Columns/....
 	DBName            model.CIStr
 	Table             *ast.TableName       // Used for showing columns.
+	Procedure         *ast.TableName       // Used for showing create procedure.
+	Event             *ast.TableName       // Used for showing create event.
 	Partition         model.CIStr          // Used for showing partition
 	Column            *ast.ColumnName      // Used for `desc table column`.
 	IndexName         model.CIStr          // Used for show table regions.
@@ -190,6 +192,10 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 		return e.fetchShowCreateUser(ctx)
 	case ast.ShowCreateView:
 		return e.fetchShowCreateView()
+	case ast.ShowCreateProcedure:
+		return e.fetchShowCreateProcedure(ctx)
+	case ast.ShowCreateEvent:
+		return e.fetchShowCreateEvent(ctx)
 	case ast.ShowCreateDatabase:
 		return e.fetchShowCreateDatabase()
 	case ast.ShowCreatePlacementPolicy:
@@ -207,7 +213,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowIndex:
 		return e.fetchShowIndex()
 	case ast.ShowProcedureStatus:
-		return e.fetchShowProcedureStatus()
+		return e.fetchShowProcedureStatus(ctx)
 	case ast.ShowPumpStatus:
 		return e.fetchShowPumpOrDrainerStatus(node.PumpNode)
 	case ast.ShowStatus:
@@ -229,7 +235,7 @@ func (e *ShowExec) fetchAll(ctx context.Context) error {
 	case ast.ShowProcessList:
 		return e.fetchShowProcessList()
 	case ast.ShowEvents:
-		// empty result
+		return e.fetchShowEvents(ctx)
 	case ast.ShowStatsExtended:
 		return e.fetchShowStatsExtended()
 	case ast.ShowStatsMeta:
@@ -321,13 +327,34 @@ func (*visibleChecker) Leave(in ast.Node) (out ast.Node, ok bool) {
 	return in, true
 }
 
+// formatCrossDBBindingHits formats the hit counts of a cross-database binding as `db1:3, db2:1`.
+func formatCrossDBBindingHits(hits map[string]int64) string {
+	schemas := make([]string, 0, len(hits))
+	for schema := range hits {
+		schemas = append(schemas, schema)
+	}
+	slices.Sort(schemas)
+	var sb strings.Builder
+	for i, schema := range schemas {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		fmt.Fprintf(&sb, "%s:%d", schema, hits[schema])
+	}
+	return sb.String()
+}
+
 func (e *ShowExec) fetchShowBind() error {
 	var tmp []*bindinfo.BindRecord
+	var getCrossDBBindingHits func(originalSQL string) map[string]int64
 	if !e.GlobalScope {
 		handle := e.Ctx().Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
 		tmp = handle.GetAllBindRecord()
+		getCrossDBBindingHits = handle.GetCrossDBBindingHits
 	} else {
-		tmp = domain.GetDomain(e.Ctx()).BindHandle().GetAllBindRecord()
+		handle := domain.GetDomain(e.Ctx()).BindHandle()
+		tmp = handle.GetAllBindRecord()
+		getCrossDBBindingHits = handle.GetCrossDBBindingHits
 	}
 	bindRecords := make([]*bindinfo.BindRecord, 0)
 	for _, bindRecord := range tmp {
@@ -364,6 +391,10 @@ func (e *ShowExec) fetchShowBind() error {
 		return cmpResult > 0
 	})
 	for _, bindData := range bindRecords {
+		schemaHits := ""
+		if bindData.IsCrossDB() {
+			schemaHits = formatCrossDBBindingHits(getCrossDBBindingHits(bindData.OriginalSQL))
+		}
 		for _, hint := range bindData.Bindings {
 			stmt, err := parser.ParseOneStmt(hint.BindSQL, hint.Charset, hint.Collation)
 			if err != nil {
@@ -392,6 +423,7 @@ func (e *ShowExec) fetchShowBind() error {
 				hint.Source,
 				hint.SQLDigest,
 				hint.PlanDigest,
+				schemaHits,
 			})
 		}
 	}
@@ -815,23 +847,28 @@ func (e *ShowExec) fetchShowIndex() error {
 				ndv = colStats.NDV
 			}
 
+			indexType := idx.Meta().Tp.String()
+			if idx.Meta().Spatial {
+				indexType = "SPATIAL"
+			}
+
 			e.appendRow([]interface{}{
-				tb.Meta().Name.O,       // Table
-				nonUniq,                // Non_unique
-				idx.Meta().Name.O,      // Key_name
-				i + 1,                  // Seq_in_index
-				colName,                // Column_name
-				"A",                    // Collation
-				ndv,                    // Cardinality
-				subPart,                // Sub_part
-				nil,                    // Packed
-				nullVal,                // Null
-				idx.Meta().Tp.String(), // Index_type
-				"",                     // Comment
-				idx.Meta().Comment,     // Index_comment
-				visible,                // Index_visible
-				expression,             // Expression
-				isClustered,            // Clustered
+				tb.Meta().Name.O,   // Table
+				nonUniq,            // Non_unique
+				idx.Meta().Name.O,  // Key_name
+				i + 1,              // Seq_in_index
+				colName,            // Column_name
+				"A",                // Collation
+				ndv,                // Cardinality
+				subPart,            // Sub_part
+				nil,                // Packed
+				nullVal,            // Null
+				indexType,          // Index_type
+				"",                 // Comment
+				idx.Meta().Comment, // Index_comment
+				visible,            // Index_visible
+				expression,         // Expression
+				isClustered,        // Clustered
 			})
 		}
 	}
@@ -1142,6 +1179,8 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 			buf.WriteString("  PRIMARY KEY ")
 		} else if idxInfo.Unique {
 			fmt.Fprintf(buf, "  UNIQUE KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
+		} else if idxInfo.Spatial {
+			fmt.Fprintf(buf, "  SPATIAL KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		} else {
 			fmt.Fprintf(buf, "  KEY %s ", stringutil.Escape(idxInfo.Name.O, sqlMode))
 		}
@@ -1232,7 +1271,11 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 
 	buf.WriteString("\n")
 
-	buf.WriteString(") ENGINE=InnoDB")
+	if tableInfo.Engine != "" {
+		fmt.Fprintf(buf, ") ENGINE=%s", tableInfo.Engine)
+	} else {
+		buf.WriteString(") ENGINE=InnoDB")
+	}
 	// We need to explicitly set the default charset and collation
 	// to make it work on MySQL server which has default collate utf8_general_ci.
 	if len(tblCollate) == 0 || tblCollate == "binary" {
@@ -1357,6 +1400,62 @@ func constructResultOfShowCreateTable(ctx sessionctx.Context, dbName *model.CISt
 		if err != nil {
 			return err
 		}
+
+		if len(tableInfo.TTLInfo.FilterExprStr) > 0 {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_FILTER")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString(tableInfo.TTLInfo.FilterExprStr)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if archiveTo := tableInfo.TTLInfo.ArchiveTo; archiveTo != nil {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_ARCHIVE_TO")
+				restoreCtx.WritePlain("=")
+				tn := ast.TableName{Schema: archiveTo.Schema, Name: archiveTo.Table}
+				return tn.Restore(restoreCtx)
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(tableInfo.TTLInfo.JobWindow) > 0 {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_JOB_WINDOW")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WriteString(tableInfo.TTLInfo.JobWindow)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
+
+		if tableInfo.TTLInfo.DeleteRate > 0 {
+			restoreCtx.WritePlain(" ")
+			err = restoreCtx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+				restoreCtx.WriteKeyWord("TTL_DELETE_RATE")
+				restoreCtx.WritePlain("=")
+				restoreCtx.WritePlainf("%d", tableInfo.TTLInfo.DeleteRate)
+				return nil
+			})
+
+			if err != nil {
+				return err
+			}
+		}
 	}
 	return nil
 }
@@ -1832,14 +1931,6 @@ func (e *ShowExec) fetchShowPrivileges() error {
 	return nil
 }
 
-func (*ShowExec) fetchShowTriggers() error {
-	return nil
-}
-
-func (*ShowExec) fetchShowProcedureStatus() error {
-	return nil
-}
-
 func (e *ShowExec) fetchShowPlugins() error {
 	tiPlugins := plugin.GetAll()
 	for _, ps := range tiPlugins {
@@ -193,6 +193,20 @@ func (e *SimpleExec) Next(ctx context.Context, _ *chunk.Chunk) (err error) {
 		err = e.executeSetResourceGroupName(x)
 	case *ast.DropQueryWatchStmt:
 		err = e.executeDropQueryWatch(x)
+	case *ast.ProcedureInfo:
+		err = e.executeCreateProcedure(ctx, x)
+	case *ast.DropProcedureStmt:
+		err = e.executeDropProcedure(ctx, x)
+	case *ast.CallStmt:
+		err = e.executeCallProcedure(ctx, x)
+	case *ast.RefreshMaterializedViewStmt:
+		err = e.executeRefreshMaterializedView(ctx, x)
+	case *ast.CreateEventStmt:
+		err = e.executeCreateEvent(ctx, x)
+	case *ast.AlterEventStmt:
+		err = e.executeAlterEvent(ctx, x)
+	case *ast.DropEventStmt:
+		err = e.executeDropEvent(ctx, x)
 	}
 	e.done = true
 	return err
@@ -2712,6 +2726,8 @@ func (e *SimpleExec) autoNewTxn() bool {
 	switch e.Statement.(type) {
 	// Data definition language (DDL) statements that define or modify database objects.
 	// (handled in DDL package)
+	case *ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
+		return true
 	// Statements that implicitly use or modify tables in the mysql database.
 	case *ast.CreateUserStmt, *ast.AlterUserStmt, *ast.DropUserStmt, *ast.RenameUserStmt, *ast.RevokeRoleStmt, *ast.GrantRoleStmt:
 		return true
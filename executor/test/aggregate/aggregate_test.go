@@ -26,6 +26,7 @@ import (
 	"time"
 
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/errno"
 	"github.com/pingcap/tidb/executor/aggregate"
 	"github.com/pingcap/tidb/executor/internal"
 	"github.com/pingcap/tidb/parser/terror"
@@ -1716,3 +1717,129 @@ func TestIssue26885(t *testing.T) {
 	tk.MustQuery("select * from t1").Check(testkit.Rows("b", "a", "b", "c", ""))
 	tk.MustQuery("SELECT c1 + 0, COUNT(c1) FROM t1 GROUP BY c1 order by c1;").Check(testkit.Rows("0 1", "1 1", "2 2", "3 1"))
 }
+
+func TestGroupingSets(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, c int)")
+	tk.MustExec("insert into t values (1, 1, 1), (1, 2, 2), (2, 1, 3), (null, 1, 4)")
+
+	// the NULL in the data is told apart from the NULL of the super-aggregate rows by grouping().
+	tk.MustQuery("select a, b, sum(c), grouping(a), grouping(a, b) from t group by a, b with rollup order by grouping(a, b), a, b").Check(testkit.Rows(
+		"<nil> 1 4 0 0",
+		"1 1 1 0 0",
+		"1 2 2 0 0",
+		"2 1 3 0 0",
+		"<nil> <nil> 4 0 1",
+		"1 <nil> 3 0 1",
+		"2 <nil> 3 0 1",
+		"<nil> <nil> 10 1 3"))
+	tk.MustQuery("select a, b, sum(c) from t group by rollup(a, b) having grouping(b) = 1 order by a, sum(c)").Check(testkit.Rows(
+		"<nil> <nil> 4",
+		"<nil> <nil> 10",
+		"1 <nil> 3",
+		"2 <nil> 3"))
+
+	tk.MustQuery("select a, b, sum(c), grouping(a, b) from t group by cube(a, b) order by grouping(a, b), a, b").Check(testkit.Rows(
+		"<nil> 1 4 0",
+		"1 1 1 0",
+		"1 2 2 0",
+		"2 1 3 0",
+		"<nil> <nil> 4 1",
+		"1 <nil> 3 1",
+		"2 <nil> 3 1",
+		"<nil> 1 8 2",
+		"<nil> 2 2 2",
+		"<nil> <nil> 10 3"))
+
+	tk.MustQuery("select a, b, count(*), grouping(b, a) from t group by grouping sets ((a), (b), ()) order by grouping(b, a), a, b").Check(testkit.Rows(
+		"<nil> 1 3 1",
+		"<nil> 2 1 1",
+		"<nil> <nil> 1 2",
+		"1 <nil> 2 2",
+		"2 <nil> 1 2",
+		"<nil> <nil> 4 3"))
+	// the duplicate grouping sets produce duplicate rows.
+	tk.MustQuery("select b, count(*) from t group by grouping sets ((b), (b)) order by b").Check(testkit.Rows(
+		"1 3", "1 3", "2 1", "2 1"))
+	tk.MustQuery("select a + 1, count(*) from t group by grouping sets ((a + 1), ()) order by grouping(a + 1), a + 1").Check(testkit.Rows(
+		"<nil> 1", "2 2", "3 1", "<nil> 4"))
+
+	tk.MustGetErrCode("select a, c from t group by cube(a, b)", errno.ErrFieldInGroupingNotGroupBy)
+	tk.MustGetErrCode("select grouping(c) from t group by grouping sets ((a), (b))", errno.ErrFieldInGroupingNotGroupBy)
+	tk.MustGetErrCode("select grouping(a) from t group by a", errno.ErrInvalidGroupFuncUse)
+}
+
+func TestHLLSketchAndApproxTopK(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10), d int)")
+	tk.MustExec("insert into t values (1, 'x', 1), (1, 'x', 1), (1, 'y', 2), (2, 'y', 2), (2, 'z', 3), (3, null, 3), (null, 'x', 3)")
+
+	tk.MustQuery("select approx_top_k(a, 2), approx_top_k(b, 1) from t").Check(testkit.Rows(
+		`[{"count": 3, "value": 1}, {"count": 2, "value": 2}] [{"count": 3, "value": "x"}]`))
+	tk.MustQuery("select d, approx_top_k(b, 3) from t group by d order by d").Check(testkit.Rows(
+		`1 [{"count": 2, "value": "x"}]`,
+		`2 [{"count": 2, "value": "y"}]`,
+		`3 [{"count": 1, "value": "x"}, {"count": 1, "value": "z"}]`))
+	tk.MustQuery("select approx_top_k(a, 2), hll_sketch(a), hll_merge(b) from t where a > 10").Check(testkit.Rows("<nil> <nil> <nil>"))
+	tk.MustGetErrMsg("select approx_top_k(a, 0) from t", "APPROX_TOP_K: k value 0 is out of range [1, 4096]")
+	tk.MustGetErrMsg("select approx_top_k(a, d) from t", "APPROX_TOP_K should take a constant expression as k argument")
+
+	// rollup tables keep the sketches, which are merged later instead of re-scanning the raw data.
+	tk.MustExec("create table rollup (d int, s blob)")
+	tk.MustExec("insert into rollup select d, hll_sketch(a, b) from t group by d")
+	tk.MustQuery("select length(hll_merge(s)) = length(hll_sketch(a, b)) from rollup, (select a, b from t) x").Check(testkit.Rows("1"))
+	tk.MustQuery("select length(hll_merge(s)) from rollup where d < 3").Check(testkit.Rows("14"))
+	tk.MustQuery("select length(hll_sketch(a)) from t").Check(testkit.Rows("14"))
+	require.EqualError(t, tk.QueryToErr("select hll_merge('abc') from t"), "Cannot read partialResult4ApproxCountDistinct: truncated input")
+
+	// hll_estimate returns the distinct count kept by a sketch, it is exact before the sketch gets thinned.
+	tk.MustQuery("select d, hll_estimate(hll_sketch(a, b)), approx_count_distinct(a, b) from t group by d order by d").Check(testkit.Rows("1 1 1", "2 2 2", "3 1 1"))
+	tk.MustQuery("select hll_estimate(hll_merge(s)), hll_estimate(null) from rollup").Check(testkit.Rows("4 <nil>"))
+	require.EqualError(t, tk.QueryToErr("select hll_estimate('abc') from t"), "[expression:1210]Incorrect arguments to hll_estimate")
+	tk.MustExec("create table digits (i int)")
+	tk.MustExec("insert into digits values (0), (1), (2), (3), (4), (5), (6), (7), (8), (9)")
+	tk.MustExec("create table big (n int)")
+	tk.MustExec("insert into big select d1.i * 10000 + d2.i * 1000 + d3.i * 100 + d4.i * 10 + d5.i from digits d1, digits d2, digits d3, digits d4, digits d5")
+	tk.MustExec("create table big_rollup (g int, s mediumblob)")
+	tk.MustExec("insert into big_rollup select n % 4, hll_sketch(n) from big group by n % 4")
+	tk.MustQuery("select hll_estimate(s) from big_rollup order by g").Check(testkit.Rows("25000", "25000", "25000", "25000"))
+	// 100000 distinct values are more than a sketch keeps, so the estimation is approximate.
+	for _, sql := range []string{
+		"select hll_estimate(hll_sketch(n)) from big",
+		"select hll_estimate(hll_merge(s)) from big_rollup",
+	} {
+		estimated, err := strconv.ParseFloat(tk.MustQuery(sql).Rows()[0][0].(string), 64)
+		require.NoError(t, err)
+		require.InEpsilon(t, 100000, estimated, 0.05, sql)
+	}
+
+	// the functions are split into partial and final phases by the parallel hash aggregation.
+	tk.MustExec("set @@tidb_hashagg_partial_concurrency = 4, @@tidb_hashagg_final_concurrency = 4")
+	tk.MustQuery("select /*+ hash_agg() */ d, approx_top_k(a, 1), length(hll_sketch(b)) from t group by d order by d").Check(testkit.Rows(
+		`1 [{"count": 2, "value": 1}] 6`,
+		`2 [{"count": 1, "value": 1}] 6`,
+		`3 [{"count": 1, "value": 2}] 10`))
+
+	// the functions are pushed down across the union of partitions.
+	tk.MustExec("set @@tidb_partition_prune_mode = 'static', @@tidb_opt_agg_push_down = 1")
+	tk.MustExec("create table pt (a int, d int) partition by hash(d) partitions 3")
+	tk.MustExec("insert into pt select a, d from t")
+	tk.MustQuery("select approx_top_k(a, 2), length(hll_sketch(a)) from pt").Check(testkit.Rows(
+		`[{"count": 3, "value": 1}, {"count": 2, "value": 2}] 14`))
+	tk.MustQuery("explain format = 'brief' select approx_top_k(a, 2), hll_sketch(a) from pt").Check(testkit.Rows(
+		"HashAgg 1.00 root  funcs:approx_top_k(Column#6, 2)->Column#4, funcs:hll_sketch(Column#7)->Column#5",
+		"└─PartitionUnion 3.00 root  ",
+		"  ├─HashAgg 1.00 root  funcs:approx_top_k(test.pt.a, 2)->Column#6, funcs:hll_sketch(test.pt.a)->Column#7",
+		"  │ └─TableReader 10000.00 root  data:TableFullScan",
+		"  │   └─TableFullScan 10000.00 cop[tikv] table:pt, partition:p0 keep order:false, stats:pseudo",
+		"  ├─HashAgg 1.00 root  funcs:approx_top_k(test.pt.a, 2)->Column#6, funcs:hll_sketch(test.pt.a)->Column#7",
+		"  │ └─TableReader 10000.00 root  data:TableFullScan",
+		"  │   └─TableFullScan 10000.00 cop[tikv] table:pt, partition:p1 keep order:false, stats:pseudo",
+		"  └─HashAgg 1.00 root  funcs:approx_top_k(test.pt.a, 2)->Column#6, funcs:hll_sketch(test.pt.a)->Column#7",
+		"    └─TableReader 10000.00 root  data:TableFullScan",
+		"      └─TableFullScan 10000.00 cop[tikv] table:pt, partition:p2 keep order:false, stats:pseudo"))
+}
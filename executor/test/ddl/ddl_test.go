@@ -1615,6 +1615,131 @@ func TestAlterTTLInfo(t *testing.T) {
 	tk.MustGetErrMsg("ALTER TABLE t TTL_JOB_INTERVAL = '1h'", "[ddl:8150]Cannot set TTL_JOB_INTERVAL on a table without TTL config")
 }
 
+func TestTTLFilterAndArchiveTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database archive")
+	tk.MustExec("use test")
+	tk.MustExec("CREATE TABLE archive.t_archive (id int primary key, created_at datetime, status varchar(16))")
+
+	tk.MustExec("CREATE TABLE t (id int primary key, created_at datetime, status varchar(16)) TTL = `created_at` + INTERVAL 5 DAY TTL_FILTER = 'status in (\"done\", \\'failed\\')' TTL_ARCHIVE_TO = archive.t_archive")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `id` int(11) NOT NULL,\n  `created_at` datetime DEFAULT NULL,\n  `status` varchar(16) DEFAULT NULL,\n  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 5 DAY */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_FILTER='`status` IN (''done'',''failed'')' */ /*T![ttl] TTL_ARCHIVE_TO=`archive`.`t_archive` */"))
+
+	// the filter and the archive table are kept when other TTL options are changed
+	tk.MustExec("ALTER TABLE t TTL = `created_at` + INTERVAL 1 DAY TTL_ENABLE = 'OFF'")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `id` int(11) NOT NULL,\n  `created_at` datetime DEFAULT NULL,\n  `status` varchar(16) DEFAULT NULL,\n  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='OFF' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_FILTER='`status` IN (''done'',''failed'')' */ /*T![ttl] TTL_ARCHIVE_TO=`archive`.`t_archive` */"))
+
+	// the archive table is in the same schema of the TTL table if the schema is not specified
+	tk.MustExec("CREATE TABLE t_archive2 (id int primary key, created_at datetime, status varchar(16))")
+	tk.MustExec("ALTER TABLE t TTL_FILTER = 'id > 10' TTL_ARCHIVE_TO = t_archive2")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `id` int(11) NOT NULL,\n  `created_at` datetime DEFAULT NULL,\n  `status` varchar(16) DEFAULT NULL,\n  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='OFF' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_FILTER='`id`>10' */ /*T![ttl] TTL_ARCHIVE_TO=`test`.`t_archive2` */"))
+
+	tk.MustExec("ALTER TABLE t TTL_FILTER = '' TTL_ARCHIVE_TO = ''")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `id` int(11) NOT NULL,\n  `created_at` datetime DEFAULT NULL,\n  `status` varchar(16) DEFAULT NULL,\n  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='OFF' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */"))
+
+	tk.MustGetErrMsg("ALTER TABLE t TTL_FILTER = 'not_exist > 1'", "[planner:1054]Unknown column 'not_exist' in 'expression'")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_FILTER = 'id > 1 from t'", "The TTL_FILTER option is not a valid expression: id > 1 from t")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_FILTER = 'id > 1; drop table t'", "The TTL_FILTER option is not a valid expression: id > 1; drop table t")
+	tk.MustGetErrCode("ALTER TABLE t TTL_ARCHIVE_TO = not_exist", errno.ErrNoSuchTable)
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TO = t", "[ddl:8265]Table 'test.t' cannot be used as the archive table of TTL: it is the TTL table itself")
+	tk.MustExec("CREATE TABLE t_ttl (created_at datetime) TTL = `created_at` + INTERVAL 5 DAY")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TO = t_ttl", "[ddl:8265]Table 'test.t_ttl' cannot be used as the archive table of TTL: it is a TTL table")
+	tk.MustExec("CREATE VIEW v AS SELECT * FROM t_archive2")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TO = v", "[ddl:8265]Table 'test.v' cannot be used as the archive table of TTL: it is not a base table")
+
+	tk.MustExec("ALTER TABLE t REMOVE TTL")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_FILTER = 'id > 1'", "[ddl:8150]Cannot set TTL_FILTER on a table without TTL config")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_ARCHIVE_TO = t_archive2", "[ddl:8150]Cannot set TTL_ARCHIVE_TO on a table without TTL config")
+	tk.MustGetErrMsg("CREATE TABLE t2 (id int) TTL_FILTER = 'id > 1'", "[ddl:8150]Cannot set TTL_FILTER on a table without TTL config")
+}
+
+func TestTTLFilterColumnAndArchiveTableInUse(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database archive")
+	tk.MustExec("use test")
+	tk.MustExec("CREATE TABLE archive.t_archive (id int primary key, created_at datetime, status varchar(16), kind int)")
+	tk.MustExec("CREATE TABLE t (id int primary key, created_at datetime, status varchar(16), kind int, other int) TTL = `created_at` + INTERVAL 5 DAY TTL_FILTER = 'status = \\'done\\' and t.kind > 1' TTL_ARCHIVE_TO = archive.t_archive")
+
+	ttlFilter := func() string {
+		tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+		require.NoError(t, err)
+		return tbl.Meta().TTLInfo.FilterExprStr
+	}
+	require.Equal(t, "`status`='done' AND `t`.`kind`>1", ttlFilter())
+
+	// the columns referenced by the filter can't be dropped
+	tk.MustGetErrMsg("ALTER TABLE t DROP COLUMN status", "[ddl:8149]Cannot drop column 'status': needed in TTL config")
+	tk.MustGetErrMsg("ALTER TABLE t DROP COLUMN kind", "[ddl:8149]Cannot drop column 'kind': needed in TTL config")
+	tk.MustGetErrMsg("ALTER TABLE t DROP COLUMN other, DROP COLUMN kind", "[ddl:8149]Cannot drop column 'kind': needed in TTL config")
+	tk.MustExec("ALTER TABLE t DROP COLUMN other")
+
+	// the filter follows the renamed columns
+	tk.MustExec("ALTER TABLE t RENAME COLUMN status TO state")
+	require.Equal(t, "`state`='done' AND `t`.`kind`>1", ttlFilter())
+	tk.MustExec("ALTER TABLE t CHANGE COLUMN kind category bigint")
+	require.Equal(t, "`state`='done' AND `t`.`category`>1", ttlFilter())
+	tk.MustExec("ALTER TABLE t CHANGE COLUMN category category2 varchar(16)")
+	require.Equal(t, "`state`='done' AND `t`.`category2`>1", ttlFilter())
+
+	// the archive table can't be dropped or renamed
+	tk.MustGetErrMsg("DROP TABLE archive.t_archive", "[ddl:8267]Table 'archive.t_archive' is the archive table of TTL table 'test.t'")
+	tk.MustGetErrMsg("RENAME TABLE archive.t_archive TO archive.t_archive2", "[ddl:8267]Table 'archive.t_archive' is the archive table of TTL table 'test.t'")
+	tk.MustGetErrMsg("RENAME TABLE archive.t_archive TO test.t_archive", "[ddl:8267]Table 'archive.t_archive' is the archive table of TTL table 'test.t'")
+	tk.MustExec("CREATE TABLE test.t_other (id int)")
+	tk.MustGetErrMsg("RENAME TABLE test.t_other TO test.t_other2, archive.t_archive TO archive.t_archive2", "[ddl:8267]Table 'archive.t_archive' is the archive table of TTL table 'test.t'")
+	tk.MustGetErrMsg("ALTER TABLE archive.t_archive RENAME TO archive.t_archive2", "[ddl:8267]Table 'archive.t_archive' is the archive table of TTL table 'test.t'")
+	tk.MustExec("TRUNCATE TABLE archive.t_archive")
+
+	// it's allowed if the TTL table is dropped together or doesn't archive into it anymore
+	tk.MustExec("DROP TABLE t, archive.t_archive")
+	tk.MustExec("CREATE TABLE archive.t_archive (id int primary key, created_at datetime)")
+	tk.MustExec("CREATE TABLE t (id int primary key, created_at datetime) TTL = `created_at` + INTERVAL 5 DAY TTL_ARCHIVE_TO = archive.t_archive")
+	tk.MustExec("ALTER TABLE t TTL_ARCHIVE_TO = ''")
+	tk.MustExec("RENAME TABLE archive.t_archive TO archive.t_archive2")
+	tk.MustExec("DROP TABLE archive.t_archive2")
+}
+
+func TestTTLJobWindowAndDeleteRate(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	tk.MustExec("CREATE TABLE t (created_at datetime) TTL = `created_at` + INTERVAL 5 DAY TTL_JOB_WINDOW = '22:00 +0800-06:00 +0800' TTL_DELETE_RATE = 100")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `created_at` datetime DEFAULT NULL\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 5 DAY */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */ /*T![ttl] TTL_JOB_WINDOW='22:00 +0800-06:00 +0800' */ /*T![ttl] TTL_DELETE_RATE=100 */"))
+	tk.MustQuery("SELECT create_options FROM information_schema.tables WHERE table_schema = 'test' AND table_name = 't'").
+		Check(testkit.Rows("ttl_job_window='22:00 +0800-06:00 +0800' ttl_delete_rate=100"))
+	tk.MustQuery("SHOW TABLE STATUS LIKE 't'").CheckAt([]int{0, 16}, testkit.RowsWithSep("|", "t|ttl_job_window='22:00 +0800-06:00 +0800' ttl_delete_rate=100"))
+	tk.MustQuery("SELECT table_schema, table_name, partition_name, ttl_enable, ttl_job_interval, ttl_job_window, ttl_delete_rate, current_job_status FROM information_schema.tidb_ttl_table_status").
+		Check(testkit.Rows("test t <nil> 1 1h 22:00 +0800-06:00 +0800 100 <nil>"))
+
+	// redefining TTL keeps the job window and the delete rate
+	tk.MustExec("ALTER TABLE t TTL = `created_at` + INTERVAL 1 DAY TTL_ENABLE = 'OFF'")
+	tk.MustQuery("SELECT ttl_enable, ttl_job_window, ttl_delete_rate FROM information_schema.tidb_ttl_table_status WHERE table_name = 't'").
+		Check(testkit.Rows("0 22:00 +0800-06:00 +0800 100"))
+
+	tk.MustExec("ALTER TABLE t TTL_JOB_WINDOW = '01:00-05:00' TTL_DELETE_RATE = 10")
+	tk.MustQuery("SHOW TABLE STATUS LIKE 't'").CheckAt([]int{0, 16}, testkit.RowsWithSep("|", "t|ttl_job_window='01:00-05:00' ttl_delete_rate=10"))
+	tk.MustExec("ALTER TABLE t TTL_JOB_WINDOW = '' TTL_DELETE_RATE = 0")
+	tk.MustQuery("SHOW CREATE TABLE t").Check(testkit.Rows("t CREATE TABLE `t` (\n  `created_at` datetime DEFAULT NULL\n) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`created_at` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='OFF' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */"))
+	tk.MustQuery("SHOW TABLE STATUS LIKE 't'").CheckAt([]int{0, 16}, testkit.RowsWithSep("|", "t|"))
+	tk.MustQuery("SELECT ttl_job_window, ttl_delete_rate FROM information_schema.tidb_ttl_table_status WHERE table_name = 't'").
+		Check(testkit.Rows("<nil> <nil>"))
+	tk.MustGetErrCode("ALTER TABLE t TTL_JOB_WINDOW = '01:00'", errno.ErrParse)
+
+	// the partitions of a TTL table are shown separately
+	tk.MustExec("CREATE TABLE tp (id int, created_at datetime) TTL = `created_at` + INTERVAL 1 DAY TTL_DELETE_RATE = 5 PARTITION BY HASH(id) PARTITIONS 2")
+	tk.MustQuery("SHOW TABLE STATUS LIKE 'tp'").CheckAt([]int{0, 16}, testkit.RowsWithSep("|", "tp|partitioned ttl_delete_rate=5"))
+	tk.MustQuery("SELECT table_name, partition_name, ttl_delete_rate FROM information_schema.tidb_ttl_table_status WHERE table_name = 'tp' ORDER BY partition_name").
+		Check(testkit.Rows("tp p0 5", "tp p1 5"))
+
+	tk.MustExec("ALTER TABLE t REMOVE TTL")
+	tk.MustQuery("SELECT count(1) FROM information_schema.tidb_ttl_table_status WHERE table_name = 't'").Check(testkit.Rows("0"))
+	tk.MustGetErrMsg("ALTER TABLE t TTL_JOB_WINDOW = '01:00-05:00'", "[ddl:8150]Cannot set TTL_JOB_WINDOW on a table without TTL config")
+	tk.MustGetErrMsg("ALTER TABLE t TTL_DELETE_RATE = 1", "[ddl:8150]Cannot set TTL_DELETE_RATE on a table without TTL config")
+	tk.MustGetErrMsg("CREATE TABLE t2 (id int) TTL_DELETE_RATE = 1", "[ddl:8150]Cannot set TTL_DELETE_RATE on a table without TTL config")
+}
+
 func TestDisableTTLForTempTable(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
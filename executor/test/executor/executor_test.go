@@ -6398,3 +6398,98 @@ func TestProcessInfoOfSubQuery(t *testing.T) {
 	tk2.MustQuery("select 1 from information_schema.processlist where TxnStart != '' and info like 'select%sleep% from t%'").Check(testkit.Rows("1"))
 	wg.Wait()
 }
+
+func TestJSONTable(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery("select * from json_table('[{\"a\": 1, \"b\": \"x\"}, {\"a\": 2}]', '$[*]' columns (id for ordinality, a int path '$.a', b varchar(10) path '$.b')) as jt").
+		Check(testkit.Rows("1 1 x", "2 2 <nil>"))
+	tk.MustQuery("select * from json_table('[{\"a\": 1}, {\"b\": 2}]', '$[*]' columns (a int path '$.a' default '0' on empty, b int exists path '$.b')) as jt").
+		Check(testkit.Rows("1 0", "0 1"))
+	tk.MustQuery("select * from json_table('[{\"a\": [1, 2]}]', '$[*]' columns (a int path '$.a' default '-1' on error, b json path '$.a')) as jt").
+		Check(testkit.Rows("-1 [1, 2]"))
+	tk.MustGetErrCode("select * from json_table('[{}]', '$[*]' columns (a int path '$.a' error on empty)) as jt", errno.ErrMissingJSONTableValue)
+	tk.MustGetErrCode("select * from json_table('[{\"a\": {}}]', '$[*]' columns (a int path '$.a' error on error)) as jt", errno.ErrWrongJSONTableValue)
+
+	// Nested paths.
+	tk.MustQuery("select * from json_table('[{\"a\": 1, \"b\": [1, 2], \"c\": [3]}, {\"a\": 2}]', '$[*]' columns (a int path '$.a', " +
+		"nested path '$.b[*]' columns (b int path '$'), nested path '$.c[*]' columns (c int path '$'))) as jt").
+		Check(testkit.Rows("1 1 <nil>", "1 2 <nil>", "1 <nil> 3", "2 <nil> <nil>"))
+
+	// The JSON document can refer to the preceding tables.
+	tk.MustExec("create table t (id int, doc json)")
+	tk.MustExec("insert into t values (1, '[1, 2]'), (2, '[]'), (3, '[3]'), (4, null)")
+	tk.MustQuery("select t.id, jt.a from t, json_table(t.doc, '$[*]' columns (a int path '$')) as jt order by t.id, jt.a").
+		Check(testkit.Rows("1 1", "1 2", "3 3"))
+	tk.MustQuery("select t.id, jt.a from t left join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on true order by t.id, jt.a").
+		Check(testkit.Rows("1 1", "1 2", "2 <nil>", "3 3", "4 <nil>"))
+	tk.MustQuery("select t.id, jt.a from t join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on jt.a > 1 order by t.id, jt.a").
+		Check(testkit.Rows("1 2", "3 3"))
+	tk.MustGetErrCode("select * from t right join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on true", errno.ErrTFForbiddenJoinType)
+}
+
+func TestSpatial(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustQuery("select st_astext(point(1, 2)), st_x(point(1, 2)), st_y(st_geomfromtext('POINT(3 4)')), st_srid(st_geomfromtext('POINT(3 4)', 4326))").
+		Check(testkit.Rows("POINT(1 2) 1 4 4326"))
+	tk.MustQuery("select st_astext(st_geomfromwkb(st_asbinary(st_geomfromtext('LINESTRING(0 0, 1 1)')))), st_geometrytype(st_geomfromtext('POLYGON((0 0,1 0,1 1,0 0))'))").
+		Check(testkit.Rows("LINESTRING(0 0,1 1) POLYGON"))
+	tk.MustQuery("select st_distance(point(0, 0), point(3, 4)), st_contains(st_geomfromtext('POLYGON((0 0,4 0,4 4,0 4,0 0))'), point(1, 1)), " +
+		"st_within(point(5, 5), st_geomfromtext('POLYGON((0 0,4 0,4 4,0 4,0 0))')), st_intersects(st_geomfromtext('LINESTRING(0 0,2 2)'), st_geomfromtext('LINESTRING(0 2,2 0)'))").
+		Check(testkit.Rows("5 1 0 1"))
+	tk.MustQuery("select st_astext(st_geomfromtext(null)), st_distance(point(0, 0), null)").Check(testkit.Rows("<nil> <nil>"))
+	require.True(t, expression.ErrGISInvalidData.Equal(tk.QueryToErr("select st_geomfromtext('POINT(1)')")))
+	require.True(t, expression.ErrGISDifferentSRIDs.Equal(tk.QueryToErr("select st_distance(point(0, 0), st_geomfromtext('POINT(1 1)', 4326))")))
+	require.True(t, expression.ErrGISInvalidData.Equal(tk.QueryToErr("select st_x(st_geomfromtext('LINESTRING(0 0, 1 1)'))")))
+
+	tk.MustExec("create table t (id int primary key, g geometry not null, p point, spatial index sp(g))")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `g` geometry NOT NULL,\n" +
+		"  `p` point DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */,\n" +
+		"  SPATIAL KEY `sp` (`g`)\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("show index from t where key_name = 'sp'").CheckAt([]int{2, 4, 10}, testkit.Rows("sp g SPATIAL"))
+	tk.MustExec("insert into t values (1, st_geomfromtext('POINT(1 1)'), point(1, 1)), (2, st_geomfromtext('POINT(5 5)'), null), " +
+		"(3, st_geomfromtext('LINESTRING(0 0,10 10)'), null), (4, st_geomfromtext('POLYGON((20 20,30 20,30 30,20 30,20 20))'), point(25, 25))")
+	tk.MustGetErrCode("insert into t values (5, point(1, 1), st_geomfromtext('LINESTRING(0 0,1 1)'))", errno.ErrCantCreateGeometryObject)
+	tk.MustGetErrCode("insert into t values (5, 'abc', null)", errno.ErrCantCreateGeometryObject)
+	tk.MustQuery("select id, st_astext(g), st_astext(p) from t where id = 1").Check(testkit.Rows("1 POINT(1 1) POINT(1 1)"))
+
+	for _, hint := range []string{"ignore index(sp)", "use index(sp)"} {
+		tk.MustQuery("select id from t " + hint + " where st_contains(st_geomfromtext('POLYGON((0 0,6 0,6 6,0 6,0 0))'), g) order by id").
+			Check(testkit.Rows("1", "2"))
+		tk.MustQuery("select id from t " + hint + " where st_intersects(g, st_geomfromtext('LINESTRING(0 2,2 0)')) order by id").
+			Check(testkit.Rows("1", "3"))
+		tk.MustQuery("select id from t " + hint + " where st_distance(g, point(26, 35)) <= 5 order by id").
+			Check(testkit.Rows("4"))
+		tk.MustQuery("select id from t " + hint + " where 2 > st_distance(point(6, 6), g) order by id").
+			Check(testkit.Rows("2", "3"))
+	}
+	require.Contains(t, tk.MustQuery("explain select id from t use index(sp) where st_intersects(g, point(1, 1))").Rows()[3][4], "spatial window:")
+	tk.MustExec("update t set g = st_geomfromtext('POINT(100 100)') where id = 1")
+	tk.MustExec("delete from t where id = 2")
+	tk.MustQuery("select id from t use index(sp) where st_distance(g, point(100, 100)) < 1").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from t use index(sp) where st_within(g, st_geomfromtext('POLYGON((0 0,6 0,6 6,0 6,0 0))'))").Check(testkit.Rows())
+	// Only the rows whose MBR intersects the window are looked up.
+	rows := tk.MustQuery("explain analyze select id from t use index(sp) where st_intersects(g, point(100, 100))").Rows()
+	require.Equal(t, "1", rows[4][2])
+	tk.MustExec("admin check table t")
+
+	tk.MustExec("create table t1 (g geometry not null)")
+	tk.MustExec("insert into t1 values (point(1, 1)), (point(2, 2))")
+	tk.MustExec("alter table t1 add spatial index sp(g)")
+	tk.MustQuery("select st_astext(g) from t1 use index(sp) where st_intersects(g, point(2, 2))").Check(testkit.Rows("POINT(2 2)"))
+	tk.MustExec("alter table t1 drop index sp")
+	tk.MustExec("create spatial index sp on t1(g)")
+	tk.MustGetErrCode("create table t2 (g geometry, spatial index(g))", errno.ErrSpatialCantHaveNull)
+	tk.MustGetErrCode("create table t2 (a int not null, spatial index(a))", errno.ErrSpatialMustHaveGeomCol)
+	tk.MustGetErrCode("create table t2 (g geometry not null, h geometry not null, spatial index(g, h))", errno.ErrTooManyKeyParts)
+	tk.MustGetErrCode("create table t2 (g geometry not null, spatial index(g(10)))", errno.ErrWrongSubKey)
+	tk.MustGetErrCode("create table t2 (g geometry not null, key(g))", errno.ErrBlobKeyWithoutLength)
+	tk.MustGetErrCode("create table t2 (g geometry not null default 'abc')", errno.ErrBlobCantHaveDefault)
+}
@@ -2186,3 +2186,94 @@ func TestOuterJoin(t *testing.T) {
 		),
 	)
 }
+
+func TestFullOuterJoin(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t1, t2")
+	tk.MustExec("create table t1(a int, b int)")
+	tk.MustExec("create table t2(a int, b int)")
+	tk.MustExec("insert into t1 values (1, 1), (2, 2), (2, 22), (3, 3), (null, 100)")
+	tk.MustExec("insert into t2 values (2, 20), (3, 30), (3, 33), (4, 40), (null, 200)")
+	tk.MustExec("set @@tidb_init_chunk_size=1")
+
+	result := testkit.Rows(
+		"<nil> <nil> 4 40",
+		"<nil> <nil> <nil> 200",
+		"1 1 <nil> <nil>",
+		"2 2 2 20",
+		"3 3 3 30",
+		"3 3 3 33",
+		"2 22 2 20",
+		"<nil> 100 <nil> <nil>",
+	)
+	for _, hint := range []string{"hash_join_build(t1)", "hash_join_build(t2)", "merge_join(t1, t2)"} {
+		sql := fmt.Sprintf("select /*+ %s */ * from t1 full join t2 on t1.a = t2.a", hint)
+		if strings.HasPrefix(hint, "merge_join") {
+			require.True(t, tk.HasPlan(sql, "MergeJoin"))
+		} else {
+			require.True(t, tk.HasPlan(sql, "HashJoin"))
+		}
+		require.True(t, tk.HasKeywordInOperatorInfo(sql, "full outer join"))
+		tk.MustQuery(sql + " order by t1.b, t2.b").Check(result)
+		tk.MustQuery(fmt.Sprintf("select /*+ %s */ * from t1 full outer join t2 on t1.a = t2.a where t1.a is null order by t1.b, t2.b", hint)).Check(testkit.Rows(
+			"<nil> <nil> 4 40",
+			"<nil> <nil> <nil> 200",
+			"<nil> 100 <nil> <nil>",
+		))
+	}
+
+	// The other conditions are evaluated by the hash join.
+	for _, hint := range []string{"hash_join_build(t1)", "hash_join_build(t2)"} {
+		tk.MustQuery(fmt.Sprintf("select /*+ %s */ * from t1 full join t2 on t1.a = t2.a and t1.b + 20 > t2.b order by t1.b, t2.b", hint)).Check(testkit.Rows(
+			"<nil> <nil> 3 30",
+			"<nil> <nil> 3 33",
+			"<nil> <nil> 4 40",
+			"<nil> <nil> <nil> 200",
+			"1 1 <nil> <nil>",
+			"2 2 2 20",
+			"3 3 <nil> <nil>",
+			"2 22 2 20",
+			"<nil> 100 <nil> <nil>",
+		))
+	}
+	tk.MustQuery("select * from t1 full join t2 on t1.a > 3 order by t1.b, t2.b").Check(testkit.Rows(
+		"<nil> <nil> 2 20",
+		"<nil> <nil> 3 30",
+		"<nil> <nil> 3 33",
+		"<nil> <nil> 4 40",
+		"<nil> <nil> <nil> 200",
+		"1 1 <nil> <nil>",
+		"2 2 <nil> <nil>",
+		"3 3 <nil> <nil>",
+		"2 22 <nil> <nil>",
+		"<nil> 100 <nil> <nil>",
+	))
+	tk.MustQuery("select count(*) from t1 full join t2 on false").Check(testkit.Rows("10"))
+
+	// The full outer join is simplified when the where conditions reject the null-extended rows.
+	sql := "select * from t1 full join t2 on t1.a = t2.a where t1.b > 1"
+	require.True(t, tk.HasKeywordInOperatorInfo(sql, "left outer join"))
+	tk.MustQuery(sql + " order by t1.b, t2.b").Check(testkit.Rows(
+		"2 2 2 20",
+		"3 3 3 30",
+		"3 3 3 33",
+		"2 22 2 20",
+		"<nil> 100 <nil> <nil>",
+	))
+	sql = "select * from t1 full join t2 on t1.a = t2.a where t2.b < 100"
+	require.True(t, tk.HasKeywordInOperatorInfo(sql, "right outer join"))
+	tk.MustQuery(sql + " order by t1.b, t2.b").Check(testkit.Rows(
+		"<nil> <nil> 4 40",
+		"2 2 2 20",
+		"3 3 3 30",
+		"3 3 3 33",
+		"2 22 2 20",
+	))
+	sql = "select * from t1 full join t2 on t1.a = t2.a where t1.b > 1 and t2.b < 100"
+	require.True(t, tk.HasKeywordInOperatorInfo(sql, "inner join"))
+	require.True(t, tk.NotHasKeywordInOperatorInfo(sql, "outer join"))
+
+	tk.MustGetErrMsg("select * from t1 full join t2 using (a)", "[planner:1235]This version of TiDB doesn't yet support 'NATURAL or USING clause with FULL JOIN'")
+}
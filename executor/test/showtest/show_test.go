@@ -1526,7 +1526,7 @@ func TestShowBuiltin(t *testing.T) {
 	res := tk.MustQuery("show builtins;")
 	require.NotNil(t, res)
 	rows := res.Rows()
-	const builtinFuncNum = 290
+	const builtinFuncNum = 308
 	require.Equal(t, builtinFuncNum, len(rows))
 	require.Equal(t, rows[0][0].(string), "abs")
 	require.Equal(t, rows[builtinFuncNum-1][0].(string), "yearweek")
@@ -2067,7 +2067,7 @@ func TestShowBindingDigestField(t *testing.T) {
 	tk.MustExec("create binding for select * from t1, t2 where t1.id = t2.id using select /*+ merge_join(t1, t2)*/ * from t1, t2 where t1.id = t2.id")
 	result := tk.MustQuery("show bindings;")
 	rows := result.Rows()[0]
-	require.Equal(t, len(rows), 11)
+	require.Equal(t, len(rows), 12)
 	require.Equal(t, rows[9], "ac1ceb4eb5c01f7c03e29b7d0d6ab567e563f4c93164184cde218f20d07fd77c")
 	tk.MustExec("drop binding for select * from t1, t2 where t1.id = t2.id")
 	result = tk.MustQuery("show bindings;")
@@ -2076,7 +2076,7 @@ func TestShowBindingDigestField(t *testing.T) {
 	tk.MustExec("create global binding for select * from t1, t2 where t1.id = t2.id using select /*+ merge_join(t1, t2)*/ * from t1, t2 where t1.id = t2.id")
 	result = tk.MustQuery("show global bindings;")
 	rows = result.Rows()[0]
-	require.Equal(t, len(rows), 11)
+	require.Equal(t, len(rows), 12)
 	require.Equal(t, rows[9], "ac1ceb4eb5c01f7c03e29b7d0d6ab567e563f4c93164184cde218f20d07fd77c")
 	tk.MustExec("drop global binding for select * from t1, t2 where t1.id = t2.id")
 	result = tk.MustQuery("show global bindings;")
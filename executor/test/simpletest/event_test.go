@@ -0,0 +1,114 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAlterDropEvent(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set @@time_zone = '+00:00'")
+	tk.MustExec("create table t (a int)")
+
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2037-01-01 00:00:00' comment 'hourly' do insert into t values (1)")
+	tk.MustQuery("show events").Check(testkit.Rows(
+		"test e1 +00:00 root@% RECURRING <nil> 1 HOUR 2037-01-01 00:00:00 <nil> ENABLED 0 utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	tk.MustQuery("show create event e1").Check(testkit.Rows(
+		"e1 ONLY_FULL_GROUP_BY,STRICT_TRANS_TABLES,NO_ZERO_IN_DATE,NO_ZERO_DATE,ERROR_FOR_DIVISION_BY_ZERO,NO_AUTO_CREATE_USER,NO_ENGINE_SUBSTITUTION +00:00 " +
+			"CREATE DEFINER=`root`@`%` EVENT `e1` ON SCHEDULE EVERY 1 HOUR STARTS '2037-01-01 00:00:00' ON COMPLETION NOT PRESERVE ENABLE COMMENT 'hourly' DO insert into t values (1) " +
+			"utf8mb4 utf8mb4_bin utf8mb4_bin"))
+	tk.MustGetErrCode("create event e1 on schedule every 1 day do select 1", errno.ErrEventAlreadyExists)
+	tk.MustExec("create event if not exists e1 on schedule every 1 day do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1537 Event 'e1' already exists"))
+
+	tk.MustGetErrCode("create event e2 on schedule every 0 hour do select 1", errno.ErrEventIntervalNotPositiveOrTooBig)
+	tk.MustGetErrCode("create event e2 on schedule every 1 hour starts '2037-01-01' ends '2036-01-01' do select 1", errno.ErrEventEndsBeforeStarts)
+	tk.MustGetErrCode("create event e2 on schedule every 1 month do select 1", errno.ErrNotSupportedYet)
+	tk.MustExec("create event e2 on schedule at '2000-01-01 00:00:00' do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1588 Event execution time is in the past and ON COMPLETION NOT PRESERVE is set. The event was dropped immediately after creation."))
+	tk.MustExec("create event e2 on schedule at '2000-01-01 00:00:00' on completion preserve do select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1544 Event execution time is in the past. Event has been disabled"))
+	tk.MustQuery("select event_name, event_type, execute_at, status, on_completion from information_schema.events where event_schema = 'test' order by event_name").Check(testkit.Rows(
+		"e1 RECURRING <nil> ENABLED NOT PRESERVE",
+		"e2 ONE TIME 2000-01-01 00:00:00 DISABLED PRESERVE"))
+
+	tk.MustExec("alter event e1 on completion preserve disable comment 'off'")
+	tk.MustQuery("select status, on_completion, event_comment from information_schema.events where event_name = 'e1'").Check(testkit.Rows("DISABLED PRESERVE off"))
+	tk.MustGetErrCode("alter event e1 rename to e1", errno.ErrEventSameName)
+	tk.MustGetErrCode("alter event e3 enable", errno.ErrEventDoesNotExist)
+	tk.MustExec("create database test2")
+	tk.MustExec("alter event e1 rename to test2.e3")
+	require.ErrorContains(t, tk.QueryToErr("show create event e1"), "Unknown event 'e1'")
+	tk.MustQuery("select event_schema, event_name, status from information_schema.events order by event_name").Check(testkit.Rows(
+		"test e2 DISABLED", "test2 e3 DISABLED"))
+
+	tk.MustExec("drop event e2")
+	tk.MustGetErrCode("drop event e2", errno.ErrEventDoesNotExist)
+	tk.MustExec("drop event if exists e2")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1539 Unknown event 'e2'"))
+	// The events are dropped with the database.
+	tk.MustExec("drop database test2")
+	tk.MustQuery("select count(*) from information_schema.events").Check(testkit.Rows("0"))
+
+	// The events are only visible to the users with the EVENT privilege on the schema.
+	tk.MustExec("create event e1 on schedule every 1 hour starts '2037-01-01 00:00:00' do select 1")
+	tk.MustExec("create user u1")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustQuery("select count(*) from information_schema.events").Check(testkit.Rows("0"))
+	tk1.MustGetErrCode("create event test.e2 on schedule every 1 hour do select 1", errno.ErrDBaccessDenied)
+	tk.MustExec("grant event on test.* to u1")
+	tk1.MustQuery("select event_name, definer from information_schema.events").Check(testkit.Rows("e1 root@%"))
+	tk1.MustGetErrCode("create definer = root event test.e2 on schedule every 1 hour do select 1", errno.ErrSpecificAccessDenied)
+}
+
+func TestEventExecution(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create event e1 on schedule every 1 second do insert into t values (1)")
+	tk.MustExec("create event e2 on schedule at now() + interval 1 second on completion preserve do insert into t values (2)")
+	tk.MustExec("create event e3 on schedule every 1 second do insert into no_such_table values (1)")
+
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select 1 from t where a = 1 having count(*) >= 2").Rows()) > 0 &&
+			len(tk.MustQuery("select 1 from t where a = 2").Rows()) > 0
+	}, 30*time.Second, 500*time.Millisecond)
+	tk.MustExec("drop event e1")
+	// The one-time event is disabled after it's executed because it's preserved.
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select 1 from information_schema.events where event_name = 'e2' and status = 'DISABLED' and last_executed is not null").Rows()) > 0
+	}, 30*time.Second, 500*time.Millisecond)
+	tk.MustQuery("select count(*) from t where a = 2").Check(testkit.Rows("1"))
+
+	// The results of the executions are recorded in the history.
+	require.Eventually(t, func() bool {
+		return len(tk.MustQuery("select 1 from information_schema.tidb_event_history where event_name = 'e3' and status = 'failed' and error_message like '%no_such_table%'").Rows()) > 0
+	}, 30*time.Second, 500*time.Millisecond)
+	tk.MustQuery("select definer, status from information_schema.tidb_event_history where event_name = 'e2'").Check(testkit.Rows("root@% success"))
+	tk.MustExec("drop event e3")
+}
@@ -0,0 +1,132 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/testkit"
+)
+
+func TestMaterializedViewCompleteRefresh(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (1, 2), (2, 3)")
+	tk.MustExec("create materialized view mv (k, s) as select a, sum(b) from t group by a")
+	tk.MustQuery("select * from mv order by k").Check(testkit.Rows("1 3", "2 3"))
+	tk.MustGetErrCode("create materialized view mv as select 1", errno.ErrTableExists)
+	tk.MustExec("create materialized view if not exists mv as select 1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1050 Table 'test.mv' already exists"))
+
+	// The view is not changed until it's refreshed.
+	tk.MustExec("insert into t values (3, 4)")
+	tk.MustQuery("select * from mv order by k").Check(testkit.Rows("1 3", "2 3"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv order by k").Check(testkit.Rows("1 3", "2 3", "3 4"))
+	tk.MustGetErrCode("refresh materialized view mv fast", errno.ErrMViewFastRefreshUnsupported)
+
+	// The table of the view can't be dropped or truncated as a table.
+	tk.MustGetErrCode("drop table mv", errno.ErrWrongObject)
+	tk.MustGetErrCode("truncate table mv", errno.ErrWrongObject)
+	tk.MustGetErrCode("drop materialized view t", errno.ErrWrongObject)
+	tk.MustGetErrCode("refresh materialized view t", errno.ErrWrongObject)
+	tk.MustExec("drop materialized view mv")
+	tk.MustGetErrCode("select * from mv", errno.ErrNoSuchTable)
+	tk.MustGetErrCode("drop materialized view mv", errno.ErrBadTable)
+	tk.MustExec("drop materialized view if exists mv")
+
+	tk.MustGetErrCode("create materialized view mv refresh complete every 1 month as select 1", errno.ErrUnsupportedDDLOperation)
+	tk.MustExec("create materialized view mv refresh complete every 1 hour as select count(*) c from t")
+	tk.MustQuery("select * from mv").Check(testkit.Rows("4"))
+}
+
+func TestMaterializedViewFastRefresh(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b int)")
+	tk.MustExec("insert into t values (1, 1, 10), (2, 1, 20), (3, 2, 30)")
+	tk.MustGetErrCode("create materialized view mv refresh fast as select a, sum(b) from t group by a", errno.ErrMViewFastRefreshUnsupported)
+	tk.MustGetErrCode("create materialized view mv refresh fast as select a, count(*) from t", errno.ErrMViewFastRefreshUnsupported)
+	tk.MustGetErrCode("create materialized view mv refresh fast as select a, avg(b), count(*) from t group by a", errno.ErrMViewFastRefreshUnsupported)
+	tk.MustExec("create materialized view mv refresh fast as " +
+		"select a, count(*) cnt, sum(b) s, count(b) cb, min(b) mi, max(b) ma from t group by a")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 30 2 10 20", "2 1 30 1 30 30"))
+
+	// The changes of the base table are recorded in the log table.
+	tk.MustExec("insert into t values (4, 2, 5), (5, 3, 50)")
+	tk.MustExec("update t set b = 25 where id = 2")
+	tk.MustExec("delete from t where id = 1")
+	tk.MustQuery("select count(*) from mlog$_mv").Check(testkit.Rows("5"))
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 30 2 10 20", "2 1 30 1 30 30"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 1 25 1 25 25", "2 2 35 2 5 30", "3 1 50 1 50 50"))
+	tk.MustQuery("select count(*) from mlog$_mv").Check(testkit.Rows("0"))
+
+	// The groups without rows are removed, and the changes in a rolled back transaction are not recorded.
+	tk.MustExec("delete from t where a = 3")
+	tk.MustExec("begin")
+	tk.MustExec("insert into t values (6, 4, 1)")
+	tk.MustExec("rollback")
+	tk.MustExec("replace into t values (3, 2, null)")
+	tk.MustExec("refresh materialized view mv fast")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 1 25 1 25 25", "2 2 5 1 5 5"))
+	tk.MustExec("refresh materialized view mv complete")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 1 25 1 25 25", "2 2 5 1 5 5"))
+
+	// A base table can have multiple log tables, the qualified columns and the conditions are supported.
+	tk.MustExec("create materialized view mv2 refresh fast as select x.a, count(*) c, max(x.b) m from t x where x.b > 5 group by x.a")
+	tk.MustExec("insert into t values (6, 1, 30), (7, 1, 1)")
+	tk.MustExec("delete from t where id = 2")
+	tk.MustExec("refresh materialized view mv2")
+	tk.MustQuery("select * from mv2 order by a").Check(testkit.Rows("1 1 30"))
+	tk.MustExec("refresh materialized view mv")
+	tk.MustQuery("select * from mv order by a").Check(testkit.Rows("1 2 31 2 1 30", "2 2 5 1 5 5"))
+	tk.MustExec("drop materialized view mv2")
+
+	// The base table and the log table can't be dropped or truncated while the view exists.
+	tk.MustGetErrCode("drop table t", errno.ErrMViewBaseTableInUse)
+	tk.MustGetErrCode("truncate table t", errno.ErrMViewBaseTableInUse)
+	tk.MustGetErrCode("drop table mlog$_mv", errno.ErrWrongObject)
+	tk.MustExec("drop materialized view mv")
+	tk.MustGetErrCode("select * from mlog$_mv", errno.ErrNoSuchTable)
+	tk.MustExec("insert into t values (8, 8, 8)")
+	tk.MustExec("drop table t")
+}
+
+func TestMaterializedViewRefreshQuoting(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table `t``1` (id int primary key, `a``b` varchar(10))")
+	tk.MustExec("insert into `t``1` values (1, 'x\\\\y'), (2, 'x\\\\y'), (3, 'z')")
+	tk.MustExec("create materialized view `mv``1` refresh fast as select `a``b`, count(*) c from `t``1` where `a``b` = 'x\\\\y' group by `a``b`")
+	tk.MustQuery("select * from `mv``1`").Check(testkit.Rows("x\\y 2"))
+	tk.MustExec("insert into `t``1` values (4, 'x\\\\y'), (5, 'x\\\\z')")
+	tk.MustExec("refresh materialized view `mv``1` fast")
+	tk.MustQuery("select * from `mv``1`").Check(testkit.Rows("x\\y 3"))
+	tk.MustExec("refresh materialized view `mv``1` complete")
+	tk.MustQuery("select * from `mv``1`").Check(testkit.Rows("x\\y 3"))
+
+	// The query of a view created with NO_BACKSLASH_ESCAPES is kept as is.
+	tk.MustExec("set @@sql_mode = concat(@@sql_mode, ',NO_BACKSLASH_ESCAPES')")
+	tk.MustExec("create materialized view mv2 as select count(*) c from `t``1` where `a``b` = 'x\\z'")
+	tk.MustQuery("select * from mv2").Check(testkit.Rows("1"))
+	tk.MustExec("refresh materialized view mv2")
+	tk.MustQuery("select * from mv2").Check(testkit.Rows("1"))
+}
@@ -0,0 +1,293 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropCallProcedure(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create procedure p1(in x int, out y int) begin insert into t values (x); select count(*) into y from t; end")
+	tk.MustGetErrCode("create procedure p1() begin end", errno.ErrSpAlreadyExists)
+	tk.MustExec("create procedure if not exists p1() begin end")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1304 PROCEDURE p1 already exists"))
+	tk.MustGetErrCode("create procedure nodb.p1() begin end", errno.ErrBadDB)
+
+	tk.MustExec("call p1(10, @y)")
+	tk.MustExec("call test.p1(20, @y)")
+	tk.MustQuery("select @y").Check(testkit.Rows("2"))
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("10", "20"))
+	tk.MustGetErrCode("call p1(1)", errno.ErrSpWrongNoOfArgs)
+	tk.MustGetErrCode("call p1(1, 2)", errno.ErrSpNotVarArg)
+	tk.MustGetErrCode("call p2()", errno.ErrSpDoesNotExist)
+
+	tk.MustQuery("select routine_schema, routine_name, routine_type, routine_definition from information_schema.routines").
+		Check(testkit.Rows("test p1 PROCEDURE begin insert into t values (x); select count(*) into y from t; end"))
+	tk.MustQuery("show procedure status like 'p%'").CheckAt([]int{0, 1, 2, 3}, testkit.Rows("test p1 PROCEDURE root@%"))
+	rows := tk.MustQuery("show create procedure p1").Rows()
+	require.Equal(t, "p1", rows[0][0])
+	require.Equal(t, "CREATE DEFINER=`root`@`%` PROCEDURE `p1`(in x int, out y int)\n    SQL SECURITY INVOKER\nbegin insert into t values (x); select count(*) into y from t; end", rows[0][2])
+
+	// The procedures are executed with the privileges of the invoker.
+	tk.MustQuery("select security_type from information_schema.routines where routine_name = 'p1'").Check(testkit.Rows("INVOKER"))
+	tk.MustQuery("show procedure status like 'p1'").CheckAt([]int{6}, testkit.Rows("INVOKER"))
+	tk.MustExec("create user u1")
+	tk.MustExec("grant select, execute on test.* to u1")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	tk1.MustGetErrCode("call p1(30, @y)", errno.ErrTableaccessDenied)
+	tk.MustExec("grant insert on test.t to u1")
+	tk1.MustExec("call p1(30, @y)")
+	tk1.MustQuery("select @y").Check(testkit.Rows("3"))
+
+	tk.MustExec("drop procedure p1")
+	tk.MustGetErrCode("drop procedure p1", errno.ErrSpDoesNotExist)
+	tk.MustExec("drop procedure if exists p1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1305 PROCEDURE test.p1 does not exist"))
+	tk.MustQuery("select count(*) from information_schema.routines").Check(testkit.Rows("0"))
+
+	// The procedures are dropped with the database.
+	tk.MustExec("create database db1")
+	tk.MustExec("create procedure db1.p() begin end")
+	tk.MustQuery("select count(*) from information_schema.routines").Check(testkit.Rows("1"))
+	tk.MustExec("drop database db1")
+	tk.MustQuery("select count(*) from information_schema.routines").Check(testkit.Rows("0"))
+
+	// The definition is checked when the procedure is created.
+	tk.MustGetErrCode("create procedure p(a int, a int) begin end", errno.ErrSpDupParam)
+	tk.MustGetErrCode("create procedure p() begin declare a int; declare a int; end", errno.ErrSpDupVar)
+	tk.MustGetErrCode("create procedure p() begin declare c cursor for select 1; declare c cursor for select 1; end", errno.ErrSpDupCurs)
+	tk.MustGetErrCode("create procedure p() begin declare c cursor for select 1; declare a int; end", errno.ErrSpVarcondAfterCurshndlr)
+	tk.MustGetErrCode("create procedure p() begin declare continue handler for sqlexception begin end; declare c cursor for select 1; end", errno.ErrSpCursorAfterHandler)
+	tk.MustGetErrCode("create procedure p() begin declare continue handler for sqlstate '00000' begin end; end", errno.ErrSpBadSQLstate)
+	tk.MustGetErrCode("create procedure p() begin leave l; end", errno.ErrSpLilabelMismatch)
+	tk.MustGetErrCode("create procedure p() l: begin iterate l; end l", errno.ErrSpLilabelMismatch)
+	tk.MustGetErrCode("create procedure p() begin open c; end", errno.ErrSpCursorMismatch)
+	tk.MustGetErrCode("create procedure p() begin declare c cursor for select 1; open c; fetch c into a; end", errno.ErrSpUndeclaredVar)
+	tk.MustGetErrCode("create procedure p() begin select 1 into a; end", errno.ErrSpUndeclaredVar)
+
+	// Recursive calls are not allowed.
+	tk.MustExec("create procedure p() begin call p(); end")
+	tk.MustGetErrCode("call p()", errno.ErrSpRecursionLimit)
+}
+
+func TestProcedureCharacteristics(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("create definer = 'root'@'%' procedure p1() comment 'it''s p1' language sql deterministic reads sql data sql security invoker select count(*) into @c from t")
+	tk.MustQuery("select is_deterministic, sql_data_access, security_type, routine_comment from information_schema.routines where routine_name = 'p1'").
+		Check(testkit.RowsWithSep("|", "YES|READS SQL DATA|INVOKER|it's p1"))
+	tk.MustQuery("show procedure status like 'p1'").CheckAt([]int{3, 6, 7}, testkit.RowsWithSep("|", "root@%|INVOKER|it's p1"))
+	createSQL := "CREATE DEFINER=`root`@`%` PROCEDURE `p1`()\n    READS SQL DATA\n    DETERMINISTIC\n    SQL SECURITY INVOKER\n    COMMENT 'it''s p1'\nselect count(*) into @c from t"
+	require.Equal(t, createSQL, tk.MustQuery("show create procedure p1").Rows()[0][2])
+	// The output of SHOW CREATE PROCEDURE can be executed to create the same procedure.
+	tk.MustExec("drop procedure p1")
+	tk.MustExec(createSQL)
+	require.Equal(t, createSQL, tk.MustQuery("show create procedure p1").Rows()[0][2])
+	tk.MustExec("call p1()")
+	tk.MustQuery("select @c").Check(testkit.Rows("0"))
+
+	// The later characteristics override the earlier ones, and the procedures are SQL SECURITY INVOKER by default.
+	tk.MustExec("create procedure p2() not deterministic deterministic modifies sql data contains sql comment 'a' comment 'b' select 1")
+	tk.MustQuery("select is_deterministic, sql_data_access, security_type, routine_comment from information_schema.routines where routine_name = 'p2'").
+		Check(testkit.RowsWithSep("|", "YES|CONTAINS SQL|INVOKER|b"))
+
+	// SQL SECURITY DEFINER is not supported.
+	tk.MustGetErrCode("create procedure p3() sql security definer select 1", errno.ErrNotSupportedYet)
+	tk.MustGetErrCode("create procedure p3() sql security invoker sql security definer select 1", errno.ErrNotSupportedYet)
+
+	// The SUPER privilege is required to create the procedures of other users.
+	tk.MustExec("create user u1")
+	tk.MustExec("grant create routine on test.* to u1")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "u1", Hostname: "%"}, nil, nil, nil))
+	tk1.MustExec("use test")
+	tk1.MustGetErrCode("create definer = 'root'@'%' procedure p3() select 1", errno.ErrSpecificAccessDenied)
+	tk1.MustExec("create definer = current_user procedure p3() select 1")
+	tk.MustQuery("select definer from information_schema.routines where routine_name = 'p3'").Check(testkit.Rows("u1@%"))
+}
+
+func TestCallProcedureRestoresSession(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec("set sql_mode = ''")
+	tk.MustExec("create procedure p() begin insert into t values ('x'); insert into t_not_exists values (1); end")
+	tk.MustExec("set sql_mode = default")
+	tk.MustExec("create database db1")
+	tk.MustExec("use db1")
+	sqlMode := tk.MustQuery("select @@sql_mode").Rows()[0][0]
+
+	// The procedure runs in its own database and SQL mode, they are restored when a statement of it fails.
+	tk.MustGetErrCode("call test.p()", errno.ErrNoSuchTable)
+	tk.MustQuery("select a from test.t").Check(testkit.Rows("0"))
+	tk.MustQuery("select database(), @@sql_mode").Check(testkit.Rows("db1 " + sqlMode.(string)))
+	tk.MustGetErrCode("insert into test.t values ('x')", errno.ErrTruncatedWrongValueForField)
+}
+
+func TestProcedureControlFlow(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int)")
+	tk.MustExec(`create procedure p(n int)
+begin
+	declare i int default 0;
+	declare s varchar(20) default '';
+	while i < n do
+		set i = i + 1;
+		if i % 3 = 0 then
+			insert into t values (i * 10);
+		elseif i % 3 = 1 then
+			insert into t values (i);
+		else
+			set s = concat(s, i);
+		end if;
+	end while;
+	l: loop
+		set i = i - 1;
+		if i > 2 then
+			iterate l;
+		end if;
+		leave l;
+	end loop l;
+	repeat
+		set i = i - 1;
+	until i <= 0 end repeat;
+	case i when 0 then set @r = concat(s, '-zero'); else set @r = 'other'; end case;
+	case when n > 5 then set @m = 'big'; else set @m = 'small'; end case;
+end`)
+	tk.MustExec("call p(7)")
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("1", "4", "7", "30", "60"))
+	tk.MustQuery("select @r, @m").Check(testkit.Rows("25-zero big"))
+
+	tk.MustExec("create procedure p2(x int) begin case x when 1 then set @r = 1; end case; end")
+	tk.MustExec("call p2(1)")
+	tk.MustGetErrCode("call p2(2)", errno.ErrSpCaseNotFound)
+
+	// The local variables shadow the columns with the same names.
+	tk.MustExec("create procedure p3(x int) begin declare b int default x + 1; insert into t select b from dual; set @b = (select max(a) from t); end")
+	tk.MustExec("call p3(99)")
+	tk.MustQuery("select @b").Check(testkit.Rows("100"))
+}
+
+func TestProcedureCursorAndHandler(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table src (id int, v varchar(10))")
+	tk.MustExec("create table dst (id int primary key, v varchar(10))")
+	tk.MustExec("insert into src values (1, 'a'), (2, 'b'), (3, 'c')")
+	tk.MustExec(`create procedure copy_rows()
+begin
+	declare done int default 0;
+	declare cid int;
+	declare cv varchar(10);
+	declare cur cursor for select id, v from src order by id;
+	declare continue handler for not found set done = 1;
+	open cur;
+	read_loop: loop
+		fetch cur into cid, cv;
+		if done then
+			leave read_loop;
+		end if;
+		insert into dst values (cid, cv);
+	end loop;
+	close cur;
+end`)
+	tk.MustExec("call copy_rows()")
+	tk.MustQuery("select * from dst order by id").Check(testkit.Rows("1 a", "2 b", "3 c"))
+
+	// Duplicate keys are handled by the handlers.
+	tk.MustExec(`create procedure dup()
+begin
+	declare continue handler for 1062 set @dup = @dup + 1;
+	set @dup = 0;
+	insert into dst values (1, 'x');
+	insert into dst values (4, 'd');
+	insert into dst values (2, 'x');
+end`)
+	tk.MustExec("call dup()")
+	tk.MustQuery("select @dup").Check(testkit.Rows("2"))
+	tk.MustQuery("select count(*) from dst").Check(testkit.Rows("4"))
+
+	tk.MustExec(`create procedure exit_handler()
+begin
+	declare exit handler for sqlexception set @step = concat(@step, '-handled');
+	set @step = 'start';
+	insert into no_such_table values (1);
+	set @step = 'unreachable';
+end`)
+	tk.MustExec("call exit_handler()")
+	tk.MustQuery("select @step").Check(testkit.Rows("start-handled"))
+
+	// The unhandled errors are returned to the client.
+	tk.MustExec("create procedure no_handler() begin insert into dst values (1, 'x'); end")
+	tk.MustGetErrCode("call no_handler()", errno.ErrDupEntry)
+
+	tk.MustExec(`create procedure bad_cursor2() begin declare a int; declare c cursor for select 1; fetch c into a; end`)
+	tk.MustGetErrCode("call bad_cursor2()", errno.ErrSpCursorNotOpen)
+	tk.MustExec(`create procedure bad_cursor3() begin declare a int; declare c cursor for select 1; open c; open c; end`)
+	tk.MustGetErrCode("call bad_cursor3()", errno.ErrSpCursorAlreadyOpen)
+	tk.MustExec(`create procedure bad_cursor4() begin declare a int; declare c cursor for select 1, 2; open c; fetch c into a; end`)
+	tk.MustGetErrCode("call bad_cursor4()", errno.ErrSpWrongNoOfFetchArgs)
+	tk.MustExec(`create procedure no_data() begin declare a int; declare c cursor for select 1 from dual where false; open c; fetch c into a; end`)
+	tk.MustGetErrCode("call no_data()", errno.ErrSpFetchNoData)
+}
+
+func TestSelectIntoVariables(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b varchar(10))")
+	tk.MustExec("insert into t values (1, 'x'), (2, 'y')")
+
+	tk.MustExec("select a, b into @a, @b from t where a = 2")
+	tk.MustQuery("select @a, @b").Check(testkit.Rows("2 y"))
+	tk.MustExec("select a into @a from t where a > 10")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1329 No data - zero rows fetched, selected, or processed"))
+	tk.MustQuery("select @a").Check(testkit.Rows("2"))
+	tk.MustGetErrCode("select a into @a from t", errno.ErrTooManyRows)
+	tk.MustGetErrCode("select a, b into @a from t", errno.ErrWrongNumberOfColumnsInSelect)
+	tk.MustGetErrCode("select a into x from t where a = 1", errno.ErrSpUndeclaredVar)
+
+	tk.MustExec(`create procedure p(out r varchar(20))
+begin
+	declare x int;
+	declare y varchar(10);
+	declare continue handler for not found set r = 'not found';
+	select a, b into x, y from t where a = 1;
+	set r = concat(x, y);
+	select a into x from t where a > 10;
+end`)
+	tk.MustExec("call p(@r)")
+	tk.MustQuery("select @r").Check(testkit.Rows("not found"))
+	tk.MustExec("create procedure p2() begin declare x int; select a into x from t; end")
+	tk.MustGetErrCode("call p2()", errno.ErrTooManyRows)
+}
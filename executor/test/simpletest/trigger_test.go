@@ -0,0 +1,267 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package simpletest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateDropTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustExec("create table log (msg varchar(64))")
+	tk.MustExec("create trigger tr1 before insert on t for each row set new.b = new.a * 2")
+	tk.MustGetErrCode("create trigger tr1 after delete on t for each row begin end", errno.ErrTrgAlreadyExists)
+	tk.MustExec("create trigger if not exists tr1 after delete on t for each row begin end")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1359 Trigger already exists"))
+	tk.MustExec("create trigger test.tr2 after update on t for each row insert into log values (concat(old.a, '->', new.a))")
+
+	tk.MustQuery("show triggers").CheckAt([]int{0, 1, 2, 3, 4, 7}, testkit.RowsWithSep("|",
+		"tr1|INSERT|t|set new.b = new.a * 2|BEFORE|root@%",
+		"tr2|UPDATE|t|insert into log values (concat(old.a, '->', new.a))|AFTER|root@%"))
+	tk.MustQuery("select trigger_schema, trigger_name, event_manipulation, event_object_table, action_order, action_timing " +
+		"from information_schema.triggers order by trigger_name").Check(testkit.Rows(
+		"test tr1 INSERT t 1 BEFORE",
+		"test tr2 UPDATE t 1 AFTER"))
+
+	tk.MustExec("drop trigger tr1")
+	tk.MustGetErrCode("drop trigger tr1", errno.ErrTrgDoesNotExist)
+	tk.MustExec("drop trigger if exists tr1")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Note 1360 Trigger does not exist"))
+	tk.MustExec("drop trigger test.tr2")
+	tk.MustQuery("select count(*) from information_schema.triggers").Check(testkit.Rows("0"))
+
+	// The triggers are dropped with the table.
+	tk.MustExec("create trigger tr before insert on t for each row begin end")
+	tk.MustExec("drop table t")
+	tk.MustExec("create table t (a int, b int)")
+	tk.MustQuery("show triggers").Check(testkit.Rows())
+
+	// The subject table is checked.
+	tk.MustExec("create view v as select * from t")
+	tk.MustGetErrCode("create trigger tr before insert on v for each row begin end", errno.ErrTrgOnViewOrTempTable)
+	tk.MustGetErrCode("create trigger tr before insert on t2 for each row begin end", errno.ErrNoSuchTable)
+	tk.MustGetErrCode("create trigger mysql.tr before insert on t for each row begin end", errno.ErrTrgInWrongSchema)
+	tk.MustGetErrCode("create trigger tr before insert on mysql.user for each row begin end", errno.ErrNoTriggersOnSystemSchema)
+	tk.MustExec("create database db1")
+	tk.MustExec("create trigger tr before insert on t for each row begin end")
+	tk.MustGetErrCode("rename table t to db1.t", errno.ErrTrgInWrongSchema)
+
+	// The definition is checked when the trigger is created.
+	tk.MustGetErrCode("create trigger tr1 before insert on t for each row set @x = old.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr1 before delete on t for each row set @x = new.a", errno.ErrTrgNoSuchRowInTrg)
+	tk.MustGetErrCode("create trigger tr1 before update on t for each row set old.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr1 after update on t for each row set new.a = 1", errno.ErrTrgCantChangeRow)
+	tk.MustGetErrCode("create trigger tr1 before update on t for each row set new.c = 1", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger tr1 before update on t for each row if old.c > 0 then set @x = 1; end if", errno.ErrBadField)
+	tk.MustGetErrCode("create trigger tr1 before insert on t for each row select 1", errno.ErrSpNoRetset)
+	tk.MustGetErrCode("create trigger tr1 before insert on t for each row begin commit; end", errno.ErrCommitNotAllowedInSfOrTrg)
+	tk.MustGetErrCode("create trigger tr1 before insert on t for each row begin leave l; end", errno.ErrSpLilabelMismatch)
+}
+
+func TestFireTrigger(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (id int primary key, a int, b varchar(10))")
+	tk.MustExec("create table log (id int auto_increment primary key, msg varchar(64))")
+	tk.MustExec("create trigger bi before insert on t for each row begin " +
+		"if new.a is null then set new.a = 0; end if; set new.b = upper(new.b); end")
+	tk.MustExec("create trigger ai after insert on t for each row insert into log (msg) values (concat('insert ', new.id, ' ', new.a, ' ', new.b))")
+	tk.MustExec("create trigger bu before update on t for each row set new.a = new.a + 100")
+	tk.MustExec("create trigger au after update on t for each row insert into log (msg) values (concat('update ', old.a, '->', new.a))")
+	tk.MustExec("create trigger ad after delete on t for each row insert into log (msg) values (concat('delete ', old.id))")
+
+	tk.MustExec("insert into t values (1, null, 'x'), (2, 5, 'y')")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	tk.MustQuery("select * from t order by id").Check(testkit.Rows("1 0 X", "2 5 Y"))
+	tk.MustExec("update t set a = a + 1 where id = 2")
+	require.Equal(t, uint64(1), tk.Session().AffectedRows())
+	tk.MustQuery("select a from t where id = 2").Check(testkit.Rows("106"))
+	tk.MustExec("insert into t values (1, 7, 'z') on duplicate key update a = 1")
+	tk.MustQuery("select a, b from t where id = 1").Check(testkit.Rows("101 X"))
+	tk.MustExec("replace into t values (2, 3, 'w')")
+	tk.MustExec("delete from t where id = 1")
+	tk.MustQuery("select * from t").Check(testkit.Rows("2 3 W"))
+	tk.MustQuery("select msg from log order by id").Check(testkit.Rows(
+		"insert 1 0 X",
+		"insert 2 5 Y",
+		"update 5->106",
+		"update 0->101",
+		"delete 2",
+		"insert 2 3 W",
+		"delete 1"))
+
+	// The triggers run in the transaction of the statement, an error in the trigger fails the statement.
+	tk.MustExec("truncate table log")
+	tk.MustExec("create table t2 (a int primary key)")
+	tk.MustExec("create trigger ai2 after insert on t2 for each row insert into log (id, msg) values (new.a, 'dup')")
+	tk.MustExec("begin")
+	tk.MustExec("insert into t2 values (1)")
+	tk.MustGetErrCode("insert into t2 values (2), (1)", errno.ErrDupEntry)
+	tk.MustGetErrCode("insert into t2 values (3), (4), (3)", errno.ErrDupEntry)
+	tk.MustQuery("select a from t2").Check(testkit.Rows("1"))
+	tk.MustQuery("select id from log").Check(testkit.Rows("1"))
+	tk.MustExec("rollback")
+	tk.MustQuery("select count(*) from t2").Check(testkit.Rows("0"))
+	tk.MustQuery("select count(*) from log").Check(testkit.Rows("0"))
+
+	// The statements in the trigger can't modify the subject table.
+	tk.MustExec("create trigger bd2 before delete on t2 for each row delete from t2")
+	tk.MustExec("insert into t2 values (1)")
+	tk.MustGetErrCode("delete from t2", errno.ErrCantUpdateUsedTableInSfOrTrg)
+	tk.MustQuery("select a from t2").Check(testkit.Rows("1"))
+}
+
+func TestTriggerWithForeignKey(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set @@foreign_key_checks = 1")
+	tk.MustExec("create table parent (id int primary key)")
+	tk.MustExec("create table child (id int primary key, pid int, foreign key (pid) references parent(id) on delete cascade)")
+	tk.MustExec("create table log (msg varchar(64))")
+	tk.MustExec("create trigger ad after delete on child for each row insert into log values (concat('child ', old.id))")
+	tk.MustExec("create trigger bd before delete on parent for each row insert into log values (concat('parent ', old.id))")
+	// The trigger inserts the referenced row, which is checked at the end of the statement.
+	tk.MustExec("create trigger bi before insert on child for each row insert ignore into parent values (new.pid)")
+
+	tk.MustExec("insert into child values (1, 1), (2, 1), (3, 2)")
+	require.Equal(t, uint64(3), tk.Session().AffectedRows())
+	tk.MustQuery("select id from parent order by id").Check(testkit.Rows("1", "2"))
+	// The rows deleted by the foreign key cascade don't fire the triggers.
+	tk.MustExec("delete from parent where id = 1")
+	tk.MustQuery("select id from child").Check(testkit.Rows("3"))
+	tk.MustExec("delete from child")
+	tk.MustQuery("select msg from log").Check(testkit.Rows("parent 1", "child 3"))
+
+	tk.MustExec("drop trigger bi")
+	tk.MustGetErrCode("insert into child values (4, 4)", errno.ErrNoReferencedRow2)
+}
+
+func TestFireTriggerPerRow(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("create table log (msg varchar(64))")
+	// The triggers are fired around the write of each row, so they see the rows changed before.
+	tk.MustExec("create trigger bi before insert on t for each row " +
+		"insert into log select concat('before insert ', new.a, ': ', count(*)) from t")
+	tk.MustExec("create trigger ai after insert on t for each row " +
+		"insert into log select concat('after insert ', new.a, ': ', count(*)) from t")
+	tk.MustExec("create trigger au after update on t for each row " +
+		"insert into log select concat('after update ', new.a, ': ', sum(b)) from t")
+	tk.MustExec("create trigger bd before delete on t for each row " +
+		"insert into log select concat('before delete ', old.a, ': ', count(*)) from t")
+
+	tk.MustExec("insert into t values (1, 0), (2, 0), (3, 0)")
+	tk.MustExec("replace into t values (4, 0), (1, 1)")
+	tk.MustExec("update t set b = 1 order by a")
+	tk.MustExec("delete from t order by a limit 2")
+	tk.MustQuery("select msg from log").Check(testkit.Rows(
+		"before insert 1: 0",
+		"after insert 1: 1",
+		"before insert 2: 1",
+		"after insert 2: 2",
+		"before insert 3: 2",
+		"after insert 3: 3",
+		"before insert 4: 3",
+		"after insert 4: 4",
+		"before insert 1: 4",
+		"before delete 1: 4",
+		"after insert 1: 4",
+		"after update 1: 1",
+		"after update 2: 2",
+		"after update 3: 3",
+		"after update 4: 4",
+		"before delete 1: 4",
+		"before delete 2: 3"))
+	tk.MustQuery("select a from t order by a").Check(testkit.Rows("3", "4"))
+}
+
+func TestTriggerStatementAtomicity(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int)")
+	tk.MustExec("create table log (id int primary key)")
+	// The trigger fails on the third row, after the changes of the first two rows are flushed.
+	tk.MustExec("create trigger ai after insert on t for each row insert into log values (new.b)")
+	tk.MustExec("create trigger au after update on t for each row insert into log values (new.b)")
+
+	for _, mode := range []string{"optimistic", "pessimistic"} {
+		tk.MustExec("set @@tidb_txn_mode = '" + mode + "'")
+		tk.MustExec("delete from t")
+		tk.MustExec("delete from log")
+
+		// The statement is rolled back as a whole outside an explicit transaction.
+		tk.MustGetErrCode("insert into t values (1, 1), (2, 2), (3, 1)", errno.ErrDupEntry)
+		tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+		tk.MustQuery("select count(*) from log").Check(testkit.Rows("0"))
+		tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+		tk.MustGetErrCode("update t set b = if(a = 3, 101, b + 100) order by a", errno.ErrDupEntry)
+		tk.MustQuery("select b from t order by a").Check(testkit.Rows("1", "2", "3"))
+		tk.MustQuery("select id from log order by id").Check(testkit.Rows("1", "2", "3"))
+
+		// Only the failed statement is rolled back in an explicit transaction.
+		tk.MustExec("begin")
+		tk.MustExec("insert into t values (4, 4)")
+		tk.MustGetErrCode("insert into t values (5, 5), (6, 6), (7, 4)", errno.ErrDupEntry)
+		tk.MustGetErrCode("update t set b = if(a = 3, 101, b + 100) order by a", errno.ErrDupEntry)
+		tk.MustQuery("select a, b from t order by a").Check(testkit.Rows("1 1", "2 2", "3 3", "4 4"))
+		tk.MustQuery("select id from log order by id").Check(testkit.Rows("1", "2", "3", "4"))
+		tk.MustExec("commit")
+		tk.MustQuery("select a, b from t order by a").Check(testkit.Rows("1 1", "2 2", "3 3", "4 4"))
+		tk.MustQuery("select id from log order by id").Check(testkit.Rows("1", "2", "3", "4"))
+	}
+
+	// The flushed changes are locked in the pessimistic transaction, then the statement fails on the conflicting
+	// row instead of the commit, and the changes of the statement are rolled back.
+	tk.MustExec("set @@tidb_txn_mode = 'pessimistic'")
+	tk.MustExec("create table t2 (a int primary key, b int)")
+	tk.MustExec("create table log2 (id int primary key)")
+	tk.MustExec("create trigger ai2 after insert on t2 for each row insert into log2 values (new.b)")
+	tk2 := testkit.NewTestKit(t, store)
+	tk2.MustExec("use test")
+	tk2.MustExec("begin pessimistic")
+	tk2.MustExec("insert into log2 values (2)")
+	tk.MustExec("begin pessimistic")
+	tk.MustExec("insert into t2 values (10, 10)")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		tk.MustGetErrCode("insert into t2 values (1, 1), (2, 2), (3, 3)", errno.ErrDupEntry)
+	}()
+	time.Sleep(200 * time.Millisecond)
+	tk2.MustExec("commit")
+	<-done
+	tk.MustExec("commit")
+	tk.MustQuery("select a from t2").Check(testkit.Rows("10"))
+	tk.MustQuery("select id from log2 order by id").Check(testkit.Rows("2", "10"))
+}
@@ -0,0 +1,704 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package executor
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/executor/internal/exec"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/planner"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/privilege"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
+	"github.com/pingcap/tidb/util/stringutil"
+)
+
+// TriggerExec fires the triggers of a table for the rows changed by a DML statement.
+// The triggers are fired row by row: the BEFORE triggers are fired before a row is changed, and they can modify
+// the new row by `SET NEW.col = expr`; the AFTER triggers are fired right after the row is changed.
+// The statements in the trigger bodies run in the transaction and the statement context of the triggering
+// statement, so an error in a trigger fails the triggering statement. The changes of the triggering statement
+// are flushed before a trigger is fired, so the statements in the trigger can read the rows changed before.
+// The flushed changes are rolled back to the savepoint recorded by ExecStmt if the statement fails or is retried.
+type TriggerExec struct {
+	sctx   sessionctx.Context
+	is     infoschema.InfoSchema
+	tbl    table.Table
+	schema model.CIStr
+	// bodies caches the parsed trigger bodies, the key is the lower case trigger name.
+	bodies map[string]*triggerBody
+	// noTriggers is set for the rows changed by foreign key cascades, they only write the materialized view logs.
+	noTriggers bool
+	// mlogs are the log tables of the materialized views refreshed fast, the changed rows are written to them.
+	mlogs []*mviewLog
+}
+
+// mviewLog writes the changed rows of a base table to the log table of a materialized view.
+type mviewLog struct {
+	tbl table.Table
+	// offsets are the offsets in the base table rows of the log table columns, -1 if the column is dropped
+	// from the base table.
+	offsets []int
+}
+
+func (l *mviewLog) write(sctx sessionctx.Context, row []types.Datum, dml int64) error {
+	logRow := make([]types.Datum, len(l.offsets)+1)
+	for i, offset := range l.offsets {
+		if offset >= 0 {
+			logRow[i] = row[offset]
+		}
+	}
+	logRow[len(l.offsets)].SetInt64(dml)
+	_, err := l.tbl.AddRecord(sctx, logRow)
+	return err
+}
+
+type triggerBody struct {
+	stmt    ast.StmtNode
+	sqlMode mysql.SQLMode
+	parser  *parser.Parser
+	// sqls caches the restored SQL of statements and expressions in the trigger body.
+	sqls map[ast.Node]string
+}
+
+// triggerRow is the row referenced by NEW and OLD in the trigger body.
+type triggerRow struct {
+	event  model.TriggerEvent
+	cols   []*table.Column
+	oldRow []types.Datum
+	newRow []types.Datum
+}
+
+// lookup returns the value of a column of the NEW or OLD row, it returns nil if the column doesn't refer to them.
+func (r *triggerRow) lookup(name *ast.ColumnName) ast.ExprNode {
+	if r == nil || name.Schema.L != "" {
+		return nil
+	}
+	var row []types.Datum
+	switch name.Table.L {
+	case "new":
+		row = r.newRow
+	case "old":
+		row = r.oldRow
+	}
+	col := table.FindCol(r.cols, name.Name.O)
+	if row == nil || col == nil {
+		return nil
+	}
+	val := &driver.ValueExpr{Datum: row[col.Offset]}
+	val.Type = col.FieldType
+	return val
+}
+
+// assignedColumn returns the column assigned by `SET NEW.col = expr`, or nil if it's not an assignment of the NEW row.
+func (r *triggerRow) assignedColumn(assign *ast.VariableAssignment) *table.Column {
+	if r == nil || r.newRow == nil || !assign.IsSystem || assign.IsGlobal {
+		return nil
+	}
+	row, name, ok := strings.Cut(assign.Name, ".")
+	if !ok || !strings.EqualFold(row, "new") {
+		return nil
+	}
+	return table.FindCol(r.cols, name)
+}
+
+func (r *triggerRow) set(sctx sessionctx.Context, col *table.Column, val types.Datum) error {
+	casted, err := table.CastValue(sctx, val, col.ColumnInfo, false, false)
+	if err != nil {
+		return err
+	}
+	r.newRow[col.Offset] = casted
+	return nil
+}
+
+type triggerTableStackKeyType struct{}
+
+// triggerTableStackKey is the context key of the subject tables of the triggers being fired,
+// the statements in the triggers can't modify these tables.
+var triggerTableStackKey = triggerTableStackKeyType{}
+
+// buildTriggerExec builds the trigger executor of a table, it returns nil if the table has no triggers and
+// no materialized view logs. The rows changed by foreign key cascades don't fire triggers.
+func (b *executorBuilder) buildTriggerExec(tbl table.Table) (*TriggerExec, error) {
+	mlogs := b.buildMViewLogs(tbl)
+	if (len(tbl.Meta().Triggers) == 0 || b.inForeignKeyCascade) && len(mlogs) == 0 {
+		return nil, nil
+	}
+	dbInfo, ok := b.is.SchemaByTable(tbl.Meta())
+	if !ok {
+		return nil, errors.Errorf("can not find the schema of table %s", tbl.Meta().Name.O)
+	}
+	return &TriggerExec{
+		sctx:       b.ctx,
+		is:         b.is,
+		tbl:        tbl,
+		schema:     dbInfo.Name,
+		bodies:     make(map[string]*triggerBody),
+		noTriggers: b.inForeignKeyCascade,
+		mlogs:      mlogs,
+	}, nil
+}
+
+func (b *executorBuilder) buildMViewLogs(tbl table.Table) []*mviewLog {
+	var mlogs []*mviewLog
+	for _, id := range tbl.Meta().MViewLogTableIDs {
+		logTbl, ok := b.is.TableByID(id)
+		if !ok {
+			// The log table is dropped with its schema.
+			continue
+		}
+		cols := logTbl.Cols()
+		l := &mviewLog{tbl: logTbl, offsets: make([]int, len(cols)-1)}
+		for i, col := range cols[:len(cols)-1] {
+			l.offsets[i] = -1
+			if baseCol := table.FindCol(tbl.Cols(), col.Name.O); baseCol != nil {
+				l.offsets[i] = baseCol.Offset
+			}
+		}
+		mlogs = append(mlogs, l)
+	}
+	return mlogs
+}
+
+func (b *executorBuilder) buildTblID2TriggerExecs(tblID2Table map[int64]table.Table) (map[int64]*TriggerExec, error) {
+	triggersMap := make(map[int64]*TriggerExec)
+	for tid, tbl := range tblID2Table {
+		triggers, err := b.buildTriggerExec(tbl)
+		if err != nil {
+			return nil, err
+		}
+		if triggers != nil {
+			triggersMap[tid] = triggers
+		}
+	}
+	return triggersMap, nil
+}
+
+func (t *TriggerExec) hasTriggers(timing model.TriggerTiming, event model.TriggerEvent) bool {
+	if t == nil || t.noTriggers {
+		return false
+	}
+	for _, trigger := range t.tbl.Meta().Triggers {
+		if trigger.Timing == timing && trigger.Event == event {
+			return true
+		}
+	}
+	return false
+}
+
+// fireBefore fires the BEFORE triggers for a row, the changes of the NEW row are written back to newRow.
+func (t *TriggerExec) fireBefore(ctx context.Context, event model.TriggerEvent, oldRow, newRow []types.Datum) error {
+	if !t.hasTriggers(model.TriggerTimingBefore, event) {
+		return nil
+	}
+	row := &triggerRow{event: event, cols: t.tbl.Cols(), oldRow: oldRow, newRow: newRow}
+	return t.fire(ctx, model.TriggerTimingBefore, row)
+}
+
+// fireAfter writes a changed row to the materialized view logs, then fires the AFTER triggers for it.
+func (t *TriggerExec) fireAfter(ctx context.Context, event model.TriggerEvent, oldRow, newRow []types.Datum) error {
+	if t == nil {
+		return nil
+	}
+	for _, l := range t.mlogs {
+		if oldRow != nil {
+			if err := l.write(t.sctx, oldRow, -1); err != nil {
+				return err
+			}
+		}
+		if newRow != nil {
+			if err := l.write(t.sctx, newRow, 1); err != nil {
+				return err
+			}
+		}
+	}
+	if !t.hasTriggers(model.TriggerTimingAfter, event) {
+		return nil
+	}
+	row := &triggerRow{event: event, cols: t.tbl.Cols()}
+	if oldRow != nil {
+		row.oldRow = types.CloneRow(oldRow)
+	}
+	if newRow != nil {
+		row.newRow = types.CloneRow(newRow)
+	}
+	return t.fire(ctx, model.TriggerTimingAfter, row)
+}
+
+// triggerDepth returns the depth of the foreign key triggers of the statements run in ctx, the triggering
+// statement is at depth 1, and the statements in its triggers are at depth 2.
+func triggerDepth(ctx context.Context) int {
+	stack, _ := ctx.Value(triggerTableStackKey).([]int64)
+	return len(stack) + 1
+}
+
+func (t *TriggerExec) fire(ctx context.Context, timing model.TriggerTiming, row *triggerRow) error {
+	stack, _ := ctx.Value(triggerTableStackKey).([]int64)
+	ctx = context.WithValue(ctx, triggerTableStackKey, append(stack[:len(stack):len(stack)], t.tbl.Meta().ID))
+
+	vars := t.sctx.GetSessionVars()
+	originDB, originSQLMode, originStrict := vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode
+	originInTrigger := vars.StmtCtx.InHandleForeignKeyTrigger
+	defer func() {
+		vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode = originDB, originSQLMode, originStrict
+		vars.StmtCtx.InHandleForeignKeyTrigger = originInTrigger
+	}()
+	// The rows affected by the statements in the triggers are not counted, the same as foreign key cascades.
+	vars.StmtCtx.InHandleForeignKeyTrigger = true
+	// Flush the changes of the triggering statement, then the statements in the triggers can read them.
+	if err := t.flushStmt(ctx); err != nil {
+		return err
+	}
+	for _, trigger := range t.tbl.Meta().Triggers {
+		if trigger.Timing != timing || trigger.Event != row.event {
+			continue
+		}
+		body, err := t.loadBody(trigger)
+		if err != nil {
+			return err
+		}
+		// The trigger runs in the schema of its table and with the SQL mode when it was created.
+		vars.CurrentDB, vars.SQLMode, vars.StrictSQLMode = t.schema.O, body.sqlMode, body.sqlMode.HasStrictMode()
+		definer := trigger.Definer
+		p := &procedureExec{
+			sctx:   t.sctx,
+			parser: body.parser,
+			sqls:   body.sqls,
+			row:    row,
+			run: func(ctx context.Context, stmt ast.StmtNode, keepRows bool) ([][]types.Datum, int, error) {
+				return t.runStmt(ctx, stmt, keepRows, definer)
+			},
+		}
+		err = p.execStmt(ctx, newProcedureScope(nil), body.stmt)
+		switch x := err.(type) {
+		case *procedureExit:
+			err = nil
+		case *procedureJump:
+			// It's checked when the trigger is created, so it should never happen.
+			return errors.Errorf("unexpected %s of label %s", x.kind(), x.label)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (t *TriggerExec) loadBody(trigger *model.TriggerInfo) (*triggerBody, error) {
+	if body, ok := t.bodies[trigger.Name.L]; ok {
+		return body, nil
+	}
+	sqlMode, err := mysql.GetSQLMode(trigger.SQLMode)
+	if err != nil {
+		return nil, err
+	}
+	vars := t.sctx.GetSessionVars()
+	p := parser.New()
+	p.SetSQLMode(sqlMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	charset, collation := vars.GetCharsetInfo()
+	stmt, err := p.ParseOneStmt(triggerCreateStmt(t.tbl.Meta(), trigger, sqlMode), charset, collation)
+	if err != nil {
+		return nil, err
+	}
+	body := &triggerBody{
+		stmt:    stmt.(*ast.CreateTriggerStmt).Body,
+		sqlMode: sqlMode,
+		parser:  p,
+		sqls:    make(map[ast.Node]string),
+	}
+	t.bodies[trigger.Name.L] = body
+	return body, nil
+}
+
+// runStmt executes a statement of the trigger body, the privileges are checked with the definer of the trigger.
+// The changes of the statement are flushed, then its foreign key checks and cascades are handled immediately.
+func (t *TriggerExec) runStmt(ctx context.Context, stmt ast.StmtNode, keepRows bool,
+	definer *auth.UserIdentity) (rows [][]types.Datum, numCols int, err error) {
+	sctx := t.sctx
+	defer resetTriggerStmtCtx(sctx.GetSessionVars(), stmt)()
+	if err = plannercore.Preprocess(ctx, sctx, stmt); err != nil {
+		return nil, 0, err
+	}
+	p, err := planner.OptimizeForTrigger(ctx, sctx, stmt, t.is, definer)
+	if err != nil {
+		return nil, 0, err
+	}
+	b := newExecutorBuilder(sctx, t.is, nil)
+	e := b.build(p)
+	if b.err != nil {
+		return nil, 0, b.err
+	}
+	if err = checkTriggerTargetTables(ctx, e); err != nil {
+		return nil, 0, err
+	}
+	if err = e.Open(ctx); err != nil {
+		terror.Call(e.Close)
+		return nil, 0, err
+	}
+	retTypes := exec.RetTypes(e)
+	chk := exec.NewFirstChunk(e)
+	for {
+		if err = exec.Next(ctx, e, chk); err != nil {
+			terror.Call(e.Close)
+			return nil, 0, err
+		}
+		if chk.NumRows() == 0 {
+			break
+		}
+		if !keepRows {
+			continue
+		}
+		for i := 0; i < chk.NumRows(); i++ {
+			rows = append(rows, types.CloneRow(chk.GetRow(i).GetDatumRow(retTypes)))
+		}
+	}
+	if err = e.Close(); err != nil {
+		return nil, 0, err
+	}
+	if _, ok := e.(WithForeignKeyTrigger); ok {
+		if err = t.flushStmt(ctx); err != nil {
+			return nil, 0, err
+		}
+		if err = handleForeignKeyTrigger(ctx, sctx, e, triggerDepth(ctx)); err != nil {
+			return nil, 0, err
+		}
+	}
+	return rows, len(retTypes), nil
+}
+
+// flushStmt flushes the changes of the statement to the transaction. In pessimistic transactions, only the keys
+// in the statement buffer are locked when the statement finishes, so the keys are locked before they are flushed.
+func (t *TriggerExec) flushStmt(ctx context.Context) error {
+	vars := t.sctx.GetSessionVars()
+	if vars.TxnCtx.IsPessimistic {
+		txn, err := t.sctx.Txn(false)
+		if err != nil {
+			return err
+		}
+		if pTxn, ok := txn.(pessimisticTxn); ok && txn.Valid() {
+			keys, err := pTxn.KeysNeedToLock()
+			if err != nil {
+				return err
+			}
+			keys = vars.TxnCtx.CollectUnchangedKeysForLock(keys)
+			keys = filterTemporaryTableKeys(vars, keys)
+			keys = filterLockTableKeys(vars.StmtCtx, keys)
+			if len(keys) > 0 {
+				lockCtx, err := newLockCtx(t.sctx, vars.LockWaitTimeout, len(keys))
+				if err != nil {
+					return err
+				}
+				if err = txn.LockKeys(ctx, lockCtx, keys...); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	t.sctx.StmtCommit(ctx)
+	return nil
+}
+
+// resetTriggerStmtCtx applies the flags of a DML statement in a trigger to the statement context, which is shared
+// with the triggering statement. It returns a function to restore the flags.
+func resetTriggerStmtCtx(vars *variable.SessionVars, stmt ast.StmtNode) (restore func()) {
+	sc := vars.StmtCtx
+	inInsert, inUpdate, inDelete := sc.InInsertStmt, sc.InUpdateStmt, sc.InDeleteStmt
+	dupKeyAsWarning, badNullAsWarning, truncateAsWarning := sc.DupKeyAsWarning, sc.BadNullAsWarning, sc.TruncateAsWarning
+	dividedByZeroAsWarning, ignoreNoPartition := sc.DividedByZeroAsWarning, sc.IgnoreNoPartition
+	allowInvalidDate, ignoreZeroInDate := sc.AllowInvalidDate, sc.IgnoreZeroInDate
+	autoincReadFailedAsWarning, priority := sc.ErrAutoincReadFailedAsWarning, sc.Priority
+	restore = func() {
+		sc.InInsertStmt, sc.InUpdateStmt, sc.InDeleteStmt = inInsert, inUpdate, inDelete
+		sc.DupKeyAsWarning, sc.BadNullAsWarning, sc.TruncateAsWarning = dupKeyAsWarning, badNullAsWarning, truncateAsWarning
+		sc.DividedByZeroAsWarning, sc.IgnoreNoPartition = dividedByZeroAsWarning, ignoreNoPartition
+		sc.AllowInvalidDate, sc.IgnoreZeroInDate = allowInvalidDate, ignoreZeroInDate
+		sc.ErrAutoincReadFailedAsWarning, sc.Priority = autoincReadFailedAsWarning, priority
+	}
+
+	sc.InInsertStmt, sc.InUpdateStmt, sc.InDeleteStmt = false, false, false
+	switch x := stmt.(type) {
+	case *ast.InsertStmt:
+		sc.InInsertStmt = true
+		sc.DupKeyAsWarning = x.IgnoreErr
+		sc.BadNullAsWarning = !vars.StrictSQLMode || x.IgnoreErr
+		sc.IgnoreNoPartition = x.IgnoreErr
+		sc.ErrAutoincReadFailedAsWarning = x.IgnoreErr
+		sc.TruncateAsWarning = !vars.StrictSQLMode || x.IgnoreErr
+		sc.DividedByZeroAsWarning = !vars.StrictSQLMode || x.IgnoreErr
+		sc.AllowInvalidDate = vars.SQLMode.HasAllowInvalidDatesMode()
+		sc.IgnoreZeroInDate = !vars.SQLMode.HasNoZeroInDateMode() || !vars.SQLMode.HasNoZeroDateMode() ||
+			!vars.StrictSQLMode || x.IgnoreErr || sc.AllowInvalidDate
+		sc.Priority = x.Priority
+	case *ast.UpdateStmt:
+		ResetUpdateStmtCtx(sc, x, vars)
+	case *ast.DeleteStmt:
+		ResetDeleteStmtCtx(sc, x, vars)
+	}
+	return restore
+}
+
+// checkTriggerTargetTables checks the statement in a trigger doesn't modify the tables whose triggers are being fired.
+func checkTriggerTargetTables(ctx context.Context, e exec.Executor) error {
+	var targets []table.Table
+	switch x := e.(type) {
+	case *InsertExec:
+		targets = append(targets, x.Table)
+	case *ReplaceExec:
+		targets = append(targets, x.Table)
+	case *UpdateExec:
+		for _, tbl := range x.tblID2table {
+			targets = append(targets, tbl)
+		}
+	case *DeleteExec:
+		for _, tbl := range x.tblID2Table {
+			targets = append(targets, tbl)
+		}
+	}
+	stack, _ := ctx.Value(triggerTableStackKey).([]int64)
+	for _, tbl := range targets {
+		if slices.Contains(stack, tbl.Meta().ID) {
+			return exeerrors.ErrCantUpdateUsedTableInSfOrTrg.GenWithStackByArgs(tbl.Meta().Name.O)
+		}
+	}
+	return nil
+}
+
+// triggerCreateStmt returns the statement to create a trigger, the definer is omitted.
+func triggerCreateStmt(tblInfo *model.TableInfo, trigger *model.TriggerInfo, sqlMode mysql.SQLMode) string {
+	return fmt.Sprintf("CREATE TRIGGER %s %s %s ON %s FOR EACH ROW %s", stringutil.Escape(trigger.Name.O, sqlMode),
+		trigger.Timing, trigger.Event, stringutil.Escape(tblInfo.Name.O, sqlMode), trigger.Definition)
+}
+
+// triggerCreatedTime returns the time when a trigger is created, in the time zone of the session.
+func triggerCreatedTime(sctx sessionctx.Context, trigger *model.TriggerInfo) types.Time {
+	created := trigger.CreatedAt.In(sctx.GetSessionVars().Location())
+	return types.NewTime(types.FromGoTime(created), mysql.TypeDatetime, 2)
+}
+
+func schemaCollation(dbInfo *model.DBInfo) string {
+	if dbInfo.Collate != "" {
+		return dbInfo.Collate
+	}
+	return getDefaultCollate(dbInfo.Charset)
+}
+
+func (e *DDLExec) executeCreateTrigger(s *ast.CreateTriggerStmt) error {
+	// The DDL returns the error if the table doesn't exist.
+	if tbl, err := e.is.TableByName(s.Table.Schema, s.Table.Name); err == nil {
+		if err = checkTrigger(s, tbl.Cols()); err != nil {
+			return err
+		}
+	}
+	return domain.GetDomain(e.Ctx()).DDL().CreateTrigger(e.Ctx(), s)
+}
+
+func (e *DDLExec) executeDropTrigger(s *ast.DropTriggerStmt) error {
+	return domain.GetDomain(e.Ctx()).DDL().DropTrigger(e.Ctx(), s)
+}
+
+// triggerChecker checks the references of the NEW and OLD rows and the statements in the body of a trigger.
+// It works with procedureChecker, which checks the control flow of the body.
+type triggerChecker struct {
+	timing model.TriggerTiming
+	event  model.TriggerEvent
+	cols   []*table.Column
+	err    error
+}
+
+func checkTrigger(s *ast.CreateTriggerStmt, cols []*table.Column) error {
+	c := &procedureChecker{
+		scopes:  []*procedureCheckScope{{vars: map[string]struct{}{}, cursors: map[string]struct{}{}}},
+		trigger: &triggerChecker{timing: s.Timing, event: s.Event, cols: cols},
+	}
+	return c.checkStmt(s.Body)
+}
+
+// checkStmt checks a statement of the trigger body, the nested statements are checked by procedureChecker.
+func (c *triggerChecker) checkStmt(stmt ast.StmtNode) error {
+	var exprs []ast.ExprNode
+	switch x := stmt.(type) {
+	case *ast.ProcedureBlock:
+		for _, decl := range x.ProcedureVars {
+			switch d := decl.(type) {
+			case *ast.ProcedureDecl:
+				if d.DeclDefault != nil {
+					exprs = append(exprs, d.DeclDefault)
+				}
+			case *ast.ProcedureCursor:
+				if err := c.checkRowRefs(d.Selectstring); err != nil {
+					return err
+				}
+			}
+		}
+	case *ast.ProcedureLabelBlock, *ast.ProcedureLabelLoop, *ast.ProcedureLoopStmt, *ast.ProcedureJump,
+		*ast.ProcedureOpenCur, *ast.ProcedureCloseCur, *ast.ProcedureFetchInto:
+	case *ast.ProcedureWhileStmt:
+		exprs = append(exprs, x.Condition)
+	case *ast.ProcedureRepeatStmt:
+		exprs = append(exprs, x.Condition)
+	case *ast.ProcedureIfInfo:
+		for block := x.IfBody; block != nil; {
+			exprs = append(exprs, block.IfExpr)
+			elseIf, ok := block.ProcedureElseStmt.(*ast.ProcedureElseIfBlock)
+			if !ok {
+				break
+			}
+			block = elseIf.ProcedureIfStmt
+		}
+	case *ast.SimpleCaseStmt:
+		exprs = append(exprs, x.Condition)
+		for _, when := range x.WhenCases {
+			exprs = append(exprs, when.Expr)
+		}
+	case *ast.SearchCaseStmt:
+		for _, when := range x.WhenCases {
+			exprs = append(exprs, when.Expr)
+		}
+	case *ast.SetStmt:
+		for _, assign := range x.Variables {
+			if err := c.checkAssignment(assign); err != nil {
+				return err
+			}
+		}
+		return c.checkRowRefs(x)
+	case *ast.SelectStmt:
+		if x.SelectIntoOpt == nil || x.SelectIntoOpt.Tp != ast.SelectIntoVars {
+			return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+		}
+		return c.checkRowRefs(x)
+	case *ast.SetOprStmt, *ast.ShowStmt, *ast.ExplainStmt:
+		return exeerrors.ErrSpNoRetset.GenWithStackByArgs("trigger")
+	case ast.DDLNode, *ast.BeginStmt, *ast.CommitStmt, *ast.RollbackStmt, *ast.LockTablesStmt, *ast.UnlockTablesStmt:
+		return exeerrors.ErrCommitNotAllowedInSfOrTrg.GenWithStackByArgs()
+	case *ast.CallStmt:
+		return plannercore.ErrNotSupportedYet.GenWithStackByArgs("CALL in triggers")
+	default:
+		return c.checkRowRefs(stmt)
+	}
+	for _, expr := range exprs {
+		if err := c.checkRowRefs(expr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *triggerChecker) checkAssignment(assign *ast.VariableAssignment) error {
+	if !assign.IsSystem || assign.IsGlobal {
+		return nil
+	}
+	row, name, ok := strings.Cut(assign.Name, ".")
+	if !ok {
+		return nil
+	}
+	switch strings.ToLower(row) {
+	case "old":
+		return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("OLD", "")
+	case "new":
+		if c.timing == model.TriggerTimingAfter {
+			return exeerrors.ErrTrgCantChangeRow.GenWithStackByArgs("NEW", "after ")
+		}
+		return c.checkRowRef(true, name)
+	}
+	return nil
+}
+
+func (c *triggerChecker) checkRowRef(isNew bool, name string) error {
+	rowName := "OLD"
+	if isNew {
+		rowName = "NEW"
+	}
+	if (isNew && c.event == model.TriggerEventDelete) || (!isNew && c.event == model.TriggerEventInsert) {
+		return exeerrors.ErrTrgNoSuchRowInTrg.GenWithStackByArgs(rowName, "on "+c.event.String())
+	}
+	if table.FindCol(c.cols, name) == nil {
+		return plannercore.ErrUnknownColumn.GenWithStackByArgs(name, rowName)
+	}
+	return nil
+}
+
+func (c *triggerChecker) checkRowRefs(node ast.Node) error {
+	c.err = nil
+	node.Accept(c)
+	return c.err
+}
+
+// Enter implements ast.Visitor interface.
+func (c *triggerChecker) Enter(in ast.Node) (ast.Node, bool) {
+	if x, ok := in.(*ast.ColumnNameExpr); ok && x.Name.Schema.L == "" {
+		switch x.Name.Table.L {
+		case "new", "old":
+			c.err = c.checkRowRef(x.Name.Table.L == "new", x.Name.Name.O)
+		}
+	}
+	return in, c.err != nil
+}
+
+// Leave implements ast.Visitor interface.
+func (c *triggerChecker) Leave(in ast.Node) (ast.Node, bool) {
+	return in, c.err == nil
+}
+
+// fetchShowTriggers fills the result of `SHOW TRIGGERS`.
+func (e *ShowExec) fetchShowTriggers() error {
+	if e.DBName.L == "" {
+		return plannercore.ErrNoDB
+	}
+	dbInfo, ok := e.is.SchemaByName(e.DBName)
+	if !ok {
+		return exeerrors.ErrBadDB.GenWithStackByArgs(e.DBName)
+	}
+	checker := privilege.GetPrivilegeManager(e.Ctx())
+	tables := e.is.SchemaTables(dbInfo.Name)
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Meta().Name.L < tables[j].Meta().Name.L
+	})
+	for _, tbl := range tables {
+		tblInfo := tbl.Meta()
+		if len(tblInfo.Triggers) == 0 {
+			continue
+		}
+		if checker != nil && !checker.RequestVerification(e.Ctx().GetSessionVars().ActiveRoles, dbInfo.Name.L, tblInfo.Name.L, "", mysql.TriggerPriv) {
+			continue
+		}
+		for _, trigger := range tblInfo.Triggers {
+			e.appendRow([]interface{}{
+				trigger.Name.O, trigger.Event.String(), tblInfo.Name.O, trigger.Definition, trigger.Timing.String(),
+				triggerCreatedTime(e.Ctx(), trigger), trigger.SQLMode, trigger.Definer.String(), trigger.Charset,
+				trigger.Collate, schemaCollation(dbInfo),
+			})
+		}
+	}
+	return nil
+}
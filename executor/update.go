@@ -71,6 +71,8 @@ type UpdateExec struct {
 	fkChecks map[int64][]*FKCheckExec
 	// fkCascades contains the foreign key cascade. the map is tableID -> []*FKCascadeExec
 	fkCascades map[int64][]*FKCascadeExec
+	// triggers fires the triggers of the tables. the map is tableID -> *TriggerExec
+	triggers map[int64]*TriggerExec
 }
 
 // prepare `handles`, `tableUpdatable`, `changed` to avoid re-computations.
@@ -198,7 +200,7 @@ func (e *UpdateExec) exec(ctx context.Context, _ *expression.Schema, row, newDat
 		// Update row
 		fkChecks := e.fkChecks[content.TblID]
 		fkCascades := e.fkCascades[content.TblID]
-		changed, err1 := updateRecord(ctx, e.Ctx(), handle, oldData, newTableData, flags, tbl, false, e.memTracker, fkChecks, fkCascades)
+		changed, err1 := updateRecord(ctx, e.Ctx(), handle, oldData, newTableData, flags, tbl, false, e.memTracker, fkChecks, fkCascades, e.triggers[content.TblID])
 		if err1 == nil {
 			_, exist := e.updatedRowKeys[content.Start].Get(handle)
 			memDelta := e.updatedRowKeys[content.Start].Set(handle, changed)
@@ -287,6 +289,7 @@ func (e *UpdateExec) updateRows(ctx context.Context) (int, error) {
 				// Bind an interceptor for client-go to count the number of SQL executions of each TiKV.
 				txn.SetOption(kv.RPCInterceptor, sc.KvExecCounter.RPCInterceptor())
 			}
+			setOptionForRunaway(sc, txn)
 		}
 		for rowIdx := 0; rowIdx < chk.NumRows(); rowIdx++ {
 			chunkRow := chk.GetRow(rowIdx)
@@ -561,3 +564,12 @@ func (e *UpdateExec) GetFKCascades() []*FKCascadeExec {
 func (e *UpdateExec) HasFKCascades() bool {
 	return len(e.fkCascades) > 0
 }
+
+// GetTriggers implements WithForeignKeyTrigger interface.
+func (e *UpdateExec) GetTriggers() []*TriggerExec {
+	triggers := make([]*TriggerExec, 0, len(e.triggers))
+	for _, t := range e.triggers {
+		triggers = append(triggers, t)
+	}
+	return triggers
+}
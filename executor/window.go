@@ -16,6 +16,7 @@ package executor
 
 import (
 	"context"
+	"sort"
 
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/executor/aggfuncs"
@@ -241,6 +242,8 @@ type rowFrameWindowProcessor struct {
 	start          *core.FrameBound
 	end            *core.FrameBound
 	curRowIdx      uint64
+	// excluder is not nil if the frame has an EXCLUDE clause.
+	excluder *frameExcluder
 }
 
 func (p *rowFrameWindowProcessor) getStartOffset(numRows uint64) uint64 {
@@ -314,6 +317,17 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 	for ; remained > 0; lastStart, lastEnd = start, end {
 		start = p.getStartOffset(numRows)
 		end = p.getEndOffset(numRows)
+		if p.excluder != nil {
+			err = p.excluder.appendResult2Chunk(ctx, p.windowFuncs, p.partialResults, func(u uint64) chunk.Row {
+				return rows[u]
+			}, numRows, start, end, p.curRowIdx, chk)
+			if err != nil {
+				return nil, err
+			}
+			p.curRowIdx++
+			remained--
+			continue
+		}
 		p.curRowIdx++
 		remained--
 		shiftStart = start - lastStart
@@ -375,6 +389,9 @@ func (p *rowFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, row
 
 func (p *rowFrameWindowProcessor) resetPartialResult() {
 	p.curRowIdx = 0
+	if p.excluder != nil {
+		p.excluder.peers.reset()
+	}
 }
 
 type rangeFrameWindowProcessor struct {
@@ -388,6 +405,8 @@ type rangeFrameWindowProcessor struct {
 	orderByCols     []*expression.Column
 	// expectedCmpResult is used to decide if one value is included in the frame.
 	expectedCmpResult int64
+	// excluder is not nil if the frame has an EXCLUDE clause.
+	excluder *frameExcluder
 }
 
 func (p *rangeFrameWindowProcessor) getStartOffset(ctx sessionctx.Context, rows []chunk.Row) (uint64, error) {
@@ -443,6 +462,7 @@ func (p *rangeFrameWindowProcessor) getEndOffset(ctx sessionctx.Context, rows []
 }
 
 func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+	numRows := uint64(len(rows))
 	var (
 		err                      error
 		initializedSlidingWindow bool
@@ -468,6 +488,17 @@ func (p *rangeFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, r
 		if err != nil {
 			return nil, err
 		}
+		if p.excluder != nil {
+			err = p.excluder.appendResult2Chunk(ctx, p.windowFuncs, p.partialResults, func(u uint64) chunk.Row {
+				return rows[u]
+			}, numRows, start, end, p.curRowIdx, chk)
+			if err != nil {
+				return nil, err
+			}
+			p.curRowIdx++
+			remained--
+			continue
+		}
 		p.curRowIdx++
 		remained--
 		shiftStart = start - lastStart
@@ -532,4 +563,281 @@ func (p *rangeFrameWindowProcessor) resetPartialResult() {
 	p.curRowIdx = 0
 	p.lastStartOffset = 0
 	p.lastEndOffset = 0
+	if p.excluder != nil {
+		p.excluder.peers.reset()
+	}
+}
+
+type groupsFrameWindowProcessor struct {
+	windowFuncs    []aggfuncs.AggFunc
+	partialResults []aggfuncs.PartialResult
+	start          *core.FrameBound
+	end            *core.FrameBound
+	curRowIdx      uint64
+	peers          *peerGroups
+	// excluder is not nil if the frame has an EXCLUDE clause.
+	excluder *frameExcluder
+}
+
+func (p *groupsFrameWindowProcessor) appendResult2Chunk(ctx sessionctx.Context, rows []chunk.Row, chk *chunk.Chunk, remained int) ([]chunk.Row, error) {
+	numRows := uint64(len(rows))
+	var (
+		initializedSlidingWindow bool
+		start                    uint64
+		end                      uint64
+		lastStart                uint64
+		lastEnd                  uint64
+		shiftStart               uint64
+		shiftEnd                 uint64
+	)
+	err := p.peers.split(ctx, func(u uint64) chunk.Row {
+		return rows[u]
+	}, numRows)
+	if err != nil {
+		return nil, err
+	}
+	slidingWindowAggFuncs := make([]aggfuncs.SlidingWindowAggFunc, len(p.windowFuncs))
+	for i, windowFunc := range p.windowFuncs {
+		if slidingWindowAggFunc, ok := windowFunc.(aggfuncs.SlidingWindowAggFunc); ok {
+			slidingWindowAggFuncs[i] = slidingWindowAggFunc
+		}
+	}
+	for ; remained > 0; lastStart, lastEnd = start, end {
+		start, end = p.peers.frameOffsets(p.start, p.end, p.curRowIdx, numRows)
+		if p.excluder != nil {
+			err = p.excluder.appendResult2Chunk(ctx, p.windowFuncs, p.partialResults, func(u uint64) chunk.Row {
+				return rows[u]
+			}, numRows, start, end, p.curRowIdx, chk)
+			if err != nil {
+				return nil, err
+			}
+			p.curRowIdx++
+			remained--
+			continue
+		}
+		p.curRowIdx++
+		remained--
+		shiftStart = start - lastStart
+		shiftEnd = end - lastEnd
+		if start >= end {
+			for i, windowFunc := range p.windowFuncs {
+				slidingWindowAggFunc := slidingWindowAggFuncs[i]
+				if slidingWindowAggFunc != nil && initializedSlidingWindow {
+					err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
+						return rows[u]
+					}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+					if err != nil {
+						return nil, err
+					}
+				}
+				err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+				if err != nil {
+					return nil, err
+				}
+			}
+			continue
+		}
+
+		for i, windowFunc := range p.windowFuncs {
+			slidingWindowAggFunc := slidingWindowAggFuncs[i]
+			if slidingWindowAggFunc != nil && initializedSlidingWindow {
+				err = slidingWindowAggFunc.Slide(ctx, func(u uint64) chunk.Row {
+					return rows[u]
+				}, lastStart, lastEnd, shiftStart, shiftEnd, p.partialResults[i])
+			} else {
+				if minMaxSlidingWindowAggFunc, ok := windowFunc.(aggfuncs.MaxMinSlidingWindowAggFunc); ok {
+					minMaxSlidingWindowAggFunc.SetWindowStart(start)
+				}
+				_, err = windowFunc.UpdatePartialResult(ctx, rows[start:end], p.partialResults[i])
+			}
+			if err != nil {
+				return nil, err
+			}
+			err = windowFunc.AppendFinalResult2Chunk(ctx, p.partialResults[i], chk)
+			if err != nil {
+				return nil, err
+			}
+			if slidingWindowAggFunc == nil {
+				windowFunc.ResetPartialResult(p.partialResults[i])
+			}
+		}
+		if !initializedSlidingWindow {
+			initializedSlidingWindow = true
+		}
+	}
+	for i, windowFunc := range p.windowFuncs {
+		windowFunc.ResetPartialResult(p.partialResults[i])
+	}
+	return rows, nil
+}
+
+func (*groupsFrameWindowProcessor) consumeGroupRows(_ sessionctx.Context, rows []chunk.Row) ([]chunk.Row, error) {
+	return rows, nil
+}
+
+func (p *groupsFrameWindowProcessor) resetPartialResult() {
+	p.curRowIdx = 0
+	p.peers.reset()
+}
+
+// peerGroups splits the rows of a partition into the groups of peers, which have the same values on the ORDER BY columns.
+// The rows are split incrementally, so that it can be used by the pipelined window executor.
+type peerGroups struct {
+	orderByCols []*expression.Column
+	cmpFuncs    []expression.CompareFunc
+	// starts stores the offsets of the first rows of the peer groups found so far.
+	starts []uint64
+	// numSplitRows is the number of rows which have been put into the peer groups.
+	numSplitRows uint64
+	// lastGroupRow is the first row of the last peer group, the rows after it are compared with it.
+	lastGroupRow chunk.Row
+}
+
+func newPeerGroups(ctx sessionctx.Context, orderByCols []*expression.Column) *peerGroups {
+	cmpFuncs := make([]expression.CompareFunc, 0, len(orderByCols))
+	for _, col := range orderByCols {
+		cmpFuncs = append(cmpFuncs, expression.GetCmpFunction(ctx, col, col))
+	}
+	return &peerGroups{orderByCols: orderByCols, cmpFuncs: cmpFuncs}
+}
+
+// split puts the rows in [numSplitRows, numRows) into the peer groups.
+func (g *peerGroups) split(ctx sessionctx.Context, getRow func(uint64) chunk.Row, numRows uint64) error {
+	for ; g.numSplitRows < numRows; g.numSplitRows++ {
+		row := getRow(g.numSplitRows)
+		if len(g.starts) > 0 {
+			isPeer, err := g.isPeer(ctx, g.lastGroupRow, row)
+			if err != nil {
+				return err
+			}
+			if isPeer {
+				continue
+			}
+		}
+		g.starts = append(g.starts, g.numSplitRows)
+		g.lastGroupRow = row
+	}
+	return nil
+}
+
+func (g *peerGroups) isPeer(ctx sessionctx.Context, lhs, rhs chunk.Row) (bool, error) {
+	for i, col := range g.orderByCols {
+		res, _, err := g.cmpFuncs[i](ctx, col, col, lhs, rhs)
+		if err != nil || res != 0 {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+// groupOf returns the index of the peer group of the row, the row must have been split.
+func (g *peerGroups) groupOf(rowIdx uint64) uint64 {
+	return uint64(sort.Search(len(g.starts), func(i int) bool {
+		return g.starts[i] > rowIdx
+	}) - 1)
+}
+
+// groupStart returns the offset of the first row of the group. numSplitRows is returned if the group is not found yet.
+func (g *peerGroups) groupStart(group uint64) uint64 {
+	if group < uint64(len(g.starts)) {
+		return g.starts[group]
+	}
+	return g.numSplitRows
+}
+
+// groupEnd returns the offset after the last row of the group. numSplitRows is returned if the end of the group is not found yet.
+func (g *peerGroups) groupEnd(group uint64) uint64 {
+	if group+1 < uint64(len(g.starts)) {
+		return g.starts[group+1]
+	}
+	return g.numSplitRows
+}
+
+// frameOffsets returns the frame [start, end) of the current row for the GROUPS frame, the bounds count the peer groups
+// instead of the rows.
+func (g *peerGroups) frameOffsets(startBound, endBound *core.FrameBound, curRowIdx, numRows uint64) (start, end uint64) {
+	cur, numGroups := g.groupOf(curRowIdx), uint64(len(g.starts))
+	switch {
+	case startBound.UnBounded:
+		start = 0
+	case startBound.Type == ast.Preceding:
+		if cur >= startBound.Num {
+			start = g.groupStart(cur - startBound.Num)
+		}
+	case startBound.Type == ast.Following:
+		start = numRows
+		if startBound.Num < numGroups {
+			start = g.groupStart(cur + startBound.Num)
+		}
+	default: // ast.CurrentRow
+		start = g.groupStart(cur)
+	}
+	switch {
+	case endBound.UnBounded:
+		end = numRows
+	case endBound.Type == ast.Preceding:
+		if cur >= endBound.Num {
+			end = g.groupEnd(cur - endBound.Num)
+		}
+	case endBound.Type == ast.Following:
+		end = numRows
+		if endBound.Num < numGroups {
+			end = g.groupEnd(cur + endBound.Num)
+		}
+	default: // ast.CurrentRow
+		end = g.groupEnd(cur)
+	}
+	return mathutil.Min(start, numRows), mathutil.Min(end, numRows)
+}
+
+func (g *peerGroups) reset() {
+	g.starts = g.starts[:0]
+	g.numSplitRows = 0
+	g.lastGroupRow = chunk.Row{}
+}
+
+// frameExcluder removes the current row or its peers from the frame, as the EXCLUDE clause specified.
+type frameExcluder struct {
+	exclude ast.FrameExclusion
+	peers   *peerGroups
+	// frameRows is the buffer of the rows left in the frame.
+	frameRows []chunk.Row
+}
+
+// appendResult2Chunk evaluates the window functions on the rows in the frame [start, end) except for the excluded ones,
+// and appends the results to chk. The rows left may be discontinuous, so the sliding window can't be used.
+// Only the first numRows rows of the partition can be got by getRow.
+func (e *frameExcluder) appendResult2Chunk(ctx sessionctx.Context, windowFuncs []aggfuncs.AggFunc, partialResults []aggfuncs.PartialResult,
+	getRow func(uint64) chunk.Row, numRows, start, end, curRowIdx uint64, chk *chunk.Chunk) error {
+	var peerStart, peerEnd uint64
+	if e.exclude == ast.ExcludeGroup || e.exclude == ast.ExcludeTies {
+		if err := e.peers.split(ctx, getRow, numRows); err != nil {
+			return err
+		}
+		group := e.peers.groupOf(curRowIdx)
+		peerStart, peerEnd = e.peers.groupStart(group), e.peers.groupEnd(group)
+	}
+	e.frameRows = e.frameRows[:0]
+	for i := start; i < end; i++ {
+		isPeer := i >= peerStart && i < peerEnd
+		switch {
+		case e.exclude == ast.ExcludeCurrentRow && i == curRowIdx,
+			e.exclude == ast.ExcludeGroup && isPeer,
+			e.exclude == ast.ExcludeTies && isPeer && i != curRowIdx:
+			continue
+		}
+		e.frameRows = append(e.frameRows, getRow(i))
+	}
+	for i, windowFunc := range windowFuncs {
+		windowFunc.ResetPartialResult(partialResults[i])
+		if len(e.frameRows) > 0 {
+			if _, err := windowFunc.UpdatePartialResult(ctx, e.frameRows, partialResults[i]); err != nil {
+				return err
+			}
+		}
+		if err := windowFunc.AppendFinalResult2Chunk(ctx, partialResults[i], chk); err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -451,6 +451,36 @@ func baseTestSlidingWindowFunctions(tk *testkit.TestKit) {
 		Check(testkit.Rows("<nil> 11", "<nil> 11", "M 5", "F 5", "F 4", "F 3", "M 2"))
 }
 
+func TestWindowFrameGroupsAndExclusion(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (2, 1), (3, 2), (4, 3), (5, 3), (6, 3)")
+	tk.Session().GetSessionVars().MaxChunkSize = 2
+	for _, pipelined := range []string{"0", "1"} {
+		tk.MustExec("set @@tidb_enable_pipelined_window_function=" + pipelined)
+		tk.MustQuery("select a, sum(a) over (order by b groups between 1 preceding and current row) from t order by a").
+			Check(testkit.Rows("1 3", "2 3", "3 6", "4 18", "5 18", "6 18"))
+		tk.MustQuery("select a, count(a) over (order by b groups between 1 following and unbounded following) from t order by a").
+			Check(testkit.Rows("1 4", "2 4", "3 3", "4 0", "5 0", "6 0"))
+		tk.MustQuery("select a, sum(a) over (partition by b > 1 order by b groups 1 preceding) from t order by a").
+			Check(testkit.Rows("1 3", "2 3", "3 3", "4 18", "5 18", "6 18"))
+		tk.MustQuery("select a, sum(a) over (order by b, a rows between 1 preceding and 1 following exclude current row) from t order by a").
+			Check(testkit.Rows("1 2", "2 4", "3 6", "4 8", "5 10", "6 5"))
+		tk.MustQuery("select a, max(a) over (order by b rows between unbounded preceding and current row exclude group) from t order by a").
+			Check(testkit.Rows("1 <nil>", "2 <nil>", "3 2", "4 3", "5 3", "6 3"))
+		tk.MustQuery("select a, sum(a) over (order by b range between unbounded preceding and unbounded following exclude group) from t order by a").
+			Check(testkit.Rows("1 18", "2 18", "3 18", "4 6", "5 6", "6 6"))
+		tk.MustQuery("select a, sum(a) over (order by b groups between current row and 1 following exclude ties) from t order by a").
+			Check(testkit.Rows("1 4", "2 5", "3 18", "4 4", "5 5", "6 6"))
+		tk.MustQuery("select a, sum(a) over (order by b groups between unbounded preceding and unbounded following exclude no others) from t order by a").
+			Check(testkit.Rows("1 21", "2 21", "3 21", "4 21", "5 21", "6 21"))
+	}
+	tk.MustGetErrCode("select sum(a) over (order by b groups between interval 1 day preceding and current row) from t", mysql.ErrWindowRowsIntervalUse)
+	tk.MustGetErrCode("select sum(a) over (order by b groups between 1.5 preceding and current row) from t", mysql.ErrWindowFrameIllegal)
+}
+
 func TestIssue24264(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
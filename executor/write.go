@@ -26,6 +26,7 @@ import (
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx"
@@ -54,11 +55,14 @@ var (
 func updateRecord(
 	ctx context.Context, sctx sessionctx.Context, h kv.Handle, oldData, newData []types.Datum, modified []bool,
 	t table.Table,
-	onDup bool, _ *memory.Tracker, fkChecks []*FKCheckExec, fkCascades []*FKCascadeExec,
+	onDup bool, _ *memory.Tracker, fkChecks []*FKCheckExec, fkCascades []*FKCascadeExec, triggers *TriggerExec,
 ) (bool, error) {
 	r, ctx := tracing.StartRegionEx(ctx, "executor.updateRecord")
 	defer r.End()
 
+	if err := triggers.fireBefore(ctx, model.TriggerEventUpdate, oldData, newData); err != nil {
+		return false, err
+	}
 	sc := sctx.GetSessionVars().StmtCtx
 	changed, handleChanged := false, false
 	// onUpdateSpecified is for "UPDATE SET ts_field = old_value", the
@@ -150,8 +154,10 @@ func updateRecord(
 		if sctx.GetSessionVars().LockUnchangedKeys {
 			keySet |= lockUniqueKeys
 		}
-		_, err := addUnchangedKeysForLockByRow(sctx, t, h, oldData, keySet)
-		return false, err
+		if _, err := addUnchangedKeysForLockByRow(sctx, t, h, oldData, keySet); err != nil {
+			return false, err
+		}
+		return false, triggers.fireAfter(ctx, model.TriggerEventUpdate, oldData, newData)
 	}
 
 	// Fill values into on-update-now fields, only if they are really changed.
@@ -238,7 +244,9 @@ func updateRecord(
 	}
 	sc.AddUpdatedRows(1)
 	sc.AddCopiedRows(1)
-
+	if err := triggers.fireAfter(ctx, model.TriggerEventUpdate, oldData, newData); err != nil {
+		return false, err
+	}
 	return true, nil
 }
 
@@ -175,7 +175,8 @@ func NeedCount(name string) bool {
 func NeedValue(name string) bool {
 	switch name {
 	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncFirstRow, ast.AggFuncMax, ast.AggFuncMin,
-		ast.AggFuncGroupConcat, ast.AggFuncBitOr, ast.AggFuncBitAnd, ast.AggFuncBitXor, ast.AggFuncApproxPercentile:
+		ast.AggFuncGroupConcat, ast.AggFuncBitOr, ast.AggFuncBitAnd, ast.AggFuncBitXor, ast.AggFuncApproxPercentile,
+		ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
 		return true
 	default:
 		return false
@@ -197,7 +198,12 @@ func CheckAggPushDown(aggFunc *AggFuncDesc, storeType kv.StoreType) bool {
 	if len(aggFunc.OrderByItems) > 0 && aggFunc.Name != ast.AggFuncGroupConcat {
 		return false
 	}
-	if aggFunc.Name == ast.AggFuncApproxPercentile {
+	switch aggFunc.Name {
+	case ast.AggFuncApproxPercentile, ast.AggFuncApproxTopK, ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
+		return false
+	}
+	if IsExtensionAggFunc(aggFunc.Name) {
+		// custom aggregate functions can only be evaluated by TiDB
 		return false
 	}
 	ret := true
@@ -94,6 +94,10 @@ func (a *baseFuncDesc) TypeInfer(ctx sessionctx.Context) error {
 		a.typeInfer4ApproxCountDistinct(ctx)
 	case ast.AggFuncApproxPercentile:
 		return a.typeInfer4ApproxPercentile(ctx)
+	case ast.AggFuncApproxTopK:
+		return a.typeInfer4ApproxTopK(ctx)
+	case ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
+		a.typeInfer4HLLSketch(ctx)
 	case ast.AggFuncSum:
 		a.typeInfer4Sum(ctx)
 	case ast.AggFuncAvg:
@@ -122,6 +126,9 @@ func (a *baseFuncDesc) TypeInfer(ctx sessionctx.Context) error {
 	case ast.AggFuncJsonObjectAgg:
 		return a.typeInfer4JsonObjectAgg(ctx)
 	default:
+		if def, ok := GetExtensionAggFunc(a.Name); ok {
+			return a.typeInfer4Extension(ctx, def)
+		}
 		return errors.Errorf("unsupported agg function: %s", a.Name)
 	}
 	return nil
@@ -180,6 +187,38 @@ func (a *baseFuncDesc) typeInfer4ApproxPercentile(ctx sessionctx.Context) error
 	return nil
 }
 
+// MaxApproxTopK is the largest k accepted by APPROX_TOP_K.
+const MaxApproxTopK = 4096
+
+func (a *baseFuncDesc) typeInfer4ApproxTopK(ctx sessionctx.Context) error {
+	if len(a.Args) != 2 {
+		return errors.New("APPROX_TOP_K should take 2 arguments")
+	}
+	if !a.Args[1].ConstItem(ctx.GetSessionVars().StmtCtx) {
+		return errors.New("APPROX_TOP_K should take a constant expression as k argument")
+	}
+	k, isNull, err := a.Args[1].EvalInt(ctx, chunk.Row{})
+	if err != nil {
+		return fmt.Errorf("APPROX_TOP_K: Invalid argument %s", a.Args[1].String())
+	}
+	if isNull {
+		return errors.New("APPROX_TOP_K: k cannot be NULL")
+	}
+	if k <= 0 || k > MaxApproxTopK {
+		return fmt.Errorf("APPROX_TOP_K: k value %d is out of range [1, %d]", k, MaxApproxTopK)
+	}
+	a.RetTp = types.NewFieldType(mysql.TypeJSON)
+	types.SetBinChsClnFlag(a.RetTp)
+	return nil
+}
+
+// typeInfer4HLLSketch infers the type of hll_sketch and hll_merge, both of which return a serialized sketch.
+func (a *baseFuncDesc) typeInfer4HLLSketch(sessionctx.Context) {
+	a.RetTp = types.NewFieldType(mysql.TypeLongBlob)
+	a.RetTp.SetFlen(mysql.MaxLongBlobWidth)
+	types.SetBinChsClnFlag(a.RetTp)
+}
+
 // typeInfer4Sum should return a "decimal", otherwise it returns a "double".
 // Because child returns integer or decimal type.
 func (a *baseFuncDesc) typeInfer4Sum(sessionctx.Context) {
@@ -346,6 +385,8 @@ func (a *baseFuncDesc) typeInfer4PopOrSamp(sessionctx.Context) {
 }
 
 // GetDefaultValue gets the default value when the function's input is null.
+// The default value of a custom aggregate function is the result of an empty group returned by `Final`,
+// and its error is returned as is.
 // According to MySQL, default values of the function are listed as follows:
 // e.g.
 // Table t which is empty:
@@ -361,7 +402,7 @@ func (a *baseFuncDesc) typeInfer4PopOrSamp(sessionctx.Context) {
 // +--------+--------+----------+------------+-----------+----------------------+--------+--------+-----------------+--------------------------+--------------------------+
 // |   NULL |   NULL |        0 |          0 |         0 | 18446744073709551615 |   NULL |   NULL | NULL            |                        0 |                     NULL |
 // +--------+--------+----------+------------+-----------+----------------------+--------+--------+-----------------+--------------------------+--------------------------+
-func (a *baseFuncDesc) GetDefaultValue() (v types.Datum) {
+func (a *baseFuncDesc) GetDefaultValue() (v types.Datum, err error) {
 	switch a.Name {
 	case ast.AggFuncCount, ast.AggFuncBitOr, ast.AggFuncBitXor:
 		v = types.NewIntDatum(0)
@@ -370,10 +411,15 @@ func (a *baseFuncDesc) GetDefaultValue() (v types.Datum) {
 			v = types.NewIntDatum(0)
 		}
 	case ast.AggFuncFirstRow, ast.AggFuncAvg, ast.AggFuncSum, ast.AggFuncMax,
-		ast.AggFuncMin, ast.AggFuncGroupConcat, ast.AggFuncApproxPercentile:
+		ast.AggFuncMin, ast.AggFuncGroupConcat, ast.AggFuncApproxPercentile,
+		ast.AggFuncApproxTopK, ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
 		v = types.Datum{}
 	case ast.AggFuncBitAnd:
 		v = types.NewUintDatum(uint64(math.MaxUint64))
+	default:
+		if def, ok := GetExtensionAggFunc(a.Name); ok {
+			return def.Final(def.Init())
+		}
 	}
 	return
 }
@@ -384,6 +430,8 @@ var noNeedCastAggFuncs = map[string]struct{}{
 	ast.AggFuncCount:               {},
 	ast.AggFuncApproxCountDistinct: {},
 	ast.AggFuncApproxPercentile:    {},
+	ast.AggFuncApproxTopK:          {},
+	ast.AggFuncHLLSketch:           {},
 	ast.AggFuncMax:                 {},
 	ast.AggFuncMin:                 {},
 	ast.AggFuncFirstRow:            {},
@@ -400,6 +448,10 @@ func (a *baseFuncDesc) WrapCastForAggArgs(ctx sessionctx.Context) {
 	if _, ok := noNeedCastAggFuncs[a.Name]; ok {
 		return
 	}
+	if IsExtensionAggFunc(a.Name) {
+		// The args have been cast to the types required by the definition when inferring the type.
+		return
+	}
 	var castFunc func(ctx sessionctx.Context, expr expression.Expression) expression.Expression
 	switch retTp := a.RetTp; retTp.EvalType() {
 	case types.ETInt:
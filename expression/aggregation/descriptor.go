@@ -146,14 +146,21 @@ func (a *AggFuncDesc) Split(ordinal []int) (partialAggDesc, finalAggDesc *AggFun
 			RetType: a.RetTp,
 		})
 		finalAggDesc.Args = args
-	case ast.AggFuncApproxCountDistinct:
-		args := make([]expression.Expression, 0, 1)
+	case ast.AggFuncApproxCountDistinct, ast.AggFuncApproxTopK:
+		args := make([]expression.Expression, 0, 2)
 		args = append(args, &expression.Column{
 			Index:   ordinal[0],
 			RetType: types.NewFieldType(mysql.TypeString),
 		})
+		if a.Name == ast.AggFuncApproxTopK {
+			args = append(args, a.Args[len(a.Args)-1]) // k
+		}
 		finalAggDesc.Args = args
 	default:
+		if _, ok := GetExtensionAggFunc(a.Name); ok {
+			a.split4Extension(ordinal, finalAggDesc)
+			break
+		}
 		args := make([]expression.Expression, 0, 1)
 		args = append(args, &expression.Column{
 			Index:   ordinal[0],
@@ -213,6 +220,8 @@ func (a *AggFuncDesc) EvalNullValueInOuterJoin(ctx sessionctx.Context, schema *e
 		return a.evalNullValueInOuterJoin4BitAnd(ctx, schema)
 	case ast.AggFuncBitOr, ast.AggFuncBitXor:
 		return a.evalNullValueInOuterJoin4BitOr(ctx, schema)
+	case ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
+		return a.evalNullValueInOuterJoin4HLL(ctx, schema)
 	default:
 		panic("unsupported agg function")
 	}
@@ -296,6 +305,19 @@ func (a *AggFuncDesc) evalNullValueInOuterJoin4BitOr(ctx sessionctx.Context, sch
 	return con.Value, true
 }
 
+// evalNullValueInOuterJoin4HLL returns NULL if any argument becomes NULL on the null-generating side,
+// since hll_sketch skips such rows and the sketch of an empty set is NULL.
+func (a *AggFuncDesc) evalNullValueInOuterJoin4HLL(ctx sessionctx.Context, schema *expression.Schema) (types.Datum, bool) {
+	for _, arg := range a.Args {
+		result := expression.EvaluateExprWithNull(ctx, schema, arg)
+		con, ok := result.(*expression.Constant)
+		if ok && con.Value.IsNull() {
+			return types.Datum{}, true
+		}
+	}
+	return types.Datum{}, false
+}
+
 // UpdateNotNullFlag4RetType checks if we should remove the NotNull flag for the return type of the agg.
 func (a *AggFuncDesc) UpdateNotNullFlag4RetType(hasGroupBy, allAggsFirstRow bool) error {
 	var removeNotNull bool
@@ -307,7 +329,8 @@ func (a *AggFuncDesc) UpdateNotNullFlag4RetType(hasGroupBy, allAggsFirstRow bool
 		ast.WindowFuncLead, ast.WindowFuncLag, ast.AggFuncJsonObjectAgg, ast.AggFuncJsonArrayagg,
 		ast.AggFuncVarSamp, ast.AggFuncVarPop, ast.AggFuncStddevPop, ast.AggFuncStddevSamp:
 		removeNotNull = false
-	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncGroupConcat:
+	case ast.AggFuncSum, ast.AggFuncAvg, ast.AggFuncGroupConcat,
+		ast.AggFuncApproxTopK, ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
 		if !hasGroupBy {
 			removeNotNull = true
 		}
@@ -327,7 +350,10 @@ func (a *AggFuncDesc) UpdateNotNullFlag4RetType(hasGroupBy, allAggsFirstRow bool
 			removeNotNull = true
 		}
 	default:
-		return errors.Errorf("unsupported agg function: %s", a.Name)
+		// The return type of a custom aggregate function is always nullable.
+		if !IsExtensionAggFunc(a.Name) {
+			return errors.Errorf("unsupported agg function: %s", a.Name)
+		}
 	}
 	if removeNotNull {
 		a.RetTp = a.RetTp.Clone()
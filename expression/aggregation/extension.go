@@ -0,0 +1,157 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aggregation
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+)
+
+var extensionAggFuncs sync.Map
+
+// builtinAggFuncs is the names of the builtin aggregate functions, they are resolved before the extension ones,
+// so the extension aggregate functions with the same names would never be called.
+var builtinAggFuncs = map[string]struct{}{
+	ast.AggFuncCount:               {},
+	ast.AggFuncSum:                 {},
+	ast.AggFuncAvg:                 {},
+	ast.AggFuncFirstRow:            {},
+	ast.AggFuncMax:                 {},
+	ast.AggFuncMin:                 {},
+	ast.AggFuncGroupConcat:         {},
+	ast.AggFuncBitOr:               {},
+	ast.AggFuncBitXor:              {},
+	ast.AggFuncBitAnd:              {},
+	ast.AggFuncVarPop:              {},
+	ast.AggFuncVarSamp:             {},
+	ast.AggFuncStddevPop:           {},
+	ast.AggFuncStddevSamp:          {},
+	ast.AggFuncJsonArrayagg:        {},
+	ast.AggFuncJsonObjectAgg:       {},
+	ast.AggFuncApproxCountDistinct: {},
+	ast.AggFuncApproxPercentile:    {},
+	ast.AggFuncApproxTopK:          {},
+	ast.AggFuncHLLSketch:           {},
+	ast.AggFuncHLLMerge:            {},
+}
+
+func registerExtensionAggFunc(def *extension.AggFunctionDef) error {
+	if def == nil {
+		return errors.New("extension aggregate function def is nil")
+	}
+
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	lowerName := strings.ToLower(def.Name)
+	if expression.IsFunctionSupported(lowerName) || expression.IsExtensionFunc(lowerName) {
+		return errors.Errorf("extension aggregate function name '%s' conflict with scalar function", def.Name)
+	}
+
+	if _, ok := builtinAggFuncs[lowerName]; ok {
+		return errors.Errorf("extension aggregate function name '%s' conflict with builtin aggregate function", def.Name)
+	}
+
+	if _, exist := extensionAggFuncs.LoadOrStore(lowerName, def); exist {
+		return errors.Errorf("duplicated extension aggregate function name '%s'", def.Name)
+	}
+
+	return nil
+}
+
+func removeExtensionAggFunc(name string) {
+	extensionAggFuncs.Delete(strings.ToLower(name))
+}
+
+// GetExtensionAggFunc returns the definition of the custom aggregate function registered by extension.
+func GetExtensionAggFunc(name string) (*extension.AggFunctionDef, bool) {
+	def, ok := extensionAggFuncs.Load(strings.ToLower(name))
+	if !ok {
+		return nil, false
+	}
+	return def.(*extension.AggFunctionDef), true
+}
+
+// IsExtensionAggFunc returns whether the aggregate function is registered by extension.
+func IsExtensionAggFunc(name string) bool {
+	_, ok := GetExtensionAggFunc(name)
+	return ok
+}
+
+// typeInfer4Extension casts the arguments to the types required by the definition, and
+// infers the return type of the custom aggregate function.
+func (a *baseFuncDesc) typeInfer4Extension(ctx sessionctx.Context, def *extension.AggFunctionDef) error {
+	if len(a.Args) != len(def.ArgTps) {
+		return expression.ErrIncorrectParameterCount.GenWithStackByArgs(a.Name)
+	}
+
+	for i, tp := range def.ArgTps {
+		switch tp {
+		case types.ETInt:
+			a.Args[i] = expression.WrapWithCastAsInt(ctx, a.Args[i])
+		case types.ETReal:
+			a.Args[i] = expression.WrapWithCastAsReal(ctx, a.Args[i])
+		case types.ETString:
+			a.Args[i] = expression.WrapWithCastAsString(ctx, a.Args[i])
+		}
+	}
+
+	switch def.EvalTp {
+	case types.ETInt:
+		a.RetTp = types.NewFieldType(mysql.TypeLonglong)
+		a.RetTp.SetFlen(mysql.MaxIntWidth)
+		types.SetBinChsClnFlag(a.RetTp)
+	case types.ETReal:
+		a.RetTp = types.NewFieldType(mysql.TypeDouble)
+		a.RetTp.SetFlen(mysql.MaxRealWidth)
+		a.RetTp.SetDecimal(types.UnspecifiedLength)
+		types.SetBinChsClnFlag(a.RetTp)
+	case types.ETString:
+		a.RetTp = types.NewFieldType(mysql.TypeVarString)
+		a.RetTp.SetFlen(mysql.MaxFieldVarCharLength)
+		chs, coll := ctx.GetSessionVars().GetCharsetInfo()
+		a.RetTp.SetCharset(chs)
+		a.RetTp.SetCollate(coll)
+	default:
+		return errors.Errorf("unsupported extension aggregate function ret type: '%v'", def.EvalTp)
+	}
+	return nil
+}
+
+func init() {
+	extension.RegisterExtensionAggFunc = registerExtensionAggFunc
+	extension.RemoveExtensionAggFunc = removeExtensionAggFunc
+}
+
+// split4Extension sets the final phase of the custom aggregate function split by `Split`. The intermediate result
+// is the encoded state, and the final phase still outputs the encoded state if the original one is partial.
+func (a *AggFuncDesc) split4Extension(ordinal []int, finalAggDesc *AggFuncDesc) {
+	if a.Mode == Partial1Mode || a.Mode == Partial2Mode {
+		finalAggDesc.Mode = Partial2Mode
+	}
+	finalAggDesc.Args = []expression.Expression{&expression.Column{
+		Index:   ordinal[0],
+		RetType: types.NewFieldType(mysql.TypeString),
+	}}
+}
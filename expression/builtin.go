@@ -371,6 +371,10 @@ func (b *baseBuiltinFunc) getCtx() sessionctx.Context {
 	return b.ctx
 }
 
+func (b *baseBuiltinFunc) setCtx(ctx sessionctx.Context) {
+	b.ctx = ctx
+}
+
 func (b *baseBuiltinFunc) cloneFrom(from *baseBuiltinFunc) {
 	b.args = make([]Expression, 0, len(b.args))
 	for _, arg := range from.args {
@@ -484,6 +488,8 @@ type builtinFunc interface {
 	equal(builtinFunc) bool
 	// getCtx returns this function's context.
 	getCtx() sessionctx.Context
+	// setCtx sets this function's context.
+	setCtx(sessionctx.Context)
 	// getRetTp returns the return type of the built-in function.
 	getRetTp() *types.FieldType
 	// setPbCode sets pbCode for signature.
@@ -800,6 +806,7 @@ var funcs = map[string]functionClass{
 	ast.TiDBShard:       &tidbShardFunctionClass{baseFunctionClass{ast.TiDBShard, 1, 1}},
 	ast.TiDBRowChecksum: &tidbRowChecksumFunctionClass{baseFunctionClass{ast.TiDBRowChecksum, 0, 0}},
 	ast.Grouping:        &groupingImplFunctionClass{baseFunctionClass{ast.Grouping, 1, 1}},
+	ast.HLLEstimate:     &hllEstimateFunctionClass{baseFunctionClass{ast.HLLEstimate, 1, 1}},
 
 	ast.GetLock:     &lockFunctionClass{baseFunctionClass{ast.GetLock, 2, 2}},
 	ast.ReleaseLock: &releaseLockFunctionClass{baseFunctionClass{ast.ReleaseLock, 1, 1}},
@@ -895,6 +902,25 @@ var funcs = map[string]functionClass{
 	ast.JSONKeys:          &jsonKeysFunctionClass{baseFunctionClass{ast.JSONKeys, 1, 2}},
 	ast.JSONLength:        &jsonLengthFunctionClass{baseFunctionClass{ast.JSONLength, 1, 2}},
 
+	// spatial functions
+	ast.STGeomFromText:     &stGeomFromTextFunctionClass{baseFunctionClass{ast.STGeomFromText, 1, 2}},
+	ast.STGeometryFromText: &stGeomFromTextFunctionClass{baseFunctionClass{ast.STGeometryFromText, 1, 2}},
+	ast.STGeomFromWKB:      &stGeomFromWKBFunctionClass{baseFunctionClass{ast.STGeomFromWKB, 1, 2}},
+	ast.STGeometryFromWKB:  &stGeomFromWKBFunctionClass{baseFunctionClass{ast.STGeometryFromWKB, 1, 2}},
+	ast.STAsText:           &stAsTextFunctionClass{baseFunctionClass{ast.STAsText, 1, 1}},
+	ast.STAsWKT:            &stAsTextFunctionClass{baseFunctionClass{ast.STAsWKT, 1, 1}},
+	ast.STAsBinary:         &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsBinary, 1, 1}},
+	ast.STAsWKB:            &stAsBinaryFunctionClass{baseFunctionClass{ast.STAsWKB, 1, 1}},
+	ast.STSRID:             &stSRIDFunctionClass{baseFunctionClass{ast.STSRID, 1, 1}},
+	ast.STGeometryType:     &stGeometryTypeFunctionClass{baseFunctionClass{ast.STGeometryType, 1, 1}},
+	ast.STX:                &stCoordinateFunctionClass{baseFunctionClass{ast.STX, 1, 1}},
+	ast.STY:                &stCoordinateFunctionClass{baseFunctionClass{ast.STY, 1, 1}},
+	ast.Point:              &pointFunctionClass{baseFunctionClass{ast.Point, 2, 2}},
+	ast.STDistance:         &stDistanceFunctionClass{baseFunctionClass{ast.STDistance, 2, 2}},
+	ast.STContains:         &stRelationFunctionClass{baseFunctionClass{ast.STContains, 2, 2}},
+	ast.STWithin:           &stRelationFunctionClass{baseFunctionClass{ast.STWithin, 2, 2}},
+	ast.STIntersects:       &stRelationFunctionClass{baseFunctionClass{ast.STIntersects, 2, 2}},
+
 	// TiDB internal function.
 	ast.TiDBDecodeKey: &tidbDecodeKeyFunctionClass{baseFunctionClass{ast.TiDBDecodeKey, 1, 1}},
 	// This function is used to show tidb-server version info.
@@ -0,0 +1,112 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"math"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/codec"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+var (
+	_ functionClass = &hllEstimateFunctionClass{}
+)
+
+var (
+	_ builtinFunc = &builtinHLLEstimateSig{}
+)
+
+// maxHLLSketchSkipDegree is the largest skip degree of a sketch, the hash values are 32 bits.
+const maxHLLSketchSkipDegree = 32
+
+// EstimateHLLSketchSize returns the estimated distinct count of a sketch produced by
+// APPROX_COUNT_DISTINCT, HLL_SKETCH or HLL_MERGE, from the number of hash values it
+// keeps and the number of low bits those hash values are thinned by.
+func EstimateHLLSketchSize(size uint64, skipDegree uint8) uint64 {
+	if skipDegree == 0 {
+		return size
+	}
+
+	res := size * (uint64(1) << skipDegree)
+
+	// Pseudo-random remainder.
+	res += intHash64(size) & ((uint64(1) << skipDegree) - 1)
+
+	// When different elements randomly scattered across 2^32 buckets, filled buckets with average of `res` obtained.
+	p32 := uint64(1) << 32
+	fixedRes := math.Round(float64(p32) * (math.Log(float64(p32)) - math.Log(float64(p32-res))))
+	return uint64(fixedRes)
+}
+
+func intHash64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+type hllEstimateFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *hllEstimateFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlag(bf.tp.GetFlag() | mysql.UnsignedFlag)
+	sig := &builtinHLLEstimateSig{bf}
+	return sig, nil
+}
+
+type builtinHLLEstimateSig struct {
+	baseBuiltinFunc
+}
+
+func (b *builtinHLLEstimateSig) Clone() builtinFunc {
+	newSig := &builtinHLLEstimateSig{}
+	newSig.cloneFrom(&b.baseBuiltinFunc)
+	return newSig
+}
+
+// evalInt evals HLL_ESTIMATE(sketch), the estimated distinct count of a sketch
+// returned by HLL_SKETCH or HLL_MERGE.
+func (b *builtinHLLEstimateSig) evalInt(row chunk.Row) (int64, bool, error) {
+	val, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if err != nil || isNull {
+		return 0, isNull, err
+	}
+	sketch := hack.Slice(val)
+	if len(sketch) == 0 || sketch[0] > maxHLLSketchSkipDegree {
+		return 0, false, errIncorrectArgs.GenWithStackByArgs(ast.HLLEstimate)
+	}
+	skipDegree := sketch[0]
+	rest, size, err := codec.DecodeUvarint(sketch[1:])
+	if err != nil || uint64(len(rest)) < size*4 {
+		return 0, false, errIncorrectArgs.GenWithStackByArgs(ast.HLLEstimate)
+	}
+	return int64(EstimateHLLSketchSize(size, skipDegree)), false, nil
+}
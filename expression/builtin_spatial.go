@@ -0,0 +1,620 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package expression
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/hack"
+)
+
+var (
+	_ functionClass = &stGeomFromTextFunctionClass{}
+	_ functionClass = &stGeomFromWKBFunctionClass{}
+	_ functionClass = &stAsTextFunctionClass{}
+	_ functionClass = &stAsBinaryFunctionClass{}
+	_ functionClass = &stSRIDFunctionClass{}
+	_ functionClass = &stGeometryTypeFunctionClass{}
+	_ functionClass = &stCoordinateFunctionClass{}
+	_ functionClass = &pointFunctionClass{}
+	_ functionClass = &stDistanceFunctionClass{}
+	_ functionClass = &stRelationFunctionClass{}
+
+	_ builtinFunc = &builtinSTGeomFromTextSig{}
+	_ builtinFunc = &builtinSTGeomFromWKBSig{}
+	_ builtinFunc = &builtinSTAsTextSig{}
+	_ builtinFunc = &builtinSTAsBinarySig{}
+	_ builtinFunc = &builtinSTSRIDSig{}
+	_ builtinFunc = &builtinSTGeometryTypeSig{}
+	_ builtinFunc = &builtinSTCoordinateSig{}
+	_ builtinFunc = &builtinPointSig{}
+	_ builtinFunc = &builtinSTDistanceSig{}
+	_ builtinFunc = &builtinSTRelationSig{}
+)
+
+// baseBuiltinSpatialFunc is the base of the spatial functions, funcName is used in the error messages.
+type baseBuiltinSpatialFunc struct {
+	baseBuiltinFunc
+	funcName string
+}
+
+func (b *baseBuiltinSpatialFunc) cloneFrom(from *baseBuiltinSpatialFunc) {
+	b.baseBuiltinFunc.cloneFrom(&from.baseBuiltinFunc)
+	b.funcName = from.funcName
+}
+
+// setGeometryRetType sets the return type of the function to a geometry.
+func setGeometryRetType(tp *types.FieldType) {
+	tp.SetType(mysql.TypeGeometry)
+	tp.SetGeometryType(mysql.GeometryTypeGeometry)
+	tp.SetFlen(mysql.MaxBlobWidth)
+	types.SetBinChsClnFlag(tp)
+}
+
+// evalGeometry evaluates arg and decodes the geometry from the storage format.
+func evalGeometry(ctx sessionctx.Context, funcName string, arg Expression, row chunk.Row) (srid uint32, g types.Geometry, isNull bool, err error) {
+	val, isNull, err := arg.EvalString(ctx, row)
+	if isNull || err != nil {
+		return 0, g, isNull, err
+	}
+	srid, g, err = types.DecodeGeometry(hack.Slice(val))
+	if err != nil {
+		return 0, g, false, ErrGISInvalidData.GenWithStackByArgs(funcName)
+	}
+	return srid, g, false, nil
+}
+
+// evalGeometryPair evaluates the two geometry arguments of a binary spatial function,
+// the geometries must be in the same spatial reference system.
+func evalGeometryPair(ctx sessionctx.Context, funcName string, args []Expression, row chunk.Row) (g1, g2 types.Geometry, isNull bool, err error) {
+	srid1, g1, isNull, err := evalGeometry(ctx, funcName, args[0], row)
+	if isNull || err != nil {
+		return g1, g2, isNull, err
+	}
+	srid2, g2, isNull, err := evalGeometry(ctx, funcName, args[1], row)
+	if isNull || err != nil {
+		return g1, g2, isNull, err
+	}
+	if srid1 != srid2 {
+		return g1, g2, false, ErrGISDifferentSRIDs.GenWithStackByArgs(funcName, srid1, srid2)
+	}
+	return g1, g2, false, nil
+}
+
+// evalSRID evaluates the optional SRID argument of the geometry constructors.
+func evalSRID(ctx sessionctx.Context, funcName string, args []Expression, row chunk.Row) (srid uint32, isNull bool, err error) {
+	if len(args) < 2 {
+		return 0, false, nil
+	}
+	val, isNull, err := args[1].EvalInt(ctx, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if val < 0 || val > math.MaxUint32 {
+		return 0, false, errIncorrectArgs.GenWithStackByArgs(funcName)
+	}
+	return uint32(val), false, nil
+}
+
+type stGeomFromTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp)
+	return &builtinSTGeomFromTextSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+type builtinSTGeomFromTextSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTGeomFromTextSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromTextSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalString evals ST_GeomFromText(wkt[, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkt-functions.html#function_st-geomfromtext
+func (b *builtinSTGeomFromTextSig) evalString(row chunk.Row) (string, bool, error) {
+	wkt, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := evalSRID(b.ctx, b.funcName, b.args, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g, err := types.ParseGeometryWKT(wkt)
+	if err != nil {
+		return "", false, ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	return string(types.EncodeGeometry(srid, g)), false, nil
+}
+
+type stGeomFromWKBFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeomFromWKBFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	argTps := []types.EvalType{types.ETString, types.ETInt}[:len(args)]
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, argTps...)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp)
+	return &builtinSTGeomFromWKBSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+type builtinSTGeomFromWKBSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTGeomFromWKBSig) Clone() builtinFunc {
+	newSig := &builtinSTGeomFromWKBSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalString evals ST_GeomFromWKB(wkb[, srid]).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-wkb-functions.html#function_st-geomfromwkb
+func (b *builtinSTGeomFromWKBSig) evalString(row chunk.Row) (string, bool, error) {
+	wkb, isNull, err := b.args[0].EvalString(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	srid, isNull, err := evalSRID(b.ctx, b.funcName, b.args, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g, err := types.ParseGeometryWKB(hack.Slice(wkb))
+	if err != nil {
+		return "", false, ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	return string(types.EncodeGeometry(srid, g)), false, nil
+}
+
+type stAsTextFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsTextFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetSessionVars().GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	return &builtinSTAsTextSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+type builtinSTAsTextSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTAsTextSig) Clone() builtinFunc {
+	newSig := &builtinSTAsTextSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalString evals ST_AsText(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-astext
+func (b *builtinSTAsTextSig) evalString(row chunk.Row) (string, bool, error) {
+	_, g, isNull, err := evalGeometry(b.ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.WKT(), false, nil
+}
+
+type stAsBinaryFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stAsBinaryFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	types.SetBinChsClnFlag(bf.tp)
+	bf.tp.SetFlen(mysql.MaxBlobWidth)
+	return &builtinSTAsBinarySig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+type builtinSTAsBinarySig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTAsBinarySig) Clone() builtinFunc {
+	newSig := &builtinSTAsBinarySig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalString evals ST_AsBinary(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-format-conversion-functions.html#function_st-asbinary
+func (b *builtinSTAsBinarySig) evalString(row chunk.Row) (string, bool, error) {
+	_, g, isNull, err := evalGeometry(b.ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return string(g.WKB()), false, nil
+}
+
+type stSRIDFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stSRIDFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(10)
+	bf.tp.AddFlag(mysql.UnsignedFlag)
+	return &builtinSTSRIDSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+type builtinSTSRIDSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTSRIDSig) Clone() builtinFunc {
+	newSig := &builtinSTSRIDSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalInt evals ST_SRID(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-srid
+func (b *builtinSTSRIDSig) evalInt(row chunk.Row) (int64, bool, error) {
+	srid, _, isNull, err := evalGeometry(b.ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	return int64(srid), false, nil
+}
+
+type stGeometryTypeFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stGeometryTypeFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	charset, collate := ctx.GetSessionVars().GetCharsetInfo()
+	bf.tp.SetCharset(charset)
+	bf.tp.SetCollate(collate)
+	bf.tp.SetFlen(len("GEOMETRYCOLLECTION"))
+	return &builtinSTGeometryTypeSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+type builtinSTGeometryTypeSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTGeometryTypeSig) Clone() builtinFunc {
+	newSig := &builtinSTGeometryTypeSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalString evals ST_GeometryType(g).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-general-property-functions.html#function_st-geometrytype
+func (b *builtinSTGeometryTypeSig) evalString(row chunk.Row) (string, bool, error) {
+	_, g, isNull, err := evalGeometry(b.ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	return g.GeometryTypeName(), false, nil
+}
+
+type stCoordinateFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stCoordinateFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTCoordinateSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+// builtinSTCoordinateSig evals ST_X(p) and ST_Y(p).
+type builtinSTCoordinateSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTCoordinateSig) Clone() builtinFunc {
+	newSig := &builtinSTCoordinateSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalReal evals ST_X(p) or ST_Y(p).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-point-property-functions.html
+func (b *builtinSTCoordinateSig) evalReal(row chunk.Row) (float64, bool, error) {
+	_, g, isNull, err := evalGeometry(b.ctx, b.funcName, b.args[0], row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g.Tp != mysql.GeometryTypePoint {
+		return 0, false, ErrGISInvalidData.GenWithStackByArgs(b.funcName)
+	}
+	if b.funcName == ast.STX {
+		return g.Points[0].X, false, nil
+	}
+	return g.Points[0].Y, false, nil
+}
+
+type pointFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *pointFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETString, types.ETReal, types.ETReal)
+	if err != nil {
+		return nil, err
+	}
+	setGeometryRetType(bf.tp)
+	bf.tp.SetGeometryType(mysql.GeometryTypePoint)
+	return &builtinPointSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+type builtinPointSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinPointSig) Clone() builtinFunc {
+	newSig := &builtinPointSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalString evals Point(x, y).
+// See https://dev.mysql.com/doc/refman/8.0/en/gis-mysql-specific-functions.html#function_point
+func (b *builtinPointSig) evalString(row chunk.Row) (string, bool, error) {
+	x, isNull, err := b.args[0].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	y, isNull, err := b.args[1].EvalReal(b.ctx, row)
+	if isNull || err != nil {
+		return "", isNull, err
+	}
+	g := types.Geometry{Tp: mysql.GeometryTypePoint, Points: []types.GeoPoint{{X: x, Y: y}}}
+	return string(types.EncodeGeometry(0, g)), false, nil
+}
+
+type stDistanceFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stDistanceFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETReal, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	return &builtinSTDistanceSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+type builtinSTDistanceSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTDistanceSig) Clone() builtinFunc {
+	newSig := &builtinSTDistanceSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalReal evals ST_Distance(g1, g2), it returns NULL if either geometry is empty.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html#function_st-distance
+func (b *builtinSTDistanceSig) evalReal(row chunk.Row) (float64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.funcName, b.args, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return 0, true, nil
+	}
+	return types.GeometryDistance(g1, g2), false, nil
+}
+
+type stRelationFunctionClass struct {
+	baseFunctionClass
+}
+
+func (c *stRelationFunctionClass) getFunction(ctx sessionctx.Context, args []Expression) (builtinFunc, error) {
+	if err := c.verifyArgs(args); err != nil {
+		return nil, err
+	}
+	bf, err := newBaseBuiltinFuncWithTp(ctx, c.funcName, args, types.ETInt, types.ETString, types.ETString)
+	if err != nil {
+		return nil, err
+	}
+	bf.tp.SetFlen(1)
+	return &builtinSTRelationSig{baseBuiltinSpatialFunc{bf, c.funcName}}, nil
+}
+
+// builtinSTRelationSig evals the spatial relation functions ST_Contains, ST_Within and ST_Intersects.
+type builtinSTRelationSig struct {
+	baseBuiltinSpatialFunc
+}
+
+func (b *builtinSTRelationSig) Clone() builtinFunc {
+	newSig := &builtinSTRelationSig{}
+	newSig.cloneFrom(&b.baseBuiltinSpatialFunc)
+	return newSig
+}
+
+// evalInt evals the spatial relation between g1 and g2.
+// See https://dev.mysql.com/doc/refman/8.0/en/spatial-relation-functions-object-shapes.html
+func (b *builtinSTRelationSig) evalInt(row chunk.Row) (int64, bool, error) {
+	g1, g2, isNull, err := evalGeometryPair(b.ctx, b.funcName, b.args, row)
+	if isNull || err != nil {
+		return 0, isNull, err
+	}
+	var res bool
+	switch b.funcName {
+	case ast.STContains:
+		res = types.GeometryContains(g1, g2)
+	case ast.STWithin:
+		res = types.GeometryContains(g2, g1)
+	default:
+		res = types.GeometryIntersects(g1, g2)
+	}
+	if res {
+		return 1, false, nil
+	}
+	return 0, false, nil
+}
+
+// SpatialWindow is the area that a geometry must intersect to satisfy a spatial condition,
+// it's used to filter the MBRs stored in a spatial index.
+type SpatialWindow struct {
+	Geom Expression
+	// Distance is not nil if the condition is `ST_Distance(col, Geom) < Distance`, the window
+	// is then the MBR of Geom expanded by Distance.
+	Distance Expression
+}
+
+// Clone clones the SpatialWindow.
+func (w *SpatialWindow) Clone() *SpatialWindow {
+	cloned := &SpatialWindow{Geom: w.Geom.Clone()}
+	if w.Distance != nil {
+		cloned.Distance = w.Distance.Clone()
+	}
+	return cloned
+}
+
+// String implements the fmt.Stringer interface.
+func (w *SpatialWindow) String() string {
+	if w.Distance == nil {
+		return w.Geom.String()
+	}
+	return fmt.Sprintf("%s, distance:%s", w.Geom, w.Distance)
+}
+
+// Eval evaluates the MBR of the window. The window is empty if the geometry or the distance is NULL,
+// since no row can satisfy the condition then.
+func (w *SpatialWindow) Eval(ctx sessionctx.Context) (types.MBR, error) {
+	_, g, isNull, err := evalGeometry(ctx, "spatial index", w.Geom, chunk.Row{})
+	if isNull || err != nil {
+		return types.EmptyMBR(), err
+	}
+	mbr := g.Envelope()
+	if w.Distance == nil {
+		return mbr, nil
+	}
+	d, isNull, err := w.Distance.EvalReal(ctx, chunk.Row{})
+	if isNull || err != nil || d < 0 {
+		return types.EmptyMBR(), err
+	}
+	return mbr.Expand(d), nil
+}
+
+// ExtractSpatialWindow extracts the window of col from the conditions. It recognizes
+// ST_Contains, ST_Within and ST_Intersects between col and a constant geometry, and
+// ST_Distance(col, geometry) compared with a constant distance. It returns nil if none
+// of the conditions can be used.
+func ExtractSpatialWindow(conds []Expression, col *Column) *SpatialWindow {
+	for _, cond := range conds {
+		sf, ok := cond.(*ScalarFunction)
+		if !ok {
+			continue
+		}
+		args := sf.GetArgs()
+		switch sf.FuncName.L {
+		case ast.STContains, ast.STWithin, ast.STIntersects:
+			if geom := spatialWindowGeom(args, col); geom != nil {
+				return &SpatialWindow{Geom: geom}
+			}
+		case ast.LT, ast.LE, ast.GT, ast.GE:
+			distFunc, dist := args[0], args[1]
+			if sf.FuncName.L == ast.GT || sf.FuncName.L == ast.GE {
+				distFunc, dist = args[1], args[0]
+			}
+			f, ok := distFunc.(*ScalarFunction)
+			if !ok || f.FuncName.L != ast.STDistance || !isSpatialWindowConst(dist) {
+				continue
+			}
+			if geom := spatialWindowGeom(f.GetArgs(), col); geom != nil {
+				return &SpatialWindow{Geom: geom, Distance: dist}
+			}
+		}
+	}
+	return nil
+}
+
+// spatialWindowGeom returns the constant side of a binary spatial function if the other side is col.
+func spatialWindowGeom(args []Expression, col *Column) Expression {
+	if col.Equal(nil, args[0]) && isSpatialWindowConst(args[1]) {
+		return args[1]
+	}
+	if col.Equal(nil, args[1]) && isSpatialWindowConst(args[0]) {
+		return args[0]
+	}
+	return nil
+}
+
+func isSpatialWindowConst(expr Expression) bool {
+	return len(ExtractColumns(expr)) == 0 && len(ExtractCorColumns(expr)) == 0
+}
@@ -41,6 +41,8 @@ var (
 	ErrInvalidJSONForFuncIndex     = dbterror.ClassExpression.NewStd(mysql.ErrInvalidJSONValueForFuncIndex)
 	ErrDataOutOfRangeFuncIndex     = dbterror.ClassExpression.NewStd(mysql.ErrDataOutOfRangeFunctionalIndex)
 	ErrFuncIndexDataIsTooLong      = dbterror.ClassExpression.NewStd(mysql.ErrFunctionalIndexDataIsTooLong)
+	ErrGISDifferentSRIDs           = dbterror.ClassExpression.NewStd(mysql.ErrGISDifferentSRIDs)
+	ErrGISInvalidData              = dbterror.ClassExpression.NewStd(mysql.ErrGISInvalidData)
 
 	// All the un-exported errors are defined here:
 	errFunctionNotExists             = dbterror.ClassExpression.NewStd(mysql.ErrSpDoesNotExist)
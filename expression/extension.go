@@ -64,6 +64,12 @@ func removeExtensionFunc(name string) {
 	extensionFuncs.Delete(name)
 }
 
+// IsExtensionFunc returns whether the function is a custom function registered by extension.
+func IsExtensionFunc(name string) bool {
+	_, ok := extensionFuncs.Load(strings.ToLower(name))
+	return ok
+}
+
 type extensionFuncClass struct {
 	baseFunctionClass
 	funcDef extension.FunctionDef
@@ -485,6 +485,36 @@ func RollupGroupingSets(rollupExprs []Expression) GroupingSets {
 	return res
 }
 
+// CubeGroupingSets generates all the subsets of the cube items as grouping sets.
+func CubeGroupingSets(cubeExprs []Expression) GroupingSets {
+	// eg: [a,b] => {}, {a}, {b}, {a,b}
+	res := make(GroupingSets, 0, 1<<len(cubeExprs))
+	for mask := 0; mask < 1<<len(cubeExprs); mask++ {
+		groupingExprs := GroupingExprs{}
+		for j := range cubeExprs {
+			if mask&(1<<j) != 0 {
+				groupingExprs = append(groupingExprs, cubeExprs[j])
+			}
+		}
+		res = append(res, newGroupingSet(groupingExprs))
+	}
+	return res
+}
+
+// ListGroupingSets generates the grouping sets specified by GROUPING SETS (...), every set is the offsets of its items.
+func ListGroupingSets(exprs []Expression, sets [][]int) GroupingSets {
+	// eg: [a,b,a], [[0,1],[2],[]] => {a,b}, {a}, {}
+	res := make(GroupingSets, 0, len(sets))
+	for _, set := range sets {
+		groupingExprs := make(GroupingExprs, 0, len(set))
+		for _, offset := range set {
+			groupingExprs = append(groupingExprs, exprs[offset])
+		}
+		res = append(res, newGroupingSet(groupingExprs))
+	}
+	return res
+}
+
 // AdjustNullabilityFromGroupingSets adjust the nullability of the Expand schema out.
 func AdjustNullabilityFromGroupingSets(gss GroupingSets, schema *Schema) {
 	// If anyone (grouping set) of the grouping sets doesn't include one grouping-set col, meaning that
@@ -246,6 +246,29 @@ func TestRollupGroupingSets(t *testing.T) {
 	require.Equal(t, mysql.HasNotNullFlag(expandSchema.Columns[3].RetType.GetFlag()), true)
 }
 
+func TestCubeAndListGroupingSets(t *testing.T) {
+	defer view.Stop()
+	a := &Column{UniqueID: 1, RetType: types.NewFieldType(mysql.TypeLong)}
+	b := &Column{UniqueID: 2, RetType: types.NewFieldType(mysql.TypeLong)}
+	c := &Column{UniqueID: 3, RetType: types.NewFieldType(mysql.TypeLong)}
+
+	cubeGroupingSets := CubeGroupingSets([]Expression{a, b, c})
+	require.Equal(t, 8, len(cubeGroupingSets))
+	require.Equal(t, "[{<>},{<Column#1>},{<Column#2>},{<Column#1,Column#2>},{<Column#3>},{<Column#1,Column#3>},{<Column#2,Column#3>},{<Column#1,Column#2,Column#3>}]", cubeGroupingSets.String())
+	distinctSize, _, _ := cubeGroupingSets.DistinctSize()
+	require.Equal(t, 8, distinctSize)
+
+	listGroupingSets := ListGroupingSets([]Expression{a, b, a, c}, [][]int{{0, 1}, {2}, {}, {3, 0}})
+	require.Equal(t, 4, len(listGroupingSets))
+	require.Equal(t, "[{<Column#1,Column#2>},{<Column#1>},{<>},{<Column#3,Column#1>}]", listGroupingSets.String())
+
+	// the duplicate sets are kept, they are distinguished by gpos.
+	listGroupingSets = ListGroupingSets([]Expression{a, a}, [][]int{{0}, {1}})
+	require.Equal(t, 2, len(listGroupingSets))
+	distinctSize, _, _ = listGroupingSets.DistinctSize()
+	require.Equal(t, 1, distinctSize)
+}
+
 func TestGroupingSetsMergeUnitTest(t *testing.T) {
 	defer view.Stop()
 	a := &Column{
@@ -391,6 +391,26 @@ func extractColumnSet(expr Expression, set *intsets.Sparse) {
 	}
 }
 
+// SetCtxForPlanCache binds the expression cloned from a plan cached by another session to ctx, so the
+// parameters and the functions in it are evaluated with ctx. The expression must be cloned before.
+func SetCtxForPlanCache(expr Expression, ctx sessionctx.Context) {
+	switch x := expr.(type) {
+	case *ScalarFunction:
+		x.Function.setCtx(ctx)
+		for _, arg := range x.GetArgs() {
+			SetCtxForPlanCache(arg, ctx)
+		}
+	case *Constant:
+		if x.ParamMarker != nil {
+			x.ParamMarker = &ParamMarker{ctx: ctx, order: x.ParamMarker.order}
+		}
+		if x.DeferredExpr != nil {
+			x.DeferredExpr = x.DeferredExpr.Clone()
+			SetCtxForPlanCache(x.DeferredExpr, ctx)
+		}
+	}
+}
+
 // SetExprColumnInOperand is used to set columns in expr as InOperand.
 func SetExprColumnInOperand(expr Expression) Expression {
 	switch v := expr.(type) {
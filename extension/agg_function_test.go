@@ -0,0 +1,278 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extension_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+type weightedAvgState struct {
+	sum    float64
+	weight float64
+}
+
+// customWeightedAvg computes sum(value * weight) / sum(weight)
+var customWeightedAvg = &extension.AggFunctionDef{
+	Name:   "custom_wavg",
+	EvalTp: types.ETReal,
+	ArgTps: []types.EvalType{types.ETReal, types.ETReal},
+	Init: func() any {
+		return &weightedAvgState{}
+	},
+	Update: func(state any, args []types.Datum) (any, error) {
+		s := state.(*weightedAvgState)
+		if args[0].IsNull() || args[1].IsNull() {
+			return s, nil
+		}
+		if args[1].GetFloat64() < 0 {
+			return nil, errors.New("negative weight")
+		}
+		s.sum += args[0].GetFloat64() * args[1].GetFloat64()
+		s.weight += args[1].GetFloat64()
+		return s, nil
+	},
+	Merge: func(dst, src any) (any, error) {
+		d, s := dst.(*weightedAvgState), src.(*weightedAvgState)
+		d.sum += s.sum
+		d.weight += s.weight
+		return d, nil
+	},
+	Final: func(state any) (types.Datum, error) {
+		s := state.(*weightedAvgState)
+		if s.weight == 0 {
+			return types.Datum{}, nil
+		}
+		return types.NewFloat64Datum(s.sum / s.weight), nil
+	},
+	EncodeState: func(state any) ([]byte, error) {
+		s := state.(*weightedAvgState)
+		data := make([]byte, 16)
+		binary.BigEndian.PutUint64(data, math.Float64bits(s.sum))
+		binary.BigEndian.PutUint64(data[8:], math.Float64bits(s.weight))
+		return data, nil
+	},
+	DecodeState: func(data []byte) (any, error) {
+		if len(data) != 16 {
+			return nil, errors.New("invalid state")
+		}
+		return &weightedAvgState{
+			sum:    math.Float64frombits(binary.BigEndian.Uint64(data)),
+			weight: math.Float64frombits(binary.BigEndian.Uint64(data[8:])),
+		}, nil
+	},
+}
+
+// customConcatSorted concatenates the distinct values in order, and its state can not be encoded.
+var customConcatSorted = &extension.AggFunctionDef{
+	Name:   "custom_concat_sorted",
+	EvalTp: types.ETString,
+	ArgTps: []types.EvalType{types.ETString},
+	Init: func() any {
+		return map[string]struct{}{}
+	},
+	Update: func(state any, args []types.Datum) (any, error) {
+		s := state.(map[string]struct{})
+		if !args[0].IsNull() {
+			s[args[0].GetString()] = struct{}{}
+		}
+		return s, nil
+	},
+	Merge: func(dst, src any) (any, error) {
+		d := dst.(map[string]struct{})
+		for k := range src.(map[string]struct{}) {
+			d[k] = struct{}{}
+		}
+		return d, nil
+	},
+	Final: func(state any) (types.Datum, error) {
+		s := state.(map[string]struct{})
+		if len(s) == 0 {
+			return types.Datum{}, nil
+		}
+		values := make([]string, 0, len(s))
+		for k := range s {
+			values = append(values, k)
+		}
+		for i := 1; i < len(values); i++ {
+			for j := i; j > 0 && values[j] < values[j-1]; j-- {
+				values[j], values[j-1] = values[j-1], values[j]
+			}
+		}
+		return types.NewStringDatum(strings.Join(values, "|")), nil
+	},
+}
+
+func TestRegisterExtensionAggFunc(t *testing.T) {
+	defer extension.Reset()
+
+	// nil func
+	extension.Reset()
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{
+		customWeightedAvg,
+		nil,
+	})))
+	require.EqualError(t, extension.Setup(), "extension aggregate function def is nil")
+
+	// empty func name
+	extension.Reset()
+	def := *customWeightedAvg
+	def.Name = ""
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def})))
+	require.EqualError(t, extension.Setup(), "extension aggregate function name should not be empty")
+
+	// unsupported type
+	extension.Reset()
+	def = *customWeightedAvg
+	def.ArgTps = []types.EvalType{types.ETDecimal}
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def})))
+	require.EqualError(t, extension.Setup(), "unsupported type '2' in extension aggregate function 'custom_wavg'")
+
+	// missing callbacks
+	extension.Reset()
+	def = *customWeightedAvg
+	def.Merge = nil
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def})))
+	require.EqualError(t, extension.Setup(), "Init, Update, Merge and Final of extension aggregate function 'custom_wavg' should not be nil")
+
+	extension.Reset()
+	def = *customWeightedAvg
+	def.DecodeState = nil
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def})))
+	require.EqualError(t, extension.Setup(), "EncodeState and DecodeState of extension aggregate function 'custom_wavg' should be provided together")
+
+	// dup name with builtin
+	extension.Reset()
+	def = *customWeightedAvg
+	def.Name = "substring"
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def})))
+	require.EqualError(t, extension.Setup(), "extension aggregate function name 'substring' conflict with scalar function")
+
+	// dup name with builtin aggregate function
+	for _, name := range []string{"sum", "MAX", "approx_count_distinct"} {
+		extension.Reset()
+		def = *customWeightedAvg
+		def.Name = name
+		require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{&def})))
+		require.EqualError(t, extension.Setup(), fmt.Sprintf("extension aggregate function name '%s' conflict with builtin aggregate function", name))
+	}
+
+	// dup name with other func in different extension
+	extension.Reset()
+	require.NoError(t, extension.Register("test1", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{customWeightedAvg})))
+	require.NoError(t, extension.Register("test2", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{customWeightedAvg})))
+	require.EqualError(t, extension.Setup(), "duplicated extension aggregate function name 'custom_wavg'")
+
+	// registered functions are removed after reset
+	extension.Reset()
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{customWeightedAvg})))
+	require.NoError(t, extension.Setup())
+	extension.Reset()
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{customWeightedAvg})))
+	require.NoError(t, extension.Setup())
+}
+
+func TestInvokeExtensionAggFunc(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	// custom_strict_wavg fails instead of returning NULL when there is no weight
+	strictWeightedAvg := *customWeightedAvg
+	strictWeightedAvg.Name = "custom_strict_wavg"
+	strictWeightedAvg.Final = func(state any) (types.Datum, error) {
+		if state.(*weightedAvgState).weight == 0 {
+			return types.Datum{}, errors.New("no weight")
+		}
+		return customWeightedAvg.Final(state)
+	}
+	require.NoError(t, extension.Register("test", extension.WithCustomAggFunctions([]*extension.AggFunctionDef{
+		customWeightedAvg,
+		customConcatSorted,
+		&strictWeightedAvg,
+	})))
+	require.NoError(t, extension.Setup())
+
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "localhost"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id int primary key, g int, v double, w int, s varchar(16), key(g))")
+	tk.MustExec("insert into t values (1, 1, 1, 1, 'b'), (2, 1, 4, 2, 'a'), (3, 2, 10, 0, 'c'), (4, 2, 20, 3, null), (5, 3, null, 1, 'b')")
+
+	// empty input
+	tk.MustQuery("select custom_wavg(v, w), custom_concat_sorted(s) from t where id > 10").Check(testkit.Rows("<nil> <nil>"))
+	tk.MustQuery("select g, custom_wavg(v, w) from t where id > 10 group by g").Check(testkit.Rows())
+
+	expected := testkit.Rows("1 3 a|b", "2 20 c", "3 <nil> b")
+	for _, concurrency := range []int{1, 4} {
+		tk.MustExec("set @@tidb_hashagg_partial_concurrency = ?", concurrency)
+		tk.MustExec("set @@tidb_hashagg_final_concurrency = ?", concurrency)
+		tk.MustQuery("select g, custom_wavg(v, w), custom_concat_sorted(s) from t group by g order by g").Check(expected)
+		tk.MustQuery("select /*+ hash_agg() */ g, custom_wavg(v, w), custom_concat_sorted(s) from t group by g order by g").Check(expected)
+		tk.MustQuery("select /*+ stream_agg() */ g, custom_wavg(v, w), CUSTOM_CONCAT_SORTED(s) from t group by g order by g").Check(expected)
+	}
+	tk.MustQuery("select custom_wavg(v, w) from t").Check(testkit.Rows("11.5"))
+	tk.MustQuery("select g from t group by g having custom_wavg(v, w) > 10").Check(testkit.Rows("2"))
+
+	// custom aggregate functions should never be pushed down to coprocessor
+	for _, row := range tk.MustQuery("explain format = 'brief' select g, custom_wavg(v, w) from t group by g").Rows() {
+		if strings.Contains(row[4].(string), "custom_wavg") {
+			require.Equal(t, "root", row[2], row)
+		}
+	}
+
+	// split across union all only when the state can be encoded
+	tk.MustExec("create table t2 like t")
+	tk.MustExec("insert into t2 values (1, 1, 7, 3, 'd')")
+	tk.MustExec("set @@tidb_opt_agg_push_down = 1")
+	sql := "select g, custom_wavg(v, w) from (select * from t union all select * from t2) u group by g order by g"
+	aggCnt := 0
+	for _, row := range tk.MustQuery("explain format = 'brief' " + sql).Rows() {
+		if strings.Contains(row[4].(string), "custom_wavg") {
+			aggCnt++
+		}
+	}
+	require.Equal(t, 3, aggCnt)
+	tk.MustQuery(sql).Check(testkit.Rows("1 5", "2 20", "3 <nil>"))
+	tk.MustQuery("select g, custom_concat_sorted(s) from (select * from t union all select * from t2) u group by g order by g").
+		Check(testkit.Rows("1 a|b|d", "2 c", "3 b"))
+
+	// views
+	tk.MustExec("create view v as select g, custom_wavg(v, w) as a from t group by g")
+	tk.MustQuery("select * from v order by g").Check(testkit.Rows("1 3", "2 20", "3 <nil>"))
+
+	// errors
+	require.EqualError(t, tk.ExecToErr("select custom_wavg(v) from t"), "[expression:1582]Incorrect parameter count in the call to native function 'custom_wavg'")
+	for _, concurrency := range []int{1, 4} {
+		tk.MustExec("set @@tidb_hashagg_partial_concurrency = ?", concurrency)
+		tk.MustExec("set @@tidb_hashagg_final_concurrency = ?", concurrency)
+		for _, hint := range []string{"hash_agg()", "stream_agg()"} {
+			require.EqualError(t, tk.QueryToErr("select /*+ "+hint+" */ custom_strict_wavg(v, w) from t where id > 10"), "no weight")
+		}
+	}
+	tk.MustQuery("select custom_strict_wavg(v, w) from t where g = 1").Check(testkit.Rows("3"))
+	tk.MustExec("insert into t values (6, 4, 1, -1, 'x')")
+	require.EqualError(t, tk.QueryToErr("select custom_wavg(v, w) from t"), "negative weight")
+}
@@ -70,3 +70,76 @@ var RegisterExtensionFunc func(*FunctionDef) error
 
 // RemoveExtensionFunc is to avoid dependency cycle
 var RemoveExtensionFunc func(string)
+
+// AggFunctionDef is the definition for the custom aggregate function.
+// The state of a group is opaque to TiDB, it is only passed back to the callbacks of the same definition.
+type AggFunctionDef struct {
+	// Name is the function's name
+	Name string
+	// EvalTp is the type of the return value, only `types.ETInt`, `types.ETReal` and `types.ETString` are supported
+	EvalTp types.EvalType
+	// ArgTps is the argument types, the arguments are cast to these types before passed to `Update`
+	ArgTps []types.EvalType
+	// Init returns the initial state of a group
+	Init func() any
+	// Update updates the state with the arguments of a row and returns the new state.
+	// The NULL arguments are passed as is, so the function can decide how to handle them.
+	Update func(state any, args []types.Datum) (any, error)
+	// Merge merges the state `src` into `dst` and returns the new state
+	Merge func(dst, src any) (any, error)
+	// Final returns the result of a group
+	Final func(state any) (types.Datum, error)
+	// EncodeState encodes the state to bytes. It is optional and should be provided with `DecodeState`.
+	// Only the function with both of them can be split to a partial and a final aggregation in different
+	// operators, for example, when the aggregation is pushed down across `UNION ALL`.
+	EncodeState func(state any) ([]byte, error)
+	// DecodeState decodes the state encoded by `EncodeState`
+	DecodeState func(data []byte) (any, error)
+	// StateSize returns the memory usage of the state in bytes. It is optional, the memory of the states is
+	// not tracked if it's nil.
+	StateSize func(state any) int64
+}
+
+// Validate validates the aggregate function definition
+func (def *AggFunctionDef) Validate() error {
+	if def.Name == "" {
+		return errors.New("extension aggregate function name should not be empty")
+	}
+
+	for _, tp := range append([]types.EvalType{def.EvalTp}, def.ArgTps...) {
+		switch tp {
+		case types.ETInt, types.ETReal, types.ETString:
+		default:
+			return errors.Errorf("unsupported type '%v' in extension aggregate function '%s'", tp, def.Name)
+		}
+	}
+
+	if def.Init == nil || def.Update == nil || def.Merge == nil || def.Final == nil {
+		return errors.Errorf("Init, Update, Merge and Final of extension aggregate function '%s' should not be nil", def.Name)
+	}
+
+	if (def.EncodeState == nil) != (def.DecodeState == nil) {
+		return errors.Errorf("EncodeState and DecodeState of extension aggregate function '%s' should be provided together", def.Name)
+	}
+
+	return nil
+}
+
+// CanSplit returns whether the function can be split to a partial and a final aggregation in different operators
+func (def *AggFunctionDef) CanSplit() bool {
+	return def.EncodeState != nil && def.DecodeState != nil
+}
+
+// StateMemUsage returns the memory usage of the state reported by `StateSize`, or 0 if it's not provided
+func (def *AggFunctionDef) StateMemUsage(state any) int64 {
+	if def.StateSize == nil {
+		return 0
+	}
+	return def.StateSize(state)
+}
+
+// RegisterExtensionAggFunc is to avoid dependency cycle
+var RegisterExtensionAggFunc func(*AggFunctionDef) error
+
+// RemoveExtensionAggFunc is to avoid dependency cycle
+var RemoveExtensionAggFunc func(string)
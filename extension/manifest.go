@@ -55,6 +55,20 @@ func WithCustomFunctions(funcs []*FunctionDef) Option {
 	}
 }
 
+// WithCustomAggFunctions specifies custom aggregate functions
+func WithCustomAggFunctions(funcs []*AggFunctionDef) Option {
+	return func(m *Manifest) {
+		m.aggFuncs = funcs
+	}
+}
+
+// WithCustomTableSource specifies a custom table source for the tables created with `ENGINE=xxx`
+func WithCustomTableSource(def *TableSourceDef) Option {
+	return func(m *Manifest) {
+		m.tableSources = append(m.tableSources, def)
+	}
+}
+
 // AccessCheckFunc is a function that returns a dynamic privilege list for db/tbl/column access
 type AccessCheckFunc func(db, tbl, column string, priv mysql.PrivilegeType, sem bool) []string
 
@@ -117,6 +131,8 @@ type Manifest struct {
 	dynPrivs              []string
 	bootstrap             func(BootstrapContext) error
 	funcs                 []*FunctionDef
+	aggFuncs              []*AggFunctionDef
+	tableSources          []*TableSourceDef
 	accessCheckFunc       AccessCheckFunc
 	sessionHandlerFactory func() *SessionHandler
 	close                 func()
@@ -215,6 +231,42 @@ func newManifestWithSetup(name string, factory func() ([]Option, error)) (_ *Man
 		}
 	}
 
+	// setup aggregate functions
+	for i := range m.aggFuncs {
+		def := m.aggFuncs[i]
+		err = clearBuilder.DoWithCollectClear(func() (func(), error) {
+			if err := RegisterExtensionAggFunc(def); err != nil {
+				return nil, err
+			}
+
+			return func() {
+				RemoveExtensionAggFunc(def.Name)
+			}, nil
+		})
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// setup table sources
+	for i := range m.tableSources {
+		def := m.tableSources[i]
+		err = clearBuilder.DoWithCollectClear(func() (func(), error) {
+			if err := registerTableSource(def); err != nil {
+				return nil, err
+			}
+
+			return func() {
+				removeTableSource(def.Engine)
+			}, nil
+		})
+
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
 	return m, clearBuilder.Build(), nil
 }
 
@@ -0,0 +1,185 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extension
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+)
+
+// TableSourceContext is an interface to provide context to the custom table source
+type TableSourceContext interface {
+	context.Context
+	User() *auth.UserIdentity
+	CurrentDB() string
+	ConnectionInfo() *variable.ConnectionInfo
+}
+
+// TableFilterOp is the operator of a TableFilter
+type TableFilterOp string
+
+const (
+	// TableFilterEQ means `column = value`
+	TableFilterEQ TableFilterOp = "eq"
+	// TableFilterNE means `column != value`
+	TableFilterNE TableFilterOp = "ne"
+	// TableFilterLT means `column < value`
+	TableFilterLT TableFilterOp = "lt"
+	// TableFilterLE means `column <= value`
+	TableFilterLE TableFilterOp = "le"
+	// TableFilterGT means `column > value`
+	TableFilterGT TableFilterOp = "gt"
+	// TableFilterGE means `column >= value`
+	TableFilterGE TableFilterOp = "ge"
+	// TableFilterIn means `column IN (values...)`
+	TableFilterIn TableFilterOp = "in"
+)
+
+// TableFilter is a predicate `column op values` pushed down to the table source.
+type TableFilter struct {
+	// Column is the lower-case name of the column
+	Column string
+	// Op is the operator of the filter
+	Op TableFilterOp
+	// Values are the constant operands. There is exactly one value except for `TableFilterIn`
+	Values []types.Datum
+}
+
+// TableReadHints are the hints for the table source to read rows.
+// All the hints are advisory, TiDB always evaluates the filters again over the returned rows,
+// so a table source can ignore any of them but should never return fewer rows than required.
+type TableReadHints struct {
+	// Columns are the columns to read, each returned row should contain the values of these columns in order
+	Columns []*model.ColumnInfo
+	// Filters are the predicates in conjunctive form, the rows not matching them can be skipped
+	Filters []TableFilter
+	// Limit is the max count of rows needed, 0 means no limit
+	Limit uint64
+}
+
+// TableReader reads the rows of a table from the custom table source
+type TableReader interface {
+	// Next returns the next batch of rows. An empty batch means there are no more rows.
+	Next() ([][]types.Datum, error)
+	// Close closes the reader
+	Close() error
+}
+
+// TableSource is the storage of the tables created with a custom engine
+type TableSource interface {
+	// OpenReader opens a reader to read the rows of the table
+	OpenReader(ctx TableSourceContext, tbl *model.TableInfo, hints *TableReadHints) (TableReader, error)
+}
+
+// TableSourceWriter can be implemented by a TableSource to make its tables writable.
+// The tables of a table source not implementing it are read only.
+type TableSourceWriter interface {
+	// Write writes rows to the table, each row contains the values of all the columns of the table in order.
+	// The write is not transactional, it is performed as soon as the rows are inserted,
+	// so the rows are not allowed to be written in an explicit transaction.
+	Write(ctx TableSourceContext, tbl *model.TableInfo, rows [][]types.Datum) error
+}
+
+// TableSourceDef is the definition for the custom table source
+type TableSourceDef struct {
+	// Engine is the name used in the `ENGINE=xxx` clause, it is case-insensitive
+	Engine string
+	// Source is the table source to read and write the tables
+	Source TableSource
+	// ValidateTable is an optional function to validate the table when it is created with this engine
+	ValidateTable func(tbl *model.TableInfo) error
+}
+
+// mysqlEngineNames are the engine names that can not be used by custom table sources
+var mysqlEngineNames = map[string]struct{}{
+	"archive":    {},
+	"blackhole":  {},
+	"csv":        {},
+	"example":    {},
+	"federated":  {},
+	"innodb":     {},
+	"memory":     {},
+	"merge":      {},
+	"mgr_myisam": {},
+	"myisam":     {},
+	"ndb":        {},
+	"heap":       {},
+}
+
+// Validate validates the table source definition
+func (def *TableSourceDef) Validate() error {
+	if def.Engine == "" {
+		return errors.New("extension table source engine should not be empty")
+	}
+
+	if _, ok := mysqlEngineNames[strings.ToLower(def.Engine)]; ok {
+		return errors.Errorf("extension table source engine '%s' conflicts with builtin", def.Engine)
+	}
+
+	if def.Source == nil {
+		return errors.Errorf("table source of engine '%s' should not be nil", def.Engine)
+	}
+
+	return nil
+}
+
+var tableSources struct {
+	sync.RWMutex
+	defs map[string]*TableSourceDef
+}
+
+func registerTableSource(def *TableSourceDef) error {
+	if def == nil {
+		return errors.New("extension table source def is nil")
+	}
+
+	if err := def.Validate(); err != nil {
+		return err
+	}
+
+	engine := strings.ToLower(def.Engine)
+	tableSources.Lock()
+	defer tableSources.Unlock()
+	if _, ok := tableSources.defs[engine]; ok {
+		return errors.Errorf("duplicated extension table source engine '%s'", def.Engine)
+	}
+
+	if tableSources.defs == nil {
+		tableSources.defs = make(map[string]*TableSourceDef)
+	}
+	tableSources.defs[engine] = def
+	return nil
+}
+
+func removeTableSource(engine string) {
+	tableSources.Lock()
+	defer tableSources.Unlock()
+	delete(tableSources.defs, strings.ToLower(engine))
+}
+
+// GetTableSource returns the custom table source of the engine
+func GetTableSource(engine string) (*TableSourceDef, bool) {
+	tableSources.RLock()
+	defer tableSources.RUnlock()
+	def, ok := tableSources.defs[strings.ToLower(engine)]
+	return def, ok
+}
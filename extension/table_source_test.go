@@ -0,0 +1,277 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package extension_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/table/tables"
+	"github.com/pingcap/tidb/testkit"
+	"github.com/pingcap/tidb/types"
+	"github.com/stretchr/testify/require"
+)
+
+type mockTableSource struct {
+	sync.Mutex
+	rows      [][]types.Datum
+	lastHints *extension.TableReadHints
+	db        string
+}
+
+func (s *mockTableSource) OpenReader(ctx extension.TableSourceContext, tbl *model.TableInfo, hints *extension.TableReadHints) (extension.TableReader, error) {
+	s.Lock()
+	defer s.Unlock()
+	s.lastHints = hints
+	s.db = ctx.CurrentDB()
+	rows := make([][]types.Datum, 0, len(s.rows))
+	for _, row := range s.rows {
+		projected := make([]types.Datum, 0, len(hints.Columns))
+		for _, col := range hints.Columns {
+			projected = append(projected, row[col.Offset])
+		}
+		rows = append(rows, projected)
+	}
+	return &mockTableReader{rows: rows}, nil
+}
+
+func (s *mockTableSource) Write(_ extension.TableSourceContext, tbl *model.TableInfo, rows [][]types.Datum) error {
+	s.Lock()
+	defer s.Unlock()
+	for _, row := range rows {
+		if len(row) != len(tbl.Columns) {
+			return errors.New("invalid row")
+		}
+		s.rows = append(s.rows, row)
+	}
+	return nil
+}
+
+type mockTableReader struct {
+	rows [][]types.Datum
+}
+
+func (r *mockTableReader) Next() ([][]types.Datum, error) {
+	// return one row each time to test reading multiple batches
+	if len(r.rows) == 0 {
+		return nil, nil
+	}
+	row := r.rows[0]
+	r.rows = r.rows[1:]
+	return [][]types.Datum{row}, nil
+}
+
+func (*mockTableReader) Close() error {
+	return nil
+}
+
+// mockReadOnlyTableSource does not implement extension.TableSourceWriter
+type mockReadOnlyTableSource struct {
+	source *mockTableSource
+}
+
+func (s mockReadOnlyTableSource) OpenReader(ctx extension.TableSourceContext, tbl *model.TableInfo, hints *extension.TableReadHints) (extension.TableReader, error) {
+	return s.source.OpenReader(ctx, tbl, hints)
+}
+
+func TestTableSourceRegister(t *testing.T) {
+	defer extension.Reset()
+
+	source := &mockTableSource{}
+	cases := []struct {
+		def *extension.TableSourceDef
+		err string
+	}{
+		{
+			def: &extension.TableSourceDef{Source: source},
+			err: "extension table source engine should not be empty",
+		},
+		{
+			def: &extension.TableSourceDef{Engine: "InnoDB", Source: source},
+			err: "extension table source engine 'InnoDB' conflicts with builtin",
+		},
+		{
+			def: &extension.TableSourceDef{Engine: "mock"},
+			err: "table source of engine 'mock' should not be nil",
+		},
+	}
+
+	for _, c := range cases {
+		extension.Reset()
+		require.NoError(t, extension.Register("test", extension.WithCustomTableSource(c.def)))
+		require.EqualError(t, extension.Setup(), c.err)
+		_, ok := extension.GetTableSource(c.def.Engine)
+		require.False(t, ok)
+	}
+
+	extension.Reset()
+	require.NoError(t, extension.Register("test1", extension.WithCustomTableSource(&extension.TableSourceDef{Engine: "mock", Source: source})))
+	require.NoError(t, extension.Register("test2", extension.WithCustomTableSource(&extension.TableSourceDef{Engine: "MOCK", Source: source})))
+	require.EqualError(t, extension.Setup(), "duplicated extension table source engine 'MOCK'")
+	_, ok := extension.GetTableSource("mock")
+	require.False(t, ok)
+
+	extension.Reset()
+	require.NoError(t, extension.Register("test", extension.WithCustomTableSource(&extension.TableSourceDef{Engine: "Mock", Source: source})))
+	require.NoError(t, extension.Setup())
+	def, ok := extension.GetTableSource("mock")
+	require.True(t, ok)
+	require.Equal(t, "Mock", def.Engine)
+
+	extension.Reset()
+	_, ok = extension.GetTableSource("mock")
+	require.False(t, ok)
+}
+
+func TestCustomEngineTable(t *testing.T) {
+	defer extension.Reset()
+	extension.Reset()
+
+	source := &mockTableSource{}
+	roSource := mockReadOnlyTableSource{source: &mockTableSource{
+		rows: [][]types.Datum{types.MakeDatums(int64(1), "x")},
+	}}
+	require.NoError(t, extension.Register(
+		"test",
+		extension.WithCustomTableSource(&extension.TableSourceDef{
+			Engine: "Mock",
+			Source: source,
+			ValidateTable: func(tbl *model.TableInfo) error {
+				if tbl.Comment == "invalid" {
+					return errors.New("invalid table")
+				}
+				return nil
+			},
+		}),
+		extension.WithCustomTableSource(&extension.TableSourceDef{
+			Engine: "mock_ro",
+			Source: roSource,
+		}),
+	))
+
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+
+	// create table
+	tk.MustGetErrMsg("create table t(a int, b varchar(10)) engine=unknown", "[ddl:1286]Unknown storage engine 'unknown'")
+	tk.MustGetErrMsg("create table t(a int, b varchar(10)) engine=mock comment='invalid'", "invalid table")
+	tk.MustGetErrMsg("create table t(a int, b varchar(10), key(a)) engine=mock",
+		"[ddl:8200]Unsupported index on table with ENGINE=mock")
+	tk.MustGetErrMsg("create table t(a int auto_increment primary key, b varchar(10)) engine=mock",
+		"[ddl:8200]Unsupported auto_increment on table with ENGINE=mock")
+	tk.MustGetErrMsg("create table t(a int, b varchar(10)) engine=mock partition by hash(a) partitions 2",
+		"[ddl:8200]Unsupported partition on table with ENGINE=mock")
+	tk.MustExec("create table t(a int, b varchar(10)) engine=MOCK")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `a` int(11) DEFAULT NULL,\n" +
+		"  `b` varchar(10) DEFAULT NULL\n" +
+		") ENGINE=mock DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin"))
+	tk.MustQuery("select engine from information_schema.tables where table_schema='test' and table_name='t'").
+		Check(testkit.Rows("mock"))
+	tk.MustGetErrMsg("alter table t add index idx(a)", "[ddl:8200]Unsupported index on table with ENGINE=mock")
+
+	// write and read
+	tk.MustExec("insert into t values(1, 'a'), (2, 'b'), (3, 'c')")
+	require.Equal(t, 3, len(source.rows))
+	tk.MustQuery("select * from t").Sort().Check(testkit.Rows("1 a", "2 b", "3 c"))
+	require.Equal(t, "test", source.db)
+	require.Equal(t, 2, len(source.lastHints.Columns))
+	require.Empty(t, source.lastHints.Filters)
+	require.Equal(t, uint64(0), source.lastHints.Limit)
+
+	// the filters are passed as hints and always evaluated by TiDB
+	tk.MustQuery("select b from t where a > 1 and 'c' != b").Check(testkit.Rows("b"))
+	require.Equal(t, []extension.TableFilter{
+		{Column: "a", Op: extension.TableFilterGT, Values: types.MakeDatums(int64(1))},
+		{Column: "b", Op: extension.TableFilterNE, Values: types.MakeDatums("c")},
+	}, source.lastHints.Filters)
+	tk.MustQuery("select a from t where a in (1, 3) and b < 'z'").Sort().Check(testkit.Rows("1", "3"))
+	require.Equal(t, 2, len(source.lastHints.Filters))
+	require.Equal(t, extension.TableFilterIn, source.lastHints.Filters[0].Op)
+	require.Equal(t, types.MakeDatums(int64(1), int64(3)), source.lastHints.Filters[0].Values)
+	require.Equal(t, extension.TableFilterLT, source.lastHints.Filters[1].Op)
+
+	// only the used columns are read
+	tk.MustQuery("select b from t where b = 'a'").Check(testkit.Rows("a"))
+	require.Equal(t, 1, len(source.lastHints.Columns))
+	require.Equal(t, "b", source.lastHints.Columns[0].Name.L)
+
+	// limit is passed to the table source when no predicate remains
+	tk.MustQuery("select a from t limit 1, 1").Check(testkit.Rows("2"))
+	require.Equal(t, uint64(2), source.lastHints.Limit)
+	tk.MustQuery("select count(*) from (select a from t where a > 1 limit 1) t1").Check(testkit.Rows("1"))
+	require.Equal(t, uint64(0), source.lastHints.Limit)
+	rows := tk.MustQuery("explain format='brief' select a from t where a >= 2").Rows()
+	require.Contains(t, rows[len(rows)-1][4], "filters:[ge(a, 2)]")
+
+	// the rows can't be written in an explicit transaction, because the writes can't be rolled back
+	tk.MustExec("begin")
+	tk.MustGetErrCode("insert into t values(4, 'd')", errno.ErrLockOrActiveTransaction)
+	tk.MustExec("rollback")
+	tk.MustExec("set @@autocommit = 0")
+	tk.MustGetErrCode("insert into t values(4, 'd')", errno.ErrLockOrActiveTransaction)
+	tk.MustExec("rollback")
+	tk.MustExec("set @@autocommit = 1")
+	require.Equal(t, 3, len(source.rows))
+
+	// the handles are allocated for the written rows, and the rows are iterated with their ordinals as handles
+	tbl, err := dom.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	h1, err := tbl.AddRecord(tk.Session(), types.MakeDatums(int64(4), "d"))
+	require.NoError(t, err)
+	h2, err := tbl.AddRecord(tk.Session(), types.MakeDatums(int64(5), "e"))
+	require.NoError(t, err)
+	require.Greater(t, h2.IntValue(), h1.IntValue())
+	var handles []int64
+	customTbl := tbl.(*tables.CustomEngineTable)
+	require.NoError(t, customTbl.IterRecords(context.Background(), tk.Session(), tbl.Cols(), func(h kv.Handle, _ []types.Datum, _ []*table.Column) (bool, error) {
+		handles = append(handles, h.IntValue())
+		return true, nil
+	}))
+	require.Equal(t, []int64{1, 2, 3, 4, 5}, handles)
+	tk.MustQuery("select * from t where a > 3").Sort().Check(testkit.Rows("4 d", "5 e"))
+
+	// the engine can't be changed from or to a custom engine
+	tk.MustGetErrMsg("alter table t engine=innodb", "[ddl:8200]Unsupported changing engine on table with ENGINE=mock")
+	tk.MustExec("alter table t engine=Mock")
+
+	// update and delete are not supported
+	tk.MustGetErrMsg("update t set b = 'x' where a = 1", "[planner:1288]The target table t of the UPDATE is not updatable")
+	tk.MustGetErrMsg("delete from t where a = 1", "[planner:1288]The target table t of the DELETE is not updatable")
+
+	// read only table
+	tk.MustExec("create table t2(a int, b varchar(10)) engine=mock_ro")
+	tk.MustQuery("select * from t2").Check(testkit.Rows("1 x"))
+	tk.MustGetErrMsg("insert into t2 values(2, 'y')", "[table:1036]Table 't2' is read only")
+
+	// join with a normal table
+	tk.MustExec("create table t3(a int primary key, c int)")
+	tk.MustExec("insert into t3 values(1, 10), (2, 20)")
+	tk.MustQuery("select t.b, t3.c from t join t3 on t.a = t3.a").Sort().Check(testkit.Rows("a 10", "b 20"))
+	tk.MustGetErrMsg("alter table t3 engine=mock", "[ddl:8200]Unsupported changing engine to ENGINE=mock")
+	tk.MustExec("alter table t3 engine=innodb")
+
+	// the table can not be read after the extension is removed
+	extension.Reset()
+	require.EqualError(t, tk.QueryToErr("select * from t"), "[ddl:1286]Unknown storage engine 'mock'")
+}
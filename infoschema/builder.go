@@ -470,7 +470,7 @@ func (b *Builder) applyTableUpdate(m *meta.Meta, diff *model.SchemaDiff) ([]int6
 		oldTableID = diff.TableID
 	case model.ActionTruncateTable, model.ActionCreateView,
 		model.ActionExchangeTablePartition, model.ActionAlterTablePartitioning,
-		model.ActionRemovePartitioning:
+		model.ActionRemovePartitioning, model.ActionCreateMaterializedView, model.ActionDropMaterializedView:
 		oldTableID = diff.OldTableID
 		newTableID = diff.TableID
 	default:
@@ -94,7 +94,8 @@ const (
 	tablePlugins    = "PLUGINS"
 	// TableConstraints is the string constant of TABLE_CONSTRAINTS.
 	TableConstraints = "TABLE_CONSTRAINTS"
-	tableTriggers    = "TRIGGERS"
+	// TableTriggers is the string constant of infoschema table.
+	TableTriggers = "TRIGGERS"
 	// TableUserPrivileges is the string constant of infoschema user privilege table.
 	TableUserPrivileges   = "USER_PRIVILEGES"
 	tableSchemaPrivileges = "SCHEMA_PRIVILEGES"
@@ -103,10 +104,12 @@ const (
 	// TableEngines is the string constant of infoschema table.
 	TableEngines = "ENGINES"
 	// TableViews is the string constant of infoschema table.
-	TableViews           = "VIEWS"
-	tableRoutines        = "ROUTINES"
-	tableParameters      = "PARAMETERS"
-	tableEvents          = "EVENTS"
+	TableViews = "VIEWS"
+	// TableRoutines is the string constant of infoschema table.
+	TableRoutines   = "ROUTINES"
+	tableParameters = "PARAMETERS"
+	// TableEvents is the string constant of infoschema table.
+	TableEvents          = "EVENTS"
 	tableGlobalStatus    = "GLOBAL_STATUS"
 	tableGlobalVariables = "GLOBAL_VARIABLES"
 	tableSessionStatus   = "SESSION_STATUS"
@@ -206,6 +209,10 @@ const (
 	TableResourceGroups = "RESOURCE_GROUPS"
 	// TableRunawayWatches is the query list of runaway watch.
 	TableRunawayWatches = "RUNAWAY_WATCHES"
+	// TableTiDBTTLTableStatus is the TTL config and the job status of the TTL tables.
+	TableTiDBTTLTableStatus = "TIDB_TTL_TABLE_STATUS"
+	// TableTiDBEventHistory is the execution history of the events.
+	TableTiDBEventHistory = "TIDB_EVENT_HISTORY"
 )
 
 const (
@@ -240,16 +247,16 @@ var tableIDMap = map[string]int64{
 	TableSessionVar:                         autoid.InformationSchemaDBID + 14,
 	tablePlugins:                            autoid.InformationSchemaDBID + 15,
 	TableConstraints:                        autoid.InformationSchemaDBID + 16,
-	tableTriggers:                           autoid.InformationSchemaDBID + 17,
+	TableTriggers:                           autoid.InformationSchemaDBID + 17,
 	TableUserPrivileges:                     autoid.InformationSchemaDBID + 18,
 	tableSchemaPrivileges:                   autoid.InformationSchemaDBID + 19,
 	tableTablePrivileges:                    autoid.InformationSchemaDBID + 20,
 	tableColumnPrivileges:                   autoid.InformationSchemaDBID + 21,
 	TableEngines:                            autoid.InformationSchemaDBID + 22,
 	TableViews:                              autoid.InformationSchemaDBID + 23,
-	tableRoutines:                           autoid.InformationSchemaDBID + 24,
+	TableRoutines:                           autoid.InformationSchemaDBID + 24,
 	tableParameters:                         autoid.InformationSchemaDBID + 25,
-	tableEvents:                             autoid.InformationSchemaDBID + 26,
+	TableEvents:                             autoid.InformationSchemaDBID + 26,
 	tableGlobalStatus:                       autoid.InformationSchemaDBID + 27,
 	tableGlobalVariables:                    autoid.InformationSchemaDBID + 28,
 	tableSessionStatus:                      autoid.InformationSchemaDBID + 29,
@@ -314,6 +321,8 @@ var tableIDMap = map[string]int64{
 	ClusterTableMemoryUsageOpsHistory:    autoid.InformationSchemaDBID + 87,
 	TableResourceGroups:                  autoid.InformationSchemaDBID + 88,
 	TableRunawayWatches:                  autoid.InformationSchemaDBID + 89,
+	TableTiDBTTLTableStatus:              autoid.InformationSchemaDBID + 90,
+	TableTiDBEventHistory:                autoid.InformationSchemaDBID + 91,
 }
 
 // columnInfo represents the basic column information of all kinds of INFORMATION_SCHEMA tables
@@ -1625,6 +1634,31 @@ var tableRunawayWatchListCols = []columnInfo{
 	{name: "ACTION", tp: mysql.TypeVarchar, size: 12, flag: mysql.NotNullFlag},
 }
 
+var tableTiDBTTLTableStatusCols = []columnInfo{
+	{name: "TABLE_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "PARTITION_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "TABLE_ID", tp: mysql.TypeLonglong, size: 21},
+	{name: "TTL_ENABLE", tp: mysql.TypeTiny, size: 1},
+	{name: "TTL_JOB_INTERVAL", tp: mysql.TypeVarchar, size: 64},
+	{name: "TTL_JOB_WINDOW", tp: mysql.TypeVarchar, size: 64},
+	{name: "TTL_DELETE_RATE", tp: mysql.TypeLonglong, size: 21, flag: mysql.UnsignedFlag},
+	{name: "LAST_JOB_START_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "LAST_JOB_FINISH_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "CURRENT_JOB_STATUS", tp: mysql.TypeVarchar, size: 64},
+}
+
+var tableTiDBEventHistoryCols = []columnInfo{
+	{name: "EVENT_ID", tp: mysql.TypeVarchar, size: 64},
+	{name: "EVENT_SCHEMA", tp: mysql.TypeVarchar, size: 64},
+	{name: "EVENT_NAME", tp: mysql.TypeVarchar, size: 64},
+	{name: "DEFINER", tp: mysql.TypeVarchar, size: 288},
+	{name: "START_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "END_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "STATUS", tp: mysql.TypeVarchar, size: 64},
+	{name: "ERROR_MESSAGE", tp: mysql.TypeLongBlob},
+}
+
 // GetShardingInfo returns a nil or description string for the sharding information of given TableInfo.
 // The returned description string may be:
 //   - "NOT_SHARDED": for tables that SHARD_ROW_ID_BITS is not specified.
@@ -2076,16 +2110,16 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableSessionVar:                         sessionVarCols,
 	tablePlugins:                            pluginsCols,
 	TableConstraints:                        tableConstraintsCols,
-	tableTriggers:                           tableTriggersCols,
+	TableTriggers:                           tableTriggersCols,
 	TableUserPrivileges:                     tableUserPrivilegesCols,
 	tableSchemaPrivileges:                   tableSchemaPrivilegesCols,
 	tableTablePrivileges:                    tableTablePrivilegesCols,
 	tableColumnPrivileges:                   tableColumnPrivilegesCols,
 	TableEngines:                            tableEnginesCols,
 	TableViews:                              tableViewsCols,
-	tableRoutines:                           tableRoutinesCols,
+	TableRoutines:                           tableRoutinesCols,
 	tableParameters:                         tableParametersCols,
-	tableEvents:                             tableEventsCols,
+	TableEvents:                             tableEventsCols,
 	tableGlobalStatus:                       tableGlobalStatusCols,
 	tableGlobalVariables:                    tableGlobalVariablesCols,
 	tableSessionStatus:                      tableSessionStatusCols,
@@ -2138,6 +2172,8 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableMemoryUsageOpsHistory:              tableMemoryUsageOpsHistoryCols,
 	TableResourceGroups:                     tableResourceGroupsCols,
 	TableRunawayWatches:                     tableRunawayWatchListCols,
+	TableTiDBTTLTableStatus:                 tableTiDBTTLTableStatusCols,
+	TableTiDBEventHistory:                   tableTiDBEventHistoryCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
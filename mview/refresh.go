@@ -0,0 +1,446 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/format"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/util/chunk"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/sqlexec"
+)
+
+// LogDMLColumn is the column of the log table which is 1 for an inserted row and -1 for a deleted row.
+// An updated row is logged as a deleted row and an inserted row.
+const LogDMLColumn = "_mlog_dml"
+
+// LogTableName returns the name of the log table of a materialized view.
+func LogTableName(mview model.CIStr) model.CIStr {
+	return model.NewCIStr("mlog$_" + mview.O)
+}
+
+// fastRefreshQuery is the query of a materialized view which can be refreshed fast.
+// The query aggregates a single base table, each field is a group key or COUNT/SUM/MIN/MAX of an expression.
+type fastRefreshQuery struct {
+	base *ast.TableName
+	// alias is the name to reference the base table in the query.
+	alias  model.CIStr
+	where  ast.ExprNode
+	keys   []ast.ExprNode
+	fields []fastRefreshField
+	// countStar is the offset of COUNT(*) in the fields, it's used to find the empty groups.
+	countStar int
+}
+
+type fastRefreshField struct {
+	// agg is the name of the aggregate function, it's empty for a group key.
+	agg string
+	// expr is the group key or the argument of the aggregate function, it's nil for COUNT(*).
+	expr ast.ExprNode
+	// count is the offset of COUNT(expr) in the fields for SUM(expr).
+	count int
+}
+
+// CheckFastRefresh checks whether the query of a materialized view can be refreshed fast,
+// it returns the base table of the query.
+func CheckFastRefresh(stmt ast.StmtNode) (*ast.TableName, error) {
+	q, err := parseFastRefreshQuery(stmt)
+	if err != nil {
+		return nil, err
+	}
+	return q.base, nil
+}
+
+func unsupportedFastRefresh(reason string, args ...interface{}) error {
+	return dbterror.ErrMViewFastRefreshUnsupported.GenWithStackByArgs(fmt.Sprintf(reason, args...))
+}
+
+func parseFastRefreshQuery(stmt ast.StmtNode) (*fastRefreshQuery, error) {
+	sel, ok := stmt.(*ast.SelectStmt)
+	if !ok || sel.With != nil || sel.IsInBraces {
+		return nil, unsupportedFastRefresh("the query must be a simple SELECT statement")
+	}
+	if sel.Distinct || sel.Having != nil || sel.OrderBy != nil || sel.Limit != nil || sel.WindowSpecs != nil {
+		return nil, unsupportedFastRefresh("DISTINCT, HAVING, ORDER BY, LIMIT and WINDOW are not supported")
+	}
+	if sel.From == nil || sel.From.TableRefs.Right != nil {
+		return nil, unsupportedFastRefresh("the query must select from a single table")
+	}
+	source, ok := sel.From.TableRefs.Left.(*ast.TableSource)
+	if !ok {
+		return nil, unsupportedFastRefresh("the query must select from a single table")
+	}
+	base, ok := source.Source.(*ast.TableName)
+	if !ok {
+		return nil, unsupportedFastRefresh("the query must select from a single table")
+	}
+	if sel.GroupBy == nil {
+		return nil, unsupportedFastRefresh("the query must have a GROUP BY clause")
+	}
+
+	q := &fastRefreshQuery{base: base, alias: base.Name, where: sel.Where, countStar: -1}
+	if source.AsName.L != "" {
+		q.alias = source.AsName
+	}
+	keys := make(map[string]bool, len(sel.GroupBy.Items))
+	for _, item := range sel.GroupBy.Items {
+		keys[exprText(item.Expr)] = false
+	}
+	args := make(map[string]int)
+	for i, field := range sel.Fields.Fields {
+		if field.WildCard != nil {
+			return nil, unsupportedFastRefresh("the wildcard field is not supported")
+		}
+		agg, ok := field.Expr.(*ast.AggregateFuncExpr)
+		if !ok {
+			text := exprText(field.Expr)
+			if _, ok := keys[text]; !ok {
+				return nil, unsupportedFastRefresh("the field %s is neither a group key nor an aggregate function", text)
+			}
+			keys[text] = true
+			q.keys = append(q.keys, field.Expr)
+			q.fields = append(q.fields, fastRefreshField{expr: field.Expr})
+			continue
+		}
+		name := strings.ToLower(agg.F)
+		switch name {
+		case ast.AggFuncCount, ast.AggFuncSum, ast.AggFuncMin, ast.AggFuncMax:
+		default:
+			return nil, unsupportedFastRefresh("the aggregate function %s is not supported", agg.F)
+		}
+		if agg.Distinct || len(agg.Args) != 1 || agg.Order != nil {
+			return nil, unsupportedFastRefresh("the aggregate function %s with DISTINCT or multiple arguments is not supported", agg.F)
+		}
+		f := fastRefreshField{agg: name, expr: agg.Args[0], count: -1}
+		if name == ast.AggFuncCount {
+			if v, ok := f.expr.(ast.ValueExpr); ok && v.GetValue() != nil {
+				// COUNT(*) is parsed as COUNT(1).
+				f.expr = nil
+				if q.countStar < 0 {
+					q.countStar = i
+				}
+			} else {
+				args[exprText(f.expr)] = i
+			}
+		}
+		q.fields = append(q.fields, f)
+	}
+	for text, selected := range keys {
+		if !selected {
+			return nil, unsupportedFastRefresh("the group key %s must be selected", text)
+		}
+	}
+	if q.countStar < 0 {
+		return nil, unsupportedFastRefresh("COUNT(*) must be selected")
+	}
+	for i := range q.fields {
+		f := &q.fields[i]
+		if f.agg != ast.AggFuncSum {
+			continue
+		}
+		text := exprText(f.expr)
+		count, ok := args[text]
+		if !ok {
+			return nil, unsupportedFastRefresh("COUNT(%s) must be selected for SUM(%s)", text, text)
+		}
+		f.count = count
+	}
+	return q, nil
+}
+
+// exprText restores an expression without the qualifiers of the columns, the query selects from a single table,
+// so the columns are always the ones of the base table.
+func exprText(expr ast.ExprNode) string {
+	expr.Accept(&columnQualifierStripper{})
+	return restoreText(expr)
+}
+
+// restoreText restores a node of the query into the refresh SQLs, the strings are restored with the backslashes
+// escaped like the query of a normal view.
+func restoreText(node ast.Node) string {
+	var sb strings.Builder
+	if err := node.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		// All the nodes parsed from the query can be restored.
+		terror.Log(err)
+	}
+	return sb.String()
+}
+
+type columnQualifierStripper struct{}
+
+func (*columnQualifierStripper) Enter(n ast.Node) (ast.Node, bool) {
+	if col, ok := n.(*ast.ColumnName); ok {
+		col.Schema, col.Table = model.CIStr{}, model.CIStr{}
+	}
+	return n, false
+}
+
+func (*columnQualifierStripper) Leave(n ast.Node) (ast.Node, bool) {
+	return n, true
+}
+
+// Refresh refreshes a materialized view in a new transaction of the session, the session must be a SQLExecutor.
+// A COMPLETE refresh replaces the rows of the view with the result of the query. A FAST refresh merges the
+// changes of the base table recorded in the log table into the aggregated rows.
+func Refresh(ctx context.Context, sctx sessionctx.Context, is infoschema.InfoSchema, tblInfo *model.TableInfo,
+	method model.MViewRefreshMethod) (err error) {
+	mv := tblInfo.MaterializedView
+	if mv == nil {
+		return errors.Errorf("table %s is not a materialized view", tblInfo.Name.O)
+	}
+	if method == model.MViewRefreshDefault {
+		method = mv.Refresh
+	}
+	if method == model.MViewRefreshFast && mv.Refresh != model.MViewRefreshFast {
+		return unsupportedFastRefresh("the view is created with REFRESH %s", mv.Refresh)
+	}
+	schema, ok := is.SchemaByTable(tblInfo)
+	if !ok {
+		return errors.Errorf("can not find the schema of table %s", tblInfo.Name.O)
+	}
+	r := &refresher{
+		exec: sctx.(sqlexec.SQLExecutor),
+		mv:   tableName(schema.Name, tblInfo.Name),
+		cols: make([]string, 0, len(tblInfo.Columns)),
+	}
+	for _, col := range tblInfo.Columns {
+		r.cols = append(r.cols, quoteName(col.Name.O))
+	}
+	if mv.LogTableID != 0 {
+		logTbl, ok := is.TableByID(mv.LogTableID)
+		if !ok {
+			return errors.Errorf("can not find the log table of materialized view %s", tblInfo.Name.O)
+		}
+		logSchema, _ := is.SchemaByTable(logTbl.Meta())
+		r.log = tableName(logSchema.Name, logTbl.Meta().Name)
+	}
+	// The refresh SQLs are executed by the session, so the query is parsed in the same way.
+	vars := sctx.GetSessionVars()
+	p := parser.New()
+	p.SetSQLMode(vars.SQLMode)
+	p.SetParserConfig(vars.BuildParserConfig())
+	charset, collation := vars.GetCharsetInfo()
+	stmt, err := p.ParseOneStmt(mv.SelectStmt, charset, collation)
+	if err != nil {
+		return err
+	}
+
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnOthers)
+	if _, err = r.exec.ExecuteInternal(ctx, "BEGIN OPTIMISTIC"); err != nil {
+		return err
+	}
+	defer func() {
+		if err != nil {
+			_, rollbackErr := r.exec.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(rollbackErr)
+			return
+		}
+		_, err = r.exec.ExecuteInternal(ctx, "COMMIT")
+	}()
+	if method == model.MViewRefreshComplete {
+		return r.completeRefresh(ctx, stmt)
+	}
+	q, err := parseFastRefreshQuery(stmt)
+	if err != nil {
+		return err
+	}
+	return r.fastRefresh(ctx, q)
+}
+
+type refresher struct {
+	exec sqlexec.SQLExecutor
+	mv   string
+	log  string
+	cols []string
+}
+
+func (r *refresher) run(ctx context.Context, sql string) ([]chunk.Row, error) {
+	rs, err := r.exec.ExecuteInternal(ctx, sql)
+	if err != nil || rs == nil {
+		return nil, err
+	}
+	defer terror.Call(rs.Close)
+	return sqlexec.DrainRecordSet(ctx, rs, 8)
+}
+
+func (r *refresher) runAll(ctx context.Context, sqls ...string) error {
+	for _, sql := range sqls {
+		if _, err := r.run(ctx, sql); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *refresher) completeRefresh(ctx context.Context, stmt ast.StmtNode) error {
+	sqls := []string{
+		"DELETE FROM " + r.mv,
+		fmt.Sprintf("INSERT INTO %s (%s) %s", r.mv, strings.Join(r.cols, ", "), restoreText(stmt)),
+	}
+	if r.log != "" {
+		sqls = append(sqls, "DELETE FROM "+r.log)
+	}
+	return r.runAll(ctx, sqls...)
+}
+
+// fastRefresh merges the changes in the log table into the view:
+//  1. The changes are aggregated by the group keys into a delta table.
+//  2. The existing groups are updated with the delta table. MIN and MAX are recalculated from the base table
+//     if the group has deleted rows.
+//  3. The new groups in the delta table are inserted.
+//  4. The empty groups are deleted, and the log table is cleared.
+func (r *refresher) fastRefresh(ctx context.Context, q *fastRefreshQuery) error {
+	rows, err := r.run(ctx, "SELECT 1 FROM "+r.log+" LIMIT 1")
+	if err != nil || len(rows) == 0 {
+		return err
+	}
+
+	var delta strings.Builder
+	delta.WriteString("SELECT ")
+	for i, f := range q.fields {
+		var expr string
+		switch f.agg {
+		case "":
+			expr = exprText(f.expr)
+		case ast.AggFuncCount:
+			if f.expr == nil {
+				expr = fmt.Sprintf("SUM(%s)", LogDMLColumn)
+			} else {
+				expr = fmt.Sprintf("SUM(IF((%s) IS NULL, 0, %s))", exprText(f.expr), LogDMLColumn)
+			}
+		case ast.AggFuncSum:
+			expr = fmt.Sprintf("SUM((%s) * %s)", exprText(f.expr), LogDMLColumn)
+		case ast.AggFuncMin, ast.AggFuncMax:
+			expr = fmt.Sprintf("%s(IF(%s > 0, %s, NULL))", strings.ToUpper(f.agg), LogDMLColumn, exprText(f.expr))
+		}
+		fmt.Fprintf(&delta, "%s AS %s, ", expr, deltaColumn(i))
+	}
+	fmt.Fprintf(&delta, "MAX(%s < 0) AS _mlog_del FROM %s AS %s", LogDMLColumn, r.log, quoteName(q.alias.O))
+	if q.where != nil {
+		fmt.Fprintf(&delta, " WHERE %s", exprText(q.where))
+	}
+	delta.WriteString(" GROUP BY ")
+	for i, key := range q.keys {
+		if i > 0 {
+			delta.WriteString(", ")
+		}
+		delta.WriteString(exprText(key))
+	}
+
+	// Update the existing groups. The counts are assigned at last, so the other assignments read the old counts.
+	var update strings.Builder
+	fmt.Fprintf(&update, "UPDATE %s AS _mv JOIN (%s) AS _delta ON %s SET ", r.mv, delta.String(), r.keysMatch(q, "_delta"))
+	var assigns, countAssigns []string
+	for i, f := range q.fields {
+		col, d := "_mv."+r.cols[i], "_delta."+deltaColumn(i)
+		switch f.agg {
+		case ast.AggFuncCount:
+			countAssigns = append(countAssigns, fmt.Sprintf("%s = %s + %s", col, col, d))
+		case ast.AggFuncSum:
+			count := "_mv." + r.cols[f.count]
+			assigns = append(assigns, fmt.Sprintf("%s = IF(%s + _delta.%s = 0, NULL, IFNULL(%s, 0) + IFNULL(%s, 0))",
+				col, count, deltaColumn(f.count), col, d))
+		case ast.AggFuncMin, ast.AggFuncMax:
+			fn := "LEAST"
+			if f.agg == ast.AggFuncMax {
+				fn = "GREATEST"
+			}
+			assigns = append(assigns, fmt.Sprintf("%s = IF(_delta._mlog_del, %s, %s(IFNULL(%s, %s), IFNULL(%s, %s)))",
+				col, r.recalculate(q, f, "_mv", r.cols), fn, col, d, d, col))
+		}
+	}
+	update.WriteString(strings.Join(append(assigns, countAssigns...), ", "))
+
+	// Insert the new groups.
+	deltaCols := make([]string, len(q.fields))
+	for i := range q.fields {
+		deltaCols[i] = deltaColumn(i)
+	}
+	values := make([]string, 0, len(q.fields))
+	for i, f := range q.fields {
+		d := "_delta." + deltaColumn(i)
+		switch f.agg {
+		case ast.AggFuncSum:
+			values = append(values, fmt.Sprintf("IF(_delta.%s = 0, NULL, %s)", deltaColumn(f.count), d))
+		case ast.AggFuncMin, ast.AggFuncMax:
+			values = append(values, fmt.Sprintf("IF(_delta._mlog_del, %s, %s)", r.recalculate(q, f, "_delta", deltaCols), d))
+		default:
+			values = append(values, d)
+		}
+	}
+	insert := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM (%s) AS _delta WHERE NOT EXISTS (SELECT 1 FROM %s AS _mv WHERE %s)",
+		r.mv, strings.Join(r.cols, ", "), strings.Join(values, ", "), delta.String(), r.mv, r.keysMatch(q, "_delta"))
+
+	return r.runAll(ctx,
+		update.String(),
+		insert,
+		fmt.Sprintf("DELETE FROM %s WHERE %s <= 0", r.mv, r.cols[q.countStar]),
+		"DELETE FROM "+r.log,
+	)
+}
+
+// keysMatch returns the condition which matches the group keys of the view and the delta table.
+func (r *refresher) keysMatch(q *fastRefreshQuery, delta string) string {
+	conds := make([]string, 0, len(q.keys))
+	for i, f := range q.fields {
+		if f.agg == "" {
+			conds = append(conds, fmt.Sprintf("_mv.%s <=> %s.%s", r.cols[i], delta, deltaColumn(i)))
+		}
+	}
+	return strings.Join(conds, " AND ")
+}
+
+// recalculate returns the subquery which calculates MIN or MAX of a group from the base table,
+// the group keys are the columns of the outer table.
+func (*refresher) recalculate(q *fastRefreshQuery, f fastRefreshField, outer string, outerCols []string) string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "(SELECT %s(%s) FROM %s AS %s WHERE ", strings.ToUpper(f.agg), exprText(f.expr),
+		tableName(q.base.Schema, q.base.Name), quoteName(q.alias.O))
+	if q.where != nil {
+		fmt.Fprintf(&sb, "(%s) AND ", exprText(q.where))
+	}
+	conds := make([]string, 0, len(q.keys))
+	for i, key := range q.fields {
+		if key.agg == "" {
+			conds = append(conds, fmt.Sprintf("(%s) <=> %s.%s", exprText(key.expr), outer, outerCols[i]))
+		}
+	}
+	sb.WriteString(strings.Join(conds, " AND "))
+	sb.WriteString(")")
+	return sb.String()
+}
+
+func deltaColumn(i int) string {
+	return fmt.Sprintf("_d%d", i)
+}
+
+func quoteName(name string) string {
+	return sqlexec.MustEscapeSQL("%n", name)
+}
+
+func tableName(schema, name model.CIStr) string {
+	return sqlexec.MustEscapeSQL("%n.%n", schema.O, name.O)
+}
@@ -0,0 +1,55 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"testing"
+
+	"github.com/pingcap/tidb/parser"
+	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckFastRefresh(t *testing.T) {
+	p := parser.New()
+	for _, sql := range []string{
+		"select a, count(*) from t group by a",
+		"select t.a, count(1), sum(b), count(b), min(b), max(b + 1) from test.t group by a",
+		"select a + b, count(*) from t group by a + b",
+	} {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		base, err := CheckFastRefresh(stmt)
+		require.NoError(t, err, sql)
+		require.Equal(t, "t", base.Name.L)
+	}
+
+	for _, sql := range []string{
+		"select count(*) from t",
+		"select a, count(*) from t, t2 group by a",
+		"select a, sum(b) from t group by a",
+		"select a, sum(b), count(*) from t group by a",
+		"select a, avg(b), count(*) from t group by a",
+		"select a, count(distinct b), count(*) from t group by a",
+		"select b, count(*) from t group by a",
+		"select a, count(*) from t group by a having count(*) > 1",
+		"select a, count(*) from t group by a union select 1, 2",
+	} {
+		stmt, err := p.ParseOneStmt(sql, "", "")
+		require.NoError(t, err)
+		_, err = CheckFastRefresh(stmt)
+		require.True(t, dbterror.ErrMViewFastRefreshUnsupported.Equal(err), sql)
+	}
+}
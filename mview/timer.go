@@ -0,0 +1,281 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package mview
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/ngaut/pools"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/sessionctx"
+	timerapi "github.com/pingcap/tidb/timer/api"
+	timerrt "github.com/pingcap/tidb/timer/runtime"
+	"github.com/pingcap/tidb/timer/tablestore"
+	"github.com/pingcap/tidb/util/logutil"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	timerKeyPrefix      = "/tidb/mview/"
+	timerHookClass      = "tidb.mview"
+	managerLoopInterval = 5 * time.Second
+	// fullSyncInterval is the interval to sync the timers even if the information schema is not changed.
+	fullSyncInterval = 2 * time.Minute
+)
+
+type sessionPool interface {
+	Get() (pools.Resource, error)
+	Put(pools.Resource)
+}
+
+// TimerData is the data stored in the refresh timer of a materialized view.
+type TimerData struct {
+	TableID int64 `json:"table_id"`
+}
+
+// RefreshManager refreshes the materialized views with a refresh interval by the timers, the timers are
+// synced with the views and run on the DDL owner.
+type RefreshManager struct {
+	pool    sessionPool
+	store   *timerapi.TimerStore
+	cli     timerapi.TimerClient
+	isOwner func() bool
+	rt      *timerrt.TimerGroupRuntime
+
+	lastSyncTime time.Time
+	lastSyncVer  int64
+
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+// NewRefreshManager creates a new RefreshManager.
+func NewRefreshManager(pool sessionPool, etcd *clientv3.Client, isOwner func() bool) *RefreshManager {
+	store := tablestore.NewTableTimerStore(1, pool, "mysql", "tidb_timers", etcd)
+	ctx, cancel := context.WithCancel(context.Background())
+	return &RefreshManager{
+		pool:    pool,
+		store:   store,
+		cli:     timerapi.NewDefaultTimerClient(store),
+		isOwner: isOwner,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Start starts the loop of the manager.
+func (m *RefreshManager) Start() {
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		ticker := time.NewTicker(managerLoopInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.ctx.Done():
+				m.pause()
+				m.store.Close()
+				return
+			case <-ticker.C:
+				m.onTick()
+			}
+		}
+	}()
+}
+
+// Stop stops the manager and waits for it to exit.
+func (m *RefreshManager) Stop() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+func (m *RefreshManager) onTick() {
+	if !m.isOwner() {
+		m.pause()
+		m.lastSyncTime, m.lastSyncVer = time.Time{}, 0
+		return
+	}
+	m.resume()
+
+	resource, err := m.pool.Get()
+	if err != nil {
+		logutil.BgLogger().Warn("failed to get session to sync materialized view timers", zap.Error(err))
+		return
+	}
+	is := resource.(sessionctx.Context).GetDomainInfoSchema().(infoschema.InfoSchema)
+	m.pool.Put(resource)
+	// Only sync the timers when the information schema is changed, or it has not been synced for a while.
+	if is.SchemaMetaVersion() > m.lastSyncVer || time.Since(m.lastSyncTime) > fullSyncInterval {
+		if err := m.syncTimers(m.ctx, is); err != nil {
+			logutil.BgLogger().Warn("failed to sync materialized view timers", zap.Error(err))
+			return
+		}
+		m.lastSyncTime, m.lastSyncVer = time.Now(), is.SchemaMetaVersion()
+	}
+}
+
+func (m *RefreshManager) resume() {
+	if m.rt != nil {
+		return
+	}
+	m.rt = timerrt.NewTimerRuntimeBuilder("mview", m.store).
+		SetCond(&timerapi.TimerCond{Key: timerapi.NewOptionalVal(timerKeyPrefix), KeyPrefix: true}).
+		RegisterHookFactory(timerHookClass, func(_ string, cli timerapi.TimerClient) timerapi.Hook {
+			return newRefreshHook(m.pool, cli)
+		}).
+		Build()
+	m.rt.Start()
+}
+
+func (m *RefreshManager) pause() {
+	if rt := m.rt; rt != nil {
+		m.rt = nil
+		rt.Stop()
+	}
+}
+
+func timerKey(tblInfo *model.TableInfo) string {
+	return timerKeyPrefix + strconv.FormatInt(tblInfo.ID, 10)
+}
+
+// syncTimers creates or updates the timers of the materialized views with a refresh interval,
+// and deletes the timers of the dropped views.
+func (m *RefreshManager) syncTimers(ctx context.Context, is infoschema.InfoSchema) error {
+	timers, err := m.cli.GetTimers(ctx, timerapi.WithKeyPrefix(timerKeyPrefix))
+	if err != nil {
+		return err
+	}
+	key2Timers := make(map[string]*timerapi.TimerRecord, len(timers))
+	for _, timer := range timers {
+		key2Timers[timer.Key] = timer
+	}
+
+	for _, db := range is.AllSchemas() {
+		for _, tbl := range is.SchemaTables(db.Name) {
+			tblInfo := tbl.Meta()
+			if tblInfo.MaterializedView == nil || tblInfo.MaterializedView.RefreshInterval == "" {
+				continue
+			}
+			key := timerKey(tblInfo)
+			interval := tblInfo.MaterializedView.RefreshInterval
+			timer, ok := key2Timers[key]
+			delete(key2Timers, key)
+			if ok {
+				if timer.SchedPolicyExpr != interval {
+					err = m.cli.UpdateTimer(ctx, timer.ID, timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, interval))
+				}
+			} else {
+				var data []byte
+				if data, err = json.Marshal(&TimerData{TableID: tblInfo.ID}); err != nil {
+					return err
+				}
+				// The view is refreshed when it's created, so the first event is scheduled after an interval.
+				_, err = m.cli.CreateTimer(ctx, timerapi.TimerSpec{
+					Key:             key,
+					Data:            data,
+					SchedPolicyType: timerapi.SchedEventInterval,
+					SchedPolicyExpr: interval,
+					HookClass:       timerHookClass,
+					Watermark:       time.Now(),
+					Enable:          true,
+				})
+			}
+			if err != nil {
+				logutil.BgLogger().Warn("failed to sync materialized view timer", zap.String("key", key), zap.Error(err))
+			}
+		}
+	}
+	for key, timer := range key2Timers {
+		if _, err = m.cli.DeleteTimer(ctx, timer.ID); err != nil {
+			logutil.BgLogger().Warn("failed to delete materialized view timer", zap.String("key", key), zap.Error(err))
+		}
+	}
+	return nil
+}
+
+type refreshHook struct {
+	pool   sessionPool
+	cli    timerapi.TimerClient
+	ctx    context.Context
+	cancel func()
+	wg     sync.WaitGroup
+}
+
+func newRefreshHook(pool sessionPool, cli timerapi.TimerClient) *refreshHook {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &refreshHook{
+		pool:   pool,
+		cli:    cli,
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (*refreshHook) Start() {}
+
+func (h *refreshHook) Stop() {
+	h.cancel()
+	h.wg.Wait()
+}
+
+func (*refreshHook) OnPreSchedEvent(context.Context, timerapi.TimerShedEvent) (timerapi.PreSchedEventResult, error) {
+	return timerapi.PreSchedEventResult{}, nil
+}
+
+// OnSchedEvent refreshes the view in the background, the event is closed when the refresh is done.
+// A failed refresh is logged and retried at the next event.
+func (h *refreshHook) OnSchedEvent(_ context.Context, event timerapi.TimerShedEvent) error {
+	timer := event.Timer()
+	var data TimerData
+	if err := json.Unmarshal(timer.Data, &data); err != nil {
+		return err
+	}
+	h.wg.Add(1)
+	go func() {
+		defer h.wg.Done()
+		logger := logutil.BgLogger().With(zap.String("key", timer.Key), zap.String("eventID", event.EventID()))
+		if err := h.refresh(data.TableID); err != nil {
+			logger.Warn("failed to refresh materialized view", zap.Error(err))
+		}
+		err := h.cli.CloseTimerEvent(h.ctx, timer.ID, event.EventID(), timerapi.WithSetWatermark(timer.EventStart))
+		if err != nil {
+			logger.Warn("failed to close materialized view timer event", zap.Error(err))
+		}
+	}()
+	return nil
+}
+
+func (h *refreshHook) refresh(tableID int64) error {
+	resource, err := h.pool.Get()
+	if err != nil {
+		return err
+	}
+	defer h.pool.Put(resource)
+	sctx := resource.(sessionctx.Context)
+	is := sctx.GetDomainInfoSchema().(infoschema.InfoSchema)
+	tbl, ok := is.TableByID(tableID)
+	if !ok || tbl.Meta().MaterializedView == nil {
+		return errors.Errorf("materialized view %d is dropped", tableID)
+	}
+	return Refresh(h.ctx, sctx, is, tbl.Meta(), model.MViewRefreshDefault)
+}
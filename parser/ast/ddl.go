@@ -36,6 +36,7 @@ var (
 	_ DDLNode = &CreateIndexStmt{}
 	_ DDLNode = &CreateTableStmt{}
 	_ DDLNode = &CreateViewStmt{}
+	_ DDLNode = &CreateTriggerStmt{}
 	_ DDLNode = &CreateSequenceStmt{}
 	_ DDLNode = &CreatePlacementPolicyStmt{}
 	_ DDLNode = &CreateResourceGroupStmt{}
@@ -44,6 +45,9 @@ var (
 	_ DDLNode = &DropIndexStmt{}
 	_ DDLNode = &DropTableStmt{}
 	_ DDLNode = &DropSequenceStmt{}
+	_ DDLNode = &DropTriggerStmt{}
+	_ DDLNode = &CreateMaterializedViewStmt{}
+	_ DDLNode = &DropMaterializedViewStmt{}
 	_ DDLNode = &DropPlacementPolicyStmt{}
 	_ DDLNode = &DropResourceGroupStmt{}
 	_ DDLNode = &RenameTableStmt{}
@@ -800,6 +804,7 @@ const (
 	ConstraintForeignKey
 	ConstraintFulltext
 	ConstraintCheck
+	ConstraintSpatial
 )
 
 // Constraint is constraint for table definition.
@@ -854,6 +859,8 @@ func (n *Constraint) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("UNIQUE INDEX")
 	case ConstraintFulltext:
 		ctx.WriteKeyWord("FULLTEXT")
+	case ConstraintSpatial:
+		ctx.WriteKeyWord("SPATIAL")
 	case ConstraintCheck:
 		if n.Name != "" {
 			ctx.WriteKeyWord("CONSTRAINT ")
@@ -1538,6 +1545,223 @@ func (n *CreateViewStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// CreateTriggerStmt is a statement to create a trigger.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-trigger.html
+type CreateTriggerStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	Definer     *auth.UserIdentity
+	TriggerName *TableName
+	Timing      model.TriggerTiming
+	Event       model.TriggerEvent
+	Table       *TableName
+	Body        StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil {
+		ctx.WriteKeyWord("DEFINER")
+		ctx.WritePlain(" = ")
+		if n.Definer.CurrentUser {
+			ctx.WriteKeyWord("current_user")
+		} else {
+			ctx.WriteName(n.Definer.Username)
+			if n.Definer.Hostname != "" {
+				ctx.WritePlain("@")
+				ctx.WriteName(n.Definer.Hostname)
+			}
+		}
+		ctx.WritePlain(" ")
+	}
+	ctx.WriteKeyWord("TRIGGER ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.TriggerName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.TriggerName")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Timing.String())
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Event.String())
+	ctx.WriteKeyWord(" ON ")
+	if err := n.Table.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Table")
+	}
+	ctx.WriteKeyWord(" FOR EACH ROW ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateTriggerStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateTriggerStmt)
+	node, ok := n.TriggerName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.TriggerName = node.(*TableName)
+	node, ok = n.Table.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Table = node.(*TableName)
+	// The trigger body references the NEW and OLD rows and the local variables, it's checked
+	// when the trigger is created and resolved when it's fired, so it isn't traversed here.
+	return v.Leave(n)
+}
+
+// DropTriggerStmt is a statement to drop a trigger.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-trigger.html
+type DropTriggerStmt struct {
+	ddlNode
+
+	IfExists    bool
+	TriggerName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropTriggerStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP TRIGGER ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.TriggerName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropTriggerStmt.TriggerName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropTriggerStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropTriggerStmt)
+	node, ok := n.TriggerName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.TriggerName = node.(*TableName)
+	return v.Leave(n)
+}
+
+// CreateMaterializedViewStmt is a statement to create a materialized view.
+type CreateMaterializedViewStmt struct {
+	ddlNode
+
+	IfNotExists bool
+	ViewName    *TableName
+	Cols        []model.CIStr
+	Refresh     model.MViewRefreshMethod
+	// RefreshInterval is the interval of the scheduled refresh, 0 means the view is refreshed manually.
+	RefreshInterval uint64
+	RefreshUnit     TimeUnitType
+	Select          StmtNode
+
+	// ColTypes are the types of the columns, they are filled by the planner.
+	ColTypes []*types.FieldType
+}
+
+// Restore implements Node interface.
+func (n *CreateMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE MATERIALIZED VIEW ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.ViewName")
+	}
+	for i, col := range n.Cols {
+		if i == 0 {
+			ctx.WritePlain(" (")
+		} else {
+			ctx.WritePlain(",")
+		}
+		ctx.WriteName(col.O)
+		if i == len(n.Cols)-1 {
+			ctx.WritePlain(")")
+		}
+	}
+	ctx.WriteKeyWord(" REFRESH ")
+	ctx.WriteKeyWord(n.Refresh.String())
+	if n.RefreshInterval > 0 {
+		ctx.WriteKeyWord(" EVERY ")
+		ctx.WritePlainf("%d ", n.RefreshInterval)
+		ctx.WriteKeyWord(n.RefreshUnit.String())
+	}
+	ctx.WriteKeyWord(" AS ")
+	if err := n.Select.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateMaterializedViewStmt.Select")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	selnode, ok := n.Select.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Select = selnode.(StmtNode)
+	return v.Leave(n)
+}
+
+// DropMaterializedViewStmt is a statement to drop a materialized view.
+type DropMaterializedViewStmt struct {
+	ddlNode
+
+	IfExists bool
+	ViewName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP MATERIALIZED VIEW ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropMaterializedViewStmt.ViewName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
 // CreatePlacementPolicyStmt is a statement to create a policy.
 type CreatePlacementPolicyStmt struct {
 	ddlNode
@@ -2222,13 +2446,17 @@ const (
 	RunawayRule RunawayOptionType = iota
 	RunawayAction
 	RunawayWatch
+	RunawayRuleProcessedKeys
+	RunawayRuleRU
+	RunawayRuleMemory
 )
 
 // ResourceGroupRunawayOption is used for parsing resource group runaway rule option.
 type ResourceGroupRunawayOption struct {
-	Tp       RunawayOptionType
-	StrValue string
-	IntValue int32
+	Tp        RunawayOptionType
+	StrValue  string
+	IntValue  int32
+	UintValue uint64
 }
 
 func (n *ResourceGroupRunawayOption) Restore(ctx *format.RestoreCtx) error {
@@ -2237,10 +2465,31 @@ func (n *ResourceGroupRunawayOption) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("EXEC_ELAPSED ")
 		ctx.WritePlain("= ")
 		ctx.WriteString(n.StrValue)
+	case RunawayRuleProcessedKeys:
+		ctx.WriteKeyWord("PROCESSED_KEYS ")
+		ctx.WritePlain("= ")
+		ctx.WritePlainf("%d", n.UintValue)
+	case RunawayRuleRU:
+		ctx.WriteKeyWord("RU ")
+		ctx.WritePlain("= ")
+		ctx.WritePlainf("%d", n.UintValue)
+	case RunawayRuleMemory:
+		ctx.WriteKeyWord("MEMORY ")
+		ctx.WritePlain("= ")
+		if len(n.StrValue) > 0 {
+			ctx.WriteString(n.StrValue)
+		} else {
+			ctx.WritePlainf("%d", n.UintValue)
+		}
 	case RunawayAction:
 		ctx.WriteKeyWord("ACTION ")
 		ctx.WritePlain("= ")
 		ctx.WriteKeyWord(model.RunawayActionType(n.IntValue).String())
+		if n.IntValue == int32(model.RunawayActionSwitchGroup) {
+			ctx.WritePlain("(")
+			ctx.WriteName(n.StrValue)
+			ctx.WritePlain(")")
+		}
 	case RunawayWatch:
 		ctx.WriteKeyWord("WATCH ")
 		ctx.WritePlain("= ")
@@ -2338,6 +2587,10 @@ const (
 	TableOptionTTL
 	TableOptionTTLEnable
 	TableOptionTTLJobInterval
+	TableOptionTTLFilter
+	TableOptionTTLArchiveTo
+	TableOptionTTLJobWindow
+	TableOptionTTLDeleteRate
 	TableOptionPlacementPolicy = TableOptionType(PlacementOptionPolicy)
 	TableOptionStatsBuckets    = TableOptionType(StatsOptionBuckets)
 	TableOptionStatsTopN       = TableOptionType(StatsOptionTopN)
@@ -2703,6 +2956,37 @@ func (n *TableOption) Restore(ctx *format.RestoreCtx) error {
 			ctx.WriteString(n.StrValue)
 			return nil
 		})
+	case TableOptionTTLFilter:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_FILTER ")
+			ctx.WritePlain("= ")
+			ctx.WriteString(n.StrValue)
+			return nil
+		})
+	case TableOptionTTLArchiveTo:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_ARCHIVE_TO ")
+			ctx.WritePlain("= ")
+			if len(n.TableNames) == 0 {
+				ctx.WriteString("")
+				return nil
+			}
+			return n.TableNames[0].Restore(ctx)
+		})
+	case TableOptionTTLJobWindow:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_JOB_WINDOW ")
+			ctx.WritePlain("= ")
+			ctx.WriteString(n.StrValue)
+			return nil
+		})
+	case TableOptionTTLDeleteRate:
+		_ = ctx.WriteWithSpecialComments(tidb.FeatureIDTTL, func() error {
+			ctx.WriteKeyWord("TTL_DELETE_RATE ")
+			ctx.WritePlain("= ")
+			ctx.WritePlainf("%d", n.UintValue)
+			return nil
+		})
 	default:
 		return errors.Errorf("invalid TableOption: %d", n.Tp)
 	}
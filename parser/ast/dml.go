@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/types"
 )
 
 var (
@@ -43,6 +44,8 @@ var (
 	_ Node = &HavingClause{}
 	_ Node = &AsOfClause{}
 	_ Node = &Join{}
+	_ Node = &JSONTable{}
+	_ Node = &JSONTableColumn{}
 	_ Node = &Limit{}
 	_ Node = &OnCondition{}
 	_ Node = &OrderByClause{}
@@ -68,6 +71,8 @@ const (
 	LeftJoin
 	// RightJoin is right Join type.
 	RightJoin
+	// FullJoin is full outer Join type.
+	FullJoin
 )
 
 // Join represents table join.
@@ -148,6 +153,11 @@ func NewCrossJoin(left, right ResultSetNode) (n *Join) {
 		if !(ok && join.Right != nil) {
 			break
 		}
+		// The full join in parentheses can't be associated with the cross join,
+		// t1 join (t2 full join t3) is not equal to (t1 join t2) full join t3.
+		if join.Tp == FullJoin && join.ExplicitParens {
+			break
+		}
 		leftMostLeafFatherOfRight = join
 	}
 
@@ -190,6 +200,8 @@ func (n *Join) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord(" LEFT")
 	case RightJoin:
 		ctx.WriteKeyWord(" RIGHT")
+	case FullJoin:
+		ctx.WriteKeyWord(" FULL")
 	}
 	if n.StraightJoin {
 		ctx.WriteKeyWord(" STRAIGHT_JOIN ")
@@ -531,6 +543,10 @@ type TableSource struct {
 
 	// AsName is the alias name of the table source.
 	AsName model.CIStr
+
+	// Lateral indicates it's a LATERAL derived table, which can refer to
+	// the columns of the preceding tables in the FROM clause.
+	Lateral bool
 }
 
 func (*TableSource) resultSet() {}
@@ -576,6 +592,9 @@ func (n *TableSource) Restore(ctx *format.RestoreCtx) error {
 			ctx.WritePlain(")")
 		}
 	} else {
+		if n.Lateral {
+			ctx.WriteKeyWord("LATERAL ")
+		}
 		if needParen {
 			ctx.WritePlain("(")
 		}
@@ -609,6 +628,171 @@ func (n *TableSource) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// JSONTable represents the JSON_TABLE table function, which extracts data from a JSON document
+// and returns it as a relational table.
+// See https://dev.mysql.com/doc/refman/8.0/en/json-table-functions.html
+type JSONTable struct {
+	node
+
+	// Expr is the JSON document, it may refer to the columns of the preceding tables.
+	Expr ExprNode
+	// Path is the row path, every value matched by it produces a row.
+	Path string
+	// Columns are the column definitions in the COLUMNS clause.
+	Columns []*JSONTableColumn
+}
+
+func (*JSONTable) resultSet() {}
+
+// Restore implements Node interface.
+func (n *JSONTable) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("JSON_TABLE")
+	ctx.WritePlain("(")
+	if err := n.Expr.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTable.Expr")
+	}
+	ctx.WritePlain(", ")
+	ctx.WriteString(n.Path)
+	ctx.WritePlain(" ")
+	if err := restoreJSONTableColumns(ctx, n.Columns); err != nil {
+		return err
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+func restoreJSONTableColumns(ctx *format.RestoreCtx, cols []*JSONTableColumn) error {
+	ctx.WriteKeyWord("COLUMNS ")
+	ctx.WritePlain("(")
+	for i, col := range cols {
+		if i != 0 {
+			ctx.WritePlain(", ")
+		}
+		if err := col.Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore JSONTable.Columns[%d]", i)
+		}
+	}
+	ctx.WritePlain(")")
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTable) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*JSONTable)
+	node, ok := n.Expr.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Expr = node.(ExprNode)
+	return v.Leave(n)
+}
+
+// JSONTableColumnType is the type of a JSON_TABLE column.
+type JSONTableColumnType int
+
+// JSON_TABLE column types.
+const (
+	// JSONTableColumnPath is a column extracted by `name type PATH path`.
+	JSONTableColumnPath JSONTableColumnType = iota
+	// JSONTableColumnExistsPath is a column defined by `name type EXISTS PATH path`.
+	JSONTableColumnExistsPath
+	// JSONTableColumnOrdinality is a column defined by `name FOR ORDINALITY`.
+	JSONTableColumnOrdinality
+	// JSONTableColumnNested is a `NESTED PATH path COLUMNS (...)` definition.
+	JSONTableColumnNested
+)
+
+// JSONTableOnResponseType is the response type of the ON EMPTY and ON ERROR clauses.
+type JSONTableOnResponseType int
+
+// JSON_TABLE ON EMPTY and ON ERROR response types.
+const (
+	JSONTableOnResponseNull JSONTableOnResponseType = iota
+	JSONTableOnResponseError
+	JSONTableOnResponseDefault
+)
+
+// JSONTableOnResponse is the ON EMPTY or ON ERROR clause of a JSON_TABLE column.
+type JSONTableOnResponse struct {
+	Tp JSONTableOnResponseType
+	// Default is the JSON string used by `DEFAULT json_string`.
+	Default string
+}
+
+// Restore writes the response, such as `DEFAULT '0'`, into ctx.
+func (n *JSONTableOnResponse) Restore(ctx *format.RestoreCtx) {
+	switch n.Tp {
+	case JSONTableOnResponseNull:
+		ctx.WriteKeyWord("NULL")
+	case JSONTableOnResponseError:
+		ctx.WriteKeyWord("ERROR")
+	case JSONTableOnResponseDefault:
+		ctx.WriteKeyWord("DEFAULT ")
+		ctx.WriteString(n.Default)
+	}
+}
+
+// JSONTableColumn is a column definition in the COLUMNS clause of JSON_TABLE.
+type JSONTableColumn struct {
+	node
+
+	Tp JSONTableColumnType
+	// Name is empty for the nested columns.
+	Name model.CIStr
+	// FieldType is only set for the PATH and EXISTS PATH columns.
+	FieldType *types.FieldType
+	Path      string
+	OnEmpty   *JSONTableOnResponse
+	OnError   *JSONTableOnResponse
+	// NestedColumns is only set for the nested columns.
+	NestedColumns []*JSONTableColumn
+}
+
+// Restore implements Node interface.
+func (n *JSONTableColumn) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == JSONTableColumnNested {
+		ctx.WriteKeyWord("NESTED PATH ")
+		ctx.WriteString(n.Path)
+		ctx.WritePlain(" ")
+		return restoreJSONTableColumns(ctx, n.NestedColumns)
+	}
+	ctx.WriteName(n.Name.O)
+	if n.Tp == JSONTableColumnOrdinality {
+		ctx.WriteKeyWord(" FOR ORDINALITY")
+		return nil
+	}
+	ctx.WritePlain(" ")
+	if err := n.FieldType.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore JSONTableColumn.FieldType")
+	}
+	if n.Tp == JSONTableColumnExistsPath {
+		ctx.WriteKeyWord(" EXISTS")
+	}
+	ctx.WriteKeyWord(" PATH ")
+	ctx.WriteString(n.Path)
+	if n.OnEmpty != nil {
+		ctx.WritePlain(" ")
+		n.OnEmpty.Restore(ctx)
+		ctx.WriteKeyWord(" ON EMPTY")
+	}
+	if n.OnError != nil {
+		ctx.WritePlain(" ")
+		n.OnError.Restore(ctx)
+		ctx.WriteKeyWord(" ON ERROR")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *JSONTableColumn) Accept(v Visitor) (Node, bool) {
+	newNode, _ := v.Enter(n)
+	return v.Leave(newNode)
+}
+
 // SelectLockType is the lock type for SelectStmt.
 type SelectLockType int
 
@@ -850,22 +1034,64 @@ type GroupByClause struct {
 	node
 	Items  []*ByItem
 	Rollup bool
+	Cube   bool
+	// GroupingSets is the offsets of the items in each set of GROUPING SETS (...),
+	// the items of all the sets are flattened into Items.
+	GroupingSets [][]int
 }
 
 // Restore implements Node interface.
 func (n *GroupByClause) Restore(ctx *format.RestoreCtx) error {
 	ctx.WriteKeyWord("GROUP BY ")
-	for i, v := range n.Items {
+	switch {
+	case n.Cube:
+		ctx.WriteKeyWord("CUBE")
+		ctx.WritePlain("(")
+		if err := n.restoreItems(ctx, nil); err != nil {
+			return err
+		}
+		ctx.WritePlain(")")
+	case n.GroupingSets != nil:
+		ctx.WriteKeyWord("GROUPING SETS ")
+		ctx.WritePlain("(")
+		for i, set := range n.GroupingSets {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			ctx.WritePlain("(")
+			if err := n.restoreItems(ctx, set); err != nil {
+				return err
+			}
+			ctx.WritePlain(")")
+		}
+		ctx.WritePlain(")")
+	default:
+		if err := n.restoreItems(ctx, nil); err != nil {
+			return err
+		}
+		if n.Rollup {
+			ctx.WriteKeyWord(" WITH ROLLUP")
+		}
+	}
+	return nil
+}
+
+// restoreItems restores the items at the offsets, or all the items if offsets is nil.
+func (n *GroupByClause) restoreItems(ctx *format.RestoreCtx, offsets []int) error {
+	if offsets == nil {
+		offsets = make([]int, 0, len(n.Items))
+		for i := range n.Items {
+			offsets = append(offsets, i)
+		}
+	}
+	for i, offset := range offsets {
 		if i != 0 {
 			ctx.WritePlain(",")
 		}
-		if err := v.Restore(ctx); err != nil {
-			return errors.Annotatef(err, "An error occurred while restore GroupByClause.Items[%d]", i)
+		if err := n.Items[offset].Restore(ctx); err != nil {
+			return errors.Annotatef(err, "An error occurred while restore GroupByClause.Items[%d]", offset)
 		}
 	}
-	if n.Rollup {
-		ctx.WriteKeyWord(" WITH ROLLUP")
-	}
 	return nil
 }
 
@@ -1436,6 +1662,23 @@ func restoreTables(ctx *format.RestoreCtx, ts []*TableName) error {
 	return nil
 }
 
+var _ SensitiveStmtNode = &SelectStmt{}
+
+// SecureText implements SensitiveStmtNode interface.
+// It redacts the credentials in the URI of the outfile, the original text is returned for other statements.
+func (n *SelectStmt) SecureText() string {
+	if n.SelectIntoOpt == nil || !n.SelectIntoOpt.IsURI() {
+		return n.Text()
+	}
+	redactedStmt := *n
+	redactedIntoOpt := *n.SelectIntoOpt
+	redactedIntoOpt.FileName = RedactURL(n.SelectIntoOpt.FileName)
+	redactedStmt.SelectIntoOpt = &redactedIntoOpt
+	var sb strings.Builder
+	_ = redactedStmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb))
+	return sb.String()
+}
+
 // Accept implements Node Accept interface.
 func (n *SelectStmt) Accept(v Visitor) (Node, bool) {
 	newNode, skipChildren := v.Enter(n)
@@ -1547,6 +1790,14 @@ func (n *SelectStmt) Accept(v Visitor) (Node, bool) {
 		}
 	}
 
+	if n.SelectIntoOpt != nil {
+		node, ok := n.SelectIntoOpt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.SelectIntoOpt = node.(*SelectIntoOption)
+	}
+
 	return v.Leave(n)
 }
 
@@ -2977,6 +3228,7 @@ const (
 	ShowCreateResourceGroup
 	ShowImportJobs
 	ShowCreateProcedure
+	ShowCreateEvent
 )
 
 const (
@@ -3002,6 +3254,7 @@ type ShowStmt struct {
 	Table  *TableName // Used for showing columns.
 	// Procedure's naming method is consistent with the table name
 	Procedure         *TableName
+	Event             *TableName  // Used for `show create event`.
 	Partition         model.CIStr // Used for showing partition.
 	Column            *ColumnName // Used for `desc table column`.
 	IndexName         model.CIStr
@@ -3076,6 +3329,11 @@ func (n *ShowStmt) Restore(ctx *format.RestoreCtx) error {
 		if err := n.Procedure.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore ShowStmt.Procedure")
 		}
+	case ShowCreateEvent:
+		ctx.WriteKeyWord("CREATE EVENT ")
+		if err := n.Event.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore ShowStmt.Event")
+		}
 	case ShowCreateView:
 		ctx.WriteKeyWord("CREATE VIEW ")
 		if err := n.Table.Restore(ctx); err != nil {
@@ -3546,17 +3804,47 @@ type SelectIntoOption struct {
 	FileName   string
 	FieldsInfo *FieldsClause
 	LinesInfo  *LinesClause
+	// Format is the format of the outfile, nil means the default delimited text format.
+	Format *string
+	// Options are the options of the outfile, such as compression and max_file_size.
+	Options []*LoadDataOpt
+	// Variables is filled only when Tp == SelectIntoVars. Each item is either
+	// a *VariableExpr for user variables or a *ColumnNameExpr for the local
+	// variables of stored procedures.
+	Variables []ExprNode
+}
+
+// IsURI returns whether the outfile is an URI of the external storage, such as 's3://bucket/prefix/result.csv'.
+// Other file names are plain paths on the disk of TiDB server, the same as MySQL.
+func (n *SelectIntoOption) IsURI() bool {
+	return n.Tp != SelectIntoVars && strings.Contains(n.FileName, "://")
 }
 
 // Restore implements Node interface.
 func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
+	if n.Tp == SelectIntoVars {
+		ctx.WriteKeyWord("INTO ")
+		for i, v := range n.Variables {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			if err := v.Restore(ctx); err != nil {
+				return errors.Annotatef(err, "An error occurred while restore SelectInto.Variables[%d]", i)
+			}
+		}
+		return nil
+	}
 	if n.Tp != SelectIntoOutfile {
-		// only support SELECT/TABLE/VALUES ... INTO OUTFILE statement now
+		// only support SELECT/TABLE/VALUES ... INTO OUTFILE/var_list statement now
 		return errors.New("Unsupported SelectionInto type")
 	}
 
 	ctx.WriteKeyWord("INTO OUTFILE ")
 	ctx.WriteString(n.FileName)
+	if n.Format != nil {
+		ctx.WriteKeyWord(" FORMAT ")
+		ctx.WriteString(*n.Format)
+	}
 	if n.FieldsInfo != nil {
 		if err := n.FieldsInfo.Restore(ctx); err != nil {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.FieldsInfo")
@@ -3567,6 +3855,18 @@ func (n *SelectIntoOption) Restore(ctx *format.RestoreCtx) error {
 			return errors.Annotate(err, "An error occurred while restore SelectInto.LinesInfo")
 		}
 	}
+	if len(n.Options) > 0 {
+		ctx.WriteKeyWord(" WITH")
+		for i, option := range n.Options {
+			if i != 0 {
+				ctx.WritePlain(",")
+			}
+			ctx.WritePlain(" ")
+			if err := option.Restore(ctx); err != nil {
+				return errors.Annotate(err, "An error occurred while restore SelectInto.Options")
+			}
+		}
+	}
 	return nil
 }
 
@@ -3576,6 +3876,24 @@ func (n *SelectIntoOption) Accept(v Visitor) (Node, bool) {
 	if skipChildren {
 		return v.Leave(newNode)
 	}
+	n = newNode.(*SelectIntoOption)
+	for i, val := range n.Variables {
+		node, ok := val.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Variables[i] = node.(ExprNode)
+	}
+	for _, opt := range n.Options {
+		if opt.Value == nil {
+			continue
+		}
+		node, ok := opt.Value.Accept(v)
+		if !ok {
+			return n, false
+		}
+		opt.Value = node.(ExprNode)
+	}
 	return v.Leave(n)
 }
 
@@ -3621,19 +3939,31 @@ func (n *PartitionByClause) Accept(v Visitor) (Node, bool) {
 type FrameType int
 
 // Window function frame types.
-// MySQL only supports `ROWS` and `RANGES`.
+// MySQL only supports `ROWS` and `RANGES`, `GROUPS` is defined by the SQL standard.
 const (
 	Rows = iota
 	Ranges
 	Groups
 )
 
+// FrameExclusion is the type of the EXCLUDE clause of window function frame.
+type FrameExclusion int
+
+// Window function frame exclusions.
+const (
+	ExcludeNoOthers FrameExclusion = iota
+	ExcludeCurrentRow
+	ExcludeGroup
+	ExcludeTies
+)
+
 // FrameClause represents frame clause.
 type FrameClause struct {
 	node
 
-	Type   FrameType
-	Extent FrameExtent
+	Type    FrameType
+	Extent  FrameExtent
+	Exclude FrameExclusion
 }
 
 // Restore implements Node interface.
@@ -3643,6 +3973,8 @@ func (n *FrameClause) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ROWS")
 	case Ranges:
 		ctx.WriteKeyWord("RANGE")
+	case Groups:
+		ctx.WriteKeyWord("GROUPS")
 	default:
 		return errors.New("Unsupported window function frame type")
 	}
@@ -3654,6 +3986,14 @@ func (n *FrameClause) Restore(ctx *format.RestoreCtx) error {
 	if err := n.Extent.End.Restore(ctx); err != nil {
 		return errors.Annotate(err, "An error occurred while restore FrameClause.Extent.End")
 	}
+	switch n.Exclude {
+	case ExcludeCurrentRow:
+		ctx.WriteKeyWord(" EXCLUDE CURRENT ROW")
+	case ExcludeGroup:
+		ctx.WriteKeyWord(" EXCLUDE GROUP")
+	case ExcludeTies:
+		ctx.WriteKeyWord(" EXCLUDE TIES")
+	}
 
 	return nil
 }
@@ -607,6 +607,40 @@ func TestFulltextSearchModifier(t *testing.T) {
 	require.False(t, FulltextSearchModifier(FulltextSearchModifierNaturalLanguageMode).WithQueryExpansion())
 }
 
+func TestSelectIntoSecureText(t *testing.T) {
+	testCases := []struct {
+		input   string
+		secured string
+	}{
+		{
+			input:   "select * from t into outfile 's3://bucket/prefix/result.csv?access-key=aaaaa&secret-access-key=bbbbb' format 'parquet'",
+			secured: `^SELECT \* FROM .t. INTO OUTFILE \Q's3://bucket/prefix/result.csv?\E((access-key=xxxxxx|secret-access-key=xxxxxx)(&|' FORMAT 'parquet'$)){2}`,
+		},
+		{
+			input:   "select * from t into outfile 'gcs://bucket/prefix/result.csv?access-key=aaaaa&secret-access-key=bbbbb'",
+			secured: "\\QSELECT * FROM `t` INTO OUTFILE 'gcs://bucket/prefix/result.csv?access-key=aaaaa&secret-access-key=bbbbb'\\E",
+		},
+		{
+			input:   "select * from t into outfile '/tmp/50%off.csv'",
+			secured: "^\\Qselect * from t into outfile '/tmp/50%off.csv'\\E$",
+		},
+		{
+			input:   "select * from t",
+			secured: "^\\Qselect * from t\\E$",
+		},
+	}
+
+	p := parser.New()
+	for _, tc := range testCases {
+		comment := fmt.Sprintf("input = %s", tc.input)
+		node, err := p.ParseOneStmt(tc.input, "", "")
+		require.NoError(t, err, comment)
+		n, ok := node.(SensitiveStmtNode)
+		require.True(t, ok, comment)
+		require.Regexp(t, tc.secured, n.SecureText(), comment)
+	}
+}
+
 func TestImportIntoSecureText(t *testing.T) {
 	testCases := []struct {
 		input   string
@@ -0,0 +1,381 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ast
+
+import (
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/format"
+)
+
+var (
+	_ Node = &EventSchedule{}
+
+	_ StmtNode = &CreateEventStmt{}
+	_ StmtNode = &AlterEventStmt{}
+	_ StmtNode = &DropEventStmt{}
+)
+
+// EventStatusType is the status of an event specified by `ENABLE`, `DISABLE` or `DISABLE ON SLAVE`.
+type EventStatusType int
+
+// Event status types.
+const (
+	EventStatusUnspecified EventStatusType = iota
+	EventStatusEnable
+	EventStatusDisable
+	EventStatusDisableOnSlave
+)
+
+// String implements fmt.Stringer interface, it returns the status shown in `SHOW EVENTS`.
+func (s EventStatusType) String() string {
+	switch s {
+	case EventStatusDisable:
+		return "DISABLED"
+	case EventStatusDisableOnSlave:
+		return "SLAVESIDE_DISABLED"
+	default:
+		return "ENABLED"
+	}
+}
+
+// Restore writes the clause of the status.
+func (s EventStatusType) Restore(ctx *format.RestoreCtx) {
+	switch s {
+	case EventStatusEnable:
+		ctx.WriteKeyWord("ENABLE")
+	case EventStatusDisable:
+		ctx.WriteKeyWord("DISABLE")
+	case EventStatusDisableOnSlave:
+		ctx.WriteKeyWord("DISABLE ON SLAVE")
+	}
+}
+
+// EventCompletionType is the `ON COMPLETION [NOT] PRESERVE` clause of an event.
+type EventCompletionType int
+
+// Event completion types.
+const (
+	EventCompletionUnspecified EventCompletionType = iota
+	EventCompletionNotPreserve
+	EventCompletionPreserve
+)
+
+// String implements fmt.Stringer interface, it returns the value of `ON_COMPLETION` in `information_schema.EVENTS`.
+func (c EventCompletionType) String() string {
+	if c == EventCompletionPreserve {
+		return "PRESERVE"
+	}
+	return "NOT PRESERVE"
+}
+
+// Restore writes the clause of the completion.
+func (c EventCompletionType) Restore(ctx *format.RestoreCtx) {
+	switch c {
+	case EventCompletionPreserve:
+		ctx.WriteKeyWord("ON COMPLETION PRESERVE")
+	case EventCompletionNotPreserve:
+		ctx.WriteKeyWord("ON COMPLETION NOT PRESERVE")
+	}
+}
+
+// EventSchedule is the `ON SCHEDULE` clause of an event, which is either
+// `AT timestamp` or `EVERY interval [STARTS timestamp] [ENDS timestamp]`.
+type EventSchedule struct {
+	node
+
+	// At is the time to execute a one-time event, it's nil for a recurring event.
+	At ExprNode
+	// Every is the interval value of a recurring event, and Unit is its unit.
+	Every  ExprNode
+	Unit   TimeUnitType
+	Starts ExprNode
+	Ends   ExprNode
+}
+
+// Restore implements Node interface.
+func (n *EventSchedule) Restore(ctx *format.RestoreCtx) error {
+	if n.At != nil {
+		ctx.WriteKeyWord("AT ")
+		if err := n.At.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.At")
+		}
+		return nil
+	}
+	ctx.WriteKeyWord("EVERY ")
+	if err := n.Every.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore EventSchedule.Every")
+	}
+	ctx.WritePlain(" ")
+	ctx.WriteKeyWord(n.Unit.String())
+	if n.Starts != nil {
+		ctx.WriteKeyWord(" STARTS ")
+		if err := n.Starts.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Starts")
+		}
+	}
+	if n.Ends != nil {
+		ctx.WriteKeyWord(" ENDS ")
+		if err := n.Ends.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore EventSchedule.Ends")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *EventSchedule) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*EventSchedule)
+	for _, expr := range []*ExprNode{&n.At, &n.Every, &n.Starts, &n.Ends} {
+		if *expr == nil {
+			continue
+		}
+		node, ok := (*expr).Accept(v)
+		if !ok {
+			return n, false
+		}
+		*expr = node.(ExprNode)
+	}
+	return v.Leave(n)
+}
+
+func restoreEventDefiner(ctx *format.RestoreCtx, definer *auth.UserIdentity) {
+	if definer == nil {
+		return
+	}
+	ctx.WriteKeyWord("DEFINER")
+	ctx.WritePlain(" = ")
+	if definer.CurrentUser {
+		ctx.WriteKeyWord("current_user")
+	} else {
+		ctx.WriteName(definer.Username)
+		if definer.Hostname != "" {
+			ctx.WritePlain("@")
+			ctx.WriteName(definer.Hostname)
+		}
+	}
+	ctx.WritePlain(" ")
+}
+
+func restoreEventOptions(ctx *format.RestoreCtx, status EventStatusType, comment *string) {
+	if status != EventStatusUnspecified {
+		ctx.WritePlain(" ")
+		status.Restore(ctx)
+	}
+	if comment != nil {
+		ctx.WriteKeyWord(" COMMENT ")
+		ctx.WriteString(*comment)
+	}
+}
+
+func acceptEventBody(v Visitor, body StmtNode) (StmtNode, bool) {
+	if body == nil {
+		return nil, true
+	}
+	node, ok := body.Accept(v)
+	if !ok {
+		return body, false
+	}
+	return node.(StmtNode), true
+}
+
+// CreateEventStmt is a statement to create an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/create-event.html
+type CreateEventStmt struct {
+	stmtNode
+
+	IfNotExists  bool
+	Definer      *auth.UserIdentity
+	EventName    *TableName
+	Schedule     *EventSchedule
+	OnCompletion EventCompletionType
+	Status       EventStatusType
+	Comment      *string
+	Body         StmtNode
+}
+
+// Restore implements Node interface.
+func (n *CreateEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("CREATE ")
+	restoreEventDefiner(ctx, n.Definer)
+	ctx.WriteKeyWord("EVENT ")
+	if n.IfNotExists {
+		ctx.WriteKeyWord("IF NOT EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.EventName")
+	}
+	ctx.WriteKeyWord(" ON SCHEDULE ")
+	if err := n.Schedule.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Schedule")
+	}
+	if n.OnCompletion != EventCompletionUnspecified {
+		ctx.WritePlain(" ")
+		n.OnCompletion.Restore(ctx)
+	}
+	restoreEventOptions(ctx, n.Status, n.Comment)
+	ctx.WriteKeyWord(" DO ")
+	if err := n.Body.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore CreateEventStmt.Body")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *CreateEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*CreateEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	node, ok = n.Schedule.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.Schedule = node.(*EventSchedule)
+	if n.Body, ok = acceptEventBody(v, n.Body); !ok {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// AlterEventStmt is a statement to change an event, the unspecified clauses are not changed.
+// See https://dev.mysql.com/doc/refman/8.0/en/alter-event.html
+type AlterEventStmt struct {
+	stmtNode
+
+	Definer   *auth.UserIdentity
+	EventName *TableName
+	// Schedule is nil if the schedule is not changed.
+	Schedule     *EventSchedule
+	OnCompletion EventCompletionType
+	// NewName is not nil if the event is renamed.
+	NewName *TableName
+	Status  EventStatusType
+	Comment *string
+	// Body is nil if the body is not changed.
+	Body StmtNode
+}
+
+// Restore implements Node interface.
+func (n *AlterEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("ALTER ")
+	restoreEventDefiner(ctx, n.Definer)
+	ctx.WriteKeyWord("EVENT ")
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore AlterEventStmt.EventName")
+	}
+	if n.Schedule != nil {
+		ctx.WriteKeyWord(" ON SCHEDULE ")
+		if err := n.Schedule.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Schedule")
+		}
+	}
+	if n.OnCompletion != EventCompletionUnspecified {
+		ctx.WritePlain(" ")
+		n.OnCompletion.Restore(ctx)
+	}
+	if n.NewName != nil {
+		ctx.WriteKeyWord(" RENAME TO ")
+		if err := n.NewName.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.NewName")
+		}
+	}
+	restoreEventOptions(ctx, n.Status, n.Comment)
+	if n.Body != nil {
+		ctx.WriteKeyWord(" DO ")
+		if err := n.Body.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore AlterEventStmt.Body")
+		}
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *AlterEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*AlterEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	if n.Schedule != nil {
+		node, ok = n.Schedule.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Schedule = node.(*EventSchedule)
+	}
+	if n.NewName != nil {
+		node, ok = n.NewName.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.NewName = node.(*TableName)
+	}
+	if n.Body, ok = acceptEventBody(v, n.Body); !ok {
+		return n, false
+	}
+	return v.Leave(n)
+}
+
+// DropEventStmt is a statement to drop an event.
+// See https://dev.mysql.com/doc/refman/8.0/en/drop-event.html
+type DropEventStmt struct {
+	stmtNode
+
+	IfExists  bool
+	EventName *TableName
+}
+
+// Restore implements Node interface.
+func (n *DropEventStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("DROP EVENT ")
+	if n.IfExists {
+		ctx.WriteKeyWord("IF EXISTS ")
+	}
+	if err := n.EventName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore DropEventStmt.EventName")
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *DropEventStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*DropEventStmt)
+	node, ok := n.EventName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.EventName = node.(*TableName)
+	return v.Leave(n)
+}
@@ -303,6 +303,7 @@ const (
 	GetLock         = "get_lock"
 	ReleaseLock     = "release_lock"
 	Grouping        = "grouping"
+	HLLEstimate     = "hll_estimate"
 
 	// encryption and compression functions
 	AesDecrypt               = "aes_decrypt"
@@ -354,6 +355,25 @@ const (
 	JSONKeys          = "json_keys"
 	JSONLength        = "json_length"
 
+	// spatial functions
+	STAsBinary         = "st_asbinary"
+	STAsText           = "st_astext"
+	STAsWKB            = "st_aswkb"
+	STAsWKT            = "st_aswkt"
+	STContains         = "st_contains"
+	STDistance         = "st_distance"
+	STGeomFromText     = "st_geomfromtext"
+	STGeomFromWKB      = "st_geomfromwkb"
+	STGeometryFromText = "st_geometryfromtext"
+	STGeometryFromWKB  = "st_geometryfromwkb"
+	STGeometryType     = "st_geometrytype"
+	STIntersects       = "st_intersects"
+	STSRID             = "st_srid"
+	STWithin           = "st_within"
+	STX                = "st_x"
+	STY                = "st_y"
+	Point              = "point"
+
 	// TiDB internal function.
 	TiDBDecodeKey       = "tidb_decode_key"
 	TiDBDecodeBase64Key = "tidb_decode_base64_key"
@@ -779,6 +799,12 @@ const (
 	AggFuncApproxCountDistinct = "approx_count_distinct"
 	// AggFuncApproxPercentile is the name of approx_percentile function.
 	AggFuncApproxPercentile = "approx_percentile"
+	// AggFuncApproxTopK is the name of approx_top_k function.
+	AggFuncApproxTopK = "approx_top_k"
+	// AggFuncHLLSketch is the name of hll_sketch function.
+	AggFuncHLLSketch = "hll_sketch"
+	// AggFuncHLLMerge is the name of hll_merge function.
+	AggFuncHLLMerge = "hll_merge"
 )
 
 // AggregateFuncExpr represents aggregate function expression.
@@ -49,6 +49,7 @@ var (
 	_ StmtNode = &SetSessionStatesStmt{}
 	_ StmtNode = &UseStmt{}
 	_ StmtNode = &FlushStmt{}
+	_ StmtNode = &RefreshMaterializedViewStmt{}
 	_ StmtNode = &KillStmt{}
 	_ StmtNode = &CreateBindingStmt{}
 	_ StmtNode = &DropBindingStmt{}
@@ -864,6 +865,43 @@ func (n *VariableAssignment) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// RefreshMaterializedViewStmt is a statement to refresh a materialized view.
+type RefreshMaterializedViewStmt struct {
+	stmtNode
+
+	ViewName *TableName
+	// Method is MViewRefreshDefault if the view is refreshed by its own method.
+	Method model.MViewRefreshMethod
+}
+
+// Restore implements Node interface.
+func (n *RefreshMaterializedViewStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("REFRESH MATERIALIZED VIEW ")
+	if err := n.ViewName.Restore(ctx); err != nil {
+		return errors.Annotate(err, "An error occurred while restore RefreshMaterializedViewStmt.ViewName")
+	}
+	if n.Method != model.MViewRefreshDefault {
+		ctx.WritePlain(" ")
+		ctx.WriteKeyWord(n.Method.String())
+	}
+	return nil
+}
+
+// Accept implements Node Accept interface.
+func (n *RefreshMaterializedViewStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*RefreshMaterializedViewStmt)
+	node, ok := n.ViewName.Accept(v)
+	if !ok {
+		return n, false
+	}
+	n.ViewName = node.(*TableName)
+	return v.Leave(n)
+}
+
 // FlushStmtType is the type for FLUSH statement.
 type FlushStmtType int
 
@@ -4013,6 +4051,11 @@ func (n *QueryWatchOption) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ACTION ")
 		ctx.WritePlain("= ")
 		ctx.WriteKeyWord(model.RunawayActionType(n.IntValue).String())
+		if n.IntValue == int32(model.RunawayActionSwitchGroup) {
+			ctx.WritePlain("(")
+			ctx.WriteName(n.StrValue.O)
+			ctx.WritePlain(")")
+		}
 	case QueryWatchType:
 		if n.BoolValue {
 			ctx.WriteKeyWord("SQL TEXT ")
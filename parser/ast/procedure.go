@@ -17,6 +17,7 @@ import (
 	"strconv"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/parser/format"
 	"github.com/pingcap/tidb/parser/types"
 )
@@ -233,15 +234,21 @@ func (n *ProcedureBlock) Accept(v Visitor) (Node, bool) {
 type ProcedureInfo struct {
 	stmtNode
 	IfNotExists       bool
+	Definer           *auth.UserIdentity
 	ProcedureName     *TableName
-	ProcedureParam    []*StoreParameter //procedure param
-	ProcedureBody     StmtNode          //procedure body statement
-	ProcedureParamStr string            //procedure parameter string
+	ProcedureParam    []*StoreParameter          //procedure param
+	Characteristics   []*ProcedureCharacteristic //procedure characteristics
+	ProcedureBody     StmtNode                   //procedure body statement
+	ProcedureParamStr string                     //procedure parameter string
 }
 
 // Restore implements Node interface.
 func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord("CREATE PROCEDURE ")
+	ctx.WriteKeyWord("CREATE ")
+	if n.Definer != nil && !n.Definer.CurrentUser {
+		restoreEventDefiner(ctx, n.Definer)
+	}
+	ctx.WriteKeyWord("PROCEDURE ")
 	if n.IfNotExists {
 		ctx.WriteKeyWord("IF NOT EXISTS ")
 	}
@@ -260,6 +267,10 @@ func (n *ProcedureInfo) Restore(ctx *format.RestoreCtx) error {
 		}
 	}
 	ctx.WritePlain(") ")
+	for _, c := range n.Characteristics {
+		c.Restore(ctx)
+		ctx.WritePlain(" ")
+	}
 	err = (n.ProcedureBody).Restore(ctx)
 	if err != nil {
 		return err
@@ -289,6 +300,52 @@ func (n *ProcedureInfo) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// ProcedureCharacteristicType is the type of a characteristic of the stored procedure.
+type ProcedureCharacteristicType int
+
+// The characteristics of the stored procedure.
+const (
+	ProcedureComment ProcedureCharacteristicType = iota
+	ProcedureLanguageSQL
+	ProcedureDeterministic
+	ProcedureNotDeterministic
+	ProcedureContainsSQL
+	ProcedureNoSQL
+	ProcedureReadsSQLData
+	ProcedureModifiesSQLData
+	ProcedureSQLSecurityDefiner
+	ProcedureSQLSecurityInvoker
+)
+
+var procedureCharacteristicKeyWords = map[ProcedureCharacteristicType]string{
+	ProcedureComment:            "COMMENT",
+	ProcedureLanguageSQL:        "LANGUAGE SQL",
+	ProcedureDeterministic:      "DETERMINISTIC",
+	ProcedureNotDeterministic:   "NOT DETERMINISTIC",
+	ProcedureContainsSQL:        "CONTAINS SQL",
+	ProcedureNoSQL:              "NO SQL",
+	ProcedureReadsSQLData:       "READS SQL DATA",
+	ProcedureModifiesSQLData:    "MODIFIES SQL DATA",
+	ProcedureSQLSecurityDefiner: "SQL SECURITY DEFINER",
+	ProcedureSQLSecurityInvoker: "SQL SECURITY INVOKER",
+}
+
+// ProcedureCharacteristic is a characteristic of the stored procedure, e.g. `COMMENT 'str'` or `SQL SECURITY INVOKER`.
+type ProcedureCharacteristic struct {
+	Tp ProcedureCharacteristicType
+	// Comment is the string of the COMMENT characteristic.
+	Comment string
+}
+
+// Restore writes the characteristic into ctx.
+func (n *ProcedureCharacteristic) Restore(ctx *format.RestoreCtx) {
+	ctx.WriteKeyWord(procedureCharacteristicKeyWords[n.Tp])
+	if n.Tp == ProcedureComment {
+		ctx.WritePlain(" ")
+		ctx.WriteString(n.Comment)
+	}
+}
+
 // DropProcedureStmt represents the ast of `drop procedure`
 type DropProcedureStmt struct {
 	stmtNode
@@ -782,6 +839,45 @@ func (n *ProcedureWhileStmt) Accept(v Visitor) (Node, bool) {
 	return v.Leave(n)
 }
 
+// ProcedureLoopStmt stores `loop ... end loop` statement.
+type ProcedureLoopStmt struct {
+	stmtNode
+
+	Body []StmtNode
+}
+
+// Restore implements ProcedureLoopStmt interface.
+func (n *ProcedureLoopStmt) Restore(ctx *format.RestoreCtx) error {
+	ctx.WriteKeyWord("LOOP ")
+	for _, stmt := range n.Body {
+		err := stmt.Restore(ctx)
+		if err != nil {
+			return err
+		}
+		ctx.WriteKeyWord(";")
+	}
+	ctx.WriteKeyWord("END LOOP")
+	return nil
+}
+
+// Accept implements ProcedureLoopStmt Accept interface.
+func (n *ProcedureLoopStmt) Accept(v Visitor) (Node, bool) {
+	newNode, skipChildren := v.Enter(n)
+	if skipChildren {
+		return v.Leave(newNode)
+	}
+	n = newNode.(*ProcedureLoopStmt)
+
+	for i, stmt := range n.Body {
+		node, ok := stmt.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Body[i] = node.(StmtNode)
+	}
+	return v.Leave(n)
+}
+
 // ProcedureCursor stores procedure cursor statement.
 type ProcedureCursor struct {
 	ProcedureDeclInfo
@@ -1161,7 +1257,7 @@ func (n *ProcedureJump) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ITERATE ")
 	}
 
-	ctx.WriteString(n.Name)
+	ctx.WriteName(n.Name)
 	return nil
 }
 
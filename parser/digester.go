@@ -420,7 +420,8 @@ func (d *sqlDigester) isLit(t token) (beLit bool) {
 	tok := t.tok
 	if d.isNumLit(tok) || tok == stringLit || tok == bitLit || tok == paramMarker {
 		beLit = true
-	} else if t.lit == "*" {
+	} else if t.lit == "*" && tok != quotedIdentifier {
+		// The quoted identifier `*` is the wildcard schema of cross-database bindings, not a literal.
 		beLit = true
 	} else if tok == null || (tok == identifier && strings.ToLower(t.lit) == "null") {
 		beLit = true
@@ -48,6 +48,7 @@ func TestNormalize(t *testing.T) {
 		{"select a, b from t order by 1, 2", "select `a` , `b` from `t` order by 1 , 2"},
 		{"select count(*) from t", "select count ( ? ) from `t`"},
 		{"select * from t Force Index(kk)", "select * from `t`"},
+		{"select * from (`*`.t1) join `*`.t2", "select * from ( `*` . `t1` ) join `*` . `t2`"},
 		{"select * from t USE Index(kk)", "select * from `t`"},
 		{"select * from t Ignore Index(kk)", "select * from `t`"},
 		{"select * from t1 straight_join t2 on t1.id=t2.id", "select * from `t1` join `t2` on `t1` . `id` = `t2` . `id`"},
@@ -279,6 +279,13 @@ func (s *Scanner) Lex(v *yySymType) int {
 			return toTimestamp
 		}
 	}
+	// fix shift/reduce conflict between the table alias FULL and FULL [OUTER] JOIN
+	if tok == full && s.lastKeyword2 != as {
+		if tok1 := s.getNextToken(); tok1 == join || tok1 == outer {
+			s.lastKeyword = fullJoin
+			return fullJoin
+		}
+	}
 	// fix shift/reduce conflict with DEFINED NULL BY xxx OPTIONALLY ENCLOSED
 	if tok == optionally {
 		tok1, tok2 := s.getNextTwoTokens()
@@ -163,10 +163,12 @@ var tokenMap = map[string]int{
 	"ANY":                      any,
 	"APPROX_COUNT_DISTINCT":    approxCountDistinct,
 	"APPROX_PERCENTILE":        approxPercentile,
+	"APPROX_TOP_K":             approxTopK,
 	"ARRAY":                    array,
 	"AS":                       as,
 	"ASC":                      asc,
 	"ASCII":                    ascii,
+	"AT":                       at,
 	"ATTRIBUTE":                attribute,
 	"ATTRIBUTES":               attributes,
 	"BATCH":                    batch,
@@ -184,6 +186,7 @@ var tokenMap = map[string]int{
 	"BACKEND":                  backend,
 	"BACKUP":                   backup,
 	"BACKUPS":                  backups,
+	"BEFORE":                   before,
 	"BEGIN":                    begin,
 	"BETWEEN":                  between,
 	"BERNOULLI":                bernoulli,
@@ -249,6 +252,8 @@ var tokenMap = map[string]int{
 	"COMMIT":                   commit,
 	"COMMITTED":                committed,
 	"COMPACT":                  compact,
+	"COMPLETE":                 complete,
+	"COMPLETION":               completion,
 	"COMPRESSED":               compressed,
 	"COMPRESSION":              compression,
 	"CONCURRENCY":              concurrency,
@@ -256,6 +261,7 @@ var tokenMap = map[string]int{
 	"CONNECTION":               connection,
 	"CONSISTENCY":              consistency,
 	"CONSISTENT":               consistent,
+	"CONTAINS":                 contains,
 	"CONSTRAINT":               constraint,
 	"CONSTRAINTS":              constraints,
 	"CONTEXT":                  context,
@@ -267,6 +273,7 @@ var tokenMap = map[string]int{
 	"CPU":                      cpu,
 	"CREATE":                   create,
 	"CROSS":                    cross,
+	"CUBE":                     cube,
 	"CSV_BACKSLASH_ESCAPE":     csvBackslashEscape,
 	"CSV_DELIMITER":            csvDelimiter,
 	"CSV_HEADER":               csvHeader,
@@ -311,6 +318,7 @@ var tokenMap = map[string]int{
 	"DEPTH":                    depth,
 	"DESC":                     desc,
 	"DESCRIBE":                 describe,
+	"DETERMINISTIC":            deterministic,
 	"DIGEST":                   digest,
 	"DIRECTORY":                directory,
 	"DISABLE":                  disable,
@@ -330,14 +338,17 @@ var tokenMap = map[string]int{
 	"DUAL":                     dual,
 	"DUMP":                     dump,
 	"DUPLICATE":                duplicate,
+	"EACH":                     each,
 	"DURATION":                 timeDuration,
 	"DYNAMIC":                  dynamic,
 	"ELSE":                     elseKwd,
 	"ELSEIF":                   elseIfKwd,
+	"EMPTY":                    emptyKwd,
 	"ENABLE":                   enable,
 	"ENABLED":                  enabled,
 	"ENCLOSED":                 enclosed,
 	"ENCRYPTION":               encryption,
+	"ENDS":                     ends,
 	"END":                      end,
 	"END_TIME":                 endTime,
 	"ENFORCED":                 enforced,
@@ -350,6 +361,8 @@ var tokenMap = map[string]int{
 	"ESCAPED":                  escaped,
 	"EVENT":                    event,
 	"EVENTS":                   events,
+	"EVERY":                    every,
+	"EXCLUDE":                  exclude,
 	"EVOLVE":                   evolve,
 	"EXACT":                    exact,
 	"EXEC_ELAPSED":             execElapsed,
@@ -366,6 +379,7 @@ var tokenMap = map[string]int{
 	"EXTENDED":                 extended,
 	"EXTRACT":                  extract,
 	"FALSE":                    falseKwd,
+	"FAST":                     fast,
 	"FAULTS":                   faultsSym,
 	"FETCH":                    fetch,
 	"FIELDS":                   fields,
@@ -392,12 +406,15 @@ var tokenMap = map[string]int{
 	"GC_TTL":                   gcTTL,
 	"GENERAL":                  general,
 	"GENERATED":                generated,
+	"GEOMETRY":                 geometryType,
+	"GEOMETRYCOLLECTION":       geomCollectionType,
 	"GET_FORMAT":               getFormat,
 	"GLOBAL":                   global,
 	"GRANT":                    grant,
 	"GRANTS":                   grants,
 	"GROUP_CONCAT":             groupConcat,
 	"GROUP":                    group,
+	"GROUPING":                 grouping,
 	"HASH":                     hash,
 	"HANDLER":                  handler,
 	"HAVING":                   having,
@@ -405,6 +422,8 @@ var tokenMap = map[string]int{
 	"HIGH_PRIORITY":            highPriority,
 	"HISTORY":                  history,
 	"HISTOGRAM":                histogram,
+	"HLL_MERGE":                hllMerge,
+	"HLL_SKETCH":               hllSketch,
 	"HOSTS":                    hosts,
 	"HOUR_MICROSECOND":         hourMicrosecond,
 	"HOUR_MINUTE":              hourMinute,
@@ -458,6 +477,7 @@ var tokenMap = map[string]int{
 	"JOB":                      job,
 	"JOBS":                     jobs,
 	"JOIN":                     join,
+	"JSON_TABLE":               jsonTable,
 	"JSON_ARRAYAGG":            jsonArrayagg,
 	"JSON_OBJECTAGG":           jsonObjectAgg,
 	"JSON":                     jsonType,
@@ -470,6 +490,7 @@ var tokenMap = map[string]int{
 	"LAST_BACKUP":              lastBackup,
 	"LAST":                     last,
 	"LASTVAL":                  lastval,
+	"LATERAL":                  lateral,
 	"LEADER":                   leader,
 	"LEADER_CONSTRAINTS":       leaderConstraints,
 	"LEADING":                  leading,
@@ -484,6 +505,7 @@ var tokenMap = map[string]int{
 	"LIMIT":                    limit,
 	"LINEAR":                   linear,
 	"LINES":                    lines,
+	"LINESTRING":               lineStringType,
 	"LIST":                     list,
 	"LOAD":                     load,
 	"LOCAL":                    local,
@@ -492,12 +514,14 @@ var tokenMap = map[string]int{
 	"LOCATION":                 location,
 	"LOCK":                     lock,
 	"LOCKED":                   locked,
+	"LOOP":                     loop,
 	"LOGS":                     logs,
 	"LONG":                     long,
 	"LONGBLOB":                 longblobType,
 	"LONGTEXT":                 longtextType,
 	"LOW_PRIORITY":             lowPriority,
 	"MASTER":                   master,
+	"MATERIALIZED":             materialized,
 	"MATCH":                    match,
 	"MAX_CONNECTIONS_PER_HOUR": maxConnectionsPerHour,
 	"MAX_IDXNUM":               max_idxnum,
@@ -525,12 +549,17 @@ var tokenMap = map[string]int{
 	"MINVALUE":                 minValue,
 	"MOD":                      mod,
 	"MODE":                     mode,
+	"MODIFIES":                 modifies,
 	"MODIFY":                   modify,
 	"MONTH":                    month,
+	"MULTILINESTRING":          multiLineStringType,
+	"MULTIPOINT":               multiPointType,
+	"MULTIPOLYGON":             multiPolygonType,
 	"NAMES":                    names,
 	"NATIONAL":                 national,
 	"NATURAL":                  natural,
 	"NCHAR":                    ncharType,
+	"NESTED":                   nested,
 	"NEVER":                    never,
 	"NEXT_ROW_ID":              next_row_id,
 	"NEXT":                     next,
@@ -569,6 +598,8 @@ var tokenMap = map[string]int{
 	"OPTIMIZE":                 optimize,
 	"OPTION":                   option,
 	"OPTIONAL":                 optional,
+	"OTHERS":                   others,
+	"ORDINALITY":               ordinality,
 	"OPTIONALLY":               optionally,
 	"OR":                       or,
 	"ORDER":                    order,
@@ -579,6 +610,7 @@ var tokenMap = map[string]int{
 	"PAGE":                     pageSym,
 	"PARSER":                   parser,
 	"PARTIAL":                  partial,
+	"PATH":                     pathKwd,
 	"PARTITION":                partition,
 	"PARTITIONING":             partitioning,
 	"PARTITIONS":               partitions,
@@ -594,6 +626,7 @@ var tokenMap = map[string]int{
 	"PLUGINS":                  plugins,
 	"POINT":                    point,
 	"POLICY":                   policy,
+	"POLYGON":                  polygonType,
 	"POSITION":                 position,
 	"PRE_SPLIT_REGIONS":        preSplitRegions,
 	"PRECEDING":                preceding,
@@ -606,6 +639,7 @@ var tokenMap = map[string]int{
 	"PRIVILEGES":               privileges,
 	"PROCEDURE":                procedure,
 	"PROCESS":                  process,
+	"PROCESSED_KEYS":           processedKeys,
 	"PROCESSLIST":              processlist,
 	"PROFILE":                  profile,
 	"PROFILES":                 profiles,
@@ -620,12 +654,14 @@ var tokenMap = map[string]int{
 	"RANGE":                    rangeKwd,
 	"RATE_LIMIT":               rateLimit,
 	"READ":                     read,
+	"READS":                    reads,
 	"REAL":                     realType,
 	"REBUILD":                  rebuild,
 	"RECENT":                   recent,
 	"RECOVER":                  recover,
 	"RECURSIVE":                recursive,
 	"REDUNDANT":                redundant,
+	"REFRESH":                  refresh,
 	"REFERENCES":               references,
 	"REGEXP":                   regexpKwd,
 	"REGION":                   region,
@@ -666,6 +702,7 @@ var tokenMap = map[string]int{
 	"ROW":                      row,
 	"ROWS":                     rows,
 	"RTREE":                    rtree,
+	"RU":                       ru,
 	"HYPO":                     hypo,
 	"RESUME":                   resume,
 	"RUN":                      run,
@@ -691,6 +728,7 @@ var tokenMap = map[string]int{
 	"SERIAL":                   serial,
 	"SERIALIZABLE":             serializable,
 	"SESSION":                  session,
+	"SETS":                     sets,
 	"SESSION_STATES":           sessionStates,
 	"SET":                      set,
 	"SETVAL":                   setval,
@@ -733,6 +771,7 @@ var tokenMap = map[string]int{
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
 	"START":                    start,
+	"STARTS":                   starts,
 	"START_TIME":               startTime,
 	"START_TS":                 startTS,
 	"STARTING":                 starting,
@@ -772,6 +811,7 @@ var tokenMap = map[string]int{
 	"SURVIVAL_PREFERENCES":     survivalPreferences,
 	"SWAPS":                    swaps,
 	"SWITCHES":                 switchesSym,
+	"SWITCH_GROUP":             switchGroup,
 	"SYSTEM":                   system,
 	"SYSTEM_TIME":              systemTime,
 	"TARGET":                   target,
@@ -788,6 +828,7 @@ var tokenMap = map[string]int{
 	"TERMINATED":               terminated,
 	"TEXT":                     textType,
 	"THAN":                     than,
+	"TIES":                     ties,
 	"THEN":                     then,
 	"TIDB":                     tidb,
 	"TIDB_CURRENT_TSO":         tidbCurrentTSO,
@@ -827,8 +868,12 @@ var tokenMap = map[string]int{
 	"TRUNCATE":                 truncate,
 	"TRUE_CARD_COST":           trueCardCost,
 	"TTL":                      ttl,
+	"TTL_ARCHIVE_TO":           ttlArchiveTo,
+	"TTL_DELETE_RATE":          ttlDeleteRate,
 	"TTL_ENABLE":               ttlEnable,
+	"TTL_FILTER":               ttlFilter,
 	"TTL_JOB_INTERVAL":         ttlJobInterval,
+	"TTL_JOB_WINDOW":           ttlJobWindow,
 	"TYPE":                     tp,
 	"UNBOUNDED":                unbounded,
 	"UNCOMMITTED":              uncommitted,
@@ -902,12 +947,15 @@ var btFuncTokenMap = map[string]int{
 	"COUNT":                 builtinCount,
 	"APPROX_COUNT_DISTINCT": builtinApproxCountDistinct,
 	"APPROX_PERCENTILE":     builtinApproxPercentile,
+	"APPROX_TOP_K":          builtinApproxTopK,
 	"CURDATE":               builtinCurDate,
 	"CURTIME":               builtinCurTime,
 	"DATE_ADD":              builtinDateAdd,
 	"DATE_SUB":              builtinDateSub,
 	"EXTRACT":               builtinExtract,
 	"GROUP_CONCAT":          builtinGroupConcat,
+	"HLL_MERGE":             builtinHLLMerge,
+	"HLL_SKETCH":            builtinHLLSketch,
 	"MAX":                   builtinMax,
 	"MID":                   builtinSubstring,
 	"MIN":                   builtinMin,
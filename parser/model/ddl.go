@@ -104,6 +104,10 @@ const (
 	ActionDropResourceGroup             ActionType = 70
 	ActionAlterTablePartitioning        ActionType = 71
 	ActionRemovePartitioning            ActionType = 72
+	ActionCreateTrigger                 ActionType = 73
+	ActionDropTrigger                   ActionType = 74
+	ActionCreateMaterializedView        ActionType = 75
+	ActionDropMaterializedView          ActionType = 76
 )
 
 var actionMap = map[ActionType]string{
@@ -174,6 +178,10 @@ var actionMap = map[ActionType]string{
 	ActionDropResourceGroup:             "drop resource group",
 	ActionAlterTablePartitioning:        "alter table partition by",
 	ActionRemovePartitioning:            "alter table remove partitioning",
+	ActionCreateTrigger:                 "create trigger",
+	ActionDropTrigger:                   "drop trigger",
+	ActionCreateMaterializedView:        "create materialized view",
+	ActionDropMaterializedView:          "drop materialized view",
 
 	// `ActionAlterTableAlterPartition` is removed and will never be used.
 	// Just left a tombstone here for compatibility.
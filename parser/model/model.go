@@ -537,6 +537,21 @@ type TableInfo struct {
 	ExchangePartitionInfo *ExchangePartitionInfo `json:"exchange_partition_info"`
 
 	TTLInfo *TTLInfo `json:"ttl_info"`
+
+	// Triggers are the row-level triggers of the table, in the order they are fired.
+	Triggers []*TriggerInfo `json:"triggers,omitempty"`
+
+	// MaterializedView is set if the table stores the result of a materialized view.
+	MaterializedView *MaterializedViewInfo `json:"materialized_view,omitempty"`
+	// MViewLogTableIDs are the log tables of the materialized views refreshed fast on this table,
+	// the changes of this table are written to them.
+	MViewLogTableIDs []int64 `json:"mview_log_table_ids,omitempty"`
+	// MViewLogOf is the ID of the materialized view if the table is its log table.
+	MViewLogOf int64 `json:"mview_log_of,omitempty"`
+
+	// Engine is the lower-case name of the custom storage engine provided by an extension.
+	// It is empty for the tables stored in TiKV.
+	Engine string `json:"engine,omitempty"`
 }
 
 // SepAutoInc decides whether _rowid and auto_increment id use separate allocator.
@@ -748,6 +763,18 @@ func (t *TableInfo) Clone() *TableInfo {
 	if t.TTLInfo != nil {
 		nt.TTLInfo = t.TTLInfo.Clone()
 	}
+	if t.Triggers != nil {
+		nt.Triggers = make([]*TriggerInfo, len(t.Triggers))
+		for i := range t.Triggers {
+			nt.Triggers[i] = t.Triggers[i].Clone()
+		}
+	}
+	if t.MaterializedView != nil {
+		nt.MaterializedView = t.MaterializedView.Clone()
+	}
+	if t.MViewLogTableIDs != nil {
+		nt.MViewLogTableIDs = append([]int64(nil), t.MViewLogTableIDs...)
+	}
 
 	return &nt
 }
@@ -1099,6 +1126,119 @@ type ViewInfo struct {
 	Cols        []CIStr            `json:"view_cols"`
 }
 
+// TriggerTiming is the action time of a trigger.
+type TriggerTiming int
+
+//revive:disable:exported
+const (
+	TriggerTimingBefore TriggerTiming = iota
+	TriggerTimingAfter
+)
+
+//revive:enable:exported
+
+// String implements fmt.Stringer interface.
+func (t TriggerTiming) String() string {
+	if t == TriggerTimingAfter {
+		return "AFTER"
+	}
+	return "BEFORE"
+}
+
+// TriggerEvent is the kind of the operation that activates a trigger.
+type TriggerEvent int
+
+//revive:disable:exported
+const (
+	TriggerEventInsert TriggerEvent = iota
+	TriggerEventUpdate
+	TriggerEventDelete
+)
+
+//revive:enable:exported
+
+// String implements fmt.Stringer interface.
+func (e TriggerEvent) String() string {
+	switch e {
+	case TriggerEventUpdate:
+		return "UPDATE"
+	case TriggerEventDelete:
+		return "DELETE"
+	default:
+		return "INSERT"
+	}
+}
+
+// TriggerInfo provides meta data describing a row-level trigger of a table.
+type TriggerInfo struct {
+	Name   CIStr         `json:"name"`
+	Timing TriggerTiming `json:"timing"`
+	Event  TriggerEvent  `json:"event"`
+	// Definition is the trigger body.
+	Definition string             `json:"definition"`
+	Definer    *auth.UserIdentity `json:"definer"`
+	// SQLMode is the SQL mode when the trigger is created, the trigger body is parsed and executed with it.
+	SQLMode   string    `json:"sql_mode"`
+	Charset   string    `json:"charset"`
+	Collate   string    `json:"collate"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Clone clones TriggerInfo.
+func (t *TriggerInfo) Clone() *TriggerInfo {
+	nt := *t
+	if t.Definer != nil {
+		definer := *t.Definer
+		nt.Definer = &definer
+	}
+	return &nt
+}
+
+// MViewRefreshMethod is the refresh method of a materialized view.
+type MViewRefreshMethod byte
+
+//revive:disable:exported
+const (
+	// MViewRefreshDefault means the method isn't specified, it's only used by REFRESH MATERIALIZED VIEW.
+	MViewRefreshDefault MViewRefreshMethod = iota
+	MViewRefreshComplete
+	MViewRefreshFast
+)
+
+//revive:enable:exported
+
+// String implements fmt.Stringer interface.
+func (m MViewRefreshMethod) String() string {
+	switch m {
+	case MViewRefreshComplete:
+		return "COMPLETE"
+	case MViewRefreshFast:
+		return "FAST"
+	}
+	return "DEFAULT"
+}
+
+// MaterializedViewInfo provides meta data describing a materialized view.
+// A COMPLETE refresh replaces the rows of the table with the result of the query. A FAST refresh applies
+// the changes of the base table recorded in the log table to the aggregated rows.
+type MaterializedViewInfo struct {
+	// SelectStmt is the query of the view, the table names in it are qualified with their schemas.
+	SelectStmt string             `json:"select"`
+	Refresh    MViewRefreshMethod `json:"refresh"`
+	// RefreshInterval is the interval of the scheduled refresh in the format of the timer framework,
+	// such as "1h". It's empty if the view is only refreshed manually.
+	RefreshInterval string `json:"refresh_interval,omitempty"`
+	// BaseTableID and LogTableID are only set for the views refreshed fast.
+	BaseTableID int64 `json:"base_table_id,omitempty"`
+	LogTableID  int64 `json:"log_table_id,omitempty"`
+}
+
+// Clone clones MaterializedViewInfo.
+func (m *MaterializedViewInfo) Clone() *MaterializedViewInfo {
+	nm := *m
+	return &nm
+}
+
 const (
 	DefaultSequenceCacheBool          = true
 	DefaultSequenceCycleBool          = false
@@ -1454,6 +1594,7 @@ type IndexInfo struct {
 	Invisible     bool           `json:"is_invisible"` // Whether the index is invisible.
 	Global        bool           `json:"is_global"`    // Whether the index is global.
 	MVIndex       bool           `json:"mv_index"`     // Whether the index is multivalued index.
+	Spatial       bool           `json:"is_spatial"`   // Whether the index is spatial index.
 }
 
 // Clone clones IndexInfo.
@@ -1806,11 +1947,30 @@ type TTLInfo struct {
 	// JobInterval is the interval between two TTL scan jobs.
 	// It's suggested to get a duration with `(*TTLInfo).GetJobInterval`
 	JobInterval string `json:"job_interval"`
+	// FilterExprStr is an optional predicate. Only the expired rows matching it will be removed.
+	FilterExprStr string `json:"filter_expr,omitempty"`
+	// ArchiveTo is the table to which the expired rows are moved before they are deleted.
+	ArchiveTo *TTLArchiveTable `json:"archive_to,omitempty"`
+	// JobWindow is the daily time window in which the TTL jobs of this table can run, e.g. "22:00 +0800-06:00 +0800".
+	// It's suggested to get the window with `(*TTLInfo).GetJobWindow`
+	JobWindow string `json:"job_window,omitempty"`
+	// DeleteRate is the max count of delete statements per second for this table on each TiDB node. 0 means no limit.
+	DeleteRate uint64 `json:"delete_rate,omitempty"`
+}
+
+// TTLArchiveTable is the table storing the rows removed by TTL
+type TTLArchiveTable struct {
+	Schema CIStr `json:"schema"`
+	Table  CIStr `json:"table"`
 }
 
 // Clone clones TTLInfo
 func (t *TTLInfo) Clone() *TTLInfo {
 	cloned := *t
+	if t.ArchiveTo != nil {
+		archiveTo := *t.ArchiveTo
+		cloned.ArchiveTo = &archiveTo
+	}
 	return &cloned
 }
 
@@ -1827,6 +1987,45 @@ func (t *TTLInfo) GetJobInterval() (time.Duration, error) {
 	return duration.ParseDuration(t.JobInterval)
 }
 
+// GetJobWindow parses the job window of the table. `ok` is false if the table doesn't have its own job window, then
+// only the global window defined by `tidb_ttl_job_schedule_window_start_time` and
+// `tidb_ttl_job_schedule_window_end_time` takes effect.
+func (t *TTLInfo) GetJobWindow() (start, end time.Time, ok bool, err error) {
+	if len(t.JobWindow) == 0 {
+		return start, end, false, nil
+	}
+
+	start, end, err = ParseTTLJobWindow(t.JobWindow)
+	return start, end, err == nil, err
+}
+
+// ParseTTLJobWindow parses a time window like "22:00 +0800-06:00 +0800". The time zone of the start or the end time
+// can be omitted, and UTC will be used then.
+func ParseTTLJobWindow(window string) (start, end time.Time, err error) {
+	for i := 0; i < len(window); i++ {
+		if window[i] != '-' {
+			continue
+		}
+
+		var startErr, endErr error
+		start, startErr = parseTTLJobWindowTime(window[:i])
+		end, endErr = parseTTLJobWindowTime(window[i+1:])
+		if startErr == nil && endErr == nil {
+			return start, end, nil
+		}
+	}
+	return start, end, errors.Errorf("'%s' should be like '22:00 +0800-06:00 +0800'", window)
+}
+
+func parseTTLJobWindowTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	t, err := time.ParseInLocation("15:04 -0700", s, time.UTC)
+	if err != nil {
+		t, err = time.ParseInLocation("15:04", s, time.UTC)
+	}
+	return t, err
+}
+
 func writeSettingItemToBuilder(sb *strings.Builder, item string, separatorFns ...func()) {
 	if sb.Len() != 0 {
 		for _, fn := range separatorFns {
@@ -1913,6 +2112,7 @@ const (
 	RunawayActionDryRun
 	RunawayActionCooldown
 	RunawayActionKill
+	RunawayActionSwitchGroup
 )
 
 // RunawayWatchType is the type of runaway watch.
@@ -1924,6 +2124,7 @@ const (
 	WatchExact
 	WatchSimilar
 	WatchPlan
+	WatchRegexp
 )
 
 func (t RunawayWatchType) String() string {
@@ -1934,6 +2135,8 @@ func (t RunawayWatchType) String() string {
 		return "SIMILAR"
 	case WatchPlan:
 		return "PLAN"
+	case WatchRegexp:
+		return "REGEXP"
 	default:
 		return "NONE"
 	}
@@ -1957,6 +2160,8 @@ func (t RunawayActionType) String() string {
 		return "COOLDOWN"
 	case RunawayActionKill:
 		return "KILL"
+	case RunawayActionSwitchGroup:
+		return "SWITCH_GROUP"
 	default:
 		return "DRYRUN"
 	}
@@ -1971,9 +2176,15 @@ type ResourceGroupRefInfo struct {
 // ResourceGroupRunawaySettings is the runaway settings of the resource group
 type ResourceGroupRunawaySettings struct {
 	ExecElapsedTimeMs uint64            `json:"exec_elapsed_time_ms"`
+	ProcessedKeys     uint64            `json:"processed_keys"`
+	RequestUnit       uint64            `json:"request_unit"`
+	MemoryBytes       uint64            `json:"memory_bytes"`
 	Action            RunawayActionType `json:"action"`
-	WatchType         RunawayWatchType  `json:"watch_type"`
-	WatchDurationMs   int64             `json:"watch_duration_ms"`
+	// SwitchGroupName is the resource group which the runaway queries are switched to if the action is
+	// RunawayActionSwitchGroup.
+	SwitchGroupName string           `json:"switch_group_name"`
+	WatchType       RunawayWatchType `json:"watch_type"`
+	WatchDurationMs int64            `json:"watch_duration_ms"`
 }
 
 type ResourceGroupBackgroundSettings struct {
@@ -2048,17 +2259,33 @@ func (p *ResourceGroupSettings) String() string {
 		writeSettingItemToBuilder(sb, "BURSTABLE", separatorFn)
 	}
 	if p.Runaway != nil {
-		writeSettingDurationToBuilder(sb, "QUERY_LIMIT=(EXEC_ELAPSED", time.Duration(p.Runaway.ExecElapsedTimeMs)*time.Millisecond, separatorFn)
-		writeSettingItemToBuilder(sb, "ACTION="+p.Runaway.Action.String())
+		runaway := new(strings.Builder)
+		if p.Runaway.ExecElapsedTimeMs > 0 {
+			writeSettingDurationToBuilder(runaway, "EXEC_ELAPSED", time.Duration(p.Runaway.ExecElapsedTimeMs)*time.Millisecond)
+		}
+		if p.Runaway.ProcessedKeys > 0 {
+			writeSettingIntegerToBuilder(runaway, "PROCESSED_KEYS", p.Runaway.ProcessedKeys)
+		}
+		if p.Runaway.RequestUnit > 0 {
+			writeSettingIntegerToBuilder(runaway, "RU", p.Runaway.RequestUnit)
+		}
+		if p.Runaway.MemoryBytes > 0 {
+			writeSettingIntegerToBuilder(runaway, "MEMORY", p.Runaway.MemoryBytes)
+		}
+		if p.Runaway.Action == RunawayActionSwitchGroup {
+			writeSettingItemToBuilder(runaway, "ACTION="+p.Runaway.Action.String()+"("+p.Runaway.SwitchGroupName+")")
+		} else {
+			writeSettingItemToBuilder(runaway, "ACTION="+p.Runaway.Action.String())
+		}
 		if p.Runaway.WatchType != WatchNone {
-			writeSettingItemToBuilder(sb, "WATCH="+p.Runaway.WatchType.String())
+			writeSettingItemToBuilder(runaway, "WATCH="+p.Runaway.WatchType.String())
 			if p.Runaway.WatchDurationMs > 0 {
-				writeSettingDurationToBuilder(sb, "DURATION", time.Duration(p.Runaway.WatchDurationMs)*time.Millisecond)
+				writeSettingDurationToBuilder(runaway, "DURATION", time.Duration(p.Runaway.WatchDurationMs)*time.Millisecond)
 			} else {
-				writeSettingItemToBuilder(sb, "DURATION=UNLIMITED")
+				writeSettingItemToBuilder(runaway, "DURATION=UNLIMITED")
 			}
 		}
-		sb.WriteString(")")
+		writeSettingItemToBuilder(sb, "QUERY_LIMIT=("+runaway.String()+")", separatorFn)
 	}
 	if p.Background != nil {
 		fmt.Fprintf(sb, ", BACKGROUND=(TASK_TYPES='%s')", strings.Join(p.Background.JobTypes, ","))
@@ -818,3 +818,27 @@ func TestTTLJobInterval(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, time.Hour*200, interval)
 }
+
+func TestTTLJobWindow(t *testing.T) {
+	ttlInfo := &TTLInfo{}
+	_, _, ok, err := ttlInfo.GetJobWindow()
+	require.NoError(t, err)
+	require.False(t, ok)
+
+	ttlInfo = &TTLInfo{JobWindow: "22:00 +0800-06:30 +0800"}
+	start, end, ok, err := ttlInfo.GetJobWindow()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "14:00", start.UTC().Format("15:04"))
+	require.Equal(t, "22:30", end.UTC().Format("15:04"))
+
+	start, end, err = ParseTTLJobWindow("01:00 -0700 - 2:15")
+	require.NoError(t, err)
+	require.Equal(t, "08:00", start.UTC().Format("15:04"))
+	require.Equal(t, "02:15", end.UTC().Format("15:04"))
+
+	for _, window := range []string{"", "22:00", "22:00-", "-06:00", "25:00-06:00", "22:00 +0800 06:00 +0800", "22:00~06:00"} {
+		_, _, err = ParseTTLJobWindow(window)
+		require.Error(t, err, window)
+	}
+}
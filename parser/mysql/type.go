@@ -47,6 +47,18 @@ const (
 	TypeGeometry   byte = 0xff
 )
 
+// Geometry subtypes of TypeGeometry, the values are the same as the geometry type codes of WKB.
+const (
+	GeometryTypeGeometry           byte = 0
+	GeometryTypePoint              byte = 1
+	GeometryTypeLineString         byte = 2
+	GeometryTypePolygon            byte = 3
+	GeometryTypeMultiPoint         byte = 4
+	GeometryTypeMultiLineString    byte = 5
+	GeometryTypeMultiPolygon       byte = 6
+	GeometryTypeGeometryCollection byte = 7
+)
+
 // Flag information.
 const (
 	NotNullFlag        uint = 1 << 0  /* Field can't be NULL */
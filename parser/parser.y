@@ -57,6 +57,7 @@ import (
 	toTimestamp          "TO TIMESTAMP"
 	memberof             "MEMBER OF"
 	optionallyEnclosedBy "OPTIONALLY ENCLOSED BY"
+	fullJoin             "FULL JOIN"
 
 	/*yy:token "_%c"    */
 	underscoreCS "UNDERSCORE_CHARSET"
@@ -104,6 +105,7 @@ import (
 	convert           "CONVERT"
 	create            "CREATE"
 	cross             "CROSS"
+	cube              "CUBE"
 	cumeDist          "CUME_DIST"
 	currentDate       "CURRENT_DATE"
 	currentTime       "CURRENT_TIME"
@@ -150,6 +152,7 @@ import (
 	generated         "GENERATED"
 	grant             "GRANT"
 	group             "GROUP"
+	grouping          "GROUPING"
 	groups            "GROUPS"
 	having            "HAVING"
 	highPriority      "HIGH_PRIORITY"
@@ -178,11 +181,13 @@ import (
 	int8Type          "INT8"
 	iterate           "ITERATE"
 	join              "JOIN"
+	jsonTable         "JSON_TABLE"
 	key               "KEY"
 	keys              "KEYS"
 	kill              "KILL"
 	lag               "LAG"
 	lastValue         "LAST_VALUE"
+	lateral           "LATERAL"
 	lead              "LEAD"
 	leading           "LEADING"
 	leave             "LEAVE"
@@ -196,6 +201,7 @@ import (
 	localTime         "LOCALTIME"
 	localTs           "LOCALTIMESTAMP"
 	lock              "LOCK"
+	loop              "LOOP"
 	longblobType      "LONGBLOB"
 	longtextType      "LONGTEXT"
 	lowPriority       "LOW_PRIORITY"
@@ -318,6 +324,7 @@ import (
 	always                "ALWAYS"
 	any                   "ANY"
 	ascii                 "ASCII"
+	at                    "AT"
 	attribute             "ATTRIBUTE"
 	attributes            "ATTRIBUTES"
 	statsOptions          "STATS_OPTIONS"
@@ -333,6 +340,7 @@ import (
 	backend               "BACKEND"
 	backup                "BACKUP"
 	backups               "BACKUPS"
+	before                "BEFORE"
 	begin                 "BEGIN"
 	bernoulli             "BERNOULLI"
 	binding               "BINDING"
@@ -367,12 +375,15 @@ import (
 	commit                "COMMIT"
 	committed             "COMMITTED"
 	compact               "COMPACT"
+	complete              "COMPLETE"
+	completion            "COMPLETION"
 	compressed            "COMPRESSED"
 	compression           "COMPRESSION"
 	concurrency           "CONCURRENCY"
 	connection            "CONNECTION"
 	consistency           "CONSISTENCY"
 	consistent            "CONSISTENT"
+	contains              "CONTAINS"
 	context               "CONTEXT"
 	cpu                   "CPU"
 	csvBackslashEscape    "CSV_BACKSLASH_ESCAPE"
@@ -395,6 +406,7 @@ import (
 	declare               "DECLARE"
 	definer               "DEFINER"
 	delayKeyWrite         "DELAY_KEY_WRITE"
+	deterministic         "DETERMINISTIC"
 	digest                "DIGEST"
 	directory             "DIRECTORY"
 	disable               "DISABLE"
@@ -404,9 +416,12 @@ import (
 	do                    "DO"
 	duplicate             "DUPLICATE"
 	dynamic               "DYNAMIC"
+	each                  "EACH"
+	emptyKwd              "EMPTY"
 	enable                "ENABLE"
 	enabled               "ENABLED"
 	encryption            "ENCRYPTION"
+	ends                  "ENDS"
 	end                   "END"
 	enforced              "ENFORCED"
 	engine                "ENGINE"
@@ -416,13 +431,16 @@ import (
 	escape                "ESCAPE"
 	event                 "EVENT"
 	events                "EVENTS"
+	every                 "EVERY"
 	evolve                "EVOLVE"
 	exchange              "EXCHANGE"
+	exclude               "EXCLUDE"
 	exclusive             "EXCLUSIVE"
 	execute               "EXECUTE"
 	expansion             "EXPANSION"
 	expire                "EXPIRE"
 	extended              "EXTENDED"
+	fast                  "FAST"
 	faultsSym             "FAULTS"
 	fields                "FIELDS"
 	file                  "FILE"
@@ -435,6 +453,8 @@ import (
 	full                  "FULL"
 	function              "FUNCTION"
 	general               "GENERAL"
+	geomCollectionType    "GEOMETRYCOLLECTION"
+	geometryType          "GEOMETRY"
 	global                "GLOBAL"
 	grants                "GRANTS"
 	handler               "HANDLER"
@@ -466,6 +486,7 @@ import (
 	last                  "LAST"
 	lastBackup            "LAST_BACKUP"
 	lastval               "LASTVAL"
+	lineStringType        "LINESTRING"
 	less                  "LESS"
 	level                 "LEVEL"
 	list                  "LIST"
@@ -474,6 +495,7 @@ import (
 	location              "LOCATION"
 	logs                  "LOGS"
 	master                "MASTER"
+	materialized          "MATERIALIZED"
 	max_idxnum            "MAX_IDXNUM"
 	max_minutes           "MAX_MINUTES"
 	maxConnectionsPerHour "MAX_CONNECTIONS_PER_HOUR"
@@ -490,11 +512,16 @@ import (
 	minute                "MINUTE"
 	minValue              "MINVALUE"
 	mode                  "MODE"
+	modifies              "MODIFIES"
 	modify                "MODIFY"
 	month                 "MONTH"
+	multiLineStringType   "MULTILINESTRING"
+	multiPointType        "MULTIPOINT"
+	multiPolygonType      "MULTIPOLYGON"
 	names                 "NAMES"
 	national              "NATIONAL"
 	ncharType             "NCHAR"
+	nested                "NESTED"
 	never                 "NEVER"
 	next                  "NEXT"
 	nextval               "NEXTVAL"
@@ -519,10 +546,13 @@ import (
 	only                  "ONLY"
 	open                  "OPEN"
 	optional              "OPTIONAL"
+	others                "OTHERS"
+	ordinality            "ORDINALITY"
 	packKeys              "PACK_KEYS"
 	pageSym               "PAGE"
 	parser                "PARSER"
 	partial               "PARTIAL"
+	pathKwd               "PATH"
 	partitioning          "PARTITIONING"
 	partitions            "PARTITIONS"
 	password              "PASSWORD"
@@ -534,6 +564,7 @@ import (
 	plugins               "PLUGINS"
 	point                 "POINT"
 	policy                "POLICY"
+	polygonType           "POLYGON"
 	preSplitRegions       "PRE_SPLIT_REGIONS"
 	preceding             "PRECEDING"
 	prepare               "PREPARE"
@@ -550,9 +581,11 @@ import (
 	query                 "QUERY"
 	quick                 "QUICK"
 	rateLimit             "RATE_LIMIT"
+	reads                 "READS"
 	rebuild               "REBUILD"
 	recover               "RECOVER"
 	redundant             "REDUNDANT"
+	refresh               "REFRESH"
 	reload                "RELOAD"
 	remove                "REMOVE"
 	reorganize            "REORGANIZE"
@@ -591,6 +624,7 @@ import (
 	serial                "SERIAL"
 	serializable          "SERIALIZABLE"
 	session               "SESSION"
+	sets                  "SETS"
 	setval                "SETVAL"
 	shardRowIDBits        "SHARD_ROW_ID_BITS"
 	share                 "SHARE"
@@ -617,6 +651,7 @@ import (
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
 	start                 "START"
+	starts                "STARTS"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsPersistent       "STATS_PERSISTENT"
 	statsSamplePages      "STATS_SAMPLE_PAGES"
@@ -638,6 +673,7 @@ import (
 	temptable             "TEMPTABLE"
 	textType              "TEXT"
 	than                  "THAN"
+	ties                  "TIES"
 	tikvImporter          "TIKV_IMPORTER"
 	timestampType         "TIMESTAMP"
 	timeType              "TIME"
@@ -650,8 +686,12 @@ import (
 	triggers              "TRIGGERS"
 	truncate              "TRUNCATE"
 	ttl                   "TTL"
+	ttlArchiveTo          "TTL_ARCHIVE_TO"
+	ttlDeleteRate         "TTL_DELETE_RATE"
 	ttlEnable             "TTL_ENABLE"
+	ttlFilter             "TTL_FILTER"
 	ttlJobInterval        "TTL_JOB_INTERVAL"
+	ttlJobWindow          "TTL_JOB_WINDOW"
 	unbounded             "UNBOUNDED"
 	uncommitted           "UNCOMMITTED"
 	undefined             "UNDEFINED"
@@ -678,6 +718,7 @@ import (
 	addDate               "ADDDATE"
 	approxCountDistinct   "APPROX_COUNT_DISTINCT"
 	approxPercentile      "APPROX_PERCENTILE"
+	approxTopK            "APPROX_TOP_K"
 	bitAnd                "BIT_AND"
 	bitOr                 "BIT_OR"
 	bitXor                "BIT_XOR"
@@ -708,6 +749,8 @@ import (
 	getFormat             "GET_FORMAT"
 	gcTTL                 "GC_TTL"
 	groupConcat           "GROUP_CONCAT"
+	hllMerge              "HLL_MERGE"
+	hllSketch             "HLL_SKETCH"
 	next_row_id           "NEXT_ROW_ID"
 	inplace               "INPLACE"
 	instant               "INSTANT"
@@ -784,8 +827,11 @@ import (
 	ioReadBandwidth       "IO_READ_BANDWIDTH"
 	ioWriteBandwidth      "IO_WRITE_BANDWIDTH"
 	execElapsed           "EXEC_ELAPSED"
+	processedKeys         "PROCESSED_KEYS"
+	ru                    "RU"
 	dryRun                "DRYRUN"
 	cooldown              "COOLDOWN"
+	switchGroup           "SWITCH_GROUP"
 	watch                 "WATCH"
 	similar               "SIMILAR"
 	queryLimit            "QUERY_LIMIT"
@@ -844,12 +890,15 @@ import (
 	builtinCount
 	builtinApproxCountDistinct
 	builtinApproxPercentile
+	builtinApproxTopK
 	builtinCurDate
 	builtinCurTime
 	builtinDateAdd
 	builtinDateSub
 	builtinExtract
 	builtinGroupConcat
+	builtinHLLMerge
+	builtinHLLSketch
 	builtinMax
 	builtinMin
 	builtinNow
@@ -954,6 +1003,11 @@ import (
 	CreateBindingStmt          "CREATE BINDING statement"
 	CreatePolicyStmt           "CREATE PLACEMENT POLICY statement"
 	CreateProcedureStmt        "CREATE PROCEDURE statement"
+	CreateTriggerStmt          "CREATE TRIGGER statement"
+	CreateEventStmt            "CREATE EVENT statement"
+	AlterEventStmt             "ALTER EVENT statement"
+	DropEventStmt              "DROP EVENT statement"
+	CreateMaterializedViewStmt "CREATE MATERIALIZED VIEW statement"
 	AddQueryWatchStmt          "ADD QUERY WATCH statement"
 	CreateResourceGroupStmt    "CREATE RESOURCE GROUP statement"
 	CreateSequenceStmt         "CREATE SEQUENCE statement"
@@ -966,6 +1020,9 @@ import (
 	DropResourceGroupStmt      "DROP RESOURCE GROUP statement"
 	DropStatisticsStmt         "DROP STATISTICS statement"
 	DropStatsStmt              "DROP STATS statement"
+	DropTriggerStmt            "DROP TRIGGER statement"
+	DropMaterializedViewStmt   "DROP MATERIALIZED VIEW statement"
+	RefreshMaterializedViewStmt "REFRESH MATERIALIZED VIEW statement"
 	DropTableStmt              "DROP TABLE statement"
 	DropSequenceStmt           "DROP SEQUENCE statement"
 	DropUserStmt               "DROP USER"
@@ -1155,6 +1212,8 @@ import (
 	GlobalScope                            "The scope of variable"
 	StatementScope                         "The scope of statement"
 	GroupByClause                          "GROUP BY clause"
+	GroupingSet                            "Grouping set in GROUPING SETS clause"
+	GroupingSetList                        "Grouping set list in GROUPING SETS clause"
 	HavingClause                           "HAVING clause"
 	AsOfClause                             "AS OF clause"
 	AsOfClauseOpt                          "AS OF clause optional"
@@ -1186,6 +1245,11 @@ import (
 	IntervalExpr                           "Interval expression"
 	JoinTable                              "join table"
 	JoinType                               "join type"
+	JSONTableColumn                        "JSON_TABLE column definition"
+	JSONTableColumnList                    "JSON_TABLE column definition list"
+	JSONTableColumnsClause                 "JSON_TABLE COLUMNS clause"
+	JSONTableOnEmptyOnErrorOpt             "JSON_TABLE ON EMPTY and ON ERROR clauses"
+	JSONTableOnResponse                    "JSON_TABLE ON EMPTY or ON ERROR response"
 	KillOrKillTiDB                         "Kill or Kill TiDB"
 	LocationLabelList                      "location label name list"
 	LikeTableWithOrWithoutParen            "LIKE table_name or ( LIKE table_name )"
@@ -1284,6 +1348,8 @@ import (
 	SelectStmtFromTable                    "SELECT statement from table"
 	SelectStmtGroup                        "SELECT statement optional GROUP BY clause"
 	SelectStmtIntoOption                   "SELECT statement into clause"
+	SelectIntoVarList                      "SELECT INTO variable list"
+	SelectIntoVar                          "SELECT INTO user variable or procedure local variable"
 	SequenceOption                         "Create sequence option"
 	SequenceOptionList                     "Create sequence option list"
 	SetRoleOpt                             "Set role options"
@@ -1344,6 +1410,21 @@ import (
 	TransactionChar                        "Transaction characteristic"
 	TransactionChars                       "Transaction characteristic list"
 	TrimDirection                          "Trim string direction"
+	TriggerEvent                           "Trigger event"
+	TriggerTiming                          "Trigger action time"
+	EventSchedule                          "Event schedule"
+	EventStartsOpt                         "Optional event start time"
+	EventEndsOpt                           "Optional event end time"
+	EventCompletion                        "Event completion"
+	EventCompletionOpt                     "Optional event completion"
+	EventStatusOpt                         "Optional event status"
+	EventCommentOpt                        "Optional event comment"
+	AlterEventScheduleOpt                  "Optional event schedule and completion of ALTER EVENT"
+	AlterEventRenameOpt                    "Optional new name of ALTER EVENT"
+	AlterEventBodyOpt                      "Optional event body of ALTER EVENT"
+	MViewRefreshMethod                     "Materialized view refresh method"
+	MViewRefreshMethodOpt                  "Optional materialized view refresh method"
+	MViewRefreshInterval                   "Materialized view refresh interval"
 	SetOprOpt                              "Union/Except/Intersect Option(empty/ALL/DISTINCT)"
 	Username                               "Username"
 	UsernameList                           "UsernameList"
@@ -1388,6 +1469,7 @@ import (
 	OptWild                                "Optional Wildcard"
 	OptWindowOrderByClause                 "Optional ORDER BY clause in WINDOW"
 	OptWindowFrameClause                   "Optional FRAME clause in WINDOW"
+	OptWindowFrameExclusion                "Optional EXCLUDE clause in WINDOW frame"
 	OptWindowingClause                     "Optional OVER clause"
 	WindowingClause                        "OVER clause"
 	WindowClauseOptional                   "Optional WINDOW clause"
@@ -1419,6 +1501,8 @@ import (
 	BlobType                               "Blob types"
 	TextType                               "Text types"
 	DateAndTimeType                        "Date and Time types"
+	SpatialType                            "Spatial types"
+	GeometryTypeName                       "Geometry type name"
 	OptFieldLen                            "Field length or empty"
 	FieldLen                               "Field length"
 	FieldOpts                              "Field type definition option list"
@@ -1483,6 +1567,8 @@ import (
 	SpPdparams                             "Procedure params"
 	SpPdparam                              "Procedure param"
 	ProcedureOptDefault                    "Optional procedure variable default value"
+	ProcedureCharacteristicList            "Procedure characteristic list"
+	ProcedureCharacteristic                "Procedure characteristic"
 	ProcedureProcStmts                     "Procedure statement list"
 	ProcedureProcStmt1s                    "One more procedure statement"
 	ProcedureDecl                          "Procedure variable statement"
@@ -1575,6 +1661,7 @@ import (
 	ProcedurceLabelOpt              "Optional Procedure label name"
 
 %precedence empty
+%precedence into
 %precedence as
 %precedence placement
 %precedence lowerThanSelectOpt
@@ -1616,7 +1703,7 @@ import (
 %right '('
 %left ')'
 %precedence higherThanParenthese
-%left join straightJoin inner cross left right full natural
+%left join straightJoin inner cross left right full fullJoin natural
 %precedence lowerThanOn
 %precedence on using
 %right assignmentEq
@@ -1808,10 +1895,30 @@ DirectResourceGroupRunawayOption:
 		}
 		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayRule, StrValue: $3}
 	}
+|	"PROCESSED_KEYS" EqOpt LengthNum
+	{
+		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayRuleProcessedKeys, UintValue: $3.(uint64)}
+	}
+|	"RU" EqOpt LengthNum
+	{
+		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayRuleRU, UintValue: $3.(uint64)}
+	}
+|	"MEMORY" EqOpt LengthNum
+	{
+		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayRuleMemory, UintValue: $3.(uint64)}
+	}
+|	"MEMORY" EqOpt stringLit
+	{
+		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayRuleMemory, StrValue: $3}
+	}
 |	"ACTION" EqOpt ResourceGroupRunawayActionOption
 	{
 		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayAction, IntValue: $3.(int32)}
 	}
+|	"ACTION" EqOpt "SWITCH_GROUP" '(' ResourceGroupName ')'
+	{
+		$$ = &ast.ResourceGroupRunawayOption{Tp: ast.RunawayAction, IntValue: int32(model.RunawayActionSwitchGroup), StrValue: $5}
+	}
 |	"WATCH" EqOpt ResourceGroupRunawayWatchOption WatchDurationOption
 	{
 		dur := strings.ToLower($4.(string))
@@ -3712,6 +3819,19 @@ ConstraintElem:
 		}
 		$$ = c
 	}
+|	"SPATIAL" KeyOrIndexOpt IndexName '(' IndexPartSpecificationList ')' IndexOptionList
+	{
+		c := &ast.Constraint{
+			Tp:           ast.ConstraintSpatial,
+			Keys:         $5.([]*ast.IndexPartSpecification),
+			Name:         $3.(*ast.NullString).String,
+			IsEmptyIndex: $3.(*ast.NullString).Empty,
+		}
+		if $7 != nil {
+			c.Option = $7.(*ast.IndexOption)
+		}
+		$$ = c
+	}
 |	KeyOrIndex IfNotExists IndexNameAndTypeOpt '(' IndexPartSpecificationList ')' IndexOptionList
 	{
 		c := &ast.Constraint{
@@ -6284,6 +6404,47 @@ GroupByClause:
 	{
 		$$ = &ast.GroupByClause{Items: $3.([]*ast.ByItem), Rollup: $4.(bool)}
 	}
+|	"GROUP" "BY" "ROLLUP" '(' ByList ')'
+	{
+		$$ = &ast.GroupByClause{Items: $5.([]*ast.ByItem), Rollup: true}
+	}
+|	"GROUP" "BY" "CUBE" '(' ByList ')'
+	{
+		$$ = &ast.GroupByClause{Items: $5.([]*ast.ByItem), Cube: true}
+	}
+|	"GROUP" "BY" "GROUPING" "SETS" '(' GroupingSetList ')'
+	{
+		gby := &ast.GroupByClause{}
+		for _, set := range $6.([][]*ast.ByItem) {
+			offsets := make([]int, 0, len(set))
+			for _, item := range set {
+				offsets = append(offsets, len(gby.Items))
+				gby.Items = append(gby.Items, item)
+			}
+			gby.GroupingSets = append(gby.GroupingSets, offsets)
+		}
+		$$ = gby
+	}
+
+GroupingSetList:
+	GroupingSet
+	{
+		$$ = [][]*ast.ByItem{$1.([]*ast.ByItem)}
+	}
+|	GroupingSetList ',' GroupingSet
+	{
+		$$ = append($1.([][]*ast.ByItem), $3.([]*ast.ByItem))
+	}
+
+GroupingSet:
+	'(' ')'
+	{
+		$$ = []*ast.ByItem{}
+	}
+|	'(' ByList ')'
+	{
+		$$ = $2
+	}
 
 HavingClause:
 	{
@@ -6520,6 +6681,7 @@ UnReservedKeyword:
 |	"AFTER"
 |	"ALWAYS"
 |	"AVG"
+|	"BEFORE"
 |	"BEGIN"
 |	"BIT"
 |	"BOOL"
@@ -6549,6 +6711,13 @@ UnReservedKeyword:
 |	"DO"
 |	"DUPLICATE"
 |	"DYNAMIC"
+|	"EACH"
+|	"EVERY"
+|	"EXCLUDE"
+|	"FAST"
+|	"COMPLETE"
+|	"MATERIALIZED"
+|	"REFRESH"
 |	"ENCRYPTION"
 |	"END"
 |	"ENFORCED"
@@ -6597,6 +6766,7 @@ UnReservedKeyword:
 |	"ROLLBACK"
 |	"ROLLUP"
 |	"SESSION"
+|	"SETS"
 |	"SIGNED"
 |	"SHARD_ROW_ID_BITS"
 |	"SHUTDOWN"
@@ -6605,12 +6775,21 @@ UnReservedKeyword:
 |	"STATUS"
 |	"OPEN"
 |	"POINT"
+|	"GEOMETRY"
+|	"LINESTRING"
+|	"POLYGON"
+|	"MULTIPOINT"
+|	"MULTILINESTRING"
+|	"MULTIPOLYGON"
+|	"GEOMETRYCOLLECTION"
 |	"SUBPARTITIONS"
 |	"SUBPARTITION"
 |	"TABLES"
 |	"TABLESPACE"
 |	"TEXT"
 |	"THAN"
+|	"TIES"
+|	"OTHERS"
 |	"TIME" %prec lowerThanStringLitToken
 |	"TIMESTAMP" %prec lowerThanStringLitToken
 |	"TRACE"
@@ -6713,6 +6892,10 @@ UnReservedKeyword:
 |	"ALGORITHM"
 |	"DEFINER"
 |	"INVOKER"
+|	"CONTAINS"
+|	"DETERMINISTIC"
+|	"MODIFIES"
+|	"READS"
 |	"MERGE"
 |	"TEMPTABLE"
 |	"UNDEFINED"
@@ -6856,6 +7039,14 @@ UnReservedKeyword:
 |	"TTL"
 |	"TTL_ENABLE"
 |	"TTL_JOB_INTERVAL"
+|	"TTL_FILTER"
+|	"TTL_ARCHIVE_TO"
+|	"TTL_JOB_WINDOW"
+|	"TTL_DELETE_RATE"
+|	"AT"
+|	"STARTS"
+|	"ENDS"
+|	"COMPLETION"
 |	"FAILED_LOGIN_ATTEMPTS"
 |	"PASSWORD_LOCK_TIME"
 |	"DIGEST"
@@ -6869,6 +7060,10 @@ UnReservedKeyword:
 |	"OLTP_READ_WRITE"
 |	"OLTP_READ_ONLY"
 |	"OLTP_WRITE_ONLY"
+|	"EMPTY"
+|	"NESTED"
+|	"ORDINALITY"
+|	"PATH"
 
 TiDBKeyword:
 	"ADMIN"
@@ -6920,6 +7115,7 @@ NotKeywordToken:
 	"ADDDATE"
 |	"APPROX_COUNT_DISTINCT"
 |	"APPROX_PERCENTILE"
+|	"APPROX_TOP_K"
 |	"BIT_AND"
 |	"BIT_OR"
 |	"BIT_XOR"
@@ -6938,6 +7134,8 @@ NotKeywordToken:
 |	"END_TIME"
 |	"GET_FORMAT"
 |	"GROUP_CONCAT"
+|	"HLL_MERGE"
+|	"HLL_SKETCH"
 |	"INPLACE"
 |	"INSTANT"
 |	"INTERNAL"
@@ -7025,8 +7223,11 @@ NotKeywordToken:
 |	"RESTORED_TS"
 |	"FULL_BACKUP_STORAGE"
 |	"EXEC_ELAPSED"
+|	"PROCESSED_KEYS"
+|	"RU"
 |	"DRYRUN"
 |	"COOLDOWN"
+|	"SWITCH_GROUP"
 |	"WATCH"
 |	"SIMILAR"
 |	"QUERY_LIMIT"
@@ -7809,6 +8010,7 @@ FunctionNameConflict:
 |	"DATE"
 |	"DATABASE"
 |	"DAY"
+|	"GROUPING"
 |	"HOUR"
 |	"IF"
 |	"INTERVAL"
@@ -8172,6 +8374,18 @@ SumExpr:
 	{
 		$$ = &ast.AggregateFuncExpr{F: $1, Args: $3.([]ast.ExprNode)}
 	}
+|	builtinApproxTopK '(' Expression ',' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3, $5}}
+	}
+|	builtinHLLSketch '(' ExpressionList ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: $3.([]ast.ExprNode)}
+	}
+|	builtinHLLMerge '(' Expression ')'
+	{
+		$$ = &ast.AggregateFuncExpr{F: $1, Args: []ast.ExprNode{$3}}
+	}
 |	builtinBitAnd '(' Expression ')' OptWindowingClause
 	{
 		if $5 != nil {
@@ -8752,6 +8966,10 @@ TableName:
 	{
 		$$ = &ast.TableName{Schema: model.NewCIStr($1), Name: model.NewCIStr($3)}
 	}
+|	'*' '.' Identifier
+	{
+		$$ = &ast.TableName{Schema: model.NewCIStr("*"), Name: model.NewCIStr($3)}
+	}
 
 TableNameList:
 	TableName
@@ -8944,7 +9162,7 @@ HelpStmt:
 	}
 
 SelectStmtBasic:
-	"SELECT" SelectStmtOpts SelectStmtFieldList HavingClause
+	"SELECT" SelectStmtOpts SelectStmtFieldList SelectStmtIntoOption HavingClause
 	{
 		st := &ast.SelectStmt{
 			SelectStmtOpts: $2.(*ast.SelectStmtOpts),
@@ -8956,7 +9174,15 @@ SelectStmtBasic:
 			st.TableHints = st.SelectStmtOpts.TableHints
 		}
 		if $4 != nil {
-			st.Having = $4.(*ast.HavingClause)
+			st.SelectIntoOpt = $4.(*ast.SelectIntoOption)
+			lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
+			if lastField.Expr != nil && lastField.AsName.O == "" {
+				lastEnd := parser.endOffset(&yyS[yypt-1])
+				lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:lastEnd])
+			}
+		}
+		if $5 != nil {
+			st.Having = $5.(*ast.HavingClause)
 		}
 		$$ = st
 	}
@@ -8966,7 +9192,7 @@ SelectStmtFromDualTable:
 	{
 		st := $1.(*ast.SelectStmt)
 		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
-		if lastField.Expr != nil && lastField.AsName.O == "" {
+		if lastField.Expr != nil && lastField.AsName.O == "" && st.SelectIntoOpt == nil {
 			lastEnd := yyS[yypt-1].offset - 1
 			lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:lastEnd])
 		}
@@ -8981,7 +9207,7 @@ SelectStmtFromTable:
 		st := $1.(*ast.SelectStmt)
 		st.From = $3.(*ast.TableRefsClause)
 		lastField := st.Fields.Fields[len(st.Fields.Fields)-1]
-		if lastField.Expr != nil && lastField.AsName.O == "" {
+		if lastField.Expr != nil && lastField.AsName.O == "" && st.SelectIntoOpt == nil {
 			lastEnd := parser.endOffset(&yyS[yypt-5])
 			lastField.SetText(parser.lexer.client, parser.src[lastField.Offset:lastEnd])
 		}
@@ -9092,6 +9318,10 @@ SelectStmt:
 			st.Limit = $5.(*ast.Limit)
 		}
 		if $7 != nil {
+			if st.SelectIntoOpt != nil {
+				yylex.AppendError(yylex.Errorf("Multiple INTO clauses in one query block"))
+				return 1
+			}
 			st.SelectIntoOpt = $7.(*ast.SelectIntoOption)
 		}
 		$$ = st
@@ -9112,6 +9342,10 @@ SelectStmt:
 			st.LockInfo = $5.(*ast.SelectLockInfo)
 		}
 		if $6 != nil {
+			if st.SelectIntoOpt != nil {
+				yylex.AppendError(yylex.Errorf("Multiple INTO clauses in one query block"))
+				return 1
+			}
 			st.SelectIntoOpt = $6.(*ast.SelectIntoOption)
 		}
 		$$ = st
@@ -9129,6 +9363,10 @@ SelectStmt:
 			st.Limit = $3.(*ast.Limit)
 		}
 		if $5 != nil {
+			if st.SelectIntoOpt != nil {
+				yylex.AppendError(yylex.Errorf("Multiple INTO clauses in one query block"))
+				return 1
+			}
 			st.SelectIntoOpt = $5.(*ast.SelectIntoOption)
 		}
 		$$ = st
@@ -9327,14 +9565,36 @@ OptWindowFrameClause:
 	{
 		$$ = nil
 	}
-|	WindowFrameUnits WindowFrameExtent
+|	WindowFrameUnits WindowFrameExtent OptWindowFrameExclusion
 	{
 		$$ = &ast.FrameClause{
-			Type:   $1.(ast.FrameType),
-			Extent: $2.(ast.FrameExtent),
+			Type:    $1.(ast.FrameType),
+			Extent:  $2.(ast.FrameExtent),
+			Exclude: $3.(ast.FrameExclusion),
 		}
 	}
 
+OptWindowFrameExclusion:
+	{
+		$$ = ast.ExcludeNoOthers
+	}
+|	"EXCLUDE" "NO" "OTHERS"
+	{
+		$$ = ast.ExcludeNoOthers
+	}
+|	"EXCLUDE" "CURRENT" "ROW"
+	{
+		$$ = ast.ExcludeCurrentRow
+	}
+|	"EXCLUDE" "GROUP"
+	{
+		$$ = ast.ExcludeGroup
+	}
+|	"EXCLUDE" "TIES"
+	{
+		$$ = ast.ExcludeTies
+	}
+
 WindowFrameUnits:
 	"ROWS"
 	{
@@ -9595,12 +9855,96 @@ TableFactor:
 		resultNode := $1.(*ast.SubqueryExpr).Query
 		$$ = &ast.TableSource{Source: resultNode, AsName: $2.(model.CIStr)}
 	}
+|	"LATERAL" SubSelect TableAsNameOpt
+	{
+		resultNode := $2.(*ast.SubqueryExpr).Query
+		$$ = &ast.TableSource{Source: resultNode, AsName: $3.(model.CIStr), Lateral: true}
+	}
 |	'(' TableRefs ')'
 	{
 		j := $2.(*ast.Join)
 		j.ExplicitParens = true
 		$$ = $2
 	}
+|	"JSON_TABLE" '(' Expression ',' stringLit JSONTableColumnsClause ')' TableAsName
+	{
+		jt := &ast.JSONTable{Expr: $3, Path: $5, Columns: $6.([]*ast.JSONTableColumn)}
+		$$ = &ast.TableSource{Source: jt, AsName: $8.(model.CIStr)}
+	}
+
+JSONTableColumnsClause:
+	"COLUMNS" '(' JSONTableColumnList ')'
+	{
+		$$ = $3
+	}
+
+JSONTableColumnList:
+	JSONTableColumn
+	{
+		$$ = []*ast.JSONTableColumn{$1.(*ast.JSONTableColumn)}
+	}
+|	JSONTableColumnList ',' JSONTableColumn
+	{
+		$$ = append($1.([]*ast.JSONTableColumn), $3.(*ast.JSONTableColumn))
+	}
+
+JSONTableColumn:
+	Identifier "FOR" "ORDINALITY"
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnOrdinality, Name: model.NewCIStr($1)}
+	}
+|	Identifier Type "PATH" stringLit JSONTableOnEmptyOnErrorOpt
+	{
+		col := &ast.JSONTableColumn{Tp: ast.JSONTableColumnPath, Name: model.NewCIStr($1), FieldType: $2.(*types.FieldType), Path: $4}
+		if $5 != nil {
+			responses := $5.([]*ast.JSONTableOnResponse)
+			col.OnEmpty, col.OnError = responses[0], responses[1]
+		}
+		$$ = col
+	}
+|	Identifier Type "EXISTS" "PATH" stringLit
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnExistsPath, Name: model.NewCIStr($1), FieldType: $2.(*types.FieldType), Path: $5}
+	}
+|	"NESTED" "PATH" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $3, NestedColumns: $4.([]*ast.JSONTableColumn)}
+	}
+|	"NESTED" stringLit JSONTableColumnsClause
+	{
+		$$ = &ast.JSONTableColumn{Tp: ast.JSONTableColumnNested, Path: $2, NestedColumns: $3.([]*ast.JSONTableColumn)}
+	}
+
+JSONTableOnEmptyOnErrorOpt:
+	{
+		$$ = nil
+	}
+|	JSONTableOnResponse "ON" "EMPTY"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), nil}
+	}
+|	JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{nil, $1.(*ast.JSONTableOnResponse)}
+	}
+|	JSONTableOnResponse "ON" "EMPTY" JSONTableOnResponse "ON" "ERROR"
+	{
+		$$ = []*ast.JSONTableOnResponse{$1.(*ast.JSONTableOnResponse), $4.(*ast.JSONTableOnResponse)}
+	}
+
+JSONTableOnResponse:
+	"NULL"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseNull}
+	}
+|	"ERROR"
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseError}
+	}
+|	"DEFAULT" stringLit
+	{
+		$$ = &ast.JSONTableOnResponse{Tp: ast.JSONTableOnResponseDefault, Default: $2}
+	}
 
 PartitionNameListOpt:
 	/* empty */
@@ -9759,6 +10103,10 @@ JoinType:
 	{
 		$$ = ast.RightJoin
 	}
+|	fullJoin
+	{
+		$$ = ast.FullJoin
+	}
 
 OuterOpt:
 	{}
@@ -9994,25 +10342,71 @@ SelectStmtGroup:
 |	GroupByClause
 
 SelectStmtIntoOption:
+	%prec empty
 	{
 		$$ = nil
 	}
-|	"INTO" "OUTFILE" stringLit Fields Lines
+|	"INTO" SelectIntoVarList
+	{
+		$$ = &ast.SelectIntoOption{
+			Tp:        ast.SelectIntoVars,
+			Variables: $2.([]ast.ExprNode),
+		}
+	}
+|	"INTO" "OUTFILE" stringLit FormatOpt Fields Lines %prec lowerThanWith
 	{
 		x := &ast.SelectIntoOption{
 			Tp:       ast.SelectIntoOutfile,
 			FileName: $3,
+			Format:   $4.(*string),
 		}
-		if $4 != nil {
-			x.FieldsInfo = $4.(*ast.FieldsClause)
+		if $5 != nil {
+			x.FieldsInfo = $5.(*ast.FieldsClause)
+		}
+		if $6 != nil {
+			x.LinesInfo = $6.(*ast.LinesClause)
+		}
+
+		$$ = x
+	}
+|	"INTO" "OUTFILE" stringLit FormatOpt Fields Lines "WITH" LoadDataOptionList
+	{
+		x := &ast.SelectIntoOption{
+			Tp:       ast.SelectIntoOutfile,
+			FileName: $3,
+			Format:   $4.(*string),
+			Options:  $8.([]*ast.LoadDataOpt),
 		}
 		if $5 != nil {
-			x.LinesInfo = $5.(*ast.LinesClause)
+			x.FieldsInfo = $5.(*ast.FieldsClause)
+		}
+		if $6 != nil {
+			x.LinesInfo = $6.(*ast.LinesClause)
 		}
 
 		$$ = x
 	}
 
+SelectIntoVarList:
+	SelectIntoVar
+	{
+		$$ = []ast.ExprNode{$1.(ast.ExprNode)}
+	}
+|	SelectIntoVarList ',' SelectIntoVar
+	{
+		$$ = append($1.([]ast.ExprNode), $3.(ast.ExprNode))
+	}
+
+SelectIntoVar:
+	Identifier
+	{
+		$$ = &ast.ColumnNameExpr{Name: &ast.ColumnName{Name: model.NewCIStr($1)}}
+	}
+|	UserVariable
+	{
+		$$ = $1
+	}
+
 // See https://dev.mysql.com/doc/refman/5.7/en/subqueries.html
 SubSelect:
 	'(' SelectStmt ')'
@@ -11329,6 +11723,13 @@ ShowStmt:
 			Procedure: $4.(*ast.TableName),
 		}
 	}
+|	"SHOW" "CREATE" "EVENT" TableName
+	{
+		$$ = &ast.ShowStmt{
+			Tp:    ast.ShowCreateEvent,
+			Event: $4.(*ast.TableName),
+		}
+	}
 
 ShowPlacementTarget:
 	DatabaseSym DBName
@@ -11880,6 +12281,7 @@ Statement:
 	EmptyStmt
 |	AdminStmt
 |	AlterDatabaseStmt
+|	AlterEventStmt
 |	AlterTableStmt
 |	AlterUserStmt
 |	AlterInstanceStmt
@@ -11906,6 +12308,9 @@ Statement:
 |	CreateBindingStmt
 |	CreatePolicyStmt
 |	CreateProcedureStmt
+|	CreateTriggerStmt
+|	CreateEventStmt
+|	CreateMaterializedViewStmt
 |	CreateResourceGroupStmt
 |	AddQueryWatchStmt
 |	CreateSequenceStmt
@@ -11924,8 +12329,12 @@ Statement:
 |	DropRoleStmt
 |	DropStatisticsStmt
 |	DropStatsStmt
+|	DropTriggerStmt
+|	DropEventStmt
+|	DropMaterializedViewStmt
 |	DropBindingStmt
 |	FlushStmt
+|	RefreshMaterializedViewStmt
 |	FlashbackTableStmt
 |	FlashbackToTimestampStmt
 |	FlashbackDatabaseStmt
@@ -12336,6 +12745,38 @@ TableOption:
 		}
 		$$ = &ast.TableOption{Tp: ast.TableOptionTTLJobInterval, StrValue: $3}
 	}
+|	"TTL_FILTER" EqOpt stringLit
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLFilter, StrValue: $3}
+	}
+|	"TTL_ARCHIVE_TO" EqOpt TableName
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLArchiveTo, TableNames: []*ast.TableName{$3.(*ast.TableName)}}
+	}
+|	"TTL_ARCHIVE_TO" EqOpt stringLit
+	{
+		// `TTL_ARCHIVE_TO = ''` removes the archive table of a TTL table
+		if $3 != "" {
+			yylex.AppendError(yylex.Errorf("The TTL_ARCHIVE_TO option has to be a table name or ''"))
+			return 1
+		}
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLArchiveTo}
+	}
+|	"TTL_JOB_WINDOW" EqOpt stringLit
+	{
+		// `TTL_JOB_WINDOW = ''` removes the job window of a TTL table
+		if $3 != "" {
+			if _, _, err := model.ParseTTLJobWindow($3); err != nil {
+				yylex.AppendError(yylex.Errorf("The TTL_JOB_WINDOW option is not a valid time window: %s", err.Error()))
+				return 1
+			}
+		}
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLJobWindow, StrValue: $3}
+	}
+|	"TTL_DELETE_RATE" EqOpt LengthNum
+	{
+		$$ = &ast.TableOption{Tp: ast.TableOptionTTLDeleteRate, UintValue: $3.(uint64)}
+	}
 
 ForceOpt:
 	/* empty */
@@ -12451,6 +12892,7 @@ Type:
 	NumericType
 |	StringType
 |	DateAndTimeType
+|	SpatialType
 
 NumericType:
 	IntegerType OptFieldLen FieldOpts
@@ -12974,6 +13416,50 @@ DateAndTimeType:
 		$$ = tp
 	}
 
+SpatialType:
+	GeometryTypeName
+	{
+		tp := types.NewFieldType(mysql.TypeGeometry)
+		tp.SetGeometryType($1.(byte))
+		tp.SetCharset(charset.CharsetBin)
+		tp.SetCollate(charset.CollationBin)
+		$$ = tp
+	}
+
+GeometryTypeName:
+	"GEOMETRY"
+	{
+		$$ = mysql.GeometryTypeGeometry
+	}
+|	"POINT"
+	{
+		$$ = mysql.GeometryTypePoint
+	}
+|	"LINESTRING"
+	{
+		$$ = mysql.GeometryTypeLineString
+	}
+|	"POLYGON"
+	{
+		$$ = mysql.GeometryTypePolygon
+	}
+|	"MULTIPOINT"
+	{
+		$$ = mysql.GeometryTypeMultiPoint
+	}
+|	"MULTILINESTRING"
+	{
+		$$ = mysql.GeometryTypeMultiLineString
+	}
+|	"MULTIPOLYGON"
+	{
+		$$ = mysql.GeometryTypeMultiPolygon
+	}
+|	"GEOMETRYCOLLECTION"
+	{
+		$$ = mysql.GeometryTypeGeometryCollection
+	}
+
 FieldLen:
 	'(' LengthNum ')'
 	{
@@ -14479,6 +14965,10 @@ LoadDataOption:
 	{
 		$$ = &ast.LoadDataOpt{Name: strings.ToLower($1), Value: $3.(ast.ExprNode)}
 	}
+|	"COMPRESSION" "=" SignedLiteral
+	{
+		$$ = &ast.LoadDataOpt{Name: strings.ToLower($1), Value: $3.(ast.ExprNode)}
+	}
 
 ImportIntoStmt:
 	"IMPORT" "INTO" TableName ColumnNameOrUserVarListOptWithBrackets LoadDataSetSpecOpt "FROM" stringLit FormatOpt LoadDataOptionListOpt
@@ -15279,6 +15769,7 @@ ProcedureStatementStmt:
 |	DeleteFromStmt
 |	AnalyzeTableStmt
 |	TruncateTableStmt
+|	CallStmt
 
 ProcedureCursorSelectStmt:
 	SelectStmt
@@ -15669,6 +16160,12 @@ ProcedureUnlabelLoopStmt:
 			Condition: $4.(ast.ExprNode),
 		}
 	}
+|	"LOOP" ProcedureProcStmt1s "END" "LOOP"
+	{
+		$$ = &ast.ProcedureLoopStmt{
+			Body: $2.([]ast.StmtNode),
+		}
+	}
 
 ProcedureLabeledBlock:
 	identifier ':' ProcedureBlockContent ProcedurceLabelOpt
@@ -15748,37 +16245,103 @@ ProcedureProcStmt:
  *	CREATE
  *  [DEFINER = user]
  *  PROCEDURE [IF NOT EXISTS] sp_name ([proc_parameter[,...]])
- *  routine_body
+ *  [characteristic ...] routine_body
  *  proc_parameter:
  *  [ IN | OUT | INOUT ] param_name type
- *  func_parameter:
- *  param_name type
  *  type:
  *  Any valid MySQL data type
+ *  characteristic: {
+ *    COMMENT 'string'
+ *  | LANGUAGE SQL
+ *  | [NOT] DETERMINISTIC
+ *  | { CONTAINS SQL | NO SQL | READS SQL DATA | MODIFIES SQL DATA }
+ *  | SQL SECURITY { DEFINER | INVOKER }
+ *  }
  * routine_body:
  *  Valid SQL routine statement
+ * The OR REPLACE, ALGORITHM and SQL SECURITY clauses before PROCEDURE are only accepted by the grammar
+ * to share the prefix with CREATE VIEW, they are rejected here. The stored functions are not supported,
+ * so there is no CREATE FUNCTION statement.
  ********************************************************************************************/
 CreateProcedureStmt:
-	"CREATE" "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureProcStmt
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "PROCEDURE" IfNotExists TableName '(' OptSpPdparams ')' ProcedureCharacteristicList ProcedureProcStmt
 	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined || $5.(model.ViewSecurity) != model.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
 		x := &ast.ProcedureInfo{
-			IfNotExists:    $3.(bool),
-			ProcedureName:  $4.(*ast.TableName),
-			ProcedureParam: $6.([]*ast.StoreParameter),
-			ProcedureBody:  $8,
+			IfNotExists:     $7.(bool),
+			Definer:         $4.(*auth.UserIdentity),
+			ProcedureName:   $8.(*ast.TableName),
+			ProcedureParam:  $10.([]*ast.StoreParameter),
+			Characteristics: $12.([]*ast.ProcedureCharacteristic),
+			ProcedureBody:   $13,
 		}
 		startOffset := parser.startOffset(&yyS[yypt])
-		originStmt := $8
+		originStmt := $13
 		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
-		startOffset = parser.startOffset(&yyS[yypt-3])
+		startOffset = parser.startOffset(&yyS[yypt-4])
 		if parser.src[startOffset] == '(' {
 			startOffset++
 		}
-		endOffset := parser.startOffset(&yyS[yypt-1])
+		endOffset := parser.startOffset(&yyS[yypt-2])
 		x.ProcedureParamStr = strings.TrimSpace(parser.src[startOffset:endOffset])
 		$$ = x
 	}
 
+ProcedureCharacteristicList:
+	/* empty */
+	{
+		$$ = []*ast.ProcedureCharacteristic(nil)
+	}
+|	ProcedureCharacteristicList ProcedureCharacteristic
+	{
+		$$ = append($1.([]*ast.ProcedureCharacteristic), $2.(*ast.ProcedureCharacteristic))
+	}
+
+ProcedureCharacteristic:
+	"COMMENT" stringLit
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureComment, Comment: $2}
+	}
+|	"LANGUAGE" "SQL"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureLanguageSQL}
+	}
+|	"DETERMINISTIC"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureDeterministic}
+	}
+|	"NOT" "DETERMINISTIC"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureNotDeterministic}
+	}
+|	"CONTAINS" "SQL"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureContainsSQL}
+	}
+|	"NO" "SQL"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureNoSQL}
+	}
+|	"READS" "SQL" "DATA"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureReadsSQLData}
+	}
+|	"MODIFIES" "SQL" "DATA"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureModifiesSQLData}
+	}
+|	"SQL" "SECURITY" "DEFINER"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureSQLSecurityDefiner}
+	}
+|	"SQL" "SECURITY" "INVOKER"
+	{
+		$$ = &ast.ProcedureCharacteristic{Tp: ast.ProcedureSQLSecurityInvoker}
+	}
+
 /********************************************************************************************
 *  DROP PROCEDURE  [IF EXISTS] sp_name
 ********************************************************************************************/
@@ -15791,6 +16354,378 @@ DropProcedureStmt:
 		}
 	}
 
+/********************************************************************************************
+ *
+ *  Create Trigger Statement
+ *
+ *  Example:
+ *	CREATE
+ *  [DEFINER = user]
+ *  TRIGGER [IF NOT EXISTS] trigger_name
+ *  trigger_time trigger_event
+ *  ON tbl_name FOR EACH ROW
+ *  trigger_body
+ *  trigger_time: { BEFORE | AFTER }
+ *  trigger_event: { INSERT | UPDATE | DELETE }
+ * The OR REPLACE, ALGORITHM and SQL SECURITY clauses are only accepted by the grammar to share
+ * the prefix with CREATE VIEW, they are rejected here.
+ ********************************************************************************************/
+CreateTriggerStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "TRIGGER" IfNotExists TableName TriggerTiming TriggerEvent "ON" TableName "FOR" "EACH" "ROW" ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined || $5.(model.ViewSecurity) != model.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		x := &ast.CreateTriggerStmt{
+			IfNotExists: $7.(bool),
+			Definer:     $4.(*auth.UserIdentity),
+			TriggerName: $8.(*ast.TableName),
+			Timing:      $9.(model.TriggerTiming),
+			Event:       $10.(model.TriggerEvent),
+			Table:       $12.(*ast.TableName),
+			Body:        $16,
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		x.Body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+TriggerTiming:
+	"BEFORE"
+	{
+		$$ = model.TriggerTimingBefore
+	}
+|	"AFTER"
+	{
+		$$ = model.TriggerTimingAfter
+	}
+
+TriggerEvent:
+	"INSERT"
+	{
+		$$ = model.TriggerEventInsert
+	}
+|	"UPDATE"
+	{
+		$$ = model.TriggerEventUpdate
+	}
+|	"DELETE"
+	{
+		$$ = model.TriggerEventDelete
+	}
+
+/********************************************************************************************
+*  DROP TRIGGER [IF EXISTS] [schema_name.]trigger_name
+********************************************************************************************/
+DropTriggerStmt:
+	"DROP" "TRIGGER" IfExists TableName
+	{
+		$$ = &ast.DropTriggerStmt{
+			IfExists:    $3.(bool),
+			TriggerName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Event Statement
+ *
+ *  Example:
+ *	CREATE
+ *  [DEFINER = user]
+ *  EVENT [IF NOT EXISTS] event_name
+ *  ON SCHEDULE schedule
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [ENABLE | DISABLE | DISABLE ON SLAVE]
+ *  [COMMENT 'string']
+ *  DO event_body
+ *  schedule: {
+ *    AT timestamp
+ *    | EVERY interval [STARTS timestamp] [ENDS timestamp]
+ *  }
+ * The OR REPLACE, ALGORITHM and SQL SECURITY clauses are only accepted by the grammar to share
+ * the prefix with CREATE VIEW, they are rejected here.
+ ********************************************************************************************/
+CreateEventStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "EVENT" IfNotExists TableName "ON" "SCHEDULE" EventSchedule EventCompletionOpt EventStatusOpt EventCommentOpt "DO" ProcedureProcStmt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined || $5.(model.ViewSecurity) != model.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		x := &ast.CreateEventStmt{
+			IfNotExists:  $7.(bool),
+			Definer:      $4.(*auth.UserIdentity),
+			EventName:    $8.(*ast.TableName),
+			Schedule:     $11.(*ast.EventSchedule),
+			OnCompletion: $12.(ast.EventCompletionType),
+			Status:       $13.(ast.EventStatusType),
+			Body:         $16,
+		}
+		if $14 != nil {
+			comment := $14.(string)
+			x.Comment = &comment
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		x.Body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = x
+	}
+
+EventSchedule:
+	"AT" Expression
+	{
+		$$ = &ast.EventSchedule{At: $2}
+	}
+|	"EVERY" Expression TimeUnit EventStartsOpt EventEndsOpt
+	{
+		x := &ast.EventSchedule{
+			Every: $2,
+			Unit:  $3.(ast.TimeUnitType),
+		}
+		if $4 != nil {
+			x.Starts = $4.(ast.ExprNode)
+		}
+		if $5 != nil {
+			x.Ends = $5.(ast.ExprNode)
+		}
+		$$ = x
+	}
+
+EventStartsOpt:
+	{
+		$$ = nil
+	}
+|	"STARTS" Expression
+	{
+		$$ = $2
+	}
+
+EventEndsOpt:
+	{
+		$$ = nil
+	}
+|	"ENDS" Expression
+	{
+		$$ = $2
+	}
+
+EventCompletion:
+	"ON" "COMPLETION" "PRESERVE"
+	{
+		$$ = ast.EventCompletionPreserve
+	}
+|	"ON" "COMPLETION" "NOT" "PRESERVE"
+	{
+		$$ = ast.EventCompletionNotPreserve
+	}
+
+EventCompletionOpt:
+	{
+		$$ = ast.EventCompletionUnspecified
+	}
+|	EventCompletion
+
+EventStatusOpt:
+	{
+		$$ = ast.EventStatusUnspecified
+	}
+|	"ENABLE"
+	{
+		$$ = ast.EventStatusEnable
+	}
+|	"DISABLE"
+	{
+		$$ = ast.EventStatusDisable
+	}
+|	"DISABLE" "ON" "SLAVE"
+	{
+		$$ = ast.EventStatusDisableOnSlave
+	}
+
+EventCommentOpt:
+	{
+		$$ = nil
+	}
+|	"COMMENT" stringLit
+	{
+		$$ = $2
+	}
+
+/********************************************************************************************
+ *
+ *  Alter Event Statement
+ *
+ *  Example:
+ *	ALTER
+ *  [DEFINER = user]
+ *  EVENT event_name
+ *  [ON SCHEDULE schedule]
+ *  [ON COMPLETION [NOT] PRESERVE]
+ *  [RENAME TO new_event_name]
+ *  [ENABLE | DISABLE | DISABLE ON SLAVE]
+ *  [COMMENT 'string']
+ *  [DO event_body]
+ ********************************************************************************************/
+AlterEventStmt:
+	"ALTER" ViewDefiner "EVENT" TableName AlterEventScheduleOpt AlterEventRenameOpt EventStatusOpt EventCommentOpt AlterEventBodyOpt
+	{
+		x := $5.(*ast.AlterEventStmt)
+		x.Definer = $2.(*auth.UserIdentity)
+		x.EventName = $4.(*ast.TableName)
+		if $6 != nil {
+			x.NewName = $6.(*ast.TableName)
+		}
+		x.Status = $7.(ast.EventStatusType)
+		if $8 != nil {
+			comment := $8.(string)
+			x.Comment = &comment
+		}
+		if $9 != nil {
+			x.Body = $9.(ast.StmtNode)
+		}
+		$$ = x
+	}
+
+AlterEventScheduleOpt:
+	{
+		$$ = &ast.AlterEventStmt{}
+	}
+|	"ON" "SCHEDULE" EventSchedule EventCompletionOpt
+	{
+		$$ = &ast.AlterEventStmt{
+			Schedule:     $3.(*ast.EventSchedule),
+			OnCompletion: $4.(ast.EventCompletionType),
+		}
+	}
+|	EventCompletion
+	{
+		$$ = &ast.AlterEventStmt{OnCompletion: $1.(ast.EventCompletionType)}
+	}
+
+AlterEventRenameOpt:
+	{
+		$$ = nil
+	}
+|	"RENAME" "TO" TableName
+	{
+		$$ = $3
+	}
+
+AlterEventBodyOpt:
+	{
+		$$ = nil
+	}
+|	"DO" ProcedureProcStmt
+	{
+		body := $2
+		startOffset := parser.startOffset(&yyS[yypt])
+		body.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		$$ = body
+	}
+
+/********************************************************************************************
+*  DROP EVENT [IF EXISTS] [schema_name.]event_name
+********************************************************************************************/
+DropEventStmt:
+	"DROP" "EVENT" IfExists TableName
+	{
+		$$ = &ast.DropEventStmt{
+			IfExists:  $3.(bool),
+			EventName: $4.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+ *
+ *  Create Materialized View Statement
+ *
+ *  Example:
+ *	CREATE MATERIALIZED VIEW [IF NOT EXISTS] view_name [(col1, col2)]
+ *  [REFRESH {FAST | COMPLETE} [EVERY interval unit]]
+ *  AS select_statement
+ * The OR REPLACE, ALGORITHM, DEFINER and SQL SECURITY clauses are only accepted by the grammar
+ * to share the prefix with CREATE VIEW, they are rejected here.
+ ********************************************************************************************/
+CreateMaterializedViewStmt:
+	"CREATE" OrReplace ViewAlgorithm ViewDefiner ViewSQLSecurity "MATERIALIZED" "VIEW" IfNotExists ViewName ViewFieldList MViewRefreshInterval "AS" CreateViewSelectOpt
+	{
+		if $2.(bool) || $3.(model.ViewAlgorithm) != model.AlgorithmUndefined || !$4.(*auth.UserIdentity).CurrentUser || $5.(model.ViewSecurity) != model.SecurityDefiner {
+			yylex.AppendError(ErrSyntax)
+			return 1
+		}
+		startOffset := parser.startOffset(&yyS[yypt])
+		selStmt := $13.(ast.StmtNode)
+		selStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:parser.yylval.offset]))
+		x := $11.(*ast.CreateMaterializedViewStmt)
+		x.IfNotExists = $8.(bool)
+		x.ViewName = $9.(*ast.TableName)
+		x.Select = selStmt
+		if $10 != nil {
+			x.Cols = $10.([]model.CIStr)
+		}
+		$$ = x
+	}
+
+MViewRefreshInterval:
+	/* EMPTY */
+	{
+		$$ = &ast.CreateMaterializedViewStmt{Refresh: model.MViewRefreshComplete}
+	}
+|	"REFRESH" MViewRefreshMethod
+	{
+		$$ = &ast.CreateMaterializedViewStmt{Refresh: $2.(model.MViewRefreshMethod)}
+	}
+|	"REFRESH" MViewRefreshMethod "EVERY" LengthNum TimestampUnit
+	{
+		$$ = &ast.CreateMaterializedViewStmt{
+			Refresh:         $2.(model.MViewRefreshMethod),
+			RefreshInterval: $4.(uint64),
+			RefreshUnit:     $5.(ast.TimeUnitType),
+		}
+	}
+
+MViewRefreshMethod:
+	"FAST"
+	{
+		$$ = model.MViewRefreshFast
+	}
+|	"COMPLETE"
+	{
+		$$ = model.MViewRefreshComplete
+	}
+
+MViewRefreshMethodOpt:
+	/* EMPTY */
+	{
+		$$ = model.MViewRefreshDefault
+	}
+|	MViewRefreshMethod
+
+/********************************************************************************************
+*  DROP MATERIALIZED VIEW [IF EXISTS] view_name
+********************************************************************************************/
+DropMaterializedViewStmt:
+	"DROP" "MATERIALIZED" "VIEW" IfExists TableName
+	{
+		$$ = &ast.DropMaterializedViewStmt{
+			IfExists: $4.(bool),
+			ViewName: $5.(*ast.TableName),
+		}
+	}
+
+/********************************************************************************************
+*  REFRESH MATERIALIZED VIEW view_name [FAST | COMPLETE]
+********************************************************************************************/
+RefreshMaterializedViewStmt:
+	"REFRESH" "MATERIALIZED" "VIEW" TableName MViewRefreshMethodOpt
+	{
+		$$ = &ast.RefreshMaterializedViewStmt{
+			ViewName: $4.(*ast.TableName),
+			Method:   $5.(model.MViewRefreshMethod),
+		}
+	}
+
 /********************************************************************
  *
  * Calibrate Resource Statement
@@ -15934,6 +16869,10 @@ QueryWatchOption:
 	{
 		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchAction, IntValue: $3.(int32)}
 	}
+|	"ACTION" EqOpt "SWITCH_GROUP" '(' ResourceGroupName ')'
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchAction, IntValue: int32(model.RunawayActionSwitchGroup), StrValue: model.NewCIStr($5)}
+	}
 |	QueryWatchTextOption
 	{
 		$$ = $1.(*ast.QueryWatchOption)
@@ -15952,6 +16891,10 @@ QueryWatchTextOption:
 	{
 		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, IntValue: $3.(int32), ExprValue: $5, BoolValue: true}
 	}
+|	"SQL" "TEXT" "REGEXP" "TO" SimpleExpr
+	{
+		$$ = &ast.QueryWatchOption{Tp: ast.QueryWatchType, IntValue: int32(model.WatchRegexp), ExprValue: $5, BoolValue: true}
+	}
 
 DropQueryWatchStmt:
 	"QUERY" "WATCH" "REMOVE" NUM
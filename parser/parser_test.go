@@ -47,7 +47,7 @@ func TestSimple(t *testing.T) {
 		"fulltext", "grant", "group", "having", "hour_microsecond", "hour_minute",
 		"hour_second", "if", "ignore", "in", "index", "infile", "inner", "insert", "int", "into", "integer",
 		"interval", "is", "join", "key", "keys", "kill", "leading", "left", "like", "ilike", "limit", "lines", "load",
-		"localtime", "localtimestamp", "lock", "longblob", "longtext", "mediumblob", "maxvalue", "mediumint", "mediumtext",
+		"localtime", "localtimestamp", "lock", "loop", "longblob", "longtext", "mediumblob", "maxvalue", "mediumint", "mediumtext",
 		"minute_microsecond", "minute_second", "mod", "not", "no_write_to_binlog", "null", "numeric",
 		"on", "option", "optionally", "or", "order", "outer", "partition", "precision", "primary", "procedure", "range", "read", "real", "recursive",
 		"references", "regexp", "rename", "repeat", "replace", "revoke", "restrict", "right", "rlike",
@@ -723,6 +723,13 @@ func TestDMLStmt(t *testing.T) {
 		{"select a, b from t into outfile '/tmp/result.txt'", true, "SELECT `a`,`b` FROM `t` INTO OUTFILE '/tmp/result.txt'"},
 		{"select a from t order by a into outfile '/tmp/abc'", true, "SELECT `a` FROM `t` ORDER BY `a` INTO OUTFILE '/tmp/abc'"},
 		{"select 1 into outfile '/tmp/1.csv'", true, "SELECT 1 INTO OUTFILE '/tmp/1.csv'"},
+		{"select a into @a from t", true, "SELECT `a` FROM `t` INTO @`a`"},
+		{"select a, b into @a, c from t where id = 1 limit 1", true, "SELECT `a`,`b` FROM `t` WHERE `id`=1 LIMIT 1 INTO @`a`,`c`"},
+		{"select a, b from t where id = 1 into @a, @b", true, "SELECT `a`,`b` FROM `t` WHERE `id`=1 INTO @`a`,@`b`"},
+		{"select 1 into @a", true, "SELECT 1 INTO @`a`"},
+		{"select 1 into outfile '/tmp/1.csv' from t", true, "SELECT 1 FROM `t` INTO OUTFILE '/tmp/1.csv'"},
+		{"select a into @a from t into @b", false, ""},
+		{"select a into 1 from t", false, ""},
 		{"select 1 for update into outfile '/tmp/1.csv'", true, "SELECT 1 FOR UPDATE INTO OUTFILE '/tmp/1.csv'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ','", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ','"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"'"},
@@ -732,6 +739,10 @@ func TestDMLStmt(t *testing.T) {
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' optionally enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' OPTIONALLY ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
 		{"select a,b,a+b from t into outfile '/tmp/result.txt' fields terminated BY ',' enclosed BY '\"' lines starting by 'xy' terminated BY '\r'", true, "SELECT `a`,`b`,`a`+`b` FROM `t` INTO OUTFILE '/tmp/result.txt' FIELDS TERMINATED BY ',' ENCLOSED BY '\"' LINES STARTING BY 'xy' TERMINATED BY '\r'"},
+		{"select a from t into outfile 's3://bucket/prefix/result' format 'parquet'", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/prefix/result' FORMAT 'parquet'"},
+		{"select a from t into outfile 's3://bucket/result.csv' format 'csv' fields terminated by ',' with compression='gzip', max_file_size='256MiB'", true, "SELECT `a` FROM `t` INTO OUTFILE 's3://bucket/result.csv' FORMAT 'csv' FIELDS TERMINATED BY ',' WITH compression=_UTF8MB4'gzip', max_file_size=_UTF8MB4'256MiB'"},
+		{"select a into outfile '/tmp/result.csv' lines terminated by '\n' with compression='zstd' from t where a > 1", true, "SELECT `a` FROM `t` WHERE `a`>1 INTO OUTFILE '/tmp/result.csv' LINES TERMINATED BY '\n' WITH compression=_UTF8MB4'zstd'"},
+		{"select a from t into outfile '/tmp/result.csv' with", false, ""},
 
 		// from join
 		{"SELECT * from t1, t2, t3", true, "SELECT * FROM ((`t1`) JOIN `t2`) JOIN `t3`"},
@@ -747,6 +758,14 @@ func TestDMLStmt(t *testing.T) {
 		{"select * from t1 natural inner join t2", false, ""},
 		{"select * from t1 natural cross join t2", false, ""},
 		{"select * from t3 join t1 join t2 on t1.a=t2.a on t3.b=t2.b", true, "SELECT * FROM `t3` JOIN (`t1` JOIN `t2` ON `t1`.`a`=`t2`.`a`) ON `t3`.`b`=`t2`.`b`"},
+		{"select * from t1 full join t2 on t1.id = t2.id", true, "SELECT * FROM `t1` FULL JOIN `t2` ON `t1`.`id`=`t2`.`id`"},
+		{"select * from t1 full outer join t2 using (id)", true, "SELECT * FROM `t1` FULL JOIN `t2` USING (`id`)"},
+		{"select * from t1 join t2 full join t3 on t2.id = t3.id", true, "SELECT * FROM (`t1` JOIN `t2`) FULL JOIN `t3` ON `t2`.`id`=`t3`.`id`"},
+		{"select * from t1 join (t2 full join t3 on t2.id = t3.id) left join t4 on t1.id = t4.id", true, "SELECT * FROM (`t1` JOIN (`t2` FULL JOIN `t3` ON `t2`.`id`=`t3`.`id`)) LEFT JOIN `t4` ON `t1`.`id`=`t4`.`id`"},
+		{"select * from t1 full join t2", false, ""},
+		{"select * from t1 full, t2 as full", true, "SELECT * FROM (`t1` AS `full`) JOIN `t2` AS `full`"},
+		{"select * from t1 as full join t2", true, "SELECT * FROM `t1` AS `full` JOIN `t2`"},
+		{"select full.id from t1 full", true, "SELECT `full`.`id` FROM `t1` AS `full`"},
 
 		// for straight_join
 		{"select * from t1 straight_join t2 on t1.id = t2.id", true, "SELECT * FROM `t1` STRAIGHT_JOIN `t2` ON `t1`.`id`=`t2`.`id`"},
@@ -1131,6 +1150,11 @@ AAAAAAAAAAAA5gm5Mg==
 		{"query watch add SQL TEXT SIMILAR to 'select 1' resource group rg1", true, "QUERY WATCH ADD SQL TEXT SIMILAR TO _UTF8MB4'select 1' RESOURCE GROUP `rg1`"},
 		{"query watch add ACTION = KILL SQL TEXT SIMILAR to 'select 1'", true, "QUERY WATCH ADD ACTION = KILL SQL TEXT SIMILAR TO _UTF8MB4'select 1'"},
 		{"query watch add ACTION COOLDOWN resource group rg1 SQL TEXT SIMILAR to 'select 1'", true, "QUERY WATCH ADD ACTION = COOLDOWN RESOURCE GROUP `rg1` SQL TEXT SIMILAR TO _UTF8MB4'select 1'"},
+		{"query watch add SQL TEXT REGEXP to 'select .* from t where a = [0-9]+'", true, "QUERY WATCH ADD SQL TEXT REGEXP TO _UTF8MB4'select .* from t where a = [0-9]+'"},
+		{"query watch add ACTION SWITCH_GROUP(rg2) SQL TEXT SIMILAR to 'select 1'", true, "QUERY WATCH ADD ACTION = SWITCH_GROUP(`rg2`) SQL TEXT SIMILAR TO _UTF8MB4'select 1'"},
+		{"query watch add resource group rg1 ACTION = SWITCH_GROUP(`rg2`) SQL TEXT REGEXP to @pattern", true, "QUERY WATCH ADD RESOURCE GROUP `rg1` ACTION = SWITCH_GROUP(`rg2`) SQL TEXT REGEXP TO @`pattern`"},
+		{"query watch add ACTION SWITCH_GROUP SQL TEXT SIMILAR to 'select 1'", false, ""},
+		{"query watch add ACTION SWITCH_GROUP(rg2) ACTION KILL SQL TEXT SIMILAR to 'select 1'", false, ""},
 		{"query watch add resource group `default` resource group `rg1` SQL TEXT SIMILAR to 'select 1'", false, ""},
 		{"query watch add SQL SIMILAR to 'select 1'", false, ""},
 		{"query watch add SQL TEXT SIMILAR 'select 1'", false, ""},
@@ -2166,6 +2190,13 @@ func TestBuiltin(t *testing.T) {
 		{`select approx_percentile(c1) from t;`, true, "SELECT APPROX_PERCENTILE(`c1`) FROM `t`"},
 		{`select approx_percentile(c1, c2) from t;`, true, "SELECT APPROX_PERCENTILE(`c1`, `c2`) FROM `t`"},
 		{`select approx_percentile(c1, 123) from t;`, true, "SELECT APPROX_PERCENTILE(`c1`, 123) FROM `t`"},
+		{`select approx_top_k(c1, 10) from t;`, true, "SELECT APPROX_TOP_K(`c1`, 10) FROM `t`"},
+		{`select approx_top_k(c1) from t;`, false, ""},
+		{`select hll_sketch(c1) from t;`, true, "SELECT HLL_SKETCH(`c1`) FROM `t`"},
+		{`select hll_sketch(c1, c2) from t;`, true, "SELECT HLL_SKETCH(`c1`, `c2`) FROM `t`"},
+		{`select hll_merge(c1) from t;`, true, "SELECT HLL_MERGE(`c1`) FROM `t`"},
+		{`select hll_merge(c1, c2) from t;`, false, ""},
+		{`create table hll_sketch (hll_merge int, approx_top_k int);`, true, "CREATE TABLE `hll_sketch` (`hll_merge` INT,`approx_top_k` INT)"},
 		{`select group_concat(c2,c1) from t group by c1;`, true, "SELECT GROUP_CONCAT(`c2`, `c1` SEPARATOR ',') FROM `t` GROUP BY `c1`"},
 		{`select group_concat(c2,c1 SEPARATOR ';') from t group by c1;`, true, "SELECT GROUP_CONCAT(`c2`, `c1` SEPARATOR ';') FROM `t` GROUP BY `c1`"},
 		{`select group_concat(distinct c2,c1) from t group by c1;`, true, "SELECT GROUP_CONCAT(DISTINCT `c2`, `c1` SEPARATOR ',') FROM `t` GROUP BY `c1`"},
@@ -3087,6 +3118,8 @@ func TestDDL(t *testing.T) {
 		{"ALTER TABLE t ADD FULLTEXT KEY `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
 		{"ALTER TABLE t ADD FULLTEXT INDEX `FullText` (`name` ASC)", true, "ALTER TABLE `t` ADD FULLTEXT `FullText`(`name`)"},
+		{"ALTER TABLE t ADD SPATIAL KEY `sp` (`g`)", true, "ALTER TABLE `t` ADD SPATIAL `sp`(`g`)"},
+		{"ALTER TABLE t ADD SPATIAL INDEX (`g`)", true, "ALTER TABLE `t` ADD SPATIAL(`g`)"},
 		{"ALTER TABLE t ADD INDEX (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX IF NOT EXISTS (a) USING BTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX IF NOT EXISTS(`a`) USING BTREE COMMENT 'a'"},
 		{"ALTER TABLE t ADD INDEX (a) USING RTREE COMMENT 'a'", true, "ALTER TABLE `t` ADD INDEX(`a`) USING RTREE COMMENT 'a'"},
@@ -3772,6 +3805,9 @@ func TestDDL(t *testing.T) {
 		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(EXEC_ELAPSED '10s' ACTION DRYRUN)", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' ACTION = DRYRUN)"},
 		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(EXEC_ELAPSED '10m' ACTION COOLDOWN)", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10m' ACTION = COOLDOWN)"},
 		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(ACTION KILL EXEC_ELAPSED='10m')", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (ACTION = KILL EXEC_ELAPSED = '10m')"},
+		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(EXEC_ELAPSED '10s' ACTION SWITCH_GROUP(rg_low))", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' ACTION = SWITCH_GROUP(`rg_low`))"},
+		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(ACTION = SWITCH_GROUP(`default`) RU=100 WATCH SIMILAR)", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (ACTION = SWITCH_GROUP(`default`) RU = 100 WATCH = SIMILAR DURATION = UNLIMITED)"},
+		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(EXEC_ELAPSED '10s' ACTION SWITCH_GROUP)", false, ""},
 		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(EXEC_ELAPSED '10s' WATCH=SIMILAR DURATION '10m' ACTION COOLDOWN)", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' WATCH = SIMILAR DURATION = '10m' ACTION = COOLDOWN)"},
 		{"create resource group x ru_per_sec=1000 QUERY_LIMIT (EXEC_ELAPSED \"10s\" ACTION COOLDOWN WATCH EXACT DURATION='10m')", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' ACTION = COOLDOWN WATCH = EXACT DURATION = '10m')"},
 		{"create resource group x ru_per_sec=1000 QUERY_LIMIT (EXEC_ELAPSED '9s' ACTION COOLDOWN WATCH EXACT)", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '9s' ACTION = COOLDOWN WATCH = EXACT DURATION = UNLIMITED)"},
@@ -3782,6 +3818,11 @@ func TestDDL(t *testing.T) {
 		{"create resource group x ru_per_sec=1000 background (task_types='br,lightning')", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, BACKGROUND = (TASK_TYPES = 'br,lightning')"},
 		{`create resource group x ru_per_sec=1000 QUERY_LIMIT (EXEC_ELAPSED "10s" ACTION COOLDOWN WATCH EXACT DURATION='10m')  background (task_types 'br,lightning')`, true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' ACTION = COOLDOWN WATCH = EXACT DURATION = '10m'), BACKGROUND = (TASK_TYPES = 'br,lightning')"},
 		{`create resource group x ru_per_sec=1000 QUERY_LIMIT (EXEC_ELAPSED "10s" ACTION COOLDOWN WATCH PLAN DURATION='10m')  background (task_types 'br,lightning')`, true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' ACTION = COOLDOWN WATCH = PLAN DURATION = '10m'), BACKGROUND = (TASK_TYPES = 'br,lightning')"},
+		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(PROCESSED_KEYS 10000 ACTION KILL)", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (PROCESSED_KEYS = 10000 ACTION = KILL)"},
+		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(EXEC_ELAPSED='10s', RU=500, MEMORY='1GiB' ACTION COOLDOWN)", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s' RU = 500 MEMORY = '1GiB' ACTION = COOLDOWN)"},
+		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(MEMORY=1048576 ACTION DRYRUN WATCH SIMILAR)", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (MEMORY = 1048576 ACTION = DRYRUN WATCH = SIMILAR DURATION = UNLIMITED)"},
+		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(RU=500 RU=1000 ACTION KILL)", false, ""},
+		{"create resource group x ru_per_sec=1000 QUERY_LIMIT=(PROCESSED_KEYS='10' ACTION KILL)", false, ""},
 		// This case is expected in parser test but not in actual ddl job.
 		{"create resource group x ru_per_sec=1000 QUERY_LIMIT = (EXEC_ELAPSED '10s')", true, "CREATE RESOURCE GROUP `x` RU_PER_SEC = 1000, QUERY_LIMIT = (EXEC_ELAPSED = '10s')"},
 		{"create resource group x ru_per_sec=1000 QUERY=(EXEC_ELAPSED '10s')", false, ""},
@@ -4768,6 +4809,14 @@ func TestType(t *testing.T) {
 
 		// for json type
 		{`create table t (a JSON);`, true, "CREATE TABLE `t` (`a` JSON)"},
+
+		// for spatial types
+		{"create table t (g geometry, p point not null, l linestring, pg polygon)", true, "CREATE TABLE `t` (`g` GEOMETRY,`p` POINT NOT NULL,`l` LINESTRING,`pg` POLYGON)"},
+		{"create table t (mp multipoint, ml multilinestring, mpg multipolygon, gc geometrycollection)", true, "CREATE TABLE `t` (`mp` MULTIPOINT,`ml` MULTILINESTRING,`mpg` MULTIPOLYGON,`gc` GEOMETRYCOLLECTION)"},
+		{"create table t (p point not null, spatial key idx(p))", true, "CREATE TABLE `t` (`p` POINT NOT NULL,SPATIAL `idx`(`p`))"},
+		{"create table t (p point not null, spatial index (p) comment 'c')", true, "CREATE TABLE `t` (`p` POINT NOT NULL,SPATIAL(`p`) COMMENT 'c')"},
+		{"create table t (point int, polygon int, geometry int)", true, "CREATE TABLE `t` (`point` INT,`polygon` INT,`geometry` INT)"},
+		{"create table t (p point(10))", false, ""},
 	}
 	RunTest(t, table, false)
 }
@@ -5251,6 +5300,27 @@ func TestWithRollup(t *testing.T) {
 		// should be ERROR 1241 (21000): Operand should contain 1 column(s) in runtime.
 		{`select * from t group by (a, b) with rollup`, true, "SELECT * FROM `t` GROUP BY ROW(`a`,`b`) WITH ROLLUP"},
 		{`select * from t group by (a+b) with rollup`, true, "SELECT * FROM `t` GROUP BY (`a`+`b`) WITH ROLLUP"},
+		{`select * from t group by rollup(a, b)`, true, "SELECT * FROM `t` GROUP BY `a`,`b` WITH ROLLUP"},
+	}
+	RunTest(t, table, false)
+}
+
+func TestGroupingSets(t *testing.T) {
+	table := []testCase{
+		{`select a, b, grouping(a, b) from t group by cube(a, b)`, true, "SELECT `a`,`b`,GROUPING(`a`, `b`) FROM `t` GROUP BY CUBE(`a`,`b`)"},
+		{`select * from t group by cube (a+1, b)`, true, "SELECT * FROM `t` GROUP BY CUBE(`a`+1,`b`)"},
+		{`select * from t group by cube()`, false, ""},
+		{`select * from t group by cube(a) with rollup`, false, ""},
+		{`select * from t group by grouping sets ((a, b), (a), ())`, true, "SELECT * FROM `t` GROUP BY GROUPING SETS ((`a`,`b`),(`a`),())"},
+		{`select * from t group by grouping sets ((a), (b, c desc))`, true, "SELECT * FROM `t` GROUP BY GROUPING SETS ((`a`),(`b`,`c` DESC))"},
+		{`select * from t group by grouping sets ((a, b))`, true, "SELECT * FROM `t` GROUP BY GROUPING SETS ((`a`,`b`))"},
+		{`select * from t group by grouping sets (((a, b)))`, true, "SELECT * FROM `t` GROUP BY GROUPING SETS ((ROW(`a`,`b`)))"},
+		{`select * from t group by grouping sets (a, b)`, false, ""},
+		{`select * from t group by grouping sets ()`, false, ""},
+		{`select grouping(a) from t group by grouping(a)`, true, "SELECT GROUPING(`a`) FROM `t` GROUP BY GROUPING(`a`)"},
+		{`create table cube (a int)`, false, ""},
+		{`create table grouping (a int)`, false, ""},
+		{`create table sets (a int)`, true, "CREATE TABLE `sets` (`a` INT)"},
 	}
 	RunTest(t, table, false)
 }
@@ -5461,6 +5531,9 @@ func TestBinding(t *testing.T) {
 		{"set binding disabled for select * from t using select * from t use index(a)", true, "SET BINDING DISABLED FOR SELECT * FROM `t` USING SELECT * FROM `t` USE INDEX (`a`)"},
 		{"create global binding for select * from t union all select * from t using select * from t use index(a) union all select * from t use index(a)", true, "CREATE GLOBAL BINDING FOR SELECT * FROM `t` UNION ALL SELECT * FROM `t` USING SELECT * FROM `t` USE INDEX (`a`) UNION ALL SELECT * FROM `t` USE INDEX (`a`)"},
 		{"create session binding for select * from t union all select * from t using select * from t use index(a) union all select * from t use index(a)", true, "CREATE SESSION BINDING FOR SELECT * FROM `t` UNION ALL SELECT * FROM `t` USING SELECT * FROM `t` USE INDEX (`a`) UNION ALL SELECT * FROM `t` USE INDEX (`a`)"},
+		{"create global binding for select * from *.t using select * from *.t use index(a)", true, "CREATE GLOBAL BINDING FOR SELECT * FROM `*`.`t` USING SELECT * FROM `*`.`t` USE INDEX (`a`)"},
+		{"create session binding for select * from *.t1 join *.t2 on t1.a = t2.a using select * from *.t1 join *.t2 use index(a) on t1.a = t2.a", true, "CREATE SESSION BINDING FOR SELECT * FROM `*`.`t1` JOIN `*`.`t2` ON `t1`.`a`=`t2`.`a` USING SELECT * FROM `*`.`t1` JOIN `*`.`t2` USE INDEX (`a`) ON `t1`.`a`=`t2`.`a`"},
+		{"select * from *.t", true, "SELECT * FROM `*`.`t`"},
 		{"drop global binding for select * from t union all select * from t using select * from t use index(a) union all select * from t use index(a)", true, "DROP GLOBAL BINDING FOR SELECT * FROM `t` UNION ALL SELECT * FROM `t` USING SELECT * FROM `t` USE INDEX (`a`) UNION ALL SELECT * FROM `t` USE INDEX (`a`)"},
 		{"drop session binding for select * from t union all select * from t using select * from t use index(a) union all select * from t use index(a)", true, "DROP SESSION BINDING FOR SELECT * FROM `t` UNION ALL SELECT * FROM `t` USING SELECT * FROM `t` USE INDEX (`a`) UNION ALL SELECT * FROM `t` USE INDEX (`a`)"},
 		{"drop global binding for select * from t union all select * from t", true, "DROP GLOBAL BINDING FOR SELECT * FROM `t` UNION ALL SELECT * FROM `t`"},
@@ -5931,6 +6004,45 @@ func TestTableSample(t *testing.T) {
 	}
 }
 
+func TestJSONTable(t *testing.T) {
+	table := []testCase{
+		// positive test cases
+		{"select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[1, 2]', '$[*]' columns (a int path '$')) jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt`"},
+		{"select * from json_table('[1, 2]', '$[*]' columns (id for ordinality, a varchar(10) path '$.a')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[1, 2]', '$[*]' COLUMNS (`id` FOR ORDINALITY, `a` VARCHAR(10) PATH '$.a')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int exists path '$.a')) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT EXISTS PATH '$.a')) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' default '0' on empty)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' DEFAULT '0' ON EMPTY)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' error on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' ERROR ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a' null on empty default '1' on error)) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a' NULL ON EMPTY DEFAULT '1' ON ERROR)) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$.a', nested path '$.b[*]' columns (b int path '$'))) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (`a` INT PATH '$.a', NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$'))) AS `jt`"},
+		{"select * from json_table('[]', '$[*]' columns (nested '$.b[*]' columns (b int path '$', nested path '$.c' columns (c json path '$')))) as jt", true, "SELECT * FROM JSON_TABLE(_UTF8MB4'[]', '$[*]' COLUMNS (NESTED PATH '$.b[*]' COLUMNS (`b` INT PATH '$', NESTED PATH '$.c' COLUMNS (`c` JSON PATH '$')))) AS `jt`"},
+		{"select t.id, jt.a from t, json_table(t.doc, '$[*]' columns (a int path '$')) as jt where jt.a > 1", true, "SELECT `t`.`id`,`jt`.`a` FROM (`t`) JOIN JSON_TABLE(`t`.`doc`, '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt` WHERE `jt`.`a`>1"},
+		{"select * from t left join json_table(t.doc, '$[*]' columns (a int path '$')) as jt on true", true, "SELECT * FROM `t` LEFT JOIN JSON_TABLE(`t`.`doc`, '$[*]' COLUMNS (`a` INT PATH '$')) AS `jt` ON TRUE"},
+		{"select nested, ordinality, path, empty from t", true, "SELECT `nested`,`ordinality`,`path`,`empty` FROM `t`"},
+
+		// negative test cases
+		{"select * from json_table('[]', '$[*]' columns (a int path '$'))", false, ""},
+		{"select * from json_table('[]', '$[*]') as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns ()) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int)) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$' on error)) as jt", false, ""},
+		{"select * from json_table('[]', '$[*]' columns (a int path '$' null on error null on empty)) as jt", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
+func TestLateralDerivedTable(t *testing.T) {
+	table := []testCase{
+		{"select * from t1, lateral (select * from t2 where t2.a = t1.a) as dt", true, "SELECT * FROM (`t1`) JOIN LATERAL (SELECT * FROM `t2` WHERE `t2`.`a`=`t1`.`a`) AS `dt`"},
+		{"select * from t1, lateral (select * from t2 where t2.a = t1.a order by t2.b limit 3) dt", true, "SELECT * FROM (`t1`) JOIN LATERAL (SELECT * FROM `t2` WHERE `t2`.`a`=`t1`.`a` ORDER BY `t2`.`b` LIMIT 3) AS `dt`"},
+		{"select * from t1 left join lateral (select t2.b from t2 where t2.a = t1.a) as dt on true", true, "SELECT * FROM `t1` LEFT JOIN LATERAL (SELECT `t2`.`b` FROM `t2` WHERE `t2`.`a`=`t1`.`a`) AS `dt` ON TRUE"},
+		{"select * from t1 join lateral (select 1 union select t1.a) as dt", true, "SELECT * FROM `t1` JOIN LATERAL (SELECT 1 UNION SELECT `t1`.`a`) AS `dt`"},
+		{"select * from t1, lateral t2", false, ""},
+		{"select lateral from t", false, ""},
+	}
+	RunTest(t, table, false)
+}
+
 func TestGeneratedColumn(t *testing.T) {
 	tests := []struct {
 		input string
@@ -6319,6 +6431,15 @@ func TestWindowFunctions(t *testing.T) {
 		{`SELECT AVG(val) OVER (RANGE BETWEEN INTERVAL 5 DAY PRECEDING AND INTERVAL '2:30' MINUTE_SECOND FOLLOWING) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN INTERVAL 5 DAY PRECEDING AND INTERVAL _UTF8MB4'2:30' MINUTE_SECOND FOLLOWING) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
 		{`SELECT AVG(val) OVER (RANGE CURRENT ROW) FROM t;`, true, "SELECT AVG(`val`) OVER (RANGE BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
+		{`SELECT SUM(val) OVER (ORDER BY time GROUPS BETWEEN 1 PRECEDING AND 2 FOLLOWING) FROM t;`, true, "SELECT SUM(`val`) OVER (ORDER BY `time` GROUPS BETWEEN 1 PRECEDING AND 2 FOLLOWING) FROM `t`"},
+		{`SELECT SUM(val) OVER (ORDER BY time GROUPS CURRENT ROW) FROM t;`, true, "SELECT SUM(`val`) OVER (ORDER BY `time` GROUPS BETWEEN CURRENT ROW AND CURRENT ROW) FROM `t`"},
+		{`SELECT SUM(val) OVER (ORDER BY time ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING EXCLUDE CURRENT ROW) FROM t;`, true, "SELECT SUM(`val`) OVER (ORDER BY `time` ROWS BETWEEN 1 PRECEDING AND 1 FOLLOWING EXCLUDE CURRENT ROW) FROM `t`"},
+		{`SELECT SUM(val) OVER (ORDER BY time RANGE UNBOUNDED PRECEDING EXCLUDE GROUP) FROM t;`, true, "SELECT SUM(`val`) OVER (ORDER BY `time` RANGE BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW EXCLUDE GROUP) FROM `t`"},
+		{`SELECT SUM(val) OVER (ORDER BY time GROUPS BETWEEN 1 PRECEDING AND 1 FOLLOWING EXCLUDE TIES) FROM t;`, true, "SELECT SUM(`val`) OVER (ORDER BY `time` GROUPS BETWEEN 1 PRECEDING AND 1 FOLLOWING EXCLUDE TIES) FROM `t`"},
+		{`SELECT SUM(val) OVER (ORDER BY time ROWS 1 PRECEDING EXCLUDE NO OTHERS) FROM t;`, true, "SELECT SUM(`val`) OVER (ORDER BY `time` ROWS BETWEEN 1 PRECEDING AND CURRENT ROW) FROM `t`"},
+		{`SELECT SUM(val) OVER (ORDER BY time EXCLUDE CURRENT ROW) FROM t;`, false, ""},
+		{`SELECT SUM(val) OVER (ORDER BY time ROWS 1 PRECEDING EXCLUDE OTHERS) FROM t;`, false, ""},
+		{`SELECT ties, others, exclude FROM t;`, true, "SELECT `ties`,`others`,`exclude` FROM `t`"},
 
 		// For named windows.
 		// See https://dev.mysql.com/doc/refman/8.0/en/window-functions-named-windows.html
@@ -7428,6 +7549,28 @@ func TestTTLTableOption(t *testing.T) {
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '@monthly'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10hourxx'", false, ""},
 		{"create table t (created_at datetime) TTL_JOB_INTERVAL = '10.10.255h'", false, ""},
+
+		// filter and archive table of ttl
+		{"create table t (created_at datetime, status int) TTL = created_at + INTERVAL 1 YEAR TTL_FILTER = 'status = 2'", true, "CREATE TABLE `t` (`created_at` DATETIME,`status` INT) TTL = `created_at` + INTERVAL 1 YEAR TTL_FILTER = 'status = 2'"},
+		{"create table t (created_at datetime) TTL = created_at + INTERVAL 1 YEAR TTL_ARCHIVE_TO = test.t_archive", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_ARCHIVE_TO = `test`.`t_archive`"},
+		{"create table t (created_at datetime) /*T![ttl] TTL = created_at + INTERVAL 1 YEAR TTL_FILTER 'created_at > 0' TTL_ARCHIVE_TO t_archive*/", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_FILTER = 'created_at > 0' TTL_ARCHIVE_TO = `t_archive`"},
+		{"alter table t TTL_FILTER = 'status in (1, 2)'", true, "ALTER TABLE `t` TTL_FILTER = 'status in (1, 2)'"},
+		{"alter table t TTL_FILTER = ''", true, "ALTER TABLE `t` TTL_FILTER = ''"},
+		{"alter table t TTL_ARCHIVE_TO = db.t_archive", true, "ALTER TABLE `t` TTL_ARCHIVE_TO = `db`.`t_archive`"},
+		{"alter table t TTL_ARCHIVE_TO = ''", true, "ALTER TABLE `t` TTL_ARCHIVE_TO = ''"},
+		{"alter table t TTL_ARCHIVE_TO = 'db.t_archive'", false, ""},
+		{"create table ttl_filter (ttl_archive_to int)", true, "CREATE TABLE `ttl_filter` (`ttl_archive_to` INT)"},
+
+		// job window and delete rate of ttl
+		{"create table t (created_at datetime) TTL = created_at + INTERVAL 1 YEAR TTL_JOB_WINDOW = '22:00 +0800-06:00 +0800' TTL_DELETE_RATE = 10", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_JOB_WINDOW = '22:00 +0800-06:00 +0800' TTL_DELETE_RATE = 10"},
+		{"create table t (created_at datetime) /*T![ttl] TTL = created_at + INTERVAL 1 YEAR TTL_JOB_WINDOW '01:00-05:00' TTL_DELETE_RATE 0*/", true, "CREATE TABLE `t` (`created_at` DATETIME) TTL = `created_at` + INTERVAL 1 YEAR TTL_JOB_WINDOW = '01:00-05:00' TTL_DELETE_RATE = 0"},
+		{"alter table t TTL_JOB_WINDOW = ''", true, "ALTER TABLE `t` TTL_JOB_WINDOW = ''"},
+		{"alter table t TTL_JOB_WINDOW = '01:00-05:00' TTL_DELETE_RATE = 100", true, "ALTER TABLE `t` TTL_JOB_WINDOW = '01:00-05:00' TTL_DELETE_RATE = 100"},
+		{"alter table t TTL_JOB_WINDOW = '01:00'", false, ""},
+		{"alter table t TTL_JOB_WINDOW = '01:00-25:00'", false, ""},
+		{"alter table t TTL_DELETE_RATE = -1", false, ""},
+		{"alter table t TTL_DELETE_RATE = '10'", false, ""},
+		{"create table ttl_job_window (ttl_delete_rate int)", true, "CREATE TABLE `ttl_job_window` (`ttl_delete_rate` INT)"},
 	}
 
 	RunTest(t, table, false)
@@ -7462,3 +7605,200 @@ func TestMultiStmt(t *testing.T) {
 	require.Equal(t, "'baz'", stmt3.Fields.Fields[2].Text())
 	require.Equal(t, "1", stmt4.Fields.Fields[0].Text())
 }
+
+func TestProcedure(t *testing.T) {
+	table := []testCase{
+		{"create procedure p() select 1", true, "CREATE PROCEDURE `p`() SELECT 1"},
+		{"create procedure if not exists test.p(in a int, out b varchar(10), inout c int) begin declare x int default 1; set b = 'a'; end", true, "CREATE PROCEDURE IF NOT EXISTS `test`.`p`( IN `a` INT(11), OUT `b` VARCHAR(10), INOUT `c` INT(11)) BEGIN DECLARE `x` INT(11) DEFAULT 1;SET @@SESSION.`b`=_UTF8MB4'a'; END"},
+		{"create procedure p() begin declare done int default 0; declare c cursor for select a from t; declare continue handler for not found set done = 1; open c; l: loop fetch c into x; if done then leave l; end if; end loop l; close c; end", true, "CREATE PROCEDURE `p`() BEGIN DECLARE `done` INT(11) DEFAULT 0;DECLARE C CURSOR FOR SELECT `a` FROM `t`;DECLARE CONTINUE HANDLER FOR NOT FOUND SET @@SESSION.`done`=1;OPEN C;`l`: LOOP FETCH C INTO X;IF `done` THEN LEAVE `l`;END IF;END LOOP `l`;CLOSE C; END"},
+		{"create procedure p() begin loop select 1; end loop; end", true, "CREATE PROCEDURE `p`() BEGIN LOOP SELECT 1;END LOOP; END"},
+		{"create procedure p() begin call q(1, @a); end", true, "CREATE PROCEDURE `p`() BEGIN CALL `q`(1, @`a`); END"},
+		{"create procedure p() begin loop end loop; end", false, ""},
+		{"create procedure p() begin select a, b into x, @y from t where id = 1; end", true, "CREATE PROCEDURE `p`() BEGIN SELECT `a`,`b` FROM `t` WHERE `id`=1 INTO `x`,@`y`; END"},
+		{"create definer = 'root'@'%' procedure p() comment 'a''b' language sql not deterministic reads sql data sql security invoker select 1", true, "CREATE DEFINER = `root`@`%` PROCEDURE `p`() COMMENT 'a''b' LANGUAGE SQL NOT DETERMINISTIC READS SQL DATA SQL SECURITY INVOKER SELECT 1"},
+		{"create definer = current_user procedure p() deterministic contains sql no sql modifies sql data sql security definer select 1", true, "CREATE PROCEDURE `p`() DETERMINISTIC CONTAINS SQL NO SQL MODIFIES SQL DATA SQL SECURITY DEFINER SELECT 1"},
+		{"create procedure p() comment 'c' l: loop leave l; end loop l", true, "CREATE PROCEDURE `p`() COMMENT 'c' `l`: LOOP LEAVE `l`;END LOOP `l`"},
+		{"create procedure p() sql security select 1", false, ""},
+		{"create or replace procedure p() select 1", false, ""},
+		{"create sql security invoker procedure p() select 1", false, ""},
+		{"drop procedure p", true, "DROP PROCEDURE `p`"},
+		{"drop procedure if exists test.p", true, "DROP PROCEDURE IF EXISTS `test`.`p`"},
+	}
+	p := parser.New()
+	for _, tbl := range table {
+		stmt, err := p.ParseOneStmt(tbl.src, "", "")
+		if !tbl.ok {
+			require.Error(t, err, tbl.src)
+			continue
+		}
+		require.NoError(t, err, tbl.src)
+		var sb strings.Builder
+		require.NoError(t, stmt.Restore(NewRestoreCtx(DefaultRestoreFlags, &sb)), tbl.src)
+		require.Equal(t, tbl.restore, sb.String(), tbl.src)
+		_, err = p.ParseOneStmt(sb.String(), "", "")
+		require.NoError(t, err, sb.String())
+	}
+
+	stmt, err := p.ParseOneStmt("create procedure p(in a int, out b int) begin l1: loop leave l1; end loop; end", "", "")
+	require.NoError(t, err)
+	info := stmt.(*ast.ProcedureInfo)
+	require.Equal(t, "in a int, out b int", info.ProcedureParamStr)
+	require.Equal(t, "begin l1: loop leave l1; end loop; end", info.ProcedureBody.Text())
+	block := info.ProcedureBody.(*ast.ProcedureBlock)
+	loop := block.ProcedureProcStmts[0].(*ast.ProcedureLabelLoop)
+	require.Equal(t, "l1", loop.LabelName)
+	require.IsType(t, &ast.ProcedureLoopStmt{}, loop.Block)
+
+	stmt, err = p.ParseOneStmt("create definer = u procedure p(in a int) comment 'c' sql security invoker select a", "", "")
+	require.NoError(t, err)
+	info = stmt.(*ast.ProcedureInfo)
+	require.Equal(t, "u", info.Definer.Username)
+	require.Equal(t, "in a int", info.ProcedureParamStr)
+	require.Equal(t, "select a", info.ProcedureBody.Text())
+	require.Len(t, info.Characteristics, 2)
+	require.Equal(t, ast.ProcedureComment, info.Characteristics[0].Tp)
+	require.Equal(t, "c", info.Characteristics[0].Comment)
+	require.Equal(t, ast.ProcedureSQLSecurityInvoker, info.Characteristics[1].Tp)
+
+	// The stored functions are not supported.
+	_, err = p.ParseOneStmt("create function f(a int) returns int deterministic return a + 1", "", "")
+	require.Error(t, err)
+
+	stmt, err = p.ParseOneStmt("select a, b into x, @y from t", "", "")
+	require.NoError(t, err)
+	into := stmt.(*ast.SelectStmt).SelectIntoOpt
+	require.Equal(t, ast.SelectIntoVars, into.Tp)
+	require.Equal(t, "x", into.Variables[0].(*ast.ColumnNameExpr).Name.Name.O)
+	require.Equal(t, "y", into.Variables[1].(*ast.VariableExpr).Name)
+	require.Equal(t, "b", stmt.(*ast.SelectStmt).Fields.Fields[1].Text())
+}
+
+func TestTrigger(t *testing.T) {
+	table := []testCase{
+		{"create trigger tr before insert on t for each row set new.a = 1", true, "CREATE DEFINER = CURRENT_USER TRIGGER `tr` BEFORE INSERT ON `t` FOR EACH ROW SET @@SESSION.`new.a`=1"},
+		{"create definer = current_user trigger if not exists test.tr after update on test.t for each row begin insert into log values (old.a, new.a); end", true, "CREATE DEFINER = CURRENT_USER TRIGGER IF NOT EXISTS `test`.`tr` AFTER UPDATE ON `test`.`t` FOR EACH ROW BEGIN INSERT INTO `log` VALUES (`old`.`a`,`new`.`a`); END"},
+		{"create definer = 'root'@'%' trigger tr after delete on t for each row insert into log values (old.a)", true, "CREATE DEFINER = `root`@`%` TRIGGER `tr` AFTER DELETE ON `t` FOR EACH ROW INSERT INTO `log` VALUES (`old`.`a`)"},
+		{"create or replace trigger tr before insert on t for each row set @a = 1", false, ""},
+		{"create algorithm = merge trigger tr before insert on t for each row set @a = 1", false, ""},
+		{"create trigger tr before insert on t set @a = 1", false, ""},
+		{"create trigger tr instead of insert on t for each row set @a = 1", false, ""},
+		{"drop trigger tr", true, "DROP TRIGGER `tr`"},
+		{"drop trigger if exists test.tr", true, "DROP TRIGGER IF EXISTS `test`.`tr`"},
+	}
+	p := parser.New()
+	for _, tbl := range table {
+		stmt, err := p.ParseOneStmt(tbl.src, "", "")
+		if !tbl.ok {
+			require.Error(t, err, tbl.src)
+			continue
+		}
+		require.NoError(t, err, tbl.src)
+		var sb strings.Builder
+		require.NoError(t, stmt.Restore(NewRestoreCtx(DefaultRestoreFlags, &sb)), tbl.src)
+		require.Equal(t, tbl.restore, sb.String(), tbl.src)
+		_, err = p.ParseOneStmt(sb.String(), "", "")
+		require.NoError(t, err, sb.String())
+	}
+
+	stmt, err := p.ParseOneStmt("create trigger tr before update on t for each row begin set new.a = old.a + 1; end", "", "")
+	require.NoError(t, err)
+	trigger := stmt.(*ast.CreateTriggerStmt)
+	require.Equal(t, model.TriggerTimingBefore, trigger.Timing)
+	require.Equal(t, model.TriggerEventUpdate, trigger.Event)
+	require.Equal(t, "begin set new.a = old.a + 1; end", trigger.Body.Text())
+	assign := trigger.Body.(*ast.ProcedureBlock).ProcedureProcStmts[0].(*ast.SetStmt).Variables[0]
+	require.Equal(t, "new.a", assign.Name)
+}
+
+func TestEvent(t *testing.T) {
+	table := []testCase{
+		{"create event ev on schedule every 1 hour do delete from t where a < now()", true, "CREATE DEFINER = CURRENT_USER EVENT `ev` ON SCHEDULE EVERY 1 HOUR DO DELETE FROM `t` WHERE `a`<NOW()"},
+		{"create definer = 'root'@'%' event if not exists test.ev on schedule every '1:30' hour_minute starts '2023-01-01 00:00:00' ends now() + interval 1 day on completion preserve disable comment 'cleanup' do insert into log values (now())",
+			true, "CREATE DEFINER = `root`@`%` EVENT IF NOT EXISTS `test`.`ev` ON SCHEDULE EVERY _UTF8MB4'1:30' HOUR_MINUTE STARTS _UTF8MB4'2023-01-01 00:00:00' ENDS DATE_ADD(NOW(), INTERVAL 1 DAY) ON COMPLETION PRESERVE DISABLE COMMENT 'cleanup' DO INSERT INTO `log` VALUES (NOW())"},
+		{"create event ev on schedule at current_timestamp + interval 1 minute on completion not preserve disable on slave do update t set a = a + 1", true, "CREATE DEFINER = CURRENT_USER EVENT `ev` ON SCHEDULE AT DATE_ADD(CURRENT_TIMESTAMP(), INTERVAL 1 MINUTE) ON COMPLETION NOT PRESERVE DISABLE ON SLAVE DO UPDATE `t` SET `a`=`a`+1"},
+		{"create event ev on schedule every 1 day enable do begin insert into t values (1); end", true, "CREATE DEFINER = CURRENT_USER EVENT `ev` ON SCHEDULE EVERY 1 DAY ENABLE DO BEGIN INSERT INTO `t` VALUES (1); END"},
+		{"create or replace event ev on schedule every 1 day do select 1", false, ""},
+		{"create event ev on schedule every 1 do select 1", false, ""},
+		{"create event ev on schedule at now() starts now() do select 1", false, ""},
+		{"create event ev do select 1", false, ""},
+		{"alter event ev enable", true, "ALTER DEFINER = CURRENT_USER EVENT `ev` ENABLE"},
+		{"alter definer = 'u'@'%' event test.ev on schedule every 2 minute on completion preserve rename to test.ev2 disable comment '' do select 1", true, "ALTER DEFINER = `u`@`%` EVENT `test`.`ev` ON SCHEDULE EVERY 2 MINUTE ON COMPLETION PRESERVE RENAME TO `test`.`ev2` DISABLE COMMENT '' DO SELECT 1"},
+		{"alter event ev on completion not preserve", true, "ALTER DEFINER = CURRENT_USER EVENT `ev` ON COMPLETION NOT PRESERVE"},
+		{"alter event ev do select 1", true, "ALTER DEFINER = CURRENT_USER EVENT `ev` DO SELECT 1"},
+		{"drop event ev", true, "DROP EVENT `ev`"},
+		{"drop event if exists test.ev", true, "DROP EVENT IF EXISTS `test`.`ev`"},
+		{"show create event test.ev", true, "SHOW CREATE EVENT `test`.`ev`"},
+		{"create table at (at int, starts int, ends int, completion int)", true, "CREATE TABLE `at` (`at` INT,`starts` INT,`ends` INT,`completion` INT)"},
+	}
+	p := parser.New()
+	for _, tbl := range table {
+		stmt, err := p.ParseOneStmt(tbl.src, "", "")
+		if !tbl.ok {
+			require.Error(t, err, tbl.src)
+			continue
+		}
+		require.NoError(t, err, tbl.src)
+		var sb strings.Builder
+		require.NoError(t, stmt.Restore(NewRestoreCtx(DefaultRestoreFlags, &sb)), tbl.src)
+		require.Equal(t, tbl.restore, sb.String(), tbl.src)
+		_, err = p.ParseOneStmt(sb.String(), "", "")
+		require.NoError(t, err, sb.String())
+	}
+
+	stmt, err := p.ParseOneStmt("create event ev on schedule every 10 second starts now() do insert into t select * from s", "", "")
+	require.NoError(t, err)
+	ev := stmt.(*ast.CreateEventStmt)
+	require.Nil(t, ev.Schedule.At)
+	require.Equal(t, ast.TimeUnitSecond, ev.Schedule.Unit)
+	require.NotNil(t, ev.Schedule.Starts)
+	require.Nil(t, ev.Schedule.Ends)
+	require.Nil(t, ev.Comment)
+	require.Equal(t, ast.EventStatusUnspecified, ev.Status)
+	require.Equal(t, "insert into t select * from s", ev.Body.Text())
+
+	stmt, err = p.ParseOneStmt("alter event ev rename to ev2 do  delete from t ", "", "")
+	require.NoError(t, err)
+	alter := stmt.(*ast.AlterEventStmt)
+	require.Nil(t, alter.Schedule)
+	require.Equal(t, "ev2", alter.NewName.Name.O)
+	require.Equal(t, "delete from t", alter.Body.Text())
+}
+
+func TestMaterializedView(t *testing.T) {
+	table := []testCase{
+		{"create materialized view mv as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW `mv` REFRESH COMPLETE AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view if not exists test.mv (a, c) refresh fast as select a, count(*) from t group by a", true, "CREATE MATERIALIZED VIEW IF NOT EXISTS `test`.`mv` (`a`,`c`) REFRESH FAST AS SELECT `a`,COUNT(1) FROM `t` GROUP BY `a`"},
+		{"create materialized view mv refresh complete every 2 hour as select * from t", true, "CREATE MATERIALIZED VIEW `mv` REFRESH COMPLETE EVERY 2 HOUR AS SELECT * FROM `t`"},
+		{"create materialized view mv refresh every 2 hour as select * from t", false, ""},
+		{"create or replace materialized view mv as select * from t", false, ""},
+		{"create definer = 'root'@'%' materialized view mv as select * from t", false, ""},
+		{"drop materialized view mv", true, "DROP MATERIALIZED VIEW `mv`"},
+		{"drop materialized view if exists test.mv", true, "DROP MATERIALIZED VIEW IF EXISTS `test`.`mv`"},
+		{"refresh materialized view mv", true, "REFRESH MATERIALIZED VIEW `mv`"},
+		{"refresh materialized view test.mv fast", true, "REFRESH MATERIALIZED VIEW `test`.`mv` FAST"},
+		{"refresh materialized view mv complete", true, "REFRESH MATERIALIZED VIEW `mv` COMPLETE"},
+		{"create table refresh (fast int, complete int, every int, materialized int)", true, "CREATE TABLE `refresh` (`fast` INT,`complete` INT,`every` INT,`materialized` INT)"},
+	}
+	p := parser.New()
+	for _, tbl := range table {
+		stmt, err := p.ParseOneStmt(tbl.src, "", "")
+		if !tbl.ok {
+			require.Error(t, err, tbl.src)
+			continue
+		}
+		require.NoError(t, err, tbl.src)
+		var sb strings.Builder
+		require.NoError(t, stmt.Restore(NewRestoreCtx(DefaultRestoreFlags, &sb)), tbl.src)
+		require.Equal(t, tbl.restore, sb.String(), tbl.src)
+		_, err = p.ParseOneStmt(sb.String(), "", "")
+		require.NoError(t, err, sb.String())
+	}
+
+	stmt, err := p.ParseOneStmt("create materialized view mv refresh fast every 30 minute as select a, sum(b) from t group by a", "", "")
+	require.NoError(t, err)
+	mv := stmt.(*ast.CreateMaterializedViewStmt)
+	require.Equal(t, model.MViewRefreshFast, mv.Refresh)
+	require.Equal(t, uint64(30), mv.RefreshInterval)
+	require.Equal(t, ast.TimeUnitMinute, mv.RefreshUnit)
+	require.Equal(t, "select a, sum(b) from t group by a", mv.Select.Text())
+}
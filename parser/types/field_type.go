@@ -57,6 +57,8 @@ type FieldType struct {
 	elems            []string
 	elemsIsBinaryLit []bool
 	array            bool
+	// geometryType is the subtype of a geometry column, such as POINT or POLYGON.
+	geometryType byte
 	// Please keep in mind that jsonFieldType should be updated if you add a new field here.
 }
 
@@ -243,6 +245,16 @@ func (ft *FieldType) ArrayType() *FieldType {
 	return clone
 }
 
+// GetGeometryType returns the subtype of the geometry FieldType.
+func (ft *FieldType) GetGeometryType() byte {
+	return ft.geometryType
+}
+
+// SetGeometryType sets the subtype of the geometry FieldType.
+func (ft *FieldType) SetGeometryType(tp byte) {
+	ft.geometryType = tp
+}
+
 // SetElemWithIsBinaryLit sets the element of the FieldType.
 func (ft *FieldType) SetElemWithIsBinaryLit(idx int, element string, isBinaryLit bool) {
 	ft.elems[idx] = element
@@ -296,6 +308,7 @@ func (ft *FieldType) Equal(other *FieldType) bool {
 		ft.charset == other.charset &&
 		ft.collate == other.collate &&
 		flenEqual &&
+		ft.geometryType == other.geometryType &&
 		mysql.HasUnsignedFlag(ft.flag) == mysql.HasUnsignedFlag(other.flag)
 	if !partialEqual || len(ft.elems) != len(other.elems) {
 		return false
@@ -369,7 +382,7 @@ func (ft *FieldType) Init(tp byte) {
 // CompactStr only considers tp/CharsetBin/flen/Deimal.
 // This is used for showing column type in infoschema.
 func (ft *FieldType) CompactStr() string {
-	ts := TypeToStr(ft.GetType(), ft.charset)
+	ts := ft.typeStr()
 	suffix := ""
 
 	defaultFlen, defaultDecimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
@@ -423,6 +436,13 @@ func (ft *FieldType) CompactStr() string {
 	return ts + suffix
 }
 
+func (ft *FieldType) typeStr() string {
+	if ft.GetType() == mysql.TypeGeometry {
+		return GeometryTypeStr(ft.geometryType)
+	}
+	return TypeToStr(ft.GetType(), ft.charset)
+}
+
 // InfoSchemaStr joins the CompactStr with unsigned flag and
 // returns a string.
 func (ft *FieldType) InfoSchemaStr() string {
@@ -463,7 +483,7 @@ func (ft *FieldType) String() string {
 
 // Restore implements Node interface.
 func (ft *FieldType) Restore(ctx *format.RestoreCtx) error {
-	ctx.WriteKeyWord(TypeToStr(ft.GetType(), ft.charset))
+	ctx.WriteKeyWord(ft.typeStr())
 
 	precision := UnspecifiedLength
 	scale := UnspecifiedLength
@@ -634,6 +654,7 @@ type jsonFieldType struct {
 	Elems            []string
 	ElemsIsBinaryLit []bool
 	Array            bool
+	GeometryType     byte
 }
 
 // UnmarshalJSON implements the json.Unmarshaler interface.
@@ -650,6 +671,7 @@ func (ft *FieldType) UnmarshalJSON(data []byte) error {
 		ft.elems = r.Elems
 		ft.elemsIsBinaryLit = r.ElemsIsBinaryLit
 		ft.array = r.Array
+		ft.geometryType = r.GeometryType
 	}
 	return err
 }
@@ -666,6 +688,7 @@ func (ft *FieldType) MarshalJSON() ([]byte, error) {
 	r.Elems = ft.elems
 	r.ElemsIsBinaryLit = ft.elemsIsBinaryLit
 	r.Array = ft.array
+	r.GeometryType = ft.geometryType
 	return json.Marshal(r)
 }
 
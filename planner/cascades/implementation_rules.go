@@ -447,7 +447,7 @@ type ImplHashJoinBuildLeft struct {
 // Match implements ImplementationRule Match interface.
 func (*ImplHashJoinBuildLeft) Match(expr *memo.GroupExpr, prop *property.PhysicalProperty) (matched bool) {
 	switch expr.ExprNode.(*plannercore.LogicalJoin).JoinType {
-	case plannercore.InnerJoin, plannercore.LeftOuterJoin, plannercore.RightOuterJoin:
+	case plannercore.InnerJoin, plannercore.LeftOuterJoin, plannercore.RightOuterJoin, plannercore.FullOuterJoin:
 		return prop.IsSortItemEmpty()
 	default:
 		return false
@@ -464,6 +464,8 @@ func (*ImplHashJoinBuildLeft) OnImplement(expr *memo.GroupExpr, reqProp *propert
 		return []memo.Implementation{getImplForHashJoin(expr, reqProp, 1, true)}, nil
 	case plannercore.RightOuterJoin:
 		return []memo.Implementation{getImplForHashJoin(expr, reqProp, 0, false)}, nil
+	case plannercore.FullOuterJoin:
+		return []memo.Implementation{getImplForHashJoin(expr, reqProp, 1, true)}, nil
 	default:
 		return nil, nil
 	}
@@ -489,7 +491,7 @@ func (*ImplHashJoinBuildRight) OnImplement(expr *memo.GroupExpr, reqProp *proper
 		return []memo.Implementation{getImplForHashJoin(expr, reqProp, 1, false)}, nil
 	case plannercore.LeftOuterJoin:
 		return []memo.Implementation{getImplForHashJoin(expr, reqProp, 1, false)}, nil
-	case plannercore.RightOuterJoin:
+	case plannercore.RightOuterJoin, plannercore.FullOuterJoin:
 		return []memo.Implementation{getImplForHashJoin(expr, reqProp, 0, true)}, nil
 	}
 	return nil, nil
@@ -935,6 +935,9 @@ func (*pushDownJoin) predicatePushDown(
 			remainCond = append(expression.ScalarFuncs2Exprs(equalCond), otherCond...)
 			remainCond = append(remainCond, rightPushCond...) // nozero
 		}
+	case plannercore.FullOuterJoin:
+		remainCond = make([]expression.Expression, len(predicates))
+		copy(remainCond, predicates)
 	default:
 		// TODO: Enhance this rule to deal with Semi/SmiAnti Joins.
 	}
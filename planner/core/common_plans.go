@@ -686,7 +686,10 @@ type SelectInto struct {
 	baseSchemaProducer
 
 	TargetPlan Plan
-	IntoOpt    *ast.SelectIntoOption
+	// TargetNames are the output names of TargetPlan, which may be lost after the physical optimization.
+	TargetNames types.NameSlice
+	IntoOpt     *ast.SelectIntoOption
+	Options     []*LoadDataOpt
 	LineFieldsInfo
 }
 
@@ -70,6 +70,7 @@ var (
 	ErrWindowRangeFrameNumericType           = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowRangeFrameNumericType)
 	ErrWindowRangeBoundNotConstant           = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowRangeBoundNotConstant)
 	ErrWindowRowsIntervalUse                 = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowRowsIntervalUse)
+	ErrWindowGroupsFrameOrderBy              = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowGroupsFrameOrderBy)
 	ErrWindowFunctionIgnoresFrame            = dbterror.ClassOptimizer.NewStd(mysql.ErrWindowFunctionIgnoresFrame)
 	ErrInvalidNumberOfArgs                   = dbterror.ClassOptimizer.NewStd(mysql.ErrInvalidNumberOfArgs)
 	ErrFieldInGroupingNotGroupBy             = dbterror.ClassOptimizer.NewStd(mysql.ErrFieldInGroupingNotGroupBy)
@@ -96,6 +97,7 @@ var (
 	ErrCTERecursiveRequiresNonRecursiveFirst = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveRequiresNonRecursiveFirst)
 	ErrCTERecursiveForbidsAggregation        = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveForbidsAggregation)
 	ErrCTERecursiveForbiddenJoinOrder        = dbterror.ClassOptimizer.NewStd(mysql.ErrCTERecursiveForbiddenJoinOrder)
+	ErrTFForbiddenJoinType                   = dbterror.ClassOptimizer.NewStd(mysql.ErrTFForbiddenJoinType)
 	ErrInvalidRequiresSingleReference        = dbterror.ClassOptimizer.NewStd(mysql.ErrInvalidRequiresSingleReference)
 	ErrSQLInReadOnlyMode                     = dbterror.ClassOptimizer.NewStd(mysql.ErrReadOnlyMode)
 	// Since we cannot know if user logged in with a password, use message of ErrAccessDeniedNoPassword instead
@@ -136,6 +136,10 @@ func (p *PhysicalMergeJoin) tryToGetChildReqProp(prop *property.PhysicalProperty
 		if prop.IsPrefix(lProp) && p.JoinType == RightOuterJoin {
 			return nil, false
 		}
+		// The null-extended rows of both sides are mixed in the output of full outer join.
+		if p.JoinType == FullOuterJoin {
+			return nil, false
+		}
 	}
 
 	return []*property.PhysicalProperty{lProp, rProp}, true
@@ -218,6 +222,11 @@ func (p *LogicalJoin) GetMergeJoin(prop *property.PhysicalProperty, schema *expr
 		mergeJoin := PhysicalMergeJoin{basePhysicalJoin: baseJoin}.Init(p.SCtx(), statsInfo.ScaleByExpectCnt(prop.ExpectedCnt), p.SelectBlockOffset())
 		mergeJoin.SetSchema(schema)
 		mergeJoin.OtherConditions = p.moveEqualToOtherConditions(offsets)
+		// The merge join doesn't track which inner rows are matched by the other conditions,
+		// so the full outer join can only be merged by its join keys.
+		if p.JoinType == FullOuterJoin && len(mergeJoin.OtherConditions) > 0 {
+			continue
+		}
 		mergeJoin.initCompareFuncs()
 		if reqProps, ok := mergeJoin.tryToGetChildReqProp(prop); ok {
 			// Adjust expected count for children nodes.
@@ -342,6 +351,9 @@ func (p *LogicalJoin) getEnforcedMergeJoin(prop *property.PhysicalProperty, sche
 		if p.JoinType == RightOuterJoin && hasLeftColInProp {
 			return nil
 		}
+		if p.JoinType == FullOuterJoin {
+			return nil
+		}
 	}
 	// Generate the enforced sort merge join
 	leftKeys := getNewJoinKeysByOffsets(leftJoinKeys, offsets)
@@ -357,6 +369,9 @@ func (p *LogicalJoin) getEnforcedMergeJoin(prop *property.PhysicalProperty, sche
 		newNullEQ = nil
 		otherConditions = append(otherConditions, expression.ScalarFuncs2Exprs(p.EqualConditions)...)
 	}
+	if p.JoinType == FullOuterJoin && len(otherConditions) > 0 {
+		return nil
+	}
 	lProp := property.NewPhysicalProperty(property.RootTaskType, leftKeys, desc, math.MaxFloat64, true)
 	rProp := property.NewPhysicalProperty(property.RootTaskType, rightKeys, desc, math.MaxFloat64, true)
 	baseJoin := basePhysicalJoin{
@@ -435,6 +450,15 @@ func (p *LogicalJoin) getHashJoins(prop *property.PhysicalProperty) (joins []Phy
 				joins = append(joins, p.getHashJoin(prop, 0, false))
 			}
 		}
+	case FullOuterJoin:
+		// Both children are outer sides of the full outer join, the build side is always used as
+		// the outer side in the executor so that its unmatched rows can be tracked.
+		if !forceRightToBuild {
+			joins = append(joins, p.getHashJoin(prop, 1, true))
+		}
+		if !forceLeftToBuild {
+			joins = append(joins, p.getHashJoin(prop, 0, true))
+		}
 	case InnerJoin:
 		if ForcedHashLeftJoin4Test.Load() {
 			joins = append(joins, p.getHashJoin(prop, 1, false))
@@ -955,7 +979,7 @@ func (p *LogicalJoin) buildIndexJoinInner2IndexScan(
 	outerIdx int, avgInnerRowCnt float64) (joins []PhysicalPlan) {
 	ds := wrapper.ds
 	us := wrapper.us
-	helper, keyOff2IdxOff := p.getIndexJoinBuildHelper(ds, innerJoinKeys, func(path *util.AccessPath) bool { return !path.IsTablePath() }, outerJoinKeys)
+	helper, keyOff2IdxOff := p.getIndexJoinBuildHelper(ds, innerJoinKeys, func(path *util.AccessPath) bool { return !path.IsTablePath() && (path.Index == nil || !path.Index.Spatial) }, outerJoinKeys)
 	if helper == nil {
 		return nil
 	}
@@ -2589,7 +2613,7 @@ func (p *LogicalProjection) TryToGetChildProp(prop *property.PhysicalProperty) (
 	return newProp, true
 }
 
-// exhaustPhysicalPlans enumerate all the possible physical plan for expand operator (currently only mpp case is supported)
+// exhaustPhysicalPlans enumerate all the possible physical plan for expand operator.
 func (p *LogicalExpand) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]PhysicalPlan, bool, error) {
 	// under the mpp task type, if the sort item is not empty, refuse it, cause expanded data doesn't support any sort items.
 	if !prop.IsSortItemEmpty() {
@@ -2606,6 +2630,7 @@ func (p *LogicalExpand) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([
 	if prop.TaskTp == property.MppTaskType && prop.MPPPartitionTp != property.AnyType {
 		return nil, true, nil
 	}
+	expands := make([]PhysicalPlan, 0, 2)
 	// for property.RootTaskType and property.MppTaskType with no partition option, we can give an MPP Expand.
 	if p.SCtx().GetSessionVars().IsMPPAllowed() {
 		mppProp := prop.CloneEssentialFields()
@@ -2616,10 +2641,20 @@ func (p *LogicalExpand) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([
 			ExtraGroupingColNames: p.ExtraGroupingColNames,
 		}.Init(p.SCtx(), p.StatsInfo().ScaleByExpectCnt(prop.ExpectedCnt), p.SelectBlockOffset(), mppProp)
 		expand.SetSchema(p.Schema())
-		return []PhysicalPlan{expand}, true, nil
+		expands = append(expands, expand)
 	}
-	// if MPP switch is shutdown, nothing can be generated.
-	return nil, true, nil
+	// for property.RootTaskType, we can also give a TiDB Expand, which evaluates the level projections in the root.
+	if prop.TaskTp == property.RootTaskType {
+		rootProp := prop.CloneEssentialFields()
+		expand := PhysicalExpand{
+			GroupingSets:          p.rollupGroupingSets,
+			LevelExprs:            p.LevelExprs,
+			ExtraGroupingColNames: p.ExtraGroupingColNames,
+		}.Init(p.SCtx(), p.StatsInfo().ScaleByExpectCnt(prop.ExpectedCnt), p.SelectBlockOffset(), rootProp)
+		expand.SetSchema(p.Schema())
+		expands = append(expands, expand)
+	}
+	return expands, true, nil
 }
 
 func (p *LogicalProjection) exhaustPhysicalPlans(prop *property.PhysicalProperty) ([]PhysicalPlan, bool, error) {
@@ -2848,6 +2883,11 @@ func (lw *LogicalWindow) tryToGetMppWindows(prop *property.PhysicalProperty) []P
 		if !allSupported {
 			return nil
 		}
+		if lw.Frame != nil && (lw.Frame.Type == ast.Groups || lw.Frame.Exclude != ast.ExcludeNoOthers) {
+			lw.SCtx().GetSessionVars().RaiseWarningWhenMPPEnforced(
+				"MPP mode may be blocked because window function frame can't be pushed down, because TiFlash does not support groups frame type or frame exclusion yet.")
+			return nil
+		}
 		if lw.Frame != nil && lw.Frame.Type == ast.Ranges {
 			if _, err := expression.ExpressionsToPBList(lw.SCtx().GetSessionVars().StmtCtx, lw.Frame.Start.CalcFuncs, lw.SCtx().GetClient()); err != nil {
 				lw.SCtx().GetSessionVars().RaiseWarningWhenMPPEnforced(
@@ -108,6 +108,11 @@ func (p *PhysicalIndexScan) OperatorInfo(normalized bool) string {
 			}
 		}
 	}
+	if p.SpatialWindow != nil && !normalized {
+		buffer.WriteString("spatial window:")
+		buffer.WriteString(p.SpatialWindow.String())
+		buffer.WriteString(", ")
+	}
 	buffer.WriteString("keep order:")
 	buffer.WriteString(strconv.FormatBool(p.KeepOrder))
 	if p.Desc {
@@ -413,6 +418,16 @@ func (p *PhysicalTableDual) ExplainInfo() string {
 	return str.String()
 }
 
+// ExplainInfo implements Plan interface.
+func (p *PhysicalJSONTable) ExplainInfo() string {
+	var str strings.Builder
+	str.WriteString("json:")
+	str.WriteString(p.Expr.ExplainInfo())
+	str.WriteString(", path:")
+	str.WriteString(p.Root.Path.String())
+	return str.String()
+}
+
 // ExplainInfo implements Plan interface.
 func (p *PhysicalSort) ExplainInfo() string {
 	buffer := bytes.NewBufferString("")
@@ -817,15 +832,26 @@ func (p *PhysicalWindow) ExplainInfo() string {
 		if !isFirst {
 			buffer.WriteString(" ")
 		}
-		if p.Frame.Type == ast.Rows {
+		switch p.Frame.Type {
+		case ast.Rows:
 			buffer.WriteString("rows")
-		} else {
+		case ast.Groups:
+			buffer.WriteString("groups")
+		default:
 			buffer.WriteString("range")
 		}
 		buffer.WriteString(" between ")
 		p.formatFrameBound(buffer, p.Frame.Start)
 		buffer.WriteString(" and ")
 		p.formatFrameBound(buffer, p.Frame.End)
+		switch p.Frame.Exclude {
+		case ast.ExcludeCurrentRow:
+			buffer.WriteString(" exclude current row")
+		case ast.ExcludeGroup:
+			buffer.WriteString(" exclude group")
+		case ast.ExcludeTies:
+			buffer.WriteString(" exclude ties")
+		}
 	}
 	buffer.WriteString(")")
 	if p.TiFlashFineGrainedShuffleStreamCount > 0 {
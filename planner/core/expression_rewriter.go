@@ -859,7 +859,7 @@ func (er *expressionRewriter) handleExistSubquery(ctx context.Context, v *ast.Ex
 		semiJoinRewrite = false
 	}
 
-	if er.b.disableSubQueryPreprocessing || len(ExtractCorrelatedCols4LogicalPlan(np)) > 0 || hasCTEConsumerInSubPlan(np) {
+	if er.b.disableSubQueryPreprocessing || er.keepUncorrelatedSubquery() || len(ExtractCorrelatedCols4LogicalPlan(np)) > 0 || hasCTEConsumerInSubPlan(np) {
 		er.p, er.err = er.b.buildSemiApply(er.p, np, nil, er.asScalar, v.Not, semiJoinRewrite, noDecorrelate)
 		if er.err != nil || !er.asScalar {
 			return v, true
@@ -1086,7 +1086,7 @@ func (er *expressionRewriter) handleScalarSubquery(ctx context.Context, v *ast.S
 		noDecorrelate = false
 	}
 
-	if er.b.disableSubQueryPreprocessing || len(ExtractCorrelatedCols4LogicalPlan(np)) > 0 || hasCTEConsumerInSubPlan(np) {
+	if er.b.disableSubQueryPreprocessing || er.keepUncorrelatedSubquery() || len(ExtractCorrelatedCols4LogicalPlan(np)) > 0 || hasCTEConsumerInSubPlan(np) {
 		er.p = er.b.buildApplyWithJoinType(er.p, np, LeftOuterJoin, noDecorrelate)
 		if np.Schema().Len() > 1 {
 			newCols := make([]expression.Expression, 0, np.Schema().Len())
@@ -1426,6 +1426,23 @@ func (er *expressionRewriter) useCache() bool {
 	return er.sctx.GetSessionVars().StmtCtx.UseCache
 }
 
+// keepUncorrelatedSubquery checks whether an uncorrelated subquery should be kept in the plan as a join
+// instead of being evaluated in rewriting stage. When building a plan for the plan cache, the evaluated
+// result would be folded into the plan as a constant, so the plan couldn't be reused. Keeping the subquery
+// makes the plan independent of its result, and the subquery is executed again at each execution.
+// It's controlled by tidb_enable_plan_cache_for_uncorrelated_subquery because the subquery is executed
+// as a join, so the ranges built from its result are lost.
+func (er *expressionRewriter) keepUncorrelatedSubquery() bool {
+	sessVars := er.sctx.GetSessionVars()
+	if !er.useCache() || !sessVars.EnablePlanCacheForSubquery || !sessVars.EnablePlanCacheForUncorrelatedSubquery {
+		return false
+	}
+	// The mock plans used to rewrite expressions like INSERT VALUES and SET are dropped after rewriting,
+	// so the subquery must be evaluated there.
+	_, isDual := er.p.(*LogicalTableDual)
+	return er.p != nil && !isDual
+}
+
 func (er *expressionRewriter) rewriteVariable(v *ast.VariableExpr) {
 	stkLen := len(er.ctxStack)
 	name := strings.ToLower(v.Name)
@@ -157,6 +157,21 @@ func (p *LogicalTableDual) findBestTask(prop *property.PhysicalProperty, planCou
 	return &rootTask{p: dual, isEmpty: p.RowCount == 0}, 1, nil
 }
 
+func (p *LogicalJSONTable) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, opt *physicalOptimizeOp) (task, int64, error) {
+	if !prop.IsSortItemEmpty() || planCounter.Empty() {
+		return invalidTask, 0, nil
+	}
+	jt := PhysicalJSONTable{
+		Expr:   p.Expr,
+		Root:   p.Root,
+		AsName: p.AsName,
+	}.Init(p.SCtx(), p.StatsInfo(), p.SelectBlockOffset())
+	jt.SetSchema(p.schema)
+	planCounter.Dec(1)
+	opt.appendCandidate(p, jt, prop)
+	return &rootTask{p: jt}, 1, nil
+}
+
 func (p *LogicalShow) findBestTask(prop *property.PhysicalProperty, planCounter *PlanCounterTp, _ *physicalOptimizeOp) (task, int64, error) {
 	if !prop.IsSortItemEmpty() || planCounter.Empty() {
 		return invalidTask, 0, nil
@@ -2512,6 +2527,10 @@ func (ds *DataSource) getOriginalPhysicalIndexScan(prop *property.PhysicalProper
 	}.Init(ds.SCtx(), ds.SelectBlockOffset())
 	rowCount := path.CountAfterAccess
 	is.initSchema(append(path.FullIdxCols, ds.commonHandleCols...), !isSingleScan)
+	if idx.Spatial && path.FullIdxCols[0] != nil {
+		// The spatial functions can't be pushed down, so the window is extracted from all the conditions.
+		is.SpatialWindow = expression.ExtractSpatialWindow(ds.allConds, path.FullIdxCols[0])
+	}
 
 	// If (1) there exists an index whose selectivity is smaller than the threshold,
 	// and (2) there is Selection on the IndexScan, we don't use the ExpectedCnt to
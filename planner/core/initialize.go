@@ -191,6 +191,19 @@ func (p PhysicalTableDual) Init(ctx sessionctx.Context, stats *property.StatsInf
 	return &p
 }
 
+// Init initializes LogicalJSONTable.
+func (p LogicalJSONTable) Init(ctx sessionctx.Context, offset int) *LogicalJSONTable {
+	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	return &p
+}
+
+// Init initializes PhysicalJSONTable.
+func (p PhysicalJSONTable) Init(ctx sessionctx.Context, stats *property.StatsInfo, offset int) *PhysicalJSONTable {
+	p.basePhysicalPlan = newBasePhysicalPlan(ctx, plancodec.TypeJSONTable, &p, offset)
+	p.SetStats(stats)
+	return &p
+}
+
 // Init initializes LogicalMaxOneRow.
 func (p LogicalMaxOneRow) Init(ctx sessionctx.Context, offset int) *LogicalMaxOneRow {
 	p.baseLogicalPlan = newBaseLogicalPlan(ctx, plancodec.TypeMaxOneRow, &p, offset)
@@ -220,10 +220,13 @@ func (a *aggOrderByResolver) Leave(inNode ast.Node) (ast.Node, bool) {
 	return inNode, true
 }
 
-func (b *PlanBuilder) buildExpand(p LogicalPlan, gbyItems []expression.Expression) (LogicalPlan, []expression.Expression, error) {
+// maxCubeItems is the maximum number of the items in CUBE (...), each row is expanded into 2^n rows by it.
+const maxCubeItems = 12
+
+func (b *PlanBuilder) buildExpand(p LogicalPlan, gby *ast.GroupByClause, gbyItems []expression.Expression) (LogicalPlan, []expression.Expression, error) {
 	b.optFlag |= flagResolveExpand
 
-	// Rollup syntax require expand OP to do the data expansion, different data replica supply the different grouping layout.
+	// Rollup, cube and grouping sets syntax require expand OP to do the data expansion, different data replica supply the different grouping layout.
 	distinctGbyExprs, gbyExprsRefPos := expression.DeduplicateGbyExpression(b.ctx, gbyItems)
 	// build another projection below.
 	proj := LogicalProjection{Exprs: make([]expression.Expression, 0, p.Schema().Len()+len(distinctGbyExprs))}.Init(b.ctx, b.getSelectOffset())
@@ -267,8 +270,21 @@ func (b *PlanBuilder) buildExpand(p LogicalPlan, gbyItems []expression.Expressio
 	newGbyItems := expression.RestoreGbyExpression(distinctGbyCols, gbyExprsRefPos)
 
 	// build expand.
-	rollupGroupingSets := expression.RollupGroupingSets(newGbyItems)
+	var rollupGroupingSets expression.GroupingSets
+	switch {
+	case gby.Cube:
+		if len(newGbyItems) > maxCubeItems {
+			return nil, nil, ErrInvalidNumberOfArgs.GenWithStackByArgs("CUBE", maxCubeItems)
+		}
+		rollupGroupingSets = expression.CubeGroupingSets(newGbyItems)
+	case gby.GroupingSets != nil:
+		rollupGroupingSets = expression.ListGroupingSets(newGbyItems, gby.GroupingSets)
+	default:
+		rollupGroupingSets = expression.RollupGroupingSets(newGbyItems)
+	}
 	// eg: <a,b,c> with rollup => {},{a},{a,b},{a,b,c}
+	//     cube(a,b)           => {},{a},{b},{a,b}
+	//     grouping sets((a),(b,c)) => {a},{b,c}
 	// for every grouping set above, we should individually set those not-needed grouping-set col as null value.
 	// eg: let's say base schema is <a,b,c,d>, d is unrelated col, keep it real in every grouping set projection.
 	// 		for grouping set {a,b,c}, project it as: [a,    b,    c,    d,   gid]
@@ -518,7 +534,7 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 	case *ast.Join:
 		return b.buildJoin(ctx, x)
 	case *ast.TableSource:
-		var isTableName bool
+		var isTableName, isJSONTable bool
 		switch v := x.Source.(type) {
 		case *ast.SelectStmt:
 			ci := b.prepareCTECheckForSubQuery()
@@ -531,6 +547,9 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 		case *ast.TableName:
 			p, err = b.buildDataSource(ctx, v, &x.AsName)
 			isTableName = true
+		case *ast.JSONTable:
+			p, err = b.buildJSONTable(ctx, v, x.AsName)
+			isJSONTable = true
 		default:
 			err = ErrUnsupportedType.GenWithStackByArgs(v)
 		}
@@ -546,8 +565,8 @@ func (b *PlanBuilder) buildResultSetNode(ctx context.Context, node ast.ResultSet
 				name.TblName = x.AsName
 			}
 		}
-		// `TableName` is not a select block, so we do not need to handle it.
-		if plannerSelectBlockAsName := *(b.ctx.GetSessionVars().PlannerSelectBlockAsName.Load()); len(plannerSelectBlockAsName) > 0 && !isTableName {
+		// `TableName` and `JSONTable` are not select blocks, so we do not need to handle them.
+		if plannerSelectBlockAsName := *(b.ctx.GetSessionVars().PlannerSelectBlockAsName.Load()); len(plannerSelectBlockAsName) > 0 && !isTableName && !isJSONTable {
 			plannerSelectBlockAsName[p.SelectBlockOffset()] = ast.HintTable{DBName: p.OutputNames()[0].DBName, TableName: p.OutputNames()[0].TblName}
 		}
 		// Duplicate column name in one table is not allowed.
@@ -595,6 +614,8 @@ func (p *LogicalJoin) pushDownConstExpr(expr expression.Expression, leftCond []e
 	case SemiJoin, InnerJoin:
 		leftCond = append(leftCond, expr)
 		rightCond = append(rightCond, expr)
+	case FullOuterJoin:
+		p.OtherConditions = append(p.OtherConditions, expr)
 	case AntiSemiJoin:
 		if filterCond {
 			leftCond = append(leftCond, expr)
@@ -1025,10 +1046,25 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		return nil, err
 	}
 
+	// The right side may refer to the columns of the left side, e.g. JSON_TABLE(t.a, ...) or
+	// a LATERAL derived table, these columns are resolved as correlated columns through the outer schema.
+	canBeLateral := isLateralTableSource(joinNode.Right)
+	if canBeLateral {
+		b.outerSchemas = append(b.outerSchemas, leftPlan.Schema())
+		b.outerNames = append(b.outerNames, leftPlan.OutputNames())
+	}
 	rightPlan, err := b.buildResultSetNode(ctx, joinNode.Right, false)
+	if canBeLateral {
+		b.outerSchemas = b.outerSchemas[0 : len(b.outerSchemas)-1]
+		b.outerNames = b.outerNames[0 : len(b.outerNames)-1]
+	}
 	if err != nil {
 		return nil, err
 	}
+	isLateral := canBeLateral && len(extractCorColumnsBySchema4LogicalPlan(rightPlan, leftPlan.Schema())) > 0
+	if isLateral && (joinNode.Tp == ast.RightJoin || joinNode.Tp == ast.FullJoin) {
+		return nil, ErrTFForbiddenJoinType.GenWithStackByArgs(joinNode.Right.(*ast.TableSource).AsName.O)
+	}
 
 	// The recursive part in CTE must not be on the right side of a LEFT JOIN.
 	if lc, ok := rightPlan.(*LogicalCTETable); ok && joinNode.Tp == ast.LeftJoin {
@@ -1039,7 +1075,19 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 	handleMap2 := b.handleHelper.popMap()
 	b.handleHelper.mergeAndPush(handleMap1, handleMap2)
 
-	joinPlan := LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+	var (
+		joinPlan   *LogicalJoin
+		resultPlan LogicalPlan
+	)
+	if isLateral {
+		// The right side is evaluated once for every row of the left side, so it's built as an Apply.
+		b.optFlag = b.optFlag | flagBuildKeyInfo | flagDecorrelate
+		ap := LogicalApply{}.Init(b.ctx, b.getSelectOffset())
+		joinPlan, resultPlan = &ap.LogicalJoin, ap
+	} else {
+		joinPlan = LogicalJoin{StraightJoin: joinNode.StraightJoin || b.inStraightJoin}.Init(b.ctx, b.getSelectOffset())
+		resultPlan = joinPlan
+	}
 	joinPlan.SetChildren(leftPlan, rightPlan)
 	joinPlan.SetSchema(expression.MergeSchema(leftPlan.Schema(), rightPlan.Schema()))
 	joinPlan.names = make([]*types.FieldName, leftPlan.Schema().Len()+rightPlan.Schema().Len())
@@ -1058,6 +1106,9 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		b.optFlag = b.optFlag | flagEliminateOuterJoin
 		joinPlan.JoinType = RightOuterJoin
 		resetNotNullFlag(joinPlan.schema, 0, leftPlan.Schema().Len())
+	case ast.FullJoin:
+		joinPlan.JoinType = FullOuterJoin
+		resetNotNullFlag(joinPlan.schema, 0, joinPlan.schema.Len())
 	default:
 		joinPlan.JoinType = InnerJoin
 	}
@@ -1092,6 +1143,8 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 	// Clear NotNull flag for the inner side schema if it's an outer join.
 	if joinNode.Tp == ast.LeftJoin || joinNode.Tp == ast.RightJoin {
 		resetNotNullFlag(joinPlan.fullSchema, lFullSchema.Len(), joinPlan.fullSchema.Len())
+	} else if joinNode.Tp == ast.FullJoin {
+		resetNotNullFlag(joinPlan.fullSchema, 0, joinPlan.fullSchema.Len())
 	}
 
 	// Merge sub-plan's fullNames into this join plan, similar to the fullSchema logic above.
@@ -1115,6 +1168,9 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 	// that names all columns that exist in both tables.
 	//
 	// See https://dev.mysql.com/doc/refman/5.7/en/join.html for more detail.
+	if joinNode.Tp == ast.FullJoin && (joinNode.NaturalJoin || joinNode.Using != nil) {
+		return nil, ErrNotSupportedYet.GenWithStackByArgs("NATURAL or USING clause with FULL JOIN")
+	}
 	if joinNode.NaturalJoin {
 		err = b.buildNaturalJoin(joinPlan, leftPlan, rightPlan, joinNode)
 		if err != nil {
@@ -1127,11 +1183,11 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		}
 	} else if joinNode.On != nil {
 		b.curClause = onClause
-		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, joinPlan, nil, false)
+		onExpr, newPlan, err := b.rewrite(ctx, joinNode.On.Expr, resultPlan, nil, false)
 		if err != nil {
 			return nil, err
 		}
-		if newPlan != joinPlan {
+		if newPlan != resultPlan {
 			return nil, errors.New("ON condition doesn't support subqueries yet")
 		}
 		onCondition := expression.SplitCNFItems(onExpr)
@@ -1139,7 +1195,7 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		// possible decorrelate optimizations. The ON clause is actually treated as a WHERE clause now.
 		if joinPlan.JoinType == InnerJoin {
 			sel := LogicalSelection{Conditions: onCondition}.Init(b.ctx, b.getSelectOffset())
-			sel.SetChildren(joinPlan)
+			sel.SetChildren(resultPlan)
 			return sel, nil
 		}
 		joinPlan.AttachOnConds(onCondition)
@@ -1149,7 +1205,153 @@ func (b *PlanBuilder) buildJoin(ctx context.Context, joinNode *ast.Join) (Logica
 		joinPlan.cartesianJoin = true
 	}
 
-	return joinPlan, nil
+	return resultPlan, nil
+}
+
+// isLateralTableSource checks whether the table source can refer to the columns of the
+// preceding tables in the FROM clause.
+func isLateralTableSource(node ast.ResultSetNode) bool {
+	ts, ok := node.(*ast.TableSource)
+	if !ok {
+		return false
+	}
+	if ts.Lateral {
+		return true
+	}
+	_, ok = ts.Source.(*ast.JSONTable)
+	return ok
+}
+
+// buildJSONTable builds the JSON_TABLE table function. The columns of the preceding tables
+// referred by the JSON document are resolved as correlated columns.
+func (b *PlanBuilder) buildJSONTable(ctx context.Context, jt *ast.JSONTable, asName model.CIStr) (LogicalPlan, error) {
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	mockTablePlan.SetSchema(expression.NewSchema())
+	b.curClause = tableFunctionArgument
+	expr, np, err := b.rewrite(ctx, jt.Expr, mockTablePlan, nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if np != mockTablePlan {
+		return nil, errors.New("JSON_TABLE doesn't support subqueries yet")
+	}
+
+	p := LogicalJSONTable{
+		Expr:   expression.WrapWithCastAsJSON(b.ctx, expr),
+		AsName: asName,
+	}.Init(b.ctx, b.getSelectOffset())
+	schema := expression.NewSchema()
+	names := make(types.NameSlice, 0, len(jt.Columns))
+	p.Root, err = b.buildJSONTablePath(jt.Path, jt.Columns, asName, schema, &names)
+	if err != nil {
+		return nil, err
+	}
+	p.setSchemaAndNames(schema, names)
+	// JSON_TABLE has no handle columns.
+	b.handleHelper.pushMap(nil)
+	return p, nil
+}
+
+// buildJSONTablePath builds the row path or a NESTED PATH of JSON_TABLE, and appends its
+// columns to the schema in the order they are defined.
+func (b *PlanBuilder) buildJSONTablePath(path string, cols []*ast.JSONTableColumn, asName model.CIStr,
+	schema *expression.Schema, names *types.NameSlice) (*JSONTablePath, error) {
+	pathExpr, err := types.ParseJSONPathExpr(path)
+	if err != nil {
+		return nil, err
+	}
+	jtPath := &JSONTablePath{Path: pathExpr}
+	for _, col := range cols {
+		if col.Tp == ast.JSONTableColumnNested {
+			nested, err := b.buildJSONTablePath(col.Path, col.NestedColumns, asName, schema, names)
+			if err != nil {
+				return nil, err
+			}
+			jtPath.Nested = append(jtPath.Nested, nested)
+			continue
+		}
+		jtCol := &JSONTableColumn{Tp: col.Tp, Name: col.Name, Offset: schema.Len()}
+		var ft *types.FieldType
+		if col.Tp == ast.JSONTableColumnOrdinality {
+			ft = types.NewFieldType(mysql.TypeLong)
+			ft.AddFlag(mysql.UnsignedFlag)
+			flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(mysql.TypeLong)
+			ft.SetFlen(flen)
+			ft.SetDecimal(decimal)
+			types.SetBinChsClnFlag(ft)
+		} else {
+			if jtCol.Path, err = types.ParseJSONPathExpr(col.Path); err != nil {
+				return nil, err
+			}
+			if jtCol.OnEmpty, err = buildJSONTableOnResponse(col.OnEmpty); err != nil {
+				return nil, err
+			}
+			if jtCol.OnError, err = buildJSONTableOnResponse(col.OnError); err != nil {
+				return nil, err
+			}
+			ft = b.buildJSONTableColumnType(col.FieldType)
+		}
+		schema.Append(&expression.Column{
+			UniqueID: b.ctx.GetSessionVars().AllocPlanColumnID(),
+			RetType:  ft,
+		})
+		*names = append(*names, &types.FieldName{
+			TblName:     asName,
+			ColName:     col.Name,
+			OrigTblName: asName,
+			OrigColName: col.Name,
+		})
+		jtPath.Columns = append(jtPath.Columns, jtCol)
+	}
+	return jtPath, nil
+}
+
+func buildJSONTableOnResponse(resp *ast.JSONTableOnResponse) (*JSONTableOnResponse, error) {
+	if resp == nil {
+		return nil, nil
+	}
+	res := &JSONTableOnResponse{Tp: resp.Tp}
+	if resp.Tp == ast.JSONTableOnResponseDefault {
+		var err error
+		res.Default, err = types.ParseBinaryJSONFromString(resp.Default)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// buildJSONTableColumnType fills the default length, decimal, charset and collation of
+// a JSON_TABLE column type.
+func (b *PlanBuilder) buildJSONTableColumnType(tp *types.FieldType) *types.FieldType {
+	ft := tp.Clone()
+	flen, decimal := mysql.GetDefaultFieldLengthAndDecimal(ft.GetType())
+	if ft.GetFlen() == types.UnspecifiedLength {
+		ft.SetFlen(flen)
+	}
+	if ft.GetDecimal() == types.UnspecifiedLength {
+		ft.SetDecimal(decimal)
+	}
+	switch {
+	case ft.GetType() == mysql.TypeJSON:
+		ft.SetCharset(mysql.DefaultCharset)
+		ft.SetCollate(mysql.DefaultCollationName)
+		ft.AddFlag(mysql.BinaryFlag)
+	case ft.EvalType() == types.ETString:
+		if ft.GetCharset() == "" {
+			chs, coll := b.ctx.GetSessionVars().GetCharsetInfo()
+			ft.SetCharset(chs)
+			ft.SetCollate(coll)
+		} else if ft.GetCollate() == "" {
+			coll, err := charset.GetDefaultCollation(ft.GetCharset())
+			if err == nil {
+				ft.SetCollate(coll)
+			}
+		}
+	default:
+		types.SetBinChsClnFlag(ft)
+	}
+	return ft
 }
 
 // buildUsingClause eliminate the redundant columns and ordering columns based
@@ -3696,7 +3898,7 @@ func (*PlanBuilder) checkOnlyFullGroupByWithGroupClause(p LogicalPlan, sel *ast.
 		}
 		switch errExprLoc.Loc {
 		case ErrExprInSelect:
-			if sel.GroupBy.Rollup {
+			if sel.GroupBy.Rollup || sel.GroupBy.Cube || sel.GroupBy.GroupingSets != nil {
 				return ErrFieldInGroupingNotGroupBy.GenWithStackByArgs(strconv.Itoa(errExprLoc.Offset + 1))
 			}
 			return ErrFieldNotInGroupBy.GenWithStackByArgs(errExprLoc.Offset+1, errExprLoc.Loc, name.DBName.O+"."+name.TblName.O+"."+name.OrigColName.O)
@@ -3895,7 +4097,7 @@ func (b *PlanBuilder) resolveGbyExprs(ctx context.Context, p LogicalPlan, gby *a
 		exprs = append(exprs, expr)
 		p = np
 	}
-	return p, exprs, gby.Rollup, nil
+	return p, exprs, gby.Rollup || gby.Cube || gby.GroupingSets != nil, nil
 }
 
 func (*PlanBuilder) unfoldWildStar(p LogicalPlan, selectFields []*ast.SelectField) (resultList []*ast.SelectField, err error) {
@@ -4496,9 +4698,9 @@ func (b *PlanBuilder) buildSelect(ctx context.Context, sel *ast.SelectStmt) (p L
 		}
 	}
 	if needBuildAgg {
-		// if rollup syntax is specified, Expand OP is required to replicate the data to feed different grouping layout.
+		// if rollup, cube or grouping sets syntax is specified, Expand OP is required to replicate the data to feed different grouping layout.
 		if rollup {
-			p, gbyCols, err = b.buildExpand(p, gbyCols)
+			p, gbyCols, err = b.buildExpand(p, sel.GroupBy, gbyCols)
 			if err != nil {
 				return nil, err
 			}
@@ -5552,6 +5754,10 @@ func (b *PlanBuilder) buildMemTable(_ context.Context, dbName model.CIStr, table
 			p.Extractor = &TiKVRegionStatusExtractor{tablesID: make([]int64, 0)}
 		}
 	}
+	// The tables with custom engines receive the predicates and limit as hints
+	if tableInfo.Engine != "" {
+		p.Extractor = &CustomEngineTableExtractor{}
+	}
 	return p, nil
 }
 
@@ -5594,6 +5800,7 @@ func (b *PlanBuilder) BuildDataSourceFromView(ctx context.Context, dbName model.
 	if err != nil {
 		return nil, err
 	}
+	convertExtensionAggFunc(selectNode)
 	originalVisitInfo := b.visitInfo
 	b.visitInfo = make([]visitInfo, 0)
 
@@ -6175,7 +6382,7 @@ func (b *PlanBuilder) buildUpdateLists(ctx context.Context, tableList []*ast.Tab
 		foundListItem := false
 		for _, tl := range tableList {
 			if (tl.Schema.L == "" || tl.Schema.L == name.DBName.L) && (tl.Name.L == name.TblName.L) {
-				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() {
+				if isCTE(tl) || tl.TableInfo.IsView() || tl.TableInfo.IsSequence() || tl.TableInfo.Engine != "" {
 					return nil, nil, false, ErrNonUpdatableTable.GenWithStackByArgs(name.TblName.O, "UPDATE")
 				}
 				foundListItem = true
@@ -6497,6 +6704,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 			if tn.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", tn.Name.O)
 			}
+			if tn.TableInfo.Engine != "" {
+				return nil, ErrNonUpdatableTable.GenWithStackByArgs(tn.Name.O, "DELETE")
+			}
 			if sessionVars.User != nil {
 				authErr = ErrTableaccessDenied.FastGenByArgs("DELETE", sessionVars.User.AuthUsername, sessionVars.User.AuthHostname, tb.Name.L)
 			}
@@ -6516,6 +6726,9 @@ func (b *PlanBuilder) buildDelete(ctx context.Context, ds *ast.DeleteStmt) (Plan
 			if v.TableInfo.IsSequence() {
 				return nil, errors.Errorf("delete sequence %s is not supported now", v.Name.O)
 			}
+			if v.TableInfo.Engine != "" {
+				return nil, ErrNonUpdatableTable.GenWithStackByArgs(v.Name.O, "DELETE")
+			}
 			dbName := v.Schema.L
 			if dbName == "" {
 				dbName = b.ctx.GetSessionVars().CurrentDB
@@ -6739,7 +6952,7 @@ func (b *PlanBuilder) buildByItemsForWindow(
 }
 
 // buildWindowFunctionFrameBound builds the bounds of window function frames.
-// For type `Rows`, the bound expr must be an unsigned integer.
+// For type `Rows` and `Groups`, the bound expr must be an unsigned integer.
 // For type `Range`, the bound expr must be temporal or numeric types.
 func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast.WindowSpec, orderByItems []property.SortItem, boundClause *ast.FrameBound) (*FrameBound, error) {
 	frameType := spec.Frame.Type
@@ -6748,7 +6961,7 @@ func (b *PlanBuilder) buildWindowFunctionFrameBound(_ context.Context, spec *ast
 		return bound, nil
 	}
 
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Type == ast.CurrentRow {
 			return bound, nil
 		}
@@ -6836,7 +7049,7 @@ func (b *PlanBuilder) buildWindowFunctionFrame(ctx context.Context, spec *ast.Wi
 	if frameClause == nil {
 		return nil, nil
 	}
-	frame := &WindowFrame{Type: frameClause.Type}
+	frame := &WindowFrame{Type: frameClause.Type, Exclude: frameClause.Exclude}
 	var err error
 	frame.Start, err = b.buildWindowFunctionFrameBound(ctx, spec, orderByItems, &frameClause.Extent.Start)
 	if err != nil {
@@ -7030,9 +7243,6 @@ func (b *PlanBuilder) checkOriginWindowSpec(spec *ast.WindowSpec, orderByItems [
 	if spec.Frame == nil {
 		return nil
 	}
-	if spec.Frame.Type == ast.Groups {
-		return ErrNotSupportedYet.GenWithStackByArgs("GROUPS")
-	}
 	start, end := spec.Frame.Extent.Start, spec.Frame.Extent.End
 	if start.Type == ast.Following && start.UnBounded {
 		return ErrWindowFrameStartIllegal.GenWithStackByArgs(getWindowName(spec.Name.O))
@@ -7064,7 +7274,7 @@ func (b *PlanBuilder) checkOriginWindowFrameBound(bound *ast.FrameBound, spec *a
 	}
 
 	frameType := spec.Frame.Type
-	if frameType == ast.Rows {
+	if frameType == ast.Rows || frameType == ast.Groups {
 		if bound.Unit != ast.TimeUnitInvalid {
 			return ErrWindowRowsIntervalUse.GenWithStackByArgs(getWindowName(spec.Name.O))
 		}
@@ -7072,6 +7282,10 @@ func (b *PlanBuilder) checkOriginWindowFrameBound(bound *ast.FrameBound, spec *a
 		if isNull || !isExpectedType {
 			return ErrWindowFrameIllegal.GenWithStackByArgs(getWindowName(spec.Name.O))
 		}
+		// The peer groups of a GROUPS frame are decided by the ORDER BY clause.
+		if frameType == ast.Groups && len(orderByItems) == 0 {
+			return ErrWindowGroupsFrameOrderBy.GenWithStackByArgs(getWindowName(spec.Name.O))
+		}
 		return nil
 	}
 
@@ -7119,8 +7333,9 @@ func (b *PlanBuilder) handleDefaultFrame(spec *ast.WindowSpec, windowFuncName st
 		}
 		return &newSpec, true
 	}
-	// "RANGE/ROWS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING" is equivalent to empty frame.
-	if needFrame && spec.Frame != nil &&
+	// "RANGE/ROWS/GROUPS BETWEEN UNBOUNDED PRECEDING AND UNBOUNDED FOLLOWING" is equivalent to empty frame,
+	// unless some rows are excluded from it.
+	if needFrame && spec.Frame != nil && spec.Frame.Exclude == ast.ExcludeNoOthers &&
 		spec.Frame.Extent.Start.UnBounded && spec.Frame.Extent.End.UnBounded {
 		newSpec := *spec
 		newSpec.Frame = nil
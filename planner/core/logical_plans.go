@@ -51,6 +51,7 @@ var (
 	_ LogicalPlan = &LogicalApply{}
 	_ LogicalPlan = &LogicalMaxOneRow{}
 	_ LogicalPlan = &LogicalTableDual{}
+	_ LogicalPlan = &LogicalJSONTable{}
 	_ LogicalPlan = &DataSource{}
 	_ LogicalPlan = &TiKVSingleGather{}
 	_ LogicalPlan = &LogicalTableScan{}
@@ -63,7 +64,7 @@ var (
 	_ LogicalPlan = &LogicalExpand{}
 )
 
-// JoinType contains CrossJoin, InnerJoin, LeftOuterJoin, RightOuterJoin, SemiJoin, AntiJoin.
+// JoinType contains CrossJoin, InnerJoin, LeftOuterJoin, RightOuterJoin, FullOuterJoin, SemiJoin, AntiJoin.
 type JoinType int
 
 const (
@@ -81,11 +82,13 @@ const (
 	LeftOuterSemiJoin
 	// AntiLeftOuterSemiJoin means if row a in table A matches some rows in B, output (a, false), otherwise, output (a, true).
 	AntiLeftOuterSemiJoin
+	// FullOuterJoin means full join.
+	FullOuterJoin
 )
 
 // IsOuterJoin returns if this joiner is an outer joiner
 func (tp JoinType) IsOuterJoin() bool {
-	return tp == LeftOuterJoin || tp == RightOuterJoin ||
+	return tp == LeftOuterJoin || tp == RightOuterJoin || tp == FullOuterJoin ||
 		tp == LeftOuterSemiJoin || tp == AntiLeftOuterSemiJoin
 }
 
@@ -111,6 +114,8 @@ func (tp JoinType) String() string {
 		return "left outer semi join"
 	case AntiLeftOuterSemiJoin:
 		return "anti left outer semi join"
+	case FullOuterJoin:
+		return "full outer join"
 	}
 	return "unsupported join type"
 }
@@ -523,6 +528,13 @@ func (p *LogicalJoin) columnSubstituteAll(schema *expression.Schema, exprs []exp
 // `OtherConditions` by the result of extract.
 func (p *LogicalJoin) AttachOnConds(onConds []expression.Expression) {
 	eq, left, right, other := p.extractOnCondition(onConds, false, false)
+	if p.JoinType == FullOuterJoin {
+		// Neither side of the full outer join can be filtered by the join conditions, so
+		// they're all evaluated on the joined rows.
+		other = append(other, left...)
+		other = append(other, right...)
+		left, right = nil, nil
+	}
 	p.AppendJoinConds(eq, left, right, other)
 }
 
@@ -1424,6 +1436,48 @@ type LogicalTableDual struct {
 	RowCount int
 }
 
+// JSONTableOnResponse is the ON EMPTY or ON ERROR clause of a JSON_TABLE column.
+type JSONTableOnResponse struct {
+	Tp ast.JSONTableOnResponseType
+	// Default is the value of `DEFAULT json_string`.
+	Default types.BinaryJSON
+}
+
+// JSONTableColumn is a PATH, EXISTS PATH or FOR ORDINALITY column of JSON_TABLE.
+type JSONTableColumn struct {
+	Tp   ast.JSONTableColumnType
+	Name model.CIStr
+	// Offset is the offset of the column in the schema of JSON_TABLE.
+	Offset  int
+	Path    types.JSONPathExpression
+	OnEmpty *JSONTableOnResponse
+	OnError *JSONTableOnResponse
+}
+
+// JSONTablePath is the row path of JSON_TABLE or a NESTED PATH. Every value matched by Path
+// produces the Columns, and the rows of the sibling Nested paths are combined in a union.
+type JSONTablePath struct {
+	Path    types.JSONPathExpression
+	Columns []*JSONTableColumn
+	Nested  []*JSONTablePath
+}
+
+// LogicalJSONTable represents the JSON_TABLE table function.
+type LogicalJSONTable struct {
+	logicalSchemaProducer
+
+	// Expr is the JSON document. It contains correlated columns when it refers to
+	// the preceding tables, and the JSON_TABLE is built as the inner side of an Apply.
+	Expr   expression.Expression
+	Root   *JSONTablePath
+	AsName model.CIStr
+}
+
+// ExtractCorrelatedCols implements LogicalPlan interface.
+func (p *LogicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
 // LogicalMemTable represents a memory table or virtual table
 // Some memory tables wants to take the ownership of some predications
 // e.g
@@ -1644,7 +1698,7 @@ func (ds *DataSource) Convert2Gathers() (gathers []LogicalPlan) {
 	tg := ds.buildTableGather()
 	gathers = append(gathers, tg)
 	for _, path := range ds.possibleAccessPaths {
-		if !path.IsIntHandlePath {
+		if !path.IsIntHandlePath && !path.Index.Spatial {
 			path.FullIdxCols, path.FullIdxColLens = expression.IndexInfo2Cols(ds.Columns, ds.schema.Columns, path.Index)
 			path.IdxCols, path.IdxColLens = expression.IndexInfo2PrefixCols(ds.Columns, ds.schema.Columns, path.Index)
 			// If index columns can cover all of the needed columns, we can use a IndexGather + IndexScan.
@@ -1812,6 +1866,13 @@ func (ds *DataSource) fillIndexPath(path *util.AccessPath, conds []expression.Ex
 	path.CountAfterAccess = float64(ds.statisticTable.RealtimeCount)
 	path.IdxCols, path.IdxColLens = expression.IndexInfo2PrefixCols(ds.Columns, ds.schema.Columns, path.Index)
 	path.FullIdxCols, path.FullIdxColLens = expression.IndexInfo2Cols(ds.Columns, ds.schema.Columns, path.Index)
+	if path.Index.Spatial {
+		// The key of a spatial index holds the MBR of the geometry instead of the geometry itself,
+		// so the index is always fully scanned and filtered by the MBR in the executor.
+		path.IdxCols, path.IdxColLens = nil, nil
+		path.TableFilters = conds
+		return nil
+	}
 	if !path.Index.Unique && !path.Index.Primary && len(path.Index.Columns) == len(path.IdxCols) {
 		handleCol := ds.getPKIsHandleCol()
 		if handleCol != nil && !mysql.HasUnsignedFlag(handleCol.RetType.GetFlag()) {
@@ -1863,9 +1924,12 @@ func (ds *DataSource) deriveIndexPathStats(path *util.AccessPath, _ []expression
 			}
 		}
 	}
-	var indexFilters []expression.Expression
-	indexFilters, path.TableFilters = ds.splitIndexFilterConditions(path.TableFilters, path.FullIdxCols, path.FullIdxColLens)
-	path.IndexFilters = append(path.IndexFilters, indexFilters...)
+	// The geometry can't be read from a spatial index, so there are no index filters.
+	if !path.Index.Spatial {
+		var indexFilters []expression.Expression
+		indexFilters, path.TableFilters = ds.splitIndexFilterConditions(path.TableFilters, path.FullIdxCols, path.FullIdxColLens)
+		path.IndexFilters = append(path.IndexFilters, indexFilters...)
+	}
 	// If the `CountAfterAccess` is less than `stats.RowCount`, there must be some inconsistent stats info.
 	// We prefer the `stats.RowCount` because it could use more stats info to calculate the selectivity.
 	if path.CountAfterAccess < ds.StatsInfo().RowCount && !isIm {
@@ -2012,6 +2076,8 @@ type WindowFrame struct {
 	Type  ast.FrameType
 	Start *FrameBound
 	End   *FrameBound
+	// Exclude removes the current row or its peers from the frame.
+	Exclude ast.FrameExclusion
 }
 
 // Clone copies a window frame totally.
@@ -2108,6 +2174,7 @@ func (p *LogicalWindow) EqualFrame(ctx sessionctx.Context, newWindow *LogicalWin
 		return true
 	}
 	if p.Frame.Type != newWindow.Frame.Type ||
+		p.Frame.Exclude != newWindow.Frame.Exclude ||
 		p.Frame.Start.Type != newWindow.Frame.Start.Type ||
 		p.Frame.Start.UnBounded != newWindow.Frame.Start.UnBounded ||
 		p.Frame.Start.Num != newWindow.Frame.Start.Num ||
@@ -2214,6 +2281,8 @@ type ShowContents struct {
 	Tp                ast.ShowStmtType // Databases/Tables/Columns/....
 	DBName            string
 	Table             *ast.TableName  // Used for showing columns.
+	Procedure         *ast.TableName  // Used for showing create procedure.
+	Event             *ast.TableName  // Used for showing create event.
 	Partition         model.CIStr     // Use for showing partition
 	Column            *ast.ColumnName // Used for `desc table column`.
 	IndexName         model.CIStr
@@ -26,6 +26,7 @@ import (
 
 	"github.com/pingcap/kvproto/pkg/coprocessor"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/extension"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/ast"
@@ -1677,3 +1678,111 @@ func (e *TiKVRegionStatusExtractor) explainInfo(_ *PhysicalMemTable) string {
 func (e *TiKVRegionStatusExtractor) GetTablesID() []int64 {
 	return e.tablesID
 }
+
+// CustomEngineTableExtractor is used to extract the predicates of the tables with custom engines provided by extensions.
+// The extracted filters and limit are passed to the table source as hints, so all the predicates are still remained.
+type CustomEngineTableExtractor struct {
+	extractHelper
+
+	// Filters are the predicates in the form of `column op constant`
+	Filters []extension.TableFilter
+	// Limit is the max count of rows needed, 0 means no limit
+	Limit uint64
+}
+
+var customEngineTableFilterOps = map[string]extension.TableFilterOp{
+	ast.EQ: extension.TableFilterEQ,
+	ast.NE: extension.TableFilterNE,
+	ast.LT: extension.TableFilterLT,
+	ast.LE: extension.TableFilterLE,
+	ast.GT: extension.TableFilterGT,
+	ast.GE: extension.TableFilterGE,
+	ast.In: extension.TableFilterIn,
+}
+
+// reversedTableFilterOps are used for the predicates in the form of `constant op column`
+var reversedTableFilterOps = map[extension.TableFilterOp]extension.TableFilterOp{
+	extension.TableFilterLT: extension.TableFilterGT,
+	extension.TableFilterLE: extension.TableFilterGE,
+	extension.TableFilterGT: extension.TableFilterLT,
+	extension.TableFilterGE: extension.TableFilterLE,
+}
+
+// Extract implements the MemTablePredicateExtractor Extract interface
+func (e *CustomEngineTableExtractor) Extract(_ sessionctx.Context,
+	schema *expression.Schema,
+	names []*types.FieldName,
+	predicates []expression.Expression,
+) []expression.Expression {
+	cols := make(map[int64]*types.FieldName, len(names))
+	for i, name := range names {
+		cols[schema.Columns[i].UniqueID] = name
+	}
+
+	for _, expr := range predicates {
+		fn, ok := expr.(*expression.ScalarFunction)
+		if !ok {
+			continue
+		}
+
+		op, ok := customEngineTableFilterOps[fn.FuncName.L]
+		if !ok {
+			continue
+		}
+
+		var colName string
+		var values []types.Datum
+		if op == extension.TableFilterIn {
+			colName, values = e.extractColInConsExpr(cols, fn)
+		} else {
+			colName, values = e.extractColBinaryOpConsExpr(cols, fn)
+			if _, isCol := fn.GetArgs()[0].(*expression.Column); !isCol {
+				if reversed, ok := reversedTableFilterOps[op]; ok {
+					op = reversed
+				}
+			}
+		}
+
+		if colName == "" {
+			continue
+		}
+
+		e.Filters = append(e.Filters, extension.TableFilter{
+			Column: colName,
+			Op:     op,
+			Values: values,
+		})
+	}
+	return predicates
+}
+
+func (e *CustomEngineTableExtractor) explainInfo(_ *PhysicalMemTable) string {
+	r := new(bytes.Buffer)
+	if len(e.Filters) > 0 {
+		r.WriteString("filters:[")
+		for i, filter := range e.Filters {
+			if i > 0 {
+				r.WriteString(", ")
+			}
+			fmt.Fprintf(r, "%s(%s", filter.Op, filter.Column)
+			for _, v := range filter.Values {
+				s, err := v.ToString()
+				if err != nil {
+					s = "?"
+				}
+				fmt.Fprintf(r, ", %s", s)
+			}
+			r.WriteString(")")
+		}
+		r.WriteString("], ")
+	}
+	if e.Limit > 0 {
+		fmt.Fprintf(r, "limit:%d, ", e.Limit)
+	}
+	// remove the last ", " in the message info
+	s := r.String()
+	if len(s) > 2 {
+		return s[:len(s)-2]
+	}
+	return s
+}
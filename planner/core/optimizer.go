@@ -188,6 +188,23 @@ func CheckPrivilege(activeRoles []*auth.RoleIdentity, pm privilege.Manager, vs [
 	return nil
 }
 
+// CheckPrivilegeWithUser checks the privilege for a specific user rather than the current user, it's used
+// by the objects running with the privileges of their definer. The dynamic privileges are not granted to them.
+func CheckPrivilegeWithUser(pm privilege.Manager, user *auth.UserIdentity, vs []visitInfo) error {
+	for _, v := range vs {
+		if v.privilege == mysql.ExtendedPriv {
+			return ErrSpecificAccessDenied.GenWithStackByArgs(v.dynamicPriv)
+		}
+		if !pm.RequestVerificationWithUser(v.db, v.table, v.column, v.privilege, user) {
+			if v.err == nil {
+				return ErrPrivilegeCheckFail.GenWithStackByArgs(v.privilege.String())
+			}
+			return v.err
+		}
+	}
+	return nil
+}
+
 // VisitInfo4PrivCheck generates privilege check infos because privilege check of local temporary tables is different
 // with normal tables. `CREATE` statement needs `CREATE TEMPORARY TABLE` privilege from the database, and subsequent
 // statements do not need any privileges.
@@ -1279,7 +1296,7 @@ func transformPhysicalPlan(p PhysicalPlan, f func(p PhysicalPlan) PhysicalPlan)
 
 func existsCartesianProduct(p LogicalPlan) bool {
 	if join, ok := p.(*LogicalJoin); ok && len(join.EqualConditions) == 0 {
-		return join.JoinType == InnerJoin || join.JoinType == LeftOuterJoin || join.JoinType == RightOuterJoin
+		return join.JoinType == InnerJoin || join.JoinType == LeftOuterJoin || join.JoinType == RightOuterJoin || join.JoinType == FullOuterJoin
 	}
 	for _, child := range p.Children() {
 		if existsCartesianProduct(child) {
@@ -50,6 +50,7 @@ var (
 	_ PhysicalPlan = &PhysicalTopN{}
 	_ PhysicalPlan = &PhysicalMaxOneRow{}
 	_ PhysicalPlan = &PhysicalTableDual{}
+	_ PhysicalPlan = &PhysicalJSONTable{}
 	_ PhysicalPlan = &PhysicalUnionAll{}
 	_ PhysicalPlan = &PhysicalSort{}
 	_ PhysicalPlan = &NominalSort{}
@@ -691,6 +692,9 @@ type PhysicalIndexScan struct {
 
 	NeedCommonHandle bool
 
+	// SpatialWindow is used to skip the entries of a spatial index whose MBR doesn't intersect it.
+	SpatialWindow *expression.SpatialWindow
+
 	// required by cost model
 	// tblColHists contains all columns before pruning, which are used to calculate row-size
 	tblColHists   *statistics.HistColl
@@ -728,6 +732,9 @@ func (p *PhysicalIndexScan) Clone() (PhysicalPlan, error) {
 	copy(cloned.IdxColLens, p.IdxColLens)
 	cloned.Ranges = util.CloneRanges(p.Ranges)
 	cloned.Columns = util.CloneColInfos(p.Columns)
+	if p.SpatialWindow != nil {
+		cloned.SpatialWindow = p.SpatialWindow.Clone()
+	}
 	if p.dataSourceSchema != nil {
 		cloned.dataSourceSchema = p.dataSourceSchema.Clone()
 	}
@@ -2219,6 +2226,33 @@ type PhysicalTableDual struct {
 	names []*types.FieldName
 }
 
+// PhysicalJSONTable is the physical plan of JSON_TABLE.
+type PhysicalJSONTable struct {
+	physicalSchemaProducer
+
+	Expr   expression.Expression
+	Root   *JSONTablePath
+	AsName model.CIStr
+}
+
+// ExtractCorrelatedCols implements PhysicalPlan interface.
+func (p *PhysicalJSONTable) ExtractCorrelatedCols() []*expression.CorrelatedColumn {
+	return expression.ExtractCorColumns(p.Expr)
+}
+
+// MemoryUsage return the memory usage of PhysicalJSONTable
+func (p *PhysicalJSONTable) MemoryUsage() (sum int64) {
+	if p == nil {
+		return
+	}
+
+	sum = p.physicalSchemaProducer.MemoryUsage() + size.SizeOfInterface + size.SizeOfPointer + p.AsName.MemoryUsage()
+	if p.Expr != nil {
+		sum += p.Expr.MemoryUsage()
+	}
+	return
+}
+
 // OutputNames returns the outputting names of each column.
 func (p *PhysicalTableDual) OutputNames() types.NameSlice {
 	return p.names
@@ -691,6 +691,9 @@ func HasMaxOneRow(p LogicalPlan, childMaxOneRow []bool) bool {
 		switch x.JoinType {
 		case SemiJoin, AntiSemiJoin, LeftOuterSemiJoin, AntiLeftOuterSemiJoin:
 			return childMaxOneRow[0]
+		case FullOuterJoin:
+			// The unmatched rows of both sides are all kept, so two rows may be produced.
+			return false
 		default:
 			return childMaxOneRow[0] && childMaxOneRow[1]
 		}
@@ -257,11 +257,12 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 	sessVars := sctx.GetSessionVars()
 	stmtCtx := sessVars.StmtCtx
 
-	candidate, exist := sctx.GetSessionPlanCache().Get(cacheKey, matchOpts)
-	if !exist {
+	var cachedVal *PlanCacheValue
+	if candidate, exist := sctx.GetSessionPlanCache().Get(cacheKey, matchOpts); exist {
+		cachedVal = candidate.(*PlanCacheValue)
+	} else if cachedVal, exist = getInstanceCachedPlan(sctx, isNonPrepared, cacheKey, matchOpts); !exist {
 		return nil, nil, false, nil
 	}
-	cachedVal := candidate.(*PlanCacheValue)
 	if err := CheckPreparedPriv(sctx, stmt, is); err != nil {
 		return nil, nil, false, err
 	}
@@ -287,7 +288,12 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 	} else {
 		core_metrics.GetPlanCacheHitCounter(isNonPrepared).Inc()
 	}
-	stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
+	if cachedVal.planDigest != nil {
+		stmtCtx.SetPlanDigest(cachedVal.normalizedPlan, cachedVal.planDigest)
+	} else {
+		stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
+	}
+	stmtCtx.PlanCacheVariant = paramSensitiveVariant(stmt.QueryFeatures, matchOpts.ParamSelectivityBuckets)
 	stmtCtx.StmtHints = *cachedVal.stmtHints
 	return cachedVal.Plan, cachedVal.OutPutNames, true, nil
 }
@@ -333,9 +339,16 @@ func generateNewPlan(ctx context.Context, sctx sessionctx.Context, isNonPrepared
 		}
 		cached := NewPlanCacheValue(p, names, stmtCtx.TblInfo2UnionScan, matchOpts, &stmtCtx.StmtHints)
 		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(p)
+		cached.normalizedPlan, cached.planDigest = stmt.NormalizedPlan, stmt.PlanDigest
 		stmtCtx.SetPlan(p)
 		stmtCtx.SetPlanDigest(stmt.NormalizedPlan, stmt.PlanDigest)
-		sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		stmtCtx.PlanCacheVariant = paramSensitiveVariant(stmt.QueryFeatures, matchOpts.ParamSelectivityBuckets)
+		if sessVars.InPlanCacheWarmUp {
+			putInstancePlanCache(sctx, cacheKey, cached, matchOpts)
+		} else {
+			sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+		}
+		recordPlanCacheSnapshot(sctx, isNonPrepared, is, stmt, bindSQL, matchOpts)
 	}
 	sessVars.FoundInPlanCache = false
 	return p, names, err
@@ -795,6 +808,9 @@ func GetBindSQL4PlanCache(sctx sessionctx.Context, stmt *PlanCacheStmt) (string,
 	}
 	sessionHandle := sctx.Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
 	bindRecord := sessionHandle.GetBindRecord(stmt.SQLDigest4PC, stmt.NormalizedSQL4PC, "")
+	if bindRecord == nil {
+		bindRecord, _ = sessionHandle.MatchCrossDBBindRecord(stmt.PreparedAst.Stmt, stmt.StmtDB)
+	}
 	if bindRecord != nil {
 		enabledBinding := bindRecord.FindEnabledBinding()
 		if enabledBinding != nil {
@@ -807,6 +823,9 @@ func GetBindSQL4PlanCache(sctx sessionctx.Context, stmt *PlanCacheStmt) (string,
 		return "", ignore
 	}
 	bindRecord = globalHandle.GetBindRecord(stmt.SQLDigest4PC, stmt.NormalizedSQL4PC, "")
+	if bindRecord == nil {
+		bindRecord, _ = globalHandle.MatchCrossDBBindRecord(stmt.PreparedAst.Stmt, stmt.StmtDB)
+	}
 	if bindRecord != nil {
 		enabledBinding := bindRecord.FindEnabledBinding()
 		if enabledBinding != nil {
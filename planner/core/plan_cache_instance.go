@@ -0,0 +1,265 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/planner/util"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/util/kvcache"
+	utilpc "github.com/pingcap/tidb/util/plancache"
+)
+
+var (
+	instancePlanCacheOptVarsOnce sync.Once
+	instancePlanCacheOptVars     []string
+)
+
+// optVars4InstanceCache returns the names of the session variables which affect the optimizer, they are the
+// `tidb_opt_*` variables and the switches of the optimizer features.
+func optVars4InstanceCache() []string {
+	instancePlanCacheOptVarsOnce.Do(func() {
+		names := []string{
+			variable.TiDBEnableIndexMerge,
+			variable.TiDBEnableIndexMergeJoin,
+			variable.TiDBEnableINLJoinInnerMultiPattern,
+			variable.TiDBCostModelVersion,
+			variable.TiDBPartitionPruneMode,
+			variable.TiDBAllowMPPExecution,
+			variable.TiDBEnforceMPPExecution,
+			variable.TiDBAllowBatchCop,
+			variable.TiDBAllowTiFlashCop,
+			variable.TiDBEnableCascadesPlanner,
+			variable.TiDBOptimizerSelectivityLevel,
+			variable.TiDBDefaultStrMatchSelectivity,
+			variable.TiDBEnablePseudoForOutdatedStats,
+			variable.TiDBEnableExtendedStats,
+			variable.TiDBIndexJoinDoubleReadPenaltyCostRate,
+			variable.TiDBEnableParallelApply,
+			variable.TiDBEnableUnsafeSubstitute,
+		}
+		for name, sv := range variable.GetSysVars() {
+			if strings.HasPrefix(name, "tidb_opt_") && sv.HasSessionScope() {
+				names = append(names, name)
+			}
+		}
+		slices.Sort(names)
+		instancePlanCacheOptVars = names
+	})
+	return instancePlanCacheOptVars
+}
+
+// instancePlanCacheKey returns the key of the plan in the instance plan cache, which is the session plan
+// cache key without the connection ID. It contains the schema version of the statement and the values of
+// the optimizer variables, so the plans are only shared by the sessions which optimize them in the same way.
+func instancePlanCacheKey(sessVars *variable.SessionVars, cacheKey kvcache.Key) string {
+	key, ok := cacheKey.(*planCacheKey)
+	if !ok {
+		return ""
+	}
+	instanceKey := *key
+	instanceKey.connID = 0
+	instanceKey.hash = nil
+	hash := instanceKey.Hash()
+	for _, name := range optVars4InstanceCache() {
+		val, err := sessVars.GetSessionOrGlobalSystemVar(context.Background(), name)
+		if err != nil {
+			// the variable is unregistered after the names are collected
+			continue
+		}
+		hash = append(hash, name...)
+		hash = append(hash, '=')
+		hash = append(hash, val...)
+		hash = append(hash, 0)
+	}
+	return string(hash)
+}
+
+func instancePlanCacheOf(sctx sessionctx.Context, isNonPrepared bool) *utilpc.InstanceCache {
+	// only prepared statements are warmed up
+	if variable.PlanCacheSnapshotSize.Load() <= 0 || isNonPrepared {
+		return nil
+	}
+	dom := domain.GetDomain(sctx)
+	if dom == nil {
+		return nil
+	}
+	return dom.InstancePlanCache()
+}
+
+// putInstancePlanCache puts the plan built by warming up the plan cache into the instance plan cache,
+// the plans which can't be shared by other sessions are skipped.
+func putInstancePlanCache(sctx sessionctx.Context, cacheKey kvcache.Key, cached *PlanCacheValue,
+	matchOpts *utilpc.PlanCacheMatchOpts) {
+	cache := instancePlanCacheOf(sctx, false)
+	if cache == nil {
+		return
+	}
+	if _, ok := clonePlan4InstanceCache(sctx, cached.Plan); !ok {
+		return
+	}
+	cache.Put(instancePlanCacheKey(sctx.GetSessionVars(), cacheKey), cached, matchOpts, int(variable.PlanCacheSnapshotSize.Load()))
+}
+
+// getInstanceCachedPlan gets the plan from the instance plan cache, it's cloned for this session and put into
+// the session plan cache, the plans in the instance plan cache are never changed.
+func getInstanceCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache.Key,
+	matchOpts *utilpc.PlanCacheMatchOpts) (*PlanCacheValue, bool) {
+	cache := instancePlanCacheOf(sctx, isNonPrepared)
+	if cache == nil || cache.Size() == 0 {
+		return nil, false
+	}
+	sessVars := sctx.GetSessionVars()
+	candidate, exist := cache.Get(instancePlanCacheKey(sessVars, cacheKey), func(opts *utilpc.PlanCacheMatchOpts) bool {
+		return matchInstancePlan(sessVars, opts, matchOpts)
+	})
+	if !exist {
+		return nil, false
+	}
+	shared := candidate.(*PlanCacheValue)
+	plan, ok := clonePlan4InstanceCache(sctx, shared.Plan)
+	if !ok {
+		return nil, false
+	}
+	cached := NewPlanCacheValue(plan, shared.OutPutNames, shared.TblInfo2UnionScan, matchOpts, shared.stmtHints)
+	cached.normalizedPlan, cached.planDigest = shared.normalizedPlan, shared.planDigest
+	sctx.GetSessionPlanCache().Put(cacheKey, cached, matchOpts)
+	return cached, true
+}
+
+// matchInstancePlan checks whether the plan in the instance plan cache can be used, unlike the session plan
+// cache, a plan is never used once the statistics of the tables are changed.
+func matchInstancePlan(sessVars *variable.SessionVars, cached, matchOpts *utilpc.PlanCacheMatchOpts) bool {
+	if !checkTypesCompatibility4PC(cached.ParamTypes, matchOpts.ParamTypes) ||
+		!checkUint64SliceIfEqual(cached.LimitOffsetAndCount, matchOpts.LimitOffsetAndCount) ||
+		!checkUint64SliceIfEqual(cached.ParamSelectivityBuckets, matchOpts.ParamSelectivityBuckets) {
+		return false
+	}
+	if len(cached.LimitOffsetAndCount) > 0 && !sessVars.EnablePlanCacheForParamLimit {
+		return false
+	}
+	if cached.HasSubQuery && !sessVars.EnablePlanCacheForSubquery {
+		return false
+	}
+	return cached.StatsVersionHash == matchOpts.StatsVersionHash && cached.ForeignKeyChecks == matchOpts.ForeignKeyChecks
+}
+
+// clonePlan4InstanceCache clones the plan and binds it to sctx. It returns false if the plan contains
+// any operator that can't be shared by sessions.
+func clonePlan4InstanceCache(sctx sessionctx.Context, p Plan) (PhysicalPlan, bool) {
+	pp, ok := p.(PhysicalPlan)
+	if !ok {
+		return nil, false
+	}
+	cloned, err := pp.Clone()
+	if err != nil {
+		return nil, false
+	}
+	if !setCtx4InstanceCache(sctx, cloned) {
+		return nil, false
+	}
+	return cloned, true
+}
+
+// setCtx4InstanceCache binds the cloned plan and the expressions in it to sctx.
+func setCtx4InstanceCache(sctx sessionctx.Context, p PhysicalPlan) bool {
+	switch x := p.(type) {
+	case *PhysicalTableReader:
+		if !setCtx4InstanceCache(sctx, x.tablePlan) {
+			return false
+		}
+	case *PhysicalIndexReader:
+		if !setCtx4InstanceCache(sctx, x.indexPlan) || !setCtx4InstanceCacheForAll(sctx, x.IndexPlans) {
+			return false
+		}
+	case *PhysicalIndexLookUpReader:
+		if !setCtx4InstanceCache(sctx, x.indexPlan) || !setCtx4InstanceCache(sctx, x.tablePlan) ||
+			!setCtx4InstanceCacheForAll(sctx, x.IndexPlans) || !setCtx4InstanceCacheForAll(sctx, x.TablePlans) {
+			return false
+		}
+	case *PhysicalTableScan:
+		if x.Table.GetPartitionInfo() != nil || len(x.ByItems) > 0 || len(x.runtimeFilterList) > 0 {
+			return false
+		}
+		setCtx4Exprs(sctx, x.AccessCondition)
+		setCtx4Exprs(sctx, x.filterCondition)
+		x.lateMaterializationFilterCondition = util.CloneExprs(x.lateMaterializationFilterCondition)
+		setCtx4Exprs(sctx, x.lateMaterializationFilterCondition)
+	case *PhysicalIndexScan:
+		if x.Table.GetPartitionInfo() != nil || len(x.ByItems) > 0 || x.SpatialWindow != nil {
+			return false
+		}
+		setCtx4Exprs(sctx, x.AccessCondition)
+		genExprs := make(map[model.TableItemID]expression.Expression, len(x.GenExprs))
+		for id, expr := range x.GenExprs {
+			genExprs[id] = expr.Clone()
+			expression.SetCtxForPlanCache(genExprs[id], sctx)
+		}
+		x.GenExprs = genExprs
+	case *PhysicalSelection:
+		setCtx4Exprs(sctx, x.Conditions)
+	case *PhysicalProjection:
+		setCtx4Exprs(sctx, x.Exprs)
+	case *PhysicalLimit:
+	case *PhysicalTopN:
+		setCtx4ByItems(sctx, x.ByItems)
+	case *PhysicalSort:
+		setCtx4ByItems(sctx, x.ByItems)
+	case *PhysicalHashAgg:
+		setCtx4Agg(sctx, &x.basePhysicalAgg)
+	case *PhysicalStreamAgg:
+		setCtx4Agg(sctx, &x.basePhysicalAgg)
+	default:
+		return false
+	}
+	p.(interface{ SetSCtx(sessionctx.Context) }).SetSCtx(sctx)
+	return setCtx4InstanceCacheForAll(sctx, p.Children())
+}
+
+func setCtx4InstanceCacheForAll(sctx sessionctx.Context, plans []PhysicalPlan) bool {
+	for _, p := range plans {
+		if !setCtx4InstanceCache(sctx, p) {
+			return false
+		}
+	}
+	return true
+}
+
+func setCtx4Exprs(sctx sessionctx.Context, exprs []expression.Expression) {
+	for _, expr := range exprs {
+		expression.SetCtxForPlanCache(expr, sctx)
+	}
+}
+
+func setCtx4ByItems(sctx sessionctx.Context, byItems []*util.ByItems) {
+	for _, item := range byItems {
+		expression.SetCtxForPlanCache(item.Expr, sctx)
+	}
+}
+
+func setCtx4Agg(sctx sessionctx.Context, agg *basePhysicalAgg) {
+	for _, aggFunc := range agg.AggFuncs {
+		setCtx4Exprs(sctx, aggFunc.Args)
+	}
+	setCtx4Exprs(sctx, agg.GroupByItems)
+}
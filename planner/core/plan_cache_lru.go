@@ -269,6 +269,12 @@ func (l *LRUPlanCache) pickFromBucket(bucket map[*list.Element]struct{}, matchOp
 			// offset and key slice matched, but it is a plan with param limit and the switch is disabled
 			continue
 		}
+		// check the selectivity buckets of parameters, a plan built without statistics has no buckets,
+		// and it's reusable after the statistics are ready if tidb_plan_cache_invalidation_on_fresh_stats is off.
+		if !checkUint64SliceIfEqual(plan.matchOpts.ParamSelectivityBuckets, matchOpts.ParamSelectivityBuckets) &&
+			(plan.matchOpts.ParamSelectivityBuckets != nil || l.sctx.GetSessionVars().PlanCacheInvalidationOnFreshStats) {
+			continue
+		}
 		// check subquery switch state
 		if plan.matchOpts.HasSubQuery && !l.sctx.GetSessionVars().EnablePlanCacheForSubquery {
 			continue
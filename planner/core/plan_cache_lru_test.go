@@ -422,6 +422,45 @@ func TestIssue38244(t *testing.T) {
 	require.Equal(t, len(lru.buckets), 3)
 }
 
+func TestLRUPCParamSelectivityBuckets(t *testing.T) {
+	ctx := MockContext()
+	lru := NewLRUPlanCache(2, 0, 0, ctx, false)
+	defer func() {
+		domain.GetDomain(ctx).StatsHandle().Close()
+	}()
+	pTypes := []*types.FieldType{types.NewFieldType(mysql.TypeLong)}
+	key := &planCacheKey{database: "test"}
+	buckets := [][]uint64{{0}, {4}, {1}}
+	vals := make([]*PlanCacheValue, len(buckets))
+
+	// one key holds a plan for each selectivity bucket
+	for i := range buckets {
+		opts := &utilpc.PlanCacheMatchOpts{ParamTypes: pTypes, ParamSelectivityBuckets: buckets[i]}
+		vals[i] = &PlanCacheValue{matchOpts: opts}
+		lru.Put(key, vals[i], opts)
+	}
+	require.Equal(t, uint(2), lru.size)
+	require.Len(t, lru.buckets, 1)
+	_, exist := lru.Get(key, &utilpc.PlanCacheMatchOpts{ParamTypes: pTypes, ParamSelectivityBuckets: buckets[0]})
+	require.False(t, exist) // evicted by LRU
+	for i := 1; i < len(buckets); i++ {
+		val, exist := lru.Get(key, &utilpc.PlanCacheMatchOpts{ParamTypes: pTypes, ParamSelectivityBuckets: buckets[i]})
+		require.True(t, exist)
+		require.Same(t, vals[i], val)
+	}
+
+	// a plan built without statistics is reused after the statistics are ready only if the invalidation is off
+	lru.DeleteAll()
+	opts := &utilpc.PlanCacheMatchOpts{ParamTypes: pTypes}
+	lru.Put(key, &PlanCacheValue{matchOpts: opts}, opts)
+	ctx.GetSessionVars().PlanCacheInvalidationOnFreshStats = true
+	_, exist = lru.Get(key, &utilpc.PlanCacheMatchOpts{ParamTypes: pTypes, ParamSelectivityBuckets: buckets[0]})
+	require.False(t, exist)
+	ctx.GetSessionVars().PlanCacheInvalidationOnFreshStats = false
+	_, exist = lru.Get(key, &utilpc.PlanCacheMatchOpts{ParamTypes: pTypes, ParamSelectivityBuckets: buckets[0]})
+	require.True(t, exist)
+}
+
 func TestLRUPlanCacheMemoryUsage(t *testing.T) {
 	pTypes := []*types.FieldType{types.NewFieldType(mysql.TypeFloat), types.NewFieldType(mysql.TypeDouble)}
 	ctx := MockContext()
@@ -0,0 +1,323 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"math"
+	"strings"
+
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/pingcap/tidb/parser/opcode"
+	"github.com/pingcap/tidb/planner/cardinality"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/statistics"
+	"github.com/pingcap/tidb/statistics/handle/cache"
+	"github.com/pingcap/tidb/types"
+	driver "github.com/pingcap/tidb/types/parser_driver"
+	"github.com/pingcap/tidb/util"
+	"github.com/pingcap/tidb/util/collate"
+	"github.com/pingcap/tidb/util/ranger"
+)
+
+// paramSelectivityBounds are the upper bounds of the selectivity buckets used to choose a cached plan
+// for parameter-sensitive statements. A selectivity larger than the last bound falls into the last bucket.
+var paramSelectivityBounds = []float64{0.001, 0.01, 0.1, 0.3}
+
+// paramSelectivityUnknown is the bucket of a predicate whose selectivity can't be estimated.
+var paramSelectivityUnknown = uint64(len(paramSelectivityBounds) + 1)
+
+var paramSelectivityLabels = []string{"<=0.1%", "(0.1%,1%]", "(1%,10%]", "(10%,30%]", ">30%", "unknown"}
+
+// paramPredicate is a predicate like `col op ?` or `col in (?, ?, ...)` in a prepared statement.
+// The selectivity of its parameters decides which cached plan is chosen.
+type paramPredicate struct {
+	col     *ast.ColumnName
+	op      opcode.Op // opcode.In for `col in (?, ?, ...)`
+	markers []*driver.ParamMarkerExpr
+}
+
+// collectParamPredicate records the node if it is a predicate which compares a column with parameters.
+func (f *PlanCacheQueryFeatures) collectParamPredicate(in ast.Node) {
+	switch node := in.(type) {
+	case *ast.TableSource:
+		if tn, ok := node.Source.(*ast.TableName); ok && node.AsName.L != "" {
+			f.addTableAlias(node.AsName.L, tn)
+		}
+	case *ast.TableName:
+		f.addTableAlias(node.Name.L, node)
+	case *ast.BinaryOperationExpr:
+		op := node.Op
+		switch op {
+		case opcode.EQ, opcode.NullEQ, opcode.LT, opcode.LE, opcode.GT, opcode.GE:
+		default:
+			return
+		}
+		col, isCol := node.L.(*ast.ColumnNameExpr)
+		marker, isMarker := node.R.(*driver.ParamMarkerExpr)
+		if !isCol || !isMarker {
+			col, isCol = node.R.(*ast.ColumnNameExpr)
+			marker, isMarker = node.L.(*driver.ParamMarkerExpr)
+			if !isCol || !isMarker {
+				return
+			}
+			// `? < col` is the same as `col > ?`
+			switch op {
+			case opcode.LT:
+				op = opcode.GT
+			case opcode.LE:
+				op = opcode.GE
+			case opcode.GT:
+				op = opcode.LT
+			case opcode.GE:
+				op = opcode.LE
+			}
+		}
+		f.paramPredicates = append(f.paramPredicates, &paramPredicate{col: col.Name, op: op, markers: []*driver.ParamMarkerExpr{marker}})
+	case *ast.PatternInExpr:
+		col, isCol := node.Expr.(*ast.ColumnNameExpr)
+		if node.Not || node.Sel != nil || !isCol || len(node.List) == 0 {
+			return
+		}
+		markers := make([]*driver.ParamMarkerExpr, 0, len(node.List))
+		for _, item := range node.List {
+			marker, isMarker := item.(*driver.ParamMarkerExpr)
+			if !isMarker {
+				return
+			}
+			markers = append(markers, marker)
+		}
+		f.paramPredicates = append(f.paramPredicates, &paramPredicate{col: col.Name, op: opcode.In, markers: markers})
+	}
+}
+
+func (f *PlanCacheQueryFeatures) addTableAlias(alias string, tn *ast.TableName) {
+	if f.tableAliases == nil {
+		f.tableAliases = make(map[string]*ast.TableName)
+	}
+	if old, ok := f.tableAliases[alias]; ok && old != tn {
+		// the same name refers to different tables in different query blocks, we can't tell which one is used.
+		f.tableAliases[alias] = nil
+		return
+	}
+	f.tableAliases[alias] = tn
+}
+
+// getParamSelectivityBuckets estimates the selectivity of all parameter predicates with the statistics
+// and returns their buckets. It returns nil if no predicate can be estimated.
+func getParamSelectivityBuckets(sctx sessionctx.Context, is infoschema.InfoSchema, features *PlanCacheQueryFeatures) []uint64 {
+	if features == nil || len(features.paramPredicates) == 0 {
+		return nil
+	}
+	buckets := make([]uint64, len(features.paramPredicates))
+	known := false
+	for i, pred := range features.paramPredicates {
+		buckets[i] = paramSelectivityUnknown
+		sel, ok := estimateParamPredicate(sctx, is, features, pred)
+		if !ok {
+			continue
+		}
+		known = true
+		buckets[i] = uint64(len(paramSelectivityBounds))
+		for j, bound := range paramSelectivityBounds {
+			if sel <= bound {
+				buckets[i] = uint64(j)
+				break
+			}
+		}
+	}
+	if !known {
+		return nil
+	}
+	return buckets
+}
+
+// paramSensitiveVariant returns the description of the plan variant chosen by the selectivity buckets,
+// like `t.a:(1%,10%], b:>30%`.
+func paramSensitiveVariant(features *PlanCacheQueryFeatures, buckets []uint64) string {
+	if features == nil || len(buckets) != len(features.paramPredicates) {
+		return ""
+	}
+	var sb strings.Builder
+	for i, pred := range features.paramPredicates {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		if pred.col.Table.O != "" {
+			sb.WriteString(pred.col.Table.O)
+			sb.WriteString(".")
+		}
+		sb.WriteString(pred.col.Name.O)
+		sb.WriteString(":")
+		sb.WriteString(paramSelectivityLabels[buckets[i]])
+	}
+	return sb.String()
+}
+
+func resolveParamPredicateColumn(is infoschema.InfoSchema, features *PlanCacheQueryFeatures,
+	col *ast.ColumnName) (*model.TableInfo, *model.ColumnInfo) {
+	if col.Table.L != "" {
+		tn := features.tableAliases[col.Table.L]
+		if tn == nil || (col.Schema.L != "" && col.Schema.L != tn.Schema.L) {
+			return nil, nil
+		}
+		tbl, err := is.TableByName(tn.Schema, tn.Name)
+		if err != nil {
+			return nil, nil
+		}
+		return tbl.Meta(), model.FindColumnInfo(tbl.Meta().Cols(), col.Name.L)
+	}
+	var tblInfo *model.TableInfo
+	var colInfo *model.ColumnInfo
+	for _, tn := range features.tables {
+		tbl, err := is.TableByName(tn.Schema, tn.Name)
+		if err != nil { // CTE in this case
+			continue
+		}
+		if tblInfo != nil && tblInfo.ID == tbl.Meta().ID {
+			continue
+		}
+		if c := model.FindColumnInfo(tbl.Meta().Cols(), col.Name.L); c != nil {
+			if colInfo != nil {
+				// ambiguous column
+				return nil, nil
+			}
+			tblInfo, colInfo = tbl.Meta(), c
+		}
+	}
+	return tblInfo, colInfo
+}
+
+// estimateParamPredicate estimates the selectivity of the predicate under the current parameters.
+func estimateParamPredicate(sctx sessionctx.Context, is infoschema.InfoSchema, features *PlanCacheQueryFeatures,
+	pred *paramPredicate) (float64, bool) {
+	tblInfo, colInfo := resolveParamPredicateColumn(is, features, pred.col)
+	if colInfo == nil {
+		return 0, false
+	}
+	statsHandle := domain.GetDomain(sctx).StatsHandle()
+	if statsHandle == nil {
+		return 0, false
+	}
+	statsTbl := statsHandle.GetTableStats(tblInfo, cache.WithTableStatsByQuery())
+	if statsTbl == nil || statsTbl.Pseudo || statsTbl.RealtimeCount <= 0 {
+		return 0, false
+	}
+
+	isIntHandle := tblInfo.PKIsHandle && mysql.HasPriKeyFlag(colInfo.GetFlag())
+	ranges, ok := buildParamPredicateRanges(sctx, colInfo, pred, isIntHandle)
+	if !ok {
+		return 0, false
+	}
+	var rowCount float64
+	var err error
+	if isIntHandle {
+		rowCount, err = cardinality.GetRowCountByIntColumnRanges(sctx, &statsTbl.HistColl, colInfo.ID, ranges)
+	} else if idxID, found := findParamPredicateIndex(statsTbl, colInfo); found {
+		rowCount, err = cardinality.GetRowCountByIndexRanges(sctx, &statsTbl.HistColl, idxID, ranges)
+	} else {
+		rowCount, err = cardinality.GetRowCountByColumnRanges(sctx, &statsTbl.HistColl, colInfo.ID, ranges)
+	}
+	if err != nil {
+		return 0, false
+	}
+	return math.Min(math.Max(rowCount/float64(statsTbl.RealtimeCount), 0), 1), true
+}
+
+// findParamPredicateIndex returns an index whose first column is the column, the index statistics are more
+// likely to be loaded than the column statistics.
+func findParamPredicateIndex(statsTbl *statistics.Table, colInfo *model.ColumnInfo) (int64, bool) {
+	for _, idxID := range statsTbl.ColID2IdxIDs[colInfo.ID] {
+		idx, ok := statsTbl.Indices[idxID]
+		if !ok || idx.Info == nil || len(idx.Info.Columns) == 0 ||
+			idx.Info.Columns[0].Length != types.UnspecifiedLength || !idx.IsAnalyzed() || idx.IsEvicted() {
+			continue
+		}
+		return idxID, true
+	}
+	return 0, false
+}
+
+func buildParamPredicateRanges(sctx sessionctx.Context, colInfo *model.ColumnInfo, pred *paramPredicate,
+	isIntHandle bool) ([]*ranger.Range, bool) {
+	// use a separate statement context to avoid the conversion warnings being appended to the statement.
+	sc := &stmtctx.StatementContext{TimeZone: sctx.GetSessionVars().Location()}
+	collator := collate.GetCollator(colInfo.GetCollate())
+	values := make([]types.Datum, 0, len(pred.markers))
+	for _, marker := range pred.markers {
+		if !marker.InExecute || marker.Datum.IsNull() {
+			return nil, false
+		}
+		val, err := marker.Datum.ConvertTo(sc, &colInfo.FieldType)
+		if err != nil || val.IsNull() {
+			return nil, false
+		}
+		values = append(values, val)
+	}
+
+	minVal, maxVal := types.MinNotNullDatum(), types.MaxValueDatum()
+	if isIntHandle {
+		if mysql.HasUnsignedFlag(colInfo.GetFlag()) {
+			minVal, maxVal = types.NewUintDatum(0), types.NewUintDatum(math.MaxUint64)
+		} else {
+			minVal, maxVal = types.NewIntDatum(math.MinInt64), types.NewIntDatum(math.MaxInt64)
+		}
+	}
+	newRange := func(low, high types.Datum, lowExclude, highExclude bool) *ranger.Range {
+		return &ranger.Range{
+			LowVal:      []types.Datum{low},
+			HighVal:     []types.Datum{high},
+			LowExclude:  lowExclude,
+			HighExclude: highExclude,
+			Collators:   []collate.Collator{collator},
+		}
+	}
+	ranges := make([]*ranger.Range, 0, len(values))
+	switch pred.op {
+	case opcode.EQ, opcode.NullEQ, opcode.In:
+		for _, val := range values {
+			ranges = append(ranges, newRange(val, val, false, false))
+		}
+	case opcode.LT:
+		ranges = append(ranges, newRange(minVal, values[0], false, true))
+	case opcode.LE:
+		ranges = append(ranges, newRange(minVal, values[0], false, false))
+	case opcode.GT:
+		ranges = append(ranges, newRange(values[0], maxVal, true, false))
+	case opcode.GE:
+		ranges = append(ranges, newRange(values[0], maxVal, false, false))
+	default:
+		return nil, false
+	}
+	return ranges, true
+}
+
+// getPlanCacheVariant returns the cached plan variant used by the statement of the process.
+func getPlanCacheVariant(processInfo *util.ProcessInfo) string {
+	if processInfo.StmtCtx == nil {
+		return ""
+	}
+	if processInfo.RefCountOfStmtCtx != nil {
+		if !processInfo.RefCountOfStmtCtx.TryIncrease() {
+			return ""
+		}
+		defer processInfo.RefCountOfStmtCtx.Decrease()
+	}
+	return processInfo.StmtCtx.PlanCacheVariant
+}
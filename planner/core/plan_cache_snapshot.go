@@ -0,0 +1,79 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package core
+
+import (
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/infoschema"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/types"
+	utilpc "github.com/pingcap/tidb/util/plancache"
+)
+
+// PlanCacheSnapshotVersions returns the versions of the schema and the statistics of the tables used by the
+// prepared statement. An entry of the plan cache snapshot is stale if they differ from the recorded ones.
+func PlanCacheSnapshotVersions(sctx sessionctx.Context, is infoschema.InfoSchema, stmt *PlanCacheStmt) (tableVersion, statsVersion uint64) {
+	if stmt.QueryFeatures == nil {
+		return 0, 0
+	}
+	return getPlanCacheTableVersion(is, stmt.QueryFeatures), getPlanCacheStatsVersionHash(sctx, is, stmt.QueryFeatures)
+}
+
+// getPlanCacheTableVersion returns the sum of the schema update timestamps of the tables used by the statement.
+func getPlanCacheTableVersion(is infoschema.InfoSchema, features *PlanCacheQueryFeatures) (tableVersion uint64) {
+	for _, node := range features.tables {
+		t, err := is.TableByName(node.Schema, node.Name)
+		if err != nil { // CTE in this case
+			continue
+		}
+		tableVersion += t.Meta().UpdateTS
+	}
+	return
+}
+
+func planCacheSnapshotOf(sctx sessionctx.Context, isNonPrepared bool) *utilpc.Snapshot {
+	// only prepared statements can be re-prepared after restarting
+	if variable.PlanCacheSnapshotSize.Load() <= 0 || isNonPrepared || sctx.GetSessionVars().InRestrictedSQL {
+		return nil
+	}
+	dom := domain.GetDomain(sctx)
+	if dom == nil {
+		return nil
+	}
+	return dom.PlanCacheSnapshot()
+}
+
+// recordPlanCacheSnapshot records the statement whose plan is put into the plan cache in the snapshot of this instance.
+func recordPlanCacheSnapshot(sctx sessionctx.Context, isNonPrepared bool, is infoschema.InfoSchema, stmt *PlanCacheStmt,
+	bindSQL string, matchOpts *utilpc.PlanCacheMatchOpts) {
+	snapshot := planCacheSnapshotOf(sctx, isNonPrepared)
+	if snapshot == nil || stmt.QueryFeatures == nil {
+		return
+	}
+	paramTypes := make([]*types.FieldType, len(matchOpts.ParamTypes))
+	for i, tp := range matchOpts.ParamTypes {
+		paramTypes[i] = tp.Clone()
+	}
+	snapshot.Record(utilpc.SnapshotEntry{
+		DB:           stmt.StmtDB,
+		StmtText:     stmt.StmtText,
+		SQLDigest:    stmt.SQLDigest.String(),
+		ParamTypes:   paramTypes,
+		BindSQL:      bindSQL,
+		TableVersion: getPlanCacheTableVersion(is, stmt.QueryFeatures),
+		StatsVersion: matchOpts.StatsVersionHash,
+	}, int(variable.PlanCacheSnapshotSize.Load()))
+}
@@ -1741,6 +1741,102 @@ func TestPlanCacheWithSubquery(t *testing.T) {
 	}
 }
 
+func TestPlanCacheWithUncorrelatedSubquery(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, key(a))")
+	tk.MustExec("create table s(a int, b int)")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3)")
+	tk.MustExec("insert into s values (1, 1)")
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_uncorrelated_subquery = 1")
+
+	// the subqueries are not evaluated when building the plan, so the cached plan sees the new data of s.
+	testCases := []struct {
+		sql     string
+		param   int
+		before  []string
+		after   []string
+		explain string
+	}{
+		{"select a from t where a > (select max(a) from s where b < ?) order by a", 10, []string{"2", "3"}, []string{"3"}, "MaxOneRow"},
+		{"select a, (select max(a) from s where b < ?) from t order by a", 10, []string{"1 1", "2 1", "3 1"}, []string{"1 2", "2 2", "3 2"}, "MaxOneRow"},
+		{"select a from t where exists (select 1 from s where b > ?) order by a", 0, []string{"1", "2", "3"}, nil, "semi join"},
+		{"select a from t where not exists (select 1 from s where b > ?) order by a", 0, nil, []string{"1", "2", "3"}, "anti semi join"},
+		{"select a, exists (select 1 from s where b > ?) from t order by a", 0, []string{"1 1", "2 1", "3 1"}, []string{"1 0", "2 0", "3 0"}, "left outer semi join"},
+		{"select a from t where a in (select a from s where b < ?) order by a", 10, []string{"1"}, []string{"2"}, "join"},
+	}
+	for _, c := range testCases {
+		tk.MustExec("truncate table s")
+		tk.MustExec("insert into s values (1, 1)")
+		tk.MustExec(fmt.Sprintf("prepare stmt from '%s'", c.sql))
+		tk.MustExec(fmt.Sprintf("set @p = %d", c.param))
+		tk.MustExec("execute stmt using @p")
+		tk.MustQuery("execute stmt using @p").Check(testkit.Rows(c.before...))
+		tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+		tk.MustExec("update s set a = 2, b = -1")
+		tk.MustExec("set @p = 0")
+		tk.MustQuery("execute stmt using @p").Check(testkit.Rows(c.after...))
+		tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+		tk.MustExec("execute stmt using @p")
+		tkProcess := tk.Session().ShowProcess()
+		ps := []*util.ProcessInfo{tkProcess}
+		tk.Session().SetSessionManager(&testkit.MockSessionManager{PS: ps})
+		rows := tk.MustQuery(fmt.Sprintf("explain for connection %d", tkProcess.ID)).Rows()
+		require.Contains(t, fmt.Sprintf("%v", rows), c.explain, c.sql)
+	}
+
+	// the subquery still returns more than one row at execution.
+	tk.MustExec("insert into s values (3, 3)")
+	tk.MustExec("prepare stmt from 'select a from t where a > (select a from s where b < ?)'")
+	tk.MustExec("set @p = 0")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("3"))
+	tk.MustExec("set @p = 10")
+	require.EqualError(t, tk.QueryToErr("execute stmt using @p"), "[executor:1242]Subquery returns more than 1 row")
+
+	// the subquery in update and delete statements
+	tk.MustExec("prepare stmt from 'update t set b = (select max(b) from s where a < ?) where a = 1'")
+	tk.MustExec("set @p = 3")
+	tk.MustExec("execute stmt using @p")
+	tk.MustQuery("select b from t where a = 1").Check(testkit.Rows("-1"))
+	tk.MustExec("set @p = 10")
+	tk.MustExec("execute stmt using @p")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select b from t where a = 1").Check(testkit.Rows("3"))
+	tk.MustExec("prepare stmt from 'delete from t where exists (select 1 from s where b > ?)'")
+	tk.MustExec("set @p = 100")
+	tk.MustExec("execute stmt using @p")
+	tk.MustExec("set @p = 0")
+	tk.MustExec("execute stmt using @p")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustQuery("select count(*) from t").Check(testkit.Rows("0"))
+
+	// the subquery in insert values is still evaluated when building the plan.
+	tk.MustExec("prepare stmt from 'insert into t values (?, (select max(b) from s))'")
+	tk.MustExec("set @p = 1")
+	tk.MustExec("execute stmt using @p")
+	tk.MustExec("execute stmt using @p")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("select a, b from t").Check(testkit.Rows("1 3", "1 3"))
+
+	// the switches off
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_uncorrelated_subquery = 0")
+	tk.MustExec("prepare stmt from 'select a from t where a > (select max(a) from s where b < ?)'")
+	tk.MustExec("execute stmt using @p")
+	tk.MustExec("execute stmt using @p")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("execute stmt using @p")
+	tk.MustQuery("show warnings").Check(testkit.Rows("Warning 1105 skip prepared plan-cache: query has uncorrelated sub-queries is un-cacheable"))
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_uncorrelated_subquery = 1")
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_subquery = 0")
+	tk.MustExec("prepare stmt from 'select a from t where a > (select max(a) from s where b < ?)'")
+	tk.MustExec("execute stmt using @p")
+	tk.MustExec("execute stmt using @p")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+}
+
 func TestIssue41828(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
@@ -1826,6 +1922,79 @@ func TestPlanCacheSubquerySPMEffective(t *testing.T) {
 	}
 }
 
+func TestPlanCacheParamSensitive(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, key(a))")
+	vals := make([]string, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		a := 1
+		if i >= 900 {
+			a = i
+		}
+		vals = append(vals, fmt.Sprintf("(%d, %d)", a, i))
+	}
+	tk.MustExec("insert into t values " + strings.Join(vals, ","))
+	tk.MustExec("analyze table t")
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_param_sensitive = 1")
+
+	explainPlan := func(execute string) string {
+		tk.MustQuery(execute)
+		tkProcess := tk.Session().ShowProcess()
+		tk.Session().SetSessionManager(&testkit.MockSessionManager{PS: []*util.ProcessInfo{tkProcess}})
+		rows := tk.MustQuery(fmt.Sprintf("explain for connection %d", tkProcess.ID)).Rows()
+		return fmt.Sprintf("%v %v", rows, tk.Session().GetSessionVars().StmtCtx.GetWarnings())
+	}
+
+	tk.MustExec("prepare stmt from 'select b from t where a = ?'")
+	tk.MustExec("set @p = 950")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("950"))
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("950"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// the skewed value gets a plan of its own instead of the cached index plan.
+	tk.MustExec("set @p = 1")
+	require.Len(t, tk.MustQuery("execute stmt using @p").Rows(), 900)
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	require.Len(t, tk.MustQuery("execute stmt using @p").Rows(), 900)
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	plan := explainPlan("execute stmt using @p")
+	require.Contains(t, plan, "TableFullScan")
+	require.Contains(t, plan, "Use the cached plan variant chosen by parameter selectivity: a:>30%")
+
+	// both plans are kept in the cache.
+	tk.MustExec("set @p = 990")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("990"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	plan = explainPlan("execute stmt using @p")
+	require.Contains(t, plan, "IndexRangeScan")
+	require.Contains(t, plan, "a:<=0.1%")
+
+	// the parameter on the left side and in-list
+	tk.MustExec("prepare stmt from 'select count(*) from t t1 where ? > t1.b and t1.a in (?, ?)'")
+	tk.MustExec("set @p1 = 2000, @p2 = 950, @p3 = 960")
+	tk.MustQuery("execute stmt using @p1, @p2, @p3").Check(testkit.Rows("2"))
+	tk.MustExec("set @p1 = 2000, @p2 = 1, @p3 = 970")
+	tk.MustQuery("execute stmt using @p1, @p2, @p3").Check(testkit.Rows("901"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustQuery("execute stmt using @p1, @p2, @p3").Check(testkit.Rows("901"))
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	require.Contains(t, explainPlan("execute stmt using @p1, @p2, @p3"), "t1.b:>30%, t1.a:>30%")
+
+	// the switch off
+	tk.MustExec("set @@session.tidb_enable_plan_cache_for_param_sensitive = 0")
+	tk.MustExec("prepare stmt from 'select b from t where a = ?'")
+	tk.MustExec("set @p = 950")
+	tk.MustQuery("execute stmt using @p").Check(testkit.Rows("950"))
+	tk.MustExec("set @p = 1")
+	require.Len(t, tk.MustQuery("execute stmt using @p").Rows(), 900)
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	plan = explainPlan("execute stmt using @p")
+	require.Contains(t, plan, "IndexRangeScan")
+	require.NotContains(t, plan, "parameter selectivity")
+}
+
 func TestNonPreparedPlanCacheDMLSwitch(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
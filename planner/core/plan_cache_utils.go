@@ -340,6 +340,9 @@ type PlanCacheValue struct {
 	matchOpts *utilpc.PlanCacheMatchOpts
 	// stmtHints stores the hints which set session variables, because the hints won't be processed using cached plan.
 	stmtHints *stmtctx.StmtHints
+	// normalizedPlan and planDigest belong to this plan, since a statement may have several cached plans.
+	normalizedPlan string
+	planDigest     *parser.Digest
 }
 
 // unKnownMemoryUsage represent the memory usage of uncounted structure, maybe need implement later
@@ -369,12 +372,14 @@ func (v *PlanCacheValue) MemoryUsage() (sum int64) {
 	}
 
 	sum += size.SizeOfInterface + size.SizeOfSlice*2 + int64(cap(v.OutPutNames))*size.SizeOfPointer +
-		size.SizeOfMap + int64(len(v.TblInfo2UnionScan))*(size.SizeOfPointer+size.SizeOfBool) + size.SizeOfInt64*2
+		size.SizeOfMap + int64(len(v.TblInfo2UnionScan))*(size.SizeOfPointer+size.SizeOfBool) + size.SizeOfInt64*2 +
+		int64(len(v.normalizedPlan)) + size.SizeOfPointer
 	if v.matchOpts != nil {
 		sum += int64(cap(v.matchOpts.ParamTypes)) * size.SizeOfPointer
 		for _, ft := range v.matchOpts.ParamTypes {
 			sum += ft.MemoryUsage()
 		}
+		sum += int64(cap(v.matchOpts.LimitOffsetAndCount)+cap(v.matchOpts.ParamSelectivityBuckets)) * size.SizeOfUint64
 	}
 
 	for _, name := range v.OutPutNames {
@@ -409,6 +414,10 @@ type PlanCacheQueryFeatures struct {
 	limits      []*ast.Limit
 	hasSubquery bool
 	tables      []*ast.TableName // to capture table stats changes
+
+	// paramPredicates and tableAliases are used to choose a plan by the selectivity of parameters.
+	paramPredicates []*paramPredicate
+	tableAliases    map[string]*ast.TableName
 }
 
 // Enter implements Visitor interface.
@@ -421,6 +430,7 @@ func (f *PlanCacheQueryFeatures) Enter(in ast.Node) (out ast.Node, skipChildren
 	case *ast.TableName:
 		f.tables = append(f.tables, node)
 	}
+	f.collectParamPredicate(in)
 	return in, false
 }
 
@@ -482,15 +492,10 @@ func GetPreparedStmt(stmt *ast.ExecuteStmt, vars *variable.SessionVars) (*PlanCa
 func GetMatchOpts(sctx sessionctx.Context, is infoschema.InfoSchema, stmt *PlanCacheStmt, params []expression.Expression) (*utilpc.PlanCacheMatchOpts, error) {
 	var statsVerHash uint64
 	var limitOffsetAndCount []uint64
+	var paramSelectivityBuckets []uint64
 
 	if stmt.QueryFeatures != nil {
-		for _, node := range stmt.QueryFeatures.tables {
-			t, err := is.TableByName(node.Schema, node.Name)
-			if err != nil { // CTE in this case
-				continue
-			}
-			statsVerHash += getLatestVersionFromStatsTable(sctx, t.Meta(), t.Meta().ID) // use '+' as the hash function for simplicity
-		}
+		statsVerHash = getPlanCacheStatsVersionHash(sctx, is, stmt.QueryFeatures)
 
 		for _, node := range stmt.QueryFeatures.limits {
 			if node.Count != nil {
@@ -518,17 +523,34 @@ func GetMatchOpts(sctx sessionctx.Context, is infoschema.InfoSchema, stmt *PlanC
 				}
 			}
 		}
+
+		if sctx.GetSessionVars().EnablePlanCacheForParamSensitive && sctx.GetSessionVars().StmtCtx.UseCache {
+			paramSelectivityBuckets = getParamSelectivityBuckets(sctx, is, stmt.QueryFeatures)
+		}
 	}
 
 	return &utilpc.PlanCacheMatchOpts{
-		LimitOffsetAndCount: limitOffsetAndCount,
-		HasSubQuery:         stmt.QueryFeatures.hasSubquery,
-		StatsVersionHash:    statsVerHash,
-		ParamTypes:          parseParamTypes(sctx, params),
-		ForeignKeyChecks:    sctx.GetSessionVars().ForeignKeyChecks,
+		LimitOffsetAndCount:     limitOffsetAndCount,
+		HasSubQuery:             stmt.QueryFeatures.hasSubquery,
+		StatsVersionHash:        statsVerHash,
+		ParamSelectivityBuckets: paramSelectivityBuckets,
+		ParamTypes:              parseParamTypes(sctx, params),
+		ForeignKeyChecks:        sctx.GetSessionVars().ForeignKeyChecks,
 	}, nil
 }
 
+// getPlanCacheStatsVersionHash returns the hash value of the statistics versions of the tables used by the statement.
+func getPlanCacheStatsVersionHash(sctx sessionctx.Context, is infoschema.InfoSchema, features *PlanCacheQueryFeatures) (statsVerHash uint64) {
+	for _, node := range features.tables {
+		t, err := is.TableByName(node.Schema, node.Name)
+		if err != nil { // CTE in this case
+			continue
+		}
+		statsVerHash += getLatestVersionFromStatsTable(sctx, t.Meta(), t.Meta().ID) // use '+' as the hash function for simplicity
+	}
+	return
+}
+
 // CheckTypesCompatibility4PC compares FieldSlice with []*types.FieldType
 // Currently this is only used in plan cache to check whether the types of parameters are compatible.
 // If the types of parameters are compatible, we can use the cached plan.
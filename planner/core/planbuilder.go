@@ -435,21 +435,23 @@ const (
 	expressionClause
 	windowOrderByClause
 	partitionByClause
+	tableFunctionArgument
 )
 
 var clauseMsg = map[clauseCode]string{
-	unknowClause:        "",
-	fieldList:           "field list",
-	havingClause:        "having clause",
-	onClause:            "on clause",
-	orderByClause:       "order clause",
-	whereClause:         "where clause",
-	groupByClause:       "group statement",
-	showStatement:       "show statement",
-	globalOrderByClause: "global ORDER clause",
-	expressionClause:    "expression",
-	windowOrderByClause: "window order by",
-	partitionByClause:   "window partition by",
+	unknowClause:          "",
+	fieldList:             "field list",
+	havingClause:          "having clause",
+	onClause:              "on clause",
+	orderByClause:         "order clause",
+	whereClause:           "where clause",
+	groupByClause:         "group statement",
+	showStatement:         "show statement",
+	globalOrderByClause:   "global ORDER clause",
+	expressionClause:      "expression",
+	windowOrderByClause:   "window order by",
+	partitionByClause:     "window partition by",
+	tableFunctionArgument: "a table function argument",
 }
 
 type capFlagType = uint64
@@ -870,7 +872,9 @@ func (b *PlanBuilder) Build(ctx context.Context, node ast.Node) (Plan, error) {
 		*ast.GrantStmt, *ast.DropUserStmt, *ast.AlterUserStmt, *ast.RevokeStmt, *ast.KillStmt, *ast.DropStatsStmt,
 		*ast.GrantRoleStmt, *ast.RevokeRoleStmt, *ast.SetRoleStmt, *ast.SetDefaultRoleStmt, *ast.ShutdownStmt,
 		*ast.RenameUserStmt, *ast.NonTransactionalDMLStmt, *ast.SetSessionStatesStmt, *ast.SetResourceGroupStmt,
-		*ast.LoadDataActionStmt, *ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt:
+		*ast.LoadDataActionStmt, *ast.ImportIntoActionStmt, *ast.CalibrateResourceStmt, *ast.AddQueryWatchStmt, *ast.DropQueryWatchStmt,
+		*ast.ProcedureInfo, *ast.DropProcedureStmt, *ast.CallStmt, *ast.RefreshMaterializedViewStmt,
+		*ast.CreateEventStmt, *ast.AlterEventStmt, *ast.DropEventStmt:
 		return b.buildSimple(ctx, node.(ast.StmtNode))
 	case ast.DDLNode:
 		return b.buildDDL(ctx, x)
@@ -1051,14 +1055,15 @@ func (b *PlanBuilder) buildSet(ctx context.Context, v *ast.SetStmt) (Plan, error
 func (b *PlanBuilder) buildDropBindPlan(v *ast.DropBindingStmt) (Plan, error) {
 	var p *SQLBindPlan
 	if v.OriginNode != nil {
+		normdOrigSQL, _, db := normalizeBindingOriginSQL(v.OriginNode, b.ctx.GetSessionVars().CurrentDB)
 		p = &SQLBindPlan{
 			SQLBindOp:    OpSQLBindDrop,
-			NormdOrigSQL: parser.Normalize(utilparser.RestoreWithDefaultDB(v.OriginNode, b.ctx.GetSessionVars().CurrentDB, v.OriginNode.Text())),
+			NormdOrigSQL: normdOrigSQL,
 			IsGlobal:     v.GlobalScope,
-			Db:           utilparser.GetDefaultDB(v.OriginNode, b.ctx.GetSessionVars().CurrentDB),
+			Db:           db,
 		}
 		if v.HintedNode != nil {
-			p.BindSQL = utilparser.RestoreWithDefaultDB(v.HintedNode, b.ctx.GetSessionVars().CurrentDB, v.HintedNode.Text())
+			p.BindSQL = utilparser.RestoreWithDefaultDB(v.HintedNode, getBindingRestoreDB(v.OriginNode, b.ctx.GetSessionVars().CurrentDB), v.HintedNode.Text())
 		}
 	} else {
 		p = &SQLBindPlan{
@@ -1074,10 +1079,11 @@ func (b *PlanBuilder) buildDropBindPlan(v *ast.DropBindingStmt) (Plan, error) {
 func (b *PlanBuilder) buildSetBindingStatusPlan(v *ast.SetBindingStmt) (Plan, error) {
 	var p *SQLBindPlan
 	if v.OriginNode != nil {
+		normdOrigSQL, _, db := normalizeBindingOriginSQL(v.OriginNode, b.ctx.GetSessionVars().CurrentDB)
 		p = &SQLBindPlan{
 			SQLBindOp:    OpSetBindingStatus,
-			NormdOrigSQL: parser.Normalize(utilparser.RestoreWithDefaultDB(v.OriginNode, b.ctx.GetSessionVars().CurrentDB, v.OriginNode.Text())),
-			Db:           utilparser.GetDefaultDB(v.OriginNode, b.ctx.GetSessionVars().CurrentDB),
+			NormdOrigSQL: normdOrigSQL,
+			Db:           db,
 		}
 	} else if v.SQLDigest != "" {
 		p = &SQLBindPlan{
@@ -1100,6 +1106,26 @@ func (b *PlanBuilder) buildSetBindingStatusPlan(v *ast.SetBindingStmt) (Plan, er
 	return p, nil
 }
 
+// getBindingRestoreDB returns the default database used to restore the statements of a binding.
+// The tables of a cross-database binding all use the wildcard schema.
+func getBindingRestoreDB(originNode ast.StmtNode, currentDB string) string {
+	if bindinfo.IsCrossDBBinding(originNode) {
+		return bindinfo.CrossDBSchema
+	}
+	return currentDB
+}
+
+// normalizeBindingOriginSQL returns the normalized original sql of a binding, its digest and the
+// default database of the binding. A cross-database binding has no default database.
+func normalizeBindingOriginSQL(originNode ast.StmtNode, currentDB string) (normdOrigSQL, sqlDigest, db string) {
+	if bindinfo.IsCrossDBBinding(originNode) {
+		normdOrigSQL, sqlDigest, _ = bindinfo.NormalizeStmtForCrossDBBinding(originNode, "")
+		return normdOrigSQL, sqlDigest, ""
+	}
+	normdOrigSQL, digest := parser.NormalizeDigest(utilparser.RestoreWithDefaultDB(originNode, currentDB, originNode.Text()))
+	return normdOrigSQL, digest.String(), utilparser.GetDefaultDB(originNode, currentDB)
+}
+
 func checkHintedSQL(sql, charset, collation, db string) error {
 	p := parser.New()
 	hintsSet, _, warns, err := hint.ParseHintsSet(p, sql, charset, collation, db)
@@ -1202,18 +1228,19 @@ func (b *PlanBuilder) buildCreateBindPlan(v *ast.CreateBindingStmt) (Plan, error
 		return nil, err
 	}
 
-	normdOrigSQL, sqlDigestWithDB := parser.NormalizeDigest(utilparser.RestoreWithDefaultDB(v.OriginNode, b.ctx.GetSessionVars().CurrentDB, v.OriginNode.Text()))
+	currentDB := b.ctx.GetSessionVars().CurrentDB
+	normdOrigSQL, sqlDigestWithDB, db := normalizeBindingOriginSQL(v.OriginNode, currentDB)
 	p := &SQLBindPlan{
 		SQLBindOp:    OpSQLBindCreate,
 		NormdOrigSQL: normdOrigSQL,
-		BindSQL:      utilparser.RestoreWithDefaultDB(v.HintedNode, b.ctx.GetSessionVars().CurrentDB, v.HintedNode.Text()),
+		BindSQL:      utilparser.RestoreWithDefaultDB(v.HintedNode, getBindingRestoreDB(v.OriginNode, currentDB), v.HintedNode.Text()),
 		IsGlobal:     v.GlobalScope,
 		BindStmt:     v.HintedNode,
-		Db:           utilparser.GetDefaultDB(v.OriginNode, b.ctx.GetSessionVars().CurrentDB),
+		Db:           db,
 		Charset:      charSet,
 		Collation:    collation,
 		Source:       bindinfo.Manual,
-		SQLDigest:    sqlDigestWithDB.String(),
+		SQLDigest:    sqlDigestWithDB,
 	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", nil)
 	return p, nil
@@ -1755,8 +1782,9 @@ func (b *PlanBuilder) buildAdmin(ctx context.Context, as *ast.AdminStmt) (Plan,
 		return &SQLBindPlan{SQLBindOp: OpCaptureBindings}, nil
 	case ast.AdminEvolveBindings:
 		var err error
-		// The 'baseline evolution' only work in the test environment before the feature is GA.
-		if !config.CheckTableBeforeDrop {
+		// The 'baseline evolution' only work in the test environment or with the experimental config before the
+		// feature is GA.
+		if !config.CheckTableBeforeDrop && !config.GetGlobalConfig().Experimental.EnablePlanBaselineEvolution {
 			err = errors.Errorf("Cannot enable baseline evolution feature, it is not generally available now")
 		}
 		return &SQLBindPlan{SQLBindOp: OpEvolveBindings}, err
@@ -1955,6 +1983,10 @@ func (b *PlanBuilder) buildPhysicalIndexLookUpReaders(ctx context.Context, dbNam
 			// Skip checking clustered index.
 			continue
 		}
+		if idxInfo.Spatial {
+			// Skip checking spatial index, its key holds the MBR of the geometry instead of the geometry.
+			continue
+		}
 		if idxInfo.State != model.StatePublic {
 			logutil.Logger(ctx).Info("build physical index lookup reader, the index isn't public",
 				zap.String("index", idxInfo.Name.O),
@@ -2449,6 +2481,10 @@ func getModifiedIndexesInfoForAnalyze(sctx sessionctx.Context, tblInfo *model.Ta
 			sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing multi-valued indexes is not supported, skip %s", originIdx.Name.L))
 			continue
 		}
+		if originIdx.Spatial {
+			sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing spatial indexes is not supported, skip %s", originIdx.Name.L))
+			continue
+		}
 		if allColumns {
 			// If all the columns need to be analyzed, we don't need to modify IndexColumn.Offset.
 			idxsInfo = append(idxsInfo, originIdx)
@@ -2816,6 +2852,10 @@ func (b *PlanBuilder) buildAnalyzeTable(as *ast.AnalyzeTableStmt, opts map[ast.A
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.Spatial {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing spatial indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 			for i, id := range physicalIDs {
 				if id == tbl.TableInfo.ID {
 					id = -1
@@ -2914,6 +2954,10 @@ func (b *PlanBuilder) buildAnalyzeIndex(as *ast.AnalyzeTableStmt, opts map[ast.A
 			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 			continue
 		}
+		if idx.Spatial {
+			b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing spatial indexes is not supported, skip %s", idx.Name.L))
+			continue
+		}
 		for i, id := range physicalIDs {
 			if id == tblInfo.ID {
 				id = -1
@@ -2960,6 +3004,10 @@ func (b *PlanBuilder) buildAnalyzeAllIndex(as *ast.AnalyzeTableStmt, opts map[as
 				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing multi-valued indexes is not supported, skip %s", idx.Name.L))
 				continue
 			}
+			if idx.Spatial {
+				b.ctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("analyzing spatial indexes is not supported, skip %s", idx.Name.L))
+				continue
+			}
 
 			for i, id := range physicalIDs {
 				if id == tblInfo.ID {
@@ -3448,6 +3496,8 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 			CountWarningsOrErrors: show.CountWarningsOrErrors,
 			DBName:                show.DBName,
 			Table:                 show.Table,
+			Procedure:             show.Procedure,
+			Event:                 show.Event,
 			Partition:             show.Partition,
 			Column:                show.Column,
 			IndexName:             show.IndexName,
@@ -3498,6 +3548,12 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	case ast.ShowConfig:
 		privErr := ErrSpecificAccessDenied.GenWithStackByArgs("CONFIG")
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.ConfigPriv, "", "", "", privErr)
+	case ast.ShowEvents:
+		if show.DBName != "" {
+			b.appendRoutineVisitInfo(mysql.EventPriv, model.NewCIStr(show.DBName))
+		}
+	case ast.ShowCreateEvent:
+		b.appendRoutineVisitInfo(mysql.EventPriv, show.Event.Schema)
 	case ast.ShowCreateView:
 		var err error
 		user := b.ctx.GetSessionVars().User
@@ -3559,8 +3615,13 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	np = p
 	// If we have ShowPredicateExtractor, we do not buildSelection with Pattern
 	if show.Pattern != nil && buildPattern {
+		patternCol := p.OutputNames()[0].ColName
+		if show.Tp == ast.ShowProcedureStatus || show.Tp == ast.ShowFunctionStatus {
+			// The pattern of `SHOW PROCEDURE STATUS` matches the routine name.
+			patternCol = p.OutputNames()[1].ColName
+		}
 		show.Pattern.Expr = &ast.ColumnNameExpr{
-			Name: &ast.ColumnName{Name: p.OutputNames()[0].ColName},
+			Name: &ast.ColumnName{Name: patternCol},
 		}
 		np, err = b.buildSelection(ctx, np, show.Pattern, nil)
 		if err != nil {
@@ -3607,6 +3668,32 @@ func (b *PlanBuilder) buildShow(ctx context.Context, show *ast.ShowStmt) (Plan,
 	return np, nil
 }
 
+// appendRoutineVisitInfo checks the database level privilege required by the stored routine and event statements.
+func (b *PlanBuilder) appendRoutineVisitInfo(priv mysql.PrivilegeType, db model.CIStr) {
+	var err error
+	if user := b.ctx.GetSessionVars().User; user != nil {
+		err = ErrDBaccessDenied.GenWithStackByArgs(user.AuthUsername, user.AuthHostname, db.O)
+	}
+	b.visitInfo = appendVisitInfo(b.visitInfo, priv, db.L, "", "", err)
+}
+
+// resolveRoutineDefiner returns the definer of an event or a stored procedure with CURRENT_USER replaced
+// by the current user, the SUPER privilege is required to create or alter the routines of other users.
+func (b *PlanBuilder) resolveRoutineDefiner(definer *auth.UserIdentity) *auth.UserIdentity {
+	user := b.ctx.GetSessionVars().User
+	if user == nil {
+		return definer
+	}
+	if definer.CurrentUser {
+		return &auth.UserIdentity{Username: user.AuthUsername, Hostname: user.AuthHostname}
+	}
+	if definer.Username != user.AuthUsername || definer.Hostname != user.AuthHostname {
+		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "", "", "", err)
+	}
+	return definer
+}
+
 func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan, error) {
 	p := &Simple{Statement: node}
 
@@ -3645,6 +3732,29 @@ func (b *PlanBuilder) buildSimple(ctx context.Context, node ast.StmtNode) (Plan,
 	case *ast.DropQueryWatchStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or RESOURCE_GROUP_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "RESOURCE_GROUP_ADMIN", false, err)
+	case *ast.ProcedureInfo:
+		b.appendRoutineVisitInfo(mysql.CreateRoutinePriv, raw.ProcedureName.Schema)
+		raw.Definer = b.resolveRoutineDefiner(raw.Definer)
+	case *ast.DropProcedureStmt:
+		b.appendRoutineVisitInfo(mysql.AlterRoutinePriv, raw.ProcedureName.Schema)
+	case *ast.CallStmt:
+		b.appendRoutineVisitInfo(mysql.ExecutePriv, raw.Procedure.Schema)
+	case *ast.CreateEventStmt:
+		b.appendRoutineVisitInfo(mysql.EventPriv, raw.EventName.Schema)
+		raw.Definer = b.resolveRoutineDefiner(raw.Definer)
+	case *ast.AlterEventStmt:
+		b.appendRoutineVisitInfo(mysql.EventPriv, raw.EventName.Schema)
+		if raw.NewName != nil {
+			b.appendRoutineVisitInfo(mysql.EventPriv, raw.NewName.Schema)
+		}
+		raw.Definer = b.resolveRoutineDefiner(raw.Definer)
+	case *ast.DropEventStmt:
+		b.appendRoutineVisitInfo(mysql.EventPriv, raw.EventName.Schema)
+	case *ast.RefreshMaterializedViewStmt:
+		if user := b.ctx.GetSessionVars().User; user != nil {
+			err := ErrTableaccessDenied.GenWithStackByArgs("ALTER", user.AuthUsername, user.AuthHostname, raw.ViewName.Name.L)
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.AlterPriv, raw.ViewName.Schema.L, raw.ViewName.Name.L, "", err)
+		}
 	case *ast.GrantRoleStmt:
 		err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER or ROLE_ADMIN")
 		b.visitInfo = appendDynamicVisitInfo(b.visitInfo, "ROLE_ADMIN", false, err)
@@ -4985,6 +5095,40 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
 				"", "", err)
 		}
+	case *ast.CreateMaterializedViewStmt:
+		b.isCreateView = true
+		b.capFlag |= canExpandAST
+		defer func() {
+			b.capFlag &= ^canExpandAST
+			b.isCreateView = false
+		}()
+
+		plan, err := b.Build(ctx, v.Select)
+		if err != nil {
+			return nil, err
+		}
+		schema := plan.Schema()
+		if v.Cols == nil {
+			adjustOverlongViewColname(plan.(LogicalPlan))
+			v.Cols = make([]model.CIStr, len(schema.Columns))
+			for i, name := range plan.OutputNames() {
+				v.Cols[i] = name.ColName
+			}
+		}
+		if len(v.Cols) != schema.Len() {
+			return nil, dbterror.ErrViewWrongList
+		}
+		// The columns of the table to store the result are built from the types of the output columns.
+		v.ColTypes = make([]*types.FieldType, len(schema.Columns))
+		for i, col := range schema.Columns {
+			v.ColTypes[i] = col.RetType.Clone()
+		}
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
 	case *ast.CreateSequenceStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrTableaccessDenied.GenWithStackByArgs("CREATE", b.ctx.GetSessionVars().User.AuthUsername,
@@ -4992,6 +5136,21 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 		}
 		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.CreatePriv, v.Name.Schema.L,
 			v.Name.Name.L, "", authErr)
+	case *ast.CreateTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("TRIGGER", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.Table.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.Table.Schema.L,
+			v.Table.Name.L, "", authErr)
+		if v.Definer.CurrentUser && b.ctx.GetSessionVars().User != nil {
+			v.Definer = b.ctx.GetSessionVars().User
+		}
+		if b.ctx.GetSessionVars().User != nil && v.Definer.String() != b.ctx.GetSessionVars().User.String() {
+			err := ErrSpecificAccessDenied.GenWithStackByArgs("SUPER")
+			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.SuperPriv, "",
+				"", "", err)
+		}
 	case *ast.DropDatabaseStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
@@ -5024,6 +5183,20 @@ func (b *PlanBuilder) buildDDL(ctx context.Context, node ast.DDLNode) (Plan, err
 			b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, sequence.Schema.L,
 				sequence.Name.L, "", authErr)
 		}
+	case *ast.DropMaterializedViewStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.ViewName.Name.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.DropPriv, v.ViewName.Schema.L,
+			v.ViewName.Name.L, "", authErr)
+	case *ast.DropTriggerStmt:
+		if b.ctx.GetSessionVars().User != nil {
+			authErr = ErrDBaccessDenied.GenWithStackByArgs(b.ctx.GetSessionVars().User.AuthUsername,
+				b.ctx.GetSessionVars().User.AuthHostname, v.TriggerName.Schema.L)
+		}
+		b.visitInfo = appendVisitInfo(b.visitInfo, mysql.TriggerPriv, v.TriggerName.Schema.L,
+			"", "", authErr)
 	case *ast.TruncateTableStmt:
 		if b.ctx.GetSessionVars().User != nil {
 			authErr = ErrTableaccessDenied.GenWithStackByArgs("DROP", b.ctx.GetSessionVars().User.AuthUsername,
@@ -5237,6 +5410,9 @@ func (b *PlanBuilder) buildExplainFor(explainFor *ast.ExplainForStmt) (Plan, err
 	if explainFor.Format == types.ExplainFormatROW {
 		explainRows = processInfo.PlanExplainRows
 	}
+	if variant := getPlanCacheVariant(processInfo); variant != "" {
+		b.ctx.GetSessionVars().StmtCtx.AppendNote(errors.Errorf("Use the cached plan variant chosen by parameter selectivity: %s", variant))
+	}
 	return b.buildExplainPlan(targetPlan, explainFor.Format, explainRows, false, nil, processInfo.RuntimeStatsColl)
 }
 
@@ -5253,23 +5429,67 @@ func (b *PlanBuilder) buildExplain(ctx context.Context, explain *ast.ExplainStmt
 }
 
 func (b *PlanBuilder) buildSelectInto(ctx context.Context, sel *ast.SelectStmt) (Plan, error) {
-	if sem.IsEnabled() {
+	selectIntoInfo := sel.SelectIntoOpt
+	if selectIntoInfo.Tp == ast.SelectIntoVars {
+		return b.buildSelectIntoVars(ctx, sel)
+	}
+	var err error
+	intoServerDisk := true
+	if selectIntoInfo.IsURI() {
+		intoServerDisk, err = storage.IsLocalPath(selectIntoInfo.FileName)
+		if err != nil {
+			return nil, exeerrors.ErrLoadDataInvalidURI.FastGenByArgs(err.Error())
+		}
+	}
+	if intoServerDisk && sem.IsEnabled() {
 		return nil, ErrNotSupportedWithSem.GenWithStackByArgs("SELECT INTO")
 	}
-	selectIntoInfo := sel.SelectIntoOpt
+	mockTablePlan := LogicalTableDual{}.Init(b.ctx, b.getSelectOffset())
+	options := make([]*LoadDataOpt, 0, len(selectIntoInfo.Options))
+	for _, opt := range selectIntoInfo.Options {
+		loadDataOpt := LoadDataOpt{Name: opt.Name}
+		if opt.Value != nil {
+			loadDataOpt.Value, _, err = b.rewrite(ctx, opt.Value, mockTablePlan, nil, true)
+			if err != nil {
+				return nil, err
+			}
+		}
+		options = append(options, &loadDataOpt)
+	}
 	sel.SelectIntoOpt = nil
-	targetPlan, _, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
+	targetPlan, targetNames, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
+	// restore the option, the statement is still used to get the redacted text to log.
+	sel.SelectIntoOpt = selectIntoInfo
 	if err != nil {
 		return nil, err
 	}
 	b.visitInfo = appendVisitInfo(b.visitInfo, mysql.FilePriv, "", "", "", ErrSpecificAccessDenied.GenWithStackByArgs("FILE"))
 	return &SelectInto{
 		TargetPlan:     targetPlan,
+		TargetNames:    targetNames,
 		IntoOpt:        selectIntoInfo,
+		Options:        options,
 		LineFieldsInfo: NewLineFieldsInfo(selectIntoInfo.FieldsInfo, selectIntoInfo.LinesInfo),
 	}, nil
 }
 
+// buildSelectIntoVars builds `SELECT ... INTO var_list`, which stores the only result row into variables.
+func (b *PlanBuilder) buildSelectIntoVars(ctx context.Context, sel *ast.SelectStmt) (Plan, error) {
+	selectIntoInfo := sel.SelectIntoOpt
+	sel.SelectIntoOpt = nil
+	targetPlan, _, err := OptimizeAstNode(ctx, b.ctx, sel, b.is)
+	if err != nil {
+		return nil, err
+	}
+	if targetPlan.Schema().Len() != len(selectIntoInfo.Variables) {
+		return nil, ErrWrongNumberOfColumnsInSelect.GenWithStackByArgs()
+	}
+	return &SelectInto{
+		TargetPlan: targetPlan,
+		IntoOpt:    selectIntoInfo,
+	}, nil
+}
+
 func buildShowProcedureSchema() (*expression.Schema, []*types.FieldName) {
 	tblName := "ROUTINES"
 	schema := newColumnsWithNames(11)
@@ -5414,6 +5634,10 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		}
 	case ast.ShowCreateView:
 		names = []string{"View", "Create View", "character_set_client", "collation_connection"}
+	case ast.ShowCreateProcedure:
+		names = []string{"Procedure", "sql_mode", "Create Procedure", "character_set_client", "collation_connection", "Database Collation"}
+	case ast.ShowCreateEvent:
+		names = []string{"Event", "sql_mode", "time_zone", "Create Event", "character_set_client", "collation_connection", "Database Collation"}
 	case ast.ShowCreateDatabase:
 		names = []string{"Database", "Create Database"}
 	case ast.ShowDrainerStatus:
@@ -5488,8 +5712,8 @@ func buildShowSchema(s *ast.ShowStmt, isView bool, isSequence bool) (schema *exp
 		names = []string{"Privilege", "Context", "Comment"}
 		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
 	case ast.ShowBindings:
-		names = []string{"Original_sql", "Bind_sql", "Default_db", "Status", "Create_time", "Update_time", "Charset", "Collation", "Source", "Sql_digest", "Plan_digest"}
-		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
+		names = []string{"Original_sql", "Bind_sql", "Default_db", "Status", "Create_time", "Update_time", "Charset", "Collation", "Source", "Sql_digest", "Plan_digest", "Schema_hits"}
+		ftypes = []byte{mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeDatetime, mysql.TypeDatetime, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar, mysql.TypeVarchar}
 	case ast.ShowBindingCacheStatus:
 		names = []string{"bindings_in_cache", "bindings_in_table", "memory_usage", "memory_quota"}
 		ftypes = []byte{mysql.TypeLonglong, mysql.TypeLonglong, mysql.TypeVarchar, mysql.TypeVarchar}
@@ -21,8 +21,11 @@ import (
 	"strings"
 
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/bindinfo"
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/expression/aggregation"
+	"github.com/pingcap/tidb/extension"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/meta/autoid"
@@ -134,6 +137,10 @@ func Preprocess(ctx context.Context, sctx sessionctx.Context, node ast.Node, pre
 		v.PreprocessorReturn = &PreprocessorReturn{}
 	}
 	node.Accept(&v)
+	if v.hasExtensionAggFunc {
+		// The flags of the ancestors of the converted custom aggregate functions should be reset.
+		ast.SetFlag(node)
+	}
 	// InfoSchema must be non-nil after preprocessing
 	v.ensureInfoSchema()
 	return errors.Trace(v.err)
@@ -223,6 +230,9 @@ type preprocessor struct {
 
 	staleReadProcessor staleread.Processor
 
+	// hasExtensionAggFunc is set when some function calls are converted to custom aggregate functions.
+	hasExtensionAggFunc bool
+
 	// values that may be returned
 	*PreprocessorReturn
 	err error
@@ -262,7 +272,14 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeCreate
 		p.flag |= inCreateOrDropTable
 		p.checkCreateViewGrammar(node)
-		p.checkCreateViewWithSelectGrammar(node)
+		p.checkCreateViewWithSelectGrammar(node.Select)
+	case *ast.CreateMaterializedViewStmt:
+		p.stmtTp = TypeCreate
+		p.flag |= inCreateOrDropTable
+		p.checkCreateMaterializedViewGrammar(node)
+	case *ast.DropMaterializedViewStmt:
+		p.stmtTp = TypeDrop
+		p.flag |= inCreateOrDropTable
 	case *ast.DropTableStmt:
 		p.flag |= inCreateOrDropTable
 		p.stmtTp = TypeDrop
@@ -291,6 +308,41 @@ func (p *preprocessor) Enter(in ast.Node) (out ast.Node, skipChildren bool) {
 		p.stmtTp = TypeShow
 		p.showTp = node.Tp
 		p.resolveShowStmt(node)
+	case *ast.CreateTriggerStmt:
+		p.stmtTp = TypeCreate
+		p.resolveCreateTriggerStmt(node)
+		// The statements in the trigger body are checked when the trigger is created and fired.
+		return in, true
+	case *ast.DropTriggerStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(&node.TriggerName.Schema)
+		return in, true
+	case *ast.ProcedureInfo:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(&node.ProcedureName.Schema)
+		// The statements in the routine body are checked when the routine is called.
+		return in, true
+	case *ast.DropProcedureStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(&node.ProcedureName.Schema)
+	case *ast.CallStmt:
+		p.resolveRoutineName(&node.Procedure.Schema)
+	case *ast.CreateEventStmt:
+		p.stmtTp = TypeCreate
+		p.resolveRoutineName(&node.EventName.Schema)
+		// The event body is checked when the event is executed.
+		return in, true
+	case *ast.AlterEventStmt:
+		p.stmtTp = TypeAlter
+		p.resolveRoutineName(&node.EventName.Schema)
+		if node.NewName != nil && node.NewName.Schema.L == "" {
+			node.NewName.Schema = node.EventName.Schema
+		}
+		return in, true
+	case *ast.DropEventStmt:
+		p.stmtTp = TypeDrop
+		p.resolveRoutineName(&node.EventName.Schema)
+		return in, true
 	case *ast.SetOprSelectList:
 		p.checkSetOprSelectList(node)
 	case *ast.DeleteTableList:
@@ -522,7 +574,13 @@ func (p *preprocessor) checkBindGrammar(originNode, hintedNode ast.StmtNode, def
 	}
 
 	// Check the bind operation is not on any temporary table.
-	tblNames := extractTableList(originNode, nil, false)
+	// The tables of a cross-database binding can't be resolved, all of them use the wildcard schema.
+	var tblNames []*ast.TableName
+	if bindinfo.IsCrossDBBinding(originNode) {
+		defaultDB = bindinfo.CrossDBSchema
+	} else {
+		tblNames = extractTableList(originNode, nil, false)
+	}
 	for _, tn := range tblNames {
 		tbl, err := p.tableByName(tn)
 		if err != nil {
@@ -557,7 +615,7 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 		p.flag &= ^inCreateOrDropTable
 		p.checkAutoIncrement(x)
 		p.checkContainDotColumn(x)
-	case *ast.CreateViewStmt:
+	case *ast.CreateViewStmt, *ast.CreateMaterializedViewStmt, *ast.DropMaterializedViewStmt:
 		p.flag &= ^inCreateOrDropTable
 	case *ast.DropTableStmt, *ast.AlterTableStmt, *ast.RenameTableStmt:
 		p.flag &= ^inCreateOrDropTable
@@ -587,6 +645,11 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 			p.tableAliasInJoin = p.tableAliasInJoin[:len(p.tableAliasInJoin)-1]
 		}
 	case *ast.FuncCallExpr:
+		if agg, ok := toExtensionAggFuncExpr(x); ok {
+			p.hasExtensionAggFunc = true
+			return agg, true
+		}
+
 		// The arguments for builtin NAME_CONST should be constants
 		// See https://dev.mysql.com/doc/refman/5.7/en/miscellaneous-functions.html#function_name-const for details
 		if x.FnName.L == ast.NameConst {
@@ -651,6 +714,46 @@ func (p *preprocessor) Leave(in ast.Node) (out ast.Node, ok bool) {
 	return in, p.err == nil
 }
 
+// toExtensionAggFuncExpr converts the function call to an aggregate function expression if its name is
+// registered as a custom aggregate function by extension. The parser only recognizes builtin aggregate
+// functions, so the custom ones are parsed as normal function calls.
+func toExtensionAggFuncExpr(x *ast.FuncCallExpr) (*ast.AggregateFuncExpr, bool) {
+	if x.Schema.L != "" || !aggregation.IsExtensionAggFunc(x.FnName.L) {
+		return nil, false
+	}
+	agg := &ast.AggregateFuncExpr{F: x.FnName.L, Args: x.Args}
+	agg.SetText(nil, x.Text())
+	return agg, true
+}
+
+// convertExtensionAggFunc converts the custom aggregate function calls in the statement which is not
+// preprocessed, such as the select statement of a view.
+func convertExtensionAggFunc(node ast.Node) {
+	var c extensionAggFuncConverter
+	node.Accept(&c)
+	if c.converted {
+		ast.SetFlag(node)
+	}
+}
+
+type extensionAggFuncConverter struct {
+	converted bool
+}
+
+func (*extensionAggFuncConverter) Enter(in ast.Node) (ast.Node, bool) {
+	return in, false
+}
+
+func (c *extensionAggFuncConverter) Leave(in ast.Node) (ast.Node, bool) {
+	if x, ok := in.(*ast.FuncCallExpr); ok {
+		if agg, ok := toExtensionAggFuncExpr(x); ok {
+			c.converted = true
+			return agg, true
+		}
+	}
+	return in, true
+}
+
 func checkAutoIncrementOp(colDef *ast.ColumnDef, index int) (bool, error) {
 	var hasAutoIncrement bool
 
@@ -986,8 +1089,23 @@ func (p *preprocessor) checkCreateViewWithSelect(stmt ast.Node) {
 	}
 }
 
-func (p *preprocessor) checkCreateViewWithSelectGrammar(stmt *ast.CreateViewStmt) {
-	switch stmt := stmt.Select.(type) {
+func (p *preprocessor) checkCreateMaterializedViewGrammar(stmt *ast.CreateMaterializedViewStmt) {
+	vName := stmt.ViewName.Name.String()
+	if util.IsInCorrectIdentifierName(vName) {
+		p.err = dbterror.ErrWrongTableName.GenWithStackByArgs(vName)
+		return
+	}
+	for _, col := range stmt.Cols {
+		if util.IsInCorrectIdentifierName(col.String()) {
+			p.err = dbterror.ErrWrongColumnName.GenWithStackByArgs(col)
+			return
+		}
+	}
+	p.checkCreateViewWithSelectGrammar(stmt.Select)
+}
+
+func (p *preprocessor) checkCreateViewWithSelectGrammar(sel ast.StmtNode) {
+	switch stmt := sel.(type) {
 	case *ast.SelectStmt:
 		p.checkCreateViewWithSelect(stmt)
 	case *ast.SetOprStmt:
@@ -1303,10 +1421,13 @@ var mysqlValidTableEngineNames = map[string]struct{}{
 }
 
 func checkTableEngine(engineName string) error {
-	if _, have := mysqlValidTableEngineNames[strings.ToLower(engineName)]; !have {
-		return dbterror.ErrUnknownEngine.GenWithStackByArgs(engineName)
+	if _, have := mysqlValidTableEngineNames[strings.ToLower(engineName)]; have {
+		return nil
 	}
-	return nil
+	if _, have := extension.GetTableSource(engineName); have {
+		return nil
+	}
+	return dbterror.ErrUnknownEngine.GenWithStackByArgs(engineName)
 }
 
 func checkReferInfoForTemporaryTable(tableMetaInfo *model.TableInfo) error {
@@ -1616,6 +1737,12 @@ func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
 	} else if node.Table != nil && node.Table.Schema.L == "" {
 		node.Table.Schema = model.NewCIStr(node.DBName)
 	}
+	if node.Procedure != nil {
+		p.resolveRoutineName(&node.Procedure.Schema)
+	}
+	if node.Event != nil {
+		p.resolveRoutineName(&node.Event.Schema)
+	}
 	if node.User != nil && node.User.CurrentUser {
 		// Fill the Username and Hostname with the current user.
 		currentUser := p.sctx.GetSessionVars().User
@@ -1628,6 +1755,31 @@ func (p *preprocessor) resolveShowStmt(node *ast.ShowStmt) {
 	}
 }
 
+// resolveCreateTriggerStmt fills the schema of the subject table with the current database,
+// and the schema of the trigger with the schema of the subject table.
+func (p *preprocessor) resolveCreateTriggerStmt(node *ast.CreateTriggerStmt) {
+	p.resolveRoutineName(&node.Table.Schema)
+	if p.err != nil {
+		return
+	}
+	if node.TriggerName.Schema.L == "" {
+		node.TriggerName.Schema = node.Table.Schema
+	}
+}
+
+// resolveRoutineName fills the schema of a stored routine with the current database.
+func (p *preprocessor) resolveRoutineName(schema *model.CIStr) {
+	if schema.L != "" {
+		return
+	}
+	currentDB := p.sctx.GetSessionVars().CurrentDB
+	if currentDB == "" {
+		p.err = errors.Trace(ErrNoDB)
+		return
+	}
+	*schema = model.NewCIStr(currentDB)
+}
+
 func (p *preprocessor) resolveExecuteStmt(node *ast.ExecuteStmt) {
 	prepared, err := GetPreparedStmt(node, p.sctx.GetSessionVars())
 	if err != nil {
@@ -177,6 +177,8 @@ func (p *LogicalJoin) PreparePossibleProperties(_ *expression.Schema, childrenPr
 		rightProperties = nil
 	} else if p.JoinType == RightOuterJoin {
 		leftProperties = nil
+	} else if p.JoinType == FullOuterJoin {
+		leftProperties, rightProperties = nil, nil
 	}
 	resultProperties := make([][]*expression.Column, len(leftProperties)+len(rightProperties))
 	for i, cols := range leftProperties {
@@ -162,6 +162,9 @@ func CheckCanConvertAggToProj(agg *LogicalAggregation) bool {
 		if join.JoinType == RightOuterJoin {
 			mayNullSchema = join.Children()[0].Schema()
 		}
+		if join.JoinType == FullOuterJoin {
+			mayNullSchema = join.Schema()
+		}
 		if mayNullSchema == nil {
 			return true
 		}
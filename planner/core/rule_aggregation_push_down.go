@@ -46,7 +46,7 @@ func (*aggregationPushDownSolver) isDecomposableWithJoin(fun *aggregation.AggFun
 	case ast.AggFuncAvg, ast.AggFuncGroupConcat, ast.AggFuncVarPop, ast.AggFuncJsonArrayagg, ast.AggFuncJsonObjectAgg, ast.AggFuncStddevPop, ast.AggFuncVarSamp, ast.AggFuncApproxPercentile, ast.AggFuncStddevSamp:
 		// TODO: Support avg push down.
 		return false
-	case ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow:
+	case ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow, ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
 		return true
 	case ast.AggFuncSum, ast.AggFuncCount:
 		return !fun.HasDistinct
@@ -64,10 +64,13 @@ func (*aggregationPushDownSolver) isDecomposableWithUnion(fun *aggregation.AggFu
 		return false
 	case ast.AggFuncMax, ast.AggFuncMin, ast.AggFuncFirstRow:
 		return true
-	case ast.AggFuncSum, ast.AggFuncCount, ast.AggFuncAvg, ast.AggFuncApproxCountDistinct:
+	case ast.AggFuncSum, ast.AggFuncCount, ast.AggFuncAvg, ast.AggFuncApproxCountDistinct,
+		ast.AggFuncApproxTopK, ast.AggFuncHLLSketch, ast.AggFuncHLLMerge:
 		return true
 	default:
-		return false
+		// a custom aggregate function can be decomposed only if its state can be encoded
+		def, ok := aggregation.GetExtensionAggFunc(fun.Name)
+		return ok && def.CanSplit()
 	}
 }
 
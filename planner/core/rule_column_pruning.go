@@ -395,7 +395,10 @@ func (p *LogicalMemTable) PruneColumns(parentUsedCols []*expression.Column, opt
 		infoschema.TableDeadlocks,
 		infoschema.ClusterTableDeadlocks:
 	default:
-		return nil
+		// The tables with custom engines only read the used columns.
+		if p.TableInfo.Engine == "" {
+			return nil
+		}
 	}
 	prunedColumns := make([]*expression.Column, 0)
 	used := expression.GetUsedList(parentUsedCols, p.schema)
@@ -171,7 +171,9 @@ func GetDupAgnosticAggCols(
 			aggDesc.Name != ast.AggFuncFirstRow &&
 			aggDesc.Name != ast.AggFuncMax &&
 			aggDesc.Name != ast.AggFuncMin &&
-			aggDesc.Name != ast.AggFuncApproxCountDistinct {
+			aggDesc.Name != ast.AggFuncApproxCountDistinct &&
+			aggDesc.Name != ast.AggFuncHLLSketch &&
+			aggDesc.Name != ast.AggFuncHLLMerge {
 			// If not all aggregate functions are duplicate agnostic,
 			// we should clean the aggCols, so `return true, newAggCols[:0]`.
 			return true, newAggCols[:0]
@@ -214,6 +214,10 @@ func (p *LogicalJoin) PredicatePushDown(predicates []expression.Expression, opt
 		p.OtherConditions = otherCond
 		leftCond = leftPushCond
 		rightCond = rightPushCond
+	case FullOuterJoin:
+		// The null-extended rows of both sides are produced by the join itself, so the
+		// predicates can't be pushed down to either side.
+		ret = predicates
 	case AntiSemiJoin:
 		predicates = expression.PropagateConstant(p.SCtx(), predicates)
 		// Return table dual when filter is constant false or null.
@@ -384,6 +388,10 @@ func (p *LogicalJoin) getProj(idx int) *LogicalProjection {
 
 // simplifyOuterJoin transforms "LeftOuterJoin/RightOuterJoin" to "InnerJoin" if possible.
 func simplifyOuterJoin(p *LogicalJoin, predicates []expression.Expression) {
+	if p.JoinType == FullOuterJoin {
+		simplifyFullOuterJoin(p, predicates)
+		return
+	}
 	if p.JoinType != LeftOuterJoin && p.JoinType != RightOuterJoin && p.JoinType != InnerJoin {
 		return
 	}
@@ -423,6 +431,40 @@ func simplifyOuterJoin(p *LogicalJoin, predicates []expression.Expression) {
 	}
 }
 
+// simplifyFullOuterJoin transforms "FullOuterJoin" to "LeftOuterJoin", "RightOuterJoin" or "InnerJoin"
+// if the predicates reject the null-extended rows of the right side, the left side or both sides.
+func simplifyFullOuterJoin(p *LogicalJoin, predicates []expression.Expression) {
+	leftTable, rightTable := p.children[0], p.children[1]
+	if leftPlan, ok := leftTable.(*LogicalJoin); ok {
+		simplifyOuterJoin(leftPlan, predicates)
+	}
+	if rightPlan, ok := rightTable.(*LogicalJoin); ok {
+		simplifyOuterJoin(rightPlan, predicates)
+	}
+	nullRejected := func(schema, otherSchema *expression.Schema) bool {
+		for _, expr := range predicates {
+			if expression.ExprFromSchema(expr, otherSchema) {
+				continue
+			}
+			if isNullRejected(p.SCtx(), schema, expr) {
+				return true
+			}
+		}
+		return false
+	}
+	leftRejected := nullRejected(leftTable.Schema(), rightTable.Schema())
+	rightRejected := nullRejected(rightTable.Schema(), leftTable.Schema())
+	switch {
+	case leftRejected && rightRejected:
+		p.JoinType = InnerJoin
+	case leftRejected:
+		// Only the rows whose left side isn't null-extended are kept.
+		p.JoinType = LeftOuterJoin
+	case rightRejected:
+		p.JoinType = RightOuterJoin
+	}
+}
+
 // isNullRejected check whether a condition is null-rejected
 // A condition would be null-rejected in one of following cases:
 // If it is a predicate containing a reference to an inner table that evaluates to UNKNOWN or FALSE when one of its arguments is NULL.
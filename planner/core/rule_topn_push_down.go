@@ -93,6 +93,15 @@ func (ls *LogicalSort) pushDownTopN(topN *LogicalTopN, opt *logicalOptimizeOp) L
 	return ls.children[0].pushDownTopN(topN, opt)
 }
 
+// pushDownTopN passes the limit to the table source of a table with custom engine as a hint.
+// The limit is still kept above the memory table.
+func (p *LogicalMemTable) pushDownTopN(topN *LogicalTopN, opt *logicalOptimizeOp) LogicalPlan {
+	if e, ok := p.Extractor.(*CustomEngineTableExtractor); ok && topN != nil && topN.isLimit() {
+		e.Limit = topN.Offset + topN.Count
+	}
+	return p.baseLogicalPlan.pushDownTopN(topN, opt)
+}
+
 func (p *LogicalLimit) convertToTopN(opt *logicalOptimizeOp) *LogicalTopN {
 	topn := LogicalTopN{Offset: p.Offset, Count: p.Count, limitHints: p.limitHints}.Init(p.SCtx(), p.SelectBlockOffset())
 	appendConvertTopNTraceStep(p, topn, opt)
@@ -63,6 +63,16 @@ func (p *LogicalTableDual) DeriveStats(_ []*property.StatsInfo, selfSchema *expr
 	return p.StatsInfo(), nil
 }
 
+// DeriveStats implement LogicalPlan DeriveStats interface.
+func (p *LogicalJSONTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
+	if p.StatsInfo() != nil {
+		return p.StatsInfo(), nil
+	}
+	// The row count depends on the JSON document, use a fake count like LogicalShow.
+	p.SetStats(getFakeStats(selfSchema))
+	return p.StatsInfo(), nil
+}
+
 // DeriveStats implement LogicalPlan DeriveStats interface.
 func (p *LogicalMemTable) DeriveStats(_ []*property.StatsInfo, selfSchema *expression.Schema, _ []*expression.Schema, _ [][]*expression.Column) (*property.StatsInfo, error) {
 	if p.StatsInfo() != nil {
@@ -319,7 +329,12 @@ func (ds *DataSource) derivePathStatsAndTryHeuristics() error {
 			path.IsSingleScan = true
 		} else {
 			ds.deriveIndexPathStats(path, ds.pushedDownConds, false)
-			path.IsSingleScan = ds.isSingleScan(path.FullIdxCols, path.FullIdxColLens)
+			// A spatial index can only cover the handle columns.
+			if path.Index.Spatial {
+				path.IsSingleScan = ds.isSingleScan(nil, nil)
+			} else {
+				path.IsSingleScan = ds.isSingleScan(path.FullIdxCols, path.FullIdxColLens)
+			}
 		}
 		// Try some heuristic rules to select access path.
 		if len(path.Ranges) == 0 {
@@ -809,6 +824,8 @@ func (p *LogicalJoin) DeriveStats(childStats []*property.StatsInfo, selfSchema *
 		count = math.Max(count, leftProfile.RowCount)
 	} else if p.JoinType == RightOuterJoin {
 		count = math.Max(count, rightProfile.RowCount)
+	} else if p.JoinType == FullOuterJoin {
+		count = math.Max(count, math.Max(leftProfile.RowCount, rightProfile.RowCount))
 	}
 	colNDVs := make(map[int64]float64, selfSchema.Len())
 	for id, c := range leftProfile.ColNDVs {
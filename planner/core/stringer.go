@@ -140,6 +140,8 @@ func toString(in Plan, strs []string, idxs []int) ([]string, []int) {
 			id = "MergeLeftOuterJoin"
 		case RightOuterJoin:
 			id = "MergeRightOuterJoin"
+		case FullOuterJoin:
+			id = "MergeFullOuterJoin"
 		case InnerJoin:
 			id = "MergeInnerJoin"
 		}
@@ -176,6 +178,8 @@ func toString(in Plan, strs []string, idxs []int) ([]string, []int) {
 		}
 	case *LogicalShowDDLJobs, *PhysicalShowDDLJobs:
 		str = "ShowDDLJobs"
+	case *LogicalJSONTable, *PhysicalJSONTable:
+		str = "JSONTable"
 	case *LogicalSort, *PhysicalSort:
 		str = "Sort"
 	case *LogicalJoin:
@@ -1138,7 +1138,10 @@ func (p *PhysicalTopN) attach2Task(tasks ...task) task {
 
 func (p *PhysicalExpand) attach2Task(tasks ...task) task {
 	t := tasks[0].copy()
-	// current expand can only be run in MPP TiFlash mode.
+	if p.GetChildReqProps(0).TaskTp == property.RootTaskType {
+		// the root expand is executed by TiDB.
+		return attachPlan2Task(p, t.convertToRootTask(p.SCtx()))
+	}
 	if mpp, ok := t.(*mppTask); ok {
 		p.SetChildren(mpp.p)
 		mpp.p = p
@@ -1476,7 +1479,8 @@ func BuildFinalModeAggregation(
 					partialCursor++
 				}
 			}
-			if finalAggFunc.Name == ast.AggFuncApproxCountDistinct {
+			if finalAggFunc.Name == ast.AggFuncApproxCountDistinct || finalAggFunc.Name == ast.AggFuncApproxTopK ||
+				aggregation.IsExtensionAggFunc(finalAggFunc.Name) {
 				ft := types.NewFieldType(mysql.TypeString)
 				ft.SetCharset(charset.CharsetBin)
 				ft.SetCollate(charset.CollationBin)
@@ -1512,13 +1516,14 @@ func BuildFinalModeAggregation(
 				sumAgg.TypeInfer4AvgSum(sumAgg.RetTp)
 				partial.Schema.Columns[partialCursor-1].RetType = sumAgg.RetTp
 				partial.AggFuncs = append(partial.AggFuncs, cntAgg, sumAgg)
-			} else if aggFunc.Name == ast.AggFuncApproxCountDistinct || aggFunc.Name == ast.AggFuncGroupConcat {
+			} else if aggFunc.Name == ast.AggFuncApproxCountDistinct || aggFunc.Name == ast.AggFuncGroupConcat ||
+				aggFunc.Name == ast.AggFuncApproxTopK || aggregation.IsExtensionAggFunc(aggFunc.Name) {
 				newAggFunc := aggFunc.Clone()
 				newAggFunc.Name = aggFunc.Name
 				newAggFunc.RetTp = partial.Schema.Columns[partialCursor-1].GetType()
 				partial.AggFuncs = append(partial.AggFuncs, newAggFunc)
-				if aggFunc.Name == ast.AggFuncGroupConcat {
-					// append the last separator arg
+				if aggFunc.Name == ast.AggFuncGroupConcat || aggFunc.Name == ast.AggFuncApproxTopK {
+					// append the last separator arg, or the k arg of approx_top_k
 					args = append(args, aggFunc.Args[len(aggFunc.Args)-1])
 				}
 			} else {
@@ -1853,7 +1858,7 @@ func computePartialCursorOffset(name string) int {
 	if aggregation.NeedValue(name) {
 		offset++
 	}
-	if name == ast.AggFuncApproxCountDistinct {
+	if name == ast.AggFuncApproxCountDistinct || name == ast.AggFuncApproxTopK || aggregation.IsExtensionAggFunc(name) {
 		offset++
 	}
 	return offset
@@ -266,6 +266,8 @@ func buildLogicalJoinSchema(joinType JoinType, join LogicalPlan) *expression.Sch
 		resetNotNullFlag(newSchema, leftSchema.Len(), newSchema.Len())
 	} else if joinType == RightOuterJoin {
 		resetNotNullFlag(newSchema, 0, leftSchema.Len())
+	} else if joinType == FullOuterJoin {
+		resetNotNullFlag(newSchema, 0, newSchema.Len())
 	}
 	return newSchema
 }
@@ -286,6 +288,8 @@ func BuildPhysicalJoinSchema(joinType JoinType, join PhysicalPlan) *expression.S
 		resetNotNullFlag(newSchema, leftSchema.Len(), newSchema.Len())
 	} else if joinType == RightOuterJoin {
 		resetNotNullFlag(newSchema, 0, leftSchema.Len())
+	} else if joinType == FullOuterJoin {
+		resetNotNullFlag(newSchema, 0, newSchema.Len())
 	}
 	return newSchema
 }
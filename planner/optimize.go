@@ -33,6 +33,7 @@ import (
 	"github.com/pingcap/tidb/metrics"
 	"github.com/pingcap/tidb/parser"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/planner/cascades"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/planner/util/debugtrace"
@@ -63,17 +64,17 @@ func IsReadOnly(node ast.Node, vars *variable.SessionVars) bool {
 	return ast.IsReadOnly(node)
 }
 
-func matchSQLBinding(sctx sessionctx.Context, stmtNode ast.StmtNode) (bindRecord *bindinfo.BindRecord, scope string, matched bool) {
+func matchSQLBinding(sctx sessionctx.Context, stmtNode ast.StmtNode) (bindRecord *bindinfo.BindRecord, scope, crossDBSchema string, matched bool) {
 	useBinding := sctx.GetSessionVars().UsePlanBaselines
 	if !useBinding || stmtNode == nil {
-		return nil, "", false
+		return nil, "", "", false
 	}
 	var err error
-	bindRecord, scope, err = getBindRecord(sctx, stmtNode)
+	bindRecord, scope, crossDBSchema, err = getBindRecord(sctx, stmtNode)
 	if err != nil || bindRecord == nil || len(bindRecord.Bindings) == 0 {
-		return nil, "", false
+		return nil, "", "", false
 	}
-	return bindRecord, scope, true
+	return bindRecord, scope, crossDBSchema, true
 }
 
 // getPlanFromNonPreparedPlanCache tries to get an available cached plan from the NonPrepared Plan Cache for this stmt.
@@ -223,7 +224,7 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 
 	enableUseBinding := sessVars.UsePlanBaselines
 	stmtNode, isStmtNode := node.(ast.StmtNode)
-	bindRecord, scope, match := matchSQLBinding(sctx, stmtNode)
+	bindRecord, scope, crossDBSchema, match := matchSQLBinding(sctx, stmtNode)
 	useBinding := enableUseBinding && isStmtNode && match
 	if sessVars.StmtCtx.EnableOptimizerDebugTrace {
 		failpoint.Inject("SetBindingTimeToZero", func(val failpoint.Value) {
@@ -282,8 +283,13 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 				core.DebugTraceTryBinding(sctx, binding.Hint)
 			}
 			metrics.BindUsageCounter.WithLabelValues(scope).Inc()
-			hint.BindHint(stmtNode, binding.Hint)
-			curStmtHints, _, curWarns := handleStmtHints(binding.Hint.GetFirstTableHints())
+			bindingHint := binding.Hint
+			if crossDBSchema != "" {
+				// The tables in the hints of a cross-database binding refer to the schema the statement runs on.
+				bindingHint = bindingHint.WithDefaultDB(crossDBSchema)
+			}
+			hint.BindHint(stmtNode, bindingHint)
+			curStmtHints, _, curWarns := handleStmtHints(bindingHint.GetFirstTableHints())
 			sessVars.StmtCtx.StmtHints = curStmtHints
 			// update session var by hint /set_var/
 			for name, val := range sessVars.StmtCtx.StmtHints.SetVars {
@@ -295,6 +301,10 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 			plan, curNames, cost, err := optimize(ctx, sctx, node, is)
 			if err != nil {
 				binding.Status = bindinfo.Invalid
+				// A cross-database binding may be invalid only for some of the schemas, so we don't drop it.
+				if crossDBSchema != "" {
+					continue
+				}
 				handleInvalidBindRecord(ctx, sctx, scope, bindinfo.BindRecord{
 					OriginalSQL: bindRecord.OriginalSQL,
 					Db:          bindRecord.Db,
@@ -316,6 +326,9 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 			}
 			sessVars.StmtCtx.BindSQL = chosenBinding.BindSQL
 			sessVars.FoundInBinding = true
+			if crossDBSchema != "" {
+				recordCrossDBBindingHit(sctx, scope, bindRecord.OriginalSQL, crossDBSchema)
+			}
 			if sessVars.StmtCtx.InVerboseExplain {
 				sessVars.StmtCtx.AppendNote(errors.Errorf("Using the bindSQL: %v", chosenBinding.BindSQL))
 			} else {
@@ -347,11 +360,12 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	// 3. the original binding contains no read_from_storage hint;
 	// 4. the plan when ignoring bindings contains no tiflash hint;
 	// 5. the pending verified binding has not been added already;
+	// 6. the binding is not a cross-database binding;
 	savedStmtHints := sessVars.StmtCtx.StmtHints
 	defer func() {
 		sessVars.StmtCtx.StmtHints = savedStmtHints
 	}()
-	if sessVars.EvolvePlanBaselines && bestPlanFromBind != nil &&
+	if sessVars.EvolvePlanBaselines && bestPlanFromBind != nil && crossDBSchema == "" &&
 		sessVars.SelectLimit == math.MaxUint64 { // do not evolve this query if sql_select_limit is enabled
 		// Check bestPlanFromBind firstly to avoid nil stmtNode.
 		if _, ok := stmtNode.(*ast.SelectStmt); ok && !bindRecord.Bindings[0].Hint.ContainTableHint(core.HintReadFromStorage) {
@@ -401,6 +415,38 @@ func OptimizeForForeignKeyCascade(ctx context.Context, sctx sessionctx.Context,
 	return p, nil
 }
 
+// OptimizeForTrigger does optimization and creates a Plan for a statement in the body of a trigger.
+// The node must be prepared first.
+// Compare to Optimize, OptimizeForTrigger doesn't consider plan cache and plan binding, and the privileges
+// are checked with the definer of the trigger.
+func OptimizeForTrigger(ctx context.Context, sctx sessionctx.Context, node ast.StmtNode, is infoschema.InfoSchema, definer *auth.UserIdentity) (core.Plan, error) {
+	hintProcessor := &hint.BlockHintProcessor{Ctx: sctx}
+	node.Accept(hintProcessor)
+	defer hintProcessor.HandleUnusedViewHints()
+	builder := planBuilderPool.Get().(*core.PlanBuilder)
+	defer planBuilderPool.Put(builder.ResetForReuse())
+	builder.Init(sctx, is, hintProcessor)
+	p, err := buildLogicalPlan(ctx, sctx, node, builder)
+	if err != nil {
+		return nil, err
+	}
+	// The internal sessions have no user, their privileges are not checked.
+	if pm := privilege.GetPrivilegeManager(sctx); pm != nil && sctx.GetSessionVars().User != nil {
+		if err := core.CheckPrivilegeWithUser(pm, definer, builder.GetVisitInfo()); err != nil {
+			return nil, err
+		}
+	}
+	if err := core.CheckTableLock(sctx, is, builder.GetVisitInfo()); err != nil {
+		return nil, err
+	}
+	logic, isLogicalPlan := p.(core.LogicalPlan)
+	if !isLogicalPlan {
+		return p, nil
+	}
+	finalPlan, _, err := core.DoOptimize(ctx, sctx, builder.GetOptFlag(), logic)
+	return finalPlan, err
+}
+
 func allowInReadOnlyMode(sctx sessionctx.Context, node ast.Node) (bool, error) {
 	pm := privilege.GetPrivilegeManager(sctx)
 	if pm == nil {
@@ -621,29 +667,52 @@ func ExtractSelectAndNormalizeDigest(stmtNode ast.StmtNode, specifiledDB string)
 	return nil, "", "", nil
 }
 
-func getBindRecord(ctx sessionctx.Context, stmt ast.StmtNode) (*bindinfo.BindRecord, string, error) {
+// getBindRecord returns the matched BindRecord and its scope. If the BindRecord is a
+// cross-database binding, the schema the statement runs on is returned as well.
+func getBindRecord(ctx sessionctx.Context, stmt ast.StmtNode) (*bindinfo.BindRecord, string, string, error) {
 	// When the domain is initializing, the bind will be nil.
 	if ctx.Value(bindinfo.SessionBindInfoKeyType) == nil {
-		return nil, "", nil
+		return nil, "", "", nil
 	}
-	stmtNode, normalizedSQL, hash, err := ExtractSelectAndNormalizeDigest(stmt, ctx.GetSessionVars().CurrentDB)
+	currentDB := ctx.GetSessionVars().CurrentDB
+	stmtNode, normalizedSQL, hash, err := ExtractSelectAndNormalizeDigest(stmt, currentDB)
 	if err != nil || stmtNode == nil {
-		return nil, "", err
+		return nil, "", "", err
+	}
+	// For `explain select * from t`, the statement text is not used to match bindings.
+	if normalizedSQL == "" {
+		return nil, "", "", nil
 	}
 	sessionHandle := ctx.Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
 	bindRecord := sessionHandle.GetBindRecord(hash, normalizedSQL, "")
+	schema := ""
+	if bindRecord == nil {
+		bindRecord, schema = sessionHandle.MatchCrossDBBindRecord(stmtNode, currentDB)
+	}
 	if bindRecord != nil {
 		if bindRecord.HasEnabledBinding() {
-			return bindRecord, metrics.ScopeSession, nil
+			return bindRecord, metrics.ScopeSession, schema, nil
 		}
-		return nil, "", nil
+		return nil, "", "", nil
 	}
 	globalHandle := domain.GetDomain(ctx).BindHandle()
 	if globalHandle == nil {
-		return nil, "", nil
+		return nil, "", "", nil
 	}
 	bindRecord = globalHandle.GetBindRecord(hash, normalizedSQL, "")
-	return bindRecord, metrics.ScopeGlobal, nil
+	if bindRecord == nil {
+		bindRecord, schema = globalHandle.MatchCrossDBBindRecord(stmtNode, currentDB)
+	}
+	return bindRecord, metrics.ScopeGlobal, schema, nil
+}
+
+func recordCrossDBBindingHit(sctx sessionctx.Context, scope, originalSQL, schema string) {
+	if scope == metrics.ScopeSession {
+		sessionHandle := sctx.Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
+		sessionHandle.RecordCrossDBBindingHit(originalSQL, schema)
+		return
+	}
+	domain.GetDomain(sctx).BindHandle().RecordCrossDBBindingHit(originalSQL, schema)
 }
 
 func handleInvalidBindRecord(ctx context.Context, sctx sessionctx.Context, level string, bindRecord bindinfo.BindRecord) {
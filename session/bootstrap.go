@@ -561,6 +561,7 @@ const (
     	deleted_rows bigint(64) DEFAULT NULL,
     	error_delete_rows bigint(64) DEFAULT NULL,
     	status varchar(64) NOT NULL,
+    	archive_error_rows bigint(64) DEFAULT NULL,
     	key(table_schema, table_name, create_time),
     	key(parent_table_id, create_time),
     	key(create_time)
@@ -612,6 +613,7 @@ const (
 		original_sql TEXT NOT NULL,
 		plan_digest TEXT NOT NULL,
 		tidb_server varchar(512),
+		rule varchar(512) DEFAULT '',
 		INDEX plan_index(plan_digest(64)) COMMENT "accelerate the speed when select runaway query",
 		INDEX time_index(time) COMMENT "accelerate the speed when querying with active watch"
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
@@ -626,6 +628,7 @@ const (
 		watch_text TEXT NOT NULL,
 		source varchar(512) NOT NULL,
 		action bigint(10),
+		switch_group_name varchar(32) DEFAULT '',
 		INDEX sql_index(resource_group_name,watch_text(700)) COMMENT "accelerate the speed when select quarantined query",
 		INDEX time_index(end_time) COMMENT "accelerate the speed when querying with active watch"
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
@@ -641,7 +644,29 @@ const (
 		watch_text TEXT NOT NULL,
 		source varchar(512) NOT NULL,
 		action bigint(10),
-		done_time TIMESTAMP(6) NOT NULL
+		done_time TIMESTAMP(6) NOT NULL,
+		switch_group_name varchar(32) DEFAULT ''
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
+	// CreateRoutinesTable stores the definitions of stored procedures.
+	CreateRoutinesTable = `CREATE TABLE IF NOT EXISTS mysql.routines (
+		routine_schema VARCHAR(64) NOT NULL,
+		routine_name VARCHAR(64) NOT NULL,
+		routine_type ENUM('FUNCTION','PROCEDURE') NOT NULL,
+		parameter_str BLOB NOT NULL,
+		definition LONGBLOB NOT NULL,
+		definer VARCHAR(288) NOT NULL,
+		security_type ENUM('DEFINER','INVOKER') NOT NULL DEFAULT 'INVOKER',
+		is_deterministic ENUM('YES','NO') NOT NULL DEFAULT 'NO',
+		sql_data_access ENUM('CONTAINS SQL','NO SQL','READS SQL DATA','MODIFIES SQL DATA') NOT NULL DEFAULT 'CONTAINS SQL',
+		sql_mode VARCHAR(8192) NOT NULL,
+		character_set_client VARCHAR(32) NOT NULL,
+		collation_connection VARCHAR(32) NOT NULL,
+		database_collation VARCHAR(32) NOT NULL,
+		comment TEXT NOT NULL,
+		created TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+		last_altered TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (routine_schema, routine_name, routine_type)
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
 
 	// CreateImportJobs is a table that IMPORT INTO uses.
@@ -664,6 +689,52 @@ const (
 		PRIMARY KEY (id),
 		KEY (created_by),
 		KEY (status));`
+
+	// CreateEventHistory is a table to store the execution history of events.
+	CreateEventHistory = `CREATE TABLE IF NOT EXISTS mysql.tidb_event_history (
+		event_id varchar(64) NOT NULL,
+		event_schema varchar(64) NOT NULL,
+		event_name varchar(64) NOT NULL,
+		definer varchar(288) NOT NULL,
+		start_time timestamp NOT NULL,
+		end_time timestamp NOT NULL,
+		status varchar(64) NOT NULL,
+		error_message text,
+		PRIMARY KEY (event_id),
+		KEY (event_schema, event_name, start_time),
+		KEY (start_time)
+	);`
+
+	// CreatePlanCacheSnapshot stores the prepared statements whose plans are cached by each instance,
+	// they are used to warm up the plan cache after restarting.
+	CreatePlanCacheSnapshot = `CREATE TABLE IF NOT EXISTS mysql.tidb_plan_cache_snapshot (
+		instance varchar(512) NOT NULL,
+		db varchar(64) NOT NULL,
+		stmt_digest varchar(64) NOT NULL,
+		stmt_text longtext NOT NULL,
+		param_types text NOT NULL,
+		bind_sql longtext,
+		table_version bigint(64) unsigned NOT NULL,
+		stats_version bigint(64) unsigned NOT NULL,
+		hits bigint(64) unsigned NOT NULL DEFAULT 0,
+		update_time timestamp NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+		PRIMARY KEY (instance, db, stmt_digest)
+	);`
+
+	// CreateBindInfoEvolution stores the results of verifying the bindings by the baseline evolution.
+	CreateBindInfoEvolution = `CREATE TABLE IF NOT EXISTS mysql.bind_info_evolution (
+		original_sql TEXT NOT NULL,
+		default_db TEXT NOT NULL,
+		bind_sql TEXT NOT NULL,
+		sql_digest varchar(64),
+		status varchar(64) NOT NULL,
+		reason TEXT,
+		accepted_plan_time DOUBLE,
+		verified_plan_time DOUBLE,
+		evolve_time TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX sql_index(sql_digest),
+		INDEX time_index(evolve_time)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
 )
 
 // CreateTimers is a table to store all timers for tidb
@@ -975,11 +1046,27 @@ const (
 	version172 = 172
 	// version 173 add column `summary` to `mysql.tidb_background_subtask`.
 	version173 = 173
+	// version 174 add table `mysql.routines` to store stored procedures.
+	version174 = 174
+	// version 175 add column `archive_error_rows` to `mysql.tidb_ttl_job_history`.
+	version175 = 175
+	// version 176 add table `mysql.tidb_event_history` to store the execution history of events.
+	version176 = 176
+	// version 177 add column `switch_group_name` to `mysql.tidb_runaway_watch` and `mysql.tidb_runaway_watch_done`.
+	version177 = 177
+	// version 178 add column `rule` to `mysql.tidb_runaway_queries`.
+	version178 = 178
+	// version 179 add table `mysql.tidb_plan_cache_snapshot` to store the plan cache snapshot of each instance.
+	version179 = 179
+	// version 180 add table `mysql.bind_info_evolution` to store the history of the baseline evolution.
+	version180 = 180
+	// version 181 add columns `is_deterministic` and `sql_data_access` to `mysql.routines`.
+	version181 = 181
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version173
+var currentBootstrapVersion int64 = version181
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1121,6 +1208,14 @@ var (
 		upgradeToVer171,
 		upgradeToVer172,
 		upgradeToVer173,
+		upgradeToVer174,
+		upgradeToVer175,
+		upgradeToVer176,
+		upgradeToVer177,
+		upgradeToVer178,
+		upgradeToVer179,
+		upgradeToVer180,
+		upgradeToVer181,
 	}
 )
 
@@ -2721,6 +2816,64 @@ func upgradeToVer173(s Session, ver int64) {
 	doReentrantDDL(s, "ALTER TABLE mysql.tidb_background_subtask ADD COLUMN `summary` JSON", infoschema.ErrColumnExists)
 }
 
+func upgradeToVer174(s Session, ver int64) {
+	if ver >= version174 {
+		return
+	}
+	mustExecute(s, CreateRoutinesTable)
+}
+
+func upgradeToVer175(s Session, ver int64) {
+	if ver >= version175 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_ttl_job_history ADD COLUMN `archive_error_rows` bigint(64) DEFAULT NULL AFTER `status`", infoschema.ErrColumnExists)
+}
+
+func upgradeToVer176(s Session, ver int64) {
+	if ver >= version176 {
+		return
+	}
+	mustExecute(s, CreateEventHistory)
+}
+
+func upgradeToVer177(s Session, ver int64) {
+	if ver >= version177 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_runaway_watch ADD COLUMN `switch_group_name` VARCHAR(32) DEFAULT '' AFTER `action`", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_runaway_watch_done ADD COLUMN `switch_group_name` VARCHAR(32) DEFAULT '' AFTER `done_time`", infoschema.ErrColumnExists)
+}
+
+func upgradeToVer178(s Session, ver int64) {
+	if ver >= version178 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_runaway_queries ADD COLUMN `rule` VARCHAR(512) DEFAULT '' AFTER `tidb_server`", infoschema.ErrColumnExists)
+}
+
+func upgradeToVer179(s Session, ver int64) {
+	if ver >= version179 {
+		return
+	}
+	mustExecute(s, CreatePlanCacheSnapshot)
+}
+
+func upgradeToVer180(s Session, ver int64) {
+	if ver >= version180 {
+		return
+	}
+	mustExecute(s, CreateBindInfoEvolution)
+}
+
+func upgradeToVer181(s Session, ver int64) {
+	if ver >= version181 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.routines ADD COLUMN `is_deterministic` ENUM('YES','NO') NOT NULL DEFAULT 'NO' AFTER `security_type`", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.routines ADD COLUMN `sql_data_access` ENUM('CONTAINS SQL','NO SQL','READS SQL DATA','MODIFIES SQL DATA') NOT NULL DEFAULT 'CONTAINS SQL' AFTER `is_deterministic`", infoschema.ErrColumnExists)
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -2846,6 +2999,14 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateTimers)
 	// create runaway_watch done
 	mustExecute(s, CreateDoneRunawayWatchTable)
+	// create routines
+	mustExecute(s, CreateRoutinesTable)
+	// create tidb_event_history
+	mustExecute(s, CreateEventHistory)
+	// create tidb_plan_cache_snapshot
+	mustExecute(s, CreatePlanCacheSnapshot)
+	// create bind_info_evolution
+	mustExecute(s, CreateBindInfoEvolution)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
@@ -0,0 +1,157 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package session
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/domain"
+	"github.com/pingcap/tidb/executor"
+	"github.com/pingcap/tidb/expression"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/ast"
+	plannercore "github.com/pingcap/tidb/planner/core"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/sessiontxn"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/logutil"
+	utilpc "github.com/pingcap/tidb/util/plancache"
+	"go.uber.org/zap"
+)
+
+// newPlanCacheWarmUp returns a function to warm up the plan cache with the snapshot saved before restarting.
+func newPlanCacheWarmUp(store kv.Storage, dom *domain.Domain) func() {
+	return func() {
+		start := time.Now()
+		warmed, err := warmUpPlanCache(store, dom)
+		if err != nil {
+			logutil.BgLogger().Warn("warm up plan cache failed", zap.Error(err))
+			return
+		}
+		logutil.BgLogger().Info("warm up plan cache finished", zap.Int("statements", warmed),
+			zap.Duration("cost", time.Since(start)))
+	}
+}
+
+// warmUpPlanCache re-prepares the statements in the saved plan cache snapshot of this instance and puts
+// their plans into the instance plan cache without executing them, so the sessions can use the plans
+// before building their own. The entries whose schema, statistics or bindings have changed since they
+// were saved are dropped. It returns the number of statements warmed up.
+func warmUpPlanCache(store kv.Storage, dom *domain.Domain) (int, error) {
+	size := variable.PlanCacheSnapshotSize.Load()
+	if size <= 0 {
+		return 0, nil
+	}
+	entries, err := dom.LoadPlanCacheSnapshot(int(size))
+	if err != nil || len(entries) == 0 {
+		return 0, err
+	}
+	se, err := createSession(store)
+	if err != nil {
+		return 0, err
+	}
+	defer se.Close()
+	se.sessionVars.InPlanCacheWarmUp = true
+	dom.InstancePlanCache().Clear()
+
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnOthers)
+	warmed := 0
+	for _, entry := range entries {
+		ok, err := se.warmUpPlanCacheEntry(ctx, dom, entry)
+		if err != nil {
+			logutil.BgLogger().Warn("skip the plan cache snapshot entry which fails to warm up",
+				zap.String("db", entry.DB), zap.String("stmt", entry.StmtText), zap.Error(err))
+		}
+		if ok {
+			warmed++
+		}
+	}
+	if warmed < len(entries) {
+		// the snapshot only contains the warmed entries now, save it to drop the stale ones
+		return warmed, dom.SavePlanCacheSnapshot(ctx)
+	}
+	return warmed, nil
+}
+
+// warmUpPlanCacheEntry prepares the statement and builds its plan with parameters of the recorded types.
+// It returns false if the entry is stale.
+func (s *session) warmUpPlanCacheEntry(ctx context.Context, dom *domain.Domain, entry utilpc.SnapshotEntry) (bool, error) {
+	s.sessionVars.CurrentDB = entry.DB
+	stmtID, paramCount, _, err := s.PrepareStmt(entry.StmtText)
+	if err != nil {
+		return false, err
+	}
+	defer func() {
+		if err := s.DropPreparedStmt(stmtID); err != nil {
+			logutil.BgLogger().Warn("drop the prepared statement failed", zap.Error(err))
+		}
+	}()
+	prepStmt, err := s.sessionVars.GetPreparedStmtByID(stmtID)
+	if err != nil {
+		return false, err
+	}
+	stmt, ok := prepStmt.(*plannercore.PlanCacheStmt)
+	if !ok {
+		return false, errors.Errorf("invalid PlanCacheStmt type")
+	}
+	if paramCount != len(entry.ParamTypes) {
+		return false, nil
+	}
+	tableVersion, statsVersion := plannercore.PlanCacheSnapshotVersions(s, dom.InfoSchema(), stmt)
+	if tableVersion != entry.TableVersion || statsVersion != entry.StatsVersion {
+		return false, nil
+	}
+	if bindSQL, _ := plannercore.GetBindSQL4PlanCache(s, stmt); bindSQL != entry.BindSQL {
+		return false, nil
+	}
+
+	params := make([]expression.Expression, 0, len(entry.ParamTypes))
+	for _, tp := range entry.ParamTypes {
+		// the values don't matter since the parameters are only used to build the plan
+		zero := types.NewIntDatum(0)
+		val, err := zero.ConvertTo(s.sessionVars.StmtCtx, tp)
+		if err != nil {
+			val = types.NewDatum(nil)
+		}
+		params = append(params, &expression.Constant{Value: val, RetType: tp})
+	}
+	if err := s.compileOnly(ctx, &ast.ExecuteStmt{PrepStmt: stmt, BinaryArgs: params}); err != nil {
+		return false, err
+	}
+	// keep the saved hits of the entry, which are lost when its plan is put into the plan cache
+	entry.SQLDigest = stmt.SQLDigest.String()
+	dom.PlanCacheSnapshot().Record(entry, int(variable.PlanCacheSnapshotSize.Load()))
+	return true, nil
+}
+
+// compileOnly builds the plan of the statement without executing it.
+func (s *session) compileOnly(ctx context.Context, stmtNode ast.StmtNode) error {
+	defer s.RollbackTxn(ctx)
+	if err := s.PrepareTxnCtx(ctx); err != nil {
+		return err
+	}
+	if err := executor.ResetContextOfStmt(s, stmtNode); err != nil {
+		return err
+	}
+	defer sessiontxn.GetTxnManager(s).OnStmtEnd()
+	if err := s.onTxnManagerStmtStartOrRetry(ctx, stmtNode); err != nil {
+		return err
+	}
+	compiler := executor.Compiler{Ctx: s}
+	_, err := compiler.Compile(ctx, stmtNode)
+	return err
+}
@@ -46,6 +46,7 @@ import (
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/domain/infosync"
 	"github.com/pingcap/tidb/errno"
+	"github.com/pingcap/tidb/eventsched"
 	"github.com/pingcap/tidb/executor"
 	"github.com/pingcap/tidb/expression"
 	"github.com/pingcap/tidb/extension"
@@ -89,6 +90,7 @@ import (
 	"github.com/pingcap/tidb/util/chunk"
 	"github.com/pingcap/tidb/util/collate"
 	"github.com/pingcap/tidb/util/dbterror"
+	"github.com/pingcap/tidb/util/dbterror/exeerrors"
 	"github.com/pingcap/tidb/util/execdetails"
 	"github.com/pingcap/tidb/util/intest"
 	"github.com/pingcap/tidb/util/kvcache"
@@ -2986,6 +2988,22 @@ func (s *session) MatchIdentity(username, remoteHost string) (*auth.UserIdentity
 	return nil, fmt.Errorf("could not find matching user in MatchIdentity: %s, %s", username, remoteHost)
 }
 
+// newEventSessionFactory returns the factory to create the sessions executing the bodies of events,
+// a session is authenticated as the definer of the event without verification.
+func newEventSessionFactory(store kv.Storage) eventsched.SessionFactory {
+	return func(user, host string) (eventsched.Session, error) {
+		se, err := CreateSession(store)
+		if err != nil {
+			return nil, err
+		}
+		if user != "" && !se.AuthWithoutVerification(&auth.UserIdentity{Username: user, Hostname: host}) {
+			se.Close()
+			return nil, exeerrors.ErrNoSuchUser.GenWithStackByArgs(user, host)
+		}
+		return se, nil
+	}
+}
+
 // AuthWithoutVerification is required by the ResetConnection RPC
 func (s *session) AuthWithoutVerification(user *auth.UserIdentity) bool {
 	pm := privilege.GetPrivilegeManager(s)
@@ -3494,6 +3512,9 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 		return s
 	}
 	dom.StartTTLJobManager()
+	dom.StartMViewRefreshManager()
+	dom.StartEventManager(newEventSessionFactory(store))
+	dom.StartPlanCacheSnapshotLoop(newPlanCacheWarmUp(store, dom))
 
 	analyzeCtxs, err := createSessions(store, analyzeConcurrencyQuota)
 	if err != nil {
@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/domain"
 	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/ast"
+	"github.com/pingcap/tidb/parser/auth"
 	"github.com/pingcap/tidb/planner/core"
 	"github.com/pingcap/tidb/tablecodec"
 	"github.com/pingcap/tidb/util"
@@ -112,3 +113,97 @@ func TestKeysNeedLock(t *testing.T) {
 		require.True(t, need)
 	}
 }
+
+func TestPlanCacheWarmUp(t *testing.T) {
+	store, dom := CreateStoreAndBootstrap(t)
+	defer func() { require.NoError(t, store.Close()) }()
+	defer dom.Close()
+
+	newSession := func() Session {
+		se, err := createSession(store)
+		require.NoError(t, err)
+		// the statements of the sessions without users aren't recorded in the statement summary
+		se.GetSessionVars().User = &auth.UserIdentity{Username: "root", Hostname: "%"}
+		return se
+	}
+	se := newSession()
+	ctx := context.Background()
+	queryRows := func(se Session, sql string) [][]string {
+		rs := MustExecToRecodeSet(t, se, sql)
+		rows, err := ResultSetToStringSlice(ctx, se, rs)
+		require.NoError(t, err)
+		return rows
+	}
+
+	MustExec(t, se, "set global tidb_plan_cache_snapshot_size = 10")
+	defer MustExec(t, se, "set global tidb_plan_cache_snapshot_size = default")
+	MustExec(t, se, "use test")
+	MustExec(t, se, "create table t1 (a int, b int, key(a))")
+	MustExec(t, se, "create table t2 (a int, b int)")
+	MustExec(t, se, "insert into t1 values (1, 1), (2, 2)")
+	MustExec(t, se, "prepare st1 from 'select * from t1 where a = ?'")
+	MustExec(t, se, "prepare st2 from 'select * from t2 where b > ?'")
+	MustExec(t, se, "set @a = 1")
+	for i := 0; i < 3; i++ {
+		MustExec(t, se, "execute st1 using @a")
+	}
+	MustExec(t, se, "execute st2 using @a")
+	// the statements in internal sessions are not recorded
+	_, _, err := se.(*session).ExecRestrictedSQL(kv.WithInternalSourceType(ctx, kv.InternalTxnOthers), nil, "select * from test.t1 where a = %?", 1)
+	require.NoError(t, err)
+
+	// the hits are the execution counts in the statement summary
+	require.NoError(t, dom.SavePlanCacheSnapshot(ctx))
+	require.Equal(t, [][]string{
+		{"test", "select * from t1 where a = ?", "3"},
+		{"test", "select * from t2 where b > ?", "1"},
+	}, queryRows(se, "select db, stmt_text, hits from mysql.tidb_plan_cache_snapshot order by hits desc"))
+
+	// restart with a changed schema of t2
+	MustExec(t, se, "alter table t2 add column c int")
+	snapshot := dom.PlanCacheSnapshot()
+	snapshot.Remove("test", "select * from t1 where a = ?")
+	snapshot.Remove("test", "select * from t2 where b > ?")
+	warmed, err := warmUpPlanCache(store, dom)
+	require.NoError(t, err)
+	require.Equal(t, 1, warmed)
+	require.Equal(t, 1, dom.InstancePlanCache().Size())
+	entries := snapshot.TopN(10, nil)
+	require.Len(t, entries, 1)
+	require.Equal(t, "select * from t1 where a = ?", entries[0].StmtText)
+	require.Equal(t, uint64(3), entries[0].Hits)
+	require.Equal(t, [][]string{
+		{"test", "select * from t1 where a = ?", "3"},
+	}, queryRows(se, "select db, stmt_text, hits from mysql.tidb_plan_cache_snapshot order by hits desc"))
+
+	// a new session uses the warmed plan with its own parameters at the first execution
+	se2 := newSession()
+	MustExec(t, se2, "use test")
+	MustExec(t, se2, "prepare st1 from 'select * from t1 where a = ?'")
+	MustExec(t, se2, "prepare st2 from 'select * from t2 where b > ?'")
+	MustExec(t, se2, "set @a = 2")
+	require.Equal(t, [][]string{{"2", "2"}}, queryRows(se2, "execute st1 using @a"))
+	require.Equal(t, [][]string{{"1"}}, queryRows(se2, "select @@last_plan_from_cache"))
+	require.Equal(t, [][]string{{"2", "2"}}, queryRows(se2, "execute st1 using @a"))
+	require.Equal(t, [][]string{{"1"}}, queryRows(se2, "select @@last_plan_from_cache"))
+	MustExec(t, se2, "execute st2 using @a")
+	require.Equal(t, [][]string{{"0"}}, queryRows(se2, "select @@last_plan_from_cache"))
+	// the plans in the instance plan cache are only shared by the sessions with the same optimizer variables
+	for _, setVar := range []string{"set tidb_opt_prefer_range_scan = on", "set tidb_enable_index_merge = off"} {
+		se4 := newSession()
+		MustExec(t, se4, "use test")
+		MustExec(t, se4, setVar)
+		MustExec(t, se4, "prepare st1 from 'select * from t1 where a = ?'")
+		MustExec(t, se4, "set @a = 1")
+		require.Equal(t, [][]string{{"1", "1"}}, queryRows(se4, "execute st1 using @a"))
+		require.Equal(t, [][]string{{"0"}}, queryRows(se4, "select @@last_plan_from_cache"))
+	}
+	// the plans in the instance plan cache are not used once the statistics change
+	MustExec(t, se2, "analyze table t1")
+	se3 := newSession()
+	MustExec(t, se3, "use test")
+	MustExec(t, se3, "prepare st1 from 'select * from t1 where a = ?'")
+	MustExec(t, se3, "set @a = 1")
+	require.Equal(t, [][]string{{"1", "1"}}, queryRows(se3, "execute st1 using @a"))
+	require.Equal(t, [][]string{{"0"}}, queryRows(se3, "select @@last_plan_from_cache"))
+}
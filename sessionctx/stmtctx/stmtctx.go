@@ -191,6 +191,9 @@ type StatementContext struct {
 	IsStaleness     bool
 	InRestrictedSQL bool
 	ViewDepth       int32
+	// PlanCacheVariant describes which cached plan variant is used when the plan cache keeps several plans
+	// for this statement by the selectivity of parameters, it's shown in `EXPLAIN FOR CONNECTION`.
+	PlanCacheVariant string
 	// mu struct holds variables that change during execution.
 	mu struct {
 		sync.Mutex
@@ -772,6 +772,10 @@ type SessionVars struct {
 	// InRestrictedSQL indicates if the session is handling restricted SQL execution.
 	InRestrictedSQL bool
 
+	// InPlanCacheWarmUp indicates if the session is warming up the instance plan cache, the plans it builds
+	// are put into the instance plan cache instead of its own.
+	InPlanCacheWarmUp bool
+
 	// SnapshotTS is used for reading history data. For simplicity, SnapshotTS only supports distsql request.
 	SnapshotTS uint64
 
@@ -1373,6 +1377,14 @@ type SessionVars struct {
 	// EnablePlanCacheForSubquery controls whether the prepare statement with sub query can be cached
 	EnablePlanCacheForSubquery bool
 
+	// EnablePlanCacheForUncorrelatedSubquery controls whether the uncorrelated subqueries are kept in the cached plans
+	// and executed at each execution.
+	EnablePlanCacheForUncorrelatedSubquery bool
+
+	// EnablePlanCacheForParamSensitive controls whether the plan cache can keep several plans for one statement,
+	// which are chosen by the selectivity of the parameters.
+	EnablePlanCacheForParamSensitive bool
+
 	// EnableNonPreparedPlanCache indicates whether to enable non-prepared plan cache.
 	EnableNonPreparedPlanCache bool
 
@@ -752,6 +752,7 @@ var defaultSysVars = []*SysVar{
 	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskMaxTime, Value: strconv.Itoa(DefTiDBEvolvePlanTaskMaxTime), Type: TypeInt, MinValue: -1, MaxValue: math.MaxInt64},
 	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskStartTime, Value: DefTiDBEvolvePlanTaskStartTime, Type: TypeTime},
 	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskEndTime, Value: DefTiDBEvolvePlanTaskEndTime, Type: TypeTime},
+	{Scope: ScopeGlobal, Name: TiDBEvolvePlanTaskMemQuota, Value: strconv.Itoa(DefTiDBEvolvePlanTaskMemQuota), Type: TypeInt, MinValue: -1, MaxValue: math.MaxInt64},
 	{Scope: ScopeGlobal, Name: TiDBStoreLimit, Value: strconv.FormatInt(atomic.LoadInt64(&config.GetGlobalConfig().TiKVClient.StoreLimit), 10), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt64, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
 		return strconv.FormatInt(tikvstore.StoreLimit.Load(), 10), nil
 	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
@@ -1945,7 +1946,7 @@ var defaultSysVars = []*SysVar{
 		return nil
 	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEvolvePlanBaselines, Value: BoolToOnOff(DefTiDBEvolvePlanBaselines), Type: TypeBool, Validation: func(vars *SessionVars, normalizedValue string, originalValue string, scope ScopeFlag) (string, error) {
-		if normalizedValue == "ON" && !config.CheckTableBeforeDrop {
+		if normalizedValue == "ON" && !config.CheckTableBeforeDrop && !config.GetGlobalConfig().Experimental.EnablePlanBaselineEvolution {
 			return normalizedValue, errors.Errorf("Cannot enable baseline evolution feature, it is not generally available now")
 		}
 		return normalizedValue, nil
@@ -2478,6 +2479,20 @@ var defaultSysVars = []*SysVar{
 		s.EnablePlanCacheForSubquery = TiDBOptOn(val)
 		return nil
 	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnablePlanCacheForUncorrelatedSubquery, Value: BoolToOnOff(DefTiDBEnablePlanCacheForUncorrelatedSubquery), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnablePlanCacheForUncorrelatedSubquery = TiDBOptOn(val)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBPlanCacheSnapshotSize, Value: strconv.Itoa(DefTiDBPlanCacheSnapshotSize), Type: TypeUnsigned, MinValue: 0, MaxValue: 10000, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		PlanCacheSnapshotSize.Store(TidbOptInt64(val, DefTiDBPlanCacheSnapshotSize))
+		return nil
+	}, GetGlobal: func(_ context.Context, s *SessionVars) (string, error) {
+		return strconv.FormatInt(PlanCacheSnapshotSize.Load(), 10), nil
+	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBEnablePlanCacheForParamSensitive, Value: BoolToOnOff(DefTiDBEnablePlanCacheForParamSensitive), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
+		s.EnablePlanCacheForParamSensitive = TiDBOptOn(val)
+		return nil
+	}},
 	{Scope: ScopeGlobal | ScopeSession, Name: TiDBOptEnableLateMaterialization, Value: BoolToOnOff(DefTiDBOptEnableLateMaterialization), Type: TypeBool, SetSession: func(s *SessionVars, val string) error {
 		s.EnableLateMaterialization = TiDBOptOn(val)
 		return nil
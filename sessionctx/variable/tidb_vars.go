@@ -220,6 +220,8 @@ const (
 	TiDBEvolvePlanTaskStartTime = "tidb_evolve_plan_task_start_time"
 	// TiDBEvolvePlanTaskEndTime is the end time of evolution task.
 	TiDBEvolvePlanTaskEndTime = "tidb_evolve_plan_task_end_time"
+	// TiDBEvolvePlanTaskMemQuota controls the memory quota of the statements run to verify a plan in an evolution task.
+	TiDBEvolvePlanTaskMemQuota = "tidb_evolve_plan_task_mem_quota"
 
 	// TiDBSlowLogThreshold is used to set the slow log threshold in the server.
 	TiDBSlowLogThreshold = "tidb_slow_log_threshold"
@@ -876,6 +878,18 @@ const (
 	// TiDBEnablePlanCacheForSubquery controls whether prepare statement with subquery can be cached
 	TiDBEnablePlanCacheForSubquery = "tidb_enable_plan_cache_for_subquery"
 
+	// TiDBEnablePlanCacheForUncorrelatedSubquery controls whether the uncorrelated subqueries are kept in the cached
+	// plans and executed at each execution, instead of being evaluated to constants which make the plan un-cacheable.
+	TiDBEnablePlanCacheForUncorrelatedSubquery = "tidb_enable_plan_cache_for_uncorrelated_subquery"
+
+	// TiDBEnablePlanCacheForParamSensitive controls whether plan cache can keep several plans for one statement,
+	// which are chosen by the selectivity of the parameters.
+	TiDBEnablePlanCacheForParamSensitive = "tidb_enable_plan_cache_for_param_sensitive"
+
+	// TiDBPlanCacheSnapshotSize indicates how many prepared statements are saved in the plan cache snapshot
+	// of each TiDB instance, and re-prepared to warm up the plan cache when the instance restarts.
+	TiDBPlanCacheSnapshotSize = "tidb_plan_cache_snapshot_size"
+
 	// TiDBOptEnableLateMaterialization indicates whether to enable late materialization
 	TiDBOptEnableLateMaterialization = "tidb_opt_enable_late_materialization"
 	// TiDBLoadBasedReplicaReadThreshold is the wait duration threshold to enable replica read automatically.
@@ -1215,6 +1229,7 @@ const (
 	DefTiDBEvolvePlanTaskMaxTime                   = 600 // 600s
 	DefTiDBEvolvePlanTaskStartTime                 = "00:00 +0000"
 	DefTiDBEvolvePlanTaskEndTime                   = "23:59 +0000"
+	DefTiDBEvolvePlanTaskMemQuota                  = 1 << 30
 	DefInnodbLockWaitTimeout                       = 50 // 50s
 	DefTiDBStoreLimit                              = 0
 	DefTiDBMetricSchemaStep                        = 60 // 60s
@@ -1368,6 +1383,8 @@ const (
 	DefTiDBEnablePlanCacheForParamLimit               = true
 	DefTiFlashComputeDispatchPolicy                   = tiflashcompute.DispatchPolicyConsistentHashStr
 	DefTiDBEnablePlanCacheForSubquery                 = true
+	DefTiDBEnablePlanCacheForParamSensitive           = false
+	DefTiDBPlanCacheSnapshotSize                      = 0
 	DefTiDBLoadBasedReplicaReadThreshold              = time.Second
 	DefTiDBOptEnableLateMaterialization               = true
 	DefTiDBOptOrderingIdxSelThresh                    = 0.0
@@ -1394,6 +1411,8 @@ const (
 	DefTiDBEnableCheckConstraint                      = false
 	DefTiDBSkipMissingPartitionStats                  = true
 	DefTiDBOptObjective                               = OptObjectiveModerate
+	// DefTiDBEnablePlanCacheForUncorrelatedSubquery keeps uncorrelated subqueries out of cached plans by default.
+	DefTiDBEnablePlanCacheForUncorrelatedSubquery = false
 )
 
 // Process global variables.
@@ -1489,6 +1508,7 @@ var (
 	EnableResourceControl     = atomic.NewBool(false)
 	EnableCheckConstraint     = atomic.NewBool(DefTiDBEnableCheckConstraint)
 	SkipMissingPartitionStats = atomic.NewBool(DefTiDBSkipMissingPartitionStats)
+	PlanCacheSnapshotSize     = atomic.NewInt64(DefTiDBPlanCacheSnapshotSize)
 )
 
 var (
@@ -1248,7 +1248,7 @@ func (worker *copIteratorWorker) handleTaskOnce(bo *Backoffer, task *copTask, ch
 		worker.logTimeCopTask(costTime, task, bo, copResp)
 	}
 	if worker.req.RunawayChecker != nil {
-		worker.req.RunawayChecker.AfterCopRequest()
+		worker.req.RunawayChecker.AfterCopRequest(copResp)
 	}
 
 	storeID := strconv.FormatUint(req.Context.GetPeer().GetStoreId(), 10)
@@ -109,6 +109,8 @@ var (
 	ErrOptOnCacheTable = dbterror.ClassDDL.NewStd(mysql.ErrOptOnCacheTable)
 	// ErrCheckConstraintViolated return when check constraint is violated.
 	ErrCheckConstraintViolated = dbterror.ClassTable.NewStd(mysql.ErrCheckConstraintViolated)
+	// ErrTableReadOnly is returned when writing a read only table.
+	ErrTableReadOnly = dbterror.ClassTable.NewStd(mysql.ErrOpenAsReadonly)
 )
 
 // RecordIterFunc is used for low-level record iteration.
@@ -0,0 +1,156 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tables
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/extension"
+	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/auth"
+	"github.com/pingcap/tidb/parser/model"
+	"github.com/pingcap/tidb/parser/terror"
+	"github.com/pingcap/tidb/sessionctx"
+	"github.com/pingcap/tidb/sessionctx/variable"
+	"github.com/pingcap/tidb/table"
+	"github.com/pingcap/tidb/types"
+	"github.com/pingcap/tidb/util/dbterror"
+)
+
+// CustomEngineTable is the table whose rows are stored in a table source provided by extension.
+// It is created by `CREATE TABLE ... ENGINE=xxx` and the planner reads it as a memory table.
+type CustomEngineTable struct {
+	TableCommon
+}
+
+// Type implements the table.Table interface.
+func (*CustomEngineTable) Type() table.Type {
+	return table.VirtualTable
+}
+
+func (t *CustomEngineTable) tableSource() (*extension.TableSourceDef, error) {
+	def, ok := extension.GetTableSource(t.meta.Engine)
+	if !ok {
+		return nil, dbterror.ErrUnknownEngine.GenWithStackByArgs(t.meta.Engine)
+	}
+	return def, nil
+}
+
+// OpenReader opens a reader to read rows from the table source.
+func (t *CustomEngineTable) OpenReader(ctx context.Context, sctx sessionctx.Context, hints *extension.TableReadHints) (extension.TableReader, error) {
+	def, err := t.tableSource()
+	if err != nil {
+		return nil, err
+	}
+	return def.Source.OpenReader(&tableSourceContext{Context: ctx, sctx: sctx}, t.meta, hints)
+}
+
+// IterRecords iterates all the records of the table. The table source doesn't store the handles of the rows,
+// so the handle of a row is its ordinal in the rows read from the table source.
+func (t *CustomEngineTable) IterRecords(ctx context.Context, sctx sessionctx.Context, cols []*table.Column, fn table.RecordIterFunc) error {
+	colInfos := make([]*model.ColumnInfo, 0, len(cols))
+	for _, col := range cols {
+		colInfos = append(colInfos, col.ColumnInfo)
+	}
+
+	reader, err := t.OpenReader(ctx, sctx, &extension.TableReadHints{Columns: colInfos})
+	if err != nil {
+		return err
+	}
+	defer func() {
+		terror.Log(reader.Close())
+	}()
+
+	var ordinal int64
+	for {
+		rows, err := reader.Next()
+		if err != nil {
+			return err
+		}
+
+		if len(rows) == 0 {
+			return nil
+		}
+
+		for _, row := range rows {
+			ordinal++
+			more, err := fn(kv.IntHandle(ordinal), row, cols)
+			if err != nil || !more {
+				return err
+			}
+		}
+	}
+}
+
+// AddRecord implements the table.Table interface. The row is written to the table source directly, it can't be
+// rolled back with the transaction, so the rows can't be written in an explicit transaction.
+// The returned handle is allocated from the row ID allocator of the table.
+func (t *CustomEngineTable) AddRecord(sctx sessionctx.Context, r []types.Datum, _ ...table.AddRecordOption) (kv.Handle, error) {
+	if vars := sctx.GetSessionVars(); vars.InTxn() || !vars.IsAutocommit() {
+		return nil, table.ErrLockOrActiveTransaction.GenWithStackByArgs()
+	}
+
+	def, err := t.tableSource()
+	if err != nil {
+		return nil, err
+	}
+
+	writer, ok := def.Source.(extension.TableSourceWriter)
+	if !ok {
+		return nil, table.ErrTableReadOnly.GenWithStackByArgs(t.meta.Name.O)
+	}
+
+	if cols := len(t.Cols()); len(r) > cols {
+		r = r[:cols]
+	}
+
+	ctx := &tableSourceContext{Context: context.Background(), sctx: sctx}
+	handle, err := AllocHandle(ctx, sctx, t)
+	if err != nil {
+		return nil, err
+	}
+	if err = writer.Write(ctx, t.meta, [][]types.Datum{r}); err != nil {
+		return nil, err
+	}
+	return handle, nil
+}
+
+// UpdateRecord implements the table.Table interface.
+func (*CustomEngineTable) UpdateRecord(context.Context, sessionctx.Context, kv.Handle, []types.Datum, []types.Datum, []bool) error {
+	return table.ErrUnsupportedOp
+}
+
+// RemoveRecord implements the table.Table interface.
+func (*CustomEngineTable) RemoveRecord(sessionctx.Context, kv.Handle, []types.Datum) error {
+	return table.ErrUnsupportedOp
+}
+
+// tableSourceContext implements extension.TableSourceContext
+type tableSourceContext struct {
+	context.Context
+	sctx sessionctx.Context
+}
+
+func (c *tableSourceContext) User() *auth.UserIdentity {
+	return c.sctx.GetSessionVars().User
+}
+
+func (c *tableSourceContext) CurrentDB() string {
+	return c.sctx.GetSessionVars().CurrentDB
+}
+
+func (c *tableSourceContext) ConnectionInfo() *variable.ConnectionInfo {
+	return c.sctx.GetSessionVars().ConnectionInfo
+}
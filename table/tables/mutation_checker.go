@@ -219,6 +219,10 @@ func checkIndexKeys(
 		if !ok {
 			return errors.New("index not found")
 		}
+		if indexInfo.Spatial {
+			// The key of a spatial index holds the MBR of the geometry, it can't be compared with the row.
+			continue
+		}
 
 		var isTmpIdxValAndDeleted bool
 		// If this is temp index data, need remove last byte of index data.
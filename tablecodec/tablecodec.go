@@ -627,7 +627,7 @@ func Unflatten(datum types.Datum, ft *types.FieldType, loc *time.Location) (type
 		datum.SetFloat32(float32(datum.GetFloat64()))
 		return datum, nil
 	case mysql.TypeVarchar, mysql.TypeString, mysql.TypeVarString, mysql.TypeTinyBlob,
-		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob:
+		mysql.TypeMediumBlob, mysql.TypeBlob, mysql.TypeLongBlob, mysql.TypeGeometry:
 		datum.SetString(datum.GetString(), ft.GetCollate())
 	case mysql.TypeTiny, mysql.TypeShort, mysql.TypeYear, mysql.TypeInt24,
 		mysql.TypeLong, mysql.TypeLonglong, mysql.TypeDouble:
@@ -1115,6 +1115,12 @@ func GenIndexKey(sc *stmtctx.StatementContext, tblInfo *model.TableInfo, idxInfo
 	// For string columns, indexes can be created using only the leading part of column values,
 	// using col_name(length) syntax to specify an index prefix length.
 	TruncateIndexValues(tblInfo, idxInfo, indexedValues)
+	if idxInfo.Spatial {
+		indexedValues, err = genSpatialIndexValues(indexedValues)
+		if err != nil {
+			return nil, false, err
+		}
+	}
 	key = GetIndexKeyBuf(buf, RecordRowKeyLen+len(indexedValues)*9+9)
 	key = appendTableIndexPrefix(key, phyTblID)
 	key = codec.EncodeInt(key, idxInfo.ID)
@@ -1132,6 +1138,19 @@ func GenIndexKey(sc *stmtctx.StatementContext, tblInfo *model.TableInfo, idxInfo
 	return
 }
 
+// genSpatialIndexValues replaces the geometry with its MBR, because a spatial index
+// stores the MBR of the geometry instead of the geometry itself.
+func genSpatialIndexValues(indexedValues []types.Datum) ([]types.Datum, error) {
+	if len(indexedValues) != 1 || indexedValues[0].IsNull() {
+		return indexedValues, nil
+	}
+	_, g, err := types.DecodeGeometry(indexedValues[0].GetBytes())
+	if err != nil {
+		return nil, err
+	}
+	return []types.Datum{types.NewBytesDatum(types.EncodeMBRKey(g.Envelope()))}, nil
+}
+
 // TempIndexPrefix used to generate temporary index ID from index ID.
 const TempIndexPrefix = 0x7fff000000000000
 
@@ -76,6 +76,13 @@ func WithSetEnable(enable bool) UpdateTimerOption {
 	}
 }
 
+// WithSetData indicates to set the timer's `Data` field.
+func WithSetData(data []byte) UpdateTimerOption {
+	return func(update *TimerUpdate) {
+		update.Data.Set(data)
+	}
+}
+
 // WithSetTimeZone sets the timezone of the timer
 func WithSetTimeZone(name string) UpdateTimerOption {
 	return func(update *TimerUpdate) {
@@ -91,6 +98,13 @@ func WithSetSchedExpr(tp SchedPolicyType, expr string) UpdateTimerOption {
 	}
 }
 
+// WithSetSchedOptions indicates to set the timer's schedule options.
+func WithSetSchedOptions(opts SchedOptions) UpdateTimerOption {
+	return func(update *TimerUpdate) {
+		update.SchedOptions.Set(opts)
+	}
+}
+
 // WithSetWatermark indicates to set the timer's watermark.
 func WithSetWatermark(watermark time.Time) UpdateTimerOption {
 	return func(update *TimerUpdate) {
@@ -148,6 +148,27 @@ func TestUpdateTimerOption(t *testing.T) {
 	require.True(t, ok)
 	require.Equal(t, "UTC", tz)
 	require.Equal(t, []string{"Tags", "Enable", "TimeZone", "SchedPolicyType", "SchedPolicyExpr", "Watermark", "SummaryData"}, update.FieldsSet())
+
+	// test 'Data' field
+	require.False(t, update.Data.Present())
+	WithSetData([]byte("data1"))(&update)
+	data, ok := update.Data.Get()
+	require.True(t, ok)
+	require.Equal(t, []byte("data1"), data)
+	require.Equal(t, []string{"Tags", "Data", "Enable", "TimeZone", "SchedPolicyType", "SchedPolicyExpr", "Watermark", "SummaryData"}, update.FieldsSet())
+
+	// test 'SchedOptions' field
+	require.False(t, update.SchedOptions.Present())
+	schedOpts := SchedOptions{
+		SchedStartTime:     time.Unix(123, 0),
+		SchedJitter:        time.Minute,
+		SchedMisfirePolicy: MisfireSkip,
+	}
+	WithSetSchedOptions(schedOpts)(&update)
+	gotSchedOpts, ok := update.SchedOptions.Get()
+	require.True(t, ok)
+	require.Equal(t, schedOpts, gotSchedOpts)
+	require.Equal(t, []string{"Tags", "Data", "Enable", "TimeZone", "SchedPolicyType", "SchedPolicyExpr", "SchedOptions", "Watermark", "SummaryData"}, update.FieldsSet())
 }
 
 func TestDefaultClient(t *testing.T) {
@@ -166,6 +166,8 @@ func (c *TimerCond) Clear() {
 type TimerUpdate struct {
 	// Tags indicates to set all tags for a timer.
 	Tags OptionalVal[[]string]
+	// Data indicates to set the timer's `Data` field.
+	Data OptionalVal[[]byte]
 	// Enable indicates to set the timer's `Enable` field.
 	Enable OptionalVal[bool]
 	// TimeZone indicates to set the timer's `TimeZone` field.
@@ -174,6 +176,8 @@ type TimerUpdate struct {
 	SchedPolicyType OptionalVal[SchedPolicyType]
 	// SchedPolicyExpr indicates to set the timer's `SchedPolicyExpr` field.
 	SchedPolicyExpr OptionalVal[string]
+	// SchedOptions indicates to set the timer's `SchedOptions` field.
+	SchedOptions OptionalVal[SchedOptions]
 	// ManualRequest indicates to set the timer's manual request.
 	ManualRequest OptionalVal[ManualRequest]
 	// EventStatus indicates the event status.
@@ -214,6 +218,10 @@ func (u *TimerUpdate) apply(record *TimerRecord) (*TimerRecord, error) {
 		record.Tags = v
 	}
 
+	if v, ok := u.Data.Get(); ok {
+		record.Data = v
+	}
+
 	if v, ok := u.Enable.Get(); ok {
 		record.Enable = v
 	}
@@ -231,6 +239,10 @@ func (u *TimerUpdate) apply(record *TimerRecord) (*TimerRecord, error) {
 		record.SchedPolicyExpr = v
 	}
 
+	if v, ok := u.SchedOptions.Get(); ok {
+		record.SchedOptions = v
+	}
+
 	if v, ok := u.ManualRequest.Get(); ok {
 		record.ManualRequest = v
 	}
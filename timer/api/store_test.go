@@ -249,13 +249,18 @@ func TestTimerUpdate(t *testing.T) {
 		TimeZone:        NewOptionalVal("UTC"),
 		SchedPolicyType: NewOptionalVal(SchedEventInterval),
 		SchedPolicyExpr: NewOptionalVal("5h"),
-		Watermark:       NewOptionalVal(now),
-		SummaryData:     NewOptionalVal([]byte("summarydata1")),
-		EventStatus:     NewOptionalVal(SchedEventTrigger),
-		EventID:         NewOptionalVal("event1"),
-		EventData:       NewOptionalVal([]byte("eventdata1")),
-		EventStart:      NewOptionalVal(now.Add(time.Second)),
-		Tags:            NewOptionalVal([]string{"l1", "l2"}),
+		SchedOptions: NewOptionalVal(SchedOptions{
+			SchedEndTime:       time.Unix(789, 0),
+			SchedMisfirePolicy: MisfireCatchUp,
+		}),
+		Watermark:   NewOptionalVal(now),
+		SummaryData: NewOptionalVal([]byte("summarydata1")),
+		EventStatus: NewOptionalVal(SchedEventTrigger),
+		EventID:     NewOptionalVal("event1"),
+		EventData:   NewOptionalVal([]byte("eventdata1")),
+		EventStart:  NewOptionalVal(now.Add(time.Second)),
+		Tags:        NewOptionalVal([]string{"l1", "l2"}),
+		Data:        NewOptionalVal([]byte("data1")),
 		ManualRequest: NewOptionalVal(ManualRequest{
 			ManualRequestID:   "req1",
 			ManualRequestTime: time.Unix(123, 0),
@@ -277,6 +282,11 @@ func TestTimerUpdate(t *testing.T) {
 	require.Equal(t, time.UTC, record.Location)
 	require.Equal(t, SchedEventInterval, record.SchedPolicyType)
 	require.Equal(t, "5h", record.SchedPolicyExpr)
+	require.Equal(t, SchedOptions{
+		SchedEndTime:       time.Unix(789, 0),
+		SchedMisfirePolicy: MisfireCatchUp,
+	}, record.SchedOptions)
+	require.Equal(t, []byte("data1"), record.Data)
 	require.Equal(t, now, record.Watermark)
 	require.Equal(t, []byte("summarydata1"), record.SummaryData)
 	require.Equal(t, SchedEventTrigger, record.EventStatus)
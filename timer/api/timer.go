@@ -15,6 +15,8 @@
 package api
 
 import (
+	"encoding/binary"
+	"hash/fnv"
 	"time"
 
 	"github.com/pingcap/errors"
@@ -33,6 +35,25 @@ const (
 	SchedEventCron SchedPolicyType = "CRON"
 )
 
+// MisfirePolicyType is the policy to handle the events missed when the timer runtime is not running or is busy.
+type MisfirePolicyType string
+
+const (
+	// MisfireRunOnce indicates to trigger only one event for all the missed events. It is the default policy.
+	MisfireRunOnce MisfirePolicyType = "RUN_ONCE"
+	// MisfireSkip indicates to skip the missed events and wait for the next event in the future.
+	MisfireSkip MisfirePolicyType = "SKIP"
+	// MisfireCatchUp indicates to trigger the missed events one by one.
+	MisfireCatchUp MisfirePolicyType = "CATCH_UP"
+)
+
+const (
+	// misfireThreshold is the max delay for an event to be triggered, otherwise it is regarded as missed.
+	misfireThreshold = time.Minute
+	// maxSkipMissedEvents is the max number of missed events to iterate when skipping them.
+	maxSkipMissedEvents = 1024
+)
+
 // SchedEventPolicy is an interface to tell the runtime how to schedule a timer's events.
 type SchedEventPolicy interface {
 	// NextEventTime returns the time to schedule the next timer event. If the second return value is true,
@@ -91,6 +112,43 @@ func (p *CronPolicy) NextEventTime(watermark time.Time) (time.Time, bool) {
 	return next, !next.IsZero()
 }
 
+// firstEventTimeFrom returns the first time to schedule an event not before `start`.
+func firstEventTimeFrom(policy SchedEventPolicy, start time.Time) (time.Time, bool) {
+	if _, ok := policy.(*SchedIntervalPolicy); ok {
+		// The interval policy is relative to the watermark, so the first event is at the start time.
+		return start, true
+	}
+	return policy.NextEventTime(start.Add(-time.Nanosecond))
+}
+
+// skipMissedEvents returns the time of the event to trigger at `now` if the events from `tm` are missed.
+// The latest event before `now` is returned if it is not missed yet, otherwise the first event after `now`.
+func skipMissedEvents(policy SchedEventPolicy, tm time.Time, now time.Time) (time.Time, bool) {
+	if p, ok := policy.(*SchedIntervalPolicy); ok && p.interval > 0 {
+		tm = tm.Add(now.Sub(tm) / p.interval * p.interval)
+		if now.Sub(tm) > misfireThreshold {
+			tm = tm.Add(p.interval)
+		}
+		return tm, true
+	}
+
+	for i := 0; i < maxSkipMissedEvents; i++ {
+		next, ok := policy.NextEventTime(tm)
+		if !ok {
+			return next, false
+		}
+
+		if next.After(now) {
+			if now.Sub(tm) > misfireThreshold {
+				return next, true
+			}
+			return tm, true
+		}
+		tm = next
+	}
+	return policy.NextEventTime(now)
+}
+
 // ManualRequest is the request info to trigger timer manually.
 type ManualRequest struct {
 	// ManualRequestID is the id of manual request.
@@ -128,6 +186,39 @@ type EventExtra struct {
 	EventWatermark time.Time
 }
 
+// SchedOptions is the options to control the timer's event schedule besides the schedule policy.
+type SchedOptions struct {
+	// SchedStartTime indicates no event will be scheduled before it.
+	// If it is zero, there is no lower bound.
+	SchedStartTime time.Time
+	// SchedEndTime indicates no event will be scheduled after it.
+	// If it is zero, there is no upper bound.
+	SchedEndTime time.Time
+	// SchedJitter is the max delay added to the schedule time of each event to avoid many timers being triggered at
+	// the same time. The delay is random but stable for the same timer and schedule time.
+	SchedJitter time.Duration
+	// SchedMisfirePolicy indicates how to handle the missed events. If it is empty, `MisfireRunOnce` is used.
+	SchedMisfirePolicy MisfirePolicyType
+}
+
+// Validate validates the SchedOptions.
+func (o *SchedOptions) Validate() error {
+	if o.SchedJitter < 0 {
+		return errors.New("field 'SchedJitter' should not be negative")
+	}
+
+	if !o.SchedStartTime.IsZero() && !o.SchedEndTime.IsZero() && !o.SchedEndTime.After(o.SchedStartTime) {
+		return errors.New("field 'SchedEndTime' should be after 'SchedStartTime'")
+	}
+
+	switch o.SchedMisfirePolicy {
+	case "", MisfireRunOnce, MisfireSkip, MisfireCatchUp:
+		return nil
+	default:
+		return errors.Errorf("invalid misfire policy: '%s'", o.SchedMisfirePolicy)
+	}
+}
+
 // TimerSpec is the specification of a timer without any runtime status.
 type TimerSpec struct {
 	// Namespace is the namespace of the timer.
@@ -145,6 +236,8 @@ type TimerSpec struct {
 	SchedPolicyType SchedPolicyType
 	// SchedPolicyExpr is the expression of event schedule policy with the type specified by SchedPolicyType.
 	SchedPolicyExpr string
+	// SchedOptions is the options to control the event schedule besides the schedule policy.
+	SchedOptions
 	// HookClass is the class of the hook.
 	HookClass string
 	// Watermark indicates the progress the timer's event schedule.
@@ -182,6 +275,10 @@ func (t *TimerSpec) Validate() error {
 		return errors.Wrap(err, "schedule event configuration is not valid")
 	}
 
+	if err := t.SchedOptions.Validate(); err != nil {
+		return errors.Wrap(err, "schedule event configuration is not valid")
+	}
+
 	return nil
 }
 
@@ -243,14 +340,16 @@ type TimerRecord struct {
 	Location *time.Location
 }
 
-// NextEventTime returns the next time for timer to schedule
-func (r *TimerRecord) NextEventTime() (tm time.Time, _ bool, _ error) {
+// ScheduledEventTime returns the schedule time of the next event after the watermark, bounded by `SchedStartTime`
+// and `SchedEndTime`. The jitter and the misfire policy are not considered.
+func (r *TimerRecord) ScheduledEventTime() (tm time.Time, _ bool, _ error) {
 	if !r.Enable {
 		return tm, false, nil
 	}
 
 	watermark := r.Watermark
-	if loc := r.Location; loc != nil {
+	loc := r.Location
+	if loc != nil {
 		watermark = watermark.In(loc)
 	}
 
@@ -260,7 +359,93 @@ func (r *TimerRecord) NextEventTime() (tm time.Time, _ bool, _ error) {
 	}
 
 	tm, ok := policy.NextEventTime(watermark)
-	return tm, ok, nil
+	if start := r.SchedStartTime; ok && !start.IsZero() && tm.Before(start) {
+		if loc != nil {
+			start = start.In(loc)
+		}
+		tm, ok = firstEventTimeFrom(policy, start)
+	}
+
+	if !ok || (!r.SchedEndTime.IsZero() && tm.After(r.SchedEndTime)) {
+		return time.Time{}, false, nil
+	}
+	return tm, true, nil
+}
+
+// NextEventTime returns the next time for timer to schedule, the misfire policy is not considered.
+func (r *TimerRecord) NextEventTime() (tm time.Time, _ bool, _ error) {
+	tm, ok, err := r.ScheduledEventTime()
+	if err != nil || !ok {
+		return tm, ok, err
+	}
+	return tm.Add(r.jitter(tm)), true, nil
+}
+
+// NextEventTimeAt returns the next time for timer to schedule when it is `now`.
+// Different from `NextEventTime`, the missed events are handled according to `SchedMisfirePolicy`.
+func (r *TimerRecord) NextEventTimeAt(now time.Time) (tm time.Time, _ bool, _ error) {
+	tm, ok, err := r.scheduledEventTimeAt(now)
+	if err != nil || !ok {
+		return tm, ok, err
+	}
+	return tm.Add(r.jitter(tm)), true, nil
+}
+
+// EventStartTimeAt returns the start time of the event triggered at `now`, which becomes the watermark when the event
+// is closed by default.
+// The schedule time without the jitter is returned if the event is only delayed by the jitter, so that the delays
+// are not accumulated to the following events. For the misfire policy `CATCH_UP`, the missed schedule time is returned
+// so that the watermark only moves one schedule forward each time and all the missed events are triggered.
+func (r *TimerRecord) EventStartTimeAt(now time.Time) time.Time {
+	if r.IsManualRequesting() || (r.SchedMisfirePolicy != MisfireCatchUp && r.SchedJitter <= 0) {
+		return now
+	}
+
+	tm, ok, err := r.scheduledEventTimeAt(now)
+	if err != nil || !ok || !tm.Before(now) {
+		return now
+	}
+
+	if r.SchedMisfirePolicy == MisfireCatchUp || now.Sub(tm.Add(r.jitter(tm))) <= misfireThreshold {
+		return tm
+	}
+	return now
+}
+
+// scheduledEventTimeAt returns the schedule time of the event to trigger when it is `now`, the missed events are
+// handled according to `SchedMisfirePolicy` and the jitter is not considered.
+func (r *TimerRecord) scheduledEventTimeAt(now time.Time) (tm time.Time, _ bool, _ error) {
+	tm, ok, err := r.ScheduledEventTime()
+	if err != nil || !ok {
+		return tm, ok, err
+	}
+
+	if r.SchedMisfirePolicy == MisfireSkip && !tm.IsZero() && now.Sub(tm) > misfireThreshold {
+		policy, err := CreateSchedEventPolicy(r.SchedPolicyType, r.SchedPolicyExpr)
+		if err != nil {
+			return time.Time{}, false, err
+		}
+
+		tm, ok = skipMissedEvents(policy, tm, now)
+		if !ok || (!r.SchedEndTime.IsZero() && tm.After(r.SchedEndTime)) {
+			return time.Time{}, false, nil
+		}
+	}
+	return tm, true, nil
+}
+
+// jitter returns the delay of the event scheduled at `tm`, it is always the same for the same timer and `tm`.
+func (r *TimerRecord) jitter(tm time.Time) time.Duration {
+	if r.SchedJitter <= 0 || tm.IsZero() {
+		return 0
+	}
+
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(r.ID))
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], uint64(tm.UnixNano()))
+	_, _ = h.Write(buf[:])
+	return time.Duration(h.Sum64() % uint64(r.SchedJitter))
 }
 
 // Clone returns a cloned TimerRecord.
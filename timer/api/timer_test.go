@@ -63,6 +63,24 @@ func TestTimerValidate(t *testing.T) {
 
 	record.TimeZone = ""
 	require.NoError(t, record.Validate())
+
+	record.SchedJitter = -time.Second
+	err = record.Validate()
+	require.EqualError(t, err, "schedule event configuration is not valid: field 'SchedJitter' should not be negative")
+
+	record.SchedJitter = time.Second
+	record.SchedStartTime = time.Unix(100, 0)
+	record.SchedEndTime = time.Unix(100, 0)
+	err = record.Validate()
+	require.EqualError(t, err, "schedule event configuration is not valid: field 'SchedEndTime' should be after 'SchedStartTime'")
+
+	record.SchedEndTime = time.Unix(101, 0)
+	record.SchedMisfirePolicy = "aa"
+	err = record.Validate()
+	require.EqualError(t, err, "schedule event configuration is not valid: invalid misfire policy: 'aa'")
+
+	record.SchedMisfirePolicy = MisfireCatchUp
+	require.NoError(t, record.Validate())
 }
 
 func TestTimerNextEventTime(t *testing.T) {
@@ -113,3 +131,181 @@ func TestTimerNextEventTime(t *testing.T) {
 	require.False(t, ok)
 	require.True(t, next.IsZero())
 }
+
+func TestTimerNextEventTimeWithSchedOptions(t *testing.T) {
+	watermark := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+	record := &TimerRecord{
+		TimerSpec: TimerSpec{
+			SchedPolicyType: SchedEventInterval,
+			SchedPolicyExpr: "1h",
+			Watermark:       watermark,
+			Enable:          true,
+		},
+		ID: "t1",
+	}
+
+	// no event before SchedStartTime
+	record.SchedStartTime = watermark.Add(5 * time.Hour)
+	next, ok, err := record.NextEventTime()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, watermark.Add(5*time.Hour), next)
+
+	record.SchedPolicyType = SchedEventCron
+	record.SchedPolicyExpr = "30 * * * *"
+	next, ok, err = record.NextEventTime()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, watermark.Add(5*time.Hour+30*time.Minute), next)
+
+	// no event after SchedEndTime
+	record.SchedStartTime = time.Time{}
+	record.SchedEndTime = watermark.Add(20 * time.Minute)
+	next, ok, err = record.NextEventTime()
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.True(t, next.IsZero())
+
+	record.SchedEndTime = watermark.Add(30 * time.Minute)
+	next, ok, err = record.NextEventTime()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, watermark.Add(30*time.Minute), next)
+
+	// the jitter is stable for the same timer and schedule time
+	record.SchedEndTime = time.Time{}
+	record.SchedJitter = 10 * time.Minute
+	next, ok, err = record.NextEventTime()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.False(t, next.Before(watermark.Add(30*time.Minute)))
+	require.True(t, next.Before(watermark.Add(40*time.Minute)))
+	next2, _, err := record.NextEventTime()
+	require.NoError(t, err)
+	require.Equal(t, next, next2)
+	scheduled, ok, err := record.ScheduledEventTime()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, watermark.Add(30*time.Minute), scheduled)
+}
+
+func TestTimerEventStartTimeAt(t *testing.T) {
+	watermark := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+	record := &TimerRecord{
+		ID: "t1",
+		TimerSpec: TimerSpec{
+			SchedPolicyType: SchedEventInterval,
+			SchedPolicyExpr: "1h",
+			Watermark:       watermark,
+			Enable:          true,
+		},
+	}
+
+	// the event starts at now without jitter
+	now := watermark.Add(time.Hour + time.Second)
+	require.Equal(t, now, record.EventStartTimeAt(now))
+
+	// the jitter is not accumulated to the following events
+	record.SchedJitter = 10 * time.Minute
+	for i := 1; i <= 5; i++ {
+		next, ok, err := record.NextEventTimeAt(record.Watermark)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.True(t, next.After(watermark.Add(time.Duration(i)*time.Hour)))
+		start := record.EventStartTimeAt(next.Add(time.Second))
+		require.Equal(t, watermark.Add(time.Duration(i)*time.Hour), start)
+		record.Watermark = start
+	}
+
+	// the missed event starts at now
+	record.Watermark = watermark
+	now = watermark.Add(5 * time.Hour)
+	require.Equal(t, now, record.EventStartTimeAt(now))
+
+	// the missed events are caught up one by one
+	record.SchedMisfirePolicy = MisfireCatchUp
+	require.Equal(t, watermark.Add(time.Hour), record.EventStartTimeAt(now))
+
+	// manual request always starts at now
+	record.ManualRequest = ManualRequest{
+		ManualRequestID:   "req1",
+		ManualRequestTime: now,
+		ManualTimeout:     time.Minute,
+	}
+	require.Equal(t, now, record.EventStartTimeAt(now))
+}
+
+func TestTimerNextEventTimeAtMisfire(t *testing.T) {
+	watermark := time.Date(2023, 7, 1, 10, 0, 0, 0, time.UTC)
+	record := &TimerRecord{
+		TimerSpec: TimerSpec{
+			SchedPolicyType: SchedEventInterval,
+			SchedPolicyExpr: "1h",
+			Watermark:       watermark,
+			Enable:          true,
+		},
+	}
+
+	// the missed events are triggered immediately by default
+	now := watermark.Add(5*time.Hour + 30*time.Minute)
+	for _, policy := range []MisfirePolicyType{"", MisfireRunOnce, MisfireCatchUp} {
+		record.SchedMisfirePolicy = policy
+		next, ok, err := record.NextEventTimeAt(now)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, watermark.Add(time.Hour), next)
+	}
+
+	// skip the missed events
+	record.SchedMisfirePolicy = MisfireSkip
+	next, ok, err := record.NextEventTimeAt(now)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, watermark.Add(6*time.Hour), next)
+
+	// the event in threshold is not missed
+	next, ok, err = record.NextEventTimeAt(watermark.Add(5*time.Hour + 30*time.Second))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, watermark.Add(5*time.Hour), next)
+
+	record.SchedPolicyType = SchedEventCron
+	record.SchedPolicyExpr = "0 */2 * * *"
+	next, ok, err = record.NextEventTimeAt(now)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, watermark.Add(6*time.Hour), next)
+
+	next, ok, err = record.NextEventTimeAt(watermark.Add(4*time.Hour + 10*time.Second))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, watermark.Add(4*time.Hour), next)
+
+	// no event after SchedEndTime
+	record.SchedEndTime = watermark.Add(5 * time.Hour)
+	next, ok, err = record.NextEventTimeAt(now)
+	require.NoError(t, err)
+	require.False(t, ok)
+	require.True(t, next.IsZero())
+}
+
+func TestTimerNextEventTimeAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	record := &TimerRecord{
+		TimerSpec: TimerSpec{
+			SchedPolicyType: SchedEventCron,
+			SchedPolicyExpr: "0 3 * * *",
+			Watermark:       time.Date(2023, 3, 11, 3, 0, 0, 0, loc),
+			Enable:          true,
+		},
+		Location: loc,
+	}
+
+	// the event is still scheduled at 03:00 local time after DST starts
+	next, ok, err := record.NextEventTime()
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, time.Date(2023, 3, 12, 3, 0, 0, 0, loc), next)
+	require.Equal(t, 23*time.Hour, next.Sub(record.Watermark))
+}
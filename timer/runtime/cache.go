@@ -56,7 +56,7 @@ func (c *timerCacheItem) update(timer *api.TimerRecord, nowFunc func() time.Time
 	c.nextTryTriggerTime = time.Date(2999, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	if timer.Enable {
-		t, ok, err := timer.NextEventTime()
+		t, ok, err := timer.NextEventTimeAt(nowFunc())
 		if err == nil && ok {
 			c.nextEventTime = &t
 		}
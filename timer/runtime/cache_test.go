@@ -475,3 +475,28 @@ func TestLocationChanged(t *testing.T) {
 		require.Equalf(t, tc.changed, result, "%d: compare %q and %q", i, a, b)
 	}
 }
+
+func TestCacheUpdateWithMisfirePolicy(t *testing.T) {
+	now := time.Now().In(time.UTC)
+	cache := newTimersCache()
+	cache.nowFunc = func() time.Time {
+		return now
+	}
+
+	// default policy triggers the missed event once
+	t1 := newTestTimer("t1", "10m", now.Add(-65*time.Minute))
+	require.True(t, cache.updateTimer(t1))
+	require.Equal(t, now.Add(-55*time.Minute), *cache.items["t1"].nextEventTime)
+
+	// policy SKIP waits for the next schedule time
+	t2 := newTestTimer("t2", "10m", now.Add(-65*time.Minute))
+	t2.SchedMisfirePolicy = api.MisfireSkip
+	require.True(t, cache.updateTimer(t2))
+	require.Equal(t, now.Add(5*time.Minute), *cache.items["t2"].nextEventTime)
+
+	// policy SKIP triggers the event missed in the threshold
+	t3 := newTestTimer("t3", "10m", now.Add(-10*time.Minute-30*time.Second))
+	t3.SchedMisfirePolicy = api.MisfireSkip
+	require.True(t, cache.updateTimer(t3))
+	require.Equal(t, now.Add(-30*time.Second), *cache.items["t3"].nextEventTime)
+}
@@ -432,7 +432,7 @@ func buildEventUpdate(req *triggerEventRequest, result api.PreSchedEventResult,
 	var update api.TimerUpdate
 	update.EventStatus.Set(api.SchedEventTrigger)
 	update.EventID.Set(req.eventID)
-	update.EventStart.Set(nowFunc())
+	update.EventStart.Set(req.timer.EventStartTimeAt(nowFunc()))
 	update.EventData.Set(result.EventData)
 	update.CheckVersion.Set(req.timer.Version)
 
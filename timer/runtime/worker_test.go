@@ -904,3 +904,44 @@ func TestHookWorkerLoopHandleRequestPanicRecover(t *testing.T) {
 	hookFn.AssertExpectations(t)
 	hook.AssertExpectations(t)
 }
+
+func TestBuildEventUpdateWithMisfirePolicy(t *testing.T) {
+	now := time.Now().In(time.UTC)
+	nowFunc := func() time.Time {
+		return now
+	}
+
+	timer := newTestTimer("t1", "10m", now.Add(-65*time.Minute))
+	req := &triggerEventRequest{eventID: "event1", timer: timer}
+	update := buildEventUpdate(req, api.PreSchedEventResult{}, nowFunc)
+	eventStart, ok := update.EventStart.Get()
+	require.True(t, ok)
+	require.Equal(t, now, eventStart)
+
+	// CATCH_UP uses the missed schedule time as the event start
+	timer.SchedMisfirePolicy = api.MisfireCatchUp
+	update = buildEventUpdate(req, api.PreSchedEventResult{}, nowFunc)
+	eventStart, ok = update.EventStart.Get()
+	require.True(t, ok)
+	require.Equal(t, now.Add(-55*time.Minute), eventStart)
+
+	// the event delayed by the jitter uses the schedule time as the event start
+	timer.SchedMisfirePolicy = ""
+	timer.Watermark = now.Add(-10*time.Minute - 30*time.Second)
+	timer.SchedJitter = time.Minute
+	update = buildEventUpdate(req, api.PreSchedEventResult{}, nowFunc)
+	eventStart, ok = update.EventStart.Get()
+	require.True(t, ok)
+	require.Equal(t, now.Add(-30*time.Second), eventStart)
+
+	// manual request always starts at now
+	timer.ManualRequest = api.ManualRequest{
+		ManualRequestID:   "req1",
+		ManualRequestTime: now,
+		ManualTimeout:     time.Minute,
+	}
+	update = buildEventUpdate(req, api.PreSchedEventResult{}, nowFunc)
+	eventStart, ok = update.EventStart.Get()
+	require.True(t, ok)
+	require.Equal(t, now, eventStart)
+}
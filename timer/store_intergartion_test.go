@@ -205,8 +205,15 @@ func runTimerStoreUpdate(ctx context.Context, t *testing.T, store *api.TimerStor
 	watermark := time.Unix(7890123, 0)
 	err = store.Update(ctx, tpl.ID, &api.TimerUpdate{
 		Tags:            api.NewOptionalVal([]string{"l1", "l2"}),
+		Data:            api.NewOptionalVal([]byte("data2")),
 		TimeZone:        api.NewOptionalVal("UTC"),
 		SchedPolicyExpr: api.NewOptionalVal("2h"),
+		SchedOptions: api.NewOptionalVal(api.SchedOptions{
+			SchedStartTime:     time.Unix(1000, 0),
+			SchedEndTime:       time.Unix(9000000, 0),
+			SchedJitter:        1500 * time.Millisecond,
+			SchedMisfirePolicy: api.MisfireSkip,
+		}),
 		ManualRequest: api.NewOptionalVal(api.ManualRequest{
 			ManualRequestID:   "req1",
 			ManualRequestTime: time.Unix(123, 0),
@@ -236,7 +243,14 @@ func runTimerStoreUpdate(ctx context.Context, t *testing.T, store *api.TimerStor
 	tpl.TimeZone = "UTC"
 	tpl.Location = time.UTC
 	tpl.SchedPolicyExpr = "2h"
+	tpl.SchedOptions = api.SchedOptions{
+		SchedStartTime:     time.Unix(1000, 0),
+		SchedEndTime:       time.Unix(9000000, 0),
+		SchedJitter:        1500 * time.Millisecond,
+		SchedMisfirePolicy: api.MisfireSkip,
+	}
 	tpl.Tags = []string{"l1", "l2"}
+	tpl.Data = []byte("data2")
 	tpl.EventStatus = api.SchedEventTrigger
 	tpl.EventID = eventID
 	tpl.EventData = []byte("eventdata1")
@@ -28,6 +28,7 @@ type timerExt struct {
 	Tags   []string          `json:"tags,omitempty"`
 	Manual *manualRequestObj `json:"manual,omitempty"`
 	Event  *eventExtObj      `json:"event,omitempty"`
+	Sched  *schedOptionsObj  `json:"sched,omitempty"`
 }
 
 // CreateTimerTableSQL returns a SQL to create timer table
@@ -84,6 +85,7 @@ func buildInsertTimerSQL(dbName, tableName string, record *api.TimerRecord) (str
 		Tags:   record.Tags,
 		Manual: newManualRequestObj(record.ManualRequest),
 		Event:  newEventExtObj(record.EventExtra),
+		Sched:  newSchedOptionsObj(record.SchedOptions),
 	}
 
 	extJSON, err := json.Marshal(ext)
@@ -390,6 +392,66 @@ func (o *eventExtObj) ToEventExtra() (e api.EventExtra) {
 	return
 }
 
+type schedOptionsObj struct {
+	StartTimeUnix *int64  `json:"start_time_unix"`
+	EndTimeUnix   *int64  `json:"end_time_unix"`
+	JitterMs      *int64  `json:"jitter_ms"`
+	MisfirePolicy *string `json:"misfire_policy"`
+}
+
+func newSchedOptionsObj(opts api.SchedOptions) *schedOptionsObj {
+	var empty api.SchedOptions
+	if opts == empty {
+		return nil
+	}
+
+	obj := &schedOptionsObj{}
+	if v := opts.SchedStartTime; !v.IsZero() {
+		unix := v.Unix()
+		obj.StartTimeUnix = &unix
+	}
+
+	if v := opts.SchedEndTime; !v.IsZero() {
+		unix := v.Unix()
+		obj.EndTimeUnix = &unix
+	}
+
+	if v := opts.SchedJitter; v != 0 {
+		ms := v.Milliseconds()
+		obj.JitterMs = &ms
+	}
+
+	if v := string(opts.SchedMisfirePolicy); v != "" {
+		obj.MisfirePolicy = &v
+	}
+
+	return obj
+}
+
+func (o *schedOptionsObj) ToSchedOptions() (opts api.SchedOptions) {
+	if o == nil {
+		return
+	}
+
+	if v := o.StartTimeUnix; v != nil {
+		opts.SchedStartTime = time.Unix(*v, 0)
+	}
+
+	if v := o.EndTimeUnix; v != nil {
+		opts.SchedEndTime = time.Unix(*v, 0)
+	}
+
+	if v := o.JitterMs; v != nil {
+		opts.SchedJitter = time.Duration(*v) * time.Millisecond
+	}
+
+	if v := o.MisfirePolicy; v != nil {
+		opts.SchedMisfirePolicy = api.MisfirePolicyType(*v)
+	}
+
+	return
+}
+
 func buildUpdateCriteria(update *api.TimerUpdate, args []any) (string, []any, error) {
 	updateFields := make([]string, 0, cap(args)-len(args))
 	if val, ok := update.Enable.Get(); ok {
@@ -397,6 +459,11 @@ func buildUpdateCriteria(update *api.TimerUpdate, args []any) (string, []any, er
 		args = append(args, val)
 	}
 
+	if val, ok := update.Data.Get(); ok {
+		updateFields = append(updateFields, "TIMER_DATA = %?")
+		args = append(args, val)
+	}
+
 	extFields := make(map[string]any)
 	if val, ok := update.Tags.Get(); ok {
 		if len(val) == 0 {
@@ -413,6 +480,10 @@ func buildUpdateCriteria(update *api.TimerUpdate, args []any) (string, []any, er
 		extFields["event"] = newEventExtObj(val)
 	}
 
+	if val, ok := update.SchedOptions.Get(); ok {
+		extFields["sched"] = newSchedOptionsObj(val)
+	}
+
 	if val, ok := update.TimeZone.Get(); ok {
 		updateFields = append(updateFields, "TIMEZONE = %?")
 		args = append(args, val)
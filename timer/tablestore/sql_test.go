@@ -61,6 +61,11 @@ func TestBuildInsertTimerSQL(t *testing.T) {
 					Watermark:       now,
 					Enable:          true,
 					Tags:            []string{"l1", "l2"},
+					SchedOptions: api.SchedOptions{
+						SchedStartTime:     time.Unix(789, 0),
+						SchedJitter:        time.Minute,
+						SchedMisfirePolicy: api.MisfireSkip,
+					},
 				},
 				ManualRequest: api.ManualRequest{
 					ManualRequestID:   "req1",
@@ -83,7 +88,8 @@ func TestBuildInsertTimerSQL(t *testing.T) {
 				"n1", "k1", []byte("data1"), "Asia/Shanghai", "INTERVAL", "1h", "h1", now.Unix(),
 				true, json.RawMessage(`{"tags":["l1","l2"],` +
 					`"manual":{"request_id":"req1","request_time_unix":123,"timeout_sec":60,"processed":true,"event_id":"event1"},` +
-					`"event":{"manual_request_id":"req1","watermark_unix":456}}`),
+					`"event":{"manual_request_id":"req1","watermark_unix":456},` +
+					`"sched":{"start_time_unix":789,"end_time_unix":null,"jitter_ms":60000,"misfire_policy":"SKIP"}}`),
 				"e1", "TRIGGER", now.Unix() + 1, []byte("event1"), []byte("summary1"),
 			},
 		},
@@ -416,6 +422,7 @@ func TestBuildUpdateCriteria(t *testing.T) {
 			update: &api.TimerUpdate{
 				Enable:          api.NewOptionalVal(false),
 				Tags:            api.NewOptionalVal([]string{"l1", "l2"}),
+				Data:            api.NewOptionalVal([]byte("timer1")),
 				TimeZone:        api.NewOptionalVal("Asia/Shanghai"),
 				SchedPolicyType: api.NewOptionalVal(api.SchedEventInterval),
 				SchedPolicyExpr: api.NewOptionalVal("1h"),
@@ -439,13 +446,13 @@ func TestBuildUpdateCriteria(t *testing.T) {
 				CheckEventID: api.NewOptionalVal("ee"),
 				CheckVersion: api.NewOptionalVal(uint64(1)),
 			},
-			criteria: "ENABLE = %?, TIMEZONE = %?, SCHED_POLICY_TYPE = %?, SCHED_POLICY_EXPR = %?, EVENT_STATUS = %?, " +
+			criteria: "ENABLE = %?, TIMER_DATA = %?, TIMEZONE = %?, SCHED_POLICY_TYPE = %?, SCHED_POLICY_EXPR = %?, EVENT_STATUS = %?, " +
 				"EVENT_ID = %?, EVENT_DATA = %?, EVENT_START = FROM_UNIXTIME(%?), " +
 				"WATERMARK = FROM_UNIXTIME(%?), SUMMARY_DATA = %?, " +
 				"TIMER_EXT = JSON_MERGE_PATCH(TIMER_EXT, %?), " +
 				"VERSION = VERSION + 1",
 			args: []any{
-				false, "Asia/Shanghai", "INTERVAL", "1h", "TRIGGER", "event1", []byte("data1"), now.Unix(),
+				false, []byte("timer1"), "Asia/Shanghai", "INTERVAL", "1h", "TRIGGER", "event1", []byte("data1"), now.Unix(),
 				now.Unix() + 1, []byte("summary"),
 				json.RawMessage(`{` +
 					`"event":{"manual_request_id":"req2","watermark_unix":456},` +
@@ -458,11 +465,13 @@ func TestBuildUpdateCriteria(t *testing.T) {
 			update: &api.TimerUpdate{
 				EventExtra:    api.NewOptionalVal(api.EventExtra{EventManualRequestID: "req1"}),
 				ManualRequest: api.NewOptionalVal(api.ManualRequest{ManualRequestID: "req2"}),
+				SchedOptions:  api.NewOptionalVal(api.SchedOptions{SchedEndTime: time.Unix(789, 0)}),
 			},
 			criteria: "TIMER_EXT = JSON_MERGE_PATCH(TIMER_EXT, %?), VERSION = VERSION + 1",
 			args: []any{json.RawMessage(`{` +
 				`"event":{"manual_request_id":"req1","watermark_unix":null},` +
-				`"manual":{"request_id":"req2","request_time_unix":null,"timeout_sec":null,"processed":null,"event_id":null}` +
+				`"manual":{"request_id":"req2","request_time_unix":null,"timeout_sec":null,"processed":null,"event_id":null},` +
+				`"sched":{"start_time_unix":null,"end_time_unix":789,"jitter_ms":null,"misfire_policy":null}` +
 				`}`)},
 		},
 		{
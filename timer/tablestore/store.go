@@ -200,6 +200,7 @@ func (s *tableTimerStoreCore) List(ctx context.Context, cond api.Cond) ([]*api.T
 				TimeZone:        row.GetString(4),
 				SchedPolicyType: api.SchedPolicyType(row.GetString(5)),
 				SchedPolicyExpr: row.GetString(6),
+				SchedOptions:    ext.Sched.ToSchedOptions(),
 				HookClass:       row.GetString(7),
 				Watermark:       watermark,
 				Enable:          row.GetInt64(9) != 0,
@@ -386,6 +387,12 @@ func checkUpdateConstraints(update *api.TimerUpdate, eventID string, version uin
 		}
 	}
 
+	if val, ok := update.SchedOptions.Get(); ok {
+		if err := val.Validate(); err != nil {
+			return errors.Wrap(err, "schedule event configuration is not valid")
+		}
+	}
+
 	return nil
 }
 
@@ -116,6 +116,10 @@ type TTLTaskState struct {
 	ErrorRows   uint64 `json:"error_rows"`
 
 	ScanTaskErr string `json:"scan_task_err"`
+
+	// ArchiveErrorRows and ArchiveErr are about the rows failed to be moved to the archive table
+	ArchiveErrorRows uint64 `json:"archive_error_rows,omitempty"`
+	ArchiveErr       string `json:"archive_err,omitempty"`
 }
 
 // RowToTTLTask converts a row into TTL task
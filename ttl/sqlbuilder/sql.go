@@ -148,6 +148,46 @@ func (b *SQLBuilder) WriteDelete() error {
 	return nil
 }
 
+// WriteInsertSelect writes an insert statement to copy the rows of the TTL table to its archive table without any
+// condition. The columns are specified explicitly, so the archive table could have extra columns with default values.
+func (b *SQLBuilder) WriteInsertSelect() error {
+	if b.state != writeBegin {
+		return errors.Errorf("invalid state: %v", b.state)
+	}
+	if b.tbl.TTLInfo == nil || b.tbl.TTLInfo.ArchiveTo == nil {
+		return errors.Errorf("table '%s.%s' does not have an archive table", b.tbl.Schema, b.tbl.Name)
+	}
+
+	cols := make([]*model.ColumnInfo, 0, len(b.tbl.Columns))
+	for _, col := range b.tbl.Columns {
+		if col.State == model.StatePublic && !col.Hidden && !col.IsGenerated() {
+			cols = append(cols, col)
+		}
+	}
+
+	archiveTo := b.tbl.TTLInfo.ArchiveTo
+	b.restoreCtx.WritePlain("INSERT LOW_PRIORITY INTO ")
+	tn := ast.TableName{Schema: archiveTo.Schema, Name: archiveTo.Table}
+	if err := tn.Restore(b.restoreCtx); err != nil {
+		return err
+	}
+	b.restoreCtx.WritePlain(" ")
+	b.writeColNames(cols, true)
+	b.restoreCtx.WritePlain(" SELECT ")
+	b.writeColNames(cols, false)
+	b.restoreCtx.WritePlain(" FROM ")
+	if err := b.writeTblName(); err != nil {
+		return err
+	}
+	if par := b.tbl.PartitionDef; par != nil {
+		b.restoreCtx.WritePlain(" PARTITION(")
+		b.restoreCtx.WriteName(par.Name.O)
+		b.restoreCtx.WritePlain(")")
+	}
+	b.state = writeSelOrDel
+	return nil
+}
+
 // WriteCommonCondition writes a new condition
 func (b *SQLBuilder) WriteCommonCondition(cols []*model.ColumnInfo, op string, dp []types.Datum) error {
 	switch b.state {
@@ -188,6 +228,28 @@ func (b *SQLBuilder) WriteExpireCondition(expire time.Time) error {
 	return nil
 }
 
+// WriteFilterCondition writes the condition in the `TTL_FILTER` option. It writes nothing if the table has no filter.
+func (b *SQLBuilder) WriteFilterCondition() error {
+	if b.tbl.TTLInfo == nil || len(b.tbl.TTLInfo.FilterExprStr) == 0 {
+		return nil
+	}
+
+	switch b.state {
+	case writeSelOrDel:
+		b.restoreCtx.WritePlain(" WHERE ")
+		b.state = writeWhere
+	case writeWhere:
+		b.restoreCtx.WritePlain(" AND ")
+	default:
+		return errors.Errorf("invalid state: %v", b.state)
+	}
+
+	b.restoreCtx.WritePlain("(")
+	b.restoreCtx.WritePlain(b.tbl.TTLInfo.FilterExprStr)
+	b.restoreCtx.WritePlain(")")
+	return nil
+}
+
 // WriteInCondition writes an IN condition
 func (b *SQLBuilder) WriteInCondition(cols []*model.ColumnInfo, dps ...[]types.Datum) error {
 	switch b.state {
@@ -444,6 +506,10 @@ func (g *ScanQueryGenerator) buildSQL() (string, error) {
 		return "", err
 	}
 
+	if err := b.WriteFilterCondition(); err != nil {
+		return "", err
+	}
+
 	if err := b.WriteOrderBy(g.tbl.KeyColumns, false); err != nil {
 		return "", err
 	}
@@ -474,6 +540,41 @@ func BuildDeleteSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.
 		return "", err
 	}
 
+	if err := b.WriteFilterCondition(); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteLimit(len(rows)); err != nil {
+		return "", err
+	}
+
+	return b.Build()
+}
+
+// BuildArchiveSQL builds a SQL to copy the rows to the archive table. It should be executed with the SQL built by
+// `BuildDeleteSQL` in the same transaction.
+func BuildArchiveSQL(tbl *cache.PhysicalTable, rows [][]types.Datum, expire time.Time) (string, error) {
+	if len(rows) == 0 {
+		return "", errors.New("Cannot build archive SQL with empty rows")
+	}
+
+	b := NewSQLBuilder(tbl)
+	if err := b.WriteInsertSelect(); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteInCondition(tbl.KeyColumns, rows...); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteExpireCondition(expire); err != nil {
+		return "", err
+	}
+
+	if err := b.WriteFilterCondition(); err != nil {
+		return "", err
+	}
+
 	if err := b.WriteLimit(len(rows)); err != nil {
 		return "", err
 	}
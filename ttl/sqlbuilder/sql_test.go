@@ -931,6 +931,48 @@ func TestBuildDeleteSQL(t *testing.T) {
 	}
 }
 
+func TestBuildSQLWithFilterAndArchive(t *testing.T) {
+	idCol := &model.ColumnInfo{Name: model.NewCIStr("id"), State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeInt24)}
+	timeCol := &model.ColumnInfo{Name: model.NewCIStr("time"), State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeDatetime)}
+	statusCol := &model.ColumnInfo{Name: model.NewCIStr("status"), State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeLong)}
+	genCol := &model.ColumnInfo{Name: model.NewCIStr("g"), State: model.StatePublic, FieldType: *types.NewFieldType(mysql.TypeLong), GeneratedExprString: "`id` + 1"}
+	tbl := &cache.PhysicalTable{
+		Schema: model.NewCIStr("test"),
+		TableInfo: &model.TableInfo{
+			Name:    model.NewCIStr("t1"),
+			Columns: []*model.ColumnInfo{idCol, timeCol, statusCol, genCol},
+			TTLInfo: &model.TTLInfo{
+				FilterExprStr: "`status` IN (1,2)",
+				ArchiveTo:     &model.TTLArchiveTable{Schema: model.NewCIStr("archive"), Table: model.NewCIStr("t1_archive")},
+			},
+		},
+		KeyColumns: []*model.ColumnInfo{idCol},
+		TimeColumn: timeCol,
+	}
+	expire := time.UnixMilli(0).In(time.UTC)
+
+	g, err := sqlbuilder.NewScanQueryGenerator(tbl, expire, nil, nil)
+	require.NoError(t, err)
+	sql, err := g.NextSQL(nil, 3)
+	require.NoError(t, err)
+	require.Equal(t, "SELECT LOW_PRIORITY SQL_NO_CACHE `id` FROM `test`.`t1` WHERE `time` < FROM_UNIXTIME(0) AND (`status` IN (1,2)) ORDER BY `id` ASC LIMIT 3", sql)
+
+	sql, err = sqlbuilder.BuildDeleteSQL(tbl, [][]types.Datum{d(1), d(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "DELETE LOW_PRIORITY FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0) AND (`status` IN (1,2)) LIMIT 2", sql)
+
+	sql, err = sqlbuilder.BuildArchiveSQL(tbl, [][]types.Datum{d(1), d(2)}, expire)
+	require.NoError(t, err)
+	require.Equal(t, "INSERT LOW_PRIORITY INTO `archive`.`t1_archive` (`id`, `time`, `status`) SELECT `id`, `time`, `status` FROM `test`.`t1` WHERE `id` IN (1, 2) AND `time` < FROM_UNIXTIME(0) AND (`status` IN (1,2)) LIMIT 2", sql)
+
+	_, err = sqlbuilder.BuildArchiveSQL(tbl, nil, expire)
+	require.EqualError(t, err, "Cannot build archive SQL with empty rows")
+
+	tbl.TTLInfo.ArchiveTo = nil
+	_, err = sqlbuilder.BuildArchiveSQL(tbl, [][]types.Datum{d(1)}, expire)
+	require.EqualError(t, err, "table 'test.t1' does not have an archive table")
+}
+
 func d(vs ...interface{}) []types.Datum {
 	datums := make([]types.Datum, len(vs))
 	for i, v := range vs {
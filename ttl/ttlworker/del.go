@@ -22,6 +22,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	"github.com/pingcap/tidb/ttl/cache"
 	"github.com/pingcap/tidb/ttl/metrics"
@@ -78,6 +79,58 @@ func (l *delRateLimiter) reset() (newLimit int64) {
 	return
 }
 
+var globalTableDelRateLimiter = newTableDelRateLimiter()
+
+// tableDelRateLimiter limits the delete rate of the tables with the `TTL_DELETE_RATE` option. The delete statements of
+// these tables should also be allowed by the `globalDelRateLimiter`.
+type tableDelRateLimiter struct {
+	sync.Mutex
+	limiters map[int64]*rate.Limiter
+}
+
+func newTableDelRateLimiter() *tableDelRateLimiter {
+	return &tableDelRateLimiter{limiters: make(map[int64]*rate.Limiter)}
+}
+
+func (l *tableDelRateLimiter) Wait(ctx context.Context, tbl *model.TableInfo) error {
+	if tbl.TTLInfo == nil || tbl.TTLInfo.DeleteRate == 0 {
+		return ctx.Err()
+	}
+
+	return l.getLimiter(tbl.ID, tbl.TTLInfo.DeleteRate).Wait(ctx)
+}
+
+func (l *tableDelRateLimiter) getLimiter(tableID int64, deleteRate uint64) *rate.Limiter {
+	l.Lock()
+	defer l.Unlock()
+	limiter, ok := l.limiters[tableID]
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(deleteRate), 1)
+		l.limiters[tableID] = limiter
+	} else if limiter.Limit() != rate.Limit(deleteRate) {
+		limiter.SetLimit(rate.Limit(deleteRate))
+	}
+	return limiter
+}
+
+// removeUnusedLimiters removes the limiters of the tables which are dropped or have no `TTL_DELETE_RATE` now
+func (l *tableDelRateLimiter) removeUnusedLimiters(tables map[int64]*cache.PhysicalTable) {
+	inUse := make(map[int64]struct{}, len(tables))
+	for _, tbl := range tables {
+		if tbl.TTLInfo != nil && tbl.TTLInfo.DeleteRate > 0 {
+			inUse[tbl.TableInfo.ID] = struct{}{}
+		}
+	}
+
+	l.Lock()
+	defer l.Unlock()
+	for tableID := range l.limiters {
+		if _, ok := inUse[tableID]; !ok {
+			delete(l.limiters, tableID)
+		}
+	}
+}
+
 type ttlDeleteTask struct {
 	tbl        *cache.PhysicalTable
 	expire     time.Time
@@ -114,23 +167,53 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 			return
 		}
 
+		archiveSQL := ""
+		if t.tbl.TTLInfo.ArchiveTo != nil {
+			archiveSQL, err = sqlbuilder.BuildArchiveSQL(t.tbl, delBatch, t.expire)
+			if err != nil {
+				t.statistics.IncErrorRows(len(delBatch))
+				t.statistics.IncArchiveErrorRows(len(delBatch), err)
+				logutil.BgLogger().Warn(
+					"build archive SQL in TTL failed",
+					zap.Error(err),
+					zap.String("table", t.tbl.Schema.O+"."+t.tbl.Name.O),
+				)
+				return
+			}
+		}
+
 		tracer.EnterPhase(metrics.PhaseWaitToken)
 		if err = globalDelRateLimiter.Wait(ctx); err != nil {
 			t.statistics.IncErrorRows(len(delBatch))
 			return
 		}
+		if err = globalTableDelRateLimiter.Wait(ctx, t.tbl.TableInfo); err != nil {
+			t.statistics.IncErrorRows(len(delBatch))
+			return
+		}
 		tracer.EnterPhase(metrics.PhaseOther)
 
 		sqlStart := time.Now()
-		_, needRetry, err := se.ExecuteSQLWithCheck(ctx, sql)
+		var needRetry, archiveFailed bool
+		if archiveSQL == "" {
+			_, needRetry, err = se.ExecuteSQLWithCheck(ctx, sql)
+		} else {
+			archiveFailed, needRetry, err = se.ArchiveAndDeleteWithCheck(ctx, archiveSQL, sql)
+		}
 		sqlInterval := time.Since(sqlStart)
 		if err != nil {
 			metrics.DeleteErrorDuration.Observe(sqlInterval.Seconds())
-			needRetry = needRetry && ctx.Err() == nil
+			// The errors of archive SQL are usually caused by the archive table, for example, the table is dropped or
+			// there is a duplicated key in it, so it's useless to retry.
+			needRetry = needRetry && !archiveFailed && ctx.Err() == nil
+			failedSQL := sql
+			if archiveFailed {
+				failedSQL = archiveSQL
+			}
 			logutil.BgLogger().Warn(
 				"delete SQL in TTL failed",
 				zap.Error(err),
-				zap.String("SQL", sql),
+				zap.String("SQL", failedSQL),
 				zap.Bool("needRetry", needRetry),
 			)
 
@@ -141,6 +224,9 @@ func (t *ttlDeleteTask) doDelete(ctx context.Context, rawSe session.Session) (re
 				retryRows = append(retryRows, delBatch...)
 			} else {
 				t.statistics.IncErrorRows(len(delBatch))
+				if archiveFailed {
+					t.statistics.IncArchiveErrorRows(len(delBatch), err)
+				}
 			}
 			continue
 		}
@@ -309,6 +309,33 @@ func TestTTLDeleteRateLimiter(t *testing.T) {
 	require.EqualError(t, globalDelRateLimiter.Wait(ctx), "context canceled")
 }
 
+func TestTTLTableDeleteRateLimiter(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	limiter := newTableDelRateLimiter()
+	tbl := newMockTTLTbl(t, "t1")
+	// the table without TTL_DELETE_RATE is not limited
+	require.NoError(t, limiter.Wait(ctx, tbl.TableInfo))
+	require.Empty(t, limiter.limiters)
+
+	tbl.TTLInfo.DeleteRate = 100000
+	require.NoError(t, limiter.Wait(ctx, tbl.TableInfo))
+	require.Equal(t, rate.Limit(100000), limiter.limiters[tbl.TableInfo.ID].Limit())
+
+	tbl.TTLInfo.DeleteRate = 10
+	require.NoError(t, limiter.Wait(ctx, tbl.TableInfo))
+	require.Equal(t, rate.Limit(10), limiter.limiters[tbl.TableInfo.ID].Limit())
+
+	limiter.removeUnusedLimiters(map[int64]*cache.PhysicalTable{tbl.ID: tbl})
+	require.Len(t, limiter.limiters, 1)
+
+	// the limiter is removed when the table has no TTL_DELETE_RATE
+	tbl.TTLInfo.DeleteRate = 0
+	limiter.removeUnusedLimiters(map[int64]*cache.PhysicalTable{tbl.ID: tbl})
+	require.Empty(t, limiter.limiters)
+}
+
 func TestTTLDeleteTaskWorker(t *testing.T) {
 	origBatchSize := variable.TTLDeleteBatchSize.Load()
 	variable.TTLDeleteBatchSize.Store(3)
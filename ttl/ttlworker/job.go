@@ -64,7 +64,8 @@ const finishJobHistoryTemplate = `UPDATE mysql.tidb_ttl_job_history
 	    expired_rows = %?,
 	    deleted_rows = %?,
 	    error_delete_rows = %?,
-	    status = %?
+	    status = %?,
+	    archive_error_rows = %?
 	WHERE job_id = %?`
 
 func updateJobCurrentStatusSQL(tableID int64, oldStatus cache.JobStatus, newStatus cache.JobStatus, jobID string) (string, []interface{}) {
@@ -106,6 +107,7 @@ func finishJobHistorySQL(jobID string, finishTime time.Time, summary *TTLSummary
 		summary.SuccessRows,
 		summary.ErrorRows,
 		string(cache.JobStatusFinished),
+		summary.ArchiveErrorRows,
 		jobID,
 	}
 }
@@ -25,6 +25,7 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/infoschema"
 	"github.com/pingcap/tidb/kv"
+	"github.com/pingcap/tidb/parser/model"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx/variable"
 	timerapi "github.com/pingcap/tidb/timer/api"
@@ -373,6 +374,11 @@ func (m *JobManager) triggerTTLJob(requestID string, cmd *client.TriggerNewTTLJo
 		return
 	}
 
+	if !inTableJobWindow(tables[0].TTLInfo, time.Now()) {
+		responseErr(errors.Errorf("not in TTL job window of table %s.%s", cmd.DBName, cmd.TableName))
+		return
+	}
+
 	syncer := NewTTLTimerSyncer(m.sessPool, timerapi.NewDefaultTimerClient(store))
 	tableResults := make([]*client.TriggerNewTTLJobTableResult, 0, len(tables))
 	getJobFns := make([]func() (string, bool, error), 0, len(tables))
@@ -561,6 +567,27 @@ func (m *JobManager) rescheduleJobs(se session.Session, now time.Time) {
 		return
 	}
 
+	var outOfWindowJobs []*ttlJob
+	for _, job := range m.runningJobs {
+		tbl := job.tbl
+		if latest, ok := m.infoSchemaCache.Tables[tbl.ID]; ok {
+			tbl = latest
+		}
+		if !inTableJobWindow(tbl.TTLInfo, now) {
+			outOfWindowJobs = append(outOfWindowJobs, job)
+		}
+	}
+	for _, job := range outOfWindowJobs {
+		logutil.Logger(m.ctx).Info("cancel job because it's out of the TTL job window of the table", zap.String("jobID", job.id))
+
+		summary, err := summarizeErr(errors.New("ttl job is out of the job window of the table"))
+		if err != nil {
+			logutil.Logger(m.ctx).Info("fail to summarize job", zap.Error(err))
+		}
+		m.removeJob(job)
+		job.finish(se, now, summary)
+	}
+
 	jobTables := m.readyForLockHBTimeoutJobTables(now)
 	// TODO: also consider to resume tables, but it's fine to left them there, as other nodes will take this job
 	// when the heart beat is not sent
@@ -572,6 +599,18 @@ func (m *JobManager) rescheduleJobs(se session.Session, now time.Time) {
 	}
 }
 
+// inTableJobWindow returns whether `now` is in the TTL job window of the table. It always returns true for the tables
+// without `TTL_JOB_WINDOW`, and only the global job window takes effect for them.
+func inTableJobWindow(ttlInfo *model.TTLInfo, now time.Time) bool {
+	start, end, ok, err := ttlInfo.GetJobWindow()
+	if err != nil {
+		logutil.BgLogger().Warn("illegal TTL job window", zap.String("window", ttlInfo.JobWindow), zap.Error(err))
+		return false
+	}
+
+	return !ok || timeutil.WithinDayTimePeriod(start, end, now)
+}
+
 func (m *JobManager) localJobs() []*ttlJob {
 	jobs := make([]*ttlJob, 0, len(m.runningJobs))
 	for _, job := range m.runningJobs {
@@ -600,6 +639,10 @@ tblLoop:
 			}
 		}
 
+		if !inTableJobWindow(table.TTLInfo, now) {
+			continue
+		}
+
 		status := m.tableStatusCache.Tables[table.ID]
 		if m.couldLockJob(status, table, now, false, false) {
 			tables = append(tables, table)
@@ -840,7 +883,12 @@ func (m *JobManager) updateHeartBeat(ctx context.Context, se session.Session, no
 
 // updateInfoSchemaCache updates the cache of information schema
 func (m *JobManager) updateInfoSchemaCache(se session.Session) error {
-	return m.infoSchemaCache.Update(se)
+	if err := m.infoSchemaCache.Update(se); err != nil {
+		return err
+	}
+
+	globalTableDelRateLimiter.removeUnusedLimiters(m.infoSchemaCache.Tables)
+	return nil
 }
 
 // updateTableStatusCache updates the cache of table status
@@ -888,6 +936,10 @@ type TTLSummary struct {
 	FinishedScanTask  int `json:"finished_scan_task"`
 
 	ScanTaskErr string `json:"scan_task_err,omitempty"`
+
+	ArchiveErrorRows uint64 `json:"archive_error_rows,omitempty"`
+	ArchiveErr       string `json:"archive_err,omitempty"`
+
 	SummaryText string `json:"-"`
 }
 
@@ -906,15 +958,19 @@ func summarizeErr(err error) (*TTLSummary, error) {
 
 func summarizeTaskResult(tasks []*cache.TTLTask) (*TTLSummary, error) {
 	summary := &TTLSummary{}
-	var allErr error
+	var allErr, allArchiveErr error
 	for _, t := range tasks {
 		if t.State != nil {
 			summary.TotalRows += t.State.TotalRows
 			summary.SuccessRows += t.State.SuccessRows
 			summary.ErrorRows += t.State.ErrorRows
+			summary.ArchiveErrorRows += t.State.ArchiveErrorRows
 			if len(t.State.ScanTaskErr) > 0 {
 				allErr = multierr.Append(allErr, errors.New(t.State.ScanTaskErr))
 			}
+			if len(t.State.ArchiveErr) > 0 {
+				allArchiveErr = multierr.Append(allArchiveErr, errors.New(t.State.ArchiveErr))
+			}
 		}
 
 		summary.TotalScanTask += 1
@@ -928,6 +984,9 @@ func summarizeTaskResult(tasks []*cache.TTLTask) (*TTLSummary, error) {
 	if allErr != nil {
 		summary.ScanTaskErr = allErr.Error()
 	}
+	if allArchiveErr != nil {
+		summary.ArchiveErr = allArchiveErr.Error()
+	}
 
 	buf, err := json.Marshal(summary)
 	if err != nil {
@@ -1095,7 +1154,7 @@ func (a *managerJobAdapter) CanSubmitJob(tableID, physicalID int64) bool {
 
 	tblInfo := tbl.Meta()
 	ttlInfo := tblInfo.TTLInfo
-	if ttlInfo == nil || !ttlInfo.Enable {
+	if ttlInfo == nil || !ttlInfo.Enable || !inTableJobWindow(ttlInfo, time.Now()) {
 		return false
 	}
 
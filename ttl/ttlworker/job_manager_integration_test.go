@@ -143,7 +143,7 @@ func TestFinishJob(t *testing.T) {
 		time.Unix(1, 0).Format(timeFormat),
 		expireTime.Format(timeFormat),
 		"<nil>", "<nil>", "<nil>", "<nil>",
-		"running",
+		"running", "<nil>",
 	}, " ")))
 
 	summary := &ttlworker.TTLSummary{
@@ -163,7 +163,7 @@ func TestFinishJob(t *testing.T) {
 	expectedRow := []string{
 		job.ID(), "2", "1", "db1", "t1", "<nil>",
 		startTime.Format(timeFormat), endTime.Format(timeFormat), expireTime.Format(timeFormat),
-		summary.SummaryText, "128", "120", "8", "finished",
+		summary.SummaryText, "128", "120", "8", "finished", "0",
 	}
 	tk.MustQuery("select * from mysql.tidb_ttl_job_history").Check(testkit.Rows(strings.Join(expectedRow, " ")))
 }
@@ -279,6 +279,68 @@ func TestTriggerTTLJob(t *testing.T) {
 	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2", "4"))
 }
 
+func TestTTLFilterAndArchive(t *testing.T) {
+	failpoint.Enable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
+	defer failpoint.Disable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval")
+
+	ctx, cancel := context.WithTimeout(context.TODO(), 5*time.Minute)
+	defer cancel()
+
+	store, do := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t_archive(id int primary key, t timestamp, status int, archived_at timestamp default current_timestamp)")
+	tk.MustExec("create table t(id int primary key, t timestamp, status int) TTL=`t` + INTERVAL 1 DAY TTL_FILTER='status = 2' TTL_ARCHIVE_TO=t_archive")
+	tbl, err := do.InfoSchema().TableByName(model.NewCIStr("test"), model.NewCIStr("t"))
+	require.NoError(t, err)
+	tblID := tbl.Meta().ID
+
+	timerStore := timertable.NewTableTimerStore(0, do.SysSessionPool(), "mysql", "tidb_timers", nil)
+	defer timerStore.Close()
+	timerCli := timerapi.NewDefaultTimerClient(timerStore)
+
+	// make sure the table had run a job one time to make the test stable
+	cli := do.TTLJobManager().GetCommandCli()
+	_, _ = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+
+	now := time.Now()
+	nowDateStr := now.Format("2006-01-02 15:04:05.999999")
+	expreDateStr := now.Add(-time.Hour * 25).Format("2006-01-02 15:04:05.999999")
+	tk.MustExec("insert into t values(1, ?, 2), (2, ?, 2), (3, ?, 1), (4, ?, 2)", expreDateStr, nowDateStr, expreDateStr, expreDateStr)
+
+	_, err = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	require.NoError(t, err)
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2", "3"))
+	tk.MustQuery("select id, status, archived_at is not null from t_archive order by id asc").Check(testkit.Rows("1 2 1", "4 2 1"))
+	tk.MustQuery("select deleted_rows, archive_error_rows from mysql.tidb_ttl_job_history where table_id=? order by create_time desc limit 1", tblID).
+		Check(testkit.Rows("2 0"))
+
+	// the rows should be kept if they cannot be archived
+	tk.MustExec("insert into t values(5, ?, 2)", expreDateStr)
+	tk.MustExec("insert into t_archive values(5, ?, 2, NULL)", expreDateStr)
+	_, err = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	require.NoError(t, err)
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2", "3", "5"))
+	tk.MustQuery("select error_delete_rows, archive_error_rows, json_extract(summary_text, '$.archive_err') like '%Duplicate entry%' "+
+		"from mysql.tidb_ttl_job_history where table_id=? order by create_time desc limit 1", tblID).Check(testkit.Rows("1 1 1"))
+
+	tk.MustExec("alter table t TTL_FILTER='' TTL_ARCHIVE_TO=''")
+	tk.MustQuery("show create table t").Check(testkit.Rows("t CREATE TABLE `t` (\n" +
+		"  `id` int(11) NOT NULL,\n" +
+		"  `t` timestamp NULL DEFAULT NULL,\n" +
+		"  `status` int(11) DEFAULT NULL,\n" +
+		"  PRIMARY KEY (`id`) /*T![clustered_index] CLUSTERED */\n" +
+		") ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin /*T![ttl] TTL=`t` + INTERVAL 1 DAY */ /*T![ttl] TTL_ENABLE='ON' */ /*T![ttl] TTL_JOB_INTERVAL='1h' */"))
+	_, err = client.TriggerNewTTLJob(ctx, cli, "test", "t")
+	require.NoError(t, err)
+	waitTTLJobFinished(t, tk, tblID, timerCli)
+	tk.MustQuery("select id from t order by id asc").Check(testkit.Rows("2"))
+	tk.MustQuery("select id from t_archive order by id asc").Check(testkit.Rows("1", "4", "5"))
+}
+
 func TestTTLDeleteWithTimeZoneChange(t *testing.T) {
 	failpoint.Enable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval", fmt.Sprintf("return(%d)", time.Second))
 	defer failpoint.Disable("github.com/pingcap/tidb/ttl/ttlworker/task-manager-loop-interval")
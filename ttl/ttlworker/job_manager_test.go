@@ -216,6 +216,10 @@ func TestReadyForLockHBTimeoutJobTables(t *testing.T) {
 	tblWithDailyInterval := newMockTTLTbl(t, "t2")
 	tblWithDailyInterval.TTLInfo.JobInterval = "1d"
 
+	tblOutOfWindow := newMockTTLTbl(t, "t3")
+	now := se.Now().UTC()
+	tblOutOfWindow.TTLInfo.JobWindow = now.Add(time.Hour).Format("15:04") + "-" + now.Add(2*time.Hour).Format("15:04")
+
 	cases := []struct {
 		name             string
 		infoSchemaTables []*cache.PhysicalTable
@@ -238,6 +242,8 @@ func TestReadyForLockHBTimeoutJobTables(t *testing.T) {
 		{"last start time too near for 24h", []*cache.PhysicalTable{tblWithDailyInterval}, []*cache.TableStatus{{TableID: tblWithDailyInterval.ID, ParentTableID: tblWithDailyInterval.ID, LastJobStartTime: time.Now().Add(-time.Hour * 2)}}, false},
 		// if the interval is 24h, and the last start time is far enough, it will not be scheduled because no job running
 		{"last start time far enough for 24h", []*cache.PhysicalTable{tblWithDailyInterval}, []*cache.TableStatus{{TableID: tblWithDailyInterval.ID, ParentTableID: tblWithDailyInterval.ID, LastJobStartTime: time.Now().Add(-time.Hour * 25)}}, false},
+		// table whose heart beat time is expired will not be scheduled if it's out of its own job window
+		{"out of table job window", []*cache.PhysicalTable{tblOutOfWindow}, []*cache.TableStatus{{TableID: tblOutOfWindow.ID, ParentTableID: tblOutOfWindow.ID, CurrentJobID: "job1", CurrentJobOwnerID: "test-another-id", CurrentJobOwnerHBTime: time.Now().Add(-time.Hour)}}, false},
 	}
 
 	for _, c := range cases {
@@ -44,6 +44,10 @@ type ttlStatistics struct {
 	TotalRows   atomic.Uint64
 	SuccessRows atomic.Uint64
 	ErrorRows   atomic.Uint64
+	// ArchiveErrorRows is the number of the rows failed to be moved to the archive table. It's also counted in ErrorRows.
+	ArchiveErrorRows atomic.Uint64
+	// ArchiveErr is the last error returned when moving rows to the archive table
+	ArchiveErr atomic.Pointer[string]
 }
 
 func (s *ttlStatistics) IncTotalRows(cnt int) {
@@ -61,10 +65,25 @@ func (s *ttlStatistics) IncErrorRows(cnt int) {
 	s.ErrorRows.Add(uint64(cnt))
 }
 
+func (s *ttlStatistics) IncArchiveErrorRows(cnt int, err error) {
+	s.ArchiveErrorRows.Add(uint64(cnt))
+	errMsg := err.Error()
+	s.ArchiveErr.Store(&errMsg)
+}
+
+func (s *ttlStatistics) archiveErrString() string {
+	if errMsg := s.ArchiveErr.Load(); errMsg != nil {
+		return *errMsg
+	}
+	return ""
+}
+
 func (s *ttlStatistics) Reset() {
 	s.SuccessRows.Store(0)
 	s.ErrorRows.Store(0)
 	s.TotalRows.Store(0)
+	s.ArchiveErrorRows.Store(0)
+	s.ArchiveErr.Store(nil)
 }
 
 func (s *ttlStatistics) String() string {
@@ -21,6 +21,7 @@ import (
 
 	"github.com/ngaut/pools"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/kv"
 	"github.com/pingcap/tidb/parser/terror"
 	"github.com/pingcap/tidb/sessionctx"
 	"github.com/pingcap/tidb/sessionctx/variable"
@@ -153,24 +154,65 @@ type ttlTableSession struct {
 }
 
 func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) ([]chunk.Row, bool, error) {
+	var result []chunk.Row
+	shouldRetry, err := s.runInTxnWithCheck(ctx, func() error {
+		rows, err := s.ExecuteSQL(ctx, sql)
+		if err != nil {
+			return err
+		}
+
+		result = rows
+		return nil
+	})
+
+	if err != nil {
+		return nil, shouldRetry, err
+	}
+
+	return result, false, nil
+}
+
+// ArchiveAndDeleteWithCheck executes the archive SQL and the delete SQL in one transaction, so the expired rows are
+// either moved to the archive table or kept in the TTL table. `archiveFailed` is true if the archive SQL fails.
+func (s *ttlTableSession) ArchiveAndDeleteWithCheck(ctx context.Context, archiveSQL string, deleteSQL string) (archiveFailed bool, shouldRetry bool, err error) {
+	shouldRetry, err = s.runInTxnWithCheck(ctx, func() error {
+		if _, err := s.ExecuteSQL(ctx, archiveSQL); err != nil {
+			archiveFailed = true
+			return err
+		}
+
+		_, err := s.ExecuteSQL(ctx, deleteSQL)
+		return err
+	})
+
+	if err != nil {
+		// The duplicated keys in an optimistic transaction are only reported when committing, and they can only
+		// come from the archive table because the delete SQL never writes a new key.
+		archiveFailed = archiveFailed || kv.ErrKeyExists.Equal(err)
+		return archiveFailed, shouldRetry, err
+	}
+
+	return false, false, nil
+}
+
+func (s *ttlTableSession) runInTxnWithCheck(ctx context.Context, fn func() error) (bool, error) {
 	tracer := metrics.PhaseTracerFromCtx(ctx)
 	defer tracer.EnterPhase(tracer.Phase())
 
 	tracer.EnterPhase(metrics.PhaseOther)
 	if !variable.EnableTTLJob.Load() {
-		return nil, false, errors.New("global TTL job is disabled")
+		return false, errors.New("global TTL job is disabled")
 	}
 
 	if err := s.ResetWithGlobalTimeZone(ctx); err != nil {
-		return nil, false, err
+		return false, err
 	}
 
-	var result []chunk.Row
 	shouldRetry := true
 	err := s.RunInTxn(ctx, func() error {
 		tracer.EnterPhase(metrics.PhaseQuery)
 		defer tracer.EnterPhase(tracer.Phase())
-		rows, err := s.ExecuteSQL(ctx, sql)
+		err := fn()
 		tracer.EnterPhase(metrics.PhaseCheckTTL)
 		// We must check the configuration after ExecuteSQL because of MDL and the meta the current transaction used
 		// can only be determined after executed one query.
@@ -179,19 +221,10 @@ func (s *ttlTableSession) ExecuteSQLWithCheck(ctx context.Context, sql string) (
 			return errors.Annotatef(validateErr, "table '%s.%s' meta changed, should abort current job", s.tbl.Schema, s.tbl.Name)
 		}
 
-		if err != nil {
-			return err
-		}
-
-		result = rows
-		return nil
+		return err
 	}, session.TxnModeOptimistic)
 
-	if err != nil {
-		return nil, shouldRetry, err
-	}
-
-	return result, false, nil
+	return shouldRetry, err
 }
 
 func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.PhysicalTable, expire time.Time) error {
@@ -244,5 +277,14 @@ func validateTTLWork(ctx context.Context, s session.Session, tbl *cache.Physical
 		}
 	}
 
+	if newTblInfo.TTLInfo.FilterExprStr != tbl.TTLInfo.FilterExprStr {
+		return errors.New("filter changed")
+	}
+
+	if newArchive, oldArchive := newTblInfo.TTLInfo.ArchiveTo, tbl.TTLInfo.ArchiveTo; (newArchive == nil) != (oldArchive == nil) ||
+		(newArchive != nil && (newArchive.Schema.L != oldArchive.Schema.L || newArchive.Table.L != oldArchive.Table.L)) {
+		return errors.New("archive table changed")
+	}
+
 	return nil
 }
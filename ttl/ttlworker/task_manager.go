@@ -435,6 +435,9 @@ func (m *taskManager) updateHeartBeat(ctx context.Context, se session.Session, n
 			TotalRows:   task.statistics.TotalRows.Load(),
 			SuccessRows: task.statistics.SuccessRows.Load(),
 			ErrorRows:   task.statistics.ErrorRows.Load(),
+
+			ArchiveErrorRows: task.statistics.ArchiveErrorRows.Load(),
+			ArchiveErr:       task.statistics.archiveErrString(),
 		}
 		if task.result != nil && task.result.err != nil {
 			state.ScanTaskErr = task.result.err.Error()
@@ -477,6 +480,9 @@ func (m *taskManager) reportTaskFinished(se session.Session, now time.Time, task
 		TotalRows:   task.statistics.TotalRows.Load(),
 		SuccessRows: task.statistics.SuccessRows.Load(),
 		ErrorRows:   task.statistics.ErrorRows.Load(),
+
+		ArchiveErrorRows: task.statistics.ArchiveErrorRows.Load(),
+		ArchiveErr:       task.statistics.archiveErrString(),
 	}
 	if task.result.err != nil {
 		state.ScanTaskErr = task.result.err.Error()
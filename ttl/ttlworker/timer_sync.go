@@ -38,6 +38,9 @@ const (
 	timerHookClass                 = "tidb.ttl"
 	fullRefreshTimersCacheInterval = 10 * time.Minute
 	timerDelayDeleteInterval       = 10 * time.Minute
+	// maxTimerSchedJitter is the max jitter of the TTL timers. It must be much less than the time to cancel an event
+	// whose job is not submitted, see `ttlTimerHook.OnSchedEvent`.
+	maxTimerSchedJitter = time.Minute
 )
 
 // TTLTimerData is the data stored in each timer for TTL
@@ -255,7 +258,22 @@ func (g *TTLTimersSyncer) shouldSyncTimer(timer *timerapi.TimerRecord, schema mo
 	ttlInfo := tblInfo.TTLInfo
 	return !slices.Equal(timer.Tags, tags) ||
 		timer.Enable != ttlInfo.Enable ||
-		timer.SchedPolicyExpr != ttlInfo.JobInterval
+		timer.SchedPolicyExpr != ttlInfo.JobInterval ||
+		timer.SchedOptions != getTimerSchedOptions(ttlInfo)
+}
+
+// getTimerSchedOptions returns the schedule options of the TTL timers. The jitter spreads the jobs of the tables with
+// the same job interval, e.g. the tables created at the same time. Only one job is run for the events missed when the
+// timer runtime is not running.
+func getTimerSchedOptions(ttlInfo *model.TTLInfo) timerapi.SchedOptions {
+	opts := timerapi.SchedOptions{SchedMisfirePolicy: timerapi.MisfireRunOnce}
+	if interval, err := ttlInfo.GetJobInterval(); err == nil {
+		opts.SchedJitter = interval / 10
+		if opts.SchedJitter > maxTimerSchedJitter {
+			opts.SchedJitter = maxTimerSchedJitter
+		}
+	}
+	return opts
 }
 
 func (g *TTLTimersSyncer) syncOneTimer(ctx context.Context, se session.Session, schema model.CIStr, tblInfo *model.TableInfo, partition *model.PartitionDefinition, skipCache bool) (*timerapi.TimerRecord, error) {
@@ -312,6 +330,7 @@ func (g *TTLTimersSyncer) syncOneTimer(ctx context.Context, se session.Session,
 			Data:            data,
 			SchedPolicyType: timerapi.SchedEventInterval,
 			SchedPolicyExpr: ttlInfo.JobInterval,
+			SchedOptions:    getTimerSchedOptions(ttlInfo),
 			HookClass:       timerHookClass,
 			Watermark:       watermark,
 			Enable:          ttlInfo.Enable,
@@ -331,6 +350,7 @@ func (g *TTLTimersSyncer) syncOneTimer(ctx context.Context, se session.Session,
 	err = g.cli.UpdateTimer(ctx, timer.ID,
 		timerapi.WithSetTags(tags),
 		timerapi.WithSetSchedExpr(timerapi.SchedEventInterval, tblInfo.TTLInfo.JobInterval),
+		timerapi.WithSetSchedOptions(getTimerSchedOptions(tblInfo.TTLInfo)),
 		timerapi.WithSetEnable(tblInfo.TTLInfo.Enable),
 	)
 
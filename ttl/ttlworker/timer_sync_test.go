@@ -456,6 +456,12 @@ func checkTimerWithTableMeta(t *testing.T, do *domain.Domain, cli timerapi.Timer
 	require.Equal(t, physical.TTLInfo.Enable, timer.Enable)
 	require.Equal(t, timerapi.SchedEventInterval, timer.SchedPolicyType)
 	require.Equal(t, physical.TTLInfo.JobInterval, timer.SchedPolicyExpr)
+	interval, err := physical.TTLInfo.GetJobInterval()
+	require.NoError(t, err)
+	require.Equal(t, timerapi.MisfireRunOnce, timer.SchedMisfirePolicy)
+	require.Equal(t, min(interval/10, time.Minute), timer.SchedJitter)
+	require.True(t, timer.SchedStartTime.IsZero())
+	require.True(t, timer.SchedEndTime.IsZero())
 	if partition == "" {
 		require.Equal(t, []string{
 			fmt.Sprintf("db=%s", dbInfo.Name.O),
@@ -940,6 +940,8 @@ func (d *Datum) ConvertTo(sc *stmtctx.StatementContext, target *FieldType) (Datu
 		return d.convertToMysqlSet(sc, target)
 	case mysql.TypeJSON:
 		return d.convertToMysqlJSON(sc, target)
+	case mysql.TypeGeometry:
+		return d.convertToMysqlGeometry(target)
 	case mysql.TypeNull:
 		return Datum{}, nil
 	default:
@@ -1687,6 +1689,20 @@ func (d *Datum) convertToMysqlSet(sc *stmtctx.StatementContext, target *FieldTyp
 	return ret, err
 }
 
+// convertToMysqlGeometry checks the value is a geometry in the storage format and its type matches the target.
+func (d *Datum) convertToMysqlGeometry(target *FieldType) (ret Datum, err error) {
+	switch d.k {
+	case KindString, KindBytes:
+		_, g, err := DecodeGeometry(d.GetBytes())
+		if err != nil || !IsGeometryTypeMatched(target.GetGeometryType(), g.Tp) {
+			return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+		}
+		ret.SetBytes(d.GetBytes())
+		return ret, nil
+	}
+	return ret, ErrCantCreateGeometryObject.GenWithStackByArgs()
+}
+
 func (d *Datum) convertToMysqlJSON(_ *stmtctx.StatementContext, _ *FieldType) (ret Datum, err error) {
 	switch d.k {
 	case KindString, KindBytes:
@@ -94,4 +94,6 @@ var (
 	ErrPartitionColumnStatsMissing = dbterror.ClassTypes.NewStd(mysql.ErrPartitionColumnStatsMissing)
 	// ErrIncorrectDatetimeValue is returned when the input value is in wrong format for datetime.
 	ErrIncorrectDatetimeValue = dbterror.ClassTypes.NewStd(mysql.ErrIncorrectDatetimeValue)
+	// ErrCantCreateGeometryObject is returned when the value stored into a geometry column is not a valid geometry.
+	ErrCantCreateGeometryObject = dbterror.ClassTypes.NewStd(mysql.ErrCantCreateGeometryObject)
 )
@@ -168,6 +168,9 @@ func KindStr(kind byte) (r string) {
 	return kind2Str[kind]
 }
 
+// GeometryTypeStr converts the geometry subtype to a string.
+var GeometryTypeStr = ast.GeometryTypeStr
+
 // TypeToStr converts a field to a string.
 // It is used for converting Text to Blob,
 // or converting Char to Binary.
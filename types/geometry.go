@@ -0,0 +1,554 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+/*
+	The geometry values are stored in the same format as MySQL, which is a 4 bytes
+	little-endian SRID followed by the WKB (well-known binary) representation:
+
+	srid     uint32 (little-endian)
+	wkb      byte order (1 for little-endian) + uint32 type + body
+
+	The body of each type is:
+
+	Point              x double, y double
+	LineString         uint32 num points + points
+	Polygon            uint32 num rings + rings, each ring is uint32 num points + points
+	MultiPoint         uint32 num geometries + WKB points
+	MultiLineString    uint32 num geometries + WKB line strings
+	MultiPolygon       uint32 num geometries + WKB polygons
+	GeometryCollection uint32 num geometries + WKB geometries
+*/
+
+const (
+	geometrySRIDLen   = 4
+	wkbHeaderLen      = 5
+	wkbPointLen       = 16
+	wkbBigEndian      = 0
+	wkbLittleEndian   = 1
+	maxGeometryNested = 64
+)
+
+// GeoPoint is a point in the Cartesian plane.
+type GeoPoint struct {
+	X float64
+	Y float64
+}
+
+// Geometry is a geometry value, computations on it are done in the Cartesian plane.
+type Geometry struct {
+	// Tp is the subtype of the geometry, see mysql.GeometryTypePoint and so on.
+	Tp byte
+	// Points stores the point of a Point, or the points of a LineString.
+	Points []GeoPoint
+	// Rings stores the rings of a Polygon, the first ring is the exterior ring.
+	Rings [][]GeoPoint
+	// Geoms stores the elements of a MultiPoint, MultiLineString, MultiPolygon or GeometryCollection.
+	Geoms []Geometry
+}
+
+// GeometryTypeName returns the name of the geometry type in upper case, such as POINT.
+func (g Geometry) GeometryTypeName() string {
+	return strings.ToUpper(GeometryTypeStr(g.Tp))
+}
+
+// IsEmpty returns whether the geometry is an empty geometry collection.
+func (g Geometry) IsEmpty() bool {
+	if g.Tp != mysql.GeometryTypeGeometryCollection {
+		return false
+	}
+	for _, elem := range g.Geoms {
+		if !elem.IsEmpty() {
+			return false
+		}
+	}
+	return true
+}
+
+// IsGeometryTypeMatched checks whether a geometry of type tp can be stored in a column of the geometry type colTp.
+func IsGeometryTypeMatched(colTp, tp byte) bool {
+	return colTp == mysql.GeometryTypeGeometry || colTp == tp
+}
+
+// EncodeGeometry encodes the geometry into the storage format.
+func EncodeGeometry(srid uint32, g Geometry) []byte {
+	buf := make([]byte, geometrySRIDLen, 64)
+	binary.LittleEndian.PutUint32(buf, srid)
+	return g.appendWKB(buf)
+}
+
+// DecodeGeometry decodes the geometry from the storage format.
+func DecodeGeometry(data []byte) (srid uint32, g Geometry, err error) {
+	if len(data) < geometrySRIDLen+wkbHeaderLen {
+		return 0, g, errors.New("invalid geometry data")
+	}
+	srid = binary.LittleEndian.Uint32(data)
+	g, err = ParseGeometryWKB(data[geometrySRIDLen:])
+	return srid, g, err
+}
+
+// GeometryWKB returns the WKB part of the geometry in the storage format.
+func GeometryWKB(data []byte) []byte {
+	if len(data) < geometrySRIDLen {
+		return nil
+	}
+	return data[geometrySRIDLen:]
+}
+
+// WKB returns the WKB representation of the geometry in little-endian.
+func (g Geometry) WKB() []byte {
+	return g.appendWKB(make([]byte, 0, 64))
+}
+
+func appendWKBPoint(buf []byte, p GeoPoint) []byte {
+	buf = binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.X))
+	return binary.LittleEndian.AppendUint64(buf, math.Float64bits(p.Y))
+}
+
+func appendWKBPoints(buf []byte, points []GeoPoint) []byte {
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(len(points)))
+	for _, p := range points {
+		buf = appendWKBPoint(buf, p)
+	}
+	return buf
+}
+
+func (g Geometry) appendWKB(buf []byte) []byte {
+	buf = append(buf, wkbLittleEndian)
+	buf = binary.LittleEndian.AppendUint32(buf, uint32(g.Tp))
+	switch g.Tp {
+	case mysql.GeometryTypePoint:
+		buf = appendWKBPoint(buf, g.Points[0])
+	case mysql.GeometryTypeLineString:
+		buf = appendWKBPoints(buf, g.Points)
+	case mysql.GeometryTypePolygon:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Rings)))
+		for _, ring := range g.Rings {
+			buf = appendWKBPoints(buf, ring)
+		}
+	default:
+		buf = binary.LittleEndian.AppendUint32(buf, uint32(len(g.Geoms)))
+		for _, elem := range g.Geoms {
+			buf = elem.appendWKB(buf)
+		}
+	}
+	return buf
+}
+
+// ParseGeometryWKB parses the WKB representation of a geometry, both byte orders are accepted.
+func ParseGeometryWKB(data []byte) (Geometry, error) {
+	p := wkbParser{data: data}
+	g, err := p.parseGeometry(0)
+	if err != nil {
+		return g, err
+	}
+	if p.pos != len(p.data) {
+		return g, errors.New("invalid WKB: unexpected trailing data")
+	}
+	return g, nil
+}
+
+type wkbParser struct {
+	data  []byte
+	pos   int
+	order binary.ByteOrder
+}
+
+var errInvalidWKB = errors.New("invalid WKB")
+
+func (p *wkbParser) readUint32() (uint32, error) {
+	if p.pos+4 > len(p.data) {
+		return 0, errInvalidWKB
+	}
+	v := p.order.Uint32(p.data[p.pos:])
+	p.pos += 4
+	return v, nil
+}
+
+func (p *wkbParser) readPoint() (GeoPoint, error) {
+	if p.pos+wkbPointLen > len(p.data) {
+		return GeoPoint{}, errInvalidWKB
+	}
+	x := math.Float64frombits(p.order.Uint64(p.data[p.pos:]))
+	y := math.Float64frombits(p.order.Uint64(p.data[p.pos+8:]))
+	p.pos += wkbPointLen
+	if math.IsNaN(x) || math.IsNaN(y) || math.IsInf(x, 0) || math.IsInf(y, 0) {
+		return GeoPoint{}, errInvalidWKB
+	}
+	return GeoPoint{X: x, Y: y}, nil
+}
+
+func (p *wkbParser) readPoints(minPoints int) ([]GeoPoint, error) {
+	n, err := p.readUint32()
+	if err != nil {
+		return nil, err
+	}
+	if int(n) < minPoints || int(n) > (len(p.data)-p.pos)/wkbPointLen {
+		return nil, errInvalidWKB
+	}
+	points := make([]GeoPoint, 0, n)
+	for i := uint32(0); i < n; i++ {
+		pt, err := p.readPoint()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+	}
+	return points, nil
+}
+
+func (p *wkbParser) parseGeometry(depth int) (g Geometry, err error) {
+	if depth > maxGeometryNested || p.pos+wkbHeaderLen > len(p.data) {
+		return g, errInvalidWKB
+	}
+	switch p.data[p.pos] {
+	case wkbLittleEndian:
+		p.order = binary.LittleEndian
+	case wkbBigEndian:
+		p.order = binary.BigEndian
+	default:
+		return g, errInvalidWKB
+	}
+	p.pos++
+	tp, err := p.readUint32()
+	if err != nil {
+		return g, err
+	}
+	if tp < uint32(mysql.GeometryTypePoint) || tp > uint32(mysql.GeometryTypeGeometryCollection) {
+		return g, errInvalidWKB
+	}
+	g.Tp = byte(tp)
+	switch g.Tp {
+	case mysql.GeometryTypePoint:
+		pt, err := p.readPoint()
+		if err != nil {
+			return g, err
+		}
+		g.Points = []GeoPoint{pt}
+		return g, nil
+	case mysql.GeometryTypeLineString:
+		g.Points, err = p.readPoints(2)
+		return g, err
+	case mysql.GeometryTypePolygon:
+		n, err := p.readUint32()
+		if err != nil {
+			return g, err
+		}
+		if n == 0 || int(n) > len(p.data)-p.pos {
+			return g, errInvalidWKB
+		}
+		g.Rings = make([][]GeoPoint, 0, n)
+		for i := uint32(0); i < n; i++ {
+			ring, err := p.readPoints(4)
+			if err != nil {
+				return g, err
+			}
+			if ring[0] != ring[len(ring)-1] {
+				return g, errInvalidWKB
+			}
+			g.Rings = append(g.Rings, ring)
+		}
+		return g, nil
+	}
+	n, err := p.readUint32()
+	if err != nil {
+		return g, err
+	}
+	if int(n) > len(p.data)-p.pos || (n == 0 && g.Tp != mysql.GeometryTypeGeometryCollection) {
+		return g, errInvalidWKB
+	}
+	g.Geoms = make([]Geometry, 0, n)
+	for i := uint32(0); i < n; i++ {
+		elem, err := p.parseGeometry(depth + 1)
+		if err != nil {
+			return g, err
+		}
+		if elemTp := multiElemType(g.Tp); elemTp != mysql.GeometryTypeGeometry && elem.Tp != elemTp {
+			return g, errInvalidWKB
+		}
+		g.Geoms = append(g.Geoms, elem)
+	}
+	return g, nil
+}
+
+// multiElemType returns the type of the elements of a multi geometry, or GeometryTypeGeometry
+// if the elements can be any geometry.
+func multiElemType(tp byte) byte {
+	switch tp {
+	case mysql.GeometryTypeMultiPoint:
+		return mysql.GeometryTypePoint
+	case mysql.GeometryTypeMultiLineString:
+		return mysql.GeometryTypeLineString
+	case mysql.GeometryTypeMultiPolygon:
+		return mysql.GeometryTypePolygon
+	}
+	return mysql.GeometryTypeGeometry
+}
+
+// WKT returns the WKT (well-known text) representation of the geometry.
+func (g Geometry) WKT() string {
+	var sb strings.Builder
+	g.writeWKT(&sb, true)
+	return sb.String()
+}
+
+func writeWKTPoint(sb *strings.Builder, p GeoPoint) {
+	sb.WriteString(strconv.FormatFloat(p.X, 'g', -1, 64))
+	sb.WriteByte(' ')
+	sb.WriteString(strconv.FormatFloat(p.Y, 'g', -1, 64))
+}
+
+func writeWKTPoints(sb *strings.Builder, points []GeoPoint) {
+	sb.WriteByte('(')
+	for i, p := range points {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		writeWKTPoint(sb, p)
+	}
+	sb.WriteByte(')')
+}
+
+func (g Geometry) writeWKT(sb *strings.Builder, withName bool) {
+	if withName {
+		sb.WriteString(g.GeometryTypeName())
+	}
+	switch g.Tp {
+	case mysql.GeometryTypePoint, mysql.GeometryTypeLineString:
+		writeWKTPoints(sb, g.Points)
+		return
+	case mysql.GeometryTypePolygon:
+		sb.WriteByte('(')
+		for i, ring := range g.Rings {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			writeWKTPoints(sb, ring)
+		}
+		sb.WriteByte(')')
+		return
+	}
+	if len(g.Geoms) == 0 {
+		sb.WriteString(" EMPTY")
+		return
+	}
+	sb.WriteByte('(')
+	for i, elem := range g.Geoms {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		elem.writeWKT(sb, g.Tp == mysql.GeometryTypeGeometryCollection)
+	}
+	sb.WriteByte(')')
+}
+
+// ParseGeometryWKT parses the WKT representation of a geometry.
+func ParseGeometryWKT(s string) (Geometry, error) {
+	p := wktParser{s: s}
+	g, err := p.parseGeometry(0)
+	if err != nil {
+		return g, err
+	}
+	p.skipSpaces()
+	if p.pos != len(p.s) {
+		return g, errors.Errorf("invalid WKT: unexpected '%s'", p.s[p.pos:])
+	}
+	return g, nil
+}
+
+type wktParser struct {
+	s   string
+	pos int
+}
+
+func (p *wktParser) skipSpaces() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *wktParser) errorf(expected string) error {
+	return errors.Errorf("invalid WKT: expect %s at position %d", expected, p.pos)
+}
+
+// tryConsume consumes the byte c if it's the next non-space byte.
+func (p *wktParser) tryConsume(c byte) bool {
+	p.skipSpaces()
+	if p.pos < len(p.s) && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) consume(c byte) error {
+	if !p.tryConsume(c) {
+		return p.errorf("'" + string(c) + "'")
+	}
+	return nil
+}
+
+func (p *wktParser) readWord() string {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && (p.s[p.pos] >= 'a' && p.s[p.pos] <= 'z' || p.s[p.pos] >= 'A' && p.s[p.pos] <= 'Z') {
+		p.pos++
+	}
+	return strings.ToLower(p.s[start:p.pos])
+}
+
+func (p *wktParser) readNumber() (float64, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.s) && strings.IndexByte("0123456789+-.eE", p.s[p.pos]) >= 0 {
+		p.pos++
+	}
+	f, err := strconv.ParseFloat(p.s[start:p.pos], 64)
+	if err != nil || math.IsInf(f, 0) {
+		p.pos = start
+		return 0, p.errorf("number")
+	}
+	return f, nil
+}
+
+func (p *wktParser) readPoint() (pt GeoPoint, err error) {
+	if pt.X, err = p.readNumber(); err != nil {
+		return pt, err
+	}
+	pt.Y, err = p.readNumber()
+	return pt, err
+}
+
+// readPoints reads a parenthesized list of points.
+func (p *wktParser) readPoints(minPoints int) ([]GeoPoint, error) {
+	if err := p.consume('('); err != nil {
+		return nil, err
+	}
+	var points []GeoPoint
+	for {
+		pt, err := p.readPoint()
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, pt)
+		if !p.tryConsume(',') {
+			break
+		}
+	}
+	if err := p.consume(')'); err != nil {
+		return nil, err
+	}
+	if len(points) < minPoints {
+		return nil, p.errorf("at least " + strconv.Itoa(minPoints) + " points")
+	}
+	return points, nil
+}
+
+func (p *wktParser) readPolygon() (g Geometry, err error) {
+	g.Tp = mysql.GeometryTypePolygon
+	if err = p.consume('('); err != nil {
+		return g, err
+	}
+	for {
+		ring, err := p.readPoints(4)
+		if err != nil {
+			return g, err
+		}
+		if ring[0] != ring[len(ring)-1] {
+			return g, p.errorf("closed ring")
+		}
+		g.Rings = append(g.Rings, ring)
+		if !p.tryConsume(',') {
+			break
+		}
+	}
+	return g, p.consume(')')
+}
+
+func (p *wktParser) parseGeometry(depth int) (g Geometry, err error) {
+	if depth > maxGeometryNested {
+		return g, errors.New("invalid WKT: too many nested geometries")
+	}
+	name := p.readWord()
+	switch name {
+	case "point":
+		g.Tp = mysql.GeometryTypePoint
+		g.Points, err = p.readPoints(1)
+		if err == nil && len(g.Points) != 1 {
+			err = p.errorf("one point")
+		}
+		return g, err
+	case "linestring":
+		g.Tp = mysql.GeometryTypeLineString
+		g.Points, err = p.readPoints(2)
+		return g, err
+	case "polygon":
+		return p.readPolygon()
+	case "multipoint", "multilinestring", "multipolygon":
+		g.Tp = map[string]byte{
+			"multipoint":      mysql.GeometryTypeMultiPoint,
+			"multilinestring": mysql.GeometryTypeMultiLineString,
+			"multipolygon":    mysql.GeometryTypeMultiPolygon,
+		}[name]
+	case "geometrycollection", "geomcollection":
+		g.Tp = mysql.GeometryTypeGeometryCollection
+		if p.readWord() == "empty" {
+			return g, nil
+		}
+	default:
+		return g, p.errorf("geometry type")
+	}
+	if err = p.consume('('); err != nil {
+		return g, err
+	}
+	for {
+		var elem Geometry
+		switch g.Tp {
+		case mysql.GeometryTypeMultiPoint:
+			// Both MULTIPOINT(1 1,2 2) and MULTIPOINT((1 1),(2 2)) are accepted.
+			parenthesized := p.tryConsume('(')
+			var pt GeoPoint
+			if pt, err = p.readPoint(); err == nil && parenthesized {
+				err = p.consume(')')
+			}
+			elem = Geometry{Tp: mysql.GeometryTypePoint, Points: []GeoPoint{pt}}
+		case mysql.GeometryTypeMultiLineString:
+			elem.Tp = mysql.GeometryTypeLineString
+			elem.Points, err = p.readPoints(2)
+		case mysql.GeometryTypeMultiPolygon:
+			elem, err = p.readPolygon()
+		default:
+			elem, err = p.parseGeometry(depth + 1)
+		}
+		if err != nil {
+			return g, err
+		}
+		g.Geoms = append(g.Geoms, elem)
+		if !p.tryConsume(',') {
+			break
+		}
+	}
+	return g, p.consume(')')
+}
@@ -0,0 +1,468 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/pingcap/tidb/parser/mysql"
+)
+
+// MBR is the minimum bounding rectangle of a geometry.
+type MBR struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// EmptyMBR returns the MBR of an empty geometry, it doesn't intersect any MBR.
+func EmptyMBR() MBR {
+	return MBR{MinX: math.Inf(1), MinY: math.Inf(1), MaxX: math.Inf(-1), MaxY: math.Inf(-1)}
+}
+
+// IsEmpty returns whether the MBR is empty.
+func (m MBR) IsEmpty() bool {
+	return m.MinX > m.MaxX || m.MinY > m.MaxY
+}
+
+// Intersects returns whether two MBRs intersect, touching MBRs are considered intersected.
+func (m MBR) Intersects(other MBR) bool {
+	return m.MinX <= other.MaxX && other.MinX <= m.MaxX && m.MinY <= other.MaxY && other.MinY <= m.MaxY
+}
+
+// Expand returns the MBR expanded by d on each side.
+func (m MBR) Expand(d float64) MBR {
+	if m.IsEmpty() {
+		return m
+	}
+	return MBR{MinX: m.MinX - d, MinY: m.MinY - d, MaxX: m.MaxX + d, MaxY: m.MaxY + d}
+}
+
+func (m *MBR) addPoint(p GeoPoint) {
+	m.MinX = math.Min(m.MinX, p.X)
+	m.MinY = math.Min(m.MinY, p.Y)
+	m.MaxX = math.Max(m.MaxX, p.X)
+	m.MaxY = math.Max(m.MaxY, p.Y)
+}
+
+// Envelope returns the MBR of the geometry.
+func (g Geometry) Envelope() MBR {
+	m := EmptyMBR()
+	g.walkPoints(m.addPoint)
+	return m
+}
+
+func (g Geometry) walkPoints(fn func(p GeoPoint)) {
+	for _, p := range g.Points {
+		fn(p)
+	}
+	// The holes are inside the exterior ring, so only the exterior ring is walked.
+	if len(g.Rings) > 0 {
+		for _, p := range g.Rings[0] {
+			fn(p)
+		}
+	}
+	for _, elem := range g.Geoms {
+		elem.walkPoints(fn)
+	}
+}
+
+// EncodeMBRKey encodes the MBR into a memory-comparable key, which is the
+// index value of a spatial index. The 4 coordinates are encoded in the order
+// of min x, min y, max x and max y, so an index range on the key prefix can be
+// used to prune the geometries by their min x.
+func EncodeMBRKey(m MBR) []byte {
+	buf := make([]byte, 0, 32)
+	for _, f := range []float64{m.MinX, m.MinY, m.MaxX, m.MaxY} {
+		buf = binary.BigEndian.AppendUint64(buf, encodeCmpFloat(f))
+	}
+	return buf
+}
+
+// DecodeMBRKey decodes the MBR from the key encoded by EncodeMBRKey.
+func DecodeMBRKey(key []byte) (m MBR, ok bool) {
+	if len(key) != 32 {
+		return m, false
+	}
+	m.MinX = decodeCmpFloat(binary.BigEndian.Uint64(key))
+	m.MinY = decodeCmpFloat(binary.BigEndian.Uint64(key[8:]))
+	m.MaxX = decodeCmpFloat(binary.BigEndian.Uint64(key[16:]))
+	m.MaxY = decodeCmpFloat(binary.BigEndian.Uint64(key[24:]))
+	return m, true
+}
+
+func encodeCmpFloat(f float64) uint64 {
+	if f == 0 {
+		// Normalize -0 to 0.
+		f = 0
+	}
+	u := math.Float64bits(f)
+	if f >= 0 {
+		return u | 1<<63
+	}
+	return ^u
+}
+
+func decodeCmpFloat(u uint64) float64 {
+	if u&(1<<63) != 0 {
+		return math.Float64frombits(u &^ (1 << 63))
+	}
+	return math.Float64frombits(^u)
+}
+
+// geoSegment is a segment between two points.
+type geoSegment struct {
+	a, b GeoPoint
+}
+
+// geoComponents is a geometry decomposed into its points, segments and polygons.
+type geoComponents struct {
+	points   []GeoPoint
+	segments []geoSegment
+	polygons [][][]GeoPoint
+	// lineEnds are the end points of the non-closed line strings, which are the boundary of the lines.
+	lineEnds []GeoPoint
+}
+
+func (g Geometry) components() *geoComponents {
+	c := &geoComponents{}
+	g.collectComponents(c)
+	return c
+}
+
+func (g Geometry) collectComponents(c *geoComponents) {
+	switch g.Tp {
+	case mysql.GeometryTypePoint:
+		c.points = append(c.points, g.Points[0])
+	case mysql.GeometryTypeLineString:
+		for i := 1; i < len(g.Points); i++ {
+			c.segments = append(c.segments, geoSegment{g.Points[i-1], g.Points[i]})
+		}
+		if first, last := g.Points[0], g.Points[len(g.Points)-1]; first != last {
+			c.lineEnds = append(c.lineEnds, first, last)
+		}
+	case mysql.GeometryTypePolygon:
+		c.polygons = append(c.polygons, g.Rings)
+	default:
+		for _, elem := range g.Geoms {
+			elem.collectComponents(c)
+		}
+	}
+}
+
+func ringSegments(rings [][]GeoPoint, fn func(s geoSegment) bool) bool {
+	for _, ring := range rings {
+		for i := 1; i < len(ring); i++ {
+			if fn(geoSegment{ring[i-1], ring[i]}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func cross(o, a, b GeoPoint) float64 {
+	return (a.X-o.X)*(b.Y-o.Y) - (a.Y-o.Y)*(b.X-o.X)
+}
+
+func sign(f float64) int {
+	if f > 0 {
+		return 1
+	} else if f < 0 {
+		return -1
+	}
+	return 0
+}
+
+func pointOnSegment(p GeoPoint, s geoSegment) bool {
+	return cross(s.a, s.b, p) == 0 &&
+		math.Min(s.a.X, s.b.X) <= p.X && p.X <= math.Max(s.a.X, s.b.X) &&
+		math.Min(s.a.Y, s.b.Y) <= p.Y && p.Y <= math.Max(s.a.Y, s.b.Y)
+}
+
+func segmentsIntersect(s1, s2 geoSegment) bool {
+	d1 := sign(cross(s2.a, s2.b, s1.a))
+	d2 := sign(cross(s2.a, s2.b, s1.b))
+	d3 := sign(cross(s1.a, s1.b, s2.a))
+	d4 := sign(cross(s1.a, s1.b, s2.b))
+	if d1*d2 < 0 && d3*d4 < 0 {
+		return true
+	}
+	return pointOnSegment(s1.a, s2) || pointOnSegment(s1.b, s2) || pointOnSegment(s2.a, s1) || pointOnSegment(s2.b, s1)
+}
+
+// segmentsCross returns whether two segments cross at a single point which is inside both segments.
+func segmentsCross(s1, s2 geoSegment) bool {
+	d1 := sign(cross(s2.a, s2.b, s1.a))
+	d2 := sign(cross(s2.a, s2.b, s1.b))
+	d3 := sign(cross(s1.a, s1.b, s2.a))
+	d4 := sign(cross(s1.a, s1.b, s2.b))
+	return d1*d2 < 0 && d3*d4 < 0
+}
+
+const (
+	outsidePolygon = iota
+	onPolygonBoundary
+	insidePolygon
+)
+
+// locatePoint returns whether the point is inside, outside or on the boundary of the polygon.
+func locatePoint(p GeoPoint, rings [][]GeoPoint) int {
+	if ringSegments(rings, func(s geoSegment) bool { return pointOnSegment(p, s) }) {
+		return onPolygonBoundary
+	}
+	for i, ring := range rings {
+		inside := false
+		for j := 1; j < len(ring); j++ {
+			a, b := ring[j-1], ring[j]
+			if (a.Y > p.Y) != (b.Y > p.Y) && p.X < (b.X-a.X)*(p.Y-a.Y)/(b.Y-a.Y)+a.X {
+				inside = !inside
+			}
+		}
+		// The point must be inside the exterior ring and outside all holes.
+		if inside != (i == 0) {
+			return outsidePolygon
+		}
+	}
+	return insidePolygon
+}
+
+func segmentIntersectsPolygon(s geoSegment, rings [][]GeoPoint) bool {
+	return locatePoint(s.a, rings) != outsidePolygon ||
+		ringSegments(rings, func(edge geoSegment) bool { return segmentsIntersect(s, edge) })
+}
+
+func polygonsIntersect(p1, p2 [][]GeoPoint) bool {
+	if ringSegments(p1, func(s geoSegment) bool { return segmentIntersectsPolygon(s, p2) }) {
+		return true
+	}
+	return locatePoint(p2[0][0], p1) != outsidePolygon
+}
+
+// GeometryIntersects returns whether two geometries intersect.
+func GeometryIntersects(g1, g2 Geometry) bool {
+	if !g1.Envelope().Intersects(g2.Envelope()) {
+		return false
+	}
+	c1, c2 := g1.components(), g2.components()
+	return c1.intersects(c2) || c2.intersects(c1)
+}
+
+// intersects checks the points and segments of c against all components of other,
+// and the polygons of c against the polygons of other.
+func (c *geoComponents) intersects(other *geoComponents) bool {
+	for _, p := range c.points {
+		for _, q := range other.points {
+			if p == q {
+				return true
+			}
+		}
+		for _, s := range other.segments {
+			if pointOnSegment(p, s) {
+				return true
+			}
+		}
+		for _, poly := range other.polygons {
+			if locatePoint(p, poly) != outsidePolygon {
+				return true
+			}
+		}
+	}
+	for _, s := range c.segments {
+		for _, t := range other.segments {
+			if segmentsIntersect(s, t) {
+				return true
+			}
+		}
+		for _, poly := range other.polygons {
+			if segmentIntersectsPolygon(s, poly) {
+				return true
+			}
+		}
+	}
+	for _, p1 := range c.polygons {
+		for _, p2 := range other.polygons {
+			if polygonsIntersect(p1, p2) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GeometryContains returns whether g1 contains g2, that is no points of g2 lie in
+// the exterior of g1, and at least one point of the interior of g2 lies in the interior of g1.
+func GeometryContains(g1, g2 Geometry) bool {
+	if g1.IsEmpty() || g2.IsEmpty() {
+		return false
+	}
+	m1, m2 := g1.Envelope(), g2.Envelope()
+	if m2.MinX < m1.MinX || m2.MinY < m1.MinY || m2.MaxX > m1.MaxX || m2.MaxY > m1.MaxY {
+		return false
+	}
+	c1, c2 := g1.components(), g2.components()
+	interior := false
+	for _, p := range c2.points {
+		covered, inInterior := c1.coverPoint(p)
+		if !covered {
+			return false
+		}
+		interior = interior || inInterior
+	}
+	for _, s := range c2.segments {
+		covered, inInterior := c1.coverSegment(s)
+		if !covered {
+			return false
+		}
+		interior = interior || inInterior
+	}
+	for _, poly := range c2.polygons {
+		covered, inInterior := c1.coverPolygon(poly)
+		if !covered {
+			return false
+		}
+		interior = interior || inInterior
+	}
+	return interior
+}
+
+// coverPoint returns whether the point lies in c, and whether it lies in the interior of c.
+func (c *geoComponents) coverPoint(p GeoPoint) (covered, interior bool) {
+	for _, poly := range c.polygons {
+		switch locatePoint(p, poly) {
+		case insidePolygon:
+			return true, true
+		case onPolygonBoundary:
+			covered = true
+		}
+	}
+	for _, s := range c.segments {
+		if pointOnSegment(p, s) {
+			covered = true
+			interior = true
+			for _, end := range c.lineEnds {
+				if p == end {
+					interior = false
+				}
+			}
+			if interior {
+				return true, true
+			}
+		}
+	}
+	for _, q := range c.points {
+		if p == q {
+			return true, true
+		}
+	}
+	return covered, false
+}
+
+func (c *geoComponents) coverSegment(s geoSegment) (covered, interior bool) {
+	for _, poly := range c.polygons {
+		if locatePoint(s.a, poly) == outsidePolygon || locatePoint(s.b, poly) == outsidePolygon {
+			continue
+		}
+		if ringSegments(poly, func(edge geoSegment) bool { return segmentsCross(s, edge) }) {
+			continue
+		}
+		mid := GeoPoint{X: (s.a.X + s.b.X) / 2, Y: (s.a.Y + s.b.Y) / 2}
+		switch locatePoint(mid, poly) {
+		case insidePolygon:
+			return true, true
+		case onPolygonBoundary:
+			covered = true
+		}
+	}
+	for _, t := range c.segments {
+		if pointOnSegment(s.a, t) && pointOnSegment(s.b, t) {
+			return true, true
+		}
+	}
+	return covered, false
+}
+
+func (c *geoComponents) coverPolygon(rings [][]GeoPoint) (covered, interior bool) {
+	for _, poly := range c.polygons {
+		if ringSegments(rings, func(s geoSegment) bool {
+			ok, _ := (&geoComponents{polygons: [][][]GeoPoint{poly}}).coverSegment(s)
+			return !ok
+		}) {
+			continue
+		}
+		// A hole of the container must not be inside the polygon.
+		holeInside := false
+		for _, hole := range poly[1:] {
+			for _, p := range hole {
+				if locatePoint(p, rings) == insidePolygon {
+					holeInside = true
+				}
+			}
+		}
+		if !holeInside {
+			return true, true
+		}
+	}
+	return false, false
+}
+
+// GeometryDistance returns the minimum Cartesian distance between two geometries.
+func GeometryDistance(g1, g2 Geometry) float64 {
+	if GeometryIntersects(g1, g2) {
+		return 0
+	}
+	c1, c2 := g1.components(), g2.components()
+	c1.addPolygonSegments()
+	c2.addPolygonSegments()
+	dist := math.Inf(1)
+	for _, p := range c1.points {
+		for _, q := range c2.points {
+			dist = math.Min(dist, math.Hypot(p.X-q.X, p.Y-q.Y))
+		}
+		for _, s := range c2.segments {
+			dist = math.Min(dist, pointSegmentDistance(p, s))
+		}
+	}
+	for _, s := range c1.segments {
+		for _, q := range c2.points {
+			dist = math.Min(dist, pointSegmentDistance(q, s))
+		}
+		for _, t := range c2.segments {
+			dist = min(dist, pointSegmentDistance(s.a, t), pointSegmentDistance(s.b, t),
+				pointSegmentDistance(t.a, s), pointSegmentDistance(t.b, s))
+		}
+	}
+	return dist
+}
+
+// addPolygonSegments adds the edges of the polygons to the segments, it's used to compute the
+// distance between the geometries that don't intersect.
+func (c *geoComponents) addPolygonSegments() {
+	for _, poly := range c.polygons {
+		ringSegments(poly, func(s geoSegment) bool {
+			c.segments = append(c.segments, s)
+			return false
+		})
+	}
+}
+
+func pointSegmentDistance(p GeoPoint, s geoSegment) float64 {
+	dx, dy := s.b.X-s.a.X, s.b.Y-s.a.Y
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.X-s.a.X, p.Y-s.a.Y)
+	}
+	t := ((p.X-s.a.X)*dx + (p.Y-s.a.Y)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-(s.a.X+t*dx), p.Y-(s.a.Y+t*dy))
+}
@@ -0,0 +1,144 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package types
+
+import (
+	"bytes"
+	"math"
+	"testing"
+
+	"github.com/pingcap/tidb/parser/mysql"
+	"github.com/stretchr/testify/require"
+)
+
+func mustParseGeometry(t *testing.T, wkt string) Geometry {
+	g, err := ParseGeometryWKT(wkt)
+	require.NoError(t, err, wkt)
+	return g
+}
+
+func TestGeometryWKT(t *testing.T) {
+	tests := []struct {
+		input  string
+		output string
+	}{
+		{"POINT(1 2)", "POINT(1 2)"},
+		{" point ( -1.5  2e3 ) ", "POINT(-1.5 2000)"},
+		{"LINESTRING(0 0, 1 1, 2 0)", "LINESTRING(0 0,1 1,2 0)"},
+		{"POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))", "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 1))"},
+		{"MULTIPOINT(1 1, 2 2)", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTIPOINT((1 1),(2 2))", "MULTIPOINT((1 1),(2 2))"},
+		{"MULTILINESTRING((0 0,1 1),(2 2,3 3))", "MULTILINESTRING((0 0,1 1),(2 2,3 3))"},
+		{"MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))", "MULTIPOLYGON(((0 0,1 0,1 1,0 0)),((2 2,3 2,3 3,2 2)))"},
+		{"GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))", "GEOMETRYCOLLECTION(POINT(1 1),LINESTRING(0 0,1 1))"},
+		{"GEOMETRYCOLLECTION EMPTY", "GEOMETRYCOLLECTION EMPTY"},
+	}
+	for _, tt := range tests {
+		g := mustParseGeometry(t, tt.input)
+		require.Equal(t, tt.output, g.WKT())
+
+		g2, err := ParseGeometryWKB(g.WKB())
+		require.NoError(t, err)
+		require.Equal(t, tt.output, g2.WKT())
+
+		srid, g3, err := DecodeGeometry(EncodeGeometry(4326, g))
+		require.NoError(t, err)
+		require.Equal(t, uint32(4326), srid)
+		require.Equal(t, tt.output, g3.WKT())
+	}
+
+	for _, invalid := range []string{
+		"", "POINT", "POINT()", "POINT(1)", "POINT(1 2 3)", "POINT(1 2", "LINESTRING(0 0)",
+		"POLYGON((0 0,1 0,1 1,0 1))", "POLYGON((0 0,1 1,0 0))", "MULTIPOINT()", "CIRCLE(0 0)", "POINT(1 2) x",
+	} {
+		_, err := ParseGeometryWKT(invalid)
+		require.Error(t, err, invalid)
+	}
+}
+
+func TestGeometryWKB(t *testing.T) {
+	// POINT(1 2) in big-endian.
+	wkb := []byte{0, 0, 0, 0, 1, 0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x40, 0, 0, 0, 0, 0, 0, 0}
+	g, err := ParseGeometryWKB(wkb)
+	require.NoError(t, err)
+	require.Equal(t, "POINT(1 2)", g.WKT())
+	require.Equal(t, byte(mysql.GeometryTypePoint), g.Tp)
+
+	for _, invalid := range [][]byte{nil, {1}, {2, 1, 0, 0, 0}, wkb[:len(wkb)-1], append(wkb, 0)} {
+		_, err := ParseGeometryWKB(invalid)
+		require.Error(t, err)
+	}
+	_, _, err = DecodeGeometry([]byte{0, 0, 0})
+	require.Error(t, err)
+}
+
+func TestGeometryRelations(t *testing.T) {
+	square := mustParseGeometry(t, "POLYGON((0 0,4 0,4 4,0 4,0 0),(1 1,2 1,2 2,1 2,1 1))")
+	tests := []struct {
+		g          string
+		intersects bool
+		contains   bool
+		distance   float64
+	}{
+		{"POINT(3 3)", true, true, 0},
+		{"POINT(1.5 1.5)", false, false, 0.5},
+		{"POINT(4 2)", true, false, 0},
+		{"POINT(7 8)", false, false, 5},
+		{"LINESTRING(3 0.5,3 3.5)", true, true, 0},
+		{"LINESTRING(3 3,6 3)", true, false, 0},
+		{"LINESTRING(5 0,5 4)", false, false, 1},
+		{"POLYGON((2.5 2.5,3.5 2.5,3.5 3.5,2.5 2.5))", true, true, 0},
+		{"POLYGON((0.5 0.5,3.5 0.5,3.5 3.5,0.5 3.5,0.5 0.5))", true, false, 0},
+		{"POLYGON((6 0,7 0,7 1,6 0))", false, false, 2},
+		{"MULTIPOINT(3 3,3.5 3.5)", true, true, 0},
+		{"MULTIPOINT(3 3,9 9)", true, false, 0},
+		{"GEOMETRYCOLLECTION(POINT(3 3),LINESTRING(0.5 3,3.5 3))", true, true, 0},
+	}
+	for _, tt := range tests {
+		g := mustParseGeometry(t, tt.g)
+		require.Equal(t, tt.intersects, GeometryIntersects(square, g), tt.g)
+		require.Equal(t, tt.intersects, GeometryIntersects(g, square), tt.g)
+		require.Equal(t, tt.contains, GeometryContains(square, g), tt.g)
+		require.InDelta(t, tt.distance, GeometryDistance(square, g), 1e-9, tt.g)
+		require.InDelta(t, tt.distance, GeometryDistance(g, square), 1e-9, tt.g)
+	}
+
+	line := mustParseGeometry(t, "LINESTRING(0 0,2 0)")
+	require.True(t, GeometryContains(line, mustParseGeometry(t, "POINT(1 0)")))
+	require.False(t, GeometryContains(line, mustParseGeometry(t, "POINT(0 0)")))
+	require.True(t, GeometryContains(mustParseGeometry(t, "POINT(1 1)"), mustParseGeometry(t, "POINT(1 1)")))
+	require.False(t, GeometryContains(square, mustParseGeometry(t, "GEOMETRYCOLLECTION EMPTY")))
+	require.InDelta(t, math.Sqrt(2), GeometryDistance(mustParseGeometry(t, "POINT(0 0)"), mustParseGeometry(t, "POINT(1 1)")), 1e-9)
+}
+
+func TestMBRKey(t *testing.T) {
+	g := mustParseGeometry(t, "LINESTRING(-1 2,3 -4)")
+	m := g.Envelope()
+	require.Equal(t, MBR{MinX: -1, MinY: -4, MaxX: 3, MaxY: 2}, m)
+	decoded, ok := DecodeMBRKey(EncodeMBRKey(m))
+	require.True(t, ok)
+	require.Equal(t, m, decoded)
+	require.True(t, m.Intersects(MBR{MinX: 3, MinY: 2, MaxX: 5, MaxY: 5}))
+	require.False(t, m.Intersects(MBR{MinX: 3.5, MinY: 0, MaxX: 5, MaxY: 5}))
+	require.False(t, m.Intersects(mustParseGeometry(t, "GEOMETRYCOLLECTION EMPTY").Envelope()))
+
+	// The keys are ordered by min x.
+	values := []float64{math.Inf(-1), -100, -1.5, 0, math.Copysign(0, -1), 1e-300, 2, 1e300}
+	for i := 1; i < len(values); i++ {
+		prev := EncodeMBRKey(MBR{MinX: values[i-1]})
+		cur := EncodeMBRKey(MBR{MinX: values[i]})
+		require.LessOrEqual(t, bytes.Compare(prev, cur), 0, "%v %v", values[i-1], values[i])
+	}
+}
@@ -254,6 +254,11 @@ func (bj BinaryJSON) Extract(pathExprList []JSONPathExpression) (ret BinaryJSON,
 	return
 }
 
+// ExtractAll returns all the values matched by pathExpr in bj, in document order.
+func (bj BinaryJSON) ExtractAll(pathExpr JSONPathExpression) []BinaryJSON {
+	return bj.extractTo(nil, pathExpr, make(map[*byte]struct{}), false)
+}
+
 func (bj BinaryJSON) extractOne(pathExpr JSONPathExpression) []BinaryJSON {
 	result := make([]BinaryJSON, 0, 1)
 	return bj.extractTo(result, pathExpr, nil, true)
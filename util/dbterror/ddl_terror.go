@@ -83,6 +83,8 @@ var (
 	ErrIncorrectPrefixKey = ClassDDL.NewStd(mysql.ErrWrongSubKey)
 	// ErrTooLongKey is used when the column key is too long.
 	ErrTooLongKey = ClassDDL.NewStd(mysql.ErrTooLongKey)
+	// ErrTooManyKeyParts is used when the index has too many columns.
+	ErrTooManyKeyParts = ClassDDL.NewStd(mysql.ErrTooManyKeyParts)
 	// ErrKeyColumnDoesNotExits is used when the key column doesn't exist.
 	ErrKeyColumnDoesNotExits = ClassDDL.NewStd(mysql.ErrKeyColumnDoesNotExits)
 	// ErrInvalidDDLJobVersion is used when the DDL job version is invalid.
@@ -377,6 +379,12 @@ var (
 	ErrFunctionalIndexOnBlob = ClassDDL.NewStd(mysql.ErrFunctionalIndexOnBlob)
 	// ErrDependentByPartitionFunctional returns when the dropped column depends by expression partition.
 	ErrDependentByPartitionFunctional = ClassDDL.NewStd(mysql.ErrDependentByPartitionFunctional)
+	// ErrSpatialCantHaveNull returns when the column of a spatial index is nullable.
+	ErrSpatialCantHaveNull = ClassDDL.NewStd(mysql.ErrSpatialCantHaveNull)
+	// ErrSpatialMustHaveGeomCol returns when the column of a spatial index is not a geometry column.
+	ErrSpatialMustHaveGeomCol = ClassDDL.NewStd(mysql.ErrSpatialMustHaveGeomCol)
+	// ErrSpatialFunctionalIndex returns when creating a spatial expression index.
+	ErrSpatialFunctionalIndex = ClassDDL.NewStd(mysql.ErrSpatialFunctionalIndex)
 
 	// ErrUnsupportedAlterTableSpec means we don't support this alter table specification (i.e. unknown)
 	ErrUnsupportedAlterTableSpec = ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message(fmt.Sprintf(mysql.MySQLErrName[mysql.ErrUnsupportedDDLOperation].Raw, "Unsupported/unknown ALTER TABLE specification"), nil))
@@ -445,6 +453,10 @@ var (
 	ErrUnsupportedTTLReferencedByFK = ClassDDL.NewStd(mysql.ErrUnsupportedTTLReferencedByFK)
 	// ErrUnsupportedPrimaryKeyTypeWithTTL returns when create or alter a table with TTL options but the primary key is not supported
 	ErrUnsupportedPrimaryKeyTypeWithTTL = ClassDDL.NewStd(mysql.ErrUnsupportedPrimaryKeyTypeWithTTL)
+	// ErrUnsupportedTTLArchiveTable returns when the archive table in TTL config is not suitable
+	ErrUnsupportedTTLArchiveTable = ClassDDL.NewStd(mysql.ErrUnsupportedTTLArchiveTable)
+	// ErrTTLArchiveTableInUse returns when dropping or renaming the archive table of a TTL table
+	ErrTTLArchiveTableInUse = ClassDDL.NewStd(mysql.ErrTTLArchiveTableInUse)
 
 	// ErrNotSupportedYet returns when tidb does not support this feature.
 	ErrNotSupportedYet = ClassDDL.NewStd(mysql.ErrNotSupportedYet)
@@ -469,6 +481,21 @@ var (
 	ErrCheckConstraintUsingFKReferActionColumn = ClassDDL.NewStd(mysql.ErrCheckConstraintClauseUsingFKReferActionColumn)
 	// ErrNonBooleanExprForCheckConstraint is returned for non bool expression.
 	ErrNonBooleanExprForCheckConstraint = ClassDDL.NewStd(mysql.ErrNonBooleanExprForCheckConstraint)
+
+	// ErrTrgAlreadyExists is returned when the trigger to create already exists in the schema.
+	ErrTrgAlreadyExists = ClassDDL.NewStd(mysql.ErrTrgAlreadyExists)
+	// ErrTrgDoesNotExist is returned when the trigger to drop does not exist.
+	ErrTrgDoesNotExist = ClassDDL.NewStd(mysql.ErrTrgDoesNotExist)
+	// ErrTrgOnViewOrTempTable is returned when creating a trigger on a view, a sequence or a temporary table.
+	ErrTrgOnViewOrTempTable = ClassDDL.NewStd(mysql.ErrTrgOnViewOrTempTable)
+	// ErrTrgInWrongSchema is returned when the trigger and its table are in different schemas.
+	ErrTrgInWrongSchema = ClassDDL.NewStd(mysql.ErrTrgInWrongSchema)
+	// ErrNoTriggersOnSystemSchema is returned when creating a trigger on a system table.
+	ErrNoTriggersOnSystemSchema = ClassDDL.NewStd(mysql.ErrNoTriggersOnSystemSchema)
+	// ErrMViewFastRefreshUnsupported is returned when the query of a materialized view can't be refreshed fast.
+	ErrMViewFastRefreshUnsupported = ClassDDL.NewStd(mysql.ErrMViewFastRefreshUnsupported)
+	// ErrMViewBaseTableInUse is returned when dropping or truncating the base table of a materialized view refreshed fast.
+	ErrMViewBaseTableInUse = ClassDDL.NewStd(mysql.ErrMViewBaseTableInUse)
 )
 
 // ReorgRetryableErrCodes is the error codes that are retryable for reorganization.
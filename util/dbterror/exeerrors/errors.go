@@ -67,6 +67,8 @@ var (
 	ErrBRIEExportFailed               = dbterror.ClassExecutor.NewStd(mysql.ErrBRIEExportFailed)
 	ErrBRJobNotFound                  = dbterror.ClassExecutor.NewStd(mysql.ErrBRJobNotFound)
 	ErrCTEMaxRecursionDepth           = dbterror.ClassExecutor.NewStd(mysql.ErrCTEMaxRecursionDepth)
+	ErrMissingJSONTableValue          = dbterror.ClassExecutor.NewStd(mysql.ErrMissingJSONTableValue)
+	ErrWrongJSONTableValue            = dbterror.ClassExecutor.NewStd(mysql.ErrWrongJSONTableValue)
 	ErrNotSupportedWithSem            = dbterror.ClassOptimizer.NewStd(mysql.ErrNotSupportedWithSem)
 	ErrPluginIsNotLoaded              = dbterror.ClassExecutor.NewStd(mysql.ErrPluginIsNotLoaded)
 	ErrSetPasswordAuthPlugin          = dbterror.ClassExecutor.NewStd(mysql.ErrSetPasswordAuthPlugin)
@@ -76,6 +78,45 @@ var (
 	ErrPasswordExpireAnonymousUser    = dbterror.ClassExecutor.NewStd(mysql.ErrPasswordExpireAnonymousUser)
 	ErrMustChangePassword             = dbterror.ClassExecutor.NewStd(mysql.ErrMustChangePassword)
 
+	ErrTooManyRows             = dbterror.ClassExecutor.NewStd(mysql.ErrTooManyRows)
+	ErrSpAlreadyExists         = dbterror.ClassExecutor.NewStd(mysql.ErrSpAlreadyExists)
+	ErrSpDoesNotExist          = dbterror.ClassExecutor.NewStd(mysql.ErrSpDoesNotExist)
+	ErrSpLilabelMismatch       = dbterror.ClassExecutor.NewStd(mysql.ErrSpLilabelMismatch)
+	ErrSpLabelRedefine         = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelRedefine)
+	ErrSpLabelMismatch         = dbterror.ClassExecutor.NewStd(mysql.ErrSpLabelMismatch)
+	ErrSpWrongNoOfArgs         = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfArgs)
+	ErrSpCursorMismatch        = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorMismatch)
+	ErrSpCursorAlreadyOpen     = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAlreadyOpen)
+	ErrSpCursorNotOpen         = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorNotOpen)
+	ErrSpUndeclaredVar         = dbterror.ClassExecutor.NewStd(mysql.ErrSpUndeclaredVar)
+	ErrSpWrongNoOfFetchArgs    = dbterror.ClassExecutor.NewStd(mysql.ErrSpWrongNoOfFetchArgs)
+	ErrSpFetchNoData           = dbterror.ClassExecutor.NewStd(mysql.ErrSpFetchNoData)
+	ErrSpDupParam              = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupParam)
+	ErrSpDupVar                = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupVar)
+	ErrSpDupCurs               = dbterror.ClassExecutor.NewStd(mysql.ErrSpDupCurs)
+	ErrSpVarcondAfterCurshndlr = dbterror.ClassExecutor.NewStd(mysql.ErrSpVarcondAfterCurshndlr)
+	ErrSpCursorAfterHandler    = dbterror.ClassExecutor.NewStd(mysql.ErrSpCursorAfterHandler)
+	ErrSpCaseNotFound          = dbterror.ClassExecutor.NewStd(mysql.ErrSpCaseNotFound)
+	ErrSpBadSQLstate           = dbterror.ClassExecutor.NewStd(mysql.ErrSpBadSQLstate)
+	ErrSpNotVarArg             = dbterror.ClassExecutor.NewStd(mysql.ErrSpNotVarArg)
+	ErrSpRecursionLimit        = dbterror.ClassExecutor.NewStd(mysql.ErrSpRecursionLimit)
+
+	ErrNoSuchUser                       = dbterror.ClassExecutor.NewStd(mysql.ErrNoSuchUser)
+	ErrEventAlreadyExists               = dbterror.ClassExecutor.NewStd(mysql.ErrEventAlreadyExists)
+	ErrEventDoesNotExist                = dbterror.ClassExecutor.NewStd(mysql.ErrEventDoesNotExist)
+	ErrEventIntervalNotPositiveOrTooBig = dbterror.ClassExecutor.NewStd(mysql.ErrEventIntervalNotPositiveOrTooBig)
+	ErrEventEndsBeforeStarts            = dbterror.ClassExecutor.NewStd(mysql.ErrEventEndsBeforeStarts)
+	ErrEventExecTimeInThePast           = dbterror.ClassExecutor.NewStd(mysql.ErrEventExecTimeInThePast)
+	ErrEventSameName                    = dbterror.ClassExecutor.NewStd(mysql.ErrEventSameName)
+	ErrEventCannotCreateInThePast       = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotCreateInThePast)
+	ErrEventCannotAlterInThePast        = dbterror.ClassExecutor.NewStd(mysql.ErrEventCannotAlterInThePast)
+
+	ErrTrgCantChangeRow             = dbterror.ClassExecutor.NewStd(mysql.ErrTrgCantChangeRow)
+	ErrTrgNoSuchRowInTrg            = dbterror.ClassExecutor.NewStd(mysql.ErrTrgNoSuchRowInTrg)
+	ErrSpNoRetset                   = dbterror.ClassExecutor.NewStd(mysql.ErrSpNoRetset)
+	ErrCommitNotAllowedInSfOrTrg    = dbterror.ClassExecutor.NewStd(mysql.ErrCommitNotAllowedInSfOrTrg)
+	ErrCantUpdateUsedTableInSfOrTrg = dbterror.ClassExecutor.NewStd(mysql.ErrCantUpdateUsedTableInSfOrTrg)
+
 	ErrWrongStringLength            = dbterror.ClassDDL.NewStd(mysql.ErrWrongStringLength)
 	ErrUnsupportedFlashbackTmpTable = dbterror.ClassDDL.NewStdErr(mysql.ErrUnsupportedDDLOperation, parser_mysql.Message("Recover/flashback table is not supported on temporary tables", nil))
 	ErrTruncateWrongInsertValue     = dbterror.ClassTable.NewStdErr(mysql.ErrTruncatedWrongValue, parser_mysql.Message("Incorrect %-.32s value: '%-.128s' for column '%.192s' at row %d", nil))
@@ -95,6 +136,7 @@ var (
 	ErrInvalidOptionVal               = dbterror.ClassExecutor.NewStd(mysql.ErrInvalidOptionVal)
 	ErrDuplicateOption                = dbterror.ClassExecutor.NewStd(mysql.ErrDuplicateOption)
 	ErrLoadDataUnsupportedOption      = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataUnsupportedOption)
+	ErrFileExists                     = dbterror.ClassExecutor.NewStd(mysql.ErrFileExists)
 	ErrLoadDataJobNotFound            = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataJobNotFound)
 	ErrLoadDataInvalidOperation       = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataInvalidOperation)
 	ErrLoadDataLocalUnsupportedOption = dbterror.ClassExecutor.NewStd(mysql.ErrLoadDataLocalUnsupportedOption)
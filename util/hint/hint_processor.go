@@ -64,6 +64,31 @@ func (hs *HintsSet) ContainTableHint(hint string) bool {
 	return false
 }
 
+// WithDefaultDB returns a copy of the hint set, in which the tables of the table hints without
+// database name are set to use db.
+func (hs *HintsSet) WithDefaultDB(db string) *HintsSet {
+	newHs := &HintsSet{
+		tableHints: make([][]*ast.TableOptimizerHint, 0, len(hs.tableHints)),
+		indexHints: hs.indexHints,
+	}
+	for _, tableHintsForBlock := range hs.tableHints {
+		newHints := make([]*ast.TableOptimizerHint, 0, len(tableHintsForBlock))
+		for _, tableHint := range tableHintsForBlock {
+			newHint := *tableHint
+			newHint.Tables = make([]ast.HintTable, len(tableHint.Tables))
+			copy(newHint.Tables, tableHint.Tables)
+			for i := range newHint.Tables {
+				if newHint.Tables[i].DBName.L == "" {
+					newHint.Tables[i].DBName = model.NewCIStr(db)
+				}
+			}
+			newHints = append(newHints, &newHint)
+		}
+		newHs.tableHints = append(newHs.tableHints, newHints)
+	}
+	return newHs
+}
+
 // setTableHints4StmtNode sets table hints for select/update/delete.
 func setTableHints4StmtNode(node ast.Node, hints []*ast.TableOptimizerHint) {
 	switch x := node.(type) {
@@ -0,0 +1,77 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import "sync"
+
+// InstanceCacheEntry is a plan in the InstanceCache.
+type InstanceCacheEntry struct {
+	Value     interface{}
+	MatchOpts *PlanCacheMatchOpts
+}
+
+// InstanceCache is a read-only plan cache shared by all the sessions of this instance. It's filled by warming up
+// the plan cache after restarting, and the sessions copy the plans from it into their own plan caches since a plan
+// can't be executed by several sessions at the same time.
+// The key contains the schema version, and the statistics version is checked by the MatchOpts of each plan,
+// so a stale plan is never matched.
+type InstanceCache struct {
+	mu      sync.RWMutex
+	buckets map[string][]InstanceCacheEntry
+	size    int
+}
+
+// NewInstanceCache creates an empty InstanceCache.
+func NewInstanceCache() *InstanceCache {
+	return &InstanceCache{buckets: make(map[string][]InstanceCacheEntry)}
+}
+
+// Get returns the first plan of the key that matches.
+func (c *InstanceCache) Get(key string, match func(*PlanCacheMatchOpts) bool) (interface{}, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, entry := range c.buckets[key] {
+		if match(entry.MatchOpts) {
+			return entry.Value, true
+		}
+	}
+	return nil, false
+}
+
+// Put puts a plan into the cache, nothing is put once there are capacity plans in the cache.
+func (c *InstanceCache) Put(key string, value interface{}, opts *PlanCacheMatchOpts, capacity int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size >= capacity {
+		return
+	}
+	c.buckets[key] = append(c.buckets[key], InstanceCacheEntry{Value: value, MatchOpts: opts})
+	c.size++
+}
+
+// Clear removes all the plans in the cache.
+func (c *InstanceCache) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.buckets = make(map[string][]InstanceCacheEntry)
+	c.size = 0
+}
+
+// Size returns the number of plans in the cache.
+func (c *InstanceCache) Size() int {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.size
+}
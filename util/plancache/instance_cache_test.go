@@ -0,0 +1,46 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstanceCache(t *testing.T) {
+	c := NewInstanceCache()
+	statsVersion := func(ver uint64) func(*PlanCacheMatchOpts) bool {
+		return func(opts *PlanCacheMatchOpts) bool { return opts.StatsVersionHash == ver }
+	}
+	c.Put("k1", "p1", &PlanCacheMatchOpts{StatsVersionHash: 1}, 2)
+	c.Put("k1", "p2", &PlanCacheMatchOpts{StatsVersionHash: 2}, 2)
+	// the cache is full
+	c.Put("k2", "p3", &PlanCacheMatchOpts{StatsVersionHash: 1}, 2)
+	require.Equal(t, 2, c.Size())
+
+	v, ok := c.Get("k1", statsVersion(2))
+	require.True(t, ok)
+	require.Equal(t, "p2", v)
+	_, ok = c.Get("k1", statsVersion(3))
+	require.False(t, ok)
+	_, ok = c.Get("k2", statsVersion(1))
+	require.False(t, ok)
+
+	c.Clear()
+	require.Equal(t, 0, c.Size())
+	_, ok = c.Get("k1", statsVersion(1))
+	require.False(t, ok)
+}
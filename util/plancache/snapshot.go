@@ -0,0 +1,113 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pingcap/tidb/types"
+)
+
+// SnapshotEntry is a prepared statement recorded in the plan cache snapshot.
+type SnapshotEntry struct {
+	DB       string
+	StmtText string
+	// SQLDigest is the digest of the normalized statement, it's used to find the execution count of the
+	// statement in the statement summary and isn't saved.
+	SQLDigest  string
+	ParamTypes []*types.FieldType
+	BindSQL    string
+	// TableVersion and StatsVersion are the versions of the schema and the statistics of the tables used by the
+	// statement when its plan was cached, the entry is stale once any of them changes.
+	TableVersion uint64
+	StatsVersion uint64
+	Hits         uint64
+}
+
+// Snapshot records the prepared statements whose plans are cached by the sessions of this instance.
+// It's saved periodically, and the statements are re-prepared to warm up the plan cache after restarting.
+type Snapshot struct {
+	mu      sync.RWMutex
+	entries map[string]SnapshotEntry
+}
+
+// NewSnapshot creates an empty Snapshot.
+func NewSnapshot() *Snapshot {
+	return &Snapshot{entries: make(map[string]SnapshotEntry)}
+}
+
+func snapshotKey(db, stmtText string) string {
+	return db + "\x00" + stmtText
+}
+
+// SnapshotExecCountKey returns the key of the execution count of a statement passed to TopN.
+func SnapshotExecCountKey(db, sqlDigest string) string {
+	return strings.ToLower(db) + "\x00" + sqlDigest
+}
+
+// Record records a statement whose plan is put into the plan cache, the saved hits of an existing
+// statement are kept. Nothing is recorded if capacity isn't positive.
+func (s *Snapshot) Record(entry SnapshotEntry, capacity int) {
+	if capacity <= 0 {
+		return
+	}
+	key := snapshotKey(entry.DB, entry.StmtText)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if old, ok := s.entries[key]; ok && old.Hits > entry.Hits {
+		entry.Hits = old.Hits
+	}
+	s.entries[key] = entry
+}
+
+// Remove removes the statement from the snapshot.
+func (s *Snapshot) Remove(db, stmtText string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.entries, snapshotKey(db, stmtText))
+}
+
+// TopN returns at most n statements with the most hits. The hits of a statement are its execution count in
+// execCounts, which is keyed by SnapshotExecCountKey, or the saved hits if it isn't executed since restarting.
+// The other statements are dropped from the snapshot.
+func (s *Snapshot) TopN(n int, execCounts map[string]int64) []SnapshotEntry {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entries := make([]SnapshotEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		if cnt, ok := execCounts[SnapshotExecCountKey(entry.DB, entry.SQLDigest)]; ok && entry.SQLDigest != "" {
+			entry.Hits = uint64(cnt)
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Hits != entries[j].Hits {
+			return entries[i].Hits > entries[j].Hits
+		}
+		if entries[i].DB != entries[j].DB {
+			return entries[i].DB < entries[j].DB
+		}
+		return entries[i].StmtText < entries[j].StmtText
+	})
+	if len(entries) > n {
+		for _, entry := range entries[n:] {
+			delete(s.entries, snapshotKey(entry.DB, entry.StmtText))
+		}
+		entries = entries[:n]
+	}
+	return entries
+}
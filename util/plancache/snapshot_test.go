@@ -0,0 +1,72 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package util
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func stmtTexts(entries []SnapshotEntry) []string {
+	texts := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		texts = append(texts, fmt.Sprintf("%s.%s:%d", entry.DB, entry.StmtText, entry.Hits))
+	}
+	return texts
+}
+
+func TestSnapshot(t *testing.T) {
+	s := NewSnapshot()
+	s.Record(SnapshotEntry{DB: "test", StmtText: "select ?"}, 0)
+	require.Len(t, s.TopN(10, nil), 0)
+
+	s.Record(SnapshotEntry{DB: "test", StmtText: "select ?", SQLDigest: "d1"}, 10)
+	s.Record(SnapshotEntry{DB: "test", StmtText: "select ?+1", SQLDigest: "d2", Hits: 5}, 10)
+	s.Record(SnapshotEntry{DB: "db", StmtText: "select ?", SQLDigest: "d1"}, 10)
+	require.Equal(t, []string{"test.select ?+1:5", "db.select ?:0", "test.select ?:0"}, stmtTexts(s.TopN(10, nil)))
+
+	// the hits are the execution counts in the statement summary, or the saved hits if not executed
+	execCounts := map[string]int64{
+		SnapshotExecCountKey("TEST", "d1"): 7,
+		SnapshotExecCountKey("test", "d3"): 9,
+	}
+	require.Equal(t, []string{"test.select ?:7", "test.select ?+1:5", "db.select ?:0"}, stmtTexts(s.TopN(10, execCounts)))
+
+	// the saved hits are kept when the statement is recorded again
+	s.Record(SnapshotEntry{DB: "test", StmtText: "select ?+1", SQLDigest: "d2", StatsVersion: 1}, 10)
+	entries := s.TopN(10, nil)
+	require.Equal(t, "test.select ?+1:5", stmtTexts(entries)[0])
+	require.Equal(t, uint64(1), entries[0].StatsVersion)
+
+	s.Remove("test", "select ?+1")
+	s.Remove("test", "select ?+3") // not recorded
+	require.Equal(t, []string{"db.select ?:0", "test.select ?:0"}, stmtTexts(s.TopN(10, nil)))
+}
+
+func TestSnapshotTopN(t *testing.T) {
+	s := NewSnapshot()
+	for i := 0; i < 4; i++ {
+		s.Record(SnapshotEntry{DB: "test", StmtText: fmt.Sprintf("select %d", i), SQLDigest: fmt.Sprintf("d%d", i)}, 2)
+	}
+	execCounts := map[string]int64{
+		SnapshotExecCountKey("test", "d1"): 1,
+		SnapshotExecCountKey("test", "d3"): 3,
+	}
+	require.Equal(t, []string{"test.select 3:3", "test.select 1:1"}, stmtTexts(s.TopN(2, execCounts)))
+	// the statements out of the top n are dropped
+	require.Equal(t, []string{"test.select 1:0", "test.select 3:0"}, stmtTexts(s.TopN(10, nil)))
+}
@@ -30,6 +30,9 @@ type PlanCacheMatchOpts struct {
 	HasSubQuery bool
 	// StatsVersionHash is the hash value of the statistics version
 	StatsVersionHash uint64
+	// ParamSelectivityBuckets stores the selectivity bucket of each predicate like `col op ?`,
+	// plans for the same statement with different buckets are cached separately.
+	ParamSelectivityBuckets []uint64
 
 	// Below are some variables that can affect the plan
 	ForeignKeyChecks bool
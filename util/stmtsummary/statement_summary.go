@@ -426,6 +426,75 @@ func (ssMap *stmtSummaryByDigestMap) GetMoreThanCntBindableStmt(cnt int64) []*Bi
 	return stmts
 }
 
+// PreparedStmtExecCount is the execution count of a prepared statement of users in statements_summary.
+type PreparedStmtExecCount struct {
+	Schema    string
+	Digest    string
+	ExecCount int64
+}
+
+// TopNPreparedStmts merges the execution counts of the same statement with different plans, and returns
+// at most n statements with the most executions.
+func TopNPreparedStmts(stmts []*PreparedStmtExecCount, n int) []*PreparedStmtExecCount {
+	merged := make(map[string]*PreparedStmtExecCount, len(stmts))
+	result := make([]*PreparedStmtExecCount, 0, len(stmts))
+	for _, stmt := range stmts {
+		key := stmt.Schema + "." + stmt.Digest
+		if m, ok := merged[key]; ok {
+			m.ExecCount += stmt.ExecCount
+			continue
+		}
+		m := *stmt
+		merged[key] = &m
+		result = append(result, &m)
+	}
+	slices.SortFunc(result, func(i, j *PreparedStmtExecCount) int {
+		if c := cmp.Compare(j.ExecCount, i.ExecCount); c != 0 {
+			return c
+		}
+		if c := cmp.Compare(i.Schema, j.Schema); c != 0 {
+			return c
+		}
+		return cmp.Compare(i.Digest, j.Digest)
+	})
+	if len(result) > n {
+		result = result[:n]
+	}
+	return result
+}
+
+// GetTopNPreparedStmts gets at most n prepared statements of users with the most executions in the history.
+func (ssMap *stmtSummaryByDigestMap) GetTopNPreparedStmts(n int) []*PreparedStmtExecCount {
+	ssMap.Lock()
+	values := ssMap.summaryMap.Values()
+	ssMap.Unlock()
+
+	stmts := make([]*PreparedStmtExecCount, 0, len(values))
+	for _, value := range values {
+		ssbd := value.(*stmtSummaryByDigest)
+		func() {
+			ssbd.Lock()
+			defer ssbd.Unlock()
+			if !ssbd.initialized || ssbd.isInternal {
+				return
+			}
+			stmt := &PreparedStmtExecCount{Schema: ssbd.schemaName, Digest: ssbd.digest}
+			for e := ssbd.history.Front(); e != nil; e = e.Next() {
+				ssElement := e.Value.(*stmtSummaryByDigestElement)
+				ssElement.Lock()
+				if ssElement.prepared {
+					stmt.ExecCount += ssElement.execCount
+				}
+				ssElement.Unlock()
+			}
+			if stmt.ExecCount > 0 {
+				stmts = append(stmts, stmt)
+			}
+		}()
+	}
+	return TopNPreparedStmts(stmts, n)
+}
+
 // SetEnabled enables or disables statement summary
 func (ssMap *stmtSummaryByDigestMap) SetEnabled(value bool) error {
 	// `optEnabled` and `ssMap` don't need to be strictly atomically updated.
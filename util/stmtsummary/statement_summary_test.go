@@ -1174,6 +1174,40 @@ func TestGetMoreThanCntBindableStmt(t *testing.T) {
 	require.Equal(t, 1, len(stmts))
 }
 
+// Test GetTopNPreparedStmts.
+func TestGetTopNPreparedStmts(t *testing.T) {
+	ssMap := newStmtSummaryByDigestMap()
+
+	stmtExecInfo1 := generateAnyExecInfo()
+	ssMap.AddStatement(stmtExecInfo1)
+	require.Len(t, ssMap.GetTopNPreparedStmts(10), 0)
+
+	stmtExecInfo1.Prepared = true
+	stmtExecInfo1.Digest = "digest1"
+	for i := 0; i < 2; i++ {
+		ssMap.AddStatement(stmtExecInfo1)
+	}
+	// the executions with different plans are merged
+	stmtExecInfo1.PlanDigest = "plan_digest2"
+	ssMap.AddStatement(stmtExecInfo1)
+	stmtExecInfo2 := generateAnyExecInfo()
+	stmtExecInfo2.Prepared = true
+	stmtExecInfo2.Digest = "digest2"
+	ssMap.AddStatement(stmtExecInfo2)
+	stmtExecInfo3 := generateAnyExecInfo()
+	stmtExecInfo3.Prepared = true
+	stmtExecInfo3.IsInternal = true
+	stmtExecInfo3.Digest = "digest3"
+	ssMap.AddStatement(stmtExecInfo3)
+
+	stmts := ssMap.GetTopNPreparedStmts(10)
+	require.Equal(t, []*PreparedStmtExecCount{
+		{Schema: "schema_name", Digest: "digest1", ExecCount: 3},
+		{Schema: "schema_name", Digest: "digest2", ExecCount: 1},
+	}, stmts)
+	require.Equal(t, stmts[:1], ssMap.GetTopNPreparedStmts(1))
+}
+
 // Test `formatBackoffTypes`.
 func TestFormatBackoffTypes(t *testing.T) {
 	backoffMap := make(map[string]int)
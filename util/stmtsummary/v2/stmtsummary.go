@@ -377,6 +377,29 @@ func (s *StmtSummary) GetMoreThanCntBindableStmt(cnt int64) []*stmtsummary.Binda
 	return stmts
 }
 
+// GetTopNPreparedStmts gets at most n prepared statements of users with the most executions.
+// Since the historical data has been persisted, we only refer to the statistics data of the
+// current window in memory.
+func (s *StmtSummary) GetTopNPreparedStmts(n int) []*stmtsummary.PreparedStmtExecCount {
+	s.windowLock.Lock()
+	values := s.window.lru.Values()
+	s.windowLock.Unlock()
+	stmts := make([]*stmtsummary.PreparedStmtExecCount, 0, len(values))
+	for _, value := range values {
+		record := value.(*lockedStmtRecord)
+		record.Lock()
+		if record.Prepared && !record.IsInternal && record.ExecCount > 0 {
+			stmts = append(stmts, &stmtsummary.PreparedStmtExecCount{
+				Schema:    record.SchemaName,
+				Digest:    record.Digest,
+				ExecCount: record.ExecCount,
+			})
+		}
+		record.Unlock()
+	}
+	return stmtsummary.TopNPreparedStmts(stmts, n)
+}
+
 func (s *StmtSummary) rotateLoop() {
 	tick := time.NewTicker(defaultRotateCheckInterval * time.Second)
 	defer tick.Stop()
@@ -611,3 +634,12 @@ func GetMoreThanCntBindableStmt(frequency int64) []*stmtsummary.BindableStmt {
 	}
 	return stmtsummary.StmtSummaryByDigestMap.GetMoreThanCntBindableStmt(frequency)
 }
+
+// GetTopNPreparedStmts wraps GlobalStmtSummary.GetTopNPreparedStmts and
+// stmtsummary.StmtSummaryByDigestMap.GetTopNPreparedStmts.
+func GetTopNPreparedStmts(n int) []*stmtsummary.PreparedStmtExecCount {
+	if config.GetGlobalConfig().Instance.StmtSummaryEnablePersistent {
+		return GlobalStmtSummary.GetTopNPreparedStmts(n)
+	}
+	return stmtsummary.StmtSummaryByDigestMap.GetTopNPreparedStmts(n)
+}